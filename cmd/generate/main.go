@@ -6,7 +6,9 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/stephan/rinku/internal/database"
 	"github.com/stephan/rinku/internal/types"
 )
 
@@ -35,44 +37,90 @@ func main() {
 		os.Exit(1)
 	}
 
-	result := BuildIndexes(libsFile.Libs, mappingsFile.Mappings)
+	result := database.BuildIndexes(libsFile.Libs, mappingsFile.Mappings)
 
 	var sb strings.Builder
 	sb.WriteString("// Code generated by cmd/generate. DO NOT EDIT.\n")
 	sb.WriteString("package main\n\n")
 
-	sb.WriteString("var index = map[string][]string{\n")
-	writeMap(&sb, result.Forward)
+	sb.WriteString("type requiredDep struct {\n")
+	sb.WriteString("\tCrate    string\n")
+	sb.WriteString("\tFeatures []string\n")
+	sb.WriteString("\tReason   string\n")
 	sb.WriteString("}\n\n")
 
-	sb.WriteString("var indexAll = map[string][]string{\n")
-	writeMap(&sb, result.ForwardAll)
+	sb.WriteString("type generatedIndex struct {\n")
+	sb.WriteString("\tindex           map[string][]string\n")
+	sb.WriteString("\tindexAll        map[string][]string\n")
+	sb.WriteString("\treverseIndex    map[string][]string\n")
+	sb.WriteString("\treverseIndexAll map[string][]string\n")
+	sb.WriteString("\tknownCrateNames map[string]string\n")
+	sb.WriteString("\ttags            map[string][]string\n")
+	sb.WriteString("\trequiredDeps    map[string][]requiredDep\n")
+	sb.WriteString("\tcategories      map[string]string\n")
+	sb.WriteString("\tconfidences     map[string]float64\n")
+	sb.WriteString("\tpackages        map[string]string\n")
 	sb.WriteString("}\n\n")
 
-	sb.WriteString("var reverseIndex = map[string][]string{\n")
+	// Certificates and reports name the database they were made with.
+	sb.WriteString("// dbVersion identifies the mapping database: the start of the SHA-256 of libs.json\n")
+	sb.WriteString("// and mappings.json.\n")
+	sb.WriteString(fmt.Sprintf("const dbVersion = %q\n\n", database.Version(libsData, mappingsData)))
+	sb.WriteString("// dbReleased is the released time of mappings.json, RFC 3339 or empty. A downloaded\n")
+	sb.WriteString("// database is only used if it is at least as new.\n")
+	released := ""
+	if !mappingsFile.Released.IsZero() {
+		released = mappingsFile.Released.UTC().Format(time.RFC3339)
+	}
+	sb.WriteString(fmt.Sprintf("const dbReleased = %q\n\n", released))
+
+	// The maps are built by a function rather than package-level variables,
+	// so commands that never look up libraries skip their construction.
+	sb.WriteString("// loadIndex builds the mapping database. Each call constructs new maps.\n")
+	sb.WriteString("func loadIndex() generatedIndex {\n")
+	sb.WriteString("\treturn generatedIndex{\n")
+
+	sb.WriteString("\t\tindex: map[string][]string{\n")
+	writeMap(&sb, result.Forward)
+	sb.WriteString("\t\t},\n")
+
+	sb.WriteString("\t\tindexAll: map[string][]string{\n")
+	writeMap(&sb, result.ForwardAll)
+	sb.WriteString("\t\t},\n")
+
+	sb.WriteString("\t\treverseIndex: map[string][]string{\n")
 	writeMap(&sb, result.Reverse)
-	sb.WriteString("}\n\n")
+	sb.WriteString("\t\t},\n")
 
-	sb.WriteString("var reverseIndexAll = map[string][]string{\n")
+	sb.WriteString("\t\treverseIndexAll: map[string][]string{\n")
 	writeMap(&sb, result.ReverseAll)
-	sb.WriteString("}\n\n")
+	sb.WriteString("\t\t},\n")
 
-	sb.WriteString("var knownCrateNames = map[string]string{\n")
+	sb.WriteString("\t\tknownCrateNames: map[string]string{\n")
 	writeStringMap(&sb, result.KnownCrateNames)
-	sb.WriteString("}\n\n")
+	sb.WriteString("\t\t},\n")
 
-	sb.WriteString("var tags = map[string][]string{\n")
+	sb.WriteString("\t\ttags: map[string][]string{\n")
 	writeMap(&sb, result.Tags)
-	sb.WriteString("}\n\n")
+	sb.WriteString("\t\t},\n")
 
-	sb.WriteString("type requiredDep struct {\n")
-	sb.WriteString("\tCrate    string\n")
-	sb.WriteString("\tFeatures []string\n")
-	sb.WriteString("\tReason   string\n")
-	sb.WriteString("}\n\n")
-
-	sb.WriteString("var requiredDeps = map[string][]requiredDep{\n")
+	sb.WriteString("\t\trequiredDeps: map[string][]requiredDep{\n")
 	writeRequiredDepsMap(&sb, result.RequiredDeps)
+	sb.WriteString("\t\t},\n")
+
+	sb.WriteString("\t\tcategories: map[string]string{\n")
+	writeStringMap(&sb, result.Categories)
+	sb.WriteString("\t\t},\n")
+
+	sb.WriteString("\t\tconfidences: map[string]float64{\n")
+	writeFloatMap(&sb, result.Confidences)
+	sb.WriteString("\t\t},\n")
+
+	sb.WriteString("\t\tpackages: map[string]string{\n")
+	writeStringMap(&sb, result.Packages)
+	sb.WriteString("\t\t},\n")
+
+	sb.WriteString("\t}\n")
 	sb.WriteString("}\n")
 
 	if err := os.WriteFile("index_gen.go", []byte(sb.String()), 0600); err != nil {
@@ -88,6 +136,9 @@ func main() {
 	fmt.Printf("  Known crate names: %d\n", len(result.KnownCrateNames))
 	fmt.Printf("  Tagged libraries: %d\n", len(result.Tags))
 	fmt.Printf("  Required deps: %d entries\n", len(result.RequiredDeps))
+	fmt.Printf("  Categories: %d entries\n", len(result.Categories))
+	fmt.Printf("  Confidences: %d entries\n", len(result.Confidences))
+	fmt.Printf("  Package names: %d\n", len(result.Packages))
 }
 
 func writeMap(sb *strings.Builder, m map[string][]string) {
@@ -99,7 +150,7 @@ func writeMap(sb *strings.Builder, m map[string][]string) {
 
 	for _, key := range keys {
 		targets := m[key]
-		sb.WriteString(fmt.Sprintf("\t%q: {", key))
+		sb.WriteString(fmt.Sprintf("\t\t\t%q: {", key))
 		for i, t := range targets {
 			if i > 0 {
 				sb.WriteString(", ")
@@ -118,7 +169,19 @@ func writeStringMap(sb *strings.Builder, m map[string]string) {
 	sort.Strings(keys)
 
 	for _, key := range keys {
-		sb.WriteString(fmt.Sprintf("\t%q: %q,\n", key, m[key]))
+		sb.WriteString(fmt.Sprintf("\t\t\t%q: %q,\n", key, m[key]))
+	}
+}
+
+func writeFloatMap(sb *strings.Builder, m map[string]float64) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		sb.WriteString(fmt.Sprintf("\t\t\t%q: %v,\n", key, m[key]))
 	}
 }
 
@@ -131,9 +194,9 @@ func writeRequiredDepsMap(sb *strings.Builder, m map[string][]types.RequiredDep)
 
 	for _, key := range keys {
 		deps := m[key]
-		sb.WriteString(fmt.Sprintf("\t%q: {\n", key))
+		sb.WriteString(fmt.Sprintf("\t\t\t%q: {\n", key))
 		for _, dep := range deps {
-			sb.WriteString(fmt.Sprintf("\t\t{Crate: %q", dep.Crate))
+			sb.WriteString(fmt.Sprintf("\t\t\t\t{Crate: %q", dep.Crate))
 			if len(dep.Features) > 0 {
 				sb.WriteString(", Features: []string{")
 				for i, f := range dep.Features {
@@ -149,6 +212,6 @@ func writeRequiredDepsMap(sb *strings.Builder, m map[string][]types.RequiredDep)
 			}
 			sb.WriteString("},\n")
 		}
-		sb.WriteString("\t},\n")
+		sb.WriteString("\t\t\t},\n")
 	}
 }
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/natefinch/atomic"
+
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/lock"
+	"github.com/stephan/rinku/internal/reason"
+	"github.com/stephan/rinku/internal/suppress"
+)
+
+type IgnoreCmd struct {
+	Path   string `arg:"" type:"existingfile" help:"Path to go.mod file."`
+	Module string `arg:"" help:"Required module that needs no Rust equivalent."`
+	Reason string `help:"Why the dependency is not ported; shown next to it by scan and report."`
+	Undo   bool   `help:"Remove the annotation again."`
+}
+
+func (c *IgnoreCmd) Run() error {
+	if c.Undo {
+		if err := editGoMod(c.Path, func(data []byte) ([]byte, error) {
+			return gomod.RemoveAnnotation(data, c.Module, gomod.AnnotationIgnore)
+		}); err != nil {
+			return err
+		}
+		fmt.Printf("%s is no longer ignored\n", c.Module)
+		return nil
+	}
+	if c.Reason == "" {
+		return errors.New("--reason is required: it is shown wherever the dependency is reported")
+	}
+	a := gomod.Annotation{Module: c.Module, Kind: gomod.AnnotationIgnore, Value: c.Reason}
+	if err := editGoMod(c.Path, func(data []byte) ([]byte, error) {
+		return gomod.SetAnnotation(data, a)
+	}); err != nil {
+		return err
+	}
+	fmt.Printf("Annotated %s: // %s\n", c.Module, a)
+	return nil
+}
+
+// editGoMod rewrites a go.mod with edit, leaving it untouched if edit fails.
+func editGoMod(path string, edit func([]byte) ([]byte, error)) error {
+	data, err := os.ReadFile(path) //#nosec G304 -- path given on the command line
+	if err != nil {
+		return fmt.Errorf("reading go.mod: %w", err)
+	}
+	updated, err := edit(data)
+	if err != nil {
+		return fmt.Errorf("annotating %s: %w", path, err)
+	}
+	if bytes.Equal(updated, data) {
+		return nil
+	}
+	return atomic.WriteFile(path, bytes.NewReader(updated))
+}
+
+// annotateDecision records a decide decision on the require line of its dependency,
+// replacing an earlier one: "rinku:use <crate>", "rinku:reject [reason]" or
+// "rinku:defer [reason]".
+func annotateDecision(goModPath string, e lock.Entry) error {
+	a := gomod.Annotation{Module: e.GoModule, Value: strings.ReplaceAll(e.Reason, ";", ",")}
+	switch e.Decision {
+	case lock.DecisionAccepted:
+		a.Kind, a.Value = gomod.AnnotationUse, e.Crate
+	case lock.DecisionRejected:
+		a.Kind = gomod.AnnotationReject
+	case lock.DecisionDeferred:
+		a.Kind = gomod.AnnotationDefer
+	default:
+		return nil
+	}
+	return editGoMod(goModPath, func(data []byte) ([]byte, error) {
+		var err error
+		for _, kind := range []string{gomod.AnnotationUse, gomod.AnnotationReject, gomod.AnnotationDefer} {
+			if data, err = gomod.RemoveAnnotation(data, e.GoModule, kind); err != nil {
+				return nil, err
+			}
+		}
+		return gomod.SetAnnotation(data, a)
+	})
+}
+
+// goModIgnores returns the "rinku:ignore" annotations of the go.mod in dir as
+// suppressions of unmapped dependencies, with code not-applicable: the dependency needs
+// no Rust equivalent. A missing or unparsable go.mod has none.
+func goModIgnores(dir string) []suppress.Entry {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod")) //#nosec G304 -- dir from os.Getwd()
+	if err != nil {
+		return nil
+	}
+	annotations, err := gomod.Annotations(data)
+	if err != nil {
+		return nil
+	}
+	var entries []suppress.Entry
+	for _, a := range annotations {
+		if a.Kind != gomod.AnnotationIgnore {
+			continue
+		}
+		why := a.Value
+		if why == "" {
+			why = "ignored in go.mod"
+		}
+		entries = append(entries, suppress.Entry{Dependency: a.Module, Code: reason.NotApplicable, Reason: why})
+	}
+	return entries
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stephan/rinku/internal/assess"
+	"github.com/stephan/rinku/internal/audit"
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/gosrc"
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/render"
+)
+
+type AssessCmd struct {
+	Path   string `arg:"" optional:"" default:"." type:"path" help:"Project directory or go.mod file (default: cwd)."`
+	Format string `default:"text" help:"Output format: text, json, yaml, csv, markdown, html, sarif or porcelain."`
+	Unsafe bool   `help:"Include libraries with known vulnerabilities."`
+}
+
+// Assessment is the read-only first look at a Go project written by rinku assess.
+type Assessment struct {
+	Module      string          `json:"module"`
+	GoVersion   string          `json:"go_version"`
+	Packages    int             `json:"packages"`
+	SourceLines int             `json:"source_lines"`
+	TestLines   int             `json:"test_lines"`
+	Generated   []string        `json:"generated"`
+	Direct      int             `json:"direct"`
+	Mapped      int             `json:"mapped"`
+	Unmapped    []string        `json:"unmapped"`
+	Tags        []string        `json:"tags"`
+	CgoFiles    []string        `json:"cgo_files"`
+	RiskFiles   []string        `json:"risk_files"` // without cgo
+	Generators  map[string]int  `json:"generators"` // go:generate directives per tool
+	Effort      assess.Estimate `json:"effort"`
+}
+
+// Run scans go.mod, detects tags, analyzes the source and estimates the effort of a
+// port. Unlike the other commands it reads no .rinku state and writes nothing, so it is
+// safe to run on any checkout.
+func (c *AssessCmd) Run(r *rinku.Rinku) error {
+	goModPath := c.Path
+	if info, err := os.Stat(goModPath); err == nil && info.IsDir() {
+		goModPath = filepath.Join(goModPath, "go.mod")
+	}
+	result, err := gomod.Parse(goModPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	tree, err := gosrc.Parse(filepath.Dir(goModPath))
+	if err != nil {
+		return fmt.Errorf("parsing source files: %w", err)
+	}
+
+	a := assessProject(r.Memoize(), result, tree, c.Unsafe)
+	return render.Render(os.Stdout, c.Format, assessDocument(a, goModPath))
+}
+
+func assessProject(r *rinku.Rinku, result *gomod.ParseResult, tree *gosrc.Tree, unsafe bool) *Assessment {
+	a := &Assessment{
+		Module:     result.Module,
+		GoVersion:  result.GoVersion,
+		Generated:  []string{},
+		Unmapped:   []string{},
+		Tags:       []string{},
+		CgoFiles:   []string{},
+		RiskFiles:  []string{},
+		Generators: make(map[string]int),
+	}
+
+	deps := result.DirectDependencies()
+	mapping := cargo.MapDependencies(deps, r, unsafe)
+	a.Direct, a.Mapped = len(deps), len(mapping.Mapped)
+	for _, u := range mapping.Unmapped {
+		a.Unmapped = append(a.Unmapped, u.GoDep.Path)
+	}
+	tags := make(map[string]bool)
+	for _, dep := range deps {
+		for _, tag := range r.Tags(cargo.ModulePathToGitHubURL(dep.Path)) {
+			tags[tag] = true
+		}
+	}
+	for tag := range tags {
+		a.Tags = append(a.Tags, tag)
+	}
+	sort.Strings(a.Tags)
+
+	generators, generated := audit.Codegen(tree)
+	isGenerated := make(map[string]bool, len(generated))
+	for _, path := range generated {
+		isGenerated[path] = true
+	}
+	a.Generated = append(a.Generated, generated...)
+	for _, g := range generators {
+		a.Generators[g.Tool]++
+	}
+
+	dirs := make(map[string]bool)
+	for _, f := range tree.Files {
+		if isGenerated[f.Path] {
+			continue
+		}
+		lines := tree.Fset.File(f.AST.Pos()).LineCount()
+		if f.Test {
+			a.TestLines += lines
+			continue
+		}
+		a.SourceLines += lines
+		dirs[filepath.Dir(f.Path)] = true
+	}
+	a.Packages = len(dirs)
+
+	for _, rf := range audit.Risks(tree) {
+		if isGenerated[rf.File] {
+			continue
+		}
+		if strings.Contains(rf.Kinds(), string(audit.RiskCgo)) {
+			a.CgoFiles = append(a.CgoFiles, rf.File)
+		} else {
+			a.RiskFiles = append(a.RiskFiles, rf.File)
+		}
+	}
+	sort.Strings(a.CgoFiles)
+
+	a.Effort = assess.Effort(assess.Inputs{
+		SourceLines: a.SourceLines,
+		TestLines:   a.TestLines,
+		Unmapped:    len(a.Unmapped),
+		RiskFiles:   len(a.RiskFiles),
+		CgoFiles:    len(a.CgoFiles),
+		Generators:  len(generators),
+	})
+	return a
+}
+
+// assessDocument returns the effort items as the table; findings flag what needs a
+// decision before porting.
+func assessDocument(a *Assessment, goModPath string) *render.Document {
+	readiness := 100
+	if a.Direct > 0 {
+		readiness = a.Mapped * 100 / a.Direct
+	}
+	doc := &render.Document{
+		Command: "assess",
+		Title:   a.Module,
+		Fields: []render.Field{
+			{Name: "Go", Value: a.GoVersion},
+			{Name: "Packages", Value: strconv.Itoa(a.Packages)},
+			{Name: "Lines", Value: fmt.Sprintf("%d source, %d test, %d generated files", a.SourceLines, a.TestLines, len(a.Generated))},
+			{Name: "Dependencies", Value: fmt.Sprintf("%d direct, %d mapped (%d%%)", a.Direct, a.Mapped, readiness)},
+			{Name: "Tags", Value: strings.Join(a.Tags, ", ")},
+			{Name: "Effort", Value: fmt.Sprintf("%s, ~%g days (%g hours)", a.Effort.Size, a.Effort.Days, a.Effort.Hours)},
+		},
+		Columns: []string{"driver", "count", "hours"},
+		Data:    a,
+	}
+	for _, item := range a.Effort.Items {
+		doc.Rows = append(doc.Rows, []string{item.Name, strconv.Itoa(item.Count), strconv.FormatFloat(item.Hours, 'f', -1, 64)})
+	}
+	for _, mod := range a.Unmapped {
+		doc.Findings = append(doc.Findings, render.Finding{
+			Rule:    "unmapped-dependency",
+			Level:   render.LevelWarning,
+			Message: fmt.Sprintf("%s has no Rust mapping", mod),
+			File:    relPath(goModPath),
+		})
+	}
+	for _, file := range a.CgoFiles {
+		doc.Findings = append(doc.Findings, render.Finding{Rule: "cgo", Level: render.LevelWarning, Message: "uses cgo", File: file})
+	}
+	for _, file := range a.RiskFiles {
+		doc.Findings = append(doc.Findings, render.Finding{Rule: "high-risk-file", Level: render.LevelNote, Message: "uses reflect, unsafe, go:linkname or runtime tricks", File: file})
+	}
+
+	doc.Text = func(w io.Writer) error {
+		fmt.Fprintf(w, "Assessment: %s (go %s)\n\n", a.Module, a.GoVersion)
+		fmt.Fprintf(w, "Code:          %d packages, %d source lines, %d test lines\n", a.Packages, a.SourceLines, a.TestLines)
+		fmt.Fprintf(w, "Dependencies:  %d direct, %d mapped (%d%%)\n", a.Direct, a.Mapped, readiness)
+		writeAssessList(w, "Unmapped:", a.Unmapped)
+		tags := strings.Join(a.Tags, ", ")
+		if tags == "" {
+			tags = "(none)"
+		}
+		fmt.Fprintf(w, "Tags:          %s\n", tags)
+		fmt.Fprintf(w, "CGo:           %d files\n", len(a.CgoFiles))
+		writeAssessList(w, "", a.CgoFiles)
+		fmt.Fprintf(w, "Reflection:    %d files with reflect, unsafe, go:linkname or runtime tricks\n", len(a.RiskFiles))
+		writeAssessList(w, "", a.RiskFiles)
+		var gens []string
+		directives := 0
+		for tool, n := range a.Generators {
+			gens = append(gens, fmt.Sprintf("%s ×%d", tool, n))
+			directives += n
+		}
+		sort.Strings(gens)
+		fmt.Fprintf(w, "Codegen:       %d go:generate directives, %d generated files\n", directives, len(a.Generated))
+		if len(gens) > 0 {
+			fmt.Fprintf(w, "               %s\n", strings.Join(gens, ", "))
+		}
+
+		fmt.Fprintf(w, "\nEffort: %s, ~%g days (%g hours at %g h/day)\n", a.Effort.Size, a.Effort.Days, a.Effort.Hours, assess.HoursPerDay)
+		for _, item := range a.Effort.Items {
+			fmt.Fprintf(w, "  %-24s %6d %7gh\n", item.Name, item.Count, item.Hours)
+		}
+		fmt.Fprintln(w, "\nNothing was written. Run 'rinku migrate' to start a migration.")
+		return nil
+	}
+	return doc
+}
+
+// writeAssessList writes items indented below the label column, at most five.
+func writeAssessList(w io.Writer, label string, items []string) {
+	const limit = 5
+	for i, item := range items {
+		if i == limit {
+			fmt.Fprintf(w, "%-15s… and %d more\n", "", len(items)-limit)
+			break
+		}
+		if i > 0 {
+			label = ""
+		}
+		fmt.Fprintf(w, "%-15s%s\n", label, item)
+	}
+}
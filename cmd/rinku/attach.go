@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/internal/prompt"
+)
+
+type MigrateAttachCmd struct {
+	Step    string `arg:"" help:"Step ID the artifact belongs to."`
+	Content string `arg:"" help:"File to attach, - to read stdin, or the artifact text itself."`
+	Name    string `help:"Artifact file name (default: the attached file's name, or note.md for text and stdin)."`
+}
+
+func (c *MigrateAttachCmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	p, err := prompt.ForProject(cwd)
+	if err != nil {
+		return fmt.Errorf("failed to load migration prompt: %w", err)
+	}
+	if !slices.Contains(p.Steps(), c.Step) {
+		return fmt.Errorf("step '%s' not found", c.Step)
+	}
+
+	content, name, err := readArtifact(c.Content)
+	if err != nil {
+		return err
+	}
+	if c.Name != "" {
+		name = c.Name
+	}
+
+	a, err := progress.Attach(cwd, c.Step, name, content, time.Now())
+	if err != nil {
+		return err
+	}
+	autoSync(cwd)
+	fmt.Printf("Attached %s to step %s (%d bytes)\n", a.Path, c.Step, a.Size)
+	return nil
+}
+
+// readArtifact returns the content to attach and its default name. arg is read as a file
+// if one exists at that path, from stdin if it is -, and used as text otherwise.
+func readArtifact(arg string) ([]byte, string, error) {
+	if arg == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, "", fmt.Errorf("reading from stdin: %w", err)
+		}
+		return data, "note.md", nil
+	}
+
+	// Anything that cannot be stat'ed, including text too long for a path, is text.
+	if info, err := os.Stat(arg); err == nil {
+		if !info.Mode().IsRegular() {
+			return nil, "", fmt.Errorf("%s is not a regular file", arg)
+		}
+		data, err := os.ReadFile(arg) //#nosec G304 -- the user names the file to attach
+		if err != nil {
+			return nil, "", fmt.Errorf("reading %s: %w", arg, err)
+		}
+		return data, filepath.Base(arg), nil
+	}
+	return []byte(arg + "\n"), "note.md", nil
+}
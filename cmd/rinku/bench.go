@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/stephan/rinku/internal/scaffold"
+)
+
+type BenchCmd struct {
+	Path     string `arg:"" type:"existingfile" help:"Path to go.mod file."`
+	Package  string `arg:"" help:"Package whose benchmarks to pair, as import path or directory relative to go.mod."`
+	CrateDir string `help:"Directory for the Rust benchmark crate, relative to go.mod (default: bench/<package>)."`
+	Count    int    `help:"Number of runs per Go benchmark (go test -count)." default:"5"`
+	Force    bool   `help:"Overwrite existing files."`
+}
+
+func (c *BenchCmd) Run() error {
+	if c.CrateDir != "" {
+		if err := validateOutputPath(c.CrateDir); err != nil {
+			return err
+		}
+	}
+
+	pkg, root, err := loadScaffoldPackage(c.Path, c.Package)
+	if err != nil {
+		return err
+	}
+	bench, err := scaffold.GenerateBench(pkg, scaffold.BenchOptions{
+		CrateDir: filepath.ToSlash(c.CrateDir),
+		Count:    c.Count,
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeScaffold(root, bench.Files, c.Force); err != nil {
+		return err
+	}
+
+	crateDir := filepath.ToSlash(c.CrateDir)
+	if crateDir == "" {
+		crateDir = "bench/" + pkg.Name
+	}
+	fmt.Printf("\nPaired %d benchmarks from %s.\n", len(bench.Benchmarks), pkg.ImportPath)
+	fmt.Printf("\nPort the todo!() bodies in benches/%s.rs against the Rust crate, then run\n", pkg.Name)
+	fmt.Printf("`sh %s/compare.sh` to benchmark both sides and compare time per operation.\n", crateDir)
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/stephan/rinku/internal/multistep"
+	"github.com/stephan/rinku/internal/prompt"
+)
+
+type MigrateBootstrapCmd struct {
+	Agent string `help:"Agent to format the preamble for: claude, cursor or generic." enum:"claude,cursor,generic" default:"generic"`
+}
+
+// migrationCommands are the rinku commands an agent uses during the migrate workflow.
+var migrationCommands = []multistep.Command{
+	{Usage: "rinku migrate --start <step>", Description: "Start a step and print its instructions."},
+	{Usage: "rinku migrate --finish <step> [--note <text>]", Description: "Mark a step as complete once its gate passes."},
+	{Usage: "rinku migrate --status", Description: "Show progress and recorded artifacts."},
+	{Usage: "rinku migrate attach <step> <file-or-text>", Description: "Record a transcript, decision or diff for a step."},
+	{Usage: "rinku req set <path>", Description: "Capture a requirement (content as argument or on stdin)."},
+	{Usage: "rinku req list [pattern]", Description: "List requirements, [x] done and [ ] pending."},
+	{Usage: "rinku req done <path>", Description: "Mark a requirement as implemented."},
+	{Usage: "rinku lookup <github-url>", Description: "Find the Rust replacement for a Go dependency."},
+	{Usage: "rinku idiom [name]", Description: "Show how a Go idiom translates to Rust."},
+	{Usage: "rinku verify go.mod", Description: "Check requirement coverage against detected project tags."},
+}
+
+// migrationGuardrails are the rules an agent must follow during the migrate workflow.
+var migrationGuardrails = []string{
+	"Only read and write the current directory and its children, never its parents.",
+	"Work through the steps in order; start each with `rinku migrate --start` and finish it with `rinku migrate --finish`.",
+	"Keep the public surface (CLI flags, HTTP APIs, file formats) identical in Rust; capture it as requirements before changing code.",
+	"Never edit files under .rinku/ by hand; use the rinku commands.",
+	"Do not delete or rewrite the Go sources until the workflow tells you to.",
+	"Run in a dev container, VM or sandbox.",
+}
+
+func (c *MigrateBootstrapCmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	p, err := prompt.ForProject(cwd)
+	if err != nil {
+		return fmt.Errorf("failed to load migration prompt: %w", err)
+	}
+	out, err := p.AgentBootstrap(multistep.Agent(c.Agent), multistep.BootstrapOptions{
+		Command:     "rinku migrate --start",
+		Description: "Migrate this Go project to Rust with the rinku migrate workflow",
+		Commands:    migrationCommands,
+		Guardrails:  migrationGuardrails,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+	return nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/stephan/rinku/internal/certificate"
+	"github.com/stephan/rinku/internal/config"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/internal/requirements"
+	"github.com/stephan/rinku/internal/verify"
+)
+
+type MigrateCertificateCmd struct{}
+
+func (c *MigrateCertificateCmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	cert, err := certificate.Load(cwd)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no completion certificate: it is written when the last step is finished")
+		}
+		return err
+	}
+	if err := cert.Verify(signingKey()); err != nil {
+		return fmt.Errorf("%s: %w", relPath(certificate.Path(cwd)), err)
+	}
+	fmt.Printf("Certificate of %s is valid (%s)\n", cert.Project, cert.Signature.Algorithm)
+	fmt.Printf("Completed: %s\n", cert.CompletedAt.Format("2006-01-02 15:04:05"))
+	fmt.Printf("Steps: %d\n", len(cert.Steps))
+	fmt.Printf("Requirements: %d/%d done\n", cert.Requirements.Done, cert.Requirements.Total)
+	fmt.Printf("Database: %s\n", cert.Database)
+	return nil
+}
+
+// signingKey returns the key of RINKU_SIGNING_KEY, or nil to sign with a digest.
+func signingKey() []byte {
+	return []byte(os.Getenv(certificate.KeyEnv))
+}
+
+// writeCompletionCertificate writes .rinku/completion.json for a completed migration:
+// its steps, the requirement coverage and the results verify reports for the go.mod of
+// .rinku.toml.
+func writeCompletionCertificate(index mappingIndex, cwd string, m *progress.Migration) error {
+	cfg, err := config.Load(cwd)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	cert := certificate.New(filepath.Base(cwd), m, now)
+	cert.IssuedBy = progress.Actor(cwd)
+	cert.Database = databaseVersion
+
+	summary, err := requirements.Summary(cwd)
+	if err != nil {
+		return err
+	}
+	cert.Requirements = certificate.Requirements{Total: summary.Total, Done: summary.Done, Pending: summary.Pending}
+	if summary.Total > 0 {
+		cert.Requirements.Coverage = summary.Done * 100 / summary.Total
+	}
+
+	goMod := cfg.Migration.GoMod
+	if goMod == "" {
+		goMod = "go.mod"
+	}
+	if !filepath.IsAbs(goMod) {
+		goMod = filepath.Join(cwd, goMod)
+	}
+	cert.VerifyResult.Tags = []string{}
+	cert.VerifyResult.Categories = []certificate.Category{}
+	if result, err := gomod.Parse(goMod); err == nil {
+		cert.Module = result.Module
+		r, err := index()
+		if err != nil {
+			return err
+		}
+		tags, err := dependencyTags(r, goMod)
+		if err != nil {
+			return err
+		}
+		strip, err := namespaceStrip(cwd)
+		if err != nil {
+			return err
+		}
+		statuses, err := verify.CheckCoverage(cwd, tags, strip)
+		if err != nil {
+			return fmt.Errorf("checking coverage: %w", err)
+		}
+		if tags != nil {
+			cert.VerifyResult.Tags = tags
+		}
+		for _, s := range statuses {
+			cert.VerifyResult.Categories = append(cert.VerifyResult.Categories, certificate.Category{
+				Category: s.Category, Pattern: s.Pattern, Captured: s.Count, Done: s.DoneCount,
+			})
+		}
+	}
+
+	if err := cert.Sign(signingKey()); err != nil {
+		return err
+	}
+	if err := certificate.Write(cwd, cert); err != nil {
+		return fmt.Errorf("writing completion certificate: %w", err)
+	}
+	return nil
+}
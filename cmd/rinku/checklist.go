@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/stephan/rinku/internal/multistep"
+	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/internal/prompt"
+)
+
+type MigrateCheckCmd struct {
+	Step string `arg:"" help:"Step ID the checklist belongs to."`
+	Item int    `arg:"" help:"Number of the checklist item, counted from 1 in the order of the step."`
+	Undo bool   `help:"Uncheck the item."`
+}
+
+// ChecklistItem is a "- [ ]" item of a step and whether it has been checked off.
+type ChecklistItem struct {
+	Item    int    `json:"item"` // 1-based
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+}
+
+func (c *MigrateCheckCmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	p, err := prompt.ForProject(cwd)
+	if err != nil {
+		return fmt.Errorf("failed to load migration prompt: %w", err)
+	}
+	if !slices.Contains(p.Steps(), c.Step) {
+		return fmt.Errorf("step '%s' not found", c.Step)
+	}
+	items := p.Checklist(c.Step)
+	if len(items) == 0 {
+		return fmt.Errorf("step %s has no checklist items", c.Step)
+	}
+	if c.Item < 1 || c.Item > len(items) {
+		return fmt.Errorf("step %s has checklist items 1 to %d, not %d", c.Step, len(items), c.Item)
+	}
+
+	m, err := progress.Load(cwd)
+	if err != nil {
+		return fmt.Errorf("loading progress: %w", err)
+	}
+	if m == nil {
+		return fmt.Errorf("no migration in progress: start one with rinku migrate --start %s", c.Step)
+	}
+	m.SetStepOrder(p.Steps())
+	if err := m.CheckItem(c.Step, c.Item, !c.Undo, progress.Actor(cwd)); err != nil {
+		return err
+	}
+	if err := m.Save(cwd); err != nil {
+		return fmt.Errorf("saving progress: %w", err)
+	}
+	autoSync(cwd)
+
+	verb := "Checked"
+	if c.Undo {
+		verb = "Unchecked"
+	}
+	done := len(items) - len(m.Unchecked(c.Step, len(items)))
+	fmt.Printf("%s item %d of step %s: %s (%d/%d checked)\n", verb, c.Item, c.Step, items[c.Item-1], done, len(items))
+	return nil
+}
+
+// stepChecklist returns the checklist items of a step with their state in m, which may
+// be nil before the migration starts.
+func stepChecklist(p *multistep.Prompt, m *progress.Migration, id string) []ChecklistItem {
+	var items []ChecklistItem
+	for i, text := range p.Checklist(id) {
+		checked := false
+		if m != nil && m.Steps[id] != nil {
+			checked = slices.Contains(m.Steps[id].Checked, i+1)
+		}
+		items = append(items, ChecklistItem{Item: i + 1, Text: text, Checked: checked})
+	}
+	return items
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stephan/rinku/internal/audit"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/gosrc"
+	"github.com/stephan/rinku/internal/requirements"
+	"github.com/stephan/rinku/render"
+)
+
+type ReqAPICmd struct {
+	Path   string `arg:"" optional:"" type:"existingfile" help:"Path to go.mod file (default: go.mod in cwd)."`
+	DryRun bool   `help:"Print the requirements without writing them."`
+	Force  bool   `help:"Overwrite requirements that already exist."`
+}
+
+type ReqResolveCmd struct {
+	Path       string `arg:"" help:"Requirement path of a public API item (e.g., pkg/client/Client.Do)."`
+	Resolution string `arg:"" enum:"ported,renamed,dropped" help:"How the item was carried over: ported, renamed or dropped."`
+	To         string `help:"New Rust name of a renamed item."`
+}
+
+type CompatCmd struct {
+	Path   string `arg:"" optional:"" type:"existingfile" help:"Path to go.mod file (default: go.mod in cwd)."`
+	Format string `help:"Output format: markdown or json." enum:"markdown,json" default:"markdown"`
+	Output string `short:"o" default:"-" help:"Output file (- for stdout)."`
+}
+
+// CompatEntry is one exported Go API item and what became of it in the Rust port.
+type CompatEntry struct {
+	Package   string `json:"package"` // import path
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`
+	Signature string `json:"signature"`
+	Status    string `json:"status"`         // pending, ported, renamed or dropped
+	Rust      string `json:"rust,omitempty"` // new name of renamed items
+}
+
+func (c *ReqAPICmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	_, pkgs, err := loadPublicAPI(c.Path)
+	if err != nil {
+		return err
+	}
+
+	var reqs []audit.SurfaceRequirement
+	items := 0
+	for _, pkg := range pkgs {
+		reqs = append(reqs, pkg.Requirements()...)
+		items += len(pkg.Items)
+	}
+	created, skipped, err := createRequirements(cwd, reqs, c.DryRun, c.Force)
+	if err != nil {
+		return err
+	}
+
+	verb := "Created"
+	if c.DryRun {
+		verb = "Would create"
+	}
+	fmt.Printf("\n%s %d requirements (%d packages, %d exported items", verb, created, len(pkgs), items)
+	if skipped > 0 {
+		fmt.Printf(", %d already captured", skipped)
+	}
+	fmt.Println(")")
+	fmt.Println("Mark items with 'rinku req resolve <path> ported|renamed|dropped', then run 'rinku compat'.")
+	if created > 0 && !c.DryRun {
+		autoSync(cwd)
+		notifyCoverage(cwd)
+	}
+	return nil
+}
+
+func (c *ReqResolveCmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	if err := requirements.Resolve(cwd, c.Path, c.Resolution, c.To); err != nil {
+		return err
+	}
+	autoSync(cwd)
+	notifyCoverage(cwd)
+	if c.To != "" {
+		fmt.Printf("Marked %s as %s to %s\n", c.Path, c.Resolution, c.To)
+	} else {
+		fmt.Printf("Marked %s as %s\n", c.Path, c.Resolution)
+	}
+	return nil
+}
+
+func (c *CompatCmd) Run() (err error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	module, pkgs, err := loadPublicAPI(c.Path)
+	if err != nil {
+		return err
+	}
+	entries := compatEntries(cwd, module, pkgs)
+
+	w := os.Stdout
+	if c.Output != "-" {
+		if err := validateOutputPath(c.Output); err != nil {
+			return err
+		}
+		w, err = os.Create(c.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() {
+			if cerr := w.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("failed to close output file: %w", cerr)
+			}
+		}()
+	}
+
+	if c.Format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(render.Versioned(render.APIVersion, "compat", entries)); err != nil {
+			return fmt.Errorf("encoding compatibility table: %w", err)
+		}
+		return nil
+	}
+	writeCompatMarkdown(w, module, entries)
+	return nil
+}
+
+// loadPublicAPI parses the module of a go.mod file (go.mod in cwd if empty) and returns
+// its path and exported API.
+func loadPublicAPI(goModPath string) (string, []audit.APIPackage, error) {
+	if goModPath == "" {
+		goModPath = "go.mod"
+	}
+	result, err := gomod.Parse(goModPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	tree, err := gosrc.Parse(filepath.Dir(goModPath))
+	if err != nil {
+		return "", nil, fmt.Errorf("parsing source files: %w", err)
+	}
+	pkgs := audit.PublicAPI(tree)
+	if len(pkgs) == 0 {
+		return "", nil, fmt.Errorf("%s has no importable packages with exported API\nHint: For binaries, use 'rinku req extract'", result.Module)
+	}
+	return result.Module, pkgs, nil
+}
+
+// compatEntries joins the exported API with the resolutions recorded on its requirements.
+func compatEntries(dir, module string, pkgs []audit.APIPackage) []CompatEntry {
+	var entries []CompatEntry
+	for _, pkg := range pkgs {
+		importPath := module
+		if pkg.Dir != "." {
+			importPath += "/" + pkg.Dir
+		}
+		for _, item := range pkg.Items {
+			e := CompatEntry{Package: importPath, Name: item.Name, Kind: item.Kind, Signature: item.Signature, Status: "pending"}
+			if req, _ := requirements.Get(dir, pkg.RequirementPath()+"/"+item.Name); req != nil && req.Done {
+				e.Status = requirements.Ported
+				if req.Resolution != "" {
+					e.Status = req.Resolution
+				}
+				e.Rust = req.RenamedTo
+			}
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// writeCompatMarkdown writes one table per package for the changelog or migration guide
+// of downstream consumers.
+func writeCompatMarkdown(w io.Writer, module string, entries []CompatEntry) {
+	counts := make(map[string]int)
+	for _, e := range entries {
+		counts[e.Status]++
+	}
+	_, _ = fmt.Fprintf(w, "# API compatibility: %s\n\n", module)
+	_, _ = fmt.Fprintf(w, "%d exported items: %d ported, %d renamed, %d dropped, %d pending.\n",
+		len(entries), counts[requirements.Ported], counts[requirements.Renamed], counts[requirements.Dropped], counts["pending"])
+
+	pkg := ""
+	for _, e := range entries {
+		if e.Package != pkg {
+			pkg = e.Package
+			_, _ = fmt.Fprintf(w, "\n## %s\n\n| Go | Kind | Status | Rust |\n|----|------|--------|------|\n", pkg)
+		}
+		rust := ""
+		switch {
+		case e.Rust != "":
+			rust = "`" + strings.ReplaceAll(e.Rust, "|", `\|`) + "`"
+		case e.Status == requirements.Ported:
+			rust = "same name"
+		}
+		_, _ = fmt.Fprintf(w, "| `%s` | %s | %s | %s |\n", e.Name, e.Kind, e.Status, rust)
+	}
+}
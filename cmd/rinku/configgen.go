@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/stephan/rinku/internal/configgen"
+	"github.com/stephan/rinku/internal/gomod"
+)
+
+type ConfigGenCmd struct {
+	Path   string `arg:"" type:"existingfile" help:"Path to go.mod file."`
+	Loader string `enum:",figment,config-rs" default:"" help:"Rust config crate (figment, config-rs). Defaults to the best match for the detected Go library."`
+	Output string `short:"o" default:"-" help:"Output file (- for stdout)."`
+}
+
+func (c *ConfigGenCmd) Run() (err error) {
+	var w *os.File
+	if c.Output == "-" {
+		w = os.Stdout
+	} else {
+		if err := validateOutputPath(c.Output); err != nil {
+			return err
+		}
+		w, err = os.Create(c.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() {
+			if cerr := w.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("failed to close output file: %w", cerr)
+			}
+		}()
+	}
+
+	loader, err := generateConfig(w, c.Path, configgen.Loader(c.Loader))
+	if err != nil {
+		return err
+	}
+	if c.Output != "-" {
+		fmt.Fprintf(os.Stderr, "Generated %s using %s\n", c.Output, loader)
+	}
+	return nil
+}
+
+// generateConfig writes Rust config scaffolding for the config files next to goModPath.
+// An empty loader selects the best match for the detected Go library.
+func generateConfig(w io.Writer, goModPath string, loader configgen.Loader) (configgen.Loader, error) {
+	result, err := gomod.Parse(goModPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+
+	var modulePaths []string
+	for _, dep := range result.DirectDependencies() {
+		modulePaths = append(modulePaths, dep.Path)
+	}
+	libs := configgen.DetectLibraries(modulePaths)
+
+	root := filepath.Dir(goModPath)
+	files, err := configgen.FindConfigFiles(root)
+	if err != nil {
+		return "", fmt.Errorf("finding config files: %w", err)
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("no config files found in %s\nHint: Expected files like config.yaml, settings.toml, configs/*.json or .env", root)
+	}
+
+	schema := configgen.NewSchema()
+	for _, f := range files {
+		values, err := configgen.ParseFile(filepath.Join(root, filepath.FromSlash(f.Path)), f.Format)
+		if err != nil {
+			return "", err
+		}
+		schema.Add(values)
+	}
+
+	if loader == "" {
+		loader = configgen.LoaderFigment
+		if len(libs) > 0 {
+			loader = libs[0].Loader
+		}
+	}
+
+	for _, lib := range libs {
+		fmt.Fprintf(os.Stderr, "Detected %s (%s)\n", lib.Name, lib.ModulePath)
+	}
+	for _, f := range files {
+		fmt.Fprintf(os.Stderr, "Config file: %s (%s)\n", f.Path, f.Format)
+	}
+
+	if err := configgen.Generate(w, schema, configgen.Options{Loader: loader, Sources: files}); err != nil {
+		return "", fmt.Errorf("generating config scaffolding: %w", err)
+	}
+	return loader, nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/target"
+	"github.com/stephan/rinku/render"
+)
+
+// convertToGo maps a Cargo.toml to Go modules for convert --to go, written as a go.mod
+// draft in the text format. module defaults to the package name.
+func convertToGo(r *rinku.Rinku, cargoPath, module string, unsafe bool) (*render.Document, *cargo.ReverseResult, error) {
+	f, err := os.Open(cargoPath) //#nosec G304 -- path given by the user
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening Cargo.toml: %w", err)
+	}
+	m, err := cargo.ParseManifest(f)
+	f.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	if module == "" {
+		module = m.PackageName
+	}
+	if module == "" {
+		return nil, nil, fmt.Errorf("%s has no package name: set the module path with --module", cargoPath)
+	}
+
+	result := cargo.MapToGo(m, r, unsafe)
+	doc := &render.Document{
+		Command: "convert",
+		Title:   module,
+		Fields: []render.Field{
+			{Name: "Module", Value: module},
+			{Name: "Mapped", Value: strconv.Itoa(len(result.Mapped))},
+			{Name: "Stdlib", Value: strconv.Itoa(len(result.Stdlib))},
+			{Name: "Unmapped", Value: strconv.Itoa(len(result.Unmapped))},
+		},
+		Columns: []string{"crate", "requirement", "module", "alternatives", "url"},
+		Text: func(w io.Writer) error {
+			return cargo.GenerateGoMod(w, module, result)
+		},
+	}
+	for _, g := range result.Mapped {
+		doc.Rows = append(doc.Rows, []string{g.Crate.Name, g.Crate.Version, g.Modules[0], strings.Join(g.Modules[1:], " "), g.RustURL})
+	}
+	for _, d := range result.Stdlib {
+		doc.Rows = append(doc.Rows, []string{d.Name, d.Version, "std", "", d.RustURL})
+	}
+	for _, d := range result.Unmapped {
+		doc.Rows = append(doc.Rows, []string{d.Name, d.Version, "", "", ""})
+		doc.Findings = append(doc.Findings, render.Finding{
+			Rule:    "unmapped-dependency",
+			Level:   render.LevelWarning,
+			Message: fmt.Sprintf("no Go equivalent found for %s; left as a comment in go.mod", d.Name),
+			File:    relPath(cargoPath),
+			Subject: d.Name,
+		})
+	}
+	return doc, result, nil
+}
+
+// convertToTarget maps the go.mod dependencies to the ecosystem of a target backend for
+// convert --to python or ts, written as the manifest named file in the text format. With
+// pin, the packages require their latest registry version.
+func convertToTarget(r *rinku.Rinku, b target.Backend, file, goModPath string, unsafe, pin bool) (*render.Document, *target.Mapping, error) {
+	result, err := gomod.Parse(goModPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	m := target.Map(b, result.DirectDependencies(), r, unsafe)
+	if pin {
+		if err := target.Pin(context.Background(), b, m); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
+	doc := &render.Document{
+		Command: "convert",
+		Title:   result.Module,
+		Fields: []render.Field{
+			{Name: "Module", Value: result.Module},
+			{Name: "Mapped", Value: strconv.Itoa(len(m.Mapped))},
+			{Name: "Unmapped", Value: strconv.Itoa(len(m.Unmapped))},
+		},
+		Columns: []string{"module", "version", "package", "requirement", "url"},
+		Text: func(w io.Writer) error {
+			return target.WriteManifest(w, b, file, result.Module, m)
+		},
+	}
+	for _, mapped := range m.Mapped {
+		for i, u := range mapped.URLs {
+			doc.Rows = append(doc.Rows, []string{mapped.Dep.Path, mapped.Dep.Version, mapped.Packages[i], mapped.Version(i), u})
+		}
+	}
+	for _, dep := range m.Unmapped {
+		doc.Rows = append(doc.Rows, []string{dep.Path, dep.Version, "", "", ""})
+		doc.Findings = append(doc.Findings, render.Finding{
+			Rule:    "unmapped-dependency",
+			Level:   render.LevelWarning,
+			Message: fmt.Sprintf("no %s equivalent found for %s; left out of %s", b.Lang(), dep.Path, file),
+			File:    relPath(goModPath),
+			Subject: dep.Path,
+		})
+	}
+	return doc, m, nil
+}
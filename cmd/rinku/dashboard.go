@@ -0,0 +1,454 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/internal/prompt"
+	"github.com/stephan/rinku/internal/requirements"
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/scanhistory"
+	"github.com/stephan/rinku/internal/verify"
+	"github.com/stephan/rinku/render"
+)
+
+type DashboardCmd struct {
+	Path     string `arg:"" optional:"" type:"existingfile" help:"Path to go.mod file (default: go.mod in cwd)."`
+	Format   string `help:"Output format: text, json, markdown or html." enum:"text,json,markdown,html" default:"text"`
+	Template string `type:"existingfile" help:"Go template to render the markdown or html dashboard with instead of the built-in layout (see the README for its data)."`
+	Output   string `short:"o" default:"-" help:"Output file (- for stdout)."`
+	Unsafe   bool   `help:"Include libraries with known vulnerabilities."`
+}
+
+// Dashboard aggregates the state of a migration into one summary.
+type Dashboard struct {
+	Module       string              `json:"module"`
+	GeneratedAt  time.Time           `json:"generated_at"`
+	Scan         ScanSummary         `json:"scan"`
+	Coverage     CoverageSummary     `json:"coverage"`
+	Steps        StepSummary         `json:"steps"`
+	Requirements RequirementsSummary `json:"requirements"`
+}
+
+// ScanSummary is the share of direct dependencies with a Rust mapping.
+type ScanSummary struct {
+	Direct   int      `json:"direct"`
+	Mapped   int      `json:"mapped"`
+	Unmapped []string `json:"unmapped"`
+	// Dependencies are all direct dependencies, sorted by module path.
+	Dependencies []DependencySummary `json:"dependencies"`
+	// History is the coverage of the scans saved with scan --save, oldest first.
+	History []ScanPoint `json:"history,omitempty"`
+}
+
+// ScanPoint is the coverage of one saved scan.
+type ScanPoint struct {
+	ScannedAt time.Time `json:"scanned_at"`
+	Direct    int       `json:"direct"`
+	Mapped    int       `json:"mapped"`
+	Coverage  int       `json:"coverage"` // percent mapped
+}
+
+// DependencySummary is a direct dependency and the crates it maps to, none if unmapped.
+type DependencySummary struct {
+	Module  string   `json:"module"`
+	Version string   `json:"version"`
+	Crates  []string `json:"crates"`
+}
+
+// CoverageSummary lists the requirement categories expected from the project's tags.
+type CoverageSummary struct {
+	Tags       []string          `json:"tags"`
+	Categories []CategorySummary `json:"categories"`
+	Covered    int               `json:"covered"`
+}
+
+// CategorySummary is one expected requirement category.
+type CategorySummary struct {
+	Tag      string `json:"tag"`
+	Pattern  string `json:"pattern"`
+	Captured int    `json:"captured"`
+	Done     int    `json:"done"`
+}
+
+// StepSummary is the progress of the migrate workflow.
+type StepSummary struct {
+	Started      bool      `json:"started"`
+	Completed    int       `json:"completed"`
+	Total        int       `json:"total"`
+	Current      string    `json:"current,omitempty"`
+	Remaining    string    `json:"remaining,omitempty"` // estimated, see Migration.Forecast
+	Finish       time.Time `json:"finish,omitzero"`
+	Contributors []string  `json:"contributors,omitempty"`
+}
+
+// RequirementsSummary counts captured requirements.
+type RequirementsSummary struct {
+	Done        int       `json:"done"`
+	Pending     int       `json:"pending"`
+	Open        []string  `json:"open,omitempty"` // pending requirement paths
+	LastUpdated time.Time `json:"last_updated,omitzero"`
+}
+
+func (c *DashboardCmd) Run(r *rinku.Rinku) (err error) {
+	path := c.Path
+	if path == "" {
+		path = "go.mod"
+	}
+	tmpl, err := c.template()
+	if err != nil {
+		return err
+	}
+	d, err := buildDashboard(r, path, c.Unsafe, time.Now())
+	if err != nil {
+		return err
+	}
+
+	w := os.Stdout
+	if c.Output != "-" {
+		if err := validateOutputPath(c.Output); err != nil {
+			return err
+		}
+		w, err = os.Create(c.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() {
+			if cerr := w.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("failed to close output file: %w", cerr)
+			}
+		}()
+	}
+
+	switch c.Format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(render.Versioned(render.APIVersion, "dashboard", d)); err != nil {
+			return fmt.Errorf("encoding dashboard: %w", err)
+		}
+	case "markdown", "html":
+		if err := tmpl.Execute(w, d); err != nil {
+			return fmt.Errorf("rendering dashboard: %w", err)
+		}
+	default:
+		writeDashboardText(w, d)
+	}
+	return nil
+}
+
+// dashboardTemplate is the text/template or html/template a dashboard is rendered with.
+type dashboardTemplate interface {
+	Execute(w io.Writer, data any) error
+}
+
+// dashboardFuncs are the functions available to dashboard templates.
+var dashboardFuncs = map[string]any{
+	"percent": percent,
+	"add":     func(a, b int) int { return a + b },
+	"join":    strings.Join,
+}
+
+// template returns the template of --format markdown or html: the one of --template,
+// parsed with html/template for html so that values are escaped, or the built-in one.
+func (c *DashboardCmd) template() (dashboardTemplate, error) {
+	if c.Template == "" {
+		if c.Format == "markdown" {
+			return dashboardMarkdown, nil
+		}
+		return dashboardHTML, nil
+	}
+	if c.Format != "markdown" && c.Format != "html" {
+		return nil, fmt.Errorf("--template renders --format markdown or html, not %s", c.Format)
+	}
+	data, err := os.ReadFile(c.Template) //#nosec G304 -- the user names the template
+	if err != nil {
+		return nil, fmt.Errorf("reading template: %w", err)
+	}
+	name := filepath.Base(c.Template)
+	if c.Format == "html" {
+		t, err := template.New(name).Funcs(dashboardFuncs).Parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("parsing template: %w", err)
+		}
+		return t, nil
+	}
+	t, err := texttemplate.New(name).Funcs(dashboardFuncs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+	return t, nil
+}
+
+// buildDashboard collects scan, coverage, step and requirement state for the project
+// of a go.mod file.
+func buildDashboard(r *rinku.Rinku, goModPath string, unsafe bool, now time.Time) (*Dashboard, error) {
+	result, err := gomod.Parse(goModPath)
+	if err != nil {
+		return nil, fmt.Errorf("parsing go.mod: %w", err)
+	}
+	dir := filepath.Dir(goModPath)
+	deps := result.DirectDependencies()
+
+	d := &Dashboard{Module: result.Module, GeneratedAt: now.UTC().Truncate(time.Second)}
+
+	mapping := cargo.MapDependencies(deps, r, unsafe)
+	d.Scan = ScanSummary{Direct: len(deps), Mapped: len(mapping.Mapped), Unmapped: []string{}, Dependencies: []DependencySummary{}}
+	for _, m := range mapping.Mapped {
+		d.Scan.Dependencies = append(d.Scan.Dependencies, DependencySummary{Module: m.GoDep.Path, Version: m.GoDep.Version, Crates: m.CrateNames})
+	}
+	for _, u := range mapping.Unmapped {
+		d.Scan.Unmapped = append(d.Scan.Unmapped, u.GoDep.Path)
+		d.Scan.Dependencies = append(d.Scan.Dependencies, DependencySummary{Module: u.GoDep.Path, Version: u.GoDep.Version, Crates: []string{}})
+	}
+	sort.Strings(d.Scan.Unmapped)
+	sort.Slice(d.Scan.Dependencies, func(i, j int) bool {
+		return d.Scan.Dependencies[i].Module < d.Scan.Dependencies[j].Module
+	})
+	snapshots, err := scanhistory.List(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range snapshots {
+		d.Scan.History = append(d.Scan.History, ScanPoint{ScannedAt: s.ScannedAt.Truncate(time.Second), Direct: s.Direct, Mapped: s.Mapped, Coverage: s.Coverage()})
+	}
+
+	tagSet := make(map[string]bool)
+	for _, dep := range deps {
+		for _, tag := range r.Tags(cargo.ModulePathToGitHubURL(dep.Path)) {
+			tagSet[tag] = true
+		}
+	}
+	d.Coverage.Tags = []string{}
+	for tag := range tagSet {
+		d.Coverage.Tags = append(d.Coverage.Tags, tag)
+	}
+	sort.Strings(d.Coverage.Tags)
+	strip, err := namespaceStrip(dir)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := verify.CheckCoverage(dir, d.Coverage.Tags, strip)
+	if err != nil {
+		return nil, fmt.Errorf("checking coverage: %w", err)
+	}
+	d.Coverage.Categories = []CategorySummary{}
+	for _, s := range statuses {
+		d.Coverage.Categories = append(d.Coverage.Categories, CategorySummary{Tag: s.Category, Pattern: s.Pattern, Captured: s.Count, Done: s.DoneCount})
+		if s.HasRequirements {
+			d.Coverage.Covered++
+		}
+	}
+	sort.Slice(d.Coverage.Categories, func(i, j int) bool {
+		return d.Coverage.Categories[i].Pattern < d.Coverage.Categories[j].Pattern
+	})
+
+	p, err := prompt.ForProject(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migration prompt: %w", err)
+	}
+	d.Steps.Total = len(p.Steps())
+	m, err := progress.Load(dir)
+	if err != nil {
+		return nil, fmt.Errorf("loading progress: %w", err)
+	}
+	if m != nil {
+		d.Steps.Started = true
+		d.Steps.Completed, d.Steps.Total = m.Progress()
+		d.Steps.Current = m.CurrentStep
+		d.Steps.Contributors = m.Contributors()
+		if !m.IsComplete() {
+			f := m.Forecast(p.Estimates(), now)
+			d.Steps.Remaining = formatDuration(f.Remaining)
+			d.Steps.Finish = f.Finish.UTC().Truncate(time.Minute)
+		}
+	}
+
+	summary, err := requirements.Summary(dir)
+	if err != nil {
+		return nil, err
+	}
+	d.Requirements = RequirementsSummary{Done: summary.Done, Pending: len(summary.Pending), Open: summary.Pending, LastUpdated: summary.LastUpdated}
+	return d, nil
+}
+
+// percent returns n/total as a percentage, 100 when total is zero.
+func percent(n, total int) int {
+	if total == 0 {
+		return 100
+	}
+	return n * 100 / total
+}
+
+// bar renders a fixed-width progress bar.
+func bar(n, total int) string {
+	const width = 20
+	filled := percent(n, total) * width / 100
+	return "[" + strings.Repeat("#", filled) + strings.Repeat(".", width-filled) + "]"
+}
+
+func writeDashboardText(w io.Writer, d *Dashboard) {
+	fmt.Fprintf(w, "Migration Dashboard: %s\n", d.Module)
+	fmt.Fprintf(w, "Generated: %s\n\n", d.GeneratedAt.Local().Format("2006-01-02 15:04"))
+
+	fmt.Fprintf(w, "Dependencies  %s %3d%%  %d/%d mapped to Rust crates\n",
+		bar(d.Scan.Mapped, d.Scan.Direct), percent(d.Scan.Mapped, d.Scan.Direct), d.Scan.Mapped, d.Scan.Direct)
+	fmt.Fprintf(w, "Coverage      %s %3d%%  %d/%d expected categories captured\n",
+		bar(d.Coverage.Covered, len(d.Coverage.Categories)), percent(d.Coverage.Covered, len(d.Coverage.Categories)), d.Coverage.Covered, len(d.Coverage.Categories))
+	fmt.Fprintf(w, "Steps         %s %3d%%  %d/%d steps", bar(d.Steps.Completed, d.Steps.Total), percent(d.Steps.Completed, d.Steps.Total), d.Steps.Completed, d.Steps.Total)
+	if !d.Steps.Started {
+		fmt.Fprint(w, " (workflow not started)")
+	} else if d.Steps.Current != "" {
+		fmt.Fprintf(w, " (current: %s)", d.Steps.Current)
+	}
+	fmt.Fprintln(w)
+	total := d.Requirements.Done + d.Requirements.Pending
+	fmt.Fprintf(w, "Requirements  %s %3d%%  %d/%d done", bar(d.Requirements.Done, total), percent(d.Requirements.Done, total), d.Requirements.Done, total)
+	if !d.Requirements.LastUpdated.IsZero() {
+		fmt.Fprintf(w, " (last change %s)", d.Requirements.LastUpdated.Local().Format("Jan 2 15:04"))
+	}
+	fmt.Fprintln(w)
+
+	if n := len(d.Scan.History); n > 1 {
+		fmt.Fprintf(w, "\nMapping trend: %d%% -> %d%% over %d saved scans since %s\n", d.Scan.History[0].Coverage, d.Scan.History[n-1].Coverage, n, d.Scan.History[0].ScannedAt.Local().Format("Jan 2"))
+	}
+	if d.Steps.Remaining != "" {
+		fmt.Fprintf(w, "\nETA: ~%s remaining (finish around %s)\n", d.Steps.Remaining, d.Steps.Finish.Local().Format("Jan 2 15:04"))
+	}
+	if len(d.Steps.Contributors) > 0 {
+		fmt.Fprintf(w, "Contributors: %s\n", strings.Join(d.Steps.Contributors, ", "))
+	}
+
+	if len(d.Scan.Unmapped) > 0 {
+		fmt.Fprintf(w, "\nUnmapped dependencies:\n")
+		for _, dep := range d.Scan.Unmapped {
+			fmt.Fprintf(w, "  %s\n", dep)
+		}
+	}
+	var missing []string
+	for _, cat := range d.Coverage.Categories {
+		if cat.Captured == 0 {
+			missing = append(missing, fmt.Sprintf("%s (%s)", cat.Pattern, cat.Tag))
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Fprintf(w, "\nMissing requirement categories:\n")
+		for _, m := range missing {
+			fmt.Fprintf(w, "  %s\n", m)
+		}
+	}
+	if len(d.Requirements.Open) > 0 {
+		fmt.Fprintf(w, "\nPending requirements:\n")
+		for _, p := range d.Requirements.Open {
+			fmt.Fprintf(w, "  [ ] %s\n", p)
+		}
+	}
+}
+
+var dashboardMarkdown = texttemplate.Must(texttemplate.New("dashboard").Funcs(dashboardFuncs).Parse(`# Migration Dashboard: {{.Module}}
+
+Generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}
+{{- $reqTotal := add .Requirements.Done .Requirements.Pending}}
+
+| | Progress | |
+|---|---|---|
+| Dependencies | {{percent .Scan.Mapped .Scan.Direct}}% | {{.Scan.Mapped}}/{{.Scan.Direct}} mapped |
+| Coverage | {{percent .Coverage.Covered (len .Coverage.Categories)}}% | {{.Coverage.Covered}}/{{len .Coverage.Categories}} categories captured |
+| Steps | {{percent .Steps.Completed .Steps.Total}}% | {{.Steps.Completed}}/{{.Steps.Total}} steps{{if .Steps.Current}} (current: {{.Steps.Current}}){{end}} |
+| Requirements | {{percent .Requirements.Done $reqTotal}}% | {{.Requirements.Done}}/{{$reqTotal}} done |
+{{- if .Steps.Remaining}}
+
+ETA: ~{{.Steps.Remaining}} remaining (finish around {{.Steps.Finish.Format "Jan 2 15:04 MST"}})
+{{- end}}
+{{- if .Steps.Contributors}}
+
+Contributors: {{join .Steps.Contributors ", "}}
+{{- end}}
+{{- if .Scan.Unmapped}}
+
+## Unmapped dependencies
+{{range .Scan.Unmapped}}
+- ` + "`{{.}}`" + `
+{{- end}}
+{{- end}}
+{{- if .Coverage.Categories}}
+
+## Requirement categories
+
+| Category | Tag | Captured | Done |
+|---|---|---|---|
+{{- range .Coverage.Categories}}
+| ` + "`{{.Pattern}}`" + ` | {{.Tag}} | {{.Captured}} | {{.Done}} |
+{{- end}}
+{{- end}}
+{{- if .Requirements.Open}}
+
+## Pending requirements
+{{range .Requirements.Open}}
+- [ ] ` + "`{{.}}`" + `
+{{- end}}
+{{- end}}
+`))
+
+var dashboardHTML = template.Must(template.New("dashboard").Funcs(dashboardFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Migration Dashboard: {{.Module}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 52rem; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1.5rem; }
+td, th { padding: .4rem .6rem; text-align: left; border-bottom: 1px solid #ddd; }
+.bar { background: #eee; width: 14rem; height: .8rem; border-radius: .4rem; overflow: hidden; }
+.bar span { display: block; height: 100%; background: #b7410e; }
+.muted { color: #777; }
+</style>
+</head>
+<body>
+<h1>{{.Module}}</h1>
+<p class="muted">Generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}</p>
+<table>
+{{- $reqTotal := add .Requirements.Done .Requirements.Pending}}
+<tr><th>Dependencies</th><td><div class="bar"><span style="width: {{percent .Scan.Mapped .Scan.Direct}}%"></span></div></td><td>{{.Scan.Mapped}}/{{.Scan.Direct}} mapped</td></tr>
+<tr><th>Coverage</th><td><div class="bar"><span style="width: {{percent .Coverage.Covered (len .Coverage.Categories)}}%"></span></div></td><td>{{.Coverage.Covered}}/{{len .Coverage.Categories}} categories captured</td></tr>
+<tr><th>Steps</th><td><div class="bar"><span style="width: {{percent .Steps.Completed .Steps.Total}}%"></span></div></td><td>{{.Steps.Completed}}/{{.Steps.Total}} steps{{if .Steps.Current}} (current: {{.Steps.Current}}){{end}}</td></tr>
+<tr><th>Requirements</th><td><div class="bar"><span style="width: {{percent .Requirements.Done $reqTotal}}%"></span></div></td><td>{{.Requirements.Done}}/{{$reqTotal}} done</td></tr>
+</table>
+{{- if .Steps.Remaining}}
+<p>ETA: ~{{.Steps.Remaining}} remaining (finish around {{.Steps.Finish.Format "Jan 2 15:04 MST"}})</p>
+{{- end}}
+{{- if .Steps.Contributors}}
+<p>Contributors: {{range $i, $c := .Steps.Contributors}}{{if $i}}, {{end}}{{$c}}{{end}}</p>
+{{- end}}
+{{- if .Scan.Unmapped}}
+<h2>Unmapped dependencies</h2>
+<ul>{{range .Scan.Unmapped}}<li><code>{{.}}</code></li>{{end}}</ul>
+{{- end}}
+{{- if .Coverage.Categories}}
+<h2>Requirement categories</h2>
+<table>
+<tr><th>Category</th><th>Tag</th><th>Captured</th><th>Done</th></tr>
+{{- range .Coverage.Categories}}
+<tr><td><code>{{.Pattern}}</code></td><td>{{.Tag}}</td><td>{{.Captured}}</td><td>{{.Done}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Requirements.Open}}
+<h2>Pending requirements</h2>
+<ul>{{range .Requirements.Open}}<li><code>{{.}}</code></li>{{end}}</ul>
+{{- end}}
+</body>
+</html>
+`))
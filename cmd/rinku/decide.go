@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/cratesio"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/lock"
+	"github.com/stephan/rinku/internal/rinku"
+)
+
+type DecideCmd struct {
+	Path          string  `arg:"" type:"existingfile" help:"Path to go.mod file."`
+	Status        bool    `help:"Only report how many decisions remain."`
+	Offline       bool    `help:"Do not query crates.io; lock accepted crates with version \"*\"."`
+	Unsafe        bool    `help:"Include libraries with known vulnerabilities."`
+	Annotate      bool    `help:"Also record each decision as a // rinku: comment on its require line in go.mod."`
+	MinConfidence float64 `default:"0.8" placeholder:"N" help:"Also review a mapping with one Rust target whose confidence is below this, from 0 to 1."`
+}
+
+func (c *DecideCmd) Run(r *rinku.Rinku) error {
+	if c.MinConfidence < 0 || c.MinConfidence > 1 {
+		return fmt.Errorf("--min-confidence %v is not between 0 and 1", c.MinConfidence)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	result, err := gomod.Parse(c.Path)
+	if err != nil {
+		return fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	mapping := cargo.MapDependencies(result.DirectDependencies(), r, c.Unsafe)
+
+	l, err := lock.Load(cwd)
+	if err != nil {
+		return err
+	}
+	if l == nil {
+		l = lock.New()
+	}
+
+	pending, decided := pendingDecisions(mapping, l, r, c.MinConfidence)
+	if c.Status || len(pending) == 0 {
+		printDecisionStatus(os.Stdout, pending, decided)
+		return nil
+	}
+
+	d := &decider{
+		in:      bufio.NewScanner(os.Stdin),
+		out:     os.Stdout,
+		client:  cratesio.New(),
+		offline: c.Offline,
+	}
+	saved := false
+	for i, m := range pending {
+		fmt.Printf("\n[%d/%d] %s %s\n", i+1, len(pending), m.GoDep.Path, m.GoDep.Version)
+		entry, ok := d.decide(m)
+		if !ok {
+			break
+		}
+		l.Set(entry)
+		if err := l.Save(cwd); err != nil {
+			return err
+		}
+		saved = true
+		if c.Annotate {
+			if err := annotateDecision(c.Path, entry); err != nil {
+				return err
+			}
+		}
+		if entry.Decided() {
+			decided++
+		}
+	}
+
+	// One push for the session rather than one per decision
+	if saved {
+		autoSync(cwd)
+	}
+
+	pending, decided = pendingDecisions(mapping, l, r, c.MinConfidence)
+	fmt.Println()
+	printDecisionStatus(os.Stdout, pending, decided)
+	return nil
+}
+
+// pendingDecisions returns the mapped dependencies that have no accept or reject
+// decision in the lock and either more than one Rust target or a mapping confidence
+// below minConfidence, and the number already decided. A mapping without a recorded
+// confidence, such as one of .rinku/mappings.json, is not low.
+func pendingDecisions(mapping *cargo.GenerateResult, l *lock.Lock, r *rinku.Rinku, minConfidence float64) ([]cargo.MappedDependency, int) {
+	var pending []cargo.MappedDependency
+	decided := 0
+	for _, m := range mapping.Mapped {
+		e, ok := l.Get(m.GoDep.Path)
+		switch {
+		case ok && e.Decided():
+			decided++
+		case len(m.RustTargets) > 1 || (ok && e.Decision == lock.DecisionDeferred):
+			pending = append(pending, m)
+		case m.Origin == "" && lowConfidence(r.Confidence(cargo.ModulePathToGitHubURL(m.GoDep.Path), "rust"), minConfidence):
+			pending = append(pending, m)
+		}
+	}
+	return pending, decided
+}
+
+func lowConfidence(c, minConfidence float64) bool {
+	return c > 0 && c < minConfidence
+}
+
+func printDecisionStatus(w io.Writer, pending []cargo.MappedDependency, decided int) {
+	fmt.Fprintf(w, "Decisions: %d made, %d remaining\n", decided, len(pending))
+	for _, m := range pending {
+		fmt.Fprintf(w, "  [ ] %s (%s)\n", m.GoDep.Path, strings.Join(m.CrateNames, ", "))
+	}
+}
+
+// decider prompts for mapping decisions one dependency at a time.
+type decider struct {
+	in      *bufio.Scanner
+	out     io.Writer
+	client  *cratesio.Client
+	offline bool
+}
+
+// decide asks for a decision on m. It returns false if the user quits or input ends.
+func (d *decider) decide(m cargo.MappedDependency) (lock.Entry, bool) {
+	for i, name := range m.CrateNames {
+		fmt.Fprintf(d.out, "  %d) %s (%s)\n", i+1, name, m.RustTargets[i])
+	}
+
+	entry := lock.Entry{
+		GoModule:  m.GoDep.Path,
+		GoVersion: m.GoDep.Version,
+		Source:    lock.SourceManual,
+		LockedAt:  time.Now(),
+	}
+	for {
+		answer, ok := d.prompt(fmt.Sprintf("Accept [1-%d], (r)eject, (d)efer, (q)uit: ", len(m.CrateNames)))
+		if !ok {
+			return lock.Entry{}, false
+		}
+
+		choice := -1
+		switch answer {
+		case "q":
+			return lock.Entry{}, false
+		case "r":
+			entry.Decision = lock.DecisionRejected
+		case "d":
+			entry.Decision = lock.DecisionDeferred
+			choice = 0 // convert keeps using the first target until decided
+		default:
+			n, err := strconv.Atoi(answer)
+			if err != nil || n < 1 || n > len(m.CrateNames) {
+				fmt.Fprintln(d.out, "  Invalid choice.")
+				continue
+			}
+			entry.Decision = lock.DecisionAccepted
+			choice = n - 1
+		}
+
+		if choice >= 0 {
+			entry.Crate = m.CrateNames[choice]
+			entry.RustURL = m.RustTargets[choice]
+			entry.Version = resolveVersion(context.Background(), d.client, entry.Crate, d.offline)
+		}
+		entry.Reason, _ = d.prompt("Reason (optional): ")
+		return entry, true
+	}
+}
+
+func (d *decider) prompt(question string) (string, bool) {
+	fmt.Fprint(d.out, question)
+	if !d.in.Scan() {
+		fmt.Fprintln(d.out)
+		return "", false
+	}
+	return strings.TrimSpace(d.in.Text()), true
+}
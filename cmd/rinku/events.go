@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/render"
+)
+
+type EventsCmd struct {
+	Tail   EventsTailCmd   `cmd:"" help:"Print the latest events, and with --follow new ones as they are recorded."`
+	Export EventsExportCmd `cmd:"" help:"Write the event log, optionally filtered, as JSON lines or another output format."`
+}
+
+type EventsTailCmd struct {
+	Lines  int  `short:"n" default:"10" help:"Number of events to print (0 for all)."`
+	Follow bool `short:"f" help:"Keep printing events as they are recorded, until interrupted."`
+	JSON   bool `help:"Print the events as JSON lines."`
+}
+
+type EventsExportCmd struct {
+	Since  string   `help:"Only events at or after this time (RFC 3339 or YYYY-MM-DD)."`
+	Type   []string `help:"Only events of these types, e.g. step_completed,requirement_done."`
+	Format string   `default:"jsonl" help:"Output format: jsonl, or one of text, json, yaml, csv, markdown, html and porcelain."`
+	Output string   `short:"o" default:"-" help:"Output file (- for stdout)."`
+}
+
+// followInterval is how often tail --follow checks the event log for new lines.
+const followInterval = 500 * time.Millisecond
+
+func (c *EventsTailCmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	events, offset, err := progress.EventsSince(cwd, 0)
+	if err != nil {
+		return err
+	}
+	if c.Lines > 0 && len(events) > c.Lines {
+		events = events[len(events)-c.Lines:]
+	}
+	if err := c.print(events); err != nil {
+		return err
+	}
+	if !c.Follow {
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		events, offset, err = progress.EventsSince(cwd, offset)
+		if err != nil {
+			return err
+		}
+		if err := c.print(events); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *EventsTailCmd) print(events []progress.Event) error {
+	if c.JSON {
+		return writeJSONLines(os.Stdout, events)
+	}
+	for _, e := range events {
+		if _, err := fmt.Println(e.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *EventsExportCmd) Run() (err error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	if _, ok := render.Lookup(c.Format); !ok && c.Format != "jsonl" {
+		return fmt.Errorf("unknown output format %q (available: jsonl, %s)", c.Format, strings.Join(render.Formats(), ", "))
+	}
+	var since time.Time
+	if c.Since != "" {
+		if since, err = parseSince(c.Since); err != nil {
+			return err
+		}
+	}
+
+	events, err := progress.Events(cwd)
+	if err != nil {
+		return err
+	}
+	events = filterEvents(events, since, c.Type)
+
+	w := os.Stdout
+	if c.Output != "-" {
+		if err := validateOutputPath(c.Output); err != nil {
+			return err
+		}
+		w, err = os.Create(c.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() {
+			if cerr := w.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("failed to close output file: %w", cerr)
+			}
+		}()
+	}
+	if c.Format == "jsonl" {
+		return writeJSONLines(w, events)
+	}
+	return render.Render(w, c.Format, eventsDocument(events))
+}
+
+// parseSince parses an RFC 3339 time or a date, which is midnight local time.
+func parseSince(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q (want RFC 3339 or YYYY-MM-DD)", s)
+	}
+	return t, nil
+}
+
+// filterEvents returns the events at or after since (unless zero) with one of types
+// (unless empty).
+func filterEvents(events []progress.Event, since time.Time, types []string) []progress.Event {
+	var out []progress.Event
+	for _, e := range events {
+		if !since.IsZero() && e.Time.Before(since) {
+			continue
+		}
+		if len(types) > 0 && !slices.Contains(types, string(e.Type)) {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func writeJSONLines(w io.Writer, events []progress.Event) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eventsDocument returns the events as a table; json and yaml write the events as
+// recorded.
+func eventsDocument(events []progress.Event) *render.Document {
+	doc := &render.Document{
+		Command: "events",
+		Title:   "Events",
+		Columns: []string{"time", "type", "actor", "step", "requirement", "note", "data"},
+		Data:    events,
+	}
+	if events == nil {
+		doc.Data = []progress.Event{}
+	}
+	for _, e := range events {
+		data := ""
+		if len(e.Data) > 0 {
+			b, _ := json.Marshal(e.Data)
+			data = string(b)
+		}
+		doc.Rows = append(doc.Rows, []string{e.Time.UTC().Format(time.RFC3339), string(e.Type), e.Actor, e.Step, e.Requirement, e.Note, data})
+	}
+	return doc
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/stephan/rinku/internal/audit"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/gosrc"
+	"github.com/stephan/rinku/internal/requirements"
+)
+
+type ReqExtractCmd struct {
+	Path   string `arg:"" optional:"" type:"existingfile" help:"Path to go.mod file (default: go.mod in cwd)."`
+	Bin    string `help:"Binary name used as first path segment (default: name of the main package directory, or the module)."`
+	DryRun bool   `help:"Print the requirements without writing them."`
+	Force  bool   `help:"Overwrite requirements that already exist."`
+}
+
+func (c *ReqExtractCmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	goModPath := c.Path
+	if goModPath == "" {
+		goModPath = "go.mod"
+	}
+	result, err := gomod.Parse(goModPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	tree, err := gosrc.Parse(filepath.Dir(goModPath))
+	if err != nil {
+		return fmt.Errorf("parsing source files: %w", err)
+	}
+
+	surface := audit.ExtractSurface(tree)
+	fallback := path.Base(result.Module)
+	if c.Bin != "" {
+		fallback = c.Bin
+		surface.SetBinary(c.Bin)
+	}
+	reqs := surface.Requirements(fallback)
+	if len(reqs) == 0 {
+		fmt.Println("No commands, flags, routes, configuration keys or telemetry found.")
+		return nil
+	}
+
+	created, skipped, err := createRequirements(cwd, reqs, c.DryRun, c.Force)
+	if err != nil {
+		return err
+	}
+
+	verb := "Created"
+	if c.DryRun {
+		verb = "Would create"
+	}
+	tel := surface.Telemetry
+	fmt.Printf("\n%s %d requirements (%d commands, %d flags, %d routes, %d configuration keys, %d metrics, %d log fields, %d spans found",
+		verb, created, len(surface.Commands), len(surface.Flags), len(surface.Routes), len(surface.Config),
+		len(tel.Metrics), len(tel.Logs), len(tel.Spans))
+	if skipped > 0 {
+		fmt.Printf(", %d already captured", skipped)
+	}
+	fmt.Println(")")
+	if created > 0 && !c.DryRun {
+		autoSync(cwd)
+		notifyCoverage(cwd)
+	}
+	return nil
+}
+
+// createRequirements writes generated requirements, printing each one. Existing
+// requirements are skipped unless force is set; dryRun only prints.
+func createRequirements(dir string, reqs []audit.SurfaceRequirement, dryRun, force bool) (created, skipped int, err error) {
+	for _, req := range reqs {
+		if !force {
+			if existing, _ := requirements.Get(dir, req.Path); existing != nil {
+				skipped++
+				continue
+			}
+		}
+		if !dryRun {
+			if err := requirements.Set(dir, req.Path, req.Content); err != nil {
+				return created, skipped, fmt.Errorf("setting requirement %s: %w", req.Path, err)
+			}
+		}
+		fmt.Printf("+ %s\n", req.Path)
+		created++
+	}
+	return created, skipped, nil
+}
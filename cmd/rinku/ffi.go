@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/natefinch/atomic"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/gosrc"
+	"github.com/stephan/rinku/internal/scaffold"
+)
+
+type FFICmd struct {
+	Path     string `arg:"" type:"existingfile" help:"Path to go.mod file."`
+	Package  string `arg:"" help:"Package to bridge, as import path or directory relative to go.mod."`
+	CrateDir string `help:"Directory for the Rust staticlib crate, relative to go.mod (default: ffi/<package>)."`
+	Force    bool   `help:"Overwrite existing files."`
+}
+
+func (c *FFICmd) Run() error {
+	if c.CrateDir != "" {
+		if err := validateOutputPath(c.CrateDir); err != nil {
+			return err
+		}
+	}
+
+	pkg, root, err := loadScaffoldPackage(c.Path, c.Package)
+	if err != nil {
+		return err
+	}
+	ffi, err := scaffold.GenerateFFI(pkg, scaffold.FFIOptions{CrateDir: filepath.ToSlash(c.CrateDir)})
+	if err != nil {
+		return err
+	}
+	if err := writeScaffold(root, ffi.Files, c.Force); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nBridged %d functions from %s", len(ffi.Bridged), pkg.ImportPath)
+	if len(ffi.Skipped) > 0 {
+		fmt.Printf(", skipped %d:\n", len(ffi.Skipped))
+		for _, s := range ffi.Skipped {
+			fmt.Printf("  %s: %s\n", s.Func, s.Reason)
+		}
+	} else {
+		fmt.Println()
+	}
+	fmt.Printf("\nPort the stubs in src/lib.rs, build the crate with `cargo build --release`, then build\n")
+	fmt.Printf("the Go program with `-tags rust` and route calls to the rust<Name> wrappers one at a time.\n")
+	return nil
+}
+
+// loadScaffoldPackage parses the module next to goModPath and loads the exported API of
+// one of its packages. It also returns the module root directory.
+func loadScaffoldPackage(goModPath, pkgPath string) (*scaffold.Package, string, error) {
+	result, err := gomod.Parse(goModPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	root := filepath.Dir(goModPath)
+	tree, err := gosrc.Parse(root)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing source files: %w", err)
+	}
+	pkg, err := scaffold.Load(tree, result.Module, filepath.ToSlash(pkgPath))
+	if err != nil {
+		return nil, "", err
+	}
+	return pkg, root, nil
+}
+
+// writeScaffold writes generated files below root. Existing files are kept unless force
+// is set, so hand-ported code is never overwritten by accident.
+func writeScaffold(root string, files []scaffold.File, force bool) error {
+	for _, f := range files {
+		path := filepath.Join(root, filepath.FromSlash(f.Path))
+		if !force {
+			if _, err := os.Stat(path); err == nil {
+				fmt.Printf("  exists   %s (use --force to overwrite)\n", f.Path)
+				continue
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("checking %s: %w", f.Path, err)
+			}
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			return fmt.Errorf("creating directory for %s: %w", f.Path, err)
+		}
+		if err := atomic.WriteFile(path, bytes.NewReader(f.Content)); err != nil {
+			return fmt.Errorf("writing %s: %w", f.Path, err)
+		}
+		fmt.Printf("  created  %s\n", f.Path)
+	}
+	return nil
+}
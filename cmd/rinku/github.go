@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/stephan/rinku/internal/config"
+	"github.com/stephan/rinku/internal/github"
+)
+
+// githubClient returns a GitHub client with the token from GITHUB_TOKEN or, failing
+// that, the [github] table of .rinku.toml, and where the token came from ("" if none).
+func githubClient() (*github.Client, string) {
+	client := github.New()
+	if token := os.Getenv(github.TokenEnv); token != "" {
+		client.Token = token
+		return client, github.TokenEnv
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return client, ""
+	}
+	// configureHTTP already warned about an invalid .rinku.toml
+	if cfg, err := config.Load(cwd); err == nil && cfg.GitHub.Token != "" {
+		client.Token = cfg.GitHub.Token
+		return client, config.FileName
+	}
+	return client, ""
+}
+
+// reportGitHubAccess tells the user what is fetched from GitHub, whether the requests
+// are authenticated and whether the API rate limit covers the apiRequests they need.
+func reportGitHubAccess(ctx context.Context, w io.Writer, client *github.Client, source string, fetched []string, apiRequests int) {
+	fmt.Fprintf(w, "Fetching from GitHub: %s\n", strings.Join(fetched, ", "))
+	if source != "" {
+		fmt.Fprintf(w, "Authenticated with the token from %s", source)
+	} else {
+		fmt.Fprintf(w, "Unauthenticated: set %s or [github] token in %s for a higher API rate limit", github.TokenEnv, config.FileName)
+	}
+	rate, err := client.RateLimit(ctx)
+	if err != nil {
+		fmt.Fprintln(w)
+		return
+	}
+	fmt.Fprintf(w, " (%d of %d API requests per hour left)\n", rate.Remaining, rate.Limit)
+	if apiRequests > rate.Remaining {
+		fmt.Fprintf(w, "Warning: this needs about %d API requests; the ones over the limit fail until it resets at %s\n",
+			apiRequests, rate.Reset.Local().Format("15:04"))
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/gosrc"
+	"github.com/stephan/rinku/internal/graph"
+	"github.com/stephan/rinku/render"
+)
+
+type GraphCmd struct {
+	Path   string `arg:"" type:"existingfile" help:"Path to go.mod file."`
+	Format string `enum:"dot,mermaid,json" default:"dot" help:"Output format: dot, mermaid or json."`
+	Output string `short:"o" default:"-" help:"Output file (- for stdout)."`
+}
+
+func (c *GraphCmd) Run() (err error) {
+	result, err := gomod.Parse(c.Path)
+	if err != nil {
+		return fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	scan, err := gosrc.ScanImports(filepath.Dir(c.Path))
+	if err != nil {
+		return fmt.Errorf("scanning source files: %w", err)
+	}
+	modules := make([]string, 0, len(result.Dependencies))
+	for _, dep := range result.Dependencies {
+		modules = append(modules, dep.Path)
+	}
+	g := graph.Build(result.Module, scan.Files, modules)
+	if len(g.Packages) == 0 {
+		return fmt.Errorf("no Go packages found next to %s", c.Path)
+	}
+
+	w := os.Stdout
+	if c.Output != "-" {
+		if err := validateOutputPath(c.Output); err != nil {
+			return err
+		}
+		w, err = os.Create(c.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() {
+			if cerr := w.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("failed to close output file: %w", cerr)
+			}
+		}()
+	}
+
+	switch c.Format {
+	case "mermaid":
+		err = g.Mermaid(w)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(render.Versioned(render.APIVersion, "graph", g))
+	default:
+		err = g.DOT(w)
+	}
+	if err != nil {
+		return fmt.Errorf("writing graph: %w", err)
+	}
+	if c.Output != "-" {
+		fmt.Fprintf(os.Stderr, "Wrote %d packages to %s\n", len(g.Packages), c.Output)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/stephan/rinku/internal/lock"
+	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/internal/requirements"
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/render"
+)
+
+type GrepCmd struct {
+	Pattern    string   `arg:"" help:"Regular expression (Go RE2 syntax) to search for."`
+	IgnoreCase bool     `short:"i" help:"Match case-insensitively."`
+	Type       []string `help:"Only results of these types: requirement, note, mapping or category."`
+	Lang       string   `default:"rust" help:"Target language of the database mappings and categories searched."`
+	Color      string   `default:"auto" enum:"auto,always,never" help:"Highlight the results: auto (when stdout is a terminal and NO_COLOR is unset), always or never."`
+	Format     string   `default:"text" help:"Output format: text, json, yaml, csv, markdown, html, sarif or porcelain."`
+}
+
+// Result types of rinku grep, in the order they are searched.
+const (
+	grepRequirement = "requirement" // content of a requirement
+	grepNote        = "note"        // note of a migration step
+	grepMapping     = "mapping"     // decision reason in the lock file, or reason of a required crate
+	grepCategory    = "category"    // category name of database mappings
+)
+
+var grepTypes = []string{grepRequirement, grepNote, grepMapping, grepCategory}
+
+// grepMatch is a result of rinku grep: where it was found and the matching text.
+type grepMatch struct {
+	Type  string
+	Where string
+	Text  string
+	// named is set if the pattern matched Where, the name of a category, rather than
+	// Text.
+	named bool
+}
+
+// ANSI escapes of the colorized text output.
+const (
+	ansiReset = "\x1b[0m"
+	ansiMatch = "\x1b[1;31m"
+)
+
+var grepColors = map[string]string{
+	grepRequirement: "\x1b[36m",
+	grepNote:        "\x1b[32m",
+	grepMapping:     "\x1b[33m",
+	grepCategory:    "\x1b[35m",
+}
+
+func (c *GrepCmd) Run(r *rinku.Rinku) error {
+	for _, t := range c.Type {
+		if !slices.Contains(grepTypes, t) {
+			return fmt.Errorf("unknown type %q (available: %s)", t, strings.Join(grepTypes, ", "))
+		}
+	}
+	expr := c.Pattern
+	if c.IgnoreCase {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	var matches []grepMatch
+	for _, t := range grepTypes {
+		if len(c.Type) > 0 && !slices.Contains(c.Type, t) {
+			continue
+		}
+		var found []grepMatch
+		switch t {
+		case grepRequirement:
+			found, err = grepRequirements(cwd, re)
+		case grepNote:
+			found, err = grepNotes(cwd, re)
+		case grepMapping:
+			found, err = grepMappings(r, cwd, c.Lang, re)
+		case grepCategory:
+			found = grepCategories(r, c.Lang, re)
+		}
+		if err != nil {
+			return err
+		}
+		matches = append(matches, found...)
+	}
+	return render.Render(os.Stdout, c.Format, grepDocument(c.Pattern, matches, c.colorize(), re))
+}
+
+// colorize reports whether the text output is highlighted.
+func (c *GrepCmd) colorize() bool {
+	switch c.Color {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	fi, err := os.Stdout.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// grepLines returns a match for every line of text that re matches.
+func grepLines(typ, where, text string, re *regexp.Regexp) []grepMatch {
+	var matches []grepMatch
+	for line := range strings.Lines(text) {
+		line = strings.TrimRight(line, "\r\n")
+		if re.MatchString(line) {
+			matches = append(matches, grepMatch{Type: typ, Where: where, Text: line})
+		}
+	}
+	return matches
+}
+
+func grepRequirements(cwd string, re *regexp.Regexp) ([]grepMatch, error) {
+	reqs, err := requirements.GetAll(cwd, "")
+	if err != nil {
+		return nil, err
+	}
+	var matches []grepMatch
+	for _, req := range reqs {
+		matches = append(matches, grepLines(grepRequirement, req.Path, req.Content, re)...)
+	}
+	return matches, nil
+}
+
+func grepNotes(cwd string, re *regexp.Regexp) ([]grepMatch, error) {
+	m, err := progress.Load(cwd)
+	if err != nil {
+		return nil, fmt.Errorf("loading progress: %w", err)
+	}
+	if m == nil {
+		return nil, nil
+	}
+	var matches []grepMatch
+	for _, id := range m.StepOrder {
+		if step := m.Steps[id]; step != nil {
+			matches = append(matches, grepLines(grepNote, "step "+id+byline(step.NotedBy), step.Notes, re)...)
+		}
+	}
+	return matches, nil
+}
+
+// grepMappings searches the reasons of the decisions in the lock file, then those the
+// database gives for the crates a mapping requires.
+func grepMappings(r *rinku.Rinku, cwd, lang string, re *regexp.Regexp) ([]grepMatch, error) {
+	l, err := lock.Load(cwd)
+	if err != nil {
+		return nil, err
+	}
+	var matches []grepMatch
+	if l != nil {
+		for _, mod := range slices.Sorted(maps.Keys(l.Entries)) {
+			if e := l.Entries[mod]; e.Reason != "" {
+				where := mod
+				if e.Decision != "" {
+					where += " (" + string(e.Decision) + ")"
+				}
+				matches = append(matches, grepLines(grepMapping, where, e.Reason, re)...)
+			}
+		}
+	}
+	for _, source := range r.Sources(lang) {
+		for _, dep := range r.RequiredDeps(source, lang) {
+			matches = append(matches, grepLines(grepMapping, source+" requires "+dep.Crate, dep.Reason, re)...)
+		}
+	}
+	return matches, nil
+}
+
+// grepCategories returns a match per database category whose name re matches, with
+// the libraries in it.
+func grepCategories(r *rinku.Rinku, lang string, re *regexp.Regexp) []grepMatch {
+	sources := make(map[string][]string)
+	for _, source := range r.Sources(lang) {
+		if category := r.Category(source, lang); category != "" && re.MatchString(category) {
+			sources[category] = append(sources[category], source)
+		}
+	}
+	var matches []grepMatch
+	for _, category := range slices.Sorted(maps.Keys(sources)) {
+		matches = append(matches, grepMatch{Type: grepCategory, Where: category, Text: strings.Join(sources[category], ", "), named: true})
+	}
+	return matches
+}
+
+func grepDocument(pattern string, matches []grepMatch, color bool, re *regexp.Regexp) *render.Document {
+	doc := &render.Document{
+		Command: "grep",
+		Title:   "Matches for " + pattern,
+		Fields:  []render.Field{{Name: "Matches", Value: fmt.Sprint(len(matches))}},
+		Columns: []string{"type", "where", "text"},
+	}
+	for _, m := range matches {
+		doc.Rows = append(doc.Rows, []string{m.Type, m.Where, m.Text})
+	}
+	doc.Text = func(w io.Writer) error {
+		if len(matches) == 0 {
+			_, err := fmt.Fprintf(w, "No matches for %s\n", pattern)
+			return err
+		}
+		for _, m := range matches {
+			typ, where, text := m.Type, m.Where, m.Text
+			if color {
+				typ = grepColors[m.Type] + typ + ansiReset
+				if m.named {
+					where = highlight(re, where)
+				} else {
+					text = highlight(re, text)
+				}
+			}
+			pad := strings.Repeat(" ", len("requirement")-len(m.Type))
+			if _, err := fmt.Fprintf(w, "%s%s %s: %s\n", typ, pad, where, text); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return doc
+}
+
+// highlight marks the matches of re in s.
+func highlight(re *regexp.Regexp, s string) string {
+	return re.ReplaceAllStringFunc(s, func(match string) string {
+		return ansiMatch + match + ansiReset
+	})
+}
@@ -1,698 +1,1465 @@
 // Code generated by cmd/generate. DO NOT EDIT.
 package main
 
-var index = map[string][]string{
-	"go:github.com/auth0/node-jsonwebtoken": {"https://github.com/golang-jwt/jwt"},
-	"go:github.com/axios/axios": {"https://github.com/go-resty/resty"},
-	"go:github.com/brianc/node-postgres": {"https://github.com/jackc/pgx"},
-	"go:github.com/colinhacks/zod": {"https://github.com/go-playground/validator"},
-	"go:github.com/expressjs/express": {"https://github.com/labstack/echo"},
-	"go:github.com/fastify/fastify": {"https://github.com/gofiber/fiber"},
-	"go:github.com/jaredhanson/passport": {"https://github.com/markbates/goth"},
-	"go:github.com/jestjs/jest": {"https://github.com/stretchr/testify"},
-	"go:github.com/knex/knex": {"https://github.com/Masterminds/squirrel"},
-	"go:github.com/lodash/lodash": {"https://github.com/samber/lo"},
-	"go:github.com/lorenwest/node-config": {"https://github.com/knadh/koanf"},
-	"go:github.com/moment/moment": {"https://github.com/dromara/carbon"},
-	"go:github.com/motdotla/dotenv": {"https://github.com/joho/godotenv"},
-	"go:github.com/nestjs/nest": {"https://github.com/uber-go/fx"},
-	"go:github.com/pinojs/pino": {"https://github.com/rs/zerolog"},
-	"go:github.com/prisma/prisma": {"https://github.com/ent/ent"},
-	"go:github.com/socketio/socket.io": {"https://github.com/olahol/melody"},
-	"go:github.com/taskforcesh/bullmq": {"https://github.com/hibiken/asynq"},
-	"go:github.com/typeorm/typeorm": {"https://github.com/go-gorm/gorm"},
-	"go:github.com/winstonjs/winston": {"https://github.com/golang/go"},
-	"rust:github.com/a-h/templ": {"https://github.com/djc/askama"},
-	"rust:github.com/alecthomas/chroma": {"https://github.com/trishume/syntect"},
-	"rust:github.com/alecthomas/kong": {"https://github.com/clap-rs/clap"},
-	"rust:github.com/atotto/clipboard": {"https://github.com/1Password/arboard"},
-	"rust:github.com/aws/aws-sdk-go-v2": {"https://github.com/awslabs/aws-sdk-rust"},
-	"rust:github.com/aymanbagabas/go-udiff": {"https://github.com/pascalkuthe/imara-diff"},
-	"rust:github.com/azure/azure-sdk-for-go": {"https://github.com/Azure/azure-sdk-for-rust"},
-	"rust:github.com/beorn7/perks": {"https://github.com/prometheus/client_rust"},
-	"rust:github.com/bmatcuk/doublestar": {"https://github.com/BurntSushi/globset"},
-	"rust:github.com/burntsushi/toml": {"https://github.com/toml-rs/toml"},
-	"rust:github.com/bytedance/sonic": {"https://github.com/cloudwego/sonic-rs"},
-	"rust:github.com/cespare/xxhash": {"https://github.com/shepmaster/twox-hash"},
-	"rust:github.com/charlievieth/fastwalk": {"https://github.com/BurntSushi/walkdir"},
-	"rust:github.com/charmbracelet/bubbles": {"https://github.com/ratatui/ratatui"},
-	"rust:github.com/charmbracelet/bubbletea": {"https://github.com/ratatui/ratatui"},
-	"rust:github.com/charmbracelet/colorprofile": {"https://github.com/crossterm-rs/crossterm"},
-	"rust:github.com/charmbracelet/glamour": {"https://github.com/kivikakk/comrak"},
-	"rust:github.com/charmbracelet/lipgloss": {"https://github.com/ratatui/ratatui"},
-	"rust:github.com/charmbracelet/log": {"https://github.com/tokio-rs/tracing"},
-	"rust:github.com/charmbracelet/x": {"https://github.com/crossterm-rs/crossterm"},
-	"rust:github.com/containerd/containerd": {"https://github.com/containerd/rust-extensions"},
-	"rust:github.com/coreos/go-systemd": {"https://github.com/lucab/libsystemd-rs"},
-	"rust:github.com/darccio/mergo": {"https://github.com/yanganto/struct-patch"},
-	"rust:github.com/denisbrodbeck/machineid": {"https://github.com/Hanaasagi/machine-uid"},
-	"rust:github.com/disintegration/gift": {"https://github.com/image-rs/imageproc"},
-	"rust:github.com/dlclark/regexp2": {"https://github.com/fancy-regex/fancy-regex"},
-	"rust:github.com/docker/docker": {"https://github.com/fussybeaver/bollard"},
-	"rust:github.com/docker/go-units": {"https://github.com/iliekturtles/uom"},
-	"rust:github.com/dustin/go-humanize": {"https://github.com/chronotope/humantime"},
-	"rust:github.com/etcd-io/bbolt": {"https://github.com/cberner/redb"},
-	"rust:github.com/etcd-io/etcd": {"https://github.com/etcdv3/etcd-client"},
-	"rust:github.com/fatih/color": {"https://github.com/ogham/rust-ansi-term"},
-	"rust:github.com/fsnotify/fsnotify": {"https://github.com/notify-rs/notify"},
-	"rust:github.com/gin-gonic/gin": {"https://github.com/tokio-rs/axum"},
-	"rust:github.com/go-chi/chi": {"https://github.com/tokio-rs/axum"},
-	"rust:github.com/go-gorm/gorm": {"https://github.com/SeaQL/sea-orm"},
-	"rust:github.com/go-ini/ini": {"https://github.com/zonyitoo/rust-ini"},
-	"rust:github.com/go-logr/logr": {"https://github.com/tokio-rs/tracing"},
-	"rust:github.com/go-logr/stdr": {"https://github.com/tokio-rs/tracing"},
-	"rust:github.com/go-openapi/jsonpointer": {"https://github.com/chanced/jsonptr"},
-	"rust:github.com/go-openapi/swag": {"https://github.com/juhaku/utoipa"},
-	"rust:github.com/go-redis/redis": {"https://github.com/redis-rs/redis-rs"},
-	"rust:github.com/go-yaml/yaml": {"https://github.com/dtolnay/serde-yaml"},
-	"rust:github.com/goccy/go-json": {"https://github.com/cloudwego/sonic-rs"},
-	"rust:github.com/goccy/go-yaml": {"https://github.com/dtolnay/serde-yaml"},
-	"rust:github.com/golang-jwt/jwt": {"https://github.com/keats/jsonwebtoken"},
-	"rust:github.com/golang/crypto": {"https://github.com/RustCrypto/traits"},
-	"rust:github.com/golang/mock": {"https://github.com/asomers/mockall"},
-	"rust:github.com/golang/oauth2": {"https://github.com/ramosbugs/oauth2-rs"},
-	"rust:github.com/golang/snappy": {"https://github.com/BurntSushi/rust-snappy"},
-	"rust:github.com/golang/sys": {"https://github.com/rust-lang/libc"},
-	"rust:github.com/golang/term": {"https://github.com/crossterm-rs/crossterm"},
-	"rust:github.com/golang/text": {"https://github.com/unicode-rs/unicode-normalization"},
-	"rust:github.com/golang/time": {"https://github.com/chronotope/chrono"},
-	"rust:github.com/google/pprof": {"https://github.com/tikv/pprof-rs"},
-	"rust:github.com/google/uuid": {"https://github.com/uuid-rs/uuid"},
-	"rust:github.com/googleapis/gax-go": {"https://github.com/googleapis/google-cloud-rust"},
-	"rust:github.com/googleapis/go-genproto": {"https://github.com/hyperium/tonic"},
-	"rust:github.com/googleapis/google-api-go-client": {"https://github.com/googleapis/google-cloud-rust"},
-	"rust:github.com/googleapis/google-cloud-go": {"https://github.com/googleapis/google-cloud-rust"},
-	"rust:github.com/gorilla/css": {"https://github.com/servo/rust-cssparser"},
-	"rust:github.com/gorilla/mux": {"https://github.com/tokio-rs/axum"},
-	"rust:github.com/gorilla/websocket": {"https://github.com/snapview/tokio-tungstenite"},
-	"rust:github.com/grpc-ecosystem/grpc-gateway": {"https://github.com/hyperium/tonic"},
-	"rust:github.com/grpc/grpc-go": {"https://github.com/hyperium/tonic"},
-	"rust:github.com/hashicorp/golang-lru": {"https://github.com/jeromefroe/lru-rs"},
-	"rust:github.com/invopop/jsonschema": {"https://github.com/GREsau/schemars"},
-	"rust:github.com/joho/godotenv": {"https://github.com/allan2/dotenvy"},
-	"rust:github.com/josharian/intern": {"https://github.com/droundy/internment"},
-	"rust:github.com/json-iterator/go": {"https://github.com/serde-rs/json"},
-	"rust:github.com/klauspost/compress": {"https://github.com/gyscos/zstd-rs"},
-	"rust:github.com/klauspost/cpuid": {"https://github.com/gz/rust-cpuid"},
-	"rust:github.com/kolesa-team/go-webp": {"https://github.com/image-rs/image-webp"},
-	"rust:github.com/kubernetes-sigs/yaml": {"https://github.com/dtolnay/serde-yaml"},
-	"rust:github.com/kubernetes/api": {"https://github.com/kube-rs/kube"},
-	"rust:github.com/kubernetes/apimachinery": {"https://github.com/kube-rs/kube"},
-	"rust:github.com/kubernetes/client-go": {"https://github.com/kube-rs/kube"},
-	"rust:github.com/labstack/echo": {"https://github.com/tokio-rs/axum"},
-	"rust:github.com/lucasb-eyer/go-colorful": {"https://github.com/Ogeon/palette"},
-	"rust:github.com/mailru/easyjson": {"https://github.com/serde-rs/json"},
-	"rust:github.com/mattn/go-colorable": {"https://github.com/BurntSushi/termcolor"},
-	"rust:github.com/mattn/go-isatty": {"https://github.com/softprops/atty"},
-	"rust:github.com/mattn/go-runewidth": {"https://github.com/unicode-rs/unicode-width"},
-	"rust:github.com/microsoft/go-winio": {"https://github.com/microsoft/windows-rs"},
-	"rust:github.com/miekg/dns": {"https://github.com/hickory-dns/hickory-dns"},
-	"rust:github.com/mitchellh/mapstructure": {"https://github.com/serde-rs/serde"},
-	"rust:github.com/muesli/termenv": {"https://github.com/crossterm-rs/crossterm"},
-	"rust:github.com/munnerz/goautoneg": {"https://github.com/hyperium/headers"},
-	"rust:github.com/mvdan/sh": {"https://github.com/nushell/nushell/tree/main/crates/nu-parser"},
-	"rust:github.com/natefinch/atomic": {"https://github.com/untitaker/atomicwrites-rs"},
-	"rust:github.com/natefinch/lumberjack": {"https://github.com/tokio-rs/tracing/tree/master/tracing-appender"},
-	"rust:github.com/nxadm/tail": {"https://github.com/jmagnuson/linemux"},
-	"rust:github.com/olekukonko/tablewriter": {"https://github.com/phsym/prettytable-rs"},
-	"rust:github.com/ollama/ollama": {"https://github.com/pepperoni21/ollama-rs"},
-	"rust:github.com/open-telemetry/opentelemetry-go": {"https://github.com/open-telemetry/opentelemetry-rust"},
-	"rust:github.com/openai/openai-go": {"https://github.com/64bit/async-openai"},
-	"rust:github.com/opencontainers/go-digest": {"https://github.com/RustCrypto/traits"},
-	"rust:github.com/opencontainers/image-spec": {"https://github.com/containers/oci-spec-rs"},
-	"rust:github.com/pelletier/go-toml": {"https://github.com/toml-rs/toml"},
-	"rust:github.com/pierrec/lz4": {"https://github.com/PSeitz/lz4_flex"},
-	"rust:github.com/pires/go-proxyproto": {"https://github.com/misalcedo/ppp"},
-	"rust:github.com/pkg/browser": {"https://github.com/amodm/webbrowser-rs"},
-	"rust:github.com/pkg/errors": {"https://github.com/dtolnay/anyhow"},
-	"rust:github.com/pmezard/go-difflib": {"https://github.com/mitsuhiko/similar"},
-	"rust:github.com/pressly/goose": {"https://github.com/rust-db/refinery"},
-	"rust:github.com/prometheus/client_golang": {"https://github.com/prometheus/client_rust"},
-	"rust:github.com/prometheus/client_model": {"https://github.com/prometheus/client_rust"},
-	"rust:github.com/prometheus/common": {"https://github.com/prometheus/client_rust"},
-	"rust:github.com/prometheus/procfs": {"https://github.com/eminence/procfs"},
-	"rust:github.com/puerkitobio/goquery": {"https://github.com/rust-scraper/scraper"},
-	"rust:github.com/rivo/uniseg": {"https://github.com/unicode-rs/unicode-segmentation"},
-	"rust:github.com/rs/zerolog": {"https://github.com/tokio-rs/tracing"},
-	"rust:github.com/russross/blackfriday": {"https://github.com/pulldown-cmark/pulldown-cmark"},
-	"rust:github.com/sahilm/fuzzy": {"https://github.com/lotabout/fuzzy-matcher"},
-	"rust:github.com/samber/lo": {"https://github.com/rust-itertools/itertools"},
-	"rust:github.com/sashabaranov/go-openai": {"https://github.com/64bit/async-openai"},
-	"rust:github.com/sergi/go-diff": {"https://github.com/pascalkuthe/imara-diff"},
-	"rust:github.com/sirupsen/logrus": {"https://github.com/tokio-rs/tracing"},
-	"rust:github.com/sourcegraph/jsonrpc2": {"https://github.com/paritytech/jsonrpsee"},
-	"rust:github.com/spf13/afero": {"https://github.com/manuel-woelker/rust-vfs"},
-	"rust:github.com/spf13/cobra": {"https://github.com/clap-rs/clap"},
-	"rust:github.com/spf13/pflag": {"https://github.com/clap-rs/clap"},
-	"rust:github.com/spf13/viper": {"https://github.com/rust-cli/config-rs"},
-	"rust:github.com/srwiley/oksvg": {"https://github.com/linebender/resvg"},
-	"rust:github.com/srwiley/rasterx": {"https://github.com/linebender/resvg"},
-	"rust:github.com/tdewolff/minify": {"https://github.com/wilsonzlin/minify-html", "https://github.com/GuillaumeGomez/minifier-rs"},
-	"rust:github.com/tidwall/gjson": {"https://github.com/serde-rs/json"},
-	"rust:github.com/tidwall/sjson": {"https://github.com/serde-rs/json"},
-	"rust:github.com/tmc/langchaingo": {"https://github.com/Abraxas-365/langchain-rust"},
-	"rust:github.com/uber-go/multierr": {"https://github.com/dtolnay/anyhow"},
-	"rust:github.com/uber-go/zap": {"https://github.com/tokio-rs/tracing"},
-	"rust:github.com/vishvananda/netlink": {"https://github.com/rust-netlink/netlink"},
-	"rust:github.com/yuin/goldmark": {"https://github.com/pulldown-cmark/pulldown-cmark"},
-	"rust:github.com/zeebo/xxh3": {"https://github.com/shepmaster/twox-hash"},
-}
-
-var indexAll = map[string][]string{
-	"go:github.com/auth0/node-jsonwebtoken": {"https://github.com/golang-jwt/jwt"},
-	"go:github.com/axios/axios": {"https://github.com/go-resty/resty"},
-	"go:github.com/brianc/node-postgres": {"https://github.com/jackc/pgx"},
-	"go:github.com/colinhacks/zod": {"https://github.com/go-playground/validator"},
-	"go:github.com/expressjs/express": {"https://github.com/labstack/echo"},
-	"go:github.com/fastify/fastify": {"https://github.com/gofiber/fiber"},
-	"go:github.com/jaredhanson/passport": {"https://github.com/markbates/goth"},
-	"go:github.com/jestjs/jest": {"https://github.com/stretchr/testify"},
-	"go:github.com/knex/knex": {"https://github.com/Masterminds/squirrel"},
-	"go:github.com/lodash/lodash": {"https://github.com/samber/lo"},
-	"go:github.com/lorenwest/node-config": {"https://github.com/knadh/koanf"},
-	"go:github.com/moment/moment": {"https://github.com/dromara/carbon"},
-	"go:github.com/motdotla/dotenv": {"https://github.com/joho/godotenv"},
-	"go:github.com/nestjs/nest": {"https://github.com/uber-go/fx"},
-	"go:github.com/pinojs/pino": {"https://github.com/rs/zerolog"},
-	"go:github.com/prisma/prisma": {"https://github.com/ent/ent"},
-	"go:github.com/socketio/socket.io": {"https://github.com/olahol/melody"},
-	"go:github.com/taskforcesh/bullmq": {"https://github.com/hibiken/asynq"},
-	"go:github.com/typeorm/typeorm": {"https://github.com/go-gorm/gorm"},
-	"go:github.com/winstonjs/winston": {"https://github.com/golang/go"},
-	"rust:github.com/a-h/templ": {"https://github.com/djc/askama"},
-	"rust:github.com/alecthomas/chroma": {"https://github.com/trishume/syntect"},
-	"rust:github.com/alecthomas/kong": {"https://github.com/clap-rs/clap"},
-	"rust:github.com/atotto/clipboard": {"https://github.com/1Password/arboard"},
-	"rust:github.com/aws/aws-sdk-go-v2": {"https://github.com/awslabs/aws-sdk-rust"},
-	"rust:github.com/aymanbagabas/go-udiff": {"https://github.com/pascalkuthe/imara-diff"},
-	"rust:github.com/azure/azure-sdk-for-go": {"https://github.com/Azure/azure-sdk-for-rust"},
-	"rust:github.com/beorn7/perks": {"https://github.com/prometheus/client_rust"},
-	"rust:github.com/bmatcuk/doublestar": {"https://github.com/BurntSushi/globset"},
-	"rust:github.com/burntsushi/toml": {"https://github.com/toml-rs/toml"},
-	"rust:github.com/bytedance/sonic": {"https://github.com/cloudwego/sonic-rs"},
-	"rust:github.com/cespare/xxhash": {"https://github.com/shepmaster/twox-hash"},
-	"rust:github.com/charlievieth/fastwalk": {"https://github.com/BurntSushi/walkdir"},
-	"rust:github.com/charmbracelet/bubbles": {"https://github.com/ratatui/ratatui"},
-	"rust:github.com/charmbracelet/bubbletea": {"https://github.com/ratatui/ratatui"},
-	"rust:github.com/charmbracelet/colorprofile": {"https://github.com/crossterm-rs/crossterm"},
-	"rust:github.com/charmbracelet/glamour": {"https://github.com/kivikakk/comrak"},
-	"rust:github.com/charmbracelet/lipgloss": {"https://github.com/ratatui/ratatui"},
-	"rust:github.com/charmbracelet/log": {"https://github.com/tokio-rs/tracing"},
-	"rust:github.com/charmbracelet/x": {"https://github.com/crossterm-rs/crossterm"},
-	"rust:github.com/cloudflare/cfssl": {"https://github.com/rustls/rustls"},
-	"rust:github.com/containerd/containerd": {"https://github.com/containerd/rust-extensions"},
-	"rust:github.com/coreos/go-systemd": {"https://github.com/lucab/libsystemd-rs"},
-	"rust:github.com/darccio/mergo": {"https://github.com/yanganto/struct-patch"},
-	"rust:github.com/denisbrodbeck/machineid": {"https://github.com/Hanaasagi/machine-uid"},
-	"rust:github.com/disintegration/gift": {"https://github.com/image-rs/imageproc"},
-	"rust:github.com/disintegration/imageorient": {"https://github.com/image-rs/image"},
-	"rust:github.com/dlclark/regexp2": {"https://github.com/fancy-regex/fancy-regex"},
-	"rust:github.com/docker/docker": {"https://github.com/fussybeaver/bollard"},
-	"rust:github.com/docker/go-units": {"https://github.com/iliekturtles/uom"},
-	"rust:github.com/dustin/go-humanize": {"https://github.com/chronotope/humantime"},
-	"rust:github.com/etcd-io/bbolt": {"https://github.com/cberner/redb"},
-	"rust:github.com/etcd-io/etcd": {"https://github.com/etcdv3/etcd-client"},
-	"rust:github.com/fatih/color": {"https://github.com/ogham/rust-ansi-term"},
-	"rust:github.com/felixge/httpsnoop": {"https://github.com/tower-rs/tower-http"},
-	"rust:github.com/fsnotify/fsnotify": {"https://github.com/notify-rs/notify"},
-	"rust:github.com/gin-gonic/gin": {"https://github.com/tokio-rs/axum"},
-	"rust:github.com/go-chi/chi": {"https://github.com/tokio-rs/axum"},
-	"rust:github.com/go-gorm/gorm": {"https://github.com/SeaQL/sea-orm"},
-	"rust:github.com/go-ini/ini": {"https://github.com/zonyitoo/rust-ini"},
-	"rust:github.com/go-logr/logr": {"https://github.com/tokio-rs/tracing"},
-	"rust:github.com/go-logr/stdr": {"https://github.com/tokio-rs/tracing"},
-	"rust:github.com/go-openapi/jsonpointer": {"https://github.com/chanced/jsonptr"},
-	"rust:github.com/go-openapi/swag": {"https://github.com/juhaku/utoipa"},
-	"rust:github.com/go-redis/redis": {"https://github.com/redis-rs/redis-rs"},
-	"rust:github.com/go-yaml/yaml": {"https://github.com/dtolnay/serde-yaml"},
-	"rust:github.com/goccy/go-json": {"https://github.com/cloudwego/sonic-rs"},
-	"rust:github.com/goccy/go-yaml": {"https://github.com/dtolnay/serde-yaml"},
-	"rust:github.com/gogo/protobuf": {"https://github.com/tokio-rs/prost"},
-	"rust:github.com/golang-jwt/jwt": {"https://github.com/keats/jsonwebtoken"},
-	"rust:github.com/golang/crypto": {"https://github.com/RustCrypto/traits"},
-	"rust:github.com/golang/image": {"https://github.com/image-rs/image"},
-	"rust:github.com/golang/mock": {"https://github.com/asomers/mockall"},
-	"rust:github.com/golang/net": {"https://github.com/hyperium/hyper"},
-	"rust:github.com/golang/oauth2": {"https://github.com/ramosbugs/oauth2-rs"},
-	"rust:github.com/golang/protobuf": {"https://github.com/tokio-rs/prost"},
-	"rust:github.com/golang/snappy": {"https://github.com/BurntSushi/rust-snappy"},
-	"rust:github.com/golang/sync": {"https://github.com/tokio-rs/tokio"},
-	"rust:github.com/golang/sys": {"https://github.com/rust-lang/libc"},
-	"rust:github.com/golang/term": {"https://github.com/crossterm-rs/crossterm"},
-	"rust:github.com/golang/text": {"https://github.com/unicode-rs/unicode-normalization"},
-	"rust:github.com/golang/time": {"https://github.com/chronotope/chrono"},
-	"rust:github.com/google/pprof": {"https://github.com/tikv/pprof-rs"},
-	"rust:github.com/google/uuid": {"https://github.com/uuid-rs/uuid"},
-	"rust:github.com/googleapis/gax-go": {"https://github.com/googleapis/google-cloud-rust"},
-	"rust:github.com/googleapis/go-genproto": {"https://github.com/hyperium/tonic"},
-	"rust:github.com/googleapis/google-api-go-client": {"https://github.com/googleapis/google-cloud-rust"},
-	"rust:github.com/googleapis/google-cloud-go": {"https://github.com/googleapis/google-cloud-rust"},
-	"rust:github.com/gorilla/css": {"https://github.com/servo/rust-cssparser"},
-	"rust:github.com/gorilla/mux": {"https://github.com/tokio-rs/axum"},
-	"rust:github.com/gorilla/websocket": {"https://github.com/snapview/tokio-tungstenite"},
-	"rust:github.com/grpc-ecosystem/grpc-gateway": {"https://github.com/hyperium/tonic"},
-	"rust:github.com/grpc/grpc-go": {"https://github.com/hyperium/tonic"},
-	"rust:github.com/hashicorp/golang-lru": {"https://github.com/jeromefroe/lru-rs"},
-	"rust:github.com/invopop/jsonschema": {"https://github.com/GREsau/schemars"},
-	"rust:github.com/joho/godotenv": {"https://github.com/allan2/dotenvy"},
-	"rust:github.com/josharian/intern": {"https://github.com/droundy/internment"},
-	"rust:github.com/json-iterator/go": {"https://github.com/serde-rs/json"},
-	"rust:github.com/klauspost/compress": {"https://github.com/gyscos/zstd-rs"},
-	"rust:github.com/klauspost/cpuid": {"https://github.com/gz/rust-cpuid"},
-	"rust:github.com/kolesa-team/go-webp": {"https://github.com/image-rs/image-webp"},
-	"rust:github.com/kubernetes-sigs/yaml": {"https://github.com/dtolnay/serde-yaml"},
-	"rust:github.com/kubernetes/api": {"https://github.com/kube-rs/kube"},
-	"rust:github.com/kubernetes/apimachinery": {"https://github.com/kube-rs/kube"},
-	"rust:github.com/kubernetes/client-go": {"https://github.com/kube-rs/kube"},
-	"rust:github.com/labstack/echo": {"https://github.com/tokio-rs/axum"},
-	"rust:github.com/lucasb-eyer/go-colorful": {"https://github.com/Ogeon/palette"},
-	"rust:github.com/mailru/easyjson": {"https://github.com/serde-rs/json"},
-	"rust:github.com/mattn/go-colorable": {"https://github.com/BurntSushi/termcolor"},
-	"rust:github.com/mattn/go-isatty": {"https://github.com/softprops/atty"},
-	"rust:github.com/mattn/go-runewidth": {"https://github.com/unicode-rs/unicode-width"},
-	"rust:github.com/mattn/go-sqlite3": {"https://github.com/rusqlite/rusqlite"},
-	"rust:github.com/microcosm-cc/bluemonday": {"https://github.com/rust-ammonia/ammonia"},
-	"rust:github.com/microsoft/go-winio": {"https://github.com/microsoft/windows-rs"},
-	"rust:github.com/miekg/dns": {"https://github.com/hickory-dns/hickory-dns"},
-	"rust:github.com/mitchellh/mapstructure": {"https://github.com/serde-rs/serde"},
-	"rust:github.com/modern-go/concurrent": {"https://github.com/tokio-rs/tokio"},
-	"rust:github.com/muesli/termenv": {"https://github.com/crossterm-rs/crossterm"},
-	"rust:github.com/munnerz/goautoneg": {"https://github.com/hyperium/headers"},
-	"rust:github.com/mvdan/sh": {"https://github.com/nushell/nushell/tree/main/crates/nu-parser"},
-	"rust:github.com/natefinch/atomic": {"https://github.com/untitaker/atomicwrites-rs"},
-	"rust:github.com/natefinch/lumberjack": {"https://github.com/tokio-rs/tracing/tree/master/tracing-appender"},
-	"rust:github.com/ncruces/go-sqlite3": {"https://github.com/rusqlite/rusqlite"},
-	"rust:github.com/nfnt/resize": {"https://github.com/image-rs/image"},
-	"rust:github.com/nxadm/tail": {"https://github.com/jmagnuson/linemux"},
-	"rust:github.com/olekukonko/tablewriter": {"https://github.com/phsym/prettytable-rs"},
-	"rust:github.com/ollama/ollama": {"https://github.com/pepperoni21/ollama-rs"},
-	"rust:github.com/open-telemetry/opentelemetry-go": {"https://github.com/open-telemetry/opentelemetry-rust"},
-	"rust:github.com/openai/openai-go": {"https://github.com/64bit/async-openai"},
-	"rust:github.com/opencontainers/go-digest": {"https://github.com/RustCrypto/traits"},
-	"rust:github.com/opencontainers/image-spec": {"https://github.com/containers/oci-spec-rs"},
-	"rust:github.com/pelletier/go-toml": {"https://github.com/toml-rs/toml"},
-	"rust:github.com/pierrec/lz4": {"https://github.com/PSeitz/lz4_flex"},
-	"rust:github.com/pires/go-proxyproto": {"https://github.com/misalcedo/ppp"},
-	"rust:github.com/pkg/browser": {"https://github.com/amodm/webbrowser-rs"},
-	"rust:github.com/pkg/errors": {"https://github.com/dtolnay/anyhow"},
-	"rust:github.com/pmezard/go-difflib": {"https://github.com/mitsuhiko/similar"},
-	"rust:github.com/pressly/goose": {"https://github.com/rust-db/refinery"},
-	"rust:github.com/prometheus/client_golang": {"https://github.com/prometheus/client_rust"},
-	"rust:github.com/prometheus/client_model": {"https://github.com/prometheus/client_rust"},
-	"rust:github.com/prometheus/common": {"https://github.com/prometheus/client_rust"},
-	"rust:github.com/prometheus/procfs": {"https://github.com/eminence/procfs"},
-	"rust:github.com/protocolbuffers/protobuf-go": {"https://github.com/tokio-rs/prost"},
-	"rust:github.com/puerkitobio/goquery": {"https://github.com/rust-scraper/scraper"},
-	"rust:github.com/quic-go/quic-go": {"https://github.com/quinn-rs/quinn"},
-	"rust:github.com/rivo/uniseg": {"https://github.com/unicode-rs/unicode-segmentation"},
-	"rust:github.com/rs/zerolog": {"https://github.com/tokio-rs/tracing"},
-	"rust:github.com/russross/blackfriday": {"https://github.com/pulldown-cmark/pulldown-cmark"},
-	"rust:github.com/sabhiram/go-gitignore": {"https://github.com/BurntSushi/ripgrep/tree/master/crates/ignore"},
-	"rust:github.com/sahilm/fuzzy": {"https://github.com/lotabout/fuzzy-matcher"},
-	"rust:github.com/samber/lo": {"https://github.com/rust-itertools/itertools"},
-	"rust:github.com/sashabaranov/go-openai": {"https://github.com/64bit/async-openai"},
-	"rust:github.com/sergi/go-diff": {"https://github.com/pascalkuthe/imara-diff"},
-	"rust:github.com/sirupsen/logrus": {"https://github.com/tokio-rs/tracing"},
-	"rust:github.com/sourcegraph/jsonrpc2": {"https://github.com/paritytech/jsonrpsee"},
-	"rust:github.com/spf13/afero": {"https://github.com/manuel-woelker/rust-vfs"},
-	"rust:github.com/spf13/cobra": {"https://github.com/clap-rs/clap"},
-	"rust:github.com/spf13/pflag": {"https://github.com/clap-rs/clap"},
-	"rust:github.com/spf13/viper": {"https://github.com/rust-cli/config-rs"},
-	"rust:github.com/srwiley/oksvg": {"https://github.com/linebender/resvg"},
-	"rust:github.com/srwiley/rasterx": {"https://github.com/linebender/resvg"},
-	"rust:github.com/tdewolff/minify": {"https://github.com/wilsonzlin/minify-html", "https://github.com/GuillaumeGomez/minifier-rs"},
-	"rust:github.com/tetratelabs/wazero": {"https://github.com/bytecodealliance/wasmtime"},
-	"rust:github.com/tidwall/gjson": {"https://github.com/serde-rs/json"},
-	"rust:github.com/tidwall/sjson": {"https://github.com/serde-rs/json"},
-	"rust:github.com/tmc/langchaingo": {"https://github.com/Abraxas-365/langchain-rust"},
-	"rust:github.com/uber-go/multierr": {"https://github.com/dtolnay/anyhow"},
-	"rust:github.com/uber-go/zap": {"https://github.com/tokio-rs/tracing"},
-	"rust:github.com/valyala/fasthttp": {"https://github.com/hyperium/hyper"},
-	"rust:github.com/vishvananda/netlink": {"https://github.com/rust-netlink/netlink"},
-	"rust:github.com/yuin/goldmark": {"https://github.com/pulldown-cmark/pulldown-cmark"},
-	"rust:github.com/zeebo/xxh3": {"https://github.com/shepmaster/twox-hash"},
-}
-
-var reverseIndex = map[string][]string{
-	"go:github.com/1password/arboard": {"https://github.com/atotto/clipboard"},
-	"go:github.com/64bit/async-openai": {"https://github.com/openai/openai-go", "https://github.com/sashabaranov/go-openai"},
-	"go:github.com/abraxas-365/langchain-rust": {"https://github.com/tmc/langchaingo"},
-	"go:github.com/allan2/dotenvy": {"https://github.com/joho/godotenv"},
-	"go:github.com/amodm/webbrowser-rs": {"https://github.com/pkg/browser"},
-	"go:github.com/asomers/mockall": {"https://github.com/golang/mock"},
-	"go:github.com/awslabs/aws-sdk-rust": {"https://github.com/aws/aws-sdk-go-v2"},
-	"go:github.com/azure/azure-sdk-for-rust": {"https://github.com/Azure/azure-sdk-for-go"},
-	"go:github.com/burntsushi/globset": {"https://github.com/bmatcuk/doublestar"},
-	"go:github.com/burntsushi/rust-snappy": {"https://github.com/golang/snappy"},
-	"go:github.com/burntsushi/termcolor": {"https://github.com/mattn/go-colorable"},
-	"go:github.com/burntsushi/walkdir": {"https://github.com/charlievieth/fastwalk"},
-	"go:github.com/cberner/redb": {"https://github.com/etcd-io/bbolt"},
-	"go:github.com/chanced/jsonptr": {"https://github.com/go-openapi/jsonpointer"},
-	"go:github.com/chronotope/chrono": {"https://github.com/golang/time"},
-	"go:github.com/chronotope/humantime": {"https://github.com/dustin/go-humanize"},
-	"go:github.com/clap-rs/clap": {"https://github.com/alecthomas/kong", "https://github.com/spf13/cobra", "https://github.com/spf13/pflag"},
-	"go:github.com/cloudwego/sonic-rs": {"https://github.com/bytedance/sonic", "https://github.com/goccy/go-json"},
-	"go:github.com/containerd/rust-extensions": {"https://github.com/containerd/containerd"},
-	"go:github.com/containers/oci-spec-rs": {"https://github.com/opencontainers/image-spec"},
-	"go:github.com/crossterm-rs/crossterm": {"https://github.com/charmbracelet/colorprofile", "https://github.com/charmbracelet/x", "https://github.com/golang/term", "https://github.com/muesli/termenv"},
-	"go:github.com/djc/askama": {"https://github.com/a-h/templ"},
-	"go:github.com/droundy/internment": {"https://github.com/josharian/intern"},
-	"go:github.com/dtolnay/anyhow": {"https://github.com/pkg/errors", "https://github.com/uber-go/multierr"},
-	"go:github.com/dtolnay/serde-yaml": {"https://github.com/go-yaml/yaml", "https://github.com/goccy/go-yaml", "https://github.com/kubernetes-sigs/yaml"},
-	"go:github.com/eminence/procfs": {"https://github.com/prometheus/procfs"},
-	"go:github.com/etcdv3/etcd-client": {"https://github.com/etcd-io/etcd"},
-	"go:github.com/fancy-regex/fancy-regex": {"https://github.com/dlclark/regexp2"},
-	"go:github.com/fussybeaver/bollard": {"https://github.com/docker/docker"},
-	"go:github.com/googleapis/google-cloud-rust": {"https://github.com/googleapis/google-api-go-client", "https://github.com/googleapis/google-cloud-go", "https://github.com/googleapis/gax-go"},
-	"go:github.com/gresau/schemars": {"https://github.com/invopop/jsonschema"},
-	"go:github.com/guillaumegomez/minifier-rs": {"https://github.com/tdewolff/minify"},
-	"go:github.com/gyscos/zstd-rs": {"https://github.com/klauspost/compress"},
-	"go:github.com/gz/rust-cpuid": {"https://github.com/klauspost/cpuid"},
-	"go:github.com/hanaasagi/machine-uid": {"https://github.com/denisbrodbeck/machineid"},
-	"go:github.com/hickory-dns/hickory-dns": {"https://github.com/miekg/dns"},
-	"go:github.com/hyperium/headers": {"https://github.com/munnerz/goautoneg"},
-	"go:github.com/hyperium/tonic": {"https://github.com/grpc/grpc-go", "https://github.com/googleapis/go-genproto", "https://github.com/grpc-ecosystem/grpc-gateway"},
-	"go:github.com/iliekturtles/uom": {"https://github.com/docker/go-units"},
-	"go:github.com/image-rs/image-webp": {"https://github.com/kolesa-team/go-webp"},
-	"go:github.com/image-rs/imageproc": {"https://github.com/disintegration/gift"},
-	"go:github.com/jeromefroe/lru-rs": {"https://github.com/hashicorp/golang-lru"},
-	"go:github.com/jmagnuson/linemux": {"https://github.com/nxadm/tail"},
-	"go:github.com/juhaku/utoipa": {"https://github.com/go-openapi/swag"},
-	"go:github.com/keats/jsonwebtoken": {"https://github.com/golang-jwt/jwt"},
-	"go:github.com/kivikakk/comrak": {"https://github.com/charmbracelet/glamour"},
-	"go:github.com/kube-rs/kube": {"https://github.com/kubernetes/api", "https://github.com/kubernetes/apimachinery", "https://github.com/kubernetes/client-go"},
-	"go:github.com/linebender/resvg": {"https://github.com/srwiley/oksvg", "https://github.com/srwiley/rasterx"},
-	"go:github.com/lotabout/fuzzy-matcher": {"https://github.com/sahilm/fuzzy"},
-	"go:github.com/lucab/libsystemd-rs": {"https://github.com/coreos/go-systemd"},
-	"go:github.com/manuel-woelker/rust-vfs": {"https://github.com/spf13/afero"},
-	"go:github.com/microsoft/windows-rs": {"https://github.com/Microsoft/go-winio"},
-	"go:github.com/misalcedo/ppp": {"https://github.com/pires/go-proxyproto"},
-	"go:github.com/mitsuhiko/similar": {"https://github.com/pmezard/go-difflib"},
-	"go:github.com/notify-rs/notify": {"https://github.com/fsnotify/fsnotify"},
-	"go:github.com/nushell/nushell/tree/main/crates/nu-parser": {"https://github.com/mvdan/sh"},
-	"go:github.com/ogeon/palette": {"https://github.com/lucasb-eyer/go-colorful"},
-	"go:github.com/ogham/rust-ansi-term": {"https://github.com/fatih/color"},
-	"go:github.com/open-telemetry/opentelemetry-rust": {"https://github.com/open-telemetry/opentelemetry-go"},
-	"go:github.com/paritytech/jsonrpsee": {"https://github.com/sourcegraph/jsonrpc2"},
-	"go:github.com/pascalkuthe/imara-diff": {"https://github.com/aymanbagabas/go-udiff", "https://github.com/sergi/go-diff"},
-	"go:github.com/pepperoni21/ollama-rs": {"https://github.com/ollama/ollama"},
-	"go:github.com/phsym/prettytable-rs": {"https://github.com/olekukonko/tablewriter"},
-	"go:github.com/prometheus/client_rust": {"https://github.com/prometheus/client_golang", "https://github.com/beorn7/perks", "https://github.com/prometheus/client_model", "https://github.com/prometheus/common"},
-	"go:github.com/pseitz/lz4_flex": {"https://github.com/pierrec/lz4"},
-	"go:github.com/pulldown-cmark/pulldown-cmark": {"https://github.com/yuin/goldmark", "https://github.com/russross/blackfriday"},
-	"go:github.com/ramosbugs/oauth2-rs": {"https://github.com/golang/oauth2"},
-	"go:github.com/ratatui/ratatui": {"https://github.com/charmbracelet/bubbles", "https://github.com/charmbracelet/bubbletea", "https://github.com/charmbracelet/lipgloss"},
-	"go:github.com/redis-rs/redis-rs": {"https://github.com/go-redis/redis"},
-	"go:github.com/rust-cli/config-rs": {"https://github.com/spf13/viper"},
-	"go:github.com/rust-db/refinery": {"https://github.com/pressly/goose"},
-	"go:github.com/rust-itertools/itertools": {"https://github.com/samber/lo"},
-	"go:github.com/rust-lang/libc": {"https://github.com/golang/sys"},
-	"go:github.com/rust-netlink/netlink": {"https://github.com/vishvananda/netlink"},
-	"go:github.com/rust-scraper/scraper": {"https://github.com/PuerkitoBio/goquery"},
-	"go:github.com/rustcrypto/traits": {"https://github.com/golang/crypto", "https://github.com/opencontainers/go-digest"},
-	"go:github.com/seaql/sea-orm": {"https://github.com/go-gorm/gorm"},
-	"go:github.com/serde-rs/json": {"https://github.com/json-iterator/go", "https://github.com/tidwall/gjson", "https://github.com/tidwall/sjson", "https://github.com/mailru/easyjson"},
-	"go:github.com/serde-rs/serde": {"https://github.com/mitchellh/mapstructure"},
-	"go:github.com/servo/rust-cssparser": {"https://github.com/gorilla/css"},
-	"go:github.com/shepmaster/twox-hash": {"https://github.com/cespare/xxhash", "https://github.com/zeebo/xxh3"},
-	"go:github.com/snapview/tokio-tungstenite": {"https://github.com/gorilla/websocket"},
-	"go:github.com/softprops/atty": {"https://github.com/mattn/go-isatty"},
-	"go:github.com/tikv/pprof-rs": {"https://github.com/google/pprof"},
-	"go:github.com/tokio-rs/axum": {"https://github.com/gin-gonic/gin", "https://github.com/go-chi/chi", "https://github.com/gorilla/mux", "https://github.com/labstack/echo"},
-	"go:github.com/tokio-rs/tracing": {"https://github.com/charmbracelet/log", "https://github.com/rs/zerolog", "https://github.com/sirupsen/logrus", "https://github.com/uber-go/zap", "https://github.com/go-logr/logr", "https://github.com/go-logr/stdr"},
-	"go:github.com/tokio-rs/tracing/tree/master/tracing-appender": {"https://github.com/natefinch/lumberjack"},
-	"go:github.com/toml-rs/toml": {"https://github.com/BurntSushi/toml", "https://github.com/pelletier/go-toml"},
-	"go:github.com/trishume/syntect": {"https://github.com/alecthomas/chroma"},
-	"go:github.com/unicode-rs/unicode-normalization": {"https://github.com/golang/text"},
-	"go:github.com/unicode-rs/unicode-segmentation": {"https://github.com/rivo/uniseg"},
-	"go:github.com/unicode-rs/unicode-width": {"https://github.com/mattn/go-runewidth"},
-	"go:github.com/untitaker/atomicwrites-rs": {"https://github.com/natefinch/atomic"},
-	"go:github.com/uuid-rs/uuid": {"https://github.com/google/uuid"},
-	"go:github.com/wilsonzlin/minify-html": {"https://github.com/tdewolff/minify"},
-	"go:github.com/yanganto/struct-patch": {"https://github.com/darccio/mergo"},
-	"go:github.com/zonyitoo/rust-ini": {"https://github.com/go-ini/ini"},
-	"js:github.com/dromara/carbon": {"https://github.com/moment/moment"},
-	"js:github.com/ent/ent": {"https://github.com/prisma/prisma"},
-	"js:github.com/go-gorm/gorm": {"https://github.com/typeorm/typeorm"},
-	"js:github.com/go-playground/validator": {"https://github.com/colinhacks/zod"},
-	"js:github.com/go-resty/resty": {"https://github.com/axios/axios"},
-	"js:github.com/gofiber/fiber": {"https://github.com/fastify/fastify"},
-	"js:github.com/golang-jwt/jwt": {"https://github.com/auth0/node-jsonwebtoken"},
-	"js:github.com/golang/go": {"https://github.com/winstonjs/winston"},
-	"js:github.com/hibiken/asynq": {"https://github.com/taskforcesh/bullmq"},
-	"js:github.com/jackc/pgx": {"https://github.com/brianc/node-postgres"},
-	"js:github.com/joho/godotenv": {"https://github.com/motdotla/dotenv"},
-	"js:github.com/knadh/koanf": {"https://github.com/lorenwest/node-config"},
-	"js:github.com/labstack/echo": {"https://github.com/expressjs/express"},
-	"js:github.com/markbates/goth": {"https://github.com/jaredhanson/passport"},
-	"js:github.com/masterminds/squirrel": {"https://github.com/knex/knex"},
-	"js:github.com/olahol/melody": {"https://github.com/socketio/socket.io"},
-	"js:github.com/rs/zerolog": {"https://github.com/pinojs/pino"},
-	"js:github.com/samber/lo": {"https://github.com/lodash/lodash"},
-	"js:github.com/stretchr/testify": {"https://github.com/jestjs/jest"},
-	"js:github.com/uber-go/fx": {"https://github.com/nestjs/nest"},
-}
-
-var reverseIndexAll = map[string][]string{
-	"go:github.com/1password/arboard": {"https://github.com/atotto/clipboard"},
-	"go:github.com/64bit/async-openai": {"https://github.com/openai/openai-go", "https://github.com/sashabaranov/go-openai"},
-	"go:github.com/abraxas-365/langchain-rust": {"https://github.com/tmc/langchaingo"},
-	"go:github.com/allan2/dotenvy": {"https://github.com/joho/godotenv"},
-	"go:github.com/amodm/webbrowser-rs": {"https://github.com/pkg/browser"},
-	"go:github.com/asomers/mockall": {"https://github.com/golang/mock"},
-	"go:github.com/awslabs/aws-sdk-rust": {"https://github.com/aws/aws-sdk-go-v2"},
-	"go:github.com/azure/azure-sdk-for-rust": {"https://github.com/Azure/azure-sdk-for-go"},
-	"go:github.com/burntsushi/globset": {"https://github.com/bmatcuk/doublestar"},
-	"go:github.com/burntsushi/ripgrep/tree/master/crates/ignore": {"https://github.com/sabhiram/go-gitignore"},
-	"go:github.com/burntsushi/rust-snappy": {"https://github.com/golang/snappy"},
-	"go:github.com/burntsushi/termcolor": {"https://github.com/mattn/go-colorable"},
-	"go:github.com/burntsushi/walkdir": {"https://github.com/charlievieth/fastwalk"},
-	"go:github.com/bytecodealliance/wasmtime": {"https://github.com/tetratelabs/wazero"},
-	"go:github.com/cberner/redb": {"https://github.com/etcd-io/bbolt"},
-	"go:github.com/chanced/jsonptr": {"https://github.com/go-openapi/jsonpointer"},
-	"go:github.com/chronotope/chrono": {"https://github.com/golang/time"},
-	"go:github.com/chronotope/humantime": {"https://github.com/dustin/go-humanize"},
-	"go:github.com/clap-rs/clap": {"https://github.com/alecthomas/kong", "https://github.com/spf13/cobra", "https://github.com/spf13/pflag"},
-	"go:github.com/cloudwego/sonic-rs": {"https://github.com/bytedance/sonic", "https://github.com/goccy/go-json"},
-	"go:github.com/containerd/rust-extensions": {"https://github.com/containerd/containerd"},
-	"go:github.com/containers/oci-spec-rs": {"https://github.com/opencontainers/image-spec"},
-	"go:github.com/crossterm-rs/crossterm": {"https://github.com/charmbracelet/colorprofile", "https://github.com/charmbracelet/x", "https://github.com/golang/term", "https://github.com/muesli/termenv"},
-	"go:github.com/djc/askama": {"https://github.com/a-h/templ"},
-	"go:github.com/droundy/internment": {"https://github.com/josharian/intern"},
-	"go:github.com/dtolnay/anyhow": {"https://github.com/pkg/errors", "https://github.com/uber-go/multierr"},
-	"go:github.com/dtolnay/serde-yaml": {"https://github.com/go-yaml/yaml", "https://github.com/goccy/go-yaml", "https://github.com/kubernetes-sigs/yaml"},
-	"go:github.com/eminence/procfs": {"https://github.com/prometheus/procfs"},
-	"go:github.com/etcdv3/etcd-client": {"https://github.com/etcd-io/etcd"},
-	"go:github.com/fancy-regex/fancy-regex": {"https://github.com/dlclark/regexp2"},
-	"go:github.com/fussybeaver/bollard": {"https://github.com/docker/docker"},
-	"go:github.com/googleapis/google-cloud-rust": {"https://github.com/googleapis/google-api-go-client", "https://github.com/googleapis/google-cloud-go", "https://github.com/googleapis/gax-go"},
-	"go:github.com/gresau/schemars": {"https://github.com/invopop/jsonschema"},
-	"go:github.com/guillaumegomez/minifier-rs": {"https://github.com/tdewolff/minify"},
-	"go:github.com/gyscos/zstd-rs": {"https://github.com/klauspost/compress"},
-	"go:github.com/gz/rust-cpuid": {"https://github.com/klauspost/cpuid"},
-	"go:github.com/hanaasagi/machine-uid": {"https://github.com/denisbrodbeck/machineid"},
-	"go:github.com/hickory-dns/hickory-dns": {"https://github.com/miekg/dns"},
-	"go:github.com/hyperium/headers": {"https://github.com/munnerz/goautoneg"},
-	"go:github.com/hyperium/hyper": {"https://github.com/golang/net", "https://github.com/valyala/fasthttp"},
-	"go:github.com/hyperium/tonic": {"https://github.com/grpc/grpc-go", "https://github.com/googleapis/go-genproto", "https://github.com/grpc-ecosystem/grpc-gateway"},
-	"go:github.com/iliekturtles/uom": {"https://github.com/docker/go-units"},
-	"go:github.com/image-rs/image": {"https://github.com/disintegration/imageorient", "https://github.com/golang/image", "https://github.com/nfnt/resize"},
-	"go:github.com/image-rs/image-webp": {"https://github.com/kolesa-team/go-webp"},
-	"go:github.com/image-rs/imageproc": {"https://github.com/disintegration/gift"},
-	"go:github.com/jeromefroe/lru-rs": {"https://github.com/hashicorp/golang-lru"},
-	"go:github.com/jmagnuson/linemux": {"https://github.com/nxadm/tail"},
-	"go:github.com/juhaku/utoipa": {"https://github.com/go-openapi/swag"},
-	"go:github.com/keats/jsonwebtoken": {"https://github.com/golang-jwt/jwt"},
-	"go:github.com/kivikakk/comrak": {"https://github.com/charmbracelet/glamour"},
-	"go:github.com/kube-rs/kube": {"https://github.com/kubernetes/api", "https://github.com/kubernetes/apimachinery", "https://github.com/kubernetes/client-go"},
-	"go:github.com/linebender/resvg": {"https://github.com/srwiley/oksvg", "https://github.com/srwiley/rasterx"},
-	"go:github.com/lotabout/fuzzy-matcher": {"https://github.com/sahilm/fuzzy"},
-	"go:github.com/lucab/libsystemd-rs": {"https://github.com/coreos/go-systemd"},
-	"go:github.com/manuel-woelker/rust-vfs": {"https://github.com/spf13/afero"},
-	"go:github.com/microsoft/windows-rs": {"https://github.com/Microsoft/go-winio"},
-	"go:github.com/misalcedo/ppp": {"https://github.com/pires/go-proxyproto"},
-	"go:github.com/mitsuhiko/similar": {"https://github.com/pmezard/go-difflib"},
-	"go:github.com/notify-rs/notify": {"https://github.com/fsnotify/fsnotify"},
-	"go:github.com/nushell/nushell/tree/main/crates/nu-parser": {"https://github.com/mvdan/sh"},
-	"go:github.com/ogeon/palette": {"https://github.com/lucasb-eyer/go-colorful"},
-	"go:github.com/ogham/rust-ansi-term": {"https://github.com/fatih/color"},
-	"go:github.com/open-telemetry/opentelemetry-rust": {"https://github.com/open-telemetry/opentelemetry-go"},
-	"go:github.com/paritytech/jsonrpsee": {"https://github.com/sourcegraph/jsonrpc2"},
-	"go:github.com/pascalkuthe/imara-diff": {"https://github.com/aymanbagabas/go-udiff", "https://github.com/sergi/go-diff"},
-	"go:github.com/pepperoni21/ollama-rs": {"https://github.com/ollama/ollama"},
-	"go:github.com/phsym/prettytable-rs": {"https://github.com/olekukonko/tablewriter"},
-	"go:github.com/prometheus/client_rust": {"https://github.com/prometheus/client_golang", "https://github.com/beorn7/perks", "https://github.com/prometheus/client_model", "https://github.com/prometheus/common"},
-	"go:github.com/pseitz/lz4_flex": {"https://github.com/pierrec/lz4"},
-	"go:github.com/pulldown-cmark/pulldown-cmark": {"https://github.com/yuin/goldmark", "https://github.com/russross/blackfriday"},
-	"go:github.com/quinn-rs/quinn": {"https://github.com/quic-go/quic-go"},
-	"go:github.com/ramosbugs/oauth2-rs": {"https://github.com/golang/oauth2"},
-	"go:github.com/ratatui/ratatui": {"https://github.com/charmbracelet/bubbles", "https://github.com/charmbracelet/bubbletea", "https://github.com/charmbracelet/lipgloss"},
-	"go:github.com/redis-rs/redis-rs": {"https://github.com/go-redis/redis"},
-	"go:github.com/rusqlite/rusqlite": {"https://github.com/mattn/go-sqlite3", "https://github.com/ncruces/go-sqlite3"},
-	"go:github.com/rust-ammonia/ammonia": {"https://github.com/microcosm-cc/bluemonday"},
-	"go:github.com/rust-cli/config-rs": {"https://github.com/spf13/viper"},
-	"go:github.com/rust-db/refinery": {"https://github.com/pressly/goose"},
-	"go:github.com/rust-itertools/itertools": {"https://github.com/samber/lo"},
-	"go:github.com/rust-lang/libc": {"https://github.com/golang/sys"},
-	"go:github.com/rust-netlink/netlink": {"https://github.com/vishvananda/netlink"},
-	"go:github.com/rust-scraper/scraper": {"https://github.com/PuerkitoBio/goquery"},
-	"go:github.com/rustcrypto/traits": {"https://github.com/golang/crypto", "https://github.com/opencontainers/go-digest"},
-	"go:github.com/rustls/rustls": {"https://github.com/cloudflare/cfssl"},
-	"go:github.com/seaql/sea-orm": {"https://github.com/go-gorm/gorm"},
-	"go:github.com/serde-rs/json": {"https://github.com/json-iterator/go", "https://github.com/tidwall/gjson", "https://github.com/tidwall/sjson", "https://github.com/mailru/easyjson"},
-	"go:github.com/serde-rs/serde": {"https://github.com/mitchellh/mapstructure"},
-	"go:github.com/servo/rust-cssparser": {"https://github.com/gorilla/css"},
-	"go:github.com/shepmaster/twox-hash": {"https://github.com/cespare/xxhash", "https://github.com/zeebo/xxh3"},
-	"go:github.com/snapview/tokio-tungstenite": {"https://github.com/gorilla/websocket"},
-	"go:github.com/softprops/atty": {"https://github.com/mattn/go-isatty"},
-	"go:github.com/tikv/pprof-rs": {"https://github.com/google/pprof"},
-	"go:github.com/tokio-rs/axum": {"https://github.com/gin-gonic/gin", "https://github.com/go-chi/chi", "https://github.com/gorilla/mux", "https://github.com/labstack/echo"},
-	"go:github.com/tokio-rs/prost": {"https://github.com/gogo/protobuf", "https://github.com/protocolbuffers/protobuf-go", "https://github.com/golang/protobuf"},
-	"go:github.com/tokio-rs/tokio": {"https://github.com/golang/sync", "https://github.com/modern-go/concurrent"},
-	"go:github.com/tokio-rs/tracing": {"https://github.com/charmbracelet/log", "https://github.com/rs/zerolog", "https://github.com/sirupsen/logrus", "https://github.com/uber-go/zap", "https://github.com/go-logr/logr", "https://github.com/go-logr/stdr"},
-	"go:github.com/tokio-rs/tracing/tree/master/tracing-appender": {"https://github.com/natefinch/lumberjack"},
-	"go:github.com/toml-rs/toml": {"https://github.com/BurntSushi/toml", "https://github.com/pelletier/go-toml"},
-	"go:github.com/tower-rs/tower-http": {"https://github.com/felixge/httpsnoop"},
-	"go:github.com/trishume/syntect": {"https://github.com/alecthomas/chroma"},
-	"go:github.com/unicode-rs/unicode-normalization": {"https://github.com/golang/text"},
-	"go:github.com/unicode-rs/unicode-segmentation": {"https://github.com/rivo/uniseg"},
-	"go:github.com/unicode-rs/unicode-width": {"https://github.com/mattn/go-runewidth"},
-	"go:github.com/untitaker/atomicwrites-rs": {"https://github.com/natefinch/atomic"},
-	"go:github.com/uuid-rs/uuid": {"https://github.com/google/uuid"},
-	"go:github.com/wilsonzlin/minify-html": {"https://github.com/tdewolff/minify"},
-	"go:github.com/yanganto/struct-patch": {"https://github.com/darccio/mergo"},
-	"go:github.com/zonyitoo/rust-ini": {"https://github.com/go-ini/ini"},
-	"js:github.com/dromara/carbon": {"https://github.com/moment/moment"},
-	"js:github.com/ent/ent": {"https://github.com/prisma/prisma"},
-	"js:github.com/go-gorm/gorm": {"https://github.com/typeorm/typeorm"},
-	"js:github.com/go-playground/validator": {"https://github.com/colinhacks/zod"},
-	"js:github.com/go-resty/resty": {"https://github.com/axios/axios"},
-	"js:github.com/gofiber/fiber": {"https://github.com/fastify/fastify"},
-	"js:github.com/golang-jwt/jwt": {"https://github.com/auth0/node-jsonwebtoken"},
-	"js:github.com/golang/go": {"https://github.com/winstonjs/winston"},
-	"js:github.com/hibiken/asynq": {"https://github.com/taskforcesh/bullmq"},
-	"js:github.com/jackc/pgx": {"https://github.com/brianc/node-postgres"},
-	"js:github.com/joho/godotenv": {"https://github.com/motdotla/dotenv"},
-	"js:github.com/knadh/koanf": {"https://github.com/lorenwest/node-config"},
-	"js:github.com/labstack/echo": {"https://github.com/expressjs/express"},
-	"js:github.com/markbates/goth": {"https://github.com/jaredhanson/passport"},
-	"js:github.com/masterminds/squirrel": {"https://github.com/knex/knex"},
-	"js:github.com/olahol/melody": {"https://github.com/socketio/socket.io"},
-	"js:github.com/rs/zerolog": {"https://github.com/pinojs/pino"},
-	"js:github.com/samber/lo": {"https://github.com/lodash/lodash"},
-	"js:github.com/stretchr/testify": {"https://github.com/jestjs/jest"},
-	"js:github.com/uber-go/fx": {"https://github.com/nestjs/nest"},
-}
-
-var knownCrateNames = map[string]string{
-	"github.com/awslabs/aws-sdk-rust": "aws_sdk_config",
-	"github.com/azure/azure-sdk-for-rust": "azure_core",
-	"github.com/bytecodealliance/wasmtime": "wasmtime",
-	"github.com/chronotope/chrono": "chrono",
-	"github.com/clap-rs/clap": "clap",
-	"github.com/djc/askama": "askama",
-	"github.com/dtolnay/anyhow": "anyhow",
-	"github.com/dtolnay/serde-yaml": "serde_yaml",
-	"github.com/googleapis/google-cloud-rust": "google_cloud_storage",
-	"github.com/hyperium/hyper": "hyper",
-	"github.com/hyperium/tonic": "tonic",
-	"github.com/image-rs/image": "image",
-	"github.com/redis-rs/redis-rs": "redis",
-	"github.com/rusqlite/rusqlite": "rusqlite",
-	"github.com/rustls/rustls": "rustls",
-	"github.com/seaql/sea-orm": "sea_orm",
-	"github.com/serde-rs/json": "serde_json",
-	"github.com/serde-rs/serde": "serde",
-	"github.com/tokio-rs/axum": "axum",
-	"github.com/tokio-rs/prost": "prost",
-	"github.com/tokio-rs/tokio": "tokio",
-	"github.com/tokio-rs/tracing": "tracing",
-	"github.com/toml-rs/toml": "toml",
-	"github.com/tower-rs/tower-http": "tower_http",
-	"github.com/untitaker/atomicwrites-rs": "atomicwrites",
-	"github.com/uuid-rs/uuid": "uuid",
-}
-
-var tags = map[string][]string{
-	"github.com/99designs/gqlgen": {"graphql", "codegen:gqlgen"},
-	"github.com/a-h/templ": {"templating", "codegen:templ"},
-	"github.com/alecthomas/kong": {"cli"},
-	"github.com/aws/aws-sdk-go-v2": {"cloud"},
-	"github.com/azure/azure-sdk-for-go": {"cloud"},
-	"github.com/charmbracelet/bubbles": {"cli"},
-	"github.com/charmbracelet/bubbletea": {"cli"},
-	"github.com/charmbracelet/lipgloss": {"cli"},
-	"github.com/charmbracelet/log": {"logging"},
-	"github.com/containerd/containerd": {"container"},
-	"github.com/djc/askama": {"templating"},
-	"github.com/docker/docker": {"container"},
-	"github.com/ent/ent": {"orm", "codegen:ent"},
-	"github.com/etcd-io/bbolt": {"sql"},
-	"github.com/gin-gonic/gin": {"web"},
-	"github.com/go-chi/chi": {"web"},
-	"github.com/go-gorm/gorm": {"sql", "orm"},
-	"github.com/go-redis/redis": {"sql"},
-	"github.com/go-resty/resty": {"http"},
-	"github.com/gofiber/fiber": {"web"},
-	"github.com/golang-jwt/jwt": {"auth"},
-	"github.com/golang/mock": {"testing"},
-	"github.com/golang/oauth2": {"auth"},
-	"github.com/google/wire": {"di", "codegen:wire"},
-	"github.com/googleapis/google-cloud-go": {"cloud"},
-	"github.com/gorilla/mux": {"web"},
-	"github.com/gorilla/websocket": {"websocket"},
-	"github.com/grpc-ecosystem/grpc-gateway": {"grpc"},
-	"github.com/grpc/grpc-go": {"grpc"},
-	"github.com/hibiken/asynq": {"async"},
-	"github.com/jackc/pgx": {"sql"},
-	"github.com/joho/godotenv": {"config"},
-	"github.com/knadh/koanf": {"config"},
-	"github.com/kubernetes/client-go": {"container"},
-	"github.com/labstack/echo": {"web"},
-	"github.com/markbates/goth": {"auth"},
-	"github.com/masterminds/squirrel": {"orm"},
-	"github.com/mattn/go-sqlite3": {"sql"},
-	"github.com/natefinch/atomic": {"filesystem"},
-	"github.com/ncruces/go-sqlite3": {"sql"},
-	"github.com/olahol/melody": {"websocket"},
-	"github.com/ollama/ollama": {"ai"},
-	"github.com/onsi/ginkgo": {"testing"},
-	"github.com/onsi/gomega": {"testing"},
-	"github.com/open-telemetry/opentelemetry-go": {"observability"},
-	"github.com/openai/openai-go": {"ai"},
-	"github.com/pressly/goose": {"orm"},
-	"github.com/prometheus/client_golang": {"observability"},
-	"github.com/protocolbuffers/protobuf-go": {"codegen:protobuf"},
-	"github.com/rs/zerolog": {"logging"},
-	"github.com/sashabaranov/go-openai": {"ai"},
-	"github.com/sirupsen/logrus": {"logging"},
-	"github.com/spf13/cobra": {"cli"},
-	"github.com/spf13/viper": {"config"},
-	"github.com/sqlc-dev/sqlc": {"sql", "codegen:sqlc"},
-	"github.com/stretchr/testify": {"testing"},
-	"github.com/tmc/langchaingo": {"ai"},
-	"github.com/uber-go/zap": {"logging"},
-	"github.com/untitaker/atomicwrites-rs": {"filesystem"},
-	"github.com/valyala/fasthttp": {"web"},
-}
-
 type requiredDep struct {
 	Crate    string
 	Features []string
 	Reason   string
 }
 
-var requiredDeps = map[string][]requiredDep{
-	"rust:github.com/gin-gonic/gin": {
-		{Crate: "tokio", Features: []string{"full"}, Reason: "async runtime for axum"},
-	},
-	"rust:github.com/go-chi/chi": {
-		{Crate: "tokio", Features: []string{"full"}, Reason: "async runtime for axum"},
-	},
-	"rust:github.com/gorilla/mux": {
-		{Crate: "tokio", Features: []string{"full"}, Reason: "async runtime for axum"},
-	},
-	"rust:github.com/labstack/echo": {
-		{Crate: "tokio", Features: []string{"full"}, Reason: "async runtime for axum"},
-	},
+type generatedIndex struct {
+	index           map[string][]string
+	indexAll        map[string][]string
+	reverseIndex    map[string][]string
+	reverseIndexAll map[string][]string
+	knownCrateNames map[string]string
+	tags            map[string][]string
+	requiredDeps    map[string][]requiredDep
+	categories      map[string]string
+	confidences     map[string]float64
+	packages        map[string]string
+}
+
+// dbVersion identifies the mapping database: the start of the SHA-256 of libs.json
+// and mappings.json.
+const dbVersion = "03150fdd4a67"
+
+// dbReleased is the released time of mappings.json, RFC 3339 or empty. A downloaded
+// database is only used if it is at least as new.
+const dbReleased = "2026-10-14T00:00:00Z"
+
+// loadIndex builds the mapping database. Each call constructs new maps.
+func loadIndex() generatedIndex {
+	return generatedIndex{
+		index: map[string][]string{
+			"go:github.com/auth0/node-jsonwebtoken": {"https://github.com/golang-jwt/jwt"},
+			"go:github.com/axios/axios": {"https://github.com/go-resty/resty"},
+			"go:github.com/brianc/node-postgres": {"https://github.com/jackc/pgx"},
+			"go:github.com/colinhacks/zod": {"https://github.com/go-playground/validator"},
+			"go:github.com/expressjs/express": {"https://github.com/labstack/echo"},
+			"go:github.com/fastify/fastify": {"https://github.com/gofiber/fiber"},
+			"go:github.com/jaredhanson/passport": {"https://github.com/markbates/goth"},
+			"go:github.com/jestjs/jest": {"https://github.com/stretchr/testify"},
+			"go:github.com/knex/knex": {"https://github.com/Masterminds/squirrel"},
+			"go:github.com/lodash/lodash": {"https://github.com/samber/lo"},
+			"go:github.com/lorenwest/node-config": {"https://github.com/knadh/koanf"},
+			"go:github.com/moment/moment": {"https://github.com/dromara/carbon"},
+			"go:github.com/motdotla/dotenv": {"https://github.com/joho/godotenv"},
+			"go:github.com/nestjs/nest": {"https://github.com/uber-go/fx"},
+			"go:github.com/pinojs/pino": {"https://github.com/rs/zerolog"},
+			"go:github.com/prisma/prisma": {"https://github.com/ent/ent"},
+			"go:github.com/socketio/socket.io": {"https://github.com/olahol/melody"},
+			"go:github.com/taskforcesh/bullmq": {"https://github.com/hibiken/asynq"},
+			"go:github.com/typeorm/typeorm": {"https://github.com/go-gorm/gorm"},
+			"go:github.com/winstonjs/winston": {"https://github.com/golang/go"},
+			"js:github.com/dromara/carbon": {"https://github.com/moment/moment"},
+			"js:github.com/ent/ent": {"https://github.com/prisma/prisma"},
+			"js:github.com/gin-gonic/gin": {"https://github.com/expressjs/express"},
+			"js:github.com/go-chi/chi": {"https://github.com/expressjs/express"},
+			"js:github.com/go-gorm/gorm": {"https://github.com/typeorm/typeorm"},
+			"js:github.com/go-playground/validator": {"https://github.com/colinhacks/zod"},
+			"js:github.com/go-resty/resty": {"https://github.com/axios/axios"},
+			"js:github.com/go-yaml/yaml": {"https://github.com/nodeca/js-yaml"},
+			"js:github.com/gofiber/fiber": {"https://github.com/fastify/fastify"},
+			"js:github.com/golang-jwt/jwt": {"https://github.com/auth0/node-jsonwebtoken"},
+			"js:github.com/google/uuid": {"https://github.com/uuidjs/uuid"},
+			"js:github.com/gorilla/mux": {"https://github.com/expressjs/express"},
+			"js:github.com/gorilla/websocket": {"https://github.com/websockets/ws"},
+			"js:github.com/grpc/grpc-go": {"https://github.com/grpc/grpc-node"},
+			"js:github.com/hibiken/asynq": {"https://github.com/taskforcesh/bullmq"},
+			"js:github.com/jackc/pgx": {"https://github.com/brianc/node-postgres"},
+			"js:github.com/joho/godotenv": {"https://github.com/motdotla/dotenv"},
+			"js:github.com/knadh/koanf": {"https://github.com/lorenwest/node-config"},
+			"js:github.com/labstack/echo": {"https://github.com/expressjs/express"},
+			"js:github.com/markbates/goth": {"https://github.com/jaredhanson/passport"},
+			"js:github.com/masterminds/squirrel": {"https://github.com/knex/knex"},
+			"js:github.com/prometheus/client_golang": {"https://github.com/siimon/prom-client"},
+			"js:github.com/rs/zerolog": {"https://github.com/pinojs/pino"},
+			"js:github.com/samber/lo": {"https://github.com/lodash/lodash"},
+			"js:github.com/sirupsen/logrus": {"https://github.com/winstonjs/winston"},
+			"js:github.com/spf13/cobra": {"https://github.com/tj/commander.js"},
+			"js:github.com/spf13/viper": {"https://github.com/lorenwest/node-config"},
+			"js:github.com/stretchr/testify": {"https://github.com/jestjs/jest"},
+			"js:github.com/uber-go/fx": {"https://github.com/nestjs/nest"},
+			"js:github.com/uber-go/zap": {"https://github.com/pinojs/pino"},
+			"rust:github.com/a-h/templ": {"https://github.com/djc/askama"},
+			"rust:github.com/alecthomas/chroma": {"https://github.com/trishume/syntect"},
+			"rust:github.com/alecthomas/kong": {"https://github.com/clap-rs/clap"},
+			"rust:github.com/atotto/clipboard": {"https://github.com/1Password/arboard"},
+			"rust:github.com/auth0/node-jsonwebtoken": {"https://github.com/keats/jsonwebtoken"},
+			"rust:github.com/aws/aws-sdk-go-v2": {"https://github.com/awslabs/aws-sdk-rust"},
+			"rust:github.com/aymanbagabas/go-udiff": {"https://github.com/pascalkuthe/imara-diff"},
+			"rust:github.com/azure/azure-sdk-for-go": {"https://github.com/Azure/azure-sdk-for-rust"},
+			"rust:github.com/beorn7/perks": {"https://github.com/prometheus/client_rust"},
+			"rust:github.com/bmatcuk/doublestar": {"https://github.com/BurntSushi/globset"},
+			"rust:github.com/burntsushi/toml": {"https://github.com/toml-rs/toml"},
+			"rust:github.com/bytedance/sonic": {"https://github.com/cloudwego/sonic-rs"},
+			"rust:github.com/cespare/xxhash": {"https://github.com/shepmaster/twox-hash"},
+			"rust:github.com/charlievieth/fastwalk": {"https://github.com/BurntSushi/walkdir"},
+			"rust:github.com/charmbracelet/bubbles": {"https://github.com/ratatui/ratatui"},
+			"rust:github.com/charmbracelet/bubbletea": {"https://github.com/ratatui/ratatui"},
+			"rust:github.com/charmbracelet/colorprofile": {"https://github.com/crossterm-rs/crossterm"},
+			"rust:github.com/charmbracelet/glamour": {"https://github.com/kivikakk/comrak"},
+			"rust:github.com/charmbracelet/lipgloss": {"https://github.com/ratatui/ratatui"},
+			"rust:github.com/charmbracelet/log": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/charmbracelet/x": {"https://github.com/crossterm-rs/crossterm"},
+			"rust:github.com/containerd/containerd": {"https://github.com/containerd/rust-extensions"},
+			"rust:github.com/coreos/go-systemd": {"https://github.com/lucab/libsystemd-rs"},
+			"rust:github.com/darccio/mergo": {"https://github.com/yanganto/struct-patch"},
+			"rust:github.com/denisbrodbeck/machineid": {"https://github.com/Hanaasagi/machine-uid"},
+			"rust:github.com/disintegration/gift": {"https://github.com/image-rs/imageproc"},
+			"rust:github.com/dlclark/regexp2": {"https://github.com/fancy-regex/fancy-regex"},
+			"rust:github.com/docker/docker": {"https://github.com/fussybeaver/bollard"},
+			"rust:github.com/docker/go-units": {"https://github.com/iliekturtles/uom"},
+			"rust:github.com/dustin/go-humanize": {"https://github.com/chronotope/humantime"},
+			"rust:github.com/etcd-io/bbolt": {"https://github.com/cberner/redb"},
+			"rust:github.com/etcd-io/etcd": {"https://github.com/etcdv3/etcd-client"},
+			"rust:github.com/expressjs/express": {"https://github.com/tokio-rs/axum"},
+			"rust:github.com/fatih/color": {"https://github.com/ogham/rust-ansi-term"},
+			"rust:github.com/fsnotify/fsnotify": {"https://github.com/notify-rs/notify"},
+			"rust:github.com/gin-gonic/gin": {"https://github.com/tokio-rs/axum"},
+			"rust:github.com/go-chi/chi": {"https://github.com/tokio-rs/axum"},
+			"rust:github.com/go-gorm/gorm": {"https://github.com/SeaQL/sea-orm"},
+			"rust:github.com/go-ini/ini": {"https://github.com/zonyitoo/rust-ini"},
+			"rust:github.com/go-logr/logr": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/go-logr/stdr": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/go-openapi/jsonpointer": {"https://github.com/chanced/jsonptr"},
+			"rust:github.com/go-openapi/swag": {"https://github.com/juhaku/utoipa"},
+			"rust:github.com/go-redis/redis": {"https://github.com/redis-rs/redis-rs"},
+			"rust:github.com/go-yaml/yaml": {"https://github.com/dtolnay/serde-yaml"},
+			"rust:github.com/goccy/go-json": {"https://github.com/cloudwego/sonic-rs"},
+			"rust:github.com/goccy/go-yaml": {"https://github.com/dtolnay/serde-yaml"},
+			"rust:github.com/golang-jwt/jwt": {"https://github.com/keats/jsonwebtoken"},
+			"rust:github.com/golang/crypto": {"https://github.com/RustCrypto/traits"},
+			"rust:github.com/golang/mock": {"https://github.com/asomers/mockall"},
+			"rust:github.com/golang/oauth2": {"https://github.com/ramosbugs/oauth2-rs"},
+			"rust:github.com/golang/snappy": {"https://github.com/BurntSushi/rust-snappy"},
+			"rust:github.com/golang/sys": {"https://github.com/rust-lang/libc"},
+			"rust:github.com/golang/term": {"https://github.com/crossterm-rs/crossterm"},
+			"rust:github.com/golang/text": {"https://github.com/unicode-rs/unicode-normalization"},
+			"rust:github.com/golang/time": {"https://github.com/chronotope/chrono"},
+			"rust:github.com/google/pprof": {"https://github.com/tikv/pprof-rs"},
+			"rust:github.com/google/uuid": {"https://github.com/uuid-rs/uuid"},
+			"rust:github.com/googleapis/gax-go": {"https://github.com/googleapis/google-cloud-rust"},
+			"rust:github.com/googleapis/go-genproto": {"https://github.com/hyperium/tonic"},
+			"rust:github.com/googleapis/google-api-go-client": {"https://github.com/googleapis/google-cloud-rust"},
+			"rust:github.com/googleapis/google-cloud-go": {"https://github.com/googleapis/google-cloud-rust"},
+			"rust:github.com/gorilla/css": {"https://github.com/servo/rust-cssparser"},
+			"rust:github.com/gorilla/mux": {"https://github.com/tokio-rs/axum"},
+			"rust:github.com/gorilla/websocket": {"https://github.com/snapview/tokio-tungstenite"},
+			"rust:github.com/grpc-ecosystem/grpc-gateway": {"https://github.com/hyperium/tonic"},
+			"rust:github.com/grpc/grpc-go": {"https://github.com/hyperium/tonic"},
+			"rust:github.com/hashicorp/golang-lru": {"https://github.com/jeromefroe/lru-rs"},
+			"rust:github.com/invopop/jsonschema": {"https://github.com/GREsau/schemars"},
+			"rust:github.com/joho/godotenv": {"https://github.com/allan2/dotenvy"},
+			"rust:github.com/josharian/intern": {"https://github.com/droundy/internment"},
+			"rust:github.com/jpadilla/pyjwt": {"https://github.com/keats/jsonwebtoken"},
+			"rust:github.com/json-iterator/go": {"https://github.com/serde-rs/json"},
+			"rust:github.com/klauspost/compress": {"https://github.com/gyscos/zstd-rs"},
+			"rust:github.com/klauspost/cpuid": {"https://github.com/gz/rust-cpuid"},
+			"rust:github.com/kolesa-team/go-webp": {"https://github.com/image-rs/image-webp"},
+			"rust:github.com/kubernetes-sigs/yaml": {"https://github.com/dtolnay/serde-yaml"},
+			"rust:github.com/kubernetes/api": {"https://github.com/kube-rs/kube"},
+			"rust:github.com/kubernetes/apimachinery": {"https://github.com/kube-rs/kube"},
+			"rust:github.com/kubernetes/client-go": {"https://github.com/kube-rs/kube"},
+			"rust:github.com/labstack/echo": {"https://github.com/tokio-rs/axum"},
+			"rust:github.com/lorenwest/node-config": {"https://github.com/rust-cli/config-rs"},
+			"rust:github.com/lucasb-eyer/go-colorful": {"https://github.com/Ogeon/palette"},
+			"rust:github.com/mailru/easyjson": {"https://github.com/serde-rs/json"},
+			"rust:github.com/mattn/go-colorable": {"https://github.com/BurntSushi/termcolor"},
+			"rust:github.com/mattn/go-isatty": {"https://github.com/softprops/atty"},
+			"rust:github.com/mattn/go-runewidth": {"https://github.com/unicode-rs/unicode-width"},
+			"rust:github.com/microsoft/go-winio": {"https://github.com/microsoft/windows-rs"},
+			"rust:github.com/miekg/dns": {"https://github.com/hickory-dns/hickory-dns"},
+			"rust:github.com/mitchellh/mapstructure": {"https://github.com/serde-rs/serde"},
+			"rust:github.com/moment/moment": {"https://github.com/chronotope/chrono"},
+			"rust:github.com/motdotla/dotenv": {"https://github.com/allan2/dotenvy"},
+			"rust:github.com/muesli/termenv": {"https://github.com/crossterm-rs/crossterm"},
+			"rust:github.com/munnerz/goautoneg": {"https://github.com/hyperium/headers"},
+			"rust:github.com/mvdan/sh": {"https://crates.io/crates/nu-parser"},
+			"rust:github.com/natefinch/atomic": {"https://github.com/untitaker/atomicwrites-rs"},
+			"rust:github.com/natefinch/lumberjack": {"https://crates.io/crates/tracing-appender"},
+			"rust:github.com/nxadm/tail": {"https://github.com/jmagnuson/linemux"},
+			"rust:github.com/olekukonko/tablewriter": {"https://github.com/phsym/prettytable-rs"},
+			"rust:github.com/ollama/ollama": {"https://github.com/pepperoni21/ollama-rs"},
+			"rust:github.com/open-telemetry/opentelemetry-go": {"https://github.com/open-telemetry/opentelemetry-rust"},
+			"rust:github.com/openai/openai-go": {"https://github.com/64bit/async-openai"},
+			"rust:github.com/opencontainers/go-digest": {"https://github.com/RustCrypto/traits"},
+			"rust:github.com/opencontainers/image-spec": {"https://github.com/containers/oci-spec-rs"},
+			"rust:github.com/pallets/click": {"https://github.com/clap-rs/clap"},
+			"rust:github.com/pallets/flask": {"https://github.com/tokio-rs/axum"},
+			"rust:github.com/pelletier/go-toml": {"https://github.com/toml-rs/toml"},
+			"rust:github.com/pierrec/lz4": {"https://github.com/PSeitz/lz4_flex"},
+			"rust:github.com/pinojs/pino": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/pires/go-proxyproto": {"https://github.com/misalcedo/ppp"},
+			"rust:github.com/pkg/browser": {"https://github.com/amodm/webbrowser-rs"},
+			"rust:github.com/pkg/errors": {"https://github.com/dtolnay/anyhow"},
+			"rust:github.com/pmezard/go-difflib": {"https://github.com/mitsuhiko/similar"},
+			"rust:github.com/pressly/goose": {"https://github.com/rust-db/refinery"},
+			"rust:github.com/prometheus/client_golang": {"https://github.com/prometheus/client_rust"},
+			"rust:github.com/prometheus/client_model": {"https://github.com/prometheus/client_rust"},
+			"rust:github.com/prometheus/common": {"https://github.com/prometheus/client_rust"},
+			"rust:github.com/prometheus/procfs": {"https://github.com/eminence/procfs"},
+			"rust:github.com/puerkitobio/goquery": {"https://github.com/rust-scraper/scraper"},
+			"rust:github.com/redis/redis-py": {"https://github.com/redis-rs/redis-rs"},
+			"rust:github.com/rivo/uniseg": {"https://github.com/unicode-rs/unicode-segmentation"},
+			"rust:github.com/rs/zerolog": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/russross/blackfriday": {"https://github.com/pulldown-cmark/pulldown-cmark"},
+			"rust:github.com/sahilm/fuzzy": {"https://github.com/lotabout/fuzzy-matcher"},
+			"rust:github.com/samber/lo": {"https://github.com/rust-itertools/itertools"},
+			"rust:github.com/sashabaranov/go-openai": {"https://github.com/64bit/async-openai"},
+			"rust:github.com/sergi/go-diff": {"https://github.com/pascalkuthe/imara-diff"},
+			"rust:github.com/sirupsen/logrus": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/sourcegraph/jsonrpc2": {"https://github.com/paritytech/jsonrpsee"},
+			"rust:github.com/spf13/afero": {"https://github.com/manuel-woelker/rust-vfs"},
+			"rust:github.com/spf13/cobra": {"https://github.com/clap-rs/clap"},
+			"rust:github.com/spf13/pflag": {"https://github.com/clap-rs/clap"},
+			"rust:github.com/spf13/viper": {"https://github.com/rust-cli/config-rs"},
+			"rust:github.com/sqlalchemy/sqlalchemy": {"https://github.com/SeaQL/sea-orm"},
+			"rust:github.com/srwiley/oksvg": {"https://github.com/linebender/resvg"},
+			"rust:github.com/srwiley/rasterx": {"https://github.com/linebender/resvg"},
+			"rust:github.com/tdewolff/minify": {"https://github.com/wilsonzlin/minify-html", "https://github.com/GuillaumeGomez/minifier-rs"},
+			"rust:github.com/theskumar/python-dotenv": {"https://github.com/allan2/dotenvy"},
+			"rust:github.com/tiangolo/fastapi": {"https://github.com/tokio-rs/axum"},
+			"rust:github.com/tidwall/gjson": {"https://github.com/serde-rs/json"},
+			"rust:github.com/tidwall/sjson": {"https://github.com/serde-rs/json"},
+			"rust:github.com/tmc/langchaingo": {"https://github.com/Abraxas-365/langchain-rust"},
+			"rust:github.com/typeorm/typeorm": {"https://github.com/SeaQL/sea-orm"},
+			"rust:github.com/uber-go/multierr": {"https://github.com/dtolnay/anyhow"},
+			"rust:github.com/uber-go/zap": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/uiri/toml": {"https://github.com/toml-rs/toml"},
+			"rust:github.com/vishvananda/netlink": {"https://github.com/rust-netlink/netlink"},
+			"rust:github.com/winstonjs/winston": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/yaml/pyyaml": {"https://github.com/dtolnay/serde-yaml"},
+			"rust:github.com/yuin/goldmark": {"https://github.com/pulldown-cmark/pulldown-cmark"},
+			"rust:github.com/zeebo/xxh3": {"https://github.com/shepmaster/twox-hash"},
+		},
+		indexAll: map[string][]string{
+			"go:github.com/auth0/node-jsonwebtoken": {"https://github.com/golang-jwt/jwt"},
+			"go:github.com/axios/axios": {"https://github.com/go-resty/resty"},
+			"go:github.com/brianc/node-postgres": {"https://github.com/jackc/pgx"},
+			"go:github.com/colinhacks/zod": {"https://github.com/go-playground/validator"},
+			"go:github.com/expressjs/express": {"https://github.com/labstack/echo"},
+			"go:github.com/fastify/fastify": {"https://github.com/gofiber/fiber"},
+			"go:github.com/jaredhanson/passport": {"https://github.com/markbates/goth"},
+			"go:github.com/jestjs/jest": {"https://github.com/stretchr/testify"},
+			"go:github.com/knex/knex": {"https://github.com/Masterminds/squirrel"},
+			"go:github.com/lodash/lodash": {"https://github.com/samber/lo"},
+			"go:github.com/lorenwest/node-config": {"https://github.com/knadh/koanf"},
+			"go:github.com/moment/moment": {"https://github.com/dromara/carbon"},
+			"go:github.com/motdotla/dotenv": {"https://github.com/joho/godotenv"},
+			"go:github.com/nestjs/nest": {"https://github.com/uber-go/fx"},
+			"go:github.com/pinojs/pino": {"https://github.com/rs/zerolog"},
+			"go:github.com/prisma/prisma": {"https://github.com/ent/ent"},
+			"go:github.com/socketio/socket.io": {"https://github.com/olahol/melody"},
+			"go:github.com/taskforcesh/bullmq": {"https://github.com/hibiken/asynq"},
+			"go:github.com/typeorm/typeorm": {"https://github.com/go-gorm/gorm"},
+			"go:github.com/winstonjs/winston": {"https://github.com/golang/go"},
+			"js:github.com/dromara/carbon": {"https://github.com/moment/moment"},
+			"js:github.com/ent/ent": {"https://github.com/prisma/prisma"},
+			"js:github.com/gin-gonic/gin": {"https://github.com/expressjs/express"},
+			"js:github.com/go-chi/chi": {"https://github.com/expressjs/express"},
+			"js:github.com/go-gorm/gorm": {"https://github.com/typeorm/typeorm"},
+			"js:github.com/go-playground/validator": {"https://github.com/colinhacks/zod"},
+			"js:github.com/go-resty/resty": {"https://github.com/axios/axios"},
+			"js:github.com/go-yaml/yaml": {"https://github.com/nodeca/js-yaml"},
+			"js:github.com/gofiber/fiber": {"https://github.com/fastify/fastify"},
+			"js:github.com/golang-jwt/jwt": {"https://github.com/auth0/node-jsonwebtoken"},
+			"js:github.com/google/uuid": {"https://github.com/uuidjs/uuid"},
+			"js:github.com/gorilla/mux": {"https://github.com/expressjs/express"},
+			"js:github.com/gorilla/websocket": {"https://github.com/websockets/ws"},
+			"js:github.com/grpc/grpc-go": {"https://github.com/grpc/grpc-node"},
+			"js:github.com/hibiken/asynq": {"https://github.com/taskforcesh/bullmq"},
+			"js:github.com/jackc/pgx": {"https://github.com/brianc/node-postgres"},
+			"js:github.com/joho/godotenv": {"https://github.com/motdotla/dotenv"},
+			"js:github.com/knadh/koanf": {"https://github.com/lorenwest/node-config"},
+			"js:github.com/labstack/echo": {"https://github.com/expressjs/express"},
+			"js:github.com/markbates/goth": {"https://github.com/jaredhanson/passport"},
+			"js:github.com/masterminds/squirrel": {"https://github.com/knex/knex"},
+			"js:github.com/prometheus/client_golang": {"https://github.com/siimon/prom-client"},
+			"js:github.com/rs/zerolog": {"https://github.com/pinojs/pino"},
+			"js:github.com/samber/lo": {"https://github.com/lodash/lodash"},
+			"js:github.com/sirupsen/logrus": {"https://github.com/winstonjs/winston"},
+			"js:github.com/spf13/cobra": {"https://github.com/tj/commander.js"},
+			"js:github.com/spf13/viper": {"https://github.com/lorenwest/node-config"},
+			"js:github.com/stretchr/testify": {"https://github.com/jestjs/jest"},
+			"js:github.com/uber-go/fx": {"https://github.com/nestjs/nest"},
+			"js:github.com/uber-go/zap": {"https://github.com/pinojs/pino"},
+			"rust:github.com/a-h/templ": {"https://github.com/djc/askama"},
+			"rust:github.com/alecthomas/chroma": {"https://github.com/trishume/syntect"},
+			"rust:github.com/alecthomas/kong": {"https://github.com/clap-rs/clap"},
+			"rust:github.com/atotto/clipboard": {"https://github.com/1Password/arboard"},
+			"rust:github.com/auth0/node-jsonwebtoken": {"https://github.com/keats/jsonwebtoken"},
+			"rust:github.com/aws/aws-sdk-go-v2": {"https://github.com/awslabs/aws-sdk-rust"},
+			"rust:github.com/aymanbagabas/go-udiff": {"https://github.com/pascalkuthe/imara-diff"},
+			"rust:github.com/azure/azure-sdk-for-go": {"https://github.com/Azure/azure-sdk-for-rust"},
+			"rust:github.com/beorn7/perks": {"https://github.com/prometheus/client_rust"},
+			"rust:github.com/bmatcuk/doublestar": {"https://github.com/BurntSushi/globset"},
+			"rust:github.com/burntsushi/toml": {"https://github.com/toml-rs/toml"},
+			"rust:github.com/bytedance/sonic": {"https://github.com/cloudwego/sonic-rs"},
+			"rust:github.com/cespare/xxhash": {"https://github.com/shepmaster/twox-hash"},
+			"rust:github.com/charlievieth/fastwalk": {"https://github.com/BurntSushi/walkdir"},
+			"rust:github.com/charmbracelet/bubbles": {"https://github.com/ratatui/ratatui"},
+			"rust:github.com/charmbracelet/bubbletea": {"https://github.com/ratatui/ratatui"},
+			"rust:github.com/charmbracelet/colorprofile": {"https://github.com/crossterm-rs/crossterm"},
+			"rust:github.com/charmbracelet/glamour": {"https://github.com/kivikakk/comrak"},
+			"rust:github.com/charmbracelet/lipgloss": {"https://github.com/ratatui/ratatui"},
+			"rust:github.com/charmbracelet/log": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/charmbracelet/x": {"https://github.com/crossterm-rs/crossterm"},
+			"rust:github.com/cloudflare/cfssl": {"https://github.com/rustls/rustls"},
+			"rust:github.com/containerd/containerd": {"https://github.com/containerd/rust-extensions"},
+			"rust:github.com/coreos/go-systemd": {"https://github.com/lucab/libsystemd-rs"},
+			"rust:github.com/darccio/mergo": {"https://github.com/yanganto/struct-patch"},
+			"rust:github.com/denisbrodbeck/machineid": {"https://github.com/Hanaasagi/machine-uid"},
+			"rust:github.com/disintegration/gift": {"https://github.com/image-rs/imageproc"},
+			"rust:github.com/disintegration/imageorient": {"https://github.com/image-rs/image"},
+			"rust:github.com/dlclark/regexp2": {"https://github.com/fancy-regex/fancy-regex"},
+			"rust:github.com/docker/docker": {"https://github.com/fussybeaver/bollard"},
+			"rust:github.com/docker/go-units": {"https://github.com/iliekturtles/uom"},
+			"rust:github.com/dustin/go-humanize": {"https://github.com/chronotope/humantime"},
+			"rust:github.com/etcd-io/bbolt": {"https://github.com/cberner/redb"},
+			"rust:github.com/etcd-io/etcd": {"https://github.com/etcdv3/etcd-client"},
+			"rust:github.com/expressjs/express": {"https://github.com/tokio-rs/axum"},
+			"rust:github.com/fatih/color": {"https://github.com/ogham/rust-ansi-term"},
+			"rust:github.com/felixge/httpsnoop": {"https://github.com/tower-rs/tower-http"},
+			"rust:github.com/fsnotify/fsnotify": {"https://github.com/notify-rs/notify"},
+			"rust:github.com/gin-gonic/gin": {"https://github.com/tokio-rs/axum"},
+			"rust:github.com/go-chi/chi": {"https://github.com/tokio-rs/axum"},
+			"rust:github.com/go-gorm/gorm": {"https://github.com/SeaQL/sea-orm"},
+			"rust:github.com/go-ini/ini": {"https://github.com/zonyitoo/rust-ini"},
+			"rust:github.com/go-logr/logr": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/go-logr/stdr": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/go-openapi/jsonpointer": {"https://github.com/chanced/jsonptr"},
+			"rust:github.com/go-openapi/swag": {"https://github.com/juhaku/utoipa"},
+			"rust:github.com/go-redis/redis": {"https://github.com/redis-rs/redis-rs"},
+			"rust:github.com/go-yaml/yaml": {"https://github.com/dtolnay/serde-yaml"},
+			"rust:github.com/goccy/go-json": {"https://github.com/cloudwego/sonic-rs"},
+			"rust:github.com/goccy/go-yaml": {"https://github.com/dtolnay/serde-yaml"},
+			"rust:github.com/gogo/protobuf": {"https://github.com/tokio-rs/prost"},
+			"rust:github.com/golang-jwt/jwt": {"https://github.com/keats/jsonwebtoken"},
+			"rust:github.com/golang/crypto": {"https://github.com/RustCrypto/traits"},
+			"rust:github.com/golang/image": {"https://github.com/image-rs/image"},
+			"rust:github.com/golang/mock": {"https://github.com/asomers/mockall"},
+			"rust:github.com/golang/net": {"https://github.com/hyperium/hyper"},
+			"rust:github.com/golang/oauth2": {"https://github.com/ramosbugs/oauth2-rs"},
+			"rust:github.com/golang/protobuf": {"https://github.com/tokio-rs/prost"},
+			"rust:github.com/golang/snappy": {"https://github.com/BurntSushi/rust-snappy"},
+			"rust:github.com/golang/sync": {"https://github.com/tokio-rs/tokio"},
+			"rust:github.com/golang/sys": {"https://github.com/rust-lang/libc"},
+			"rust:github.com/golang/term": {"https://github.com/crossterm-rs/crossterm"},
+			"rust:github.com/golang/text": {"https://github.com/unicode-rs/unicode-normalization"},
+			"rust:github.com/golang/time": {"https://github.com/chronotope/chrono"},
+			"rust:github.com/google/pprof": {"https://github.com/tikv/pprof-rs"},
+			"rust:github.com/google/uuid": {"https://github.com/uuid-rs/uuid"},
+			"rust:github.com/googleapis/gax-go": {"https://github.com/googleapis/google-cloud-rust"},
+			"rust:github.com/googleapis/go-genproto": {"https://github.com/hyperium/tonic"},
+			"rust:github.com/googleapis/google-api-go-client": {"https://github.com/googleapis/google-cloud-rust"},
+			"rust:github.com/googleapis/google-cloud-go": {"https://github.com/googleapis/google-cloud-rust"},
+			"rust:github.com/gorilla/css": {"https://github.com/servo/rust-cssparser"},
+			"rust:github.com/gorilla/mux": {"https://github.com/tokio-rs/axum"},
+			"rust:github.com/gorilla/websocket": {"https://github.com/snapview/tokio-tungstenite"},
+			"rust:github.com/grpc-ecosystem/grpc-gateway": {"https://github.com/hyperium/tonic"},
+			"rust:github.com/grpc/grpc-go": {"https://github.com/hyperium/tonic"},
+			"rust:github.com/hashicorp/golang-lru": {"https://github.com/jeromefroe/lru-rs"},
+			"rust:github.com/invopop/jsonschema": {"https://github.com/GREsau/schemars"},
+			"rust:github.com/joho/godotenv": {"https://github.com/allan2/dotenvy"},
+			"rust:github.com/josharian/intern": {"https://github.com/droundy/internment"},
+			"rust:github.com/jpadilla/pyjwt": {"https://github.com/keats/jsonwebtoken"},
+			"rust:github.com/json-iterator/go": {"https://github.com/serde-rs/json"},
+			"rust:github.com/klauspost/compress": {"https://github.com/gyscos/zstd-rs"},
+			"rust:github.com/klauspost/cpuid": {"https://github.com/gz/rust-cpuid"},
+			"rust:github.com/kolesa-team/go-webp": {"https://github.com/image-rs/image-webp"},
+			"rust:github.com/kubernetes-sigs/yaml": {"https://github.com/dtolnay/serde-yaml"},
+			"rust:github.com/kubernetes/api": {"https://github.com/kube-rs/kube"},
+			"rust:github.com/kubernetes/apimachinery": {"https://github.com/kube-rs/kube"},
+			"rust:github.com/kubernetes/client-go": {"https://github.com/kube-rs/kube"},
+			"rust:github.com/labstack/echo": {"https://github.com/tokio-rs/axum"},
+			"rust:github.com/lorenwest/node-config": {"https://github.com/rust-cli/config-rs"},
+			"rust:github.com/lucasb-eyer/go-colorful": {"https://github.com/Ogeon/palette"},
+			"rust:github.com/mailru/easyjson": {"https://github.com/serde-rs/json"},
+			"rust:github.com/mattn/go-colorable": {"https://github.com/BurntSushi/termcolor"},
+			"rust:github.com/mattn/go-isatty": {"https://github.com/softprops/atty"},
+			"rust:github.com/mattn/go-runewidth": {"https://github.com/unicode-rs/unicode-width"},
+			"rust:github.com/mattn/go-sqlite3": {"https://github.com/rusqlite/rusqlite"},
+			"rust:github.com/microcosm-cc/bluemonday": {"https://github.com/rust-ammonia/ammonia"},
+			"rust:github.com/microsoft/go-winio": {"https://github.com/microsoft/windows-rs"},
+			"rust:github.com/miekg/dns": {"https://github.com/hickory-dns/hickory-dns"},
+			"rust:github.com/mitchellh/mapstructure": {"https://github.com/serde-rs/serde"},
+			"rust:github.com/modern-go/concurrent": {"https://github.com/tokio-rs/tokio"},
+			"rust:github.com/moment/moment": {"https://github.com/chronotope/chrono"},
+			"rust:github.com/motdotla/dotenv": {"https://github.com/allan2/dotenvy"},
+			"rust:github.com/muesli/termenv": {"https://github.com/crossterm-rs/crossterm"},
+			"rust:github.com/munnerz/goautoneg": {"https://github.com/hyperium/headers"},
+			"rust:github.com/mvdan/sh": {"https://crates.io/crates/nu-parser"},
+			"rust:github.com/natefinch/atomic": {"https://github.com/untitaker/atomicwrites-rs"},
+			"rust:github.com/natefinch/lumberjack": {"https://crates.io/crates/tracing-appender"},
+			"rust:github.com/ncruces/go-sqlite3": {"https://github.com/rusqlite/rusqlite"},
+			"rust:github.com/nfnt/resize": {"https://github.com/image-rs/image"},
+			"rust:github.com/nxadm/tail": {"https://github.com/jmagnuson/linemux"},
+			"rust:github.com/olekukonko/tablewriter": {"https://github.com/phsym/prettytable-rs"},
+			"rust:github.com/ollama/ollama": {"https://github.com/pepperoni21/ollama-rs"},
+			"rust:github.com/open-telemetry/opentelemetry-go": {"https://github.com/open-telemetry/opentelemetry-rust"},
+			"rust:github.com/openai/openai-go": {"https://github.com/64bit/async-openai"},
+			"rust:github.com/opencontainers/go-digest": {"https://github.com/RustCrypto/traits"},
+			"rust:github.com/opencontainers/image-spec": {"https://github.com/containers/oci-spec-rs"},
+			"rust:github.com/pallets/click": {"https://github.com/clap-rs/clap"},
+			"rust:github.com/pallets/flask": {"https://github.com/tokio-rs/axum"},
+			"rust:github.com/pelletier/go-toml": {"https://github.com/toml-rs/toml"},
+			"rust:github.com/pierrec/lz4": {"https://github.com/PSeitz/lz4_flex"},
+			"rust:github.com/pinojs/pino": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/pires/go-proxyproto": {"https://github.com/misalcedo/ppp"},
+			"rust:github.com/pkg/browser": {"https://github.com/amodm/webbrowser-rs"},
+			"rust:github.com/pkg/errors": {"https://github.com/dtolnay/anyhow"},
+			"rust:github.com/pmezard/go-difflib": {"https://github.com/mitsuhiko/similar"},
+			"rust:github.com/pressly/goose": {"https://github.com/rust-db/refinery"},
+			"rust:github.com/prometheus/client_golang": {"https://github.com/prometheus/client_rust"},
+			"rust:github.com/prometheus/client_model": {"https://github.com/prometheus/client_rust"},
+			"rust:github.com/prometheus/common": {"https://github.com/prometheus/client_rust"},
+			"rust:github.com/prometheus/procfs": {"https://github.com/eminence/procfs"},
+			"rust:github.com/protocolbuffers/protobuf-go": {"https://github.com/tokio-rs/prost"},
+			"rust:github.com/puerkitobio/goquery": {"https://github.com/rust-scraper/scraper"},
+			"rust:github.com/quic-go/quic-go": {"https://github.com/quinn-rs/quinn"},
+			"rust:github.com/redis/redis-py": {"https://github.com/redis-rs/redis-rs"},
+			"rust:github.com/rivo/uniseg": {"https://github.com/unicode-rs/unicode-segmentation"},
+			"rust:github.com/rs/zerolog": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/russross/blackfriday": {"https://github.com/pulldown-cmark/pulldown-cmark"},
+			"rust:github.com/sabhiram/go-gitignore": {"https://crates.io/crates/ignore"},
+			"rust:github.com/sahilm/fuzzy": {"https://github.com/lotabout/fuzzy-matcher"},
+			"rust:github.com/samber/lo": {"https://github.com/rust-itertools/itertools"},
+			"rust:github.com/sashabaranov/go-openai": {"https://github.com/64bit/async-openai"},
+			"rust:github.com/sergi/go-diff": {"https://github.com/pascalkuthe/imara-diff"},
+			"rust:github.com/sirupsen/logrus": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/sourcegraph/jsonrpc2": {"https://github.com/paritytech/jsonrpsee"},
+			"rust:github.com/spf13/afero": {"https://github.com/manuel-woelker/rust-vfs"},
+			"rust:github.com/spf13/cobra": {"https://github.com/clap-rs/clap"},
+			"rust:github.com/spf13/pflag": {"https://github.com/clap-rs/clap"},
+			"rust:github.com/spf13/viper": {"https://github.com/rust-cli/config-rs"},
+			"rust:github.com/sqlalchemy/sqlalchemy": {"https://github.com/SeaQL/sea-orm"},
+			"rust:github.com/srwiley/oksvg": {"https://github.com/linebender/resvg"},
+			"rust:github.com/srwiley/rasterx": {"https://github.com/linebender/resvg"},
+			"rust:github.com/tdewolff/minify": {"https://github.com/wilsonzlin/minify-html", "https://github.com/GuillaumeGomez/minifier-rs"},
+			"rust:github.com/tetratelabs/wazero": {"https://github.com/bytecodealliance/wasmtime"},
+			"rust:github.com/theskumar/python-dotenv": {"https://github.com/allan2/dotenvy"},
+			"rust:github.com/tiangolo/fastapi": {"https://github.com/tokio-rs/axum"},
+			"rust:github.com/tidwall/gjson": {"https://github.com/serde-rs/json"},
+			"rust:github.com/tidwall/sjson": {"https://github.com/serde-rs/json"},
+			"rust:github.com/tmc/langchaingo": {"https://github.com/Abraxas-365/langchain-rust"},
+			"rust:github.com/typeorm/typeorm": {"https://github.com/SeaQL/sea-orm"},
+			"rust:github.com/uber-go/multierr": {"https://github.com/dtolnay/anyhow"},
+			"rust:github.com/uber-go/zap": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/uiri/toml": {"https://github.com/toml-rs/toml"},
+			"rust:github.com/valyala/fasthttp": {"https://github.com/hyperium/hyper"},
+			"rust:github.com/vishvananda/netlink": {"https://github.com/rust-netlink/netlink"},
+			"rust:github.com/winstonjs/winston": {"https://github.com/tokio-rs/tracing"},
+			"rust:github.com/yaml/pyyaml": {"https://github.com/dtolnay/serde-yaml"},
+			"rust:github.com/yuin/goldmark": {"https://github.com/pulldown-cmark/pulldown-cmark"},
+			"rust:github.com/zeebo/xxh3": {"https://github.com/shepmaster/twox-hash"},
+		},
+		reverseIndex: map[string][]string{
+			"go:crates.io/crates/nu-parser": {"https://github.com/mvdan/sh"},
+			"go:crates.io/crates/tracing-appender": {"https://github.com/natefinch/lumberjack"},
+			"go:github.com/1password/arboard": {"https://github.com/atotto/clipboard"},
+			"go:github.com/64bit/async-openai": {"https://github.com/openai/openai-go", "https://github.com/sashabaranov/go-openai"},
+			"go:github.com/abraxas-365/langchain-rust": {"https://github.com/tmc/langchaingo"},
+			"go:github.com/allan2/dotenvy": {"https://github.com/joho/godotenv"},
+			"go:github.com/amodm/webbrowser-rs": {"https://github.com/pkg/browser"},
+			"go:github.com/asomers/mockall": {"https://github.com/golang/mock"},
+			"go:github.com/auth0/node-jsonwebtoken": {"https://github.com/golang-jwt/jwt"},
+			"go:github.com/awslabs/aws-sdk-rust": {"https://github.com/aws/aws-sdk-go-v2"},
+			"go:github.com/axios/axios": {"https://github.com/go-resty/resty"},
+			"go:github.com/azure/azure-sdk-for-rust": {"https://github.com/Azure/azure-sdk-for-go"},
+			"go:github.com/brianc/node-postgres": {"https://github.com/jackc/pgx"},
+			"go:github.com/burntsushi/globset": {"https://github.com/bmatcuk/doublestar"},
+			"go:github.com/burntsushi/rust-snappy": {"https://github.com/golang/snappy"},
+			"go:github.com/burntsushi/termcolor": {"https://github.com/mattn/go-colorable"},
+			"go:github.com/burntsushi/walkdir": {"https://github.com/charlievieth/fastwalk"},
+			"go:github.com/cberner/redb": {"https://github.com/etcd-io/bbolt"},
+			"go:github.com/chanced/jsonptr": {"https://github.com/go-openapi/jsonpointer"},
+			"go:github.com/chronotope/chrono": {"https://github.com/golang/time"},
+			"go:github.com/chronotope/humantime": {"https://github.com/dustin/go-humanize"},
+			"go:github.com/clap-rs/clap": {"https://github.com/alecthomas/kong", "https://github.com/spf13/cobra", "https://github.com/spf13/pflag"},
+			"go:github.com/cloudwego/sonic-rs": {"https://github.com/bytedance/sonic", "https://github.com/goccy/go-json"},
+			"go:github.com/colinhacks/zod": {"https://github.com/go-playground/validator"},
+			"go:github.com/containerd/rust-extensions": {"https://github.com/containerd/containerd"},
+			"go:github.com/containers/oci-spec-rs": {"https://github.com/opencontainers/image-spec"},
+			"go:github.com/crossterm-rs/crossterm": {"https://github.com/charmbracelet/colorprofile", "https://github.com/charmbracelet/x", "https://github.com/golang/term", "https://github.com/muesli/termenv"},
+			"go:github.com/djc/askama": {"https://github.com/a-h/templ"},
+			"go:github.com/droundy/internment": {"https://github.com/josharian/intern"},
+			"go:github.com/dtolnay/anyhow": {"https://github.com/pkg/errors", "https://github.com/uber-go/multierr"},
+			"go:github.com/dtolnay/serde-yaml": {"https://github.com/go-yaml/yaml", "https://github.com/goccy/go-yaml", "https://github.com/kubernetes-sigs/yaml"},
+			"go:github.com/eminence/procfs": {"https://github.com/prometheus/procfs"},
+			"go:github.com/etcdv3/etcd-client": {"https://github.com/etcd-io/etcd"},
+			"go:github.com/expressjs/express": {"https://github.com/gin-gonic/gin", "https://github.com/labstack/echo", "https://github.com/go-chi/chi", "https://github.com/gorilla/mux"},
+			"go:github.com/fancy-regex/fancy-regex": {"https://github.com/dlclark/regexp2"},
+			"go:github.com/fastify/fastify": {"https://github.com/gofiber/fiber"},
+			"go:github.com/fussybeaver/bollard": {"https://github.com/docker/docker"},
+			"go:github.com/googleapis/google-cloud-rust": {"https://github.com/googleapis/google-api-go-client", "https://github.com/googleapis/google-cloud-go", "https://github.com/googleapis/gax-go"},
+			"go:github.com/gresau/schemars": {"https://github.com/invopop/jsonschema"},
+			"go:github.com/grpc/grpc-node": {"https://github.com/grpc/grpc-go"},
+			"go:github.com/guillaumegomez/minifier-rs": {"https://github.com/tdewolff/minify"},
+			"go:github.com/gyscos/zstd-rs": {"https://github.com/klauspost/compress"},
+			"go:github.com/gz/rust-cpuid": {"https://github.com/klauspost/cpuid"},
+			"go:github.com/hanaasagi/machine-uid": {"https://github.com/denisbrodbeck/machineid"},
+			"go:github.com/hickory-dns/hickory-dns": {"https://github.com/miekg/dns"},
+			"go:github.com/hyperium/headers": {"https://github.com/munnerz/goautoneg"},
+			"go:github.com/hyperium/tonic": {"https://github.com/grpc/grpc-go", "https://github.com/googleapis/go-genproto", "https://github.com/grpc-ecosystem/grpc-gateway"},
+			"go:github.com/iliekturtles/uom": {"https://github.com/docker/go-units"},
+			"go:github.com/image-rs/image-webp": {"https://github.com/kolesa-team/go-webp"},
+			"go:github.com/image-rs/imageproc": {"https://github.com/disintegration/gift"},
+			"go:github.com/jaredhanson/passport": {"https://github.com/markbates/goth"},
+			"go:github.com/jeromefroe/lru-rs": {"https://github.com/hashicorp/golang-lru"},
+			"go:github.com/jestjs/jest": {"https://github.com/stretchr/testify"},
+			"go:github.com/jmagnuson/linemux": {"https://github.com/nxadm/tail"},
+			"go:github.com/juhaku/utoipa": {"https://github.com/go-openapi/swag"},
+			"go:github.com/keats/jsonwebtoken": {"https://github.com/golang-jwt/jwt"},
+			"go:github.com/kivikakk/comrak": {"https://github.com/charmbracelet/glamour"},
+			"go:github.com/knex/knex": {"https://github.com/Masterminds/squirrel"},
+			"go:github.com/kube-rs/kube": {"https://github.com/kubernetes/api", "https://github.com/kubernetes/apimachinery", "https://github.com/kubernetes/client-go"},
+			"go:github.com/linebender/resvg": {"https://github.com/srwiley/oksvg", "https://github.com/srwiley/rasterx"},
+			"go:github.com/lodash/lodash": {"https://github.com/samber/lo"},
+			"go:github.com/lorenwest/node-config": {"https://github.com/spf13/viper", "https://github.com/knadh/koanf"},
+			"go:github.com/lotabout/fuzzy-matcher": {"https://github.com/sahilm/fuzzy"},
+			"go:github.com/lucab/libsystemd-rs": {"https://github.com/coreos/go-systemd"},
+			"go:github.com/manuel-woelker/rust-vfs": {"https://github.com/spf13/afero"},
+			"go:github.com/microsoft/windows-rs": {"https://github.com/Microsoft/go-winio"},
+			"go:github.com/misalcedo/ppp": {"https://github.com/pires/go-proxyproto"},
+			"go:github.com/mitsuhiko/similar": {"https://github.com/pmezard/go-difflib"},
+			"go:github.com/moment/moment": {"https://github.com/dromara/carbon"},
+			"go:github.com/motdotla/dotenv": {"https://github.com/joho/godotenv"},
+			"go:github.com/nestjs/nest": {"https://github.com/uber-go/fx"},
+			"go:github.com/nodeca/js-yaml": {"https://github.com/go-yaml/yaml"},
+			"go:github.com/notify-rs/notify": {"https://github.com/fsnotify/fsnotify"},
+			"go:github.com/ogeon/palette": {"https://github.com/lucasb-eyer/go-colorful"},
+			"go:github.com/ogham/rust-ansi-term": {"https://github.com/fatih/color"},
+			"go:github.com/open-telemetry/opentelemetry-rust": {"https://github.com/open-telemetry/opentelemetry-go"},
+			"go:github.com/paritytech/jsonrpsee": {"https://github.com/sourcegraph/jsonrpc2"},
+			"go:github.com/pascalkuthe/imara-diff": {"https://github.com/aymanbagabas/go-udiff", "https://github.com/sergi/go-diff"},
+			"go:github.com/pepperoni21/ollama-rs": {"https://github.com/ollama/ollama"},
+			"go:github.com/phsym/prettytable-rs": {"https://github.com/olekukonko/tablewriter"},
+			"go:github.com/pinojs/pino": {"https://github.com/uber-go/zap", "https://github.com/rs/zerolog"},
+			"go:github.com/prisma/prisma": {"https://github.com/ent/ent"},
+			"go:github.com/prometheus/client_rust": {"https://github.com/prometheus/client_golang", "https://github.com/beorn7/perks", "https://github.com/prometheus/client_model", "https://github.com/prometheus/common"},
+			"go:github.com/pseitz/lz4_flex": {"https://github.com/pierrec/lz4"},
+			"go:github.com/pulldown-cmark/pulldown-cmark": {"https://github.com/yuin/goldmark", "https://github.com/russross/blackfriday"},
+			"go:github.com/ramosbugs/oauth2-rs": {"https://github.com/golang/oauth2"},
+			"go:github.com/ratatui/ratatui": {"https://github.com/charmbracelet/bubbles", "https://github.com/charmbracelet/bubbletea", "https://github.com/charmbracelet/lipgloss"},
+			"go:github.com/redis-rs/redis-rs": {"https://github.com/go-redis/redis"},
+			"go:github.com/rust-cli/config-rs": {"https://github.com/spf13/viper"},
+			"go:github.com/rust-db/refinery": {"https://github.com/pressly/goose"},
+			"go:github.com/rust-itertools/itertools": {"https://github.com/samber/lo"},
+			"go:github.com/rust-lang/libc": {"https://github.com/golang/sys"},
+			"go:github.com/rust-netlink/netlink": {"https://github.com/vishvananda/netlink"},
+			"go:github.com/rust-scraper/scraper": {"https://github.com/PuerkitoBio/goquery"},
+			"go:github.com/rustcrypto/traits": {"https://github.com/golang/crypto", "https://github.com/opencontainers/go-digest"},
+			"go:github.com/seaql/sea-orm": {"https://github.com/go-gorm/gorm"},
+			"go:github.com/serde-rs/json": {"https://github.com/json-iterator/go", "https://github.com/tidwall/gjson", "https://github.com/tidwall/sjson", "https://github.com/mailru/easyjson"},
+			"go:github.com/serde-rs/serde": {"https://github.com/mitchellh/mapstructure"},
+			"go:github.com/servo/rust-cssparser": {"https://github.com/gorilla/css"},
+			"go:github.com/shepmaster/twox-hash": {"https://github.com/cespare/xxhash", "https://github.com/zeebo/xxh3"},
+			"go:github.com/siimon/prom-client": {"https://github.com/prometheus/client_golang"},
+			"go:github.com/snapview/tokio-tungstenite": {"https://github.com/gorilla/websocket"},
+			"go:github.com/softprops/atty": {"https://github.com/mattn/go-isatty"},
+			"go:github.com/taskforcesh/bullmq": {"https://github.com/hibiken/asynq"},
+			"go:github.com/tikv/pprof-rs": {"https://github.com/google/pprof"},
+			"go:github.com/tj/commander.js": {"https://github.com/spf13/cobra"},
+			"go:github.com/tokio-rs/axum": {"https://github.com/gin-gonic/gin", "https://github.com/go-chi/chi", "https://github.com/gorilla/mux", "https://github.com/labstack/echo"},
+			"go:github.com/tokio-rs/tracing": {"https://github.com/charmbracelet/log", "https://github.com/rs/zerolog", "https://github.com/sirupsen/logrus", "https://github.com/uber-go/zap", "https://github.com/go-logr/logr", "https://github.com/go-logr/stdr"},
+			"go:github.com/toml-rs/toml": {"https://github.com/BurntSushi/toml", "https://github.com/pelletier/go-toml"},
+			"go:github.com/trishume/syntect": {"https://github.com/alecthomas/chroma"},
+			"go:github.com/typeorm/typeorm": {"https://github.com/go-gorm/gorm"},
+			"go:github.com/unicode-rs/unicode-normalization": {"https://github.com/golang/text"},
+			"go:github.com/unicode-rs/unicode-segmentation": {"https://github.com/rivo/uniseg"},
+			"go:github.com/unicode-rs/unicode-width": {"https://github.com/mattn/go-runewidth"},
+			"go:github.com/untitaker/atomicwrites-rs": {"https://github.com/natefinch/atomic"},
+			"go:github.com/uuid-rs/uuid": {"https://github.com/google/uuid"},
+			"go:github.com/uuidjs/uuid": {"https://github.com/google/uuid"},
+			"go:github.com/websockets/ws": {"https://github.com/gorilla/websocket"},
+			"go:github.com/wilsonzlin/minify-html": {"https://github.com/tdewolff/minify"},
+			"go:github.com/winstonjs/winston": {"https://github.com/sirupsen/logrus"},
+			"go:github.com/yanganto/struct-patch": {"https://github.com/darccio/mergo"},
+			"go:github.com/zonyitoo/rust-ini": {"https://github.com/go-ini/ini"},
+			"js:github.com/allan2/dotenvy": {"https://github.com/motdotla/dotenv"},
+			"js:github.com/chronotope/chrono": {"https://github.com/moment/moment"},
+			"js:github.com/dromara/carbon": {"https://github.com/moment/moment"},
+			"js:github.com/ent/ent": {"https://github.com/prisma/prisma"},
+			"js:github.com/go-gorm/gorm": {"https://github.com/typeorm/typeorm"},
+			"js:github.com/go-playground/validator": {"https://github.com/colinhacks/zod"},
+			"js:github.com/go-resty/resty": {"https://github.com/axios/axios"},
+			"js:github.com/gofiber/fiber": {"https://github.com/fastify/fastify"},
+			"js:github.com/golang-jwt/jwt": {"https://github.com/auth0/node-jsonwebtoken"},
+			"js:github.com/golang/go": {"https://github.com/winstonjs/winston"},
+			"js:github.com/hibiken/asynq": {"https://github.com/taskforcesh/bullmq"},
+			"js:github.com/jackc/pgx": {"https://github.com/brianc/node-postgres"},
+			"js:github.com/joho/godotenv": {"https://github.com/motdotla/dotenv"},
+			"js:github.com/keats/jsonwebtoken": {"https://github.com/auth0/node-jsonwebtoken"},
+			"js:github.com/knadh/koanf": {"https://github.com/lorenwest/node-config"},
+			"js:github.com/labstack/echo": {"https://github.com/expressjs/express"},
+			"js:github.com/markbates/goth": {"https://github.com/jaredhanson/passport"},
+			"js:github.com/masterminds/squirrel": {"https://github.com/knex/knex"},
+			"js:github.com/olahol/melody": {"https://github.com/socketio/socket.io"},
+			"js:github.com/rs/zerolog": {"https://github.com/pinojs/pino"},
+			"js:github.com/rust-cli/config-rs": {"https://github.com/lorenwest/node-config"},
+			"js:github.com/samber/lo": {"https://github.com/lodash/lodash"},
+			"js:github.com/seaql/sea-orm": {"https://github.com/typeorm/typeorm"},
+			"js:github.com/stretchr/testify": {"https://github.com/jestjs/jest"},
+			"js:github.com/tokio-rs/axum": {"https://github.com/expressjs/express"},
+			"js:github.com/tokio-rs/tracing": {"https://github.com/winstonjs/winston", "https://github.com/pinojs/pino"},
+			"js:github.com/uber-go/fx": {"https://github.com/nestjs/nest"},
+			"python:github.com/allan2/dotenvy": {"https://github.com/theskumar/python-dotenv"},
+			"python:github.com/clap-rs/clap": {"https://github.com/pallets/click"},
+			"python:github.com/dtolnay/serde-yaml": {"https://github.com/yaml/pyyaml"},
+			"python:github.com/keats/jsonwebtoken": {"https://github.com/jpadilla/pyjwt"},
+			"python:github.com/redis-rs/redis-rs": {"https://github.com/redis/redis-py"},
+			"python:github.com/seaql/sea-orm": {"https://github.com/sqlalchemy/sqlalchemy"},
+			"python:github.com/tokio-rs/axum": {"https://github.com/pallets/flask", "https://github.com/tiangolo/fastapi"},
+			"python:github.com/toml-rs/toml": {"https://github.com/uiri/toml"},
+		},
+		reverseIndexAll: map[string][]string{
+			"go:crates.io/crates/ignore": {"https://github.com/sabhiram/go-gitignore"},
+			"go:crates.io/crates/nu-parser": {"https://github.com/mvdan/sh"},
+			"go:crates.io/crates/tracing-appender": {"https://github.com/natefinch/lumberjack"},
+			"go:github.com/1password/arboard": {"https://github.com/atotto/clipboard"},
+			"go:github.com/64bit/async-openai": {"https://github.com/openai/openai-go", "https://github.com/sashabaranov/go-openai"},
+			"go:github.com/abraxas-365/langchain-rust": {"https://github.com/tmc/langchaingo"},
+			"go:github.com/allan2/dotenvy": {"https://github.com/joho/godotenv"},
+			"go:github.com/amodm/webbrowser-rs": {"https://github.com/pkg/browser"},
+			"go:github.com/asomers/mockall": {"https://github.com/golang/mock"},
+			"go:github.com/auth0/node-jsonwebtoken": {"https://github.com/golang-jwt/jwt"},
+			"go:github.com/awslabs/aws-sdk-rust": {"https://github.com/aws/aws-sdk-go-v2"},
+			"go:github.com/axios/axios": {"https://github.com/go-resty/resty"},
+			"go:github.com/azure/azure-sdk-for-rust": {"https://github.com/Azure/azure-sdk-for-go"},
+			"go:github.com/brianc/node-postgres": {"https://github.com/jackc/pgx"},
+			"go:github.com/burntsushi/globset": {"https://github.com/bmatcuk/doublestar"},
+			"go:github.com/burntsushi/rust-snappy": {"https://github.com/golang/snappy"},
+			"go:github.com/burntsushi/termcolor": {"https://github.com/mattn/go-colorable"},
+			"go:github.com/burntsushi/walkdir": {"https://github.com/charlievieth/fastwalk"},
+			"go:github.com/bytecodealliance/wasmtime": {"https://github.com/tetratelabs/wazero"},
+			"go:github.com/cberner/redb": {"https://github.com/etcd-io/bbolt"},
+			"go:github.com/chanced/jsonptr": {"https://github.com/go-openapi/jsonpointer"},
+			"go:github.com/chronotope/chrono": {"https://github.com/golang/time"},
+			"go:github.com/chronotope/humantime": {"https://github.com/dustin/go-humanize"},
+			"go:github.com/clap-rs/clap": {"https://github.com/alecthomas/kong", "https://github.com/spf13/cobra", "https://github.com/spf13/pflag"},
+			"go:github.com/cloudwego/sonic-rs": {"https://github.com/bytedance/sonic", "https://github.com/goccy/go-json"},
+			"go:github.com/colinhacks/zod": {"https://github.com/go-playground/validator"},
+			"go:github.com/containerd/rust-extensions": {"https://github.com/containerd/containerd"},
+			"go:github.com/containers/oci-spec-rs": {"https://github.com/opencontainers/image-spec"},
+			"go:github.com/crossterm-rs/crossterm": {"https://github.com/charmbracelet/colorprofile", "https://github.com/charmbracelet/x", "https://github.com/golang/term", "https://github.com/muesli/termenv"},
+			"go:github.com/djc/askama": {"https://github.com/a-h/templ"},
+			"go:github.com/droundy/internment": {"https://github.com/josharian/intern"},
+			"go:github.com/dtolnay/anyhow": {"https://github.com/pkg/errors", "https://github.com/uber-go/multierr"},
+			"go:github.com/dtolnay/serde-yaml": {"https://github.com/go-yaml/yaml", "https://github.com/goccy/go-yaml", "https://github.com/kubernetes-sigs/yaml"},
+			"go:github.com/eminence/procfs": {"https://github.com/prometheus/procfs"},
+			"go:github.com/etcdv3/etcd-client": {"https://github.com/etcd-io/etcd"},
+			"go:github.com/expressjs/express": {"https://github.com/gin-gonic/gin", "https://github.com/labstack/echo", "https://github.com/go-chi/chi", "https://github.com/gorilla/mux"},
+			"go:github.com/fancy-regex/fancy-regex": {"https://github.com/dlclark/regexp2"},
+			"go:github.com/fastify/fastify": {"https://github.com/gofiber/fiber"},
+			"go:github.com/fussybeaver/bollard": {"https://github.com/docker/docker"},
+			"go:github.com/googleapis/google-cloud-rust": {"https://github.com/googleapis/google-api-go-client", "https://github.com/googleapis/google-cloud-go", "https://github.com/googleapis/gax-go"},
+			"go:github.com/gresau/schemars": {"https://github.com/invopop/jsonschema"},
+			"go:github.com/grpc/grpc-node": {"https://github.com/grpc/grpc-go"},
+			"go:github.com/guillaumegomez/minifier-rs": {"https://github.com/tdewolff/minify"},
+			"go:github.com/gyscos/zstd-rs": {"https://github.com/klauspost/compress"},
+			"go:github.com/gz/rust-cpuid": {"https://github.com/klauspost/cpuid"},
+			"go:github.com/hanaasagi/machine-uid": {"https://github.com/denisbrodbeck/machineid"},
+			"go:github.com/hickory-dns/hickory-dns": {"https://github.com/miekg/dns"},
+			"go:github.com/hyperium/headers": {"https://github.com/munnerz/goautoneg"},
+			"go:github.com/hyperium/hyper": {"https://github.com/golang/net", "https://github.com/valyala/fasthttp"},
+			"go:github.com/hyperium/tonic": {"https://github.com/grpc/grpc-go", "https://github.com/googleapis/go-genproto", "https://github.com/grpc-ecosystem/grpc-gateway"},
+			"go:github.com/iliekturtles/uom": {"https://github.com/docker/go-units"},
+			"go:github.com/image-rs/image": {"https://github.com/disintegration/imageorient", "https://github.com/golang/image", "https://github.com/nfnt/resize"},
+			"go:github.com/image-rs/image-webp": {"https://github.com/kolesa-team/go-webp"},
+			"go:github.com/image-rs/imageproc": {"https://github.com/disintegration/gift"},
+			"go:github.com/jaredhanson/passport": {"https://github.com/markbates/goth"},
+			"go:github.com/jeromefroe/lru-rs": {"https://github.com/hashicorp/golang-lru"},
+			"go:github.com/jestjs/jest": {"https://github.com/stretchr/testify"},
+			"go:github.com/jmagnuson/linemux": {"https://github.com/nxadm/tail"},
+			"go:github.com/juhaku/utoipa": {"https://github.com/go-openapi/swag"},
+			"go:github.com/keats/jsonwebtoken": {"https://github.com/golang-jwt/jwt"},
+			"go:github.com/kivikakk/comrak": {"https://github.com/charmbracelet/glamour"},
+			"go:github.com/knex/knex": {"https://github.com/Masterminds/squirrel"},
+			"go:github.com/kube-rs/kube": {"https://github.com/kubernetes/api", "https://github.com/kubernetes/apimachinery", "https://github.com/kubernetes/client-go"},
+			"go:github.com/linebender/resvg": {"https://github.com/srwiley/oksvg", "https://github.com/srwiley/rasterx"},
+			"go:github.com/lodash/lodash": {"https://github.com/samber/lo"},
+			"go:github.com/lorenwest/node-config": {"https://github.com/spf13/viper", "https://github.com/knadh/koanf"},
+			"go:github.com/lotabout/fuzzy-matcher": {"https://github.com/sahilm/fuzzy"},
+			"go:github.com/lucab/libsystemd-rs": {"https://github.com/coreos/go-systemd"},
+			"go:github.com/manuel-woelker/rust-vfs": {"https://github.com/spf13/afero"},
+			"go:github.com/microsoft/windows-rs": {"https://github.com/Microsoft/go-winio"},
+			"go:github.com/misalcedo/ppp": {"https://github.com/pires/go-proxyproto"},
+			"go:github.com/mitsuhiko/similar": {"https://github.com/pmezard/go-difflib"},
+			"go:github.com/moment/moment": {"https://github.com/dromara/carbon"},
+			"go:github.com/motdotla/dotenv": {"https://github.com/joho/godotenv"},
+			"go:github.com/nestjs/nest": {"https://github.com/uber-go/fx"},
+			"go:github.com/nodeca/js-yaml": {"https://github.com/go-yaml/yaml"},
+			"go:github.com/notify-rs/notify": {"https://github.com/fsnotify/fsnotify"},
+			"go:github.com/ogeon/palette": {"https://github.com/lucasb-eyer/go-colorful"},
+			"go:github.com/ogham/rust-ansi-term": {"https://github.com/fatih/color"},
+			"go:github.com/open-telemetry/opentelemetry-rust": {"https://github.com/open-telemetry/opentelemetry-go"},
+			"go:github.com/paritytech/jsonrpsee": {"https://github.com/sourcegraph/jsonrpc2"},
+			"go:github.com/pascalkuthe/imara-diff": {"https://github.com/aymanbagabas/go-udiff", "https://github.com/sergi/go-diff"},
+			"go:github.com/pepperoni21/ollama-rs": {"https://github.com/ollama/ollama"},
+			"go:github.com/phsym/prettytable-rs": {"https://github.com/olekukonko/tablewriter"},
+			"go:github.com/pinojs/pino": {"https://github.com/uber-go/zap", "https://github.com/rs/zerolog"},
+			"go:github.com/prisma/prisma": {"https://github.com/ent/ent"},
+			"go:github.com/prometheus/client_rust": {"https://github.com/prometheus/client_golang", "https://github.com/beorn7/perks", "https://github.com/prometheus/client_model", "https://github.com/prometheus/common"},
+			"go:github.com/pseitz/lz4_flex": {"https://github.com/pierrec/lz4"},
+			"go:github.com/pulldown-cmark/pulldown-cmark": {"https://github.com/yuin/goldmark", "https://github.com/russross/blackfriday"},
+			"go:github.com/quinn-rs/quinn": {"https://github.com/quic-go/quic-go"},
+			"go:github.com/ramosbugs/oauth2-rs": {"https://github.com/golang/oauth2"},
+			"go:github.com/ratatui/ratatui": {"https://github.com/charmbracelet/bubbles", "https://github.com/charmbracelet/bubbletea", "https://github.com/charmbracelet/lipgloss"},
+			"go:github.com/redis-rs/redis-rs": {"https://github.com/go-redis/redis"},
+			"go:github.com/rusqlite/rusqlite": {"https://github.com/mattn/go-sqlite3", "https://github.com/ncruces/go-sqlite3"},
+			"go:github.com/rust-ammonia/ammonia": {"https://github.com/microcosm-cc/bluemonday"},
+			"go:github.com/rust-cli/config-rs": {"https://github.com/spf13/viper"},
+			"go:github.com/rust-db/refinery": {"https://github.com/pressly/goose"},
+			"go:github.com/rust-itertools/itertools": {"https://github.com/samber/lo"},
+			"go:github.com/rust-lang/libc": {"https://github.com/golang/sys"},
+			"go:github.com/rust-netlink/netlink": {"https://github.com/vishvananda/netlink"},
+			"go:github.com/rust-scraper/scraper": {"https://github.com/PuerkitoBio/goquery"},
+			"go:github.com/rustcrypto/traits": {"https://github.com/golang/crypto", "https://github.com/opencontainers/go-digest"},
+			"go:github.com/rustls/rustls": {"https://github.com/cloudflare/cfssl"},
+			"go:github.com/seaql/sea-orm": {"https://github.com/go-gorm/gorm"},
+			"go:github.com/serde-rs/json": {"https://github.com/json-iterator/go", "https://github.com/tidwall/gjson", "https://github.com/tidwall/sjson", "https://github.com/mailru/easyjson"},
+			"go:github.com/serde-rs/serde": {"https://github.com/mitchellh/mapstructure"},
+			"go:github.com/servo/rust-cssparser": {"https://github.com/gorilla/css"},
+			"go:github.com/shepmaster/twox-hash": {"https://github.com/cespare/xxhash", "https://github.com/zeebo/xxh3"},
+			"go:github.com/siimon/prom-client": {"https://github.com/prometheus/client_golang"},
+			"go:github.com/snapview/tokio-tungstenite": {"https://github.com/gorilla/websocket"},
+			"go:github.com/softprops/atty": {"https://github.com/mattn/go-isatty"},
+			"go:github.com/taskforcesh/bullmq": {"https://github.com/hibiken/asynq"},
+			"go:github.com/tikv/pprof-rs": {"https://github.com/google/pprof"},
+			"go:github.com/tj/commander.js": {"https://github.com/spf13/cobra"},
+			"go:github.com/tokio-rs/axum": {"https://github.com/gin-gonic/gin", "https://github.com/go-chi/chi", "https://github.com/gorilla/mux", "https://github.com/labstack/echo"},
+			"go:github.com/tokio-rs/prost": {"https://github.com/gogo/protobuf", "https://github.com/protocolbuffers/protobuf-go", "https://github.com/golang/protobuf"},
+			"go:github.com/tokio-rs/tokio": {"https://github.com/golang/sync", "https://github.com/modern-go/concurrent"},
+			"go:github.com/tokio-rs/tracing": {"https://github.com/charmbracelet/log", "https://github.com/rs/zerolog", "https://github.com/sirupsen/logrus", "https://github.com/uber-go/zap", "https://github.com/go-logr/logr", "https://github.com/go-logr/stdr"},
+			"go:github.com/toml-rs/toml": {"https://github.com/BurntSushi/toml", "https://github.com/pelletier/go-toml"},
+			"go:github.com/tower-rs/tower-http": {"https://github.com/felixge/httpsnoop"},
+			"go:github.com/trishume/syntect": {"https://github.com/alecthomas/chroma"},
+			"go:github.com/typeorm/typeorm": {"https://github.com/go-gorm/gorm"},
+			"go:github.com/unicode-rs/unicode-normalization": {"https://github.com/golang/text"},
+			"go:github.com/unicode-rs/unicode-segmentation": {"https://github.com/rivo/uniseg"},
+			"go:github.com/unicode-rs/unicode-width": {"https://github.com/mattn/go-runewidth"},
+			"go:github.com/untitaker/atomicwrites-rs": {"https://github.com/natefinch/atomic"},
+			"go:github.com/uuid-rs/uuid": {"https://github.com/google/uuid"},
+			"go:github.com/uuidjs/uuid": {"https://github.com/google/uuid"},
+			"go:github.com/websockets/ws": {"https://github.com/gorilla/websocket"},
+			"go:github.com/wilsonzlin/minify-html": {"https://github.com/tdewolff/minify"},
+			"go:github.com/winstonjs/winston": {"https://github.com/sirupsen/logrus"},
+			"go:github.com/yanganto/struct-patch": {"https://github.com/darccio/mergo"},
+			"go:github.com/zonyitoo/rust-ini": {"https://github.com/go-ini/ini"},
+			"js:github.com/allan2/dotenvy": {"https://github.com/motdotla/dotenv"},
+			"js:github.com/chronotope/chrono": {"https://github.com/moment/moment"},
+			"js:github.com/dromara/carbon": {"https://github.com/moment/moment"},
+			"js:github.com/ent/ent": {"https://github.com/prisma/prisma"},
+			"js:github.com/go-gorm/gorm": {"https://github.com/typeorm/typeorm"},
+			"js:github.com/go-playground/validator": {"https://github.com/colinhacks/zod"},
+			"js:github.com/go-resty/resty": {"https://github.com/axios/axios"},
+			"js:github.com/gofiber/fiber": {"https://github.com/fastify/fastify"},
+			"js:github.com/golang-jwt/jwt": {"https://github.com/auth0/node-jsonwebtoken"},
+			"js:github.com/golang/go": {"https://github.com/winstonjs/winston"},
+			"js:github.com/hibiken/asynq": {"https://github.com/taskforcesh/bullmq"},
+			"js:github.com/jackc/pgx": {"https://github.com/brianc/node-postgres"},
+			"js:github.com/joho/godotenv": {"https://github.com/motdotla/dotenv"},
+			"js:github.com/keats/jsonwebtoken": {"https://github.com/auth0/node-jsonwebtoken"},
+			"js:github.com/knadh/koanf": {"https://github.com/lorenwest/node-config"},
+			"js:github.com/labstack/echo": {"https://github.com/expressjs/express"},
+			"js:github.com/markbates/goth": {"https://github.com/jaredhanson/passport"},
+			"js:github.com/masterminds/squirrel": {"https://github.com/knex/knex"},
+			"js:github.com/olahol/melody": {"https://github.com/socketio/socket.io"},
+			"js:github.com/rs/zerolog": {"https://github.com/pinojs/pino"},
+			"js:github.com/rust-cli/config-rs": {"https://github.com/lorenwest/node-config"},
+			"js:github.com/samber/lo": {"https://github.com/lodash/lodash"},
+			"js:github.com/seaql/sea-orm": {"https://github.com/typeorm/typeorm"},
+			"js:github.com/stretchr/testify": {"https://github.com/jestjs/jest"},
+			"js:github.com/tokio-rs/axum": {"https://github.com/expressjs/express"},
+			"js:github.com/tokio-rs/tracing": {"https://github.com/winstonjs/winston", "https://github.com/pinojs/pino"},
+			"js:github.com/uber-go/fx": {"https://github.com/nestjs/nest"},
+			"python:github.com/allan2/dotenvy": {"https://github.com/theskumar/python-dotenv"},
+			"python:github.com/clap-rs/clap": {"https://github.com/pallets/click"},
+			"python:github.com/dtolnay/serde-yaml": {"https://github.com/yaml/pyyaml"},
+			"python:github.com/keats/jsonwebtoken": {"https://github.com/jpadilla/pyjwt"},
+			"python:github.com/redis-rs/redis-rs": {"https://github.com/redis/redis-py"},
+			"python:github.com/seaql/sea-orm": {"https://github.com/sqlalchemy/sqlalchemy"},
+			"python:github.com/tokio-rs/axum": {"https://github.com/pallets/flask", "https://github.com/tiangolo/fastapi"},
+			"python:github.com/toml-rs/toml": {"https://github.com/uiri/toml"},
+		},
+		knownCrateNames: map[string]string{
+			"crates.io/crates/ignore": "ignore",
+			"crates.io/crates/nu-parser": "nu_parser",
+			"crates.io/crates/tracing-appender": "tracing_appender",
+			"github.com/awslabs/aws-sdk-rust": "aws_sdk_config",
+			"github.com/azure/azure-sdk-for-rust": "azure_core",
+			"github.com/bytecodealliance/wasmtime": "wasmtime",
+			"github.com/chronotope/chrono": "chrono",
+			"github.com/clap-rs/clap": "clap",
+			"github.com/djc/askama": "askama",
+			"github.com/dtolnay/anyhow": "anyhow",
+			"github.com/dtolnay/serde-yaml": "serde_yaml",
+			"github.com/googleapis/google-cloud-rust": "google_cloud_storage",
+			"github.com/hyperium/hyper": "hyper",
+			"github.com/hyperium/tonic": "tonic",
+			"github.com/image-rs/image": "image",
+			"github.com/redis-rs/redis-rs": "redis",
+			"github.com/rusqlite/rusqlite": "rusqlite",
+			"github.com/rustls/rustls": "rustls",
+			"github.com/seaql/sea-orm": "sea_orm",
+			"github.com/serde-rs/json": "serde_json",
+			"github.com/serde-rs/serde": "serde",
+			"github.com/tokio-rs/axum": "axum",
+			"github.com/tokio-rs/prost": "prost",
+			"github.com/tokio-rs/tokio": "tokio",
+			"github.com/tokio-rs/tracing": "tracing",
+			"github.com/toml-rs/toml": "toml",
+			"github.com/tower-rs/tower-http": "tower_http",
+			"github.com/untitaker/atomicwrites-rs": "atomicwrites",
+			"github.com/uuid-rs/uuid": "uuid",
+		},
+		tags: map[string][]string{
+			"github.com/99designs/gqlgen": {"graphql", "codegen:gqlgen"},
+			"github.com/a-h/templ": {"templating", "codegen:templ"},
+			"github.com/alecthomas/kong": {"cli"},
+			"github.com/aws/aws-sdk-go-v2": {"cloud"},
+			"github.com/azure/azure-sdk-for-go": {"cloud"},
+			"github.com/charmbracelet/bubbles": {"cli"},
+			"github.com/charmbracelet/bubbletea": {"cli"},
+			"github.com/charmbracelet/lipgloss": {"cli"},
+			"github.com/charmbracelet/log": {"logging"},
+			"github.com/containerd/containerd": {"container"},
+			"github.com/djc/askama": {"templating"},
+			"github.com/docker/docker": {"container"},
+			"github.com/ent/ent": {"orm", "codegen:ent"},
+			"github.com/etcd-io/bbolt": {"sql"},
+			"github.com/gin-gonic/gin": {"web"},
+			"github.com/go-chi/chi": {"web"},
+			"github.com/go-gorm/gorm": {"sql", "orm"},
+			"github.com/go-redis/redis": {"sql"},
+			"github.com/go-resty/resty": {"http"},
+			"github.com/gofiber/fiber": {"web"},
+			"github.com/golang-jwt/jwt": {"auth"},
+			"github.com/golang/mock": {"testing"},
+			"github.com/golang/oauth2": {"auth"},
+			"github.com/google/wire": {"di", "codegen:wire"},
+			"github.com/googleapis/google-cloud-go": {"cloud"},
+			"github.com/gorilla/mux": {"web"},
+			"github.com/gorilla/websocket": {"websocket"},
+			"github.com/grpc-ecosystem/grpc-gateway": {"grpc"},
+			"github.com/grpc/grpc-go": {"grpc"},
+			"github.com/hibiken/asynq": {"async"},
+			"github.com/jackc/pgx": {"sql"},
+			"github.com/joho/godotenv": {"config"},
+			"github.com/knadh/koanf": {"config"},
+			"github.com/kubernetes/client-go": {"container"},
+			"github.com/labstack/echo": {"web"},
+			"github.com/markbates/goth": {"auth"},
+			"github.com/masterminds/squirrel": {"orm"},
+			"github.com/mattn/go-sqlite3": {"sql"},
+			"github.com/natefinch/atomic": {"filesystem"},
+			"github.com/ncruces/go-sqlite3": {"sql"},
+			"github.com/olahol/melody": {"websocket"},
+			"github.com/ollama/ollama": {"ai"},
+			"github.com/onsi/ginkgo": {"testing"},
+			"github.com/onsi/gomega": {"testing"},
+			"github.com/open-telemetry/opentelemetry-go": {"observability"},
+			"github.com/openai/openai-go": {"ai"},
+			"github.com/pressly/goose": {"orm"},
+			"github.com/prometheus/client_golang": {"observability"},
+			"github.com/protocolbuffers/protobuf-go": {"codegen:protobuf"},
+			"github.com/rs/zerolog": {"logging"},
+			"github.com/sashabaranov/go-openai": {"ai"},
+			"github.com/sirupsen/logrus": {"logging"},
+			"github.com/spf13/cobra": {"cli"},
+			"github.com/spf13/viper": {"config"},
+			"github.com/sqlc-dev/sqlc": {"sql", "codegen:sqlc"},
+			"github.com/stretchr/testify": {"testing"},
+			"github.com/tmc/langchaingo": {"ai"},
+			"github.com/uber-go/zap": {"logging"},
+			"github.com/untitaker/atomicwrites-rs": {"filesystem"},
+			"github.com/valyala/fasthttp": {"web"},
+		},
+		requiredDeps: map[string][]requiredDep{
+			"rust:github.com/gin-gonic/gin": {
+				{Crate: "tokio", Features: []string{"full"}, Reason: "async runtime for axum"},
+			},
+			"rust:github.com/go-chi/chi": {
+				{Crate: "tokio", Features: []string{"full"}, Reason: "async runtime for axum"},
+			},
+			"rust:github.com/gorilla/mux": {
+				{Crate: "tokio", Features: []string{"full"}, Reason: "async runtime for axum"},
+			},
+			"rust:github.com/labstack/echo": {
+				{Crate: "tokio", Features: []string{"full"}, Reason: "async runtime for axum"},
+			},
+		},
+		categories: map[string]string{
+			"js:github.com/dromara/carbon": "time_utilities",
+			"js:github.com/ent/ent": "orm",
+			"js:github.com/gin-gonic/gin": "web_framework",
+			"js:github.com/go-chi/chi": "http_router",
+			"js:github.com/go-gorm/gorm": "orm",
+			"js:github.com/go-playground/validator": "validation",
+			"js:github.com/go-resty/resty": "http_client",
+			"js:github.com/go-yaml/yaml": "yaml",
+			"js:github.com/gofiber/fiber": "web_framework",
+			"js:github.com/golang-jwt/jwt": "jwt",
+			"js:github.com/google/uuid": "uuid",
+			"js:github.com/gorilla/mux": "http_router",
+			"js:github.com/gorilla/websocket": "websocket",
+			"js:github.com/grpc/grpc-go": "grpc",
+			"js:github.com/hibiken/asynq": "job_queue",
+			"js:github.com/jackc/pgx": "postgres",
+			"js:github.com/joho/godotenv": "dotenv",
+			"js:github.com/knadh/koanf": "config_management",
+			"js:github.com/labstack/echo": "web_framework",
+			"js:github.com/markbates/goth": "oauth",
+			"js:github.com/masterminds/squirrel": "query_builder",
+			"js:github.com/prometheus/client_golang": "metrics",
+			"js:github.com/rs/zerolog": "logging",
+			"js:github.com/samber/lo": "functional",
+			"js:github.com/sirupsen/logrus": "logging",
+			"js:github.com/spf13/cobra": "cli_framework",
+			"js:github.com/spf13/viper": "config_management",
+			"js:github.com/stretchr/testify": "testing",
+			"js:github.com/uber-go/fx": "dependency_injection",
+			"js:github.com/uber-go/zap": "logging",
+			"rust:github.com/a-h/templ": "templating",
+			"rust:github.com/alecthomas/chroma": "syntax_highlighting",
+			"rust:github.com/alecthomas/kong": "kong_cli",
+			"rust:github.com/atotto/clipboard": "clipboard",
+			"rust:github.com/auth0/node-jsonwebtoken": "jwt",
+			"rust:github.com/aws/aws-sdk-go-v2": "aws_sdk",
+			"rust:github.com/aymanbagabas/go-udiff": "unified_diff",
+			"rust:github.com/azure/azure-sdk-for-go": "azure_sdk",
+			"rust:github.com/beorn7/perks": "general",
+			"rust:github.com/bmatcuk/doublestar": "glob_matching",
+			"rust:github.com/burntsushi/toml": "toml",
+			"rust:github.com/bytedance/sonic": "simd_json",
+			"rust:github.com/cespare/xxhash": "xxhash",
+			"rust:github.com/charlievieth/fastwalk": "directory_walking",
+			"rust:github.com/charmbracelet/bubbles": "tui_components",
+			"rust:github.com/charmbracelet/bubbletea": "tui_framework",
+			"rust:github.com/charmbracelet/colorprofile": "terminal_detection",
+			"rust:github.com/charmbracelet/glamour": "markdown_rendering",
+			"rust:github.com/charmbracelet/lipgloss": "tui_styling",
+			"rust:github.com/charmbracelet/log": "logging",
+			"rust:github.com/charmbracelet/x": "ansi_sequences",
+			"rust:github.com/cloudflare/cfssl": "pki_tls",
+			"rust:github.com/containerd/containerd": "containerd",
+			"rust:github.com/coreos/go-systemd": "systemd",
+			"rust:github.com/darccio/mergo": "struct_merge",
+			"rust:github.com/denisbrodbeck/machineid": "machine_id",
+			"rust:github.com/disintegration/gift": "image_filter",
+			"rust:github.com/disintegration/imageorient": "exif_orientation",
+			"rust:github.com/dlclark/regexp2": "regex",
+			"rust:github.com/docker/docker": "docker",
+			"rust:github.com/docker/go-units": "units",
+			"rust:github.com/dustin/go-humanize": "humanize",
+			"rust:github.com/etcd-io/bbolt": "embedded_kv",
+			"rust:github.com/etcd-io/etcd": "etcd_client",
+			"rust:github.com/expressjs/express": "web_framework",
+			"rust:github.com/fatih/color": "color_output",
+			"rust:github.com/felixge/httpsnoop": "http_snoop",
+			"rust:github.com/fsnotify/fsnotify": "fsnotify",
+			"rust:github.com/gin-gonic/gin": "web_framework",
+			"rust:github.com/go-chi/chi": "lightweight_router",
+			"rust:github.com/go-gorm/gorm": "orm",
+			"rust:github.com/go-ini/ini": "ini",
+			"rust:github.com/go-logr/logr": "logging",
+			"rust:github.com/go-logr/stdr": "logging",
+			"rust:github.com/go-openapi/jsonpointer": "openapi",
+			"rust:github.com/go-openapi/swag": "openapi",
+			"rust:github.com/go-redis/redis": "redis_client",
+			"rust:github.com/go-yaml/yaml": "yaml",
+			"rust:github.com/goccy/go-json": "fast_json",
+			"rust:github.com/goccy/go-yaml": "yaml",
+			"rust:github.com/gogo/protobuf": "protobuf_alt",
+			"rust:github.com/golang-jwt/jwt": "jwt",
+			"rust:github.com/golang/crypto": "crypto",
+			"rust:github.com/golang/image": "image_processing",
+			"rust:github.com/golang/mock": "mock_generation",
+			"rust:github.com/golang/net": "http_client",
+			"rust:github.com/golang/oauth2": "oauth2",
+			"rust:github.com/golang/protobuf": "protobuf",
+			"rust:github.com/golang/snappy": "compression",
+			"rust:github.com/golang/sync": "sync_primitives",
+			"rust:github.com/golang/sys": "system_calls",
+			"rust:github.com/golang/term": "terminal",
+			"rust:github.com/golang/text": "text_processing",
+			"rust:github.com/golang/time": "time_utilities",
+			"rust:github.com/google/pprof": "profiling",
+			"rust:github.com/google/uuid": "uuid",
+			"rust:github.com/googleapis/gax-go": "general",
+			"rust:github.com/googleapis/go-genproto": "general",
+			"rust:github.com/googleapis/google-api-go-client": "google_api",
+			"rust:github.com/googleapis/google-cloud-go": "gcp_sdk",
+			"rust:github.com/gorilla/css": "css_parsing",
+			"rust:github.com/gorilla/mux": "http_router",
+			"rust:github.com/gorilla/websocket": "websocket",
+			"rust:github.com/grpc-ecosystem/grpc-gateway": "grpc",
+			"rust:github.com/grpc/grpc-go": "grpc",
+			"rust:github.com/hashicorp/golang-lru": "lru_cache",
+			"rust:github.com/invopop/jsonschema": "json_schema",
+			"rust:github.com/joho/godotenv": "dotenv",
+			"rust:github.com/josharian/intern": "string_interning",
+			"rust:github.com/jpadilla/pyjwt": "jwt",
+			"rust:github.com/json-iterator/go": "json_iterator",
+			"rust:github.com/klauspost/compress": "compression_zstd",
+			"rust:github.com/klauspost/cpuid": "cpu_detection",
+			"rust:github.com/kolesa-team/go-webp": "webp_encoding",
+			"rust:github.com/kubernetes-sigs/yaml": "yaml",
+			"rust:github.com/kubernetes/api": "kubernetes_api",
+			"rust:github.com/kubernetes/apimachinery": "kubernetes_types",
+			"rust:github.com/kubernetes/client-go": "kubernetes_client",
+			"rust:github.com/labstack/echo": "web_framework_alt",
+			"rust:github.com/lorenwest/node-config": "config_management",
+			"rust:github.com/lucasb-eyer/go-colorful": "color_manipulation",
+			"rust:github.com/mailru/easyjson": "json",
+			"rust:github.com/mattn/go-colorable": "terminal",
+			"rust:github.com/mattn/go-isatty": "terminal_isatty",
+			"rust:github.com/mattn/go-runewidth": "runewidth",
+			"rust:github.com/mattn/go-sqlite3": "sqlite",
+			"rust:github.com/microcosm-cc/bluemonday": "html_sanitization",
+			"rust:github.com/microsoft/go-winio": "windows",
+			"rust:github.com/miekg/dns": "dns",
+			"rust:github.com/mitchellh/mapstructure": "struct_mapping",
+			"rust:github.com/modern-go/concurrent": "general",
+			"rust:github.com/moment/moment": "time_utilities",
+			"rust:github.com/motdotla/dotenv": "dotenv",
+			"rust:github.com/muesli/termenv": "terminal_environment",
+			"rust:github.com/munnerz/goautoneg": "general",
+			"rust:github.com/mvdan/sh": "shell_parser",
+			"rust:github.com/natefinch/atomic": "atomic_file_writes",
+			"rust:github.com/natefinch/lumberjack": "log_rotation",
+			"rust:github.com/ncruces/go-sqlite3": "sqlite",
+			"rust:github.com/nfnt/resize": "image_resizing",
+			"rust:github.com/nxadm/tail": "file_tailing",
+			"rust:github.com/olekukonko/tablewriter": "table_writer",
+			"rust:github.com/ollama/ollama": "ollama_api",
+			"rust:github.com/open-telemetry/opentelemetry-go": "opentelemetry",
+			"rust:github.com/openai/openai-go": "openai_client",
+			"rust:github.com/opencontainers/go-digest": "crypto",
+			"rust:github.com/opencontainers/image-spec": "oci_image_spec",
+			"rust:github.com/pallets/click": "cli_framework",
+			"rust:github.com/pallets/flask": "web_framework",
+			"rust:github.com/pelletier/go-toml": "toml_parsing",
+			"rust:github.com/pierrec/lz4": "compression_lz4",
+			"rust:github.com/pinojs/pino": "logging",
+			"rust:github.com/pires/go-proxyproto": "proxy_protocol",
+			"rust:github.com/pkg/browser": "open_browser",
+			"rust:github.com/pkg/errors": "errors",
+			"rust:github.com/pmezard/go-difflib": "diff",
+			"rust:github.com/pressly/goose": "database_migrations",
+			"rust:github.com/prometheus/client_golang": "metrics",
+			"rust:github.com/prometheus/client_model": "metrics",
+			"rust:github.com/prometheus/common": "metrics",
+			"rust:github.com/prometheus/procfs": "metrics",
+			"rust:github.com/protocolbuffers/protobuf-go": "protobuf",
+			"rust:github.com/puerkitobio/goquery": "html_parsing",
+			"rust:github.com/quic-go/quic-go": "quic_protocol",
+			"rust:github.com/redis/redis-py": "redis_client",
+			"rust:github.com/rivo/uniseg": "unicode_segmentation",
+			"rust:github.com/rs/zerolog": "zero_alloc_logging",
+			"rust:github.com/russross/blackfriday": "general",
+			"rust:github.com/sabhiram/go-gitignore": "gitignore_parsing",
+			"rust:github.com/sahilm/fuzzy": "fuzzy_matching",
+			"rust:github.com/samber/lo": "functional",
+			"rust:github.com/sashabaranov/go-openai": "openai_client",
+			"rust:github.com/sergi/go-diff": "diff",
+			"rust:github.com/sirupsen/logrus": "logging",
+			"rust:github.com/sourcegraph/jsonrpc2": "jsonrpc",
+			"rust:github.com/spf13/afero": "filesystem_abstraction",
+			"rust:github.com/spf13/cobra": "cli_framework",
+			"rust:github.com/spf13/pflag": "cli_flags",
+			"rust:github.com/spf13/viper": "config_management",
+			"rust:github.com/sqlalchemy/sqlalchemy": "orm",
+			"rust:github.com/srwiley/oksvg": "svg_parsing",
+			"rust:github.com/srwiley/rasterx": "svg_rasterization",
+			"rust:github.com/tdewolff/minify": "minification",
+			"rust:github.com/tetratelabs/wazero": "wasm_runtime",
+			"rust:github.com/theskumar/python-dotenv": "dotenv",
+			"rust:github.com/tiangolo/fastapi": "web_framework",
+			"rust:github.com/tidwall/gjson": "json_parsing",
+			"rust:github.com/tidwall/sjson": "json_modification",
+			"rust:github.com/tmc/langchaingo": "langchain",
+			"rust:github.com/typeorm/typeorm": "orm",
+			"rust:github.com/uber-go/multierr": "general",
+			"rust:github.com/uber-go/zap": "high_perf_logging",
+			"rust:github.com/uiri/toml": "toml",
+			"rust:github.com/valyala/fasthttp": "fast_http",
+			"rust:github.com/vishvananda/netlink": "linux_networking",
+			"rust:github.com/winstonjs/winston": "logging",
+			"rust:github.com/yaml/pyyaml": "yaml",
+			"rust:github.com/yuin/goldmark": "markdown_parser",
+			"rust:github.com/zeebo/xxh3": "xxhash",
+		},
+		confidences: map[string]float64{
+			"go:github.com/auth0/node-jsonwebtoken": 0.8,
+			"go:github.com/axios/axios": 0.8,
+			"go:github.com/brianc/node-postgres": 0.8,
+			"go:github.com/colinhacks/zod": 0.8,
+			"go:github.com/expressjs/express": 0.8,
+			"go:github.com/fastify/fastify": 0.8,
+			"go:github.com/jaredhanson/passport": 0.8,
+			"go:github.com/jestjs/jest": 0.8,
+			"go:github.com/knex/knex": 0.8,
+			"go:github.com/lodash/lodash": 0.8,
+			"go:github.com/lorenwest/node-config": 0.8,
+			"go:github.com/moment/moment": 0.8,
+			"go:github.com/motdotla/dotenv": 0.8,
+			"go:github.com/nestjs/nest": 0.8,
+			"go:github.com/pinojs/pino": 0.8,
+			"go:github.com/prisma/prisma": 0.8,
+			"go:github.com/socketio/socket.io": 0.8,
+			"go:github.com/taskforcesh/bullmq": 0.8,
+			"go:github.com/typeorm/typeorm": 0.8,
+			"go:github.com/winstonjs/winston": 0.8,
+			"js:github.com/dromara/carbon": 0.8,
+			"js:github.com/ent/ent": 0.8,
+			"js:github.com/gin-gonic/gin": 0.85,
+			"js:github.com/go-chi/chi": 0.8,
+			"js:github.com/go-gorm/gorm": 0.8,
+			"js:github.com/go-playground/validator": 0.8,
+			"js:github.com/go-resty/resty": 0.85,
+			"js:github.com/go-yaml/yaml": 0.9,
+			"js:github.com/gofiber/fiber": 0.85,
+			"js:github.com/golang-jwt/jwt": 0.9,
+			"js:github.com/google/uuid": 0.95,
+			"js:github.com/gorilla/mux": 0.8,
+			"js:github.com/gorilla/websocket": 0.9,
+			"js:github.com/grpc/grpc-go": 0.9,
+			"js:github.com/hibiken/asynq": 0.8,
+			"js:github.com/jackc/pgx": 0.9,
+			"js:github.com/joho/godotenv": 0.95,
+			"js:github.com/knadh/koanf": 0.8,
+			"js:github.com/labstack/echo": 0.85,
+			"js:github.com/markbates/goth": 0.8,
+			"js:github.com/masterminds/squirrel": 0.8,
+			"js:github.com/prometheus/client_golang": 0.9,
+			"js:github.com/rs/zerolog": 0.9,
+			"js:github.com/samber/lo": 0.85,
+			"js:github.com/sirupsen/logrus": 0.85,
+			"js:github.com/spf13/cobra": 0.85,
+			"js:github.com/spf13/viper": 0.8,
+			"js:github.com/stretchr/testify": 0.8,
+			"js:github.com/uber-go/fx": 0.8,
+			"js:github.com/uber-go/zap": 0.85,
+			"rust:github.com/a-h/templ": 0.85,
+			"rust:github.com/alecthomas/chroma": 0.85,
+			"rust:github.com/alecthomas/kong": 0.8,
+			"rust:github.com/atotto/clipboard": 0.9,
+			"rust:github.com/auth0/node-jsonwebtoken": 0.8,
+			"rust:github.com/aws/aws-sdk-go-v2": 0.9,
+			"rust:github.com/aymanbagabas/go-udiff": 0.85,
+			"rust:github.com/azure/azure-sdk-for-go": 0.95,
+			"rust:github.com/beorn7/perks": 0.8,
+			"rust:github.com/bmatcuk/doublestar": 0.8,
+			"rust:github.com/burntsushi/toml": 0.85,
+			"rust:github.com/bytedance/sonic": 0.85,
+			"rust:github.com/cespare/xxhash": 0.9,
+			"rust:github.com/charlievieth/fastwalk": 0.8,
+			"rust:github.com/charmbracelet/bubbles": 0.85,
+			"rust:github.com/charmbracelet/bubbletea": 0.85,
+			"rust:github.com/charmbracelet/colorprofile": 0.8,
+			"rust:github.com/charmbracelet/glamour": 0.85,
+			"rust:github.com/charmbracelet/lipgloss": 0.85,
+			"rust:github.com/charmbracelet/log": 0.85,
+			"rust:github.com/charmbracelet/x": 0.8,
+			"rust:github.com/cloudflare/cfssl": 0.8,
+			"rust:github.com/containerd/containerd": 0.95,
+			"rust:github.com/coreos/go-systemd": 0.8,
+			"rust:github.com/darccio/mergo": 0.8,
+			"rust:github.com/denisbrodbeck/machineid": 0.8,
+			"rust:github.com/disintegration/gift": 0.8,
+			"rust:github.com/disintegration/imageorient": 0.8,
+			"rust:github.com/dlclark/regexp2": 0.9,
+			"rust:github.com/docker/docker": 0.9,
+			"rust:github.com/docker/go-units": 0.8,
+			"rust:github.com/dustin/go-humanize": 0.8,
+			"rust:github.com/etcd-io/bbolt": 0.8,
+			"rust:github.com/etcd-io/etcd": 0.9,
+			"rust:github.com/expressjs/express": 0.8,
+			"rust:github.com/fatih/color": 0.8,
+			"rust:github.com/felixge/httpsnoop": 0.8,
+			"rust:github.com/fsnotify/fsnotify": 0.9,
+			"rust:github.com/gin-gonic/gin": 0.85,
+			"rust:github.com/go-chi/chi": 0.85,
+			"rust:github.com/go-gorm/gorm": 0.85,
+			"rust:github.com/go-ini/ini": 0.8,
+			"rust:github.com/go-logr/logr": 0.85,
+			"rust:github.com/go-logr/stdr": 0.85,
+			"rust:github.com/go-openapi/jsonpointer": 0.8,
+			"rust:github.com/go-openapi/swag": 0.8,
+			"rust:github.com/go-redis/redis": 0.85,
+			"rust:github.com/go-yaml/yaml": 0.85,
+			"rust:github.com/goccy/go-json": 0.85,
+			"rust:github.com/goccy/go-yaml": 0.85,
+			"rust:github.com/gogo/protobuf": 0.85,
+			"rust:github.com/golang-jwt/jwt": 0.8,
+			"rust:github.com/golang/crypto": 0.8,
+			"rust:github.com/golang/image": 0.8,
+			"rust:github.com/golang/mock": 0.8,
+			"rust:github.com/golang/net": 0.8,
+			"rust:github.com/golang/oauth2": 0.8,
+			"rust:github.com/golang/protobuf": 0.85,
+			"rust:github.com/golang/snappy": 0.85,
+			"rust:github.com/golang/sync": 0.8,
+			"rust:github.com/golang/sys": 0.8,
+			"rust:github.com/golang/term": 0.8,
+			"rust:github.com/golang/text": 0.8,
+			"rust:github.com/golang/time": 0.8,
+			"rust:github.com/google/pprof": 0.8,
+			"rust:github.com/google/uuid": 0.95,
+			"rust:github.com/googleapis/gax-go": 0.95,
+			"rust:github.com/googleapis/go-genproto": 0.8,
+			"rust:github.com/googleapis/google-api-go-client": 0.95,
+			"rust:github.com/googleapis/google-cloud-go": 0.95,
+			"rust:github.com/gorilla/css": 0.8,
+			"rust:github.com/gorilla/mux": 0.85,
+			"rust:github.com/gorilla/websocket": 0.85,
+			"rust:github.com/grpc-ecosystem/grpc-gateway": 0.85,
+			"rust:github.com/grpc/grpc-go": 0.85,
+			"rust:github.com/hashicorp/golang-lru": 0.8,
+			"rust:github.com/invopop/jsonschema": 0.8,
+			"rust:github.com/joho/godotenv": 0.9,
+			"rust:github.com/josharian/intern": 0.8,
+			"rust:github.com/jpadilla/pyjwt": 0.8,
+			"rust:github.com/json-iterator/go": 0.8,
+			"rust:github.com/klauspost/compress": 0.85,
+			"rust:github.com/klauspost/cpuid": 0.8,
+			"rust:github.com/kolesa-team/go-webp": 0.8,
+			"rust:github.com/kubernetes-sigs/yaml": 0.85,
+			"rust:github.com/kubernetes/api": 0.9,
+			"rust:github.com/kubernetes/apimachinery": 0.9,
+			"rust:github.com/kubernetes/client-go": 0.9,
+			"rust:github.com/labstack/echo": 0.85,
+			"rust:github.com/lorenwest/node-config": 0.8,
+			"rust:github.com/lucasb-eyer/go-colorful": 0.8,
+			"rust:github.com/mailru/easyjson": 0.85,
+			"rust:github.com/mattn/go-colorable": 0.8,
+			"rust:github.com/mattn/go-isatty": 0.8,
+			"rust:github.com/mattn/go-runewidth": 0.8,
+			"rust:github.com/mattn/go-sqlite3": 0.85,
+			"rust:github.com/microcosm-cc/bluemonday": 0.8,
+			"rust:github.com/microsoft/go-winio": 0.95,
+			"rust:github.com/miekg/dns": 0.8,
+			"rust:github.com/mitchellh/mapstructure": 0.8,
+			"rust:github.com/modern-go/concurrent": 0.8,
+			"rust:github.com/moment/moment": 0.8,
+			"rust:github.com/motdotla/dotenv": 0.9,
+			"rust:github.com/muesli/termenv": 0.8,
+			"rust:github.com/munnerz/goautoneg": 0.8,
+			"rust:github.com/mvdan/sh": 0.8,
+			"rust:github.com/natefinch/atomic": 0.85,
+			"rust:github.com/natefinch/lumberjack": 0.8,
+			"rust:github.com/ncruces/go-sqlite3": 0.85,
+			"rust:github.com/nfnt/resize": 0.8,
+			"rust:github.com/nxadm/tail": 0.8,
+			"rust:github.com/olekukonko/tablewriter": 0.8,
+			"rust:github.com/ollama/ollama": 0.8,
+			"rust:github.com/open-telemetry/opentelemetry-go": 0.95,
+			"rust:github.com/openai/openai-go": 0.8,
+			"rust:github.com/opencontainers/go-digest": 0.8,
+			"rust:github.com/opencontainers/image-spec": 0.8,
+			"rust:github.com/pallets/click": 0.85,
+			"rust:github.com/pallets/flask": 0.8,
+			"rust:github.com/pelletier/go-toml": 0.85,
+			"rust:github.com/pierrec/lz4": 0.85,
+			"rust:github.com/pinojs/pino": 0.8,
+			"rust:github.com/pires/go-proxyproto": 0.8,
+			"rust:github.com/pkg/browser": 0.8,
+			"rust:github.com/pkg/errors": 0.85,
+			"rust:github.com/pmezard/go-difflib": 0.85,
+			"rust:github.com/pressly/goose": 0.8,
+			"rust:github.com/prometheus/client_golang": 0.95,
+			"rust:github.com/prometheus/client_model": 0.95,
+			"rust:github.com/prometheus/common": 0.95,
+			"rust:github.com/prometheus/procfs": 0.8,
+			"rust:github.com/protocolbuffers/protobuf-go": 0.85,
+			"rust:github.com/puerkitobio/goquery": 0.8,
+			"rust:github.com/quic-go/quic-go": 0.8,
+			"rust:github.com/redis/redis-py": 0.85,
+			"rust:github.com/rivo/uniseg": 0.8,
+			"rust:github.com/rs/zerolog": 0.8,
+			"rust:github.com/russross/blackfriday": 0.8,
+			"rust:github.com/sabhiram/go-gitignore": 0.8,
+			"rust:github.com/sahilm/fuzzy": 0.8,
+			"rust:github.com/samber/lo": 0.8,
+			"rust:github.com/sashabaranov/go-openai": 0.8,
+			"rust:github.com/sergi/go-diff": 0.85,
+			"rust:github.com/sirupsen/logrus": 0.85,
+			"rust:github.com/sourcegraph/jsonrpc2": 0.8,
+			"rust:github.com/spf13/afero": 0.8,
+			"rust:github.com/spf13/cobra": 0.9,
+			"rust:github.com/spf13/pflag": 0.8,
+			"rust:github.com/spf13/viper": 0.85,
+			"rust:github.com/sqlalchemy/sqlalchemy": 0.8,
+			"rust:github.com/srwiley/oksvg": 0.8,
+			"rust:github.com/srwiley/rasterx": 0.8,
+			"rust:github.com/tdewolff/minify": 0.76,
+			"rust:github.com/tetratelabs/wazero": 0.8,
+			"rust:github.com/theskumar/python-dotenv": 0.9,
+			"rust:github.com/tiangolo/fastapi": 0.8,
+			"rust:github.com/tidwall/gjson": 0.85,
+			"rust:github.com/tidwall/sjson": 0.85,
+			"rust:github.com/tmc/langchaingo": 0.9,
+			"rust:github.com/typeorm/typeorm": 0.8,
+			"rust:github.com/uber-go/multierr": 0.8,
+			"rust:github.com/uber-go/zap": 0.8,
+			"rust:github.com/uiri/toml": 0.85,
+			"rust:github.com/valyala/fasthttp": 0.8,
+			"rust:github.com/vishvananda/netlink": 0.8,
+			"rust:github.com/winstonjs/winston": 0.8,
+			"rust:github.com/yaml/pyyaml": 0.85,
+			"rust:github.com/yuin/goldmark": 0.85,
+			"rust:github.com/zeebo/xxh3": 0.9,
+		},
+		packages: map[string]string{
+			"js:@grpc/grpc-js": "https://github.com/grpc/grpc-node",
+			"js:@nestjs/common": "https://github.com/nestjs/nest",
+			"js:@nestjs/core": "https://github.com/nestjs/nest",
+			"js:@prisma/client": "https://github.com/prisma/prisma",
+			"js:axios": "https://github.com/axios/axios",
+			"js:bullmq": "https://github.com/taskforcesh/bullmq",
+			"js:commander": "https://github.com/tj/commander.js",
+			"js:config": "https://github.com/lorenwest/node-config",
+			"js:dotenv": "https://github.com/motdotla/dotenv",
+			"js:express": "https://github.com/expressjs/express",
+			"js:fastify": "https://github.com/fastify/fastify",
+			"js:ioredis": "https://github.com/redis/ioredis",
+			"js:jest": "https://github.com/jestjs/jest",
+			"js:js-yaml": "https://github.com/nodeca/js-yaml",
+			"js:jsonwebtoken": "https://github.com/auth0/node-jsonwebtoken",
+			"js:knex": "https://github.com/knex/knex",
+			"js:lodash": "https://github.com/lodash/lodash",
+			"js:moment": "https://github.com/moment/moment",
+			"js:passport": "https://github.com/jaredhanson/passport",
+			"js:pg": "https://github.com/brianc/node-postgres",
+			"js:pino": "https://github.com/pinojs/pino",
+			"js:prisma": "https://github.com/prisma/prisma",
+			"js:prom-client": "https://github.com/siimon/prom-client",
+			"js:socket.io": "https://github.com/socketio/socket.io",
+			"js:typeorm": "https://github.com/typeorm/typeorm",
+			"js:uuid": "https://github.com/uuidjs/uuid",
+			"js:winston": "https://github.com/winstonjs/winston",
+			"js:ws": "https://github.com/websockets/ws",
+			"js:zod": "https://github.com/colinhacks/zod",
+			"python:click": "https://github.com/pallets/click",
+			"python:fastapi": "https://github.com/tiangolo/fastapi",
+			"python:flask": "https://github.com/pallets/flask",
+			"python:pyjwt": "https://github.com/jpadilla/pyjwt",
+			"python:python-dotenv": "https://github.com/theskumar/python-dotenv",
+			"python:pyyaml": "https://github.com/yaml/pyyaml",
+			"python:redis": "https://github.com/redis/redis-py",
+			"python:sqlalchemy": "https://github.com/sqlalchemy/sqlalchemy",
+			"python:toml": "https://github.com/uiri/toml",
+			"rust:ammonia": "https://github.com/rust-ammonia/ammonia",
+			"rust:anyhow": "https://github.com/dtolnay/anyhow",
+			"rust:arboard": "https://github.com/1Password/arboard",
+			"rust:askama": "https://github.com/djc/askama",
+			"rust:async_openai": "https://github.com/64bit/async-openai",
+			"rust:atomicwrites": "https://github.com/untitaker/atomicwrites-rs",
+			"rust:atty": "https://github.com/softprops/atty",
+			"rust:aws_sdk_config": "https://github.com/awslabs/aws-sdk-rust",
+			"rust:axum": "https://github.com/tokio-rs/axum",
+			"rust:azure_core": "https://github.com/Azure/azure-sdk-for-rust",
+			"rust:bollard": "https://github.com/fussybeaver/bollard",
+			"rust:chrono": "https://github.com/chronotope/chrono",
+			"rust:clap": "https://github.com/clap-rs/clap",
+			"rust:client_rust": "https://github.com/prometheus/client_rust",
+			"rust:comrak": "https://github.com/kivikakk/comrak",
+			"rust:config": "https://github.com/rust-cli/config-rs",
+			"rust:crossterm": "https://github.com/crossterm-rs/crossterm",
+			"rust:dotenvy": "https://github.com/allan2/dotenvy",
+			"rust:etcd_client": "https://github.com/etcdv3/etcd-client",
+			"rust:fancy_regex": "https://github.com/fancy-regex/fancy-regex",
+			"rust:fuzzy_matcher": "https://github.com/lotabout/fuzzy-matcher",
+			"rust:globset": "https://github.com/BurntSushi/globset",
+			"rust:google_cloud_storage": "https://github.com/googleapis/google-cloud-rust",
+			"rust:headers": "https://github.com/hyperium/headers",
+			"rust:hickory_dns": "https://github.com/hickory-dns/hickory-dns",
+			"rust:humantime": "https://github.com/chronotope/humantime",
+			"rust:hyper": "https://github.com/hyperium/hyper",
+			"rust:ignore": "https://crates.io/crates/ignore",
+			"rust:image": "https://github.com/image-rs/image",
+			"rust:image_webp": "https://github.com/image-rs/image-webp",
+			"rust:imageproc": "https://github.com/image-rs/imageproc",
+			"rust:imara_diff": "https://github.com/pascalkuthe/imara-diff",
+			"rust:internment": "https://github.com/droundy/internment",
+			"rust:itertools": "https://github.com/rust-itertools/itertools",
+			"rust:jsonptr": "https://github.com/chanced/jsonptr",
+			"rust:jsonrpsee": "https://github.com/paritytech/jsonrpsee",
+			"rust:jsonwebtoken": "https://github.com/keats/jsonwebtoken",
+			"rust:kube": "https://github.com/kube-rs/kube",
+			"rust:langchain_rust": "https://github.com/Abraxas-365/langchain-rust",
+			"rust:libc": "https://github.com/rust-lang/libc",
+			"rust:libsystemd": "https://github.com/lucab/libsystemd-rs",
+			"rust:linemux": "https://github.com/jmagnuson/linemux",
+			"rust:lru": "https://github.com/jeromefroe/lru-rs",
+			"rust:lz4_flex": "https://github.com/PSeitz/lz4_flex",
+			"rust:machine_uid": "https://github.com/Hanaasagi/machine-uid",
+			"rust:minifier": "https://github.com/GuillaumeGomez/minifier-rs",
+			"rust:minify_html": "https://github.com/wilsonzlin/minify-html",
+			"rust:mockall": "https://github.com/asomers/mockall",
+			"rust:netlink": "https://github.com/rust-netlink/netlink",
+			"rust:notify": "https://github.com/notify-rs/notify",
+			"rust:nu_parser": "https://crates.io/crates/nu-parser",
+			"rust:oauth2": "https://github.com/ramosbugs/oauth2-rs",
+			"rust:oci_spec": "https://github.com/containers/oci-spec-rs",
+			"rust:ollama": "https://github.com/pepperoni21/ollama-rs",
+			"rust:opentelemetry_rust": "https://github.com/open-telemetry/opentelemetry-rust",
+			"rust:palette": "https://github.com/Ogeon/palette",
+			"rust:ppp": "https://github.com/misalcedo/ppp",
+			"rust:pprof": "https://github.com/tikv/pprof-rs",
+			"rust:prettytable": "https://github.com/phsym/prettytable-rs",
+			"rust:procfs": "https://github.com/eminence/procfs",
+			"rust:prost": "https://github.com/tokio-rs/prost",
+			"rust:pulldown_cmark": "https://github.com/pulldown-cmark/pulldown-cmark",
+			"rust:quinn": "https://github.com/quinn-rs/quinn",
+			"rust:ratatui": "https://github.com/ratatui/ratatui",
+			"rust:redb": "https://github.com/cberner/redb",
+			"rust:redis": "https://github.com/redis-rs/redis-rs",
+			"rust:refinery": "https://github.com/rust-db/refinery",
+			"rust:resvg": "https://github.com/linebender/resvg",
+			"rust:rusqlite": "https://github.com/rusqlite/rusqlite",
+			"rust:rust_ansi_term": "https://github.com/ogham/rust-ansi-term",
+			"rust:rust_cpuid": "https://github.com/gz/rust-cpuid",
+			"rust:rust_cssparser": "https://github.com/servo/rust-cssparser",
+			"rust:rust_extensions": "https://github.com/containerd/rust-extensions",
+			"rust:rust_ini": "https://github.com/zonyitoo/rust-ini",
+			"rust:rust_snappy": "https://github.com/BurntSushi/rust-snappy",
+			"rust:rust_vfs": "https://github.com/manuel-woelker/rust-vfs",
+			"rust:rustls": "https://github.com/rustls/rustls",
+			"rust:schemars": "https://github.com/GREsau/schemars",
+			"rust:scraper": "https://github.com/rust-scraper/scraper",
+			"rust:sea_orm": "https://github.com/SeaQL/sea-orm",
+			"rust:serde": "https://github.com/serde-rs/serde",
+			"rust:serde_json": "https://github.com/serde-rs/json",
+			"rust:serde_yaml": "https://github.com/dtolnay/serde-yaml",
+			"rust:similar": "https://github.com/mitsuhiko/similar",
+			"rust:sonic": "https://github.com/cloudwego/sonic-rs",
+			"rust:struct_patch": "https://github.com/yanganto/struct-patch",
+			"rust:syntect": "https://github.com/trishume/syntect",
+			"rust:termcolor": "https://github.com/BurntSushi/termcolor",
+			"rust:tokio": "https://github.com/tokio-rs/tokio",
+			"rust:tokio_tungstenite": "https://github.com/snapview/tokio-tungstenite",
+			"rust:toml": "https://github.com/toml-rs/toml",
+			"rust:tonic": "https://github.com/hyperium/tonic",
+			"rust:tower_http": "https://github.com/tower-rs/tower-http",
+			"rust:tracing": "https://github.com/tokio-rs/tracing",
+			"rust:tracing_appender": "https://crates.io/crates/tracing-appender",
+			"rust:traits": "https://github.com/RustCrypto/traits",
+			"rust:twox_hash": "https://github.com/shepmaster/twox-hash",
+			"rust:unicode_normalization": "https://github.com/unicode-rs/unicode-normalization",
+			"rust:unicode_segmentation": "https://github.com/unicode-rs/unicode-segmentation",
+			"rust:unicode_width": "https://github.com/unicode-rs/unicode-width",
+			"rust:uom": "https://github.com/iliekturtles/uom",
+			"rust:utoipa": "https://github.com/juhaku/utoipa",
+			"rust:uuid": "https://github.com/uuid-rs/uuid",
+			"rust:walkdir": "https://github.com/BurntSushi/walkdir",
+			"rust:wasmtime": "https://github.com/bytecodealliance/wasmtime",
+			"rust:webbrowser": "https://github.com/amodm/webbrowser-rs",
+			"rust:windows": "https://github.com/microsoft/windows-rs",
+			"rust:zstd": "https://github.com/gyscos/zstd-rs",
+		},
+	}
 }
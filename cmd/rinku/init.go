@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/natefinch/atomic"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/config"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/rinku"
+)
+
+type InitCmd struct {
+	Strategy string `enum:",rewrite,incremental" default:"" help:"Migration strategy: rewrite or incremental (asked if not set)."`
+	Agent    string `help:"Agent the bootstrap instruction is for: claude, cursor or generic." enum:"claude,cursor,generic" default:"generic"`
+	Scan     bool   `help:"Run the first scan without asking." xor:"scan"`
+	NoScan   bool   `help:"Skip the first scan without asking." xor:"scan"`
+	Yes      bool   `short:"y" help:"Accept the defaults instead of asking."`
+	Force    bool   `help:"Replace an existing .rinku.toml."`
+}
+
+// project is what rinku init detects in the current directory.
+type project struct {
+	GoMod     string   // go.mod of the main module, relative to the project
+	Module    string   // its module path
+	GoVersion string   // its go directive
+	Work      []string // module directories of go.work, if any
+	Deps      int      // direct dependencies of all modules
+	Tags      []string // library tags of the direct dependencies, sorted
+}
+
+func (c *InitCmd) Run(r *rinku.Rinku) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	p, err := detectProject(cwd, r)
+	if err != nil {
+		return err
+	}
+	printProject(os.Stdout, p)
+
+	path := filepath.Join(cwd, config.FileName)
+	_, statErr := os.Stat(path)
+	keep := statErr == nil && !c.Force
+	strategy := c.Strategy
+	if keep && strategy == "" {
+		cfg, err := config.Load(cwd)
+		if err != nil {
+			return err
+		}
+		strategy = cfg.Migration.Strategy
+	}
+
+	w := &wizard{in: bufio.NewScanner(os.Stdin), out: os.Stdout, defaults: c.Yes}
+	if strategy == "" {
+		strategy = w.choose("\nMigration goal", []string{
+			config.StrategyRewrite + "      port the whole program to Rust, then switch over",
+			config.StrategyIncremental + "  replace packages one at a time behind FFI or RPC seams",
+		})
+	}
+
+	if keep {
+		fmt.Printf("\nKeeping existing %s (use --force to replace it)\n", config.FileName)
+	} else {
+		if err := atomic.WriteFile(path, bytes.NewReader(initConfig(config.Migration{Strategy: strategy, GoMod: p.GoMod}))); err != nil {
+			return fmt.Errorf("writing %s: %w", config.FileName, err)
+		}
+		fmt.Printf("\nWrote %s (strategy %s)\n", config.FileName, strategy)
+	}
+
+	scan := c.Scan
+	if !c.Scan && !c.NoScan {
+		scan = w.confirm(fmt.Sprintf("Run rinku scan %s now?", p.GoMod), true)
+	}
+	if scan {
+		fmt.Println()
+		if err := (&ScanCmd{Path: filepath.Join(cwd, p.GoMod), SourceLang: "go", Format: "text"}).Run(r); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println()
+	printNextSteps(os.Stdout, strategy, c.Agent, p.GoMod)
+	return nil
+}
+
+// detectProject finds the main module in dir: its go.mod, else the first module of its
+// go.work.
+func detectProject(dir string, r *rinku.Rinku) (*project, error) {
+	p := &project{}
+	modules := []string{"."}
+	if work, err := gomod.ParseWork(filepath.Join(dir, "go.work")); err == nil {
+		if len(work.Use) == 0 {
+			return nil, errors.New("go.work has no use directives")
+		}
+		p.Work = work.Use
+		modules = work.Use
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to parse go.work: %w", err)
+	}
+
+	tagSet := make(map[string]struct{})
+	for _, m := range modules {
+		goMod := filepath.ToSlash(filepath.Join(m, "go.mod"))
+		result, err := gomod.Parse(filepath.Join(dir, goMod))
+		if errors.Is(err, os.ErrNotExist) && len(p.Work) == 0 {
+			return nil, errors.New("no go.mod or go.work in the current directory")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", goMod, err)
+		}
+		if p.GoMod == "" {
+			p.GoMod, p.Module, p.GoVersion = goMod, result.Module, result.GoVersion
+		}
+		for _, dep := range result.DirectDependencies() {
+			p.Deps++
+			for _, tag := range r.Tags(cargo.ModulePathToGitHubURL(dep.Path)) {
+				tagSet[tag] = struct{}{}
+			}
+		}
+	}
+	for tag := range tagSet {
+		p.Tags = append(p.Tags, tag)
+	}
+	sort.Strings(p.Tags)
+	return p, nil
+}
+
+func printProject(w io.Writer, p *project) {
+	fmt.Fprintf(w, "Module: %s (%s)\n", p.Module, p.GoMod)
+	if p.GoVersion != "" {
+		fmt.Fprintf(w, "Go version: %s\n", p.GoVersion)
+	}
+	if len(p.Work) > 0 {
+		fmt.Fprintf(w, "Workspace: go.work with %d modules (%s)\n", len(p.Work), strings.Join(p.Work, ", "))
+	}
+	fmt.Fprintf(w, "Direct dependencies: %d\n", p.Deps)
+	if len(p.Tags) > 0 {
+		fmt.Fprintf(w, "Tags: %s\n", strings.Join(p.Tags, ", "))
+	}
+}
+
+// initConfig returns the .rinku.toml rinku init writes.
+func initConfig(m config.Migration) []byte {
+	var b bytes.Buffer
+	fmt.Fprintln(&b, "# rinku project configuration, written by rinku init.")
+	fmt.Fprintln(&b, "# [github], [http], [crates] and [[notify]] are described in the README.")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "[migration]")
+	fmt.Fprintf(&b, "strategy = %q\n", m.Strategy)
+	fmt.Fprintf(&b, "go_mod = %q\n", m.GoMod)
+	return b.Bytes()
+}
+
+// printNextSteps prints how to start the workflow of a strategy.
+func printNextSteps(w io.Writer, strategy, agent, goMod string) {
+	bootstrap := "rinku migrate bootstrap"
+	if agent != "generic" {
+		bootstrap += " --agent " + agent
+	}
+	var steps [][2]string
+	if strategy == config.StrategyIncremental {
+		steps = [][2]string{
+			{"rinku plan-phases " + goMod, "Order the packages, leaf utilities first"},
+			{"rinku ffi " + goMod + " <pkg>", "Move a package behind a Rust staticlib and cgo bridge"},
+			{"rinku seam " + goMod + " <pkg>", "Or behind a Rust service and Go client"},
+			{bootstrap, "Start an agent on the workflow once the seams are in place"},
+		}
+	} else {
+		steps = [][2]string{
+			{bootstrap, "Start an agent on the workflow with this preamble"},
+			{"rinku migrate --start 1", "Or work through the steps yourself"},
+		}
+	}
+	width := 0
+	for _, s := range steps {
+		width = max(width, len(s[0]))
+	}
+	fmt.Fprintln(w, "Next steps:")
+	for _, s := range steps {
+		fmt.Fprintf(w, "  %-*s  %s\n", width, s[0], s[1])
+	}
+}
+
+// wizard asks the questions of rinku init. Without input, or with defaults set, every
+// question takes its default answer.
+type wizard struct {
+	in       *bufio.Scanner
+	out      io.Writer
+	defaults bool
+}
+
+// choose asks for one of options, numbered from 1, and returns the first word of the
+// chosen one. The first option is the default.
+func (w *wizard) choose(question string, options []string) string {
+	first := func(s string) string { return strings.Fields(s)[0] }
+	if w.defaults {
+		return first(options[0])
+	}
+	fmt.Fprintf(w.out, "%s:\n", question)
+	for i, o := range options {
+		fmt.Fprintf(w.out, "  %d) %s\n", i+1, o)
+	}
+	for {
+		answer, ok := w.prompt(fmt.Sprintf("Choose [1-%d] (1): ", len(options)))
+		if !ok || answer == "" {
+			return first(options[0])
+		}
+		for i, o := range options {
+			if answer == fmt.Sprint(i+1) || answer == first(o) {
+				return first(o)
+			}
+		}
+		fmt.Fprintln(w.out, "  Invalid choice.")
+	}
+}
+
+// confirm asks a yes/no question.
+func (w *wizard) confirm(question string, def bool) bool {
+	if w.defaults {
+		return def
+	}
+	hint := "[Y/n]"
+	if !def {
+		hint = "[y/N]"
+	}
+	answer, ok := w.prompt(question + " " + hint + " ")
+	if !ok || answer == "" {
+		return def
+	}
+	return strings.HasPrefix(strings.ToLower(answer), "y")
+}
+
+func (w *wizard) prompt(question string) (string, bool) {
+	fmt.Fprint(w.out, question)
+	if !w.in.Scan() {
+		fmt.Fprintln(w.out)
+		return "", false
+	}
+	return strings.TrimSpace(w.in.Text()), true
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/gosrc"
+	"github.com/stephan/rinku/internal/graph"
+	"github.com/stephan/rinku/internal/issues"
+	"github.com/stephan/rinku/internal/requirements"
+	"github.com/stephan/rinku/internal/rinku"
+)
+
+type IssuesCmd struct {
+	Path         string   `arg:"" optional:"" default:"go.mod" type:"path" help:"Path to go.mod file (default: go.mod in cwd)."`
+	Unmapped     bool     `help:"One issue per direct dependency without a Rust mapping."`
+	Requirements bool     `help:"One issue per group of pending requirements."`
+	Depth        int      `default:"2" help:"Group pending requirements by this many leading path segments."`
+	Format       string   `enum:"github-cli,json,jira,linear" default:"github-cli" help:"Output format: github-cli (a script of gh issue create commands), json (create-issue payloads with epics), jira or linear (CSV for their importers)."`
+	Repo         string   `help:"Target repository (owner/name) for gh; default: the repository of the working directory."`
+	Label        []string `default:"rinku-migration" help:"Labels of every issue (must exist in the repository)."`
+	ReportURL    string   `name:"report-url" help:"Link every issue to the published migration report, e.g. the dashboard HTML."`
+	Output       string   `short:"o" default:"-" help:"Output file (- for stdout)."`
+	Unsafe       bool     `help:"Include libraries with known vulnerabilities."`
+}
+
+// Run prints the issues; nothing is sent to a tracker. Without --unmapped or
+// --requirements both kinds are generated.
+func (c *IssuesCmd) Run(r *rinku.Rinku) (err error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	unmapped, pending := c.Unmapped, c.Requirements
+	if !unmapped && !pending {
+		unmapped, pending = true, true
+	}
+
+	var list []issues.Issue
+	if unmapped {
+		found, err := unmappedIssues(r, c.Path, c.Unsafe, c.Label)
+		if err != nil {
+			return err
+		}
+		list = append(list, found...)
+	}
+	if pending {
+		reqs, err := requirements.GetAll(cwd, "")
+		if err != nil {
+			return err
+		}
+		list = append(list, issues.Requirements(reqs, c.Depth, c.Label)...)
+	}
+
+	if c.ReportURL != "" {
+		issues.LinkReport(list, c.ReportURL)
+	}
+
+	w := os.Stdout
+	if c.Output != "-" {
+		if err := validateOutputPath(c.Output); err != nil {
+			return err
+		}
+		w, err = os.Create(c.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() {
+			if cerr := w.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("failed to close output file: %w", cerr)
+			}
+		}()
+	}
+	switch c.Format {
+	case "json":
+		err = issues.WriteJSON(w, list)
+	case "jira":
+		err = issues.WriteJira(w, list)
+	case "linear":
+		err = issues.WriteLinear(w, list)
+	default:
+		err = issues.WriteGitHubCLI(w, list, c.Repo)
+	}
+	if err != nil {
+		return fmt.Errorf("writing issues: %w", err)
+	}
+	if c.Output != "-" {
+		fmt.Fprintf(os.Stderr, "Wrote %d issues to %s\n", len(list), c.Output)
+	}
+	return nil
+}
+
+// unmappedIssues returns an issue per unmapped direct dependency of the go.mod, listing
+// the packages that import it.
+func unmappedIssues(r *rinku.Rinku, goModPath string, unsafe bool, labels []string) ([]issues.Issue, error) {
+	result, err := gomod.Parse(goModPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	mapping := cargo.MapDependencies(result.DirectDependencies(), r, unsafe)
+	if len(mapping.Unmapped) == 0 {
+		return nil, nil
+	}
+
+	scan, err := gosrc.ScanImports(filepath.Dir(goModPath))
+	if err != nil {
+		return nil, fmt.Errorf("scanning source files: %w", err)
+	}
+	modules := make([]string, 0, len(mapping.Unmapped))
+	for _, u := range mapping.Unmapped {
+		modules = append(modules, u.GoDep.Path)
+	}
+	importedBy := make(map[string][]string)
+	for _, pkg := range graph.Build(result.Module, scan.Files, modules).Packages {
+		for _, mod := range pkg.External {
+			importedBy[mod] = append(importedBy[mod], pkg.ImportPath)
+		}
+	}
+
+	deps := make([]issues.Dependency, 0, len(mapping.Unmapped))
+	for _, u := range mapping.Unmapped {
+		libURL := cargo.ModulePathToGitHubURL(u.GoDep.Path)
+		deps = append(deps, issues.Dependency{
+			Module:     u.GoDep.Path,
+			Version:    u.GoDep.Version,
+			URL:        libURL,
+			Tags:       r.Tags(libURL),
+			ImportedBy: importedBy[u.GoDep.Path],
+		})
+	}
+	return issues.Unmapped(result.Module, deps, labels), nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/cratesio"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/goproxy"
+	"github.com/stephan/rinku/internal/lock"
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/versionmap"
+)
+
+type LockCmd struct {
+	Path            string `arg:"" type:"existingfile" help:"Path to go.mod file."`
+	Update          bool   `help:"Re-resolve existing automatic entries instead of keeping them."`
+	Offline         bool   `help:"Do not query crates.io; lock new entries with version \"*\"."`
+	Contemporaneous bool   `help:"Lock the crate version that was current when each pinned Go version was released, instead of the latest."`
+	Unsafe          bool   `help:"Include libraries with known vulnerabilities."`
+}
+
+func (c *LockCmd) Run(r *rinku.Rinku) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	result, err := gomod.Parse(c.Path)
+	if err != nil {
+		return fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	deps := result.DirectDependencies()
+	mapping := cargo.MapDependencies(deps, r, c.Unsafe)
+
+	l, err := lock.Load(cwd)
+	if err != nil {
+		return err
+	}
+	if l == nil {
+		l = lock.New()
+	}
+
+	var modules []string
+	for _, dep := range deps {
+		modules = append(modules, dep.Path)
+	}
+	removed := l.Prune(modules)
+
+	client := cratesio.New()
+	versions := versionmap.NewResolver(client, goproxy.New())
+	ctx := context.Background()
+	now := time.Now()
+	added, kept := 0, 0
+	for _, m := range mapping.Mapped {
+		if e, ok := l.Get(m.GoDep.Path); ok && (!c.Update || e.Source == lock.SourceManual) {
+			e.GoVersion = m.GoDep.Version
+			kept++
+			continue
+		}
+
+		version := ""
+		if c.Contemporaneous && !c.Offline {
+			version = contemporaneousVersion(ctx, versions, m.GoDep, m.CrateNames[0])
+		}
+		if version == "" {
+			version = resolveVersion(ctx, client, m.CrateNames[0], c.Offline)
+		}
+		entry := lock.Entry{
+			GoModule:  m.GoDep.Path,
+			GoVersion: m.GoDep.Version,
+			Crate:     m.CrateNames[0],
+			RustURL:   m.RustTargets[0],
+			Version:   version,
+			Source:    lock.SourceAuto,
+			LockedAt:  now,
+		}
+		l.Set(entry)
+		added++
+	}
+
+	for _, u := range mapping.Unmapped {
+		if _, ok := l.Get(u.GoDep.Path); ok {
+			kept++
+		}
+	}
+
+	if err := l.Save(cwd); err != nil {
+		return err
+	}
+	autoSync(cwd)
+
+	fmt.Printf("Locked %d dependencies in %s (%d new, %d kept, %d removed)\n",
+		len(l.Entries), lock.Path("."), added, kept, len(removed))
+	if unlocked := len(deps) - len(l.Entries); unlocked > 0 {
+		fmt.Printf("%d dependencies have no mapping and were not locked\n", unlocked)
+	}
+	return nil
+}
+
+// resolveVersion returns the latest crates.io version of a crate, or "*" when offline
+// or when the lookup fails.
+func resolveVersion(ctx context.Context, client *cratesio.Client, name string, offline bool) string {
+	if offline {
+		return "*"
+	}
+	crate, err := client.Crate(ctx, name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; locking %s with version \"*\"\n", err, name)
+		return "*"
+	}
+	return crate.LatestVersion()
+}
+
+// contemporaneousVersion returns the requirement for the crate release line current when
+// dep's version was published, or "" when the lookup fails.
+func contemporaneousVersion(ctx context.Context, resolver *versionmap.Resolver, dep gomod.Dependency, crate string) string {
+	s, err := resolver.Suggest(ctx, dep.Path, dep.Version, crate)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; locking %s with the latest version\n", err, crate)
+		return ""
+	}
+	return s.Req
+}
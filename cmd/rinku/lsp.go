@@ -0,0 +1,16 @@
+package main
+
+import (
+	"os"
+
+	"github.com/stephan/rinku/internal/lsp"
+	"github.com/stephan/rinku/internal/rinku"
+)
+
+type LspCmd struct {
+	Unsafe bool `help:"Include libraries with known vulnerabilities."`
+}
+
+func (c *LspCmd) Run(r *rinku.Rinku) error {
+	return lsp.NewServer(r, c.Unsafe).Serve(os.Stdin, os.Stdout)
+}
@@ -1,21 +1,48 @@
 package main
 
 import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/alecthomas/kong"
 	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/certificate"
+	"github.com/stephan/rinku/internal/config"
+	"github.com/stephan/rinku/internal/database"
+	"github.com/stephan/rinku/internal/features"
 	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/gosrc"
+	"github.com/stephan/rinku/internal/httpclient"
+	"github.com/stephan/rinku/internal/idiom"
+	"github.com/stephan/rinku/internal/lock"
+	"github.com/stephan/rinku/internal/manifest"
+	"github.com/stephan/rinku/internal/multistep"
 	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/internal/projectmap"
 	"github.com/stephan/rinku/internal/prompt"
+	"github.com/stephan/rinku/internal/reason"
 	"github.com/stephan/rinku/internal/requirements"
 	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/scan"
+	"github.com/stephan/rinku/internal/suppress"
+	"github.com/stephan/rinku/internal/target"
+	"github.com/stephan/rinku/internal/testkit"
 	"github.com/stephan/rinku/internal/types"
+	"github.com/stephan/rinku/internal/url"
 	"github.com/stephan/rinku/internal/verify"
+	"github.com/stephan/rinku/render"
 )
 
 //go:generate go run ../generate
@@ -26,13 +53,38 @@ USAGE:
   rinku <command> [arguments] [flags]
 
 COMMANDS:
+  rinku init                            Set up this project and choose a migration strategy
   rinku <github-url>                    Look up Rust equivalent for a Go library
   rinku scan <path-to-go.mod>           List Rust equivalents for all dependencies
+  rinku scan-org --repos <file>         Rank many repositories by migration readiness
   rinku convert <path-to-go.mod>        Generate Cargo.toml from go.mod
+  rinku convert --to go <Cargo.toml>    Draft a go.mod from a Rust manifest
+  rinku plan <path-to-go.mod>           Preview and save generated file changes
+  rinku apply                           Execute the saved plan
+  rinku idiom [name]                    Show Rust equivalent for a Go idiom
+  rinku modmap <path-to-go.mod>         Map Go packages to Rust module paths
+  rinku plan-phases <path-to-go.mod>    Split the migration into ordered phases
+  rinku ffi <path-to-go.mod> <pkg>      Scaffold a Rust staticlib + cgo bridge for a package
+  rinku seam <path-to-go.mod> <pkg>     Scaffold a Rust service + Go client for a package
+  rinku bench <path-to-go.mod> <pkg>    Pair a package's Go benchmarks with criterion benchmarks
+  rinku parity <path-to-go.mod> <pkg>   Record Go calls as fixtures for Rust parity tests
+  rinku config-gen <path-to-go.mod>     Generate Rust config structs from config files
+  rinku outdated <path-to-Cargo.toml>   Check crate versions and mappings for updates
+  rinku report <path-to-go.mod>         Summarize migration (--security: advisory delta)
+  rinku dashboard [go.mod]              One-screen migration status (--format json|html)
+  rinku lsp                             Serve go.mod hovers and code lenses over stdio
+  rinku lock <path-to-go.mod>           Pin mapping decisions for reproducible convert
+  rinku decide <path-to-go.mod>         Accept, reject or defer ambiguous mappings
+  rinku ignore <go.mod> <module>        Accept an unmapped dependency in go.mod itself
+  rinku webhook [--addr :8080]          Comment mapping coverage on GitHub pushes and PRs
+  rinku serve [--addr :8080]            Share the mapping database as a JSON HTTP API
+  rinku sync push|pull                  Share .rinku state through HTTP, S3 or a git branch
+  rinku telemetry on|off|status         Opt in to anonymous usage counts, or out again
 
 FLAGS:
   --unsafe    Include libraries with known security vulnerabilities
   -o <file>   Output file for convert command (default: stdout)
+  --api-version <n>  Version of the json and yaml output (1, or 2 for versioned results)
   --help      Show this help message
 
 EXAMPLES:
@@ -72,55 +124,166 @@ NOTES:
 Repository: https://github.com/marvai-dev/rinku`
 
 var CLI struct {
-	Scan    ScanCmd    `cmd:"" help:"Parse go.mod and show Rust equivalents for each dependency."`
-	Convert ConvertCmd `cmd:"" help:"Generate a Cargo.toml file from go.mod."`
-	Analyze AnalyzeCmd `cmd:"" help:"Analyze go.mod and output detected project type tags."`
-	Migrate MigrateCmd `cmd:"" help:"Output migration workflow steps."`
-	Req     ReqCmd     `cmd:"" help:"Manage migration requirements."`
-	Verify  VerifyCmd  `cmd:"" help:"Check requirement coverage and implementation status."`
-	Lookup  LookupCmd  `cmd:"" default:"withargs" help:"Look up equivalent for a single GitHub URL."`
+	APIVersion string `name:"api-version" env:"RINKU_API_VERSION" placeholder:"N" help:"Version of the json and yaml output: 1 (default) writes results as they are, 2 wraps them with their version and command."`
+
+	Init       InitCmd       `cmd:"" help:"Set up a project interactively: detect its modules, choose a migration strategy, write .rinku.toml."`
+	Scan       ScanCmd       `cmd:"" help:"Parse go.mod and show Rust equivalents for each dependency."`
+	ScanOrg    ScanOrgCmd    `cmd:"" name:"scan-org" help:"Scan many repositories and rank them by migration readiness."`
+	Convert    ConvertCmd    `cmd:"" help:"Generate a Cargo.toml file from go.mod, or a go.mod draft from Cargo.toml with --to go."`
+	Plan       PlanCmd       `cmd:"" help:"Compute the Cargo.toml and scaffolding changes convert would make and save them for review."`
+	Apply      ApplyCmd      `cmd:"" help:"Execute the plan saved by rinku plan."`
+	Assess     AssessCmd     `cmd:"" help:"Summarize mapping coverage, tags, cgo, reflection, codegen and porting effort without writing any state."`
+	Analyze    AnalyzeCmd    `cmd:"" help:"Analyze go.mod and output detected project type tags."`
+	ModMap     ModMapCmd     `cmd:"" name:"modmap" help:"Map Go packages to Rust module paths and write .rinku/module-map.json."`
+	Workspace  WorkspaceCmd  `cmd:"" help:"Preview the crate names and Cargo workspace for a Go monorepo's modules."`
+	Graph      GraphCmd      `cmd:"" help:"Export the import graph of the project's packages, with their external dependencies, as DOT or Mermaid."`
+	PlanPhases PlanPhasesCmd `cmd:"" name:"plan-phases" help:"Group dependencies and packages into migration phases, leaf utilities first."`
+	FFI        FFICmd        `cmd:"" name:"ffi" help:"Generate a Rust staticlib crate and cgo wrappers for a Go package's exported functions."`
+	Seam       SeamCmd       `cmd:"" help:"Generate a Rust service and Go client exposing a Go package's exported functions as RPCs."`
+	Bench      BenchCmd      `cmd:"" help:"Generate criterion benchmarks and a comparison script for a Go package's benchmarks."`
+	Parity     ParityCmd     `cmd:"" help:"Generate a Go call recorder and Rust tests that replay the recorded calls against the port."`
+	ConfigGen  ConfigGenCmd  `cmd:"" name:"config-gen" help:"Generate Rust config structs from the project's config files."`
+	Outdated   OutdatedCmd   `cmd:"" help:"Check a generated Cargo.toml against crates.io and current mappings."`
+	Report     ReportCmd     `cmd:"" help:"Summarize a migration (use --security for an advisory comparison)."`
+	Dashboard  DashboardCmd  `cmd:"" help:"Summarize dependency mapping, requirement coverage, step progress and requirements on one screen."`
+	Lsp        LspCmd        `cmd:"" help:"Run a JSON-RPC language server on stdio that annotates go.mod files."`
+	MCP        McpCmd        `cmd:"" name:"mcp" help:"Run a Model Context Protocol server on stdio that offers lookup, scan, convert, requirements and progress as tools."`
+	Lock       LockCmd       `cmd:"" help:"Record the chosen Rust crate and version per dependency in .rinku/mappings.lock.json."`
+	Decide     DecideCmd     `cmd:"" help:"Review dependencies with several or low-confidence Rust targets and record decisions in the lock file."`
+	Ignore     IgnoreCmd     `cmd:"" help:"Mark a dependency that needs no Rust equivalent with a // rinku:ignore comment in go.mod."`
+	Webhook    WebhookCmd    `cmd:"" help:"Run a GitHub webhook server that comments mapping coverage on go.mod changes."`
+	Serve      ServeCmd      `cmd:"" help:"Run an HTTP server with a JSON API for lookup, reverse lookup, scan and convert."`
+	Migrate    MigrateCmd    `cmd:"" help:"Output migration workflow steps."`
+	Workflows  WorkflowsCmd  `cmd:"" help:"List the migration workflows of the catalog in RINKU_PROMPTS_DIR."`
+	Req        ReqCmd        `cmd:"" help:"Manage migration requirements."`
+	Issues     IssuesCmd     `cmd:"" help:"Print gh issue create commands or JSON payloads for unmapped dependencies and pending requirements."`
+	Events     EventsCmd     `cmd:"" help:"Follow or export the log of migration state changes (.rinku/events.jsonl)."`
+	Grep       GrepCmd       `cmd:"" help:"Search requirements, step notes, mapping notes and category names for a pattern."`
+	Update     UpdateCmd     `cmd:"" help:"Download the latest mapping database, used instead of the one built into the binary."`
+	Compat     CompatCmd     `cmd:"" help:"Write a compatibility table of a library's Go API and its Rust equivalents for downstream consumers."`
+	Notify     NotifyCmd     `cmd:"" help:"Test the milestone notification hooks configured in .rinku.toml."`
+	Sync       SyncCmd       `cmd:"" help:"Push and pull .rinku state to a shared remote (HTTP, S3 or a git branch)."`
+	Telemetry  TelemetryCmd  `cmd:"" help:"Turn opt-in anonymous usage counts on or off, or show them."`
+	Verify     VerifyCmd     `cmd:"" help:"Check requirement coverage and implementation status."`
+	Idiom      IdiomCmd      `cmd:"" help:"Show Rust equivalents for Go idioms."`
+	Reverse    ReverseCmd    `cmd:"" help:"List the libraries that map to a target library, given by URL, crates.io URL or crate name."`
+	Lookup     LookupCmd     `cmd:"" default:"withargs" help:"Look up equivalent for a single GitHub URL."`
 }
 
 type LookupCmd struct {
-	URL      string `arg:"" help:"GitHub URL of the library."`
-	Language string `arg:"" optional:"" default:"rust" help:"Target language (default: rust)."`
-	Unsafe   bool   `help:"Include libraries with known vulnerabilities."`
+	URL           string   `arg:"" help:"GitHub URL of the library."`
+	Language      string   `arg:"" optional:"" help:"Target language (default: rust)."`
+	Target        []string `placeholder:"LANG,..." help:"Look up several target languages at once, e.g. rust,ts; the results are grouped by language."`
+	Unsafe        bool     `help:"Include libraries with known vulnerabilities."`
+	MinConfidence float64  `placeholder:"N" help:"Only report a mapping with at least this confidence, from 0 to 1; mappings without one are left out."`
+	Format        string   `default:"text" help:"Output format: text, json, yaml, csv, markdown, html, sarif or porcelain."`
+	JSON          bool     `help:"Shorthand for --format json."`
+}
+
+// LookupResult is the json and yaml output of lookup.
+type LookupResult struct {
+	Source   string              `json:"source"` // normalized source URL
+	Language string              `json:"language"`
+	Targets  []LookupTarget      `json:"targets"`
+	Requires []types.RequiredDep `json:"requires,omitempty"`
+}
+
+// LookupTargets is the json and yaml output of lookup with several target languages.
+type LookupTargets struct {
+	Source    string          `json:"source"`
+	Languages []*LookupResult `json:"languages"`
+}
+
+// LookupTarget is an equivalent library found by lookup.
+type LookupTarget struct {
+	URL        string  `json:"url"`
+	Crate      string  `json:"crate,omitempty"` // Rust targets only
+	Category   string  `json:"category,omitempty"`
+	Confidence float64 `json:"confidence,omitempty"` // of the mapping, from 0 to 1
+	Unsafe     bool    `json:"unsafe"`               // has known vulnerabilities, listed with --unsafe only
 }
 
 type ScanCmd struct {
-	Path   string `arg:"" type:"existingfile" help:"Path to go.mod file."`
-	Unsafe bool   `help:"Include libraries with known vulnerabilities."`
+	Path          string   `arg:"" optional:"" type:"existingfile" help:"Path to go.mod file (default: go.mod in cwd; requirements.txt or package.json with --source-lang)."`
+	SourceLang    string   `enum:"go,js,python" default:"go" help:"Source language: go (go.mod), js (package.json) or python (requirements.txt)."`
+	Imports       string   `placeholder:"PATTERN" help:"Map the packages imported by the Go source files of a pattern relative to go.mod (./..., ./cmd/...), standard library and subpackages included, instead of the modules of go.mod."`
+	Lang          []string `enum:"rust,ts,js" default:"rust" aliases:"target" placeholder:"LANG,..." help:"Target languages: rust (crates) or ts and js (npm packages). With several, e.g. rust,ts, the results are grouped by language."`
+	Category      []string `placeholder:"NAME,..." help:"Only dependencies whose mapping category matches: the category (web_framework), a word of it (web) or a glob (*_cli)."`
+	MinConfidence float64  `placeholder:"N" help:"Only dependencies whose mapping has at least this confidence, from 0 to 1; mappings without one are left out."`
+	Unsafe        bool     `help:"Include libraries with known vulnerabilities."`
+	Source        bool     `help:"Also scan Go source files next to go.mod (detects stdlib test helpers like httptest)."`
+	Transitive    bool     `help:"Also map the indirect modules of the build, read from the go.sum next to go.mod."`
+	Modules       string   `type:"existingfile" help:"Read the module graph of --transitive from this output of go list -m all instead of go.sum."`
+	Save          bool     `help:"Save the result of the direct dependencies to .rinku/scans next to go.mod."`
+	History       bool     `help:"Summarize the scans saved with --save instead of scanning: coverage over time and what changed."`
+	Profile       bool     `help:"Print the memory footprint and lookup throughput of the mapping indexes to stderr."`
+	Format        string   `default:"text" help:"Output format: text, json, yaml, csv, markdown, html, sarif or porcelain."`
+	Verbose       bool     `short:"v" help:"Print lookup cache statistics to stderr."`
 }
 
 type AnalyzeCmd struct {
-	Path string `arg:"" type:"existingfile" help:"Path to go.mod file."`
+	Path       string `arg:"" type:"existingfile" help:"Path to go.mod file (requirements.txt or package.json with --source-lang)."`
+	SourceLang string `enum:"go,js,python" default:"go" help:"Source language: go (go.mod), js (package.json) or python (requirements.txt)."`
 }
 
 type ConvertCmd struct {
-	Path   string `arg:"" type:"existingfile" help:"Path to go.mod file."`
-	Output string `short:"o" default:"-" help:"Output file (- for stdout)."`
-	Unsafe bool   `help:"Include libraries with known vulnerabilities."`
+	Path     string `arg:"" type:"existingfile" help:"Path to go.mod file (Cargo.toml with --to go)."`
+	To       string `default:"rust" aliases:"target" help:"Target: rust, python or ts write their manifest from go.mod, go a go.mod draft from Cargo.toml."`
+	Module   string `help:"Module path of the go.mod draft (--to go; default: the Cargo package name)."`
+	Pin      bool   `help:"Pin packages to their latest registry version (python and ts; pin Rust crates with rinku lock)."`
+	Output   string `short:"o" default:"-" help:"Output file (- for stdout)."`
+	Unsafe   bool   `help:"Include libraries with known vulnerabilities."`
+	Source   bool   `help:"Scan Go test files next to go.mod and add [dev-dependencies] for the detected test stack."`
+	Features bool   `help:"Request only the cargo features the Go source next to go.mod needs for the crates rinku knows them of (axum, tokio, sea-orm, uuid, redis), without their defaults."`
+	NoLock   bool   `help:"Ignore .rinku/mappings.lock.json and use the current database mappings."`
+	Format   string `default:"text" help:"Output format; text writes the manifest (Cargo.toml by default), the others the dependency mapping (json, yaml, csv, markdown, html, sarif or porcelain)."`
+	Verbose  bool   `short:"v" help:"Print lookup cache statistics to stderr."`
 }
 
 type MigrateCmd struct {
+	Step          MigrateStepCmd          `cmd:"" default:"withargs" help:"Show a step or update progress (default)."`
+	Attach        MigrateAttachCmd        `cmd:"" help:"Record an artifact (transcript, decision, diff) for a step."`
+	Bootstrap     MigrateBootstrapCmd     `cmd:"" help:"Print a system-prompt style preamble that starts an agent on the workflow."`
+	Show          MigrateShowCmd          `cmd:"" help:"Show a step with Before/After, gate and requirements, without changing progress."`
+	Preview       MigratePreviewCmd       `cmd:"" help:"Render a step as --start would and check its gate as --finish would, without changing progress."`
+	Check         MigrateCheckCmd         `cmd:"" help:"Check off a checklist item (- [ ] line) of a step."`
+	Certificate   MigrateCertificateCmd   `cmd:"" help:"Check the signature of .rinku/completion.json, written when the last step is finished."`
+	ValidateState MigrateValidateStateCmd `cmd:"" name:"validate-state" help:"Check .rinku/progress.json and the requirement files against their JSON schemas."`
+}
+
+type MigrateStepCmd struct {
 	Step   string `arg:"" optional:"" help:"Step ID to retrieve."`
 	Start  string `help:"Mark step as in_progress."`
 	Finish string `help:"Mark step as completed."`
+	Skip   string `help:"Mark step as skipped, with --reason and --note."`
+	Reason string `help:"Reason code of --skip: not-applicable, deferred, out-of-scope or blocked."`
 	Status bool   `help:"Show current migration status."`
 	Reset  bool   `help:"Reset migration progress."`
-	Note   string `help:"Add note when finishing a step."`
+	Note   string `help:"Add note when finishing or skipping a step."`
+	// Workflow selects a catalog workflow when the migration starts.
+	Workflow string `help:"Run the migration with a workflow of the catalog in RINKU_PROMPTS_DIR (with --start; see rinku workflows list)."`
+	Format   string `default:"text" help:"Output format of --status: text, json, yaml, csv, markdown, html, sarif or porcelain."`
+	// NoContext leaves out the table of go.mod dependencies matching the step's categories.
+	NoContext bool `help:"Do not append the project dependencies relevant to the step."`
 }
 
 type ReqCmd struct {
-	Set  ReqSetCmd  `cmd:"" help:"Set a requirement."`
-	Get  ReqGetCmd  `cmd:"" help:"Get a requirement."`
-	List ReqListCmd `cmd:"" help:"List requirements."`
-	Done ReqDoneCmd `cmd:"" help:"Mark a requirement as done."`
+	Set      ReqSetCmd      `cmd:"" help:"Set a requirement."`
+	Get      ReqGetCmd      `cmd:"" help:"Get a requirement."`
+	List     ReqListCmd     `cmd:"" help:"List requirements."`
+	Done     ReqDoneCmd     `cmd:"" help:"Mark a requirement as done."`
+	Summary  ReqSummaryCmd  `cmd:"" help:"Count done and pending requirements per path prefix."`
+	Burndown ReqBurndownCmd `cmd:"" help:"Print total and done requirements per day for a burndown chart."`
+	Extract  ReqExtractCmd  `cmd:"" help:"Create requirements for the commands, flags, HTTP routes, configuration keys and telemetry found in Go source."`
+	API      ReqAPICmd      `cmd:"" name:"api" help:"Create requirements for the exported packages, functions and types of a Go library."`
+	Resolve  ReqResolveCmd  `cmd:"" help:"Mark a public API requirement as ported, renamed or dropped."`
+	Validate ReqValidateCmd `cmd:"" help:"Check the requirement files under .rinku/requirements against the requirement JSON schema."`
 }
 
 type ReqSetCmd struct {
-	Path    string `arg:"" help:"Requirement path (e.g., api/cli)."`
-	Content string `arg:"" optional:"" help:"Requirement content (reads from stdin if omitted)."`
+	Path        string `arg:"" help:"Requirement path (e.g., api/cli)."`
+	Content     string `arg:"" optional:"" help:"Requirement content (reads from stdin if omitted)."`
+	NoNamespace bool   `help:"Store the path as given, outside the namespace of the current step."`
 }
 
 type ReqGetCmd struct {
@@ -128,16 +291,59 @@ type ReqGetCmd struct {
 }
 
 type ReqListCmd struct {
-	Pattern string `arg:"" optional:"" help:"Optional pattern filter (supports * wildcard for single path segment)."`
+	Pattern string `arg:"" optional:"" help:"Optional pattern filter: * and ? within a segment, [a-z] classes, ** for any depth (e.g., api/**/routes)."`
+	Step    string `help:"Only list requirements captured during this step."`
 }
 
 type ReqDoneCmd struct {
 	Path string `arg:"" help:"Requirement path."`
 }
 
+type ReqSummaryCmd struct {
+	Format string `help:"Output format: text or json." enum:"text,json" default:"text"`
+	Depth  int    `help:"Only show prefixes up to this many segments (0 for all)." default:"2"`
+}
+
+type ReqBurndownCmd struct {
+	Format string `help:"Output format: csv or json." enum:"csv,json" default:"csv"`
+}
+
+type IdiomCmd struct {
+	Name string `arg:"" optional:"" help:"Idiom name (e.g., goroutine, context). Lists all idioms if omitted."`
+}
+
 type VerifyCmd struct {
-	Path string `arg:"" optional:"" type:"existingfile" help:"Path to go.mod file (default: go.mod in cwd)."`
-	Impl bool   `help:"Check if requirements are implemented (done)."`
+	Path   string `arg:"" optional:"" type:"existingfile" help:"Path to go.mod file (default: go.mod in cwd)."`
+	Impl   bool   `help:"Check if requirements are implemented (done)."`
+	Strict bool   `help:"Also flag requirements that match no category pattern, such as the typo app/cl/flags."`
+	Format string `default:"text" help:"Output format: text, json, yaml, csv, markdown, html, sarif or porcelain."`
+}
+
+// CoverageResult is the json and yaml output of verify.
+type CoverageResult struct {
+	Tags       []string         `json:"tags"`
+	Categories []CoverageStatus `json:"categories"`
+	Covered    int              `json:"covered"`
+	Missing    int              `json:"missing"`
+	// UnknownRequirements counts the requirements outside every category and Unknown
+	// lists their paths, both with --strict.
+	UnknownRequirements *int     `json:"unknown_requirements,omitempty"`
+	Unknown             []string `json:"unknown,omitzero"`
+}
+
+// CoverageStatus is a requirement category expected from the dependency tags.
+type CoverageStatus struct {
+	Category string `json:"category"`
+	Pattern  string `json:"pattern"`
+	Status   string `json:"status"` // ok or missing
+	Captured int    `json:"captured"`
+	Done     int    `json:"done"`
+}
+
+// ImplementationResult is the json and yaml output of verify --impl.
+type ImplementationResult struct {
+	Done    []string `json:"done"`
+	Pending []string `json:"pending"`
 }
 
 func (c *ReqSetCmd) Run() error {
@@ -160,20 +366,66 @@ func (c *ReqSetCmd) Run() error {
 		return fmt.Errorf("content is required (provide as argument or via stdin)")
 	}
 
-	if err := requirements.Set(cwd, c.Path, content); err != nil {
+	path := c.Path
+	if !c.NoNamespace {
+		if path, err = namespacedPath(cwd, c.Path); err != nil {
+			return err
+		}
+	}
+	if err := requirements.Set(cwd, path, content); err != nil {
 		return fmt.Errorf("setting requirement: %w", err)
 	}
-	fmt.Printf("Set %s\n", c.Path)
+	autoSync(cwd)
+	notifyCoverage(cwd)
+	fmt.Printf("Set %s\n", path)
 	return nil
 }
 
+// namespacedPath returns reqPath in the requirement namespace of the current step, the
+// [requirements] table of .rinku.toml. Without a namespace or a current step it is
+// unchanged.
+func namespacedPath(cwd, reqPath string) (string, error) {
+	cfg, err := config.Load(cwd)
+	if err != nil {
+		return "", err
+	}
+	if cfg.Requirements.Namespace == "" {
+		return reqPath, nil
+	}
+	m, err := progress.Load(cwd)
+	if err != nil {
+		return "", fmt.Errorf("loading progress: %w", err)
+	}
+	if m == nil {
+		return reqPath, nil
+	}
+	return requirements.Namespaced(cfg.Requirements.Namespace, m.GetCurrentStep(), reqPath), nil
+}
+
+// resolveReqPath returns the path of an existing requirement: reqPath in the namespace
+// of the current step if it exists there, otherwise reqPath as given.
+func resolveReqPath(cwd, reqPath string) (string, error) {
+	path, err := namespacedPath(cwd, reqPath)
+	if err != nil || path == requirements.NormalizePath(reqPath) {
+		return reqPath, err
+	}
+	if req, err := requirements.Get(cwd, path); err == nil && req != nil {
+		return path, nil
+	}
+	return reqPath, nil
+}
+
 func (c *ReqGetCmd) Run() error {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("getting current directory: %w", err)
 	}
 
-	req, err := requirements.Get(cwd, c.Path)
+	path, err := resolveReqPath(cwd, c.Path)
+	if err != nil {
+		return err
+	}
+	req, err := requirements.Get(cwd, path)
 	if err != nil {
 		return fmt.Errorf("getting requirement: %w", err)
 	}
@@ -195,19 +447,27 @@ func (c *ReqListCmd) Run() error {
 		return fmt.Errorf("listing requirements: %w", err)
 	}
 
-	if len(paths) == 0 {
-		fmt.Println("No requirements found.")
-		return nil
-	}
-
+	listed := 0
 	for _, p := range paths {
 		req, _ := requirements.Get(cwd, p)
-		if req != nil && req.Done {
+		if c.Step != "" && (req == nil || req.Step != c.Step) {
+			continue
+		}
+		listed++
+		switch {
+		case req != nil && req.Done && req.DoneBy != "":
+			fmt.Printf("[x] %s (done by %s)\n", p, req.DoneBy)
+		case req != nil && req.Done:
 			fmt.Printf("[x] %s\n", p)
-		} else {
+		case req != nil && req.CreatedBy != "":
+			fmt.Printf("[ ] %s (captured by %s)\n", p, req.CreatedBy)
+		default:
 			fmt.Printf("[ ] %s\n", p)
 		}
 	}
+	if listed == 0 {
+		fmt.Println("No requirements found.")
+	}
 	return nil
 }
 
@@ -217,10 +477,115 @@ func (c *ReqDoneCmd) Run() error {
 		return fmt.Errorf("getting current directory: %w", err)
 	}
 
-	if err := requirements.Done(cwd, c.Path); err != nil {
+	path, err := resolveReqPath(cwd, c.Path)
+	if err != nil {
+		return err
+	}
+	if err := requirements.Done(cwd, path); err != nil {
+		return err
+	}
+	autoSync(cwd)
+	notifyCoverage(cwd)
+	fmt.Printf("Marked %s as done\n", path)
+	return nil
+}
+
+func (c *ReqSummaryCmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	summary, err := requirements.Summary(cwd)
+	if err != nil {
 		return err
 	}
-	fmt.Printf("Marked %s as done\n", c.Path)
+	if c.Depth > 0 {
+		prefixes := summary.Prefixes[:0]
+		for _, p := range summary.Prefixes {
+			if strings.Count(p.Prefix, "/") < c.Depth {
+				prefixes = append(prefixes, p)
+			}
+		}
+		summary.Prefixes = prefixes
+	}
+
+	if c.Format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(render.Versioned(render.APIVersion, "req summary", summary)); err != nil {
+			return fmt.Errorf("encoding summary: %w", err)
+		}
+		return nil
+	}
+
+	if summary.Total == 0 {
+		fmt.Println("No requirements found.")
+		return nil
+	}
+	fmt.Printf("Requirements: %d/%d done (%d%%)\n", summary.Done, summary.Total, summary.Coverage())
+	fmt.Printf("Last change:  %s\n\n", summary.LastUpdated.Local().Format("2006-01-02 15:04:05"))
+	for _, p := range summary.Prefixes {
+		indent := strings.Repeat("  ", strings.Count(p.Prefix, "/"))
+		fmt.Printf("  %-40s %4d/%-4d %s\n", indent+p.Prefix, p.Done, p.Total, p.LastUpdated.Local().Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func (c *ReqBurndownCmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	reqs, err := requirements.GetAll(cwd, "")
+	if err != nil {
+		return fmt.Errorf("reading requirements: %w", err)
+	}
+	days := requirements.Burndown(reqs, time.Local)
+
+	if c.Format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(render.Versioned(render.APIVersion, "req burndown", days)); err != nil {
+			return fmt.Errorf("encoding burndown: %w", err)
+		}
+		return nil
+	}
+	cw := csv.NewWriter(os.Stdout)
+	_ = cw.Write([]string{"date", "total", "done", "remaining"})
+	for _, d := range days {
+		_ = cw.Write([]string{d.Date, strconv.Itoa(d.Total), strconv.Itoa(d.Done), strconv.Itoa(d.Remaining)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (c *IdiomCmd) Run() error {
+	db, err := idiom.Load()
+	if err != nil {
+		return fmt.Errorf("loading idioms: %w", err)
+	}
+
+	if c.Name == "" {
+		for _, i := range db.All() {
+			fmt.Printf("%-20s %s\n", i.Name, i.Go)
+		}
+		return nil
+	}
+
+	i, ok := db.Lookup(c.Name)
+	if !ok {
+		return fmt.Errorf("idiom '%s' not found\nHint: Run 'rinku idiom' to list all idioms", c.Name)
+	}
+	fmt.Printf("%s\n\n", i.Name)
+	fmt.Printf("Go:\n  %s\n\n", i.Go)
+	fmt.Printf("Rust:\n  %s\n", i.Rust)
+	if len(i.Crates) > 0 {
+		fmt.Printf("\nCrates: %s\n", strings.Join(i.Crates, ", "))
+	}
+	if i.Notes != "" {
+		fmt.Printf("\n%s\n", i.Notes)
+	}
 	return nil
 }
 
@@ -237,26 +602,60 @@ func (c *VerifyCmd) Run(r *rinku.Rinku) error {
 			return fmt.Errorf("checking implementation: %w", err)
 		}
 
-		fmt.Printf("Implementation Status\n")
-		fmt.Printf("=====================\n")
-		fmt.Printf("Done:    %d\n", len(done))
-		fmt.Printf("Pending: %d\n\n", len(pending))
-
-		if len(pending) > 0 {
-			fmt.Println("Pending requirements:")
-			for _, p := range pending {
-				fmt.Printf("  [ ] %s\n", p)
-			}
+		doc := &render.Document{
+			Command: "verify",
+			Title:   "Implementation Status",
+			Fields: []render.Field{
+				{Name: "Done", Value: strconv.Itoa(len(done))},
+				{Name: "Pending", Value: strconv.Itoa(len(pending))},
+			},
+			Columns: []string{"requirement", "status"},
+			Data:    &ImplementationResult{Done: append([]string{}, done...), Pending: append([]string{}, pending...)},
 		}
+		for _, p := range pending {
+			doc.Rows = append(doc.Rows, []string{p, "pending"})
+			doc.Findings = append(doc.Findings, render.Finding{
+				Rule:    "pending-requirement",
+				Level:   render.LevelNote,
+				Message: fmt.Sprintf("requirement %s is not done", p),
+				Subject: p,
+			})
+		}
+		for _, p := range done {
+			doc.Rows = append(doc.Rows, []string{p, "done"})
+		}
+		if err := progress.AppendEvent(cwd, progress.Event{
+			Type:  progress.EventVerify,
+			Actor: progress.Actor(cwd),
+			Data:  map[string]any{"check": "implementation", "done": len(done), "pending": len(pending)},
+		}); err != nil {
+			return err
+		}
+		doc.Text = func(w io.Writer) error {
+			fmt.Fprintf(w, "Implementation Status\n")
+			fmt.Fprintf(w, "=====================\n")
+			fmt.Fprintf(w, "Done:    %d\n", len(done))
+			fmt.Fprintf(w, "Pending: %d\n\n", len(pending))
+
+			if len(pending) > 0 {
+				fmt.Fprintln(w, "Pending requirements:")
+				for _, p := range pending {
+					fmt.Fprintf(w, "  [ ] %s\n", p)
+				}
+			}
 
-		if len(done) > 0 {
-			fmt.Println("\nCompleted requirements:")
-			for _, p := range done {
-				fmt.Printf("  [x] %s\n", p)
+			if len(done) > 0 {
+				fmt.Fprintln(w, "\nCompleted requirements:")
+				for _, p := range done {
+					fmt.Fprintf(w, "  [x] %s\n", p)
+				}
 			}
+			return nil
 		}
-
-		return nil
+		if err := applySuppressions(doc); err != nil {
+			return err
+		}
+		return render.Render(os.Stdout, c.Format, doc)
 	}
 
 	// Coverage check: needs go.mod path
@@ -265,59 +664,160 @@ func (c *VerifyCmd) Run(r *rinku.Rinku) error {
 		path = "go.mod"
 	}
 
-	// Parse go.mod to get dependencies
-	result, err := gomod.Parse(path)
+	tags, err := dependencyTags(r, path)
 	if err != nil {
-		return fmt.Errorf("parsing go.mod: %w", err)
+		return err
 	}
 
-	// Get tags from dependencies
-	deps := result.DirectDependencies()
+	// Check coverage
+	strip, err := namespaceStrip(cwd)
+	if err != nil {
+		return err
+	}
+	statuses, err := verify.CheckCoverage(cwd, tags, strip)
+	if err != nil {
+		return fmt.Errorf("checking coverage: %w", err)
+	}
+
+	result := &CoverageResult{Tags: append([]string{}, tags...), Categories: []CoverageStatus{}}
+	doc := &render.Document{
+		Command: "verify",
+		Title:   "Requirement Coverage",
+		Fields:  []render.Field{{Name: "Detected tags", Value: strings.Join(tags, ", ")}},
+		Columns: []string{"category", "pattern", "status", "captured", "done"},
+		Data:    result,
+	}
+	for _, s := range statuses {
+		status := "ok"
+		if !s.HasRequirements {
+			status = "missing"
+			result.Missing++
+			doc.Findings = append(doc.Findings, render.Finding{
+				Rule:    "missing-requirements",
+				Level:   render.LevelWarning,
+				Message: fmt.Sprintf("no %s requirements captured (%s)", s.Category, s.Pattern),
+				File:    relPath(path),
+				Subject: s.Category,
+			})
+		}
+		result.Categories = append(result.Categories, CoverageStatus{Category: s.Category, Pattern: s.Pattern, Status: status, Captured: s.Count, Done: s.DoneCount})
+		doc.Rows = append(doc.Rows, []string{s.Category, s.Pattern, status, strconv.Itoa(s.Count), strconv.Itoa(s.DoneCount)})
+	}
+	result.Covered = len(statuses) - result.Missing
+	data := map[string]any{"check": "coverage", "covered": result.Covered, "missing": result.Missing}
+	var unknown []string
+	if c.Strict {
+		if unknown, err = unknownRequirements(cwd); err != nil {
+			return err
+		}
+		for _, p := range unknown {
+			doc.Findings = append(doc.Findings, render.Finding{
+				Rule:    "unknown-requirement",
+				Level:   render.LevelWarning,
+				Message: fmt.Sprintf("requirement %s matches no category pattern and escapes every coverage check", p),
+				Subject: p,
+			})
+		}
+		doc.Fields = append(doc.Fields, render.Field{Name: "Unknown requirements", Value: strconv.Itoa(len(unknown))})
+		n := len(unknown)
+		result.UnknownRequirements = &n
+		result.Unknown = append([]string{}, unknown...)
+		data["unknown"] = n
+	}
+	if err := progress.AppendEvent(cwd, progress.Event{
+		Type:  progress.EventVerify,
+		Actor: progress.Actor(cwd),
+		Data:  data,
+	}); err != nil {
+		return err
+	}
+	doc.Text = func(w io.Writer) error {
+		fmt.Fprintf(w, "Requirement Coverage\n")
+		fmt.Fprintf(w, "====================\n")
+		fmt.Fprintf(w, "Detected tags: %v\n\n", tags)
+
+		if len(statuses) == 0 {
+			fmt.Fprintln(w, "No expected requirement categories detected.")
+		}
+		for _, s := range statuses {
+			status := "MISSING"
+			if s.HasRequirements {
+				status = fmt.Sprintf("OK (%d captured, %d done)", s.Count, s.DoneCount)
+			}
+			fmt.Fprintf(w, "  %-20s [%s] %s\n", s.Category, s.Pattern, status)
+		}
+		if len(unknown) > 0 {
+			fmt.Fprintln(w, "\nRequirements outside every category:")
+			for _, p := range unknown {
+				fmt.Fprintf(w, "  [?] %s\n", p)
+			}
+		}
+
+		if slices.ContainsFunc(doc.Findings, func(f render.Finding) bool { return f.Rule == "missing-requirements" && !f.Suppressed() }) {
+			fmt.Fprintln(w, "\nHint: Capture requirements for missing categories before proceeding.")
+		}
+		if slices.ContainsFunc(doc.Findings, func(f render.Finding) bool { return f.Rule == "unknown-requirement" && !f.Suppressed() }) {
+			fmt.Fprintln(w, "\nHint: Move misfiled requirements to a category path, e.g. <bin>/cli or <bin>/api, or suppress them.")
+		}
+		return nil
+	}
+	if err := applySuppressions(doc); err != nil {
+		return err
+	}
+	return render.Render(os.Stdout, c.Format, doc)
+}
+
+// dependencyTags returns the sorted tags of the direct dependencies of a go.mod, which
+// select the requirement categories verify expects.
+func dependencyTags(r *rinku.Rinku, path string) ([]string, error) {
+	result, err := gomod.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("parsing go.mod: %w", err)
+	}
 	tagSet := make(map[string]struct{})
-	for _, dep := range deps {
+	for _, dep := range result.DirectDependencies() {
 		ghURL := cargo.ModulePathToGitHubURL(dep.Path)
 		for _, tag := range r.Tags(ghURL) {
 			tagSet[tag] = struct{}{}
 		}
 	}
-
-	// Convert to slice
 	var tags []string
 	for tag := range tagSet {
 		tags = append(tags, tag)
 	}
+	sort.Strings(tags)
+	return tags, nil
+}
 
-	// Check coverage
-	statuses, err := verify.CheckCoverage(cwd, tags)
+// namespaceStrip returns the func removing the step namespace of the .rinku.toml in dir
+// from a requirement path, so coverage checks, step gates and the unknown check match
+// namespaced requirements like the others.
+func namespaceStrip(dir string) (func(string) string, error) {
+	cfg, err := config.Load(dir)
 	if err != nil {
-		return fmt.Errorf("checking coverage: %w", err)
+		return nil, err
 	}
+	return func(p string) string {
+		return requirements.StripNamespace(cfg.Requirements.Namespace, p)
+	}, nil
+}
 
-	fmt.Printf("Requirement Coverage\n")
-	fmt.Printf("====================\n")
-	fmt.Printf("Detected tags: %v\n\n", tags)
-
-	if len(statuses) == 0 {
-		fmt.Println("No expected requirement categories detected.")
-		return nil
+// unknownRequirements returns the requirements no coverage check or step gate matches,
+// after removing the step namespace of .rinku.toml.
+func unknownRequirements(cwd string) ([]string, error) {
+	strip, err := namespaceStrip(cwd)
+	if err != nil {
+		return nil, err
 	}
-
-	allCovered := true
-	for _, s := range statuses {
-		status := "MISSING"
-		if s.HasRequirements {
-			status = fmt.Sprintf("OK (%d captured, %d done)", s.Count, s.DoneCount)
-		} else {
-			allCovered = false
-		}
-		fmt.Printf("  %-20s [%s] %s\n", s.Category, s.Pattern, status)
+	var gates []string
+	for _, patterns := range stepRequirementPaths {
+		gates = append(gates, patterns...)
 	}
-
-	if !allCovered {
-		fmt.Println("\nHint: Capture requirements for missing categories before proceeding.")
+	unknown, err := verify.CheckUnknown(cwd, gates, strip)
+	if err != nil {
+		return nil, fmt.Errorf("checking requirement paths: %w", err)
 	}
-
-	return nil
+	return unknown, nil
 }
 
 func (c *LookupCmd) Run(r *rinku.Rinku) error {
@@ -327,29 +827,172 @@ func (c *LookupCmd) Run(r *rinku.Rinku) error {
 	if !isValidURL(c.URL) {
 		return fmt.Errorf("invalid URL: must start with http:// or https://")
 	}
-	for _, result := range r.Lookup(c.URL, c.Language, c.Unsafe) {
-		fmt.Println(result)
+	languages := c.Target
+	if c.Language != "" {
+		if len(languages) > 0 {
+			return fmt.Errorf("give the target language as argument or with --target, not both")
+		}
+		languages = []string{c.Language}
+	}
+	if len(languages) == 0 {
+		languages = []string{"rust"}
+	}
+	if c.MinConfidence < 0 || c.MinConfidence > 1 {
+		return fmt.Errorf("--min-confidence %v is not between 0 and 1", c.MinConfidence)
+	}
+	format := c.Format
+	if c.JSON {
+		format = "json"
+	}
+	if len(languages) == 1 {
+		return render.Render(os.Stdout, format, lookupDocument(r, c.URL, languages[0], c.Unsafe, c.MinConfidence))
 	}
-	// Show required dependencies if any
-	for _, dep := range r.RequiredDeps(c.URL, c.Language) {
-		if len(dep.Features) > 0 {
-			fmt.Printf("  requires: %s (features: %v)\n", dep.Crate, dep.Features)
-		} else {
-			fmt.Printf("  requires: %s\n", dep.Crate)
+	return render.Render(os.Stdout, format, lookupTargetsDocument(r, c.URL, languages, c.Unsafe, c.MinConfidence))
+}
+
+// lookupDocument returns the lookup output of one target language. A mapping with a
+// confidence below minConfidence is reported as a note instead of its targets.
+func lookupDocument(r *rinku.Rinku, libURL, language string, unsafe bool, minConfidence float64) *render.Document {
+	results := r.Lookup(libURL, language, unsafe)
+	category := r.Category(libURL, language)
+	requires := r.RequiredDeps(libURL, language)
+	confidence := r.Confidence(libURL, language)
+	var below string
+	if len(results) > 0 && confidence < minConfidence {
+		results, requires = nil, nil
+		below = fmt.Sprintf("the %s mapping of %s has confidence %s, below --min-confidence %s", language, libURL, formatConfidence(confidence), formatConfidence(minConfidence))
+		if confidence == 0 {
+			below = fmt.Sprintf("the %s mapping of %s records no confidence, --min-confidence %s needs one", language, libURL, formatConfidence(minConfidence))
 		}
 	}
-	return nil
+	safe := make(map[string]bool)
+	if unsafe {
+		for _, result := range r.Lookup(libURL, language, false) {
+			safe[result] = true
+		}
+	}
+
+	data := &LookupResult{Source: url.Normalize(libURL), Language: language, Targets: []LookupTarget{}, Requires: requires}
+	doc := &render.Document{
+		Command: "lookup",
+		Title:   libURL,
+		Fields:  []render.Field{{Name: "Target language", Value: language}},
+		Columns: []string{"url", "category", "confidence"},
+		Data:    data,
+	}
+	for _, result := range results {
+		doc.Rows = append(doc.Rows, []string{result, category, formatConfidence(confidence)})
+		t := LookupTarget{URL: result, Category: category, Confidence: confidence, Unsafe: unsafe && !safe[result]}
+		if language == "rust" {
+			if t.Crate = r.CrateName(result); t.Crate == "" {
+				t.Crate = cargo.ExtractCrateName(result)
+			}
+		}
+		data.Targets = append(data.Targets, t)
+	}
+	switch {
+	case below != "":
+		doc.Findings = append(doc.Findings, render.Finding{
+			Rule:    "low-confidence-mapping",
+			Level:   render.LevelNote,
+			Message: below,
+		})
+	case len(results) == 0:
+		doc.Findings = append(doc.Findings, render.Finding{
+			Rule:    "unmapped-dependency",
+			Level:   render.LevelNote,
+			Message: fmt.Sprintf("no %s equivalent found for %s", language, libURL),
+		})
+	}
+	if minConfidence > 0 {
+		doc.Fields = append(doc.Fields, render.Field{Name: "Minimum confidence", Value: formatConfidence(minConfidence)})
+	}
+	if len(requires) > 0 {
+		var names []string
+		for _, dep := range requires {
+			names = append(names, dep.Crate)
+		}
+		doc.Fields = append(doc.Fields, render.Field{Name: "Requires", Value: strings.Join(names, ", ")})
+	}
+	doc.Text = func(w io.Writer) error {
+		for _, result := range results {
+			fmt.Fprintln(w, result)
+		}
+		if len(results) == 0 {
+			if below != "" {
+				fmt.Fprintf(w, "No mapping: %s\n", below)
+			}
+			return nil
+		}
+		if category != "" {
+			fmt.Fprintf(w, "  category: %s\n", category)
+		}
+		if confidence > 0 {
+			fmt.Fprintf(w, "  confidence: %s\n", formatConfidence(confidence))
+		}
+		// Show required dependencies if any
+		for _, dep := range requires {
+			if len(dep.Features) > 0 {
+				fmt.Fprintf(w, "  requires: %s (features: %v)\n", dep.Crate, dep.Features)
+			} else {
+				fmt.Fprintf(w, "  requires: %s\n", dep.Crate)
+			}
+		}
+		return nil
+	}
+	return doc
 }
 
-func (c *MigrateCmd) Run() error {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("getting current directory: %w", err)
+// lookupTargetsDocument returns the lookup output of several target languages, grouped
+// by language.
+func lookupTargetsDocument(r *rinku.Rinku, libURL string, languages []string, unsafe bool, minConfidence float64) *render.Document {
+	data := &LookupTargets{Source: url.Normalize(libURL), Languages: make([]*LookupResult, 0, len(languages))}
+	doc := &render.Document{
+		Command: "lookup",
+		Title:   libURL,
+		Fields:  []render.Field{{Name: "Target languages", Value: strings.Join(languages, ", ")}},
+		Columns: []string{"language", "url", "category", "confidence"},
+		Data:    data,
+	}
+	var sections []*render.Document
+	for _, language := range languages {
+		section := lookupDocument(r, libURL, language, unsafe, minConfidence)
+		sections = append(sections, section)
+		data.Languages = append(data.Languages, section.Data.(*LookupResult))
+		for _, row := range section.Rows {
+			doc.Rows = append(doc.Rows, append([]string{language}, row...))
+		}
+		doc.Findings = append(doc.Findings, section.Findings...)
+	}
+	doc.Text = func(w io.Writer) error {
+		for i, section := range sections {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "%s:\n", languages[i])
+			var buf bytes.Buffer
+			if err := section.Text(&buf); err != nil {
+				return err
+			}
+			if buf.Len() == 0 {
+				fmt.Fprintln(w, "  (no mapping found)")
+				continue
+			}
+			for _, line := range strings.SplitAfter(buf.String(), "\n") {
+				if line != "" {
+					fmt.Fprintf(w, "  %s", line)
+				}
+			}
+		}
+		return nil
 	}
+	return doc
+}
 
-	p, err := prompt.Migration()
+func (c *MigrateStepCmd) Run(index mappingIndex) error {
+	cwd, err := os.Getwd()
 	if err != nil {
-		return fmt.Errorf("failed to load migration prompt: %w", err)
+		return fmt.Errorf("getting current directory: %w", err)
 	}
 
 	// Handle --reset first
@@ -366,8 +1009,17 @@ func (c *MigrateCmd) Run() error {
 	if err != nil {
 		return fmt.Errorf("loading progress: %w", err)
 	}
+	workflow, err := c.selectWorkflow(m)
+	if err != nil {
+		return err
+	}
+	p, err := prompt.ForWorkflow(cwd, workflow)
+	if err != nil {
+		return fmt.Errorf("failed to load migration prompt: %w", err)
+	}
 	if m == nil {
 		m = progress.New(cwd, p.Steps())
+		m.Workflow = workflow
 		if err := m.Save(cwd); err != nil {
 			return fmt.Errorf("saving initial progress: %w", err)
 		}
@@ -375,33 +1027,39 @@ func (c *MigrateCmd) Run() error {
 
 	// Handle --status
 	if c.Status {
-		showMigrationStatus(m)
-		return nil
+		doc, err := migrationStatus(cwd, m, p.Estimates())
+		if err != nil {
+			return err
+		}
+		return render.Render(os.Stdout, c.Format, doc)
 	}
 
 	// Handle --start <step>
 	if c.Start != "" {
-		if err := m.StartStep(c.Start); err != nil {
+		// Adopt changes to the workflow overrides since the migration was created
+		m.Workflow = workflow
+		m.SetStepOrder(p.Steps())
+		if err := m.StartStep(c.Start, progress.Actor(cwd)); err != nil {
 			return err
 		}
 		if err := m.Save(cwd); err != nil {
 			return fmt.Errorf("saving progress: %w", err)
 		}
+		autoSync(cwd)
 		content, ok := p.GetStep(c.Start)
 		if !ok {
 			return fmt.Errorf("step '%s' not found", c.Start)
 		}
-		// Show Before section if present
-		if before := p.Before(); before != "" {
-			fmt.Println(before)
-			fmt.Println()
+		content, err = expandStep(cwd, c.Start, content)
+		if err != nil {
+			return err
 		}
-		fmt.Println(content)
-		// Show After section if present
-		if after := p.After(); after != "" {
-			fmt.Println()
-			fmt.Println(after)
+		categories := p.Categories(c.Start)
+		deps, err := c.dependencies(index, cwd, categories)
+		if err != nil {
+			return err
 		}
+		writeStepOutput(os.Stdout, p.Before(), content, p.After(), categories, deps)
 		return nil
 	}
 
@@ -412,16 +1070,52 @@ func (c *MigrateCmd) Run() error {
 			return fmt.Errorf("cannot finish step %s: %w", c.Finish, err)
 		}
 
-		if err := m.CompleteStep(c.Finish, c.Note); err != nil {
+		cfg, err := config.Load(cwd)
+		if err != nil {
+			return err
+		}
+		m.StrictOrder = cfg.Migration.StrictOrder
+		m.AutoAdvance = cfg.Migration.AutoAdvance
+		m.StrictChecklist = cfg.Migration.StrictChecklist
+		m.Checklists = make(map[string]int)
+		for _, id := range p.Steps() {
+			m.Checklists[id] = len(p.Checklist(id))
+		}
+		current := m.CurrentStep
+		if err := m.CompleteStep(c.Finish, c.Note, progress.Actor(cwd)); err != nil {
+			var orderErr *progress.OrderError
+			if errors.As(err, &orderErr) {
+				return fmt.Errorf("cannot finish step %s: %w\nHint: Finish the earlier steps first; strict_order in the [migration] table of %s enforces the order", c.Finish, err, config.FileName)
+			}
+			var checklistErr *progress.ChecklistError
+			if errors.As(err, &checklistErr) {
+				return fmt.Errorf("cannot finish step %s: %w\nHint: Check off the items with rinku migrate check %s %d; strict_checklist in the [migration] table of %s requires all of them", c.Finish, err, c.Finish, checklistErr.Unchecked[0], config.FileName)
+			}
 			return err
 		}
 		if err := m.Save(cwd); err != nil {
 			return fmt.Errorf("saving progress: %w", err)
 		}
+		autoSync(cwd)
+		notifyStepCompleted(cwd, m, c.Finish)
 		fmt.Printf("Completed step %s\n", c.Finish)
+		if m.CurrentStep != current {
+			fmt.Printf("Current step: %s (rinku migrate --start %s)\n", m.CurrentStep, m.CurrentStep)
+		}
+		if m.IsComplete() {
+			if err := writeCompletionCertificate(index, cwd, m); err != nil {
+				return err
+			}
+			fmt.Printf("Wrote %s\n", filepath.Join(progress.ProgressDir, certificate.File))
+		}
 		return nil
 	}
 
+	// Handle --skip <step>
+	if c.Skip != "" {
+		return c.skip(index, cwd, m)
+	}
+
 	// Default: show step content
 	// No args = show introduction (entry point)
 	// Explicit step = show that step
@@ -438,31 +1132,369 @@ func (c *MigrateCmd) Run() error {
 	if !ok {
 		return fmt.Errorf("step '%s' not found", c.Step)
 	}
+	content, err = expandStep(cwd, c.Step, content)
+	if err != nil {
+		return err
+	}
 	fmt.Println(content)
-	return nil
+	return c.printContext(index, cwd, p, c.Step)
 }
 
-func showMigrationStatus(m *progress.Migration) {
-	completed, total := m.Progress()
-	fmt.Printf("Migration Progress: %d/%d steps\n", completed, total)
-	fmt.Printf("Current step: %s\n", m.CurrentStep)
-	fmt.Printf("Started: %s\n\n", m.StartedAt.Format("2006-01-02 15:04:05"))
-
-	for _, id := range m.StepOrder {
-		step := m.Steps[id]
-		symbol := statusSymbol(step.Status)
-		fmt.Printf("  %s Step %s", symbol, id)
-		if step.Status == progress.StepCompleted && step.CompletedAt != nil {
-			fmt.Printf(" (completed %s)", step.CompletedAt.Format("Jan 2 15:04"))
-		}
-		if step.Notes != "" {
-			fmt.Printf("\n      Note: %s", step.Notes)
-		}
-		fmt.Println()
+// skip marks c.Skip as skipped. Unlike --finish, it ignores the gate, the order and
+// the checklist: the reason code and note record why the work is not done.
+func (c *MigrateStepCmd) skip(index mappingIndex, cwd string, m *progress.Migration) error {
+	code, err := reason.Parse(c.Reason)
+	if err != nil {
+		return fmt.Errorf("cannot skip step %s: %w", c.Skip, err)
 	}
-}
-
-func statusSymbol(s progress.StepStatus) string {
+	if c.Note == "" {
+		return fmt.Errorf("cannot skip step %s: --note must say why", c.Skip)
+	}
+	cfg, err := config.Load(cwd)
+	if err != nil {
+		return err
+	}
+	m.AutoAdvance = cfg.Migration.AutoAdvance
+	current := m.CurrentStep
+	if err := m.SkipStep(c.Skip, code, c.Note, progress.Actor(cwd)); err != nil {
+		return err
+	}
+	if err := m.Save(cwd); err != nil {
+		return fmt.Errorf("saving progress: %w", err)
+	}
+	autoSync(cwd)
+	fmt.Printf("Skipped step %s (%s)\n", c.Skip, code)
+	if m.CurrentStep != current {
+		fmt.Printf("Current step: %s (rinku migrate --start %s)\n", m.CurrentStep, m.CurrentStep)
+	}
+	if m.IsComplete() {
+		if err := writeCompletionCertificate(index, cwd, m); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s\n", filepath.Join(progress.ProgressDir, certificate.File))
+	}
+	return nil
+}
+
+// selectWorkflow returns the catalog workflow of the migration: the one of --workflow,
+// which only a migration without started steps may switch to, or else the one it was
+// started with.
+func (c *MigrateStepCmd) selectWorkflow(m *progress.Migration) (string, error) {
+	current := ""
+	if m != nil {
+		current = m.Workflow
+	}
+	if c.Workflow == "" {
+		return current, nil
+	}
+	if c.Start == "" {
+		return "", errors.New("--workflow selects the workflow of a migration and needs --start")
+	}
+	workflow := c.Workflow
+	if workflow == prompt.DefaultWorkflow {
+		workflow = ""
+	}
+	if m != nil && workflow != current {
+		for _, step := range m.Steps {
+			if step.Status != progress.StepPending {
+				name := current
+				if name == "" {
+					name = prompt.DefaultWorkflow
+				}
+				return "", fmt.Errorf("the migration runs the %s workflow\nHint: rinku migrate --reset discards its progress to start over with %s", name, c.Workflow)
+			}
+		}
+	}
+	return workflow, nil
+}
+
+// printContext prints the project dependencies matching the categories of a step.
+func (c *MigrateStepCmd) printContext(index mappingIndex, cwd string, p *multistep.Prompt, id string) error {
+	if c.NoContext {
+		return nil
+	}
+	categories := p.Categories(id)
+	deps, err := c.dependencies(index, cwd, categories)
+	if err != nil {
+		return err
+	}
+	writeStepContext(os.Stdout, categories, deps)
+	return nil
+}
+
+// dependencies returns the project dependencies matching the categories of a step,
+// none with --no-context. Only a step with categories builds the mapping index.
+func (c *MigrateStepCmd) dependencies(index mappingIndex, cwd string, categories []string) ([]StepDependency, error) {
+	if c.NoContext || len(categories) == 0 {
+		return nil, nil
+	}
+	r, err := index()
+	if err != nil {
+		return nil, err
+	}
+	return stepDependencies(r, cwd, categories), nil
+}
+
+// StatusResult is the json and yaml output of migrate --status.
+type StatusResult struct {
+	Completed    int       `json:"completed"`
+	Total        int       `json:"total"`
+	CurrentStep  string    `json:"current_step"`
+	NextStep     string    `json:"next_step,omitempty"`
+	StartedAt    time.Time `json:"started_at"`
+	Workflow     string    `json:"workflow,omitempty"`
+	Contributors []string  `json:"contributors,omitempty"`
+	Finish       time.Time `json:"finish,omitzero"` // estimated, see Migration.Forecast
+	// Requirements counts the captured requirements, if there are any.
+	Requirements *RequirementCounts `json:"requirements,omitempty"`
+	Skipped      map[string]int     `json:"skipped,omitempty"`      // skipped steps by reason code
+	Suppressions map[string]int     `json:"suppressions,omitempty"` // suppressions in effect by reason code
+	Steps        []StepState        `json:"steps"`
+}
+
+// RequirementCounts counts captured requirements.
+type RequirementCounts struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// StepState is a step of the migration as listed by migrate --status.
+type StepState struct {
+	ID              string     `json:"id"`
+	Status          string     `json:"status"`
+	SkipCode        string     `json:"skip_code,omitempty"`
+	StartedBy       string     `json:"started_by,omitempty"`
+	CompletedBy     string     `json:"completed_by,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+	ElapsedSeconds  int64      `json:"elapsed_seconds,omitempty"`
+	EstimateSeconds int64      `json:"estimate_seconds,omitempty"`
+	Notes           string     `json:"notes,omitempty"`
+	Artifacts       []string   `json:"artifacts,omitempty"`
+}
+
+// reasonCounts returns counts keyed by reason code, "none" for items without one.
+func reasonCounts(counts reason.Counts) map[string]int {
+	if counts.Total() == 0 {
+		return nil
+	}
+	m := make(map[string]int)
+	for code, n := range counts {
+		if n == 0 {
+			continue
+		}
+		key := string(code)
+		if key == "" {
+			key = "none"
+		}
+		m[key] = n
+	}
+	return m
+}
+
+// migrationStatus returns the migration progress shown by migrate --status.
+func migrationStatus(cwd string, m *progress.Migration, estimates map[string]time.Duration) (*render.Document, error) {
+	now := time.Now()
+	completed, total := m.Progress()
+	summary, err := requirements.Summary(cwd)
+	if err != nil {
+		return nil, err
+	}
+	supp, _, err := loadSuppressions(cwd)
+	if err != nil {
+		return nil, err
+	}
+	skipped, suppressed := m.SkipCounts(), supp.Counts(now)
+	artifacts := make(map[string][]progress.Artifact)
+	for _, id := range m.StepOrder {
+		if artifacts[id], err = progress.Artifacts(cwd, id); err != nil {
+			return nil, err
+		}
+	}
+
+	doc := &render.Document{
+		Command: "status",
+		Title:   "Migration Progress",
+		Fields: []render.Field{
+			{Name: "Progress", Value: fmt.Sprintf("%d/%d", completed, total)},
+			{Name: "Current step", Value: m.CurrentStep},
+			{Name: "Started", Value: m.StartedAt.Format("2006-01-02 15:04:05")},
+		},
+		Columns: []string{"step", "status", "by", "completed", "timing", "notes", "artifacts"},
+	}
+	data := &StatusResult{
+		Completed:    completed,
+		Total:        total,
+		CurrentStep:  m.CurrentStep,
+		NextStep:     m.NextStep(),
+		StartedAt:    m.StartedAt,
+		Workflow:     m.Workflow,
+		Skipped:      reasonCounts(skipped),
+		Suppressions: reasonCounts(suppressed),
+		Steps:        []StepState{},
+	}
+	doc.Data = data
+	if m.Workflow != "" {
+		doc.Fields = append(doc.Fields, render.Field{Name: "Workflow", Value: m.Workflow})
+	}
+	if next := m.NextStep(); next != "" {
+		doc.Fields = append(doc.Fields, render.Field{Name: "Next step", Value: next})
+	}
+	contributors := m.Contributors()
+	data.Contributors = contributors
+	if len(contributors) > 0 {
+		doc.Fields = append(doc.Fields, render.Field{Name: "Contributors", Value: strings.Join(contributors, ", ")})
+	}
+	var forecast *progress.Forecast
+	if len(estimates) > 0 && !m.IsComplete() {
+		f := m.Forecast(estimates, now)
+		forecast = &f
+		data.Finish = f.Finish
+		doc.Fields = append(doc.Fields, render.Field{Name: "ETA", Value: f.Finish.Format("2006-01-02 15:04:05")})
+	}
+	if summary.Total > 0 {
+		data.Requirements = &RequirementCounts{Done: summary.Done, Total: summary.Total}
+		doc.Fields = append(doc.Fields, render.Field{Name: "Requirements", Value: fmt.Sprintf("%d/%d", summary.Done, summary.Total)})
+	}
+	if skipped.Total() > 0 {
+		doc.Fields = append(doc.Fields, render.Field{Name: "Skipped", Value: skipped.String()})
+	}
+	if suppressed.Total() > 0 {
+		doc.Fields = append(doc.Fields, render.Field{Name: "Suppressions", Value: suppressed.String()})
+	}
+	for _, id := range m.StepOrder {
+		step := m.Steps[id]
+		status, by, completedAt := string(step.Status), step.StartedBy, ""
+		switch {
+		case step.Status == progress.StepCompleted && step.CompletedAt != nil:
+			by, completedAt = step.CompletedBy, step.CompletedAt.Format("2006-01-02 15:04:05")
+		case step.Status == progress.StepSkipped && step.SkipCode != "":
+			status, by = status+" ("+string(step.SkipCode)+")", step.CompletedBy
+		}
+		var paths []string
+		for _, a := range artifacts[id] {
+			paths = append(paths, a.Path)
+		}
+		doc.Rows = append(doc.Rows, []string{id, status, by, completedAt, stepTiming(step, estimates[id], now), step.Notes, strings.Join(paths, ", ")})
+		data.Steps = append(data.Steps, StepState{
+			ID:              id,
+			Status:          string(step.Status),
+			SkipCode:        string(step.SkipCode),
+			StartedBy:       step.StartedBy,
+			CompletedBy:     step.CompletedBy,
+			CompletedAt:     step.CompletedAt,
+			ElapsedSeconds:  int64(step.Elapsed(now) / time.Second),
+			EstimateSeconds: int64(estimates[id] / time.Second),
+			Notes:           step.Notes,
+			Artifacts:       paths,
+		})
+	}
+
+	doc.Text = func(w io.Writer) error {
+		fmt.Fprintf(w, "Migration Progress: %d/%d steps\n", completed, total)
+		fmt.Fprintf(w, "Current step: %s\n", m.CurrentStep)
+		fmt.Fprintf(w, "Started: %s\n", m.StartedAt.Format("2006-01-02 15:04:05"))
+		if m.Workflow != "" {
+			fmt.Fprintf(w, "Workflow: %s\n", m.Workflow)
+		}
+		if len(contributors) > 0 {
+			fmt.Fprintf(w, "Contributors: %s\n", strings.Join(contributors, ", "))
+		}
+		if f := forecast; f != nil {
+			fmt.Fprintf(w, "ETA: ~%s remaining at %.2fx pace (finish around %s)", formatDuration(f.Remaining), f.Pace, f.Finish.Format("Jan 2 15:04"))
+			if f.Unestimated > 0 {
+				fmt.Fprintf(w, ", %d steps without estimate", f.Unestimated)
+			}
+			fmt.Fprintln(w)
+		}
+		if summary.Total > 0 {
+			fmt.Fprintf(w, "Requirements: %d/%d done (%d%%), last change %s\n", summary.Done, summary.Total, summary.Coverage(), summary.LastUpdated.Format("2006-01-02 15:04:05"))
+		}
+		if n := skipped.Total(); n > 0 {
+			fmt.Fprintf(w, "Skipped steps: %d (%s)\n", n, skipped)
+		}
+		if n := suppressed.Total(); n > 0 {
+			fmt.Fprintf(w, "Suppressions: %d in effect (%s)\n", n, suppressed)
+		}
+		fmt.Fprintln(w)
+
+		for _, id := range m.StepOrder {
+			step := m.Steps[id]
+			symbol := statusSymbol(step.Status)
+			fmt.Fprintf(w, "  %s Step %s", symbol, id)
+			if step.Status == progress.StepCompleted && step.CompletedAt != nil {
+				fmt.Fprintf(w, " (completed %s%s)", step.CompletedAt.Format("Jan 2 15:04"), byline(step.CompletedBy))
+			} else if step.Status == progress.StepInProgress && step.StartedBy != "" {
+				fmt.Fprintf(w, " (started%s)", byline(step.StartedBy))
+			} else if step.Status == progress.StepSkipped && step.SkipCode != "" {
+				fmt.Fprintf(w, " (skipped: %s%s)", step.SkipCode, byline(step.CompletedBy))
+			}
+			if timing := stepTiming(step, estimates[id], now); timing != "" {
+				fmt.Fprintf(w, " [%s]", timing)
+			}
+			if step.Notes != "" {
+				fmt.Fprintf(w, "\n      Note%s: %s", byline(step.NotedBy), step.Notes)
+			}
+			for _, a := range artifacts[id] {
+				fmt.Fprintf(w, "\n      Artifact: %s", a.Path)
+			}
+			fmt.Fprintln(w)
+		}
+		return nil
+	}
+	return doc, nil
+}
+
+// byline returns " by <who>", or "" if who is unknown.
+func byline(who string) string {
+	if who == "" {
+		return ""
+	}
+	return " by " + who
+}
+
+// stepTiming describes the time spent on a step against its estimate, e.g.
+// "took 2h30m, +30m over 2h estimate" or "est. 45m".
+func stepTiming(step *progress.StepRecord, estimate time.Duration, now time.Time) string {
+	elapsed := step.Elapsed(now)
+	switch {
+	case elapsed == 0 && estimate == 0:
+		return ""
+	case elapsed == 0:
+		return "est. " + formatDuration(estimate)
+	}
+
+	verb := "took"
+	if step.Status == progress.StepInProgress {
+		verb = "running"
+	}
+	if estimate == 0 {
+		return fmt.Sprintf("%s %s", verb, formatDuration(elapsed))
+	}
+	diff := elapsed - estimate
+	switch {
+	case diff > 0:
+		return fmt.Sprintf("%s %s, +%s over %s estimate", verb, formatDuration(elapsed), formatDuration(diff), formatDuration(estimate))
+	case diff < 0 && step.Status != progress.StepInProgress:
+		return fmt.Sprintf("%s %s, -%s under %s estimate", verb, formatDuration(elapsed), formatDuration(-diff), formatDuration(estimate))
+	default:
+		return fmt.Sprintf("%s %s of %s estimate", verb, formatDuration(elapsed), formatDuration(estimate))
+	}
+}
+
+// formatDuration prints d rounded to minutes, e.g. "1h30m" or "45m".
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Minute {
+		return "<1m"
+	}
+	s := strings.TrimSuffix(d.String(), "0s")
+	if h, ok := strings.CutSuffix(s, "h0m"); ok {
+		return h + "h"
+	}
+	return s
+}
+
+func statusSymbol(s progress.StepStatus) string {
 	switch s {
 	case progress.StepCompleted:
 		return "[x]"
@@ -490,78 +1522,312 @@ var stepRequirementPaths = map[string][]string{
 
 // checkStepGate verifies that gating requirements are met before completing a step.
 func checkStepGate(projectDir, stepID string) error {
-	patterns, ok := stepRequirementPaths[stepID]
-	if !ok {
-		return nil // No gate for this step
+	pending, err := gatePending(projectDir, stepID)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("requirements not done:\n  %s\nHint: Mark them as done with 'rinku req done <path>'", strings.Join(pending, "\n  "))
+	}
+	return nil
+}
+
+// gatePending returns the requirements matching a step's gate patterns that are not done,
+// except those whose pending-requirement finding is suppressed.
+func gatePending(projectDir, stepID string) ([]string, error) {
+	s, err := suppress.Load(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	strip, err := namespaceStrip(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var pending []string
+	for _, pattern := range stepRequirementPaths[stepID] {
+		_, notDone, err := verify.GetRequirementStatus(projectDir, pattern, strip)
+		if err != nil {
+			return nil, fmt.Errorf("checking requirements: %w", err)
+		}
+		for _, path := range notDone {
+			if _, ok := s.Lookup("pending-requirement", path, now); !ok {
+				pending = append(pending, path)
+			}
+		}
 	}
+	return pending, nil
+}
 
-	for _, pattern := range patterns {
-		allDone, pending, err := verify.GetRequirementStatus(projectDir, pattern)
+func (c *ScanCmd) Run(r *rinku.Rinku) error {
+	// Dependencies repeat across manifests and aliases normalize to the same key
+	r = r.Memoize()
+	if c.Verbose {
+		defer func() { printCacheStats(os.Stderr, r.CacheStats()) }()
+	}
+	if c.Path == "" {
+		if c.SourceLang != "go" {
+			return fmt.Errorf("--source-lang %s needs the path of the manifest", c.SourceLang)
+		}
+		c.Path = "go.mod"
+	}
+	if err := checkManifestLang(c.Path, c.SourceLang); err != nil {
+		return err
+	}
+	targets, err := c.targets()
+	if err != nil {
+		return err
+	}
+	t := targets[0]
+	if err := c.checkFilters(r, targets); err != nil {
+		return err
+	}
+	if c.SourceLang != "go" {
+		if c.Source {
+			return fmt.Errorf("--source only applies to Go projects")
+		}
+		if c.Transitive {
+			return fmt.Errorf("--transitive only applies to Go projects")
+		}
+		if c.Save || c.History {
+			return fmt.Errorf("--save and --history only apply to Go projects")
+		}
+		if c.Imports != "" {
+			return fmt.Errorf("--imports only applies to Go projects")
+		}
+	}
+	if len(targets) > 1 {
+		if c.Imports != "" || c.Save || c.History {
+			return fmt.Errorf("--imports, --save and --history take one target language")
+		}
+		return c.runTargets(r, targets)
+	}
+	if c.SourceLang != "go" {
+		return c.runManifest(r, t)
+	}
+	if c.Imports != "" {
+		return c.runImports(r, t)
+	}
+	if c.filtered() && (c.Save || c.History) {
+		return fmt.Errorf("--save and --history cover every dependency; drop --category and --min-confidence")
+	}
+	if c.History {
+		entries, err := scanHistory(c.Path)
 		if err != nil {
-			return fmt.Errorf("checking requirements: %w", err)
+			return err
 		}
+		return render.Render(os.Stdout, c.Format, scanHistoryDocument(c.Path, entries))
+	}
 
-		if !allDone && len(pending) > 0 {
-			return fmt.Errorf("requirements not done:\n  %s\nHint: Mark them as done with 'rinku req done <path>'", strings.Join(pending, "\n  "))
+	doc, data, mappings, err := c.goModDocument(r, t)
+	if err != nil {
+		return err
+	}
+	if err := applySuppressions(doc); err != nil {
+		return err
+	}
+	if err := render.Render(os.Stdout, c.Format, doc); err != nil {
+		return err
+	}
+	if c.Save {
+		s, err := saveScan(c.Path, data, t, mappings)
+		if err != nil {
+			return err
 		}
+		fmt.Fprintf(os.Stderr, "Saved %s\n", s.Path)
+	}
+	if c.Profile {
+		printProfile(os.Stderr, r.Profile(200*time.Millisecond))
 	}
 	return nil
 }
 
-func (c *ScanCmd) Run(r *rinku.Rinku) error {
+// targets returns the scan targets of --lang, rust if none is given.
+func (c *ScanCmd) targets() ([]scanTarget, error) {
+	langs := c.Lang
+	if len(langs) == 0 {
+		langs = []string{"rust"}
+	}
+	var targets []scanTarget
+	for _, lang := range langs {
+		if slices.ContainsFunc(targets, func(t scanTarget) bool { return t.name == lang }) {
+			continue
+		}
+		t, err := lookupScanTarget(lang)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// goModDocument maps the dependencies of go.mod to the language of t, with the indirect
+// modules of --transitive and the testing stack.
+func (c *ScanCmd) goModDocument(r *rinku.Rinku, t scanTarget) (*render.Document, *scan.Result, []scan.Mapping, error) {
+	if c.Modules != "" && !c.Transitive {
+		return nil, nil, nil, fmt.Errorf("--modules is the module graph of --transitive")
+	}
+
 	result, err := gomod.Parse(c.Path)
 	if err != nil {
-		return fmt.Errorf("failed to parse go.mod: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to parse go.mod: %w", err)
 	}
 
-	fmt.Printf("Module: %s\n", result.Module)
-	fmt.Printf("Go version: %s\n", result.GoVersion)
-
 	deps := result.DirectDependencies()
-	fmt.Printf("Direct dependencies: %d\n\n", len(deps))
-
-	mapped := 0
+	mappings := make([]scan.Mapping, 0, len(deps))
 	for _, dep := range deps {
-		ghURL := cargo.ModulePathToGitHubURL(dep.Path)
-		rustURLs := r.Lookup(ghURL, "rust", c.Unsafe)
-
-		fmt.Printf("%s\n", dep.Path)
-		if len(rustURLs) > 0 {
-			mapped++
-			for _, rustURL := range rustURLs {
-				crateName := r.CrateName(rustURL)
-				if crateName == "" {
-					crateName = cargo.ExtractCrateName(rustURL)
+		mappings = append(mappings, scan.Map(r, t.backend, dep.Path, cargo.ModulePathToGitHubURL(dep.Path), c.Unsafe))
+	}
+	data := &scan.Result{Module: result.Module, GoVersion: result.GoVersion}
+	mappings = c.filter(mappings, data)
+	doc := scanDocument(result.Module, c.Path, c.filterFields([]render.Field{
+		{Name: "Module", Value: result.Module},
+		{Name: "Go version", Value: result.GoVersion},
+	}, data), data, t, mappings)
+	if c.Transitive {
+		indirect, err := c.indirectModules(result.Module, deps)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		transitive := make([]scan.Mapping, 0, len(indirect))
+		for _, dep := range indirect {
+			transitive = append(transitive, scan.Map(r, t.backend, dep.Path, cargo.ModulePathToGitHubURL(dep.Path), c.Unsafe))
+		}
+		transitive = c.filter(transitive, nil)
+		addTransitive(doc, data, c.Path, t, transitive)
+	}
+
+	frameworks, err := detectTestFrameworks(c.Path, deps, c.Source)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(frameworks) > 0 {
+		names := scan.TestingStack(frameworks)
+		data.TestingStack = names
+		doc.Fields = append(doc.Fields, render.Field{Name: "Testing stack", Value: strings.Join(names, ", ")})
+		text := doc.Text
+		doc.Text = func(w io.Writer) error {
+			if err := text(w); err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "\nTesting stack:\n")
+			for _, fw := range frameworks {
+				if t.name != "rust" {
+					fmt.Fprintf(w, "  %s\n", fw.Name)
+					continue
 				}
-				fmt.Printf("  -> %s (%s)\n", crateName, rustURL)
+				fmt.Fprintf(w, "  %s\n    -> %s\n", fw.Name, fw.Rust)
 			}
-		} else {
-			fmt.Printf("  -> (no mapping found)\n")
+			return nil
 		}
 	}
+	return doc, data, mappings, nil
+}
 
-	fmt.Printf("\nMapped %d/%d direct dependencies\n", mapped, len(deps))
-	return nil
+// indirectModules returns the modules of the build that are neither the main module nor
+// a direct dependency, from --modules or the go.sum next to go.mod.
+func (c *ScanCmd) indirectModules(module string, direct []gomod.Dependency) ([]gomod.Dependency, error) {
+	var all []gomod.Dependency
+	var err error
+	if c.Modules != "" {
+		if all, err = gomod.ParseModList(c.Modules); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", filepath.Base(c.Modules), err)
+		}
+	} else {
+		sumPath := filepath.Join(filepath.Dir(c.Path), "go.sum")
+		if all, err = gomod.ParseSum(sumPath); err != nil {
+			if os.IsNotExist(err) {
+				return nil, fmt.Errorf("--transitive needs the go.sum next to go.mod\nHint: Run go mod tidy, or pass the output of go list -m all with --modules")
+			}
+			return nil, fmt.Errorf("parsing go.sum: %w", err)
+		}
+	}
+	skip := map[string]bool{module: true}
+	for _, dep := range direct {
+		skip[dep.Path] = true
+	}
+	return slices.DeleteFunc(all, func(dep gomod.Dependency) bool { return skip[dep.Path] }), nil
 }
 
-func (c *AnalyzeCmd) Run(r *rinku.Rinku) error {
-	result, err := gomod.Parse(c.Path)
-	if err != nil {
-		return fmt.Errorf("failed to parse go.mod: %w", err)
+func printProfile(w io.Writer, p *rinku.Profile) {
+	_, _ = fmt.Fprintf(w, "\nIndex profile:\n")
+	for _, idx := range p.Indexes {
+		_, _ = fmt.Fprintf(w, "  %-16s %6d keys %6d targets %10s\n", idx.Name, idx.Keys, idx.Targets, formatBytes(idx.Bytes))
 	}
+	_, _ = fmt.Fprintf(w, "  %-16s %30s\n", "total", formatBytes(p.Bytes()))
+	for _, l := range p.Lookups {
+		_, _ = fmt.Fprintf(w, "  %-16s %.1fM lookups/s (%.0f ns/op, %.1f allocs/op)\n", l.Name, l.PerSecond()/1e6, l.NsPerOp(), l.AllocsPerCall)
+	}
+}
 
-	deps := result.DirectDependencies()
-	tagSet := make(map[string]struct{})
+// printCacheStats writes the hits of a memoized Rinku, e.g.
+// "Lookup cache: 12 lookups, 4 hits (33%), 8 misses".
+func printCacheStats(w io.Writer, s rinku.CacheStats) {
+	_, _ = fmt.Fprintf(w, "Lookup cache: %d lookups, %d hits (%.0f%%), %d misses\n", s.Lookups(), s.Hits, 100*s.HitRate(), s.Misses)
+}
 
+func formatBytes(n uint64) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	return fmt.Sprintf("%.1f KiB", float64(n)/1024)
+}
+
+// detectTestFrameworks finds Go testing libraries among the go.mod dependencies and,
+// if source is set, among the imports of _test.go files in the module directory.
+func detectTestFrameworks(goModPath string, deps []gomod.Dependency, source bool) ([]testkit.Framework, error) {
+	var paths []string
 	for _, dep := range deps {
-		ghURL := cargo.ModulePathToGitHubURL(dep.Path)
-		for _, tag := range r.Tags(ghURL) {
+		paths = append(paths, dep.Path)
+	}
+	if source {
+		scan, err := gosrc.ScanImports(filepath.Dir(goModPath))
+		if err != nil {
+			return nil, fmt.Errorf("scanning source files: %w", err)
+		}
+		paths = append(paths, scan.TestImports()...)
+	}
+	return testkit.Detect(paths), nil
+}
+
+func (c *AnalyzeCmd) Run(r *rinku.Rinku) error {
+	if err := checkManifestLang(c.Path, c.SourceLang); err != nil {
+		return err
+	}
+	var urls []string
+	if c.SourceLang == "go" {
+		result, err := gomod.Parse(c.Path)
+		if err != nil {
+			return fmt.Errorf("failed to parse go.mod: %w", err)
+		}
+		for _, dep := range result.DirectDependencies() {
+			urls = append(urls, cargo.ModulePathToGitHubURL(dep.Path))
+		}
+	} else {
+		result, err := manifest.Parse(c.Path)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", filepath.Base(c.Path), err)
+		}
+		for _, dep := range result.Dependencies {
+			urls = append(urls, r.PackageURL(result.Lang, dep.Name))
+		}
+	}
+
+	tagSet := make(map[string]struct{})
+	for _, u := range urls {
+		for _, tag := range r.Tags(u) {
 			tagSet[tag] = struct{}{}
 		}
 	}
 
-	// Output unique tags, one per line
+	// Output unique tags, one per line, sorted
+	tags := make([]string, 0, len(tagSet))
 	for tag := range tagSet {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
 		fmt.Println(tag)
 	}
 
@@ -569,13 +1835,63 @@ func (c *AnalyzeCmd) Run(r *rinku.Rinku) error {
 }
 
 func (c *ConvertCmd) Run(r *rinku.Rinku) (err error) {
-	result, err := gomod.Parse(c.Path)
-	if err != nil {
-		return fmt.Errorf("failed to parse go.mod: %w", err)
+	r = r.Memoize()
+	if c.Verbose {
+		defer func() { printCacheStats(os.Stderr, r.CacheStats()) }()
+	}
+	if _, ok := render.Lookup(c.Format); !ok {
+		return fmt.Errorf("unknown output format %q (available: %s)", c.Format, strings.Join(render.Formats(), ", "))
+	}
+	var doc *render.Document
+	var summary string
+	manifestFile := "Cargo.toml"
+	if c.Pin && (c.To == "rust" || c.To == "go") {
+		return errors.New("--pin applies to --to python and ts; pin Rust crates with rinku lock")
+	}
+	if c.To == "go" {
+		if c.Source {
+			return errors.New("--source applies to go.mod files, not --to go")
+		}
+		if c.Features {
+			return errors.New("--features applies to go.mod files, not --to go")
+		}
+		var result *cargo.ReverseResult
+		doc, result, err = convertToGo(r, c.Path, c.Module, c.Unsafe)
+		if err != nil {
+			return err
+		}
+		summary = fmt.Sprintf("Generated %s with %d crates (%d mapped, %d to the standard library, %d unmapped)",
+			c.Output, len(result.Mapped)+len(result.Stdlib)+len(result.Unmapped), len(result.Mapped), len(result.Stdlib), len(result.Unmapped))
+		manifestFile = "go.mod"
+	} else if c.To != "rust" {
+		// Rust keeps the path below: the mapping lock and dev-dependencies are Cargo's
+		b, ok := target.Lookup(c.To)
+		if !ok {
+			return fmt.Errorf("unknown target %q (available: %s)", c.To, strings.Join(append(target.Names(), "go"), ", "))
+		}
+		if c.Source {
+			return errors.New("--source applies to --to rust only")
+		}
+		if c.Features {
+			return errors.New("--features applies to --to rust only")
+		}
+		var m *target.Mapping
+		manifestFile = target.ManifestFor(b, c.Output)
+		doc, m, err = convertToTarget(r, b, manifestFile, c.Path, c.Unsafe, c.Pin)
+		if err != nil {
+			return err
+		}
+		summary = fmt.Sprintf("Generated %s with %d dependencies (%d mapped, %d unmapped)",
+			c.Output, len(m.Mapped)+len(m.Unmapped), len(m.Mapped), len(m.Unmapped))
+	} else {
+		module, genResult, err := mapForConvert(r, c.Path, c.Unsafe, c.Source, c.Features, c.NoLock)
+		if err != nil {
+			return err
+		}
+		doc = convertDocument(module, c.Path, genResult)
+		summary = fmt.Sprintf("Generated %s with %d dependencies (%d mapped, %d unmapped)",
+			c.Output, len(genResult.Mapped)+len(genResult.Unmapped), len(genResult.Mapped), len(genResult.Unmapped))
 	}
-
-	deps := result.DirectDependencies()
-	genResult := cargo.MapDependencies(deps, r, c.Unsafe)
 
 	var w *os.File
 	if c.Output == "-" {
@@ -595,17 +1911,152 @@ func (c *ConvertCmd) Run(r *rinku.Rinku) (err error) {
 		}()
 	}
 
-	if err := cargo.GenerateCargoToml(w, result.Module, genResult); err != nil {
-		return fmt.Errorf("failed to generate Cargo.toml: %w", err)
+	if err := render.Render(w, c.Format, doc); err != nil {
+		return fmt.Errorf("failed to generate %s: %w", manifestFile, err)
 	}
 
 	if c.Output != "-" {
-		fmt.Fprintf(os.Stderr, "Generated %s with %d dependencies (%d mapped, %d unmapped)\n",
-			c.Output, len(deps), len(genResult.Mapped), len(genResult.Unmapped))
+		fmt.Fprintln(os.Stderr, summary)
 	}
 	return nil
 }
 
+// convertDocument returns the dependency mapping of convert, written as Cargo.toml in
+// the text format.
+func convertDocument(module, goModPath string, genResult *cargo.GenerateResult) *render.Document {
+	doc := &render.Document{
+		Command: "convert",
+		Title:   module,
+		Fields: []render.Field{
+			{Name: "Module", Value: module},
+			{Name: "Mapped", Value: strconv.Itoa(len(genResult.Mapped))},
+			{Name: "Unmapped", Value: strconv.Itoa(len(genResult.Unmapped))},
+		},
+		Columns: []string{"module", "version", "crate", "requirement", "url"},
+		Text: func(w io.Writer) error {
+			return cargo.GenerateCargoToml(w, module, genResult)
+		},
+	}
+	for _, m := range genResult.Mapped {
+		for i, rustURL := range m.RustTargets {
+			req := "*"
+			if i < len(m.Versions) && m.Versions[i] != "" {
+				req = m.Versions[i]
+			}
+			doc.Rows = append(doc.Rows, []string{m.GoDep.Path, m.GoDep.Version, m.CrateNames[i], req, rustURL})
+		}
+	}
+	for _, u := range genResult.Unmapped {
+		doc.Rows = append(doc.Rows, []string{u.GoDep.Path, u.GoDep.Version, "", "", ""})
+		doc.Findings = append(doc.Findings, render.Finding{
+			Rule:    "unmapped-dependency",
+			Level:   render.LevelWarning,
+			Message: fmt.Sprintf("no Rust equivalent found for %s; left as a comment in Cargo.toml", u.GoDep.Path),
+			File:    relPath(goModPath),
+		})
+	}
+	return doc
+}
+
+// mapForConvert maps the go.mod dependencies for Cargo.toml generation, applying the
+// mapping lock unless noLock is set, adding dev-dependencies if source is set and
+// deriving crate features from the Go source if analyze is set.
+func mapForConvert(r *rinku.Rinku, goModPath string, unsafe, source, analyze, noLock bool) (string, *cargo.GenerateResult, error) {
+	result, err := gomod.Parse(goModPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+
+	deps := result.DirectDependencies()
+	genResult := cargo.MapDependencies(deps, r, unsafe)
+	var l *lock.Lock
+	if !noLock {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", nil, fmt.Errorf("getting current directory: %w", err)
+		}
+		if l, err = lock.Load(cwd); err != nil {
+			return "", nil, err
+		}
+		if l != nil {
+			if n := l.Apply(genResult); n > 0 {
+				fmt.Fprintf(os.Stderr, "Using %d locked mappings from %s\n", n, lock.Path("."))
+			}
+		}
+	}
+	if n := markProjectMappings(r, genResult, l); n > 0 {
+		fmt.Fprintf(os.Stderr, "Using %d project mappings from %s\n", n, projectmap.Path("."))
+	}
+	if source {
+		frameworks, err := detectTestFrameworks(goModPath, deps, true)
+		if err != nil {
+			return "", nil, err
+		}
+		genResult.DevDependencies = testkit.DevDependencies(frameworks)
+	}
+	if analyze {
+		usage, err := features.Scan(filepath.Dir(goModPath))
+		if err != nil {
+			return "", nil, fmt.Errorf("scanning source files: %w", err)
+		}
+		genResult.Features = crateFeatures(usage, genResult)
+	}
+	return result.Module, genResult, nil
+}
+
+// markProjectMappings sets the origin of the mapped dependencies answered by
+// .rinku/mappings.json, unless the lock replaced their mapping, and returns their
+// number.
+func markProjectMappings(r *rinku.Rinku, genResult *cargo.GenerateResult, l *lock.Lock) int {
+	n := 0
+	for i, m := range genResult.Mapped {
+		if l != nil {
+			if _, locked := l.Get(m.GoDep.Path); locked {
+				continue
+			}
+		}
+		if r.Overlaid(cargo.ModulePathToGitHubURL(m.GoDep.Path), "rust") {
+			genResult.Mapped[i].Origin = projectmap.Path(".")
+			n++
+		}
+	}
+	return n
+}
+
+// crateFeatures returns the features usage calls for of the mapped and required crates
+// that have feature rules.
+func crateFeatures(usage *features.Usage, genResult *cargo.GenerateResult) map[string][]string {
+	result := make(map[string][]string)
+	add := func(crate string) {
+		if f, ok := usage.Features(crate); ok {
+			result[crate] = f
+		}
+	}
+	for _, m := range genResult.Mapped {
+		for _, name := range m.CrateNames {
+			add(name)
+		}
+		for _, dep := range m.RequiredDeps {
+			add(dep.Crate)
+		}
+	}
+	return result
+}
+
+// relPath returns path relative to the working directory if it is below it, as
+// findings are reported; kong resolves existingfile arguments to absolute paths.
+func relPath(path string) string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(cwd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return rel
+}
+
 func validateOutputPath(path string) error {
 	if filepath.IsAbs(path) {
 		return fmt.Errorf("absolute paths not allowed: %s", path)
@@ -630,6 +2081,110 @@ func shouldShowHelp(args []string) bool {
 	return false
 }
 
+// needsInit reports whether the current directory is a Go project rinku init has not
+// set up yet.
+func needsInit() bool {
+	if _, err := os.Stat("go.mod"); err != nil {
+		return false
+	}
+	_, err := os.Stat(config.FileName)
+	return errors.Is(err, os.ErrNotExist)
+}
+
+// newRinku builds the lookup index from the generated database and the project mapping
+// file in the working directory. Kong calls it only for
+// commands whose Run takes a *rinku.Rinku, so migrate, req and the other state commands
+// start without constructing the maps.
+// mappingIndex returns the mapping index of newRinku, built on the first call. Commands
+// that only look up mappings on some paths take it instead of a *rinku.Rinku, so the
+// others neither build the index nor load .rinku/mappings.json.
+type mappingIndex func() (*rinku.Rinku, error)
+
+func newRinku() (*rinku.Rinku, error) {
+	opts := []rinku.Option{rinku.WithIndex(databaseIndex())}
+	// .rinku/mappings.json of the project overrides the database
+	if cwd, err := os.Getwd(); err == nil {
+		m, err := projectmap.Load(cwd)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil {
+			opts = append(opts, rinku.WithOverlay(m.Index()))
+		}
+	}
+	return rinku.New(opts...), nil
+}
+
+// databaseVersion identifies the mapping database newRinku uses: the one downloaded by
+// rinku update, if it is at least as new as the compiled-in one, or else the
+// compiled-in one.
+var databaseVersion = dbVersion
+
+// builtInReleased returns the released time of the compiled-in database, zero if its
+// mappings.json records none.
+func builtInReleased() time.Time {
+	released, _ := time.Parse(time.RFC3339, dbReleased)
+	return released
+}
+
+// databaseIndex returns the database downloaded by rinku update, or the compiled-in
+// one if there is none, it cannot be read or the binary's is newer.
+func databaseIndex() rinku.Index {
+	if dir, err := database.Dir(); err == nil {
+		db, err := database.Load(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v; using the built-in database (rinku update downloads it again)\n", err)
+		}
+		if db != nil && db.Newer(builtInReleased()) {
+			databaseVersion = db.Version
+			return db.Indexes.Index()
+		}
+	}
+	databaseVersion = dbVersion
+	idx := loadIndex()
+	return rinku.Index{
+		Safe:         idx.index,
+		All:          idx.indexAll,
+		ReverseSafe:  idx.reverseIndex,
+		ReverseAll:   idx.reverseIndexAll,
+		CrateNames:   idx.knownCrateNames,
+		Tags:         idx.tags,
+		RequiredDeps: convertRequiredDeps(idx.requiredDeps),
+		Categories:   idx.categories,
+		Confidences:  idx.confidences,
+		Packages:     idx.packages,
+	}
+}
+
+// configureHTTP applies the [http] table of .rinku.toml in the working directory to the
+// client shared by the crates.io, GitHub, Go proxy and OSV clients.
+func configureHTTP() {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	cfg, err := config.Load(cwd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	httpclient.SetDefault(cfg.HTTP)
+}
+
+// configureAPIVersion selects the version of the json and yaml output given with
+// --api-version, and warns if it is deprecated.
+func configureAPIVersion(s string) error {
+	v, err := render.ParseAPIVersion(s)
+	if err != nil {
+		return fmt.Errorf("--api-version: %w", err)
+	}
+	render.APIVersion = v
+	if notice := render.Deprecation(v); notice != "" {
+		fmt.Fprintf(os.Stderr, "Warning: API version %d is deprecated: %s\n", v, notice)
+	}
+	return nil
+}
+
 func convertRequiredDeps(m map[string][]requiredDep) map[string][]types.RequiredDep {
 	result := make(map[string][]types.RequiredDep, len(m))
 	for k, deps := range m {
@@ -649,19 +2204,35 @@ func convertRequiredDeps(m map[string][]requiredDep) map[string][]types.Required
 func main() {
 	if shouldShowHelp(os.Args) {
 		fmt.Println(description)
+		if len(os.Args) == 1 && needsInit() {
+			fmt.Println("\nThis directory has a go.mod but no .rinku.toml: run rinku init to get started.")
+		}
 		os.Exit(0)
 	}
 
-	r := rinku.New(index, indexAll, reverseIndex, reverseIndexAll, knownCrateNames, tags, convertRequiredDeps(requiredDeps))
-
+	rec := openTelemetry()
+	index := mappingIndex(sync.OnceValues(func() (*rinku.Rinku, error) {
+		r, err := newRinku()
+		if err != nil {
+			return nil, err
+		}
+		return observeTelemetry(r, rec), nil
+	}))
 	ctx := kong.Parse(&CLI,
 		kong.Name("rinku"),
 		kong.Description("Find equivalent Rust libraries for Go dependencies."),
 		kong.UsageOnError(),
-		kong.Bind(r),
+		kong.Bind(index),
+		kong.BindSingletonProvider(func() (*rinku.Rinku, error) { return index() }),
 	)
 
-	err := ctx.Run(r)
+	rec.Command(ctx.Command())
+	configureHTTP()
+	err := configureAPIVersion(CLI.APIVersion)
+	if err == nil {
+		err = ctx.Run()
+	}
+	closeTelemetry(rec)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
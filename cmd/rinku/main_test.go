@@ -1,6 +1,22 @@
 package main
 
-import "testing"
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/github"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/lock"
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/scan"
+	"github.com/stephan/rinku/internal/server"
+)
 
 func TestIsValidURL(t *testing.T) {
 	tests := []struct {
@@ -46,3 +62,122 @@ func TestShouldShowHelp(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatMetadata(t *testing.T) {
+	metadata := map[string]github.Metadata{
+		"acme/api": {Stars: 42, License: "MIT", Archived: true},
+		"acme/cli": {Stars: 7},
+	}
+	tests := map[string]string{
+		"acme/api":   "     42 MIT          archived",
+		"acme/cli":   "      7 -",
+		"./checkout": "      - -",
+	}
+	for repo, want := range tests {
+		if got := formatMetadata(metadata, repo); got != want {
+			t.Errorf("formatMetadata(%q) = %q, want %q", repo, got, want)
+		}
+	}
+}
+
+func TestAnnotateDecision(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "go.mod")
+	content := "module example.com/app\n\ngo 1.22\n\nrequire github.com/lib/pq v1.10.9 // indirect\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range []lock.Entry{
+		{GoModule: "github.com/lib/pq", Decision: lock.DecisionDeferred},
+		{GoModule: "github.com/lib/pq", Decision: lock.DecisionAccepted, Crate: "tokio-postgres"},
+		{GoModule: "github.com/lib/pq", Decision: lock.DecisionRejected, Reason: "blocking; sqlx instead"},
+	} {
+		if err := annotateDecision(path, e); err != nil {
+			t.Fatalf("annotateDecision(%s): %v", e.Decision, err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the latest decision replaces the earlier ones
+	if want := "require github.com/lib/pq v1.10.9 // indirect; rinku:reject blocking, sqlx instead\n"; !strings.Contains(string(data), want) {
+		t.Errorf("go.mod =\n%s", data)
+	}
+}
+
+func TestPendingDecisions(t *testing.T) {
+	r := rinku.New(rinku.WithIndex(rinku.Index{Confidences: map[string]float64{
+		"rust:github.com/spf13/cobra":     0.9,
+		"rust:github.com/sirupsen/logrus": 0.6,
+		"rust:github.com/lib/pq":          0.6,
+	}}))
+	mapped := func(path string, crates ...string) cargo.MappedDependency {
+		return cargo.MappedDependency{GoDep: gomod.Dependency{Path: path}, CrateNames: crates, RustTargets: crates}
+	}
+	overlay := mapped("github.com/sirupsen/logrus", "tracing")
+	overlay.Origin = ".rinku/mappings.json"
+	l := lock.New()
+	l.Set(lock.Entry{GoModule: "github.com/lib/pq", Decision: lock.DecisionAccepted, Crate: "tokio-postgres"})
+
+	tests := []struct {
+		name    string
+		dep     cargo.MappedDependency
+		pending bool
+	}{
+		{"several targets", mapped("github.com/gorilla/mux", "axum", "actix-web"), true},
+		{"one confident target", mapped("github.com/spf13/cobra", "clap"), false},
+		{"one low-confidence target", mapped("github.com/sirupsen/logrus", "tracing"), true},
+		{"no recorded confidence", mapped("github.com/google/uuid", "uuid"), false},
+		{"project mapping", overlay, false},
+		{"decided", mapped("github.com/lib/pq", "tokio-postgres"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pending, _ := pendingDecisions(&cargo.GenerateResult{Mapped: []cargo.MappedDependency{tt.dep}}, l, r, 0.8)
+			if got := len(pending) == 1; got != tt.pending {
+				t.Errorf("pending = %v, want %v", got, tt.pending)
+			}
+		})
+	}
+}
+
+func TestScanMatchesServe(t *testing.T) {
+	const mod = "module acme/api\n\ngo 1.22\n\nrequire (\n\tgithub.com/sirupsen/logrus v1.9.0\n\tgithub.com/spf13/cobra v1.8.0\n\tgithub.com/stretchr/testify v1.9.0\n\tgithub.com/rs/zerolog v1.32.0\n\texample.com/unknown v0.1.0\n)\n"
+	path := filepath.Join(t.TempDir(), "go.mod")
+	if err := os.WriteFile(path, []byte(mod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r := rinku.New(rinku.WithIndex(databaseIndex()))
+	target, err := lookupScanTarget("rust")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, data, _, err := (&ScanCmd{Path: path}).goModDocument(r, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cli, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	(&server.Handler{Lookup: r}).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/scan", strings.NewReader(mod)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST /scan: status = %d: %s", rec.Code, rec.Body)
+	}
+	var served scan.Result
+	if err := json.Unmarshal(rec.Body.Bytes(), &served); err != nil {
+		t.Fatal(err)
+	}
+	srv, err := json.Marshal(served)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(cli) != string(srv) {
+		t.Errorf("scan --format json and POST /scan differ:\nscan:  %s\nserve: %s", cli, srv)
+	}
+	if len(data.Consolidations) == 0 || len(data.TestingStack) == 0 {
+		t.Errorf("go.mod exercises neither consolidations nor the testing stack: %s", cli)
+	}
+}
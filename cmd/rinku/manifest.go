@@ -0,0 +1,299 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/stephan/rinku/internal/manifest"
+	"github.com/stephan/rinku/internal/projectmap"
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/scan"
+	"github.com/stephan/rinku/internal/target"
+	"github.com/stephan/rinku/render"
+)
+
+// checkManifestLang reports a manifest that does not belong to the source language,
+// such as a package.json scanned as go.mod.
+func checkManifestLang(path, lang string) error {
+	got := manifest.Lang(path)
+	switch {
+	case lang == "go" && got != "":
+		return fmt.Errorf("%s is not a go.mod\nHint: Use --source-lang %s", filepath.Base(path), got)
+	case lang != "go" && got != lang:
+		want := "requirements.txt"
+		if lang == manifest.JS {
+			want = "package.json"
+		}
+		return fmt.Errorf("--source-lang %s expects a %s, got %s", lang, want, filepath.Base(path))
+	}
+	return nil
+}
+
+// runManifest scans a requirements.txt or package.json. Package names are resolved to
+// library URLs through the database, then mapped like Go modules.
+func (c *ScanCmd) runManifest(r *rinku.Rinku, t scanTarget) error {
+	doc, err := c.manifestDocument(r, t)
+	if err != nil {
+		return err
+	}
+	if err := applySuppressions(doc); err != nil {
+		return err
+	}
+	return render.Render(os.Stdout, c.Format, doc)
+}
+
+// manifestDocument maps the dependencies of a requirements.txt or package.json to the
+// language of t.
+func (c *ScanCmd) manifestDocument(r *rinku.Rinku, t scanTarget) (*render.Document, error) {
+	result, err := manifest.Parse(c.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(c.Path), err)
+	}
+
+	var header []render.Field
+	if result.Name != "" {
+		header = append(header, render.Field{Name: "Package", Value: result.Name})
+	}
+	header = append(header, render.Field{Name: "Source language", Value: result.Lang})
+
+	mappings := make([]scan.Mapping, 0, len(result.Dependencies))
+	for _, dep := range result.Dependencies {
+		name := dep.Name
+		if dep.Dev {
+			name += " (dev)"
+		}
+		m := scan.Map(r, t.backend, name, r.PackageURL(result.Lang, dep.Name), c.Unsafe)
+		m.Dep = dep.Name
+		mappings = append(mappings, m)
+	}
+	data := &scan.Result{Package: result.Name, SourceLang: result.Lang}
+	return scanDocument(result.Name, c.Path, c.filterFields(header, data), data, t, c.filter(mappings, data)), nil
+}
+
+// scanTarget is a target language of scan: rust, or ts and js, which share the npm
+// packages of the database.
+type scanTarget struct {
+	name    string // as given to --lang
+	display string // e.g. Rust or TypeScript
+	backend target.Backend
+}
+
+// lookupScanTarget returns the scan target of a --lang value.
+func lookupScanTarget(lang string) (scanTarget, error) {
+	t := scanTarget{name: lang}
+	switch lang {
+	case "", "rust":
+		t.name, t.display = "rust", "Rust"
+	case "ts":
+		t.display = "TypeScript"
+	case "js":
+		t.display = "JavaScript"
+	default:
+		return t, fmt.Errorf("unknown target language %q", lang)
+	}
+	backendName := t.name
+	if backendName == "js" {
+		backendName = "ts"
+	}
+	b, ok := target.Lookup(backendName)
+	if !ok {
+		return t, fmt.Errorf("no %s target backend", backendName)
+	}
+	t.backend = b
+	return t, nil
+}
+
+// mapRust looks up the Rust equivalents of a dependency. libURL is empty for packages
+// missing from the database.
+func mapRust(r *rinku.Rinku, name, libURL string, unsafe bool) scan.Mapping {
+	b, _ := target.Lookup("rust")
+	return scan.Map(r, b, name, libURL, unsafe)
+}
+
+// printMapping writes the dependency and its equivalents in the text output of scan.
+func printMapping(w io.Writer, m *scan.Mapping) {
+	var notes []string
+	if m.Confidence > 0 {
+		notes = append(notes, "confidence "+formatConfidence(m.Confidence))
+	}
+	if m.Project {
+		notes = append(notes, "project mapping")
+	}
+	project := ""
+	if len(notes) > 0 {
+		project = " (" + strings.Join(notes, ", ") + ")"
+	}
+	if m.Category != "" {
+		fmt.Fprintf(w, "%s [%s]%s\n", m.Name, m.Category, project)
+	} else {
+		fmt.Fprintf(w, "%s%s\n", m.Name, project)
+	}
+	if len(m.URLs) == 0 {
+		fmt.Fprintf(w, "  -> (no mapping found)\n")
+		return
+	}
+	for i, u := range m.URLs {
+		fmt.Fprintf(w, "  -> %s (%s)\n", m.Targets[i], u)
+	}
+}
+
+// scanDocument returns the scan result for the dependencies of the manifest at path:
+// the header fields, a row per equivalent in the target language and a finding per
+// unmapped dependency. data holds the header values for json and yaml and is completed
+// with the mappings.
+func scanDocument(title, path string, header []render.Field, data *scan.Result, t scanTarget, mappings []scan.Mapping) *render.Document {
+	column := "crate"
+	if t.name != "rust" {
+		column = "package"
+		header = append(slices.Clone(header), render.Field{Name: "Target language", Value: t.name})
+	}
+	scan.Fill(data, t.name, mappings)
+	doc := &render.Document{
+		Command: "scan",
+		Title:   title,
+		Columns: []string{"dependency", "category", column, "url", "confidence"},
+		Data:    data,
+	}
+	project := 0
+	for _, m := range mappings {
+		if m.Project {
+			project++
+			doc.Findings = append(doc.Findings, render.Finding{
+				Rule:    "project-mapping",
+				Level:   render.LevelNote,
+				Message: fmt.Sprintf("%s is mapped by %s", m.Name, projectmap.Path(".")),
+				File:    relPath(path),
+				Subject: m.Dep,
+			})
+		}
+		if len(m.URLs) == 0 {
+			doc.Rows = append(doc.Rows, []string{m.Name, "", "", "", ""})
+			doc.Findings = append(doc.Findings, render.Finding{
+				Rule:    "unmapped-dependency",
+				Level:   render.LevelWarning,
+				Message: fmt.Sprintf("no %s equivalent found for %s", t.display, m.Name),
+				File:    relPath(path),
+				Subject: m.Dep,
+			})
+			continue
+		}
+		for i, u := range m.URLs {
+			doc.Rows = append(doc.Rows, []string{m.Name, m.Category, m.Targets[i], u, formatConfidence(m.Confidence)})
+		}
+	}
+	mapped, eliminated := data.Mapped, data.Eliminated
+	// With --category or --min-confidence, the counts are of the filtered dependencies
+	direct := strconv.Itoa(len(mappings))
+	label, scope := "Direct dependencies: "+direct, "direct dependencies"
+	if data.DirectTotal > 0 {
+		direct = fmt.Sprintf("%d of %d (filtered)", len(mappings), data.DirectTotal)
+		label = fmt.Sprintf("Direct dependencies (filtered: %d of %d)", len(mappings), data.DirectTotal)
+		scope = "filtered direct dependencies"
+	}
+	doc.Fields = append(slices.Clone(header),
+		render.Field{Name: "Direct dependencies", Value: direct},
+		render.Field{Name: "Mapped", Value: strconv.Itoa(mapped)},
+	)
+	if project > 0 {
+		doc.Fields = append(doc.Fields, render.Field{Name: "Project mappings", Value: strconv.Itoa(project)})
+	}
+	groups := data.Consolidations
+	for _, g := range groups {
+		doc.Findings = append(doc.Findings, render.Finding{
+			Rule:    "consolidation",
+			Level:   render.LevelNote,
+			Message: fmt.Sprintf("%d dependencies map to %s: %s", len(g.Dependencies), g.Name(), strings.Join(g.Dependencies, ", ")),
+			File:    relPath(path),
+			Subject: g.URL,
+		})
+	}
+	if eliminated > 0 {
+		doc.Fields = append(doc.Fields, render.Field{Name: "Eliminated by consolidation", Value: strconv.Itoa(eliminated)})
+	}
+	doc.Text = func(w io.Writer) error {
+		for _, f := range header {
+			fmt.Fprintf(w, "%s: %s\n", f.Name, f.Value)
+		}
+		fmt.Fprintf(w, "%s\n\n", label)
+		for _, m := range mappings {
+			printMapping(w, &m)
+		}
+		if _, err := fmt.Fprintf(w, "\nMapped %d/%d %s\n", mapped, len(mappings), scope); err != nil {
+			return err
+		}
+		if project > 0 {
+			fmt.Fprintf(w, "Project mappings: %d from %s\n", project, projectmap.Path("."))
+		}
+		if len(groups) == 0 {
+			return nil
+		}
+		fmt.Fprintf(w, "\nConsolidation:\n")
+		for _, g := range groups {
+			fmt.Fprintf(w, "  %s (%s) replaces %d dependencies:\n", g.Name(), g.URL, len(g.Dependencies))
+			for _, dep := range g.Dependencies {
+				fmt.Fprintf(w, "    %s\n", dep)
+			}
+		}
+		_, err := fmt.Fprintf(w, "  %d fewer dependencies in %s\n", eliminated, t.display)
+		return err
+	}
+	return doc
+}
+
+// addTransitive adds the indirect modules of a build to a scan of its direct
+// dependencies: a scope column, a row per equivalent and a note per unmapped module,
+// which needs no decision of its own until the direct dependency pulling it in is
+// ported, and a Transitive dependencies section after the direct ones.
+func addTransitive(doc *render.Document, data *scan.Result, path string, t scanTarget, mappings []scan.Mapping) {
+	doc.Columns = append(doc.Columns, "scope")
+	for i := range doc.Rows {
+		doc.Rows[i] = append(doc.Rows[i], "direct")
+	}
+	for i := range data.Dependencies {
+		data.Dependencies[i].Scope = "direct"
+	}
+	mapped := 0
+	for _, m := range mappings {
+		dep := m.Dependency(t.name)
+		dep.Scope = "transitive"
+		if len(m.URLs) == 0 {
+			data.Dependencies = append(data.Dependencies, dep)
+			doc.Rows = append(doc.Rows, []string{m.Name, "", "", "", "", "transitive"})
+			doc.Findings = append(doc.Findings, render.Finding{
+				Rule:    "unmapped-transitive-dependency",
+				Level:   render.LevelNote,
+				Message: fmt.Sprintf("no %s equivalent found for the indirect module %s", t.display, m.Name),
+				File:    relPath(path),
+				Subject: m.Dep,
+			})
+			continue
+		}
+		data.Dependencies = append(data.Dependencies, dep)
+		mapped++
+		for i, u := range m.URLs {
+			doc.Rows = append(doc.Rows, []string{m.Name, m.Category, m.Targets[i], u, formatConfidence(m.Confidence), "transitive"})
+		}
+	}
+	data.Transitive = &scan.Transitive{Total: len(mappings), Mapped: mapped}
+	doc.Fields = append(doc.Fields,
+		render.Field{Name: "Transitive dependencies", Value: strconv.Itoa(len(mappings))},
+		render.Field{Name: "Transitive mapped", Value: strconv.Itoa(mapped)},
+	)
+	text := doc.Text
+	doc.Text = func(w io.Writer) error {
+		if err := text(w); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\nTransitive dependencies: %d\n\n", len(mappings))
+		for _, m := range mappings {
+			printMapping(w, &m)
+		}
+		_, err := fmt.Fprintf(w, "\nMapped %d/%d transitive dependencies\n", mapped, len(mappings))
+		return err
+	}
+}
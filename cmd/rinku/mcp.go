@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/stephan/rinku/internal/mcp"
+	"github.com/stephan/rinku/internal/rinku"
+)
+
+type McpCmd struct{}
+
+func (c *McpCmd) Run(r *rinku.Rinku) error {
+	// Commands print to os.Stdout, which runCommand redirects while a tool runs.
+	return mcp.NewServer("rinku", databaseVersion, mcpTools(r)).Serve(os.Stdin, os.Stdout)
+}
+
+// mcpTool is a tool that runs a rinku command: args returns its command line.
+func mcpTool(r *rinku.Rinku, name, description string, schema mcp.Schema, args func(mcp.Arguments) []string) mcp.Tool {
+	return mcp.Tool{
+		Name:        name,
+		Description: description,
+		InputSchema: schema,
+		Call:        func(a mcp.Arguments) (string, error) { return runCommand(r, args(a)) },
+	}
+}
+
+// mcpTools are the operations offered by rinku mcp. Commands with structured output run
+// with --format json.
+func mcpTools(r *rinku.Rinku) []mcp.Tool {
+	goMod := mcp.Property{Type: "string", Description: "Path to go.mod, relative to the working directory (default: go.mod)."}
+	step := mcp.Property{Type: "string", Description: "Step ID of the migration workflow."}
+	reqPath := mcp.Property{Type: "string", Description: "Requirement path, e.g. app/cli/flags/--host."}
+	return []mcp.Tool{
+		mcpTool(r, "lookup", "Find the equivalent libraries of a library for a target language.",
+			mcp.Object(map[string]mcp.Property{
+				"url":      {Type: "string", Description: "GitHub URL of the library, e.g. https://github.com/spf13/cobra."},
+				"language": {Type: "string", Description: "Target language (default: rust).", Enum: []string{"rust", "go", "python", "ts", "js"}},
+			}, "url"),
+			func(a mcp.Arguments) []string {
+				return command([]string{"lookup", "--format", "json"}, a.String("url"), withDefault(a.String("language"), "rust"))
+			}),
+		mcpTool(r, "scan", "Map the direct dependencies of a go.mod to Rust crates or npm packages.",
+			mcp.Object(map[string]mcp.Property{
+				"path":       goMod,
+				"lang":       {Type: "string", Description: "Target language (default: rust).", Enum: []string{"rust", "ts", "js"}},
+				"transitive": {Type: "boolean", Description: "Also map the indirect modules of go.sum."},
+				"imports":    {Type: "string", Description: "Map the packages imported by the source files of a pattern such as ./... instead."},
+			}),
+			func(a mcp.Arguments) []string {
+				flags := []string{"scan", "--format", "json"}
+				if lang := a.String("lang"); lang != "" {
+					flags = append(flags, "--lang", lang)
+				}
+				if a.Bool("transitive") {
+					flags = append(flags, "--transitive")
+				}
+				if imports := a.String("imports"); imports != "" {
+					flags = append(flags, "--imports", imports)
+				}
+				return command(flags, withDefault(a.String("path"), "go.mod"))
+			}),
+		mcpTool(r, "convert", "Generate the Cargo.toml for a go.mod, without writing it.",
+			mcp.Object(map[string]mcp.Property{"path": goMod}),
+			func(a mcp.Arguments) []string {
+				return command([]string{"convert"}, withDefault(a.String("path"), "go.mod"))
+			}),
+		mcpTool(r, "verify", "Check the requirement coverage expected from the dependency tags.",
+			mcp.Object(map[string]mcp.Property{
+				"path": goMod,
+				"impl": {Type: "boolean", Description: "Check which requirements are done."},
+			}),
+			func(a mcp.Arguments) []string {
+				flags := []string{"verify", "--format", "json"}
+				if a.Bool("impl") {
+					flags = append(flags, "--impl")
+				}
+				return command(flags, withDefault(a.String("path"), "go.mod"))
+			}),
+		mcpTool(r, "req_set", "Capture a requirement, in the namespace of the current step if one is configured.",
+			mcp.Object(map[string]mcp.Property{
+				"path":    reqPath,
+				"content": {Type: "string", Description: "What the Go code does and the port has to preserve."},
+			}, "path", "content"),
+			func(a mcp.Arguments) []string {
+				return command([]string{"req", "set"}, a.String("path"), a.String("content"))
+			}),
+		mcpTool(r, "req_get", "Read the content of a requirement.",
+			mcp.Object(map[string]mcp.Property{"path": reqPath}, "path"),
+			func(a mcp.Arguments) []string { return command([]string{"req", "get"}, a.String("path")) }),
+		mcpTool(r, "req_list", "List requirements with their done state.",
+			mcp.Object(map[string]mcp.Property{
+				"pattern": {Type: "string", Description: "Filter with * and ? within a segment and ** for any depth."},
+				"step":    {Type: "string", Description: "Only requirements captured during this step."},
+			}),
+			func(a mcp.Arguments) []string {
+				flags := []string{"req", "list"}
+				if s := a.String("step"); s != "" {
+					flags = append(flags, "--step", s)
+				}
+				var args []string
+				if p := a.String("pattern"); p != "" {
+					args = append(args, p)
+				}
+				return command(flags, args...)
+			}),
+		mcpTool(r, "req_done", "Mark a requirement as implemented.",
+			mcp.Object(map[string]mcp.Property{"path": reqPath}, "path"),
+			func(a mcp.Arguments) []string { return command([]string{"req", "done"}, a.String("path")) }),
+		mcpTool(r, "migrate_status", "Show the progress of the migration workflow.",
+			mcp.Object(nil),
+			func(mcp.Arguments) []string { return []string{"migrate", "--status", "--format", "json"} }),
+		mcpTool(r, "migrate_show", "Show a step with its gate, requirements and checklist, without changing progress.",
+			mcp.Object(map[string]mcp.Property{"step": step}, "step"),
+			func(a mcp.Arguments) []string {
+				return command([]string{"migrate", "show", "--format", "json"}, a.String("step"))
+			}),
+		mcpTool(r, "migrate_start", "Start a step and return its instructions.",
+			mcp.Object(map[string]mcp.Property{"step": step}, "step"),
+			func(a mcp.Arguments) []string { return []string{"migrate", "--start", a.String("step")} }),
+		mcpTool(r, "migrate_finish", "Finish a step once its gate passes.",
+			mcp.Object(map[string]mcp.Property{
+				"step": step,
+				"note": {Type: "string", Description: "Note recorded with the step."},
+			}, "step"),
+			func(a mcp.Arguments) []string {
+				args := []string{"migrate", "--finish", a.String("step")}
+				if note := a.String("note"); note != "" {
+					args = append(args, "--note", note)
+				}
+				return args
+			}),
+		mcpTool(r, "migrate_check", "Check off a checklist item of a step.",
+			mcp.Object(map[string]mcp.Property{
+				"step": step,
+				"item": {Type: "integer", Description: "Number of the checklist item, counted from 1."},
+				"undo": {Type: "boolean", Description: "Uncheck the item."},
+			}, "step", "item"),
+			func(a mcp.Arguments) []string {
+				flags := []string{"migrate", "check"}
+				if a.Bool("undo") {
+					flags = append(flags, "--undo")
+				}
+				return command(flags, a.String("step"), strconv.Itoa(a.Int("item")))
+			}),
+	}
+}
+
+// command returns a command line of flags followed by positional arguments, which may
+// start with a dash.
+func command(flags []string, args ...string) []string {
+	return append(append(flags, "--"), args...)
+}
+
+func withDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// runCommand runs a rinku command line in this process and returns what it printed.
+// An error keeps the output, which often explains it.
+func runCommand(r *rinku.Rinku, args []string) (string, error) {
+	cli := reflect.New(reflect.TypeOf(CLI)).Interface()
+	parser, err := kong.New(cli, kong.Name("rinku"), kong.Bind(r), kong.Exit(func(int) {}))
+	if err != nil {
+		return "", err
+	}
+	ctx, err := parser.Parse(args)
+	if err != nil {
+		return "", err
+	}
+
+	rd, wr, err := os.Pipe()
+	if err != nil {
+		return "", fmt.Errorf("capturing output: %w", err)
+	}
+	var out bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(&out, rd)
+		close(done)
+	}()
+	stdout := os.Stdout
+	os.Stdout = wr
+	err = ctx.Run()
+	os.Stdout = stdout
+	wr.Close()
+	<-done
+	rd.Close()
+
+	text := strings.TrimRight(out.String(), "\n")
+	if err != nil {
+		if text != "" {
+			return "", errors.New(text + "\nError: " + err.Error())
+		}
+		return "", err
+	}
+	return text, nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/gosrc"
+	"github.com/stephan/rinku/internal/modmap"
+)
+
+type ModMapCmd struct {
+	Path string `arg:"" type:"existingfile" help:"Path to go.mod file."`
+}
+
+func (c *ModMapCmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	result, err := gomod.Parse(c.Path)
+	if err != nil {
+		return fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	scan, err := gosrc.ScanImports(filepath.Dir(c.Path))
+	if err != nil {
+		return fmt.Errorf("scanning source files: %w", err)
+	}
+
+	m := modmap.Build(result.Module, scan.Files)
+	if len(m.Entries) == 0 {
+		return fmt.Errorf("no Go packages found next to %s", c.Path)
+	}
+	if err := m.Save(cwd); err != nil {
+		return err
+	}
+
+	fmt.Printf("%-40s %-4s %-32s %s\n", "GO PACKAGE", "KIND", "RUST PATH", "FILE")
+	for _, e := range m.Entries {
+		rustPath := e.RustPath
+		if e.Visibility != "pub" {
+			rustPath += " (" + e.Visibility + ")"
+		}
+		fmt.Printf("%-40s %-4s %-32s %s\n", e.Dir, e.Kind, rustPath, e.File)
+	}
+	fmt.Printf("\nWrote %s\n", modmap.Path("."))
+	return nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/stephan/rinku/internal/config"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/notify"
+	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/internal/requirements"
+)
+
+type NotifyCmd struct {
+	Test NotifyTestCmd `cmd:"" help:"Send a test message to every hook in .rinku.toml."`
+}
+
+type NotifyTestCmd struct{}
+
+// notifyTimeout bounds the notifications sent after a command.
+const notifyTimeout = 10 * time.Second
+
+func (c *NotifyTestCmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	cfg, err := config.Load(cwd)
+	if err != nil {
+		return err
+	}
+	if len(cfg.Notify) == 0 {
+		return fmt.Errorf("no [[notify]] hooks in %s", config.FileName)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+	n := &notify.Notifier{Hooks: cfg.Notify}
+	if err := n.Send(ctx, notify.Event{Kind: notify.Test, Project: projectName(cwd), Actor: progress.Actor(cwd)}); err != nil {
+		return err
+	}
+	fmt.Printf("Sent test notification to %d hooks\n", len(cfg.Notify))
+	return nil
+}
+
+// notifyEvents sends events to the hooks of .rinku.toml. Failures are reported but
+// do not fail the command that reached the milestone.
+func notifyEvents(cwd string, events ...notify.Event) {
+	cfg, err := config.Load(cwd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: notifications skipped: %v\n", err)
+		return
+	}
+	if len(cfg.Notify) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+	n := &notify.Notifier{Hooks: cfg.Notify}
+	for _, e := range events {
+		if err := n.Send(ctx, e); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: notification failed: %v\n", err)
+		}
+	}
+}
+
+// notifyStepCompleted announces a finished step, and the end of the migration if it
+// was the last one.
+func notifyStepCompleted(cwd string, m *progress.Migration, step string) {
+	completed, total := m.Progress()
+	e := notify.Event{
+		Kind:      notify.StepCompleted,
+		Project:   projectName(cwd),
+		Step:      step,
+		Completed: completed,
+		Total:     total,
+		Actor:     progress.Actor(cwd),
+	}
+	events := []notify.Event{e}
+	if m.IsComplete() {
+		e.Kind = notify.MigrationFinished
+		events = append(events, e)
+	}
+	notifyEvents(cwd, events...)
+}
+
+// notifyCoverage announces requirement coverage passing a hook threshold and records
+// the coverage for the next comparison.
+func notifyCoverage(cwd string) {
+	summary, err := requirements.Summary(cwd)
+	if err != nil {
+		return
+	}
+	coverage := summary.Coverage()
+	previous := notify.LastCoverage(cwd)
+	if coverage > previous {
+		notifyEvents(cwd, notify.Event{
+			Kind:     notify.Coverage,
+			Project:  projectName(cwd),
+			Coverage: coverage,
+			Previous: previous,
+			Actor:    progress.Actor(cwd),
+		})
+	}
+	if coverage != previous {
+		if err := notify.SaveCoverage(cwd, coverage); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+}
+
+// projectName is the module path of the project's go.mod, or the directory name.
+func projectName(dir string) string {
+	if result, err := gomod.Parse(filepath.Join(dir, "go.mod")); err == nil && result.Module != "" {
+		return result.Module
+	}
+	return filepath.Base(dir)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/cratesio"
+	"github.com/stephan/rinku/internal/rinku"
+)
+
+type OutdatedCmd struct {
+	Path   string `arg:"" type:"existingfile" help:"Path to Cargo.toml file."`
+	Unsafe bool   `help:"Include libraries with known vulnerabilities when checking for preferred mappings."`
+}
+
+func (c *OutdatedCmd) Run(r *rinku.Rinku) error {
+	f, err := os.Open(c.Path)
+	if err != nil {
+		return fmt.Errorf("opening Cargo.toml: %w", err)
+	}
+	defer f.Close()
+
+	manifest, err := cargo.ParseManifest(f)
+	if err != nil {
+		return err
+	}
+	if len(manifest.Dependencies) == 0 {
+		fmt.Println("No dependencies found.")
+		return nil
+	}
+
+	client := cratesio.New()
+	ctx := context.Background()
+
+	fmt.Printf("%-24s %-12s %-12s %s\n", "CRATE", "CURRENT", "LATEST", "STATUS")
+	outdated, replace := 0, 0
+	for _, dep := range manifest.Dependencies {
+		latest := "-"
+		var status string
+
+		crate, err := client.Crate(ctx, dep.Name)
+		switch {
+		case errors.Is(err, cratesio.ErrNotFound):
+			status = "not on crates.io"
+		case err != nil:
+			status = fmt.Sprintf("lookup failed: %v", err)
+		default:
+			latest = crate.LatestVersion()
+			status, err = versionStatus(dep.Version, latest)
+			if err != nil {
+				status = fmt.Sprintf("invalid version: %v", err)
+			}
+			if status == "outdated" {
+				outdated++
+			}
+		}
+
+		if preferred := preferredCrate(r, dep, c.Unsafe); preferred != "" {
+			status += fmt.Sprintf("; replace with %s (preferred mapping for %s)", preferred, dep.GoSource)
+			replace++
+		}
+
+		name := dep.Name
+		if dep.Dev {
+			name += " (dev)"
+		}
+		fmt.Printf("%-24s %-12s %-12s %s\n", name, dep.Version, latest, status)
+	}
+
+	fmt.Printf("\n%d outdated, %d with preferred replacements\n", outdated, replace)
+	return nil
+}
+
+// versionStatus compares a version requirement against the latest published version.
+func versionStatus(req, latest string) (string, error) {
+	if req == "*" || req == "" {
+		return "unpinned", nil
+	}
+	ok, err := cargo.MatchesRequirement(req, latest)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return "up to date", nil
+	}
+	return "outdated", nil
+}
+
+// preferredCrate returns the crate the database now recommends for the dependency's
+// Go source, or "" if the current crate is still among the recommended targets.
+func preferredCrate(r *rinku.Rinku, dep cargo.ManifestDependency, unsafe bool) string {
+	if dep.GoSource == "" {
+		return ""
+	}
+	targets := r.Lookup(cargo.ModulePathToGitHubURL(dep.GoSource), "rust", unsafe)
+	if len(targets) == 0 {
+		return ""
+	}
+
+	var first string
+	for _, target := range targets {
+		name := r.CrateName(target)
+		if name == "" {
+			name = cargo.ExtractCrateName(target)
+		}
+		if name == dep.Name {
+			return ""
+		}
+		if first == "" {
+			first = name
+		}
+	}
+	return first
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/stephan/rinku/internal/scaffold"
+)
+
+type ParityCmd struct {
+	Path     string   `arg:"" type:"existingfile" help:"Path to go.mod file."`
+	Package  string   `arg:"" help:"Package to record, as import path or directory relative to go.mod."`
+	Func     []string `help:"Function to record (repeatable; default: all exported functions)."`
+	CrateDir string   `help:"Directory for the Rust test crate, relative to go.mod (default: parity/<package>)."`
+	Force    bool     `help:"Overwrite existing files."`
+}
+
+func (c *ParityCmd) Run() error {
+	if c.CrateDir != "" {
+		if err := validateOutputPath(c.CrateDir); err != nil {
+			return err
+		}
+	}
+
+	pkg, root, err := loadScaffoldPackage(c.Path, c.Package)
+	if err != nil {
+		return err
+	}
+	parity, err := scaffold.GenerateParity(pkg, scaffold.ParityOptions{
+		Funcs:    c.Func,
+		CrateDir: filepath.ToSlash(c.CrateDir),
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeScaffold(root, parity.Files, c.Force); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nRecording %d functions from %s", len(parity.Recorded), pkg.ImportPath)
+	if len(parity.Skipped) > 0 {
+		fmt.Printf(", skipped %d:\n", len(parity.Skipped))
+		for _, s := range parity.Skipped {
+			fmt.Printf("  %s: %s\n", s.Func, s.Reason)
+		}
+	} else {
+		fmt.Println()
+	}
+	fmt.Printf("\nAdd inputs to %s, then record fixtures into %s with:\n", parity.RecordTest, parity.FixtureDir)
+	fmt.Printf("  go test -tags parity -run %s %s\n", parity.RecordTest, parity.PackagePath)
+	fmt.Printf("Port the todo!() bodies in tests/parity.rs and run `cargo test` to check the Rust port against them.\n")
+	return nil
+}
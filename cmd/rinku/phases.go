@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/gosrc"
+	"github.com/stephan/rinku/internal/phases"
+	"github.com/stephan/rinku/internal/rinku"
+)
+
+type PlanPhasesCmd struct {
+	Path   string `arg:"" type:"existingfile" help:"Path to go.mod file."`
+	Output string `short:"o" default:"-" help:"Output file for the phase plan (- for stdout)."`
+	Unsafe bool   `help:"Include libraries with known vulnerabilities."`
+}
+
+func (c *PlanPhasesCmd) Run(r *rinku.Rinku) (err error) {
+	result, err := gomod.Parse(c.Path)
+	if err != nil {
+		return fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	scan, err := gosrc.ScanImports(filepath.Dir(c.Path))
+	if err != nil {
+		return fmt.Errorf("scanning source files: %w", err)
+	}
+
+	mapping := cargo.MapDependencies(result.DirectDependencies(), r, c.Unsafe)
+	var deps []phases.Dependency
+	for _, m := range mapping.Mapped {
+		deps = append(deps, phases.Dependency{
+			GoModule: m.GoDep.Path,
+			Crates:   m.CrateNames,
+			Tags:     r.Tags(cargo.ModulePathToGitHubURL(m.GoDep.Path)),
+		})
+	}
+	for _, u := range mapping.Unmapped {
+		deps = append(deps, phases.Dependency{
+			GoModule: u.GoDep.Path,
+			Tags:     r.Tags(cargo.ModulePathToGitHubURL(u.GoDep.Path)),
+		})
+	}
+
+	plan := phases.Build(result.Module, scan.Files, deps)
+
+	w := os.Stdout
+	if c.Output != "-" {
+		if err := validateOutputPath(c.Output); err != nil {
+			return err
+		}
+		w, err = os.Create(c.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() {
+			if cerr := w.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("failed to close output file: %w", cerr)
+			}
+		}()
+	}
+
+	if err := plan.Markdown(w); err != nil {
+		return fmt.Errorf("writing phase plan: %w", err)
+	}
+	if c.Output != "-" {
+		fmt.Fprintf(os.Stderr, "Wrote %d phases to %s\n", len(plan.Phases), c.Output)
+	}
+	return nil
+}
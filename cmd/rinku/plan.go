@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/configgen"
+	"github.com/stephan/rinku/internal/plan"
+	"github.com/stephan/rinku/internal/rinku"
+)
+
+type PlanCmd struct {
+	Path     string `arg:"" type:"existingfile" help:"Path to go.mod file."`
+	Output   string `short:"o" default:"Cargo.toml" help:"Cargo.toml path to plan."`
+	Config   string `help:"Also plan Rust config scaffolding at this path (e.g. src/config.rs)."`
+	Loader   string `enum:",figment,config-rs" default:"" help:"Rust config crate for --config."`
+	Unsafe   bool   `help:"Include libraries with known vulnerabilities."`
+	Source   bool   `help:"Scan Go test files next to go.mod and add [dev-dependencies] for the detected test stack."`
+	Features bool   `help:"Request only the cargo features the Go source next to go.mod needs for the crates rinku knows them of (axum, tokio, sea-orm, uuid, redis), without their defaults."`
+	NoLock   bool   `help:"Ignore .rinku/mappings.lock.json and use the current database mappings."`
+}
+
+type ApplyCmd struct{}
+
+func (c *PlanCmd) Run(r *rinku.Rinku) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	if err := validateOutputPath(c.Output); err != nil {
+		return err
+	}
+	if c.Config != "" {
+		if err := validateOutputPath(c.Config); err != nil {
+			return err
+		}
+	}
+
+	module, genResult, err := mapForConvert(r, c.Path, c.Unsafe, c.Source, c.Features, c.NoLock)
+	if err != nil {
+		return err
+	}
+	var cargoToml bytes.Buffer
+	if err := cargo.GenerateCargoToml(&cargoToml, module, genResult); err != nil {
+		return fmt.Errorf("failed to generate Cargo.toml: %w", err)
+	}
+
+	p := plan.New(c.Path)
+	if err := p.Add(cwd, c.Output, cargoToml.Bytes()); err != nil {
+		return err
+	}
+	if c.Config != "" {
+		var config bytes.Buffer
+		if _, err := generateConfig(&config, c.Path, configgen.Loader(c.Loader)); err != nil {
+			return err
+		}
+		if err := p.Add(cwd, c.Config, config.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	counts := make(map[plan.ActionKind]int)
+	for i := range p.Actions {
+		a := &p.Actions[i]
+		counts[a.Kind]++
+		switch a.Kind {
+		case plan.ActionCreate:
+			fmt.Printf("+ create %s\n", a.Path)
+		case plan.ActionUpdate:
+			fmt.Printf("~ update %s\n", a.Path)
+		default:
+			fmt.Printf("= unchanged %s\n", a.Path)
+			continue
+		}
+		fmt.Println(a.Diff())
+	}
+
+	fmt.Printf("Plan: %d to create, %d to update, %d unchanged.\n",
+		counts[plan.ActionCreate], counts[plan.ActionUpdate], counts[plan.ActionNoop])
+	if p.Changes() == 0 {
+		return plan.Delete(cwd)
+	}
+	if err := p.Save(cwd); err != nil {
+		return err
+	}
+	fmt.Printf("Saved to %s. Run 'rinku apply' to execute it.\n", plan.Path("."))
+	return nil
+}
+
+func (c *ApplyCmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	p, err := plan.Load(cwd)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return errors.New("no saved plan found\nHint: Run 'rinku plan <path-to-go.mod>' first")
+	}
+
+	if err := p.Apply(cwd); err != nil {
+		return err
+	}
+	for _, a := range p.Actions {
+		if a.Kind != plan.ActionNoop {
+			fmt.Printf("%s %s\n", a.Kind, a.Path)
+		}
+	}
+	return plan.Delete(cwd)
+}
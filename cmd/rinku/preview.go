@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stephan/rinku/internal/multistep"
+	"github.com/stephan/rinku/internal/prompt"
+	"github.com/stephan/rinku/internal/requirements"
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/suppress"
+	"github.com/stephan/rinku/render"
+)
+
+type MigratePreviewCmd struct {
+	Step      string `arg:"" help:"Step ID to preview."`
+	Overrides string `type:"existingfile" help:"Workflow overrides file to preview instead of .rinku/workflow-overrides.yaml."`
+	Format    string `help:"Output format: text or json." enum:"text,json" default:"text"`
+}
+
+// StepPreview is a step as migrate --start would print it, with the gate check
+// migrate --finish would make.
+type StepPreview struct {
+	*StepView
+	Rendered  string        `json:"rendered"`  // output of migrate --start
+	Templated bool          `json:"templated"` // content was expanded as a template
+	Gates     []GatePattern `json:"gates"`
+}
+
+// GatePattern is one gate pattern of a step and the requirements it matches.
+type GatePattern struct {
+	Pattern    string   `json:"pattern"`
+	Matched    int      `json:"matched"`
+	Pending    []string `json:"pending"`
+	Suppressed []string `json:"suppressed,omitempty"` // pending, but not blocking --finish
+}
+
+func (c *MigratePreviewCmd) Run(r *rinku.Rinku) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	var p *multistep.Prompt
+	if c.Overrides != "" {
+		p, err = prompt.WithOverrides(c.Overrides)
+	} else {
+		p, err = prompt.ForProject(cwd)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load migration prompt: %w", err)
+	}
+
+	view, err := buildStepView(r, cwd, p, c.Step)
+	if err != nil {
+		return err
+	}
+	raw, _ := p.GetStep(c.Step)
+	preview := &StepPreview{StepView: view, Templated: strings.Contains(raw, "{{")}
+	var b strings.Builder
+	writeStepOutput(&b, view.Before, view.Content, view.After, view.Categories, view.Dependencies)
+	preview.Rendered = b.String()
+	if preview.Gates, err = gatePatterns(cwd, c.Step, view.Gate.Patterns); err != nil {
+		return err
+	}
+
+	if c.Format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(render.Versioned(render.APIVersion, "migrate preview", preview)); err != nil {
+			return fmt.Errorf("encoding preview: %w", err)
+		}
+		return nil
+	}
+	fmt.Print(preview.Rendered)
+	fmt.Println()
+	writePreviewSummary(os.Stdout, preview)
+	return nil
+}
+
+// gatePatterns checks each gate pattern of a step like gatePending.
+func gatePatterns(cwd, id string, patterns []string) ([]GatePattern, error) {
+	s, err := suppress.Load(cwd)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	gates := []GatePattern{}
+	for _, pattern := range patterns {
+		reqs, err := requirements.GetAll(cwd, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("checking requirements: %w", err)
+		}
+		g := GatePattern{Pattern: pattern, Matched: len(reqs), Pending: []string{}}
+		for _, req := range reqs {
+			if req.Done {
+				continue
+			}
+			if _, ok := s.Lookup("pending-requirement", req.Path, now); ok {
+				g.Suppressed = append(g.Suppressed, req.Path)
+			} else {
+				g.Pending = append(g.Pending, req.Path)
+			}
+		}
+		gates = append(gates, g)
+	}
+	return gates, nil
+}
+
+// writePreviewSummary writes what preview found out about a step after its output.
+func writePreviewSummary(w io.Writer, p *StepPreview) {
+	fmt.Fprintln(w, "---")
+	fmt.Fprintf(w, "Preview of step %s (%d/%d, %s); progress was not changed.\n", p.ID, p.Index, p.Total, p.Status)
+	if p.Templated {
+		names := make([]string, 0, len(p.Variables))
+		for name := range p.Variables {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(w, "Template: expanded with %s\n", strings.Join(names, ", "))
+	}
+	if len(p.Categories) > 0 {
+		fmt.Fprintf(w, "Context: %d dependencies for %s\n", len(p.Dependencies), strings.Join(p.Categories, ", "))
+	}
+	if len(p.Gates) == 0 {
+		fmt.Fprintf(w, "Gate: none; --finish %s would pass\n", p.ID)
+		return
+	}
+	for _, g := range p.Gates {
+		fmt.Fprintf(w, "Gate %s: %d requirements, %d pending\n", g.Pattern, g.Matched, len(g.Pending))
+		for _, path := range g.Pending {
+			fmt.Fprintf(w, "  [ ] %s\n", path)
+		}
+		for _, path := range g.Suppressed {
+			fmt.Fprintf(w, "  [~] %s (suppressed)\n", path)
+		}
+	}
+	if p.Gate.Passes {
+		fmt.Fprintf(w, "--finish %s would pass\n", p.ID)
+	} else {
+		fmt.Fprintf(w, "--finish %s would be refused: %d requirements not done\n", p.ID, len(p.Gate.Pending))
+	}
+}
@@ -0,0 +1,411 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stephan/rinku/internal/audit"
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/cratesio"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/goproxy"
+	"github.com/stephan/rinku/internal/gosrc"
+	"github.com/stephan/rinku/internal/osv"
+	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/internal/requirements"
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/versionmap"
+	"github.com/stephan/rinku/internal/weight"
+)
+
+type ReportCmd struct {
+	Path     string `arg:"" type:"existingfile" help:"Path to go.mod file."`
+	Security bool   `help:"Compare open advisories for Go dependencies against their mapped Rust crates."`
+	Source   bool   `help:"Analyze Go source files next to go.mod (interfaces to traits, high-risk files, concurrency)."`
+	Weight   bool   `help:"Compare transitive dependency counts of Go modules and their mapped Rust crates."`
+	Versions bool   `help:"Suggest the crate version that was current when each pinned Go version was released."`
+	Routes   bool   `help:"Inventory HTTP routes with middlewares and status codes (default when a dependency is tagged web)."`
+	Unsafe   bool   `help:"Include libraries with known vulnerabilities."`
+}
+
+func (c *ReportCmd) Run(r *rinku.Rinku) error {
+	result, err := gomod.Parse(c.Path)
+	if err != nil {
+		return fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+
+	deps := result.DirectDependencies()
+	mapping := cargo.MapDependencies(deps, r, c.Unsafe)
+
+	fmt.Printf("Module: %s\n", result.Module)
+	fmt.Printf("Direct dependencies: %d (%d mapped, %d unmapped)\n",
+		len(deps), len(mapping.Mapped), len(mapping.Unmapped))
+
+	if err := migrationReport(filepath.Dir(c.Path)); err != nil {
+		return err
+	}
+
+	routes := c.Routes || hasTag(r, deps, "web")
+	var tree *gosrc.Tree
+	if c.Source || routes {
+		tree, err = gosrc.Parse(filepath.Dir(c.Path))
+		if err != nil {
+			return fmt.Errorf("parsing source files: %w", err)
+		}
+	}
+
+	if routes {
+		fmt.Println()
+		routeReport(filepath.Dir(c.Path), path.Base(result.Module), audit.ExtractSurface(tree))
+	}
+
+	if c.Source {
+		fmt.Println()
+		interfaceReport(audit.Interfaces(tree))
+		fmt.Println()
+		riskReport(audit.Risks(tree))
+		fmt.Println()
+		concurrencyReport(audit.Concurrency(tree))
+	}
+
+	if c.Weight {
+		fmt.Println()
+		sum, err := gomod.ParseSum(filepath.Join(filepath.Dir(c.Path), "go.sum"))
+		if err != nil {
+			// Without go.sum, the requirements of go.mod are the best view of the build.
+			sum = result.Dependencies
+		}
+		weightReport(context.Background(), result.Module, mapping, sum)
+	}
+
+	if c.Versions {
+		fmt.Println()
+		versionReport(context.Background(), mapping)
+	}
+
+	if c.Security {
+		fmt.Println()
+		return securityReport(context.Background(), mapping)
+	}
+	return nil
+}
+
+// migrationReport prints workflow progress, who worked on each step and the artifacts
+// recorded per step, if the project has started the migrate workflow.
+func migrationReport(dir string) error {
+	m, err := progress.Load(dir)
+	if err != nil {
+		return fmt.Errorf("loading progress: %w", err)
+	}
+	if m == nil {
+		return nil
+	}
+	completed, total := m.Progress()
+	fmt.Printf("Migration: %d/%d steps (current: %s)\n", completed, total, m.CurrentStep)
+	if contributors := m.Contributors(); len(contributors) > 0 {
+		fmt.Printf("  contributors: %s\n", strings.Join(contributors, ", "))
+	}
+	if skipped := m.SkipCounts(); skipped.Total() > 0 {
+		fmt.Printf("  skipped steps: %d (%s)\n", skipped.Total(), skipped)
+	}
+	supp, _, err := loadSuppressions(dir)
+	if err != nil {
+		return err
+	}
+	if suppressed := supp.Counts(time.Now()); suppressed.Total() > 0 {
+		fmt.Printf("  suppressions: %d in effect (%s)\n", suppressed.Total(), suppressed)
+	}
+	for _, id := range m.StepOrder {
+		step := m.Steps[id]
+		artifacts, err := progress.Artifacts(dir, id)
+		if err != nil {
+			return err
+		}
+		var who []string
+		if step != nil && step.StartedBy != "" {
+			who = append(who, "started by "+step.StartedBy)
+		}
+		switch {
+		case step != nil && step.Status == progress.StepSkipped && step.SkipCode != "":
+			who = append(who, fmt.Sprintf("skipped (%s) by %s", step.SkipCode, step.CompletedBy))
+		case step != nil && step.CompletedBy != "":
+			who = append(who, "completed by "+step.CompletedBy)
+		}
+		if len(artifacts) == 0 && len(who) == 0 {
+			continue
+		}
+		fmt.Printf("  step %s: %d artifacts", id, len(artifacts))
+		if len(who) > 0 {
+			fmt.Printf(", %s", strings.Join(who, ", "))
+		}
+		fmt.Println()
+		for _, a := range artifacts {
+			fmt.Printf("    %s (%s)\n", a.Path, a.AddedAt.Local().Format("2006-01-02 15:04"))
+		}
+	}
+	return nil
+}
+
+// hasTag reports whether one of the dependencies maps to a library with the tag.
+func hasTag(r *rinku.Rinku, deps []gomod.Dependency, tag string) bool {
+	for _, dep := range deps {
+		if slices.Contains(r.Tags(cargo.ModulePathToGitHubURL(dep.Path)), tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeReport lists every HTTP route with its middlewares and status codes, and whether
+// it is captured and done as a requirement, so behavior can be compared route by route
+// after the migration.
+func routeReport(dir, module string, surface *audit.Surface) {
+	captured, done := 0, 0
+	var lines []string
+	for _, route := range surface.Routes {
+		bin := route.Binary
+		if bin == "" {
+			bin = module
+		}
+		mark := "[!]"
+		if req, _ := requirements.Get(dir, route.RequirementPath(bin)); req != nil {
+			captured++
+			mark = "[ ]"
+			if req.Done {
+				done++
+				mark = "[x]"
+			}
+		}
+		line := fmt.Sprintf("  %s %-7s %s -> %s", mark, route.MethodName(), route.Path, route.Handler)
+		if len(route.Middlewares) > 0 {
+			line += "\n        middleware: " + strings.Join(route.Middlewares, ", ")
+		}
+		if len(route.Statuses) > 0 {
+			line += "\n        status: " + route.StatusList()
+		}
+		lines = append(lines, line)
+	}
+	fmt.Printf("HTTP routes: %d (%d captured, %d done)\n", len(surface.Routes), captured, done)
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	if captured < len(surface.Routes) {
+		fmt.Println("  [!] not captured yet; run `rinku req extract` to add them under */api/routes")
+	}
+}
+
+// interfaceReport prints the exported interfaces with a trait sketch for each, flagging
+// the ones used through type assertions or dynamic types.
+func interfaceReport(ifaces []audit.Interface) {
+	fmt.Printf("Interfaces → traits: %d exported\n", len(ifaces))
+	flagged := 0
+	for _, iface := range ifaces {
+		fmt.Printf("  %s (%s:%d): %d methods, %d implementers\n",
+			iface.Qualified(), iface.File, iface.Line, len(iface.Methods), len(iface.Implementers))
+		fmt.Printf("    -> %s\n", iface.Rust())
+		if len(iface.Implementers) > 0 {
+			fmt.Printf("    implemented by: %s\n", strings.Join(iface.Implementers, ", "))
+		}
+		for _, f := range iface.Flags {
+			fmt.Printf("    ! %s:%d %s\n", f.File, f.Line, f.Reason)
+		}
+		if len(iface.Flags) > 0 {
+			flagged++
+		}
+	}
+	if flagged > 0 {
+		fmt.Printf("  %d flagged interfaces need redesign rather than a mechanical translation\n", flagged)
+	}
+}
+
+// riskReport lists files using reflection, unsafe code, linkname or runtime internals.
+// They are counted as high-risk because they need redesign rather than translation.
+func riskReport(files []audit.RiskFile) {
+	fmt.Printf("High-risk files: %d (reflect, unsafe, go:linkname, runtime tricks, cgo)\n", len(files))
+	for _, f := range files {
+		fmt.Printf("  %s: %s\n", f.File, f.Kinds())
+		for _, r := range f.Risks {
+			fmt.Printf("    %s:%d %s\n", f.File, r.Line, r.Detail)
+		}
+	}
+}
+
+// concurrencyReport prints per-package primitive counts and points to the idiom entries
+// for the primitives in use.
+func concurrencyReport(pkgs []audit.PackageConcurrency) {
+	fmt.Printf("Concurrency: %d packages use concurrency primitives\n", len(pkgs))
+	used := make(map[audit.Primitive]bool)
+	for _, p := range pkgs {
+		fmt.Printf("  %s (%s): score %d - %s\n", p.Dir, p.Package, p.Score(), p.Summary())
+		for prim := range p.Counts {
+			used[prim] = true
+		}
+	}
+	for _, prim := range audit.Primitives {
+		if used[prim] {
+			fmt.Printf("  see: rinku idiom %s\n", prim)
+		}
+	}
+}
+
+// weightReport compares the go.mod requirements of each mapped Go module with the resolved
+// dependency tree of its primary Rust crate and warns about much heavier targets. It ends
+// with the dependency count of the whole build before and after the migration.
+func weightReport(ctx context.Context, module string, mapping *cargo.GenerateResult, sum []gomod.Dependency) {
+	resolver := weight.NewResolver(cratesio.New(), goproxy.New())
+
+	var targets []weight.Target
+	heavy := 0
+	fmt.Println("Dependency weight (transitive dependencies, Go → Rust):")
+	for _, m := range mapping.Mapped {
+		if len(m.CrateNames) == 0 {
+			continue
+		}
+		req := "*"
+		if len(m.Versions) > 0 && m.Versions[0] != "" {
+			req = m.Versions[0]
+		}
+		targets = append(targets, weight.Target{Module: m.GoDep.Path, Version: m.GoDep.Version, Crate: m.CrateNames[0], Req: req})
+		cmp, err := resolver.Compare(ctx, m.GoDep.Path, m.GoDep.Version, m.CrateNames[0], req)
+		if err != nil {
+			fmt.Printf("  %s -> %s: lookup failed: %v\n", m.GoDep.Path, m.CrateNames[0], err)
+			continue
+		}
+		fmt.Printf("  %s -> %s: %d → %d\n", cmp.GoModule, cmp.Crate, cmp.GoDeps, cmp.RustDeps)
+		if cmp.Heavy() {
+			fmt.Printf("    warning: %s pulls %d more dependencies than %s\n", cmp.Crate, cmp.RustDeps-cmp.GoDeps, cmp.GoModule)
+			heavy++
+		}
+	}
+	if heavy > 0 {
+		fmt.Printf("  %d Rust targets are much heavier than their Go originals; consider lighter alternatives or disabling default features\n", heavy)
+	}
+
+	for _, u := range mapping.Unmapped {
+		targets = append(targets, weight.Target{Module: u.GoDep.Path, Version: u.GoDep.Version})
+	}
+	e := resolver.Estimate(ctx, module, targets, sum)
+	fmt.Println()
+	fmt.Printf("Dependency count (before → after): %d Go modules → %d crates\n", e.Before(), e.After())
+	fmt.Printf("  Go: %d direct, %d indirect\n", e.GoDirect, e.GoIndirect)
+	fmt.Printf("  Rust: %d crates in the mapped trees, %d unmapped dependencies\n", len(e.Crates), e.Unmapped)
+	fmt.Printf("  Indirect modules eliminated: %d of %d\n", len(e.Eliminated), e.GoIndirect)
+	if len(e.Retained) > 0 {
+		fmt.Printf("  Still required by unmapped dependencies: %s\n", strings.Join(e.Retained, ", "))
+	}
+	if len(e.Failed) > 0 {
+		fmt.Printf("  Lookup failed for %s; the estimate is incomplete\n", strings.Join(e.Failed, ", "))
+	}
+}
+
+// versionReport prints, for each mapped Go module, the release line of its primary crate
+// that was current when the pinned Go version was published.
+func versionReport(ctx context.Context, mapping *cargo.GenerateResult) {
+	resolver := versionmap.NewResolver(cratesio.New(), goproxy.New())
+
+	fmt.Println("Contemporaneous versions:")
+	for _, m := range mapping.Mapped {
+		s, err := resolver.Suggest(ctx, m.GoDep.Path, m.GoDep.Version, m.CrateNames[0])
+		if err != nil {
+			fmt.Printf("  %s -> %s: lookup failed: %v\n", m.GoDep.Path, m.CrateNames[0], err)
+			continue
+		}
+		fmt.Printf("  %s -> %s = \"%s\": %s\n", m.GoDep.Path, s.Crate, s.Req, s)
+	}
+	fmt.Println("  Lock these versions with 'rinku lock --contemporaneous'.")
+}
+
+// securityReport prints advisories for each Go dependency at its pinned version next to
+// advisories for the latest release of its primary Rust crate. Only mapped dependencies
+// count towards the delta; unmapped ones are reported separately.
+func securityReport(ctx context.Context, mapping *cargo.GenerateResult) error {
+	advisories := osv.New()
+	crates := cratesio.New()
+
+	var goTotal, rustTotal, unmappedTotal, failed int
+	fmt.Println("Security:")
+	for _, m := range mapping.Mapped {
+		fmt.Printf("%s %s\n", m.GoDep.Path, m.GoDep.Version)
+
+		goVulns, err := advisories.Query(ctx, osv.EcosystemGo, m.GoDep.Path, m.GoDep.Version)
+		if err != nil {
+			fmt.Printf("  Go:   lookup failed: %v\n", err)
+			failed++
+			continue
+		}
+		fmt.Printf("  Go:   %s\n", formatAdvisories(goVulns))
+
+		crateName := m.CrateNames[0]
+		crate, err := crates.Crate(ctx, crateName)
+		if err != nil {
+			fmt.Printf("  Rust: %s lookup failed: %v\n", crateName, err)
+			failed++
+			continue
+		}
+		version := crate.LatestVersion()
+		rustVulns, err := advisories.Query(ctx, osv.EcosystemCratesIO, crateName, version)
+		if err != nil {
+			fmt.Printf("  Rust: %s %s lookup failed: %v\n", crateName, version, err)
+			failed++
+			continue
+		}
+		fmt.Printf("  Rust: %s %s: %s\n", crateName, version, formatAdvisories(rustVulns))
+
+		goTotal += len(goVulns)
+		rustTotal += len(rustVulns)
+	}
+
+	for _, u := range mapping.Unmapped {
+		vulns, err := advisories.Query(ctx, osv.EcosystemGo, u.GoDep.Path, u.GoDep.Version)
+		if err != nil {
+			failed++
+			continue
+		}
+		if len(vulns) > 0 {
+			fmt.Printf("%s %s (no mapping found)\n", u.GoDep.Path, u.GoDep.Version)
+			fmt.Printf("  Go:   %s\n", formatAdvisories(vulns))
+			unmappedTotal += len(vulns)
+		}
+	}
+
+	fmt.Printf("\nAdvisories in mapped dependencies: Go %d, Rust %d (%s)\n",
+		goTotal, rustTotal, formatDelta(rustTotal-goTotal))
+	if unmappedTotal > 0 {
+		fmt.Printf("Advisories in unmapped dependencies: %d\n", unmappedTotal)
+	}
+	if failed > 0 {
+		fmt.Printf("Lookups failed: %d (excluded from totals)\n", failed)
+	}
+	return nil
+}
+
+func formatAdvisories(vulns []osv.Vulnerability) string {
+	if len(vulns) == 0 {
+		return "no known advisories"
+	}
+	ids := make([]string, len(vulns))
+	for i, v := range vulns {
+		ids[i] = v.ID
+	}
+	noun := "advisories"
+	if len(vulns) == 1 {
+		noun = "advisory"
+	}
+	return fmt.Sprintf("%d %s (%s)", len(vulns), noun, strings.Join(ids, ", "))
+}
+
+func formatDelta(delta int) string {
+	switch {
+	case delta < 0:
+		return fmt.Sprintf("%d fewer after migration", -delta)
+	case delta > 0:
+		return fmt.Sprintf("%d more after migration", delta)
+	default:
+		return "no change"
+	}
+}
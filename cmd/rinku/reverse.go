@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/url"
+	"github.com/stephan/rinku/render"
+)
+
+type ReverseCmd struct {
+	Target   string `arg:"" help:"Library URL, crates.io URL (crates.io/crates/serde) or crate name of the target library."`
+	Language string `arg:"" optional:"" help:"Source language (default: go)."`
+	Unsafe   bool   `help:"Include libraries with known vulnerabilities."`
+	Format   string `default:"text" help:"Output format: text, json, yaml, csv, markdown, html, sarif or porcelain."`
+	JSON     bool   `help:"Shorthand for --format json."`
+}
+
+// ReverseResult is the json and yaml output of reverse.
+type ReverseResult struct {
+	Target   string   `json:"target"` // normalized target URL
+	Language string   `json:"language"`
+	Sources  []string `json:"sources"`
+}
+
+func (c *ReverseCmd) Run(r *rinku.Rinku) error {
+	libURL, err := cargo.ResolveTarget(r, c.Target)
+	if err != nil {
+		return fmt.Errorf("%w\nHint: give the GitHub URL of the library instead", err)
+	}
+	if !isValidURL(libURL) {
+		return fmt.Errorf("invalid target %q: give a URL starting with http:// or https://, a crates.io URL or a crate name", c.Target)
+	}
+	language := c.Language
+	if language == "" {
+		language = "go"
+	}
+	format := c.Format
+	if c.JSON {
+		format = "json"
+	}
+	return render.Render(os.Stdout, format, reverseDocument(r, c.Target, libURL, language, c.Unsafe))
+}
+
+// reverseDocument returns the libraries of language that map to libURL, the library
+// target names. The text output adds the library to a crate.
+func reverseDocument(r *rinku.Rinku, target, libURL, language string, unsafe bool) *render.Document {
+	sources := r.ReverseLookup(libURL, language, unsafe)
+	data := &ReverseResult{Target: url.Normalize(libURL), Language: language, Sources: []string{}}
+	doc := &render.Document{
+		Command: "reverse",
+		Title:   target,
+		Fields:  []render.Field{{Name: "Source language", Value: language}},
+		Columns: []string{"url"},
+		Data:    data,
+	}
+	if libURL != target {
+		doc.Fields = append(doc.Fields, render.Field{Name: "Library", Value: libURL})
+	}
+	for _, source := range sources {
+		data.Sources = append(data.Sources, source)
+		doc.Rows = append(doc.Rows, []string{source})
+	}
+	if len(sources) == 0 {
+		doc.Findings = append(doc.Findings, render.Finding{
+			Rule:    "unmapped-dependency",
+			Level:   render.LevelNote,
+			Message: fmt.Sprintf("no %s library maps to %s", language, libURL),
+		})
+	}
+	doc.Text = func(w io.Writer) error {
+		if libURL != target {
+			fmt.Fprintf(w, "%s (%s)\n", target, libURL)
+		} else {
+			fmt.Fprintln(w, target)
+		}
+		for _, source := range sources {
+			fmt.Fprintf(w, "  <- %s\n", source)
+		}
+		if len(sources) == 0 {
+			fmt.Fprintln(w, "  <- (no mapping found)")
+		}
+		return nil
+	}
+	return doc
+}
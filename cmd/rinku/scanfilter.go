@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/scan"
+	"github.com/stephan/rinku/render"
+)
+
+// categoryFilter is the --category of scan. A mapping category matches a value that is
+// the category, one of its underscore-separated words (web matches web_framework) or a
+// glob of it (*_cli). Unmapped dependencies have no category and match no value.
+type categoryFilter []string
+
+func (f categoryFilter) match(category string) bool {
+	if category == "" {
+		return false
+	}
+	words := strings.Split(category, "_")
+	return slices.ContainsFunc(f, func(value string) bool {
+		if value == category || slices.Contains(words, value) {
+			return true
+		}
+		ok, _ := path.Match(value, category)
+		return ok
+	})
+}
+
+// formatConfidence returns a mapping confidence as printed by scan and lookup, e.g.
+// 0.95, or "" if none is recorded.
+func formatConfidence(c float64) string {
+	if c == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(c, 'f', -1, 64)
+}
+
+// filtered reports whether --category or --min-confidence narrow the scan.
+func (c *ScanCmd) filtered() bool {
+	return len(c.Category) > 0 || c.MinConfidence > 0
+}
+
+// keep reports whether a dependency mapped with category and confidence passes
+// --category and --min-confidence. A mapping without a recorded confidence is below
+// any minimum.
+func (c *ScanCmd) keep(category string, confidence float64) bool {
+	if len(c.Category) > 0 && !categoryFilter(c.Category).match(category) {
+		return false
+	}
+	return confidence >= c.MinConfidence
+}
+
+// filter returns the mappings passing --category and --min-confidence, all of them
+// without either. With a filter, the number before filtering is recorded in data
+// unless it is nil.
+func (c *ScanCmd) filter(mappings []scan.Mapping, data *scan.Result) []scan.Mapping {
+	if !c.filtered() {
+		return mappings
+	}
+	if data != nil {
+		data.DirectTotal = len(mappings)
+	}
+	return slices.DeleteFunc(mappings, func(m scan.Mapping) bool { return !c.keep(m.Category, m.Confidence) })
+}
+
+// checkFilters rejects a --min-confidence outside 0 to 1, and a --category value that
+// is not a valid glob or matches no category of the database in any of the target
+// languages, most likely a typo.
+func (c *ScanCmd) checkFilters(r *rinku.Rinku, targets []scanTarget) error {
+	if c.MinConfidence < 0 || c.MinConfidence > 1 {
+		return fmt.Errorf("--min-confidence %v is not between 0 and 1", c.MinConfidence)
+	}
+	if len(c.Category) == 0 {
+		return nil
+	}
+	categories := make(map[string]bool)
+	for _, t := range targets {
+		for _, source := range r.Sources(t.backend.Lang()) {
+			if category := r.Category(source, t.backend.Lang()); category != "" {
+				categories[category] = true
+			}
+		}
+	}
+	for _, value := range c.Category {
+		if _, err := path.Match(value, ""); err != nil {
+			return fmt.Errorf("invalid --category %q: %w", value, err)
+		}
+		f := categoryFilter{value}
+		found := false
+		for category := range categories {
+			if f.match(category) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("no mapping has a category matching %q\nHint: rinku grep --type category . lists the categories", value)
+		}
+	}
+	return nil
+}
+
+// filterFields appends the fields of --category and --min-confidence to the header
+// fields of scan, and records them in data unless it is nil.
+func (c *ScanCmd) filterFields(header []render.Field, data *scan.Result) []render.Field {
+	if data != nil {
+		data.CategoryFilter, data.MinConfidence = c.Category, c.MinConfidence
+	}
+	header = slices.Clone(header)
+	if len(c.Category) > 0 {
+		header = append(header, render.Field{Name: "Category filter", Value: strings.Join(c.Category, ", ")})
+	}
+	if c.MinConfidence > 0 {
+		header = append(header, render.Field{Name: "Minimum confidence", Value: formatConfidence(c.MinConfidence)})
+	}
+	return header
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/internal/scan"
+	"github.com/stephan/rinku/internal/scanhistory"
+	"github.com/stephan/rinku/render"
+)
+
+// ScanHistoryEntry is a saved scan in the json and yaml output of scan --history.
+type ScanHistoryEntry struct {
+	ScannedAt     time.Time `json:"scanned_at"`
+	ScannedBy     string    `json:"scanned_by,omitempty"`
+	Path          string    `json:"path"`
+	Target        string    `json:"target"`
+	Direct        int       `json:"direct"`
+	Mapped        int       `json:"mapped"`
+	Coverage      int       `json:"coverage"` // percent mapped
+	NewlyMapped   []string  `json:"newly_mapped,omitempty"`
+	NewlyUnmapped []string  `json:"newly_unmapped,omitempty"`
+	Removed       []string  `json:"removed,omitempty"`
+}
+
+// saveScan writes the direct dependencies of a scan to .rinku/scans next to go.mod.
+func saveScan(goModPath string, data *scan.Result, t scanTarget, mappings []scan.Mapping) (*scanhistory.Snapshot, error) {
+	dir := filepath.Dir(goModPath)
+	s := &scanhistory.Snapshot{
+		ScannedAt:    time.Now().UTC(),
+		ScannedBy:    progress.Actor(dir),
+		Module:       data.Module,
+		Target:       t.name,
+		Direct:       data.Direct,
+		Mapped:       data.Mapped,
+		Dependencies: make([]scanhistory.Dependency, 0, len(mappings)),
+	}
+	for _, m := range mappings {
+		targets := m.Targets
+		if targets == nil {
+			targets = []string{}
+		}
+		s.Dependencies = append(s.Dependencies, scanhistory.Dependency{Path: m.Dep, Targets: targets})
+	}
+	if err := scanhistory.Save(dir, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// scanHistory returns the saved scans of the project of a go.mod file, each with its
+// change from the scan before.
+func scanHistory(goModPath string) ([]ScanHistoryEntry, error) {
+	snapshots, err := scanhistory.List(filepath.Dir(goModPath))
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]ScanHistoryEntry, 0, len(snapshots))
+	for i, s := range snapshots {
+		e := ScanHistoryEntry{
+			ScannedAt: s.ScannedAt,
+			ScannedBy: s.ScannedBy,
+			Path:      filepath.ToSlash(s.Path),
+			Target:    s.Target,
+			Direct:    s.Direct,
+			Mapped:    s.Mapped,
+			Coverage:  s.Coverage(),
+		}
+		if i > 0 {
+			c := s.Compare(&snapshots[i-1])
+			e.NewlyMapped, e.NewlyUnmapped, e.Removed = c.NewlyMapped, c.NewlyUnmapped, c.Removed
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// scanHistoryDocument renders the saved scans of scan --history.
+func scanHistoryDocument(goModPath string, entries []ScanHistoryEntry) *render.Document {
+	doc := &render.Document{
+		Command: "scan",
+		Title:   "Scan history",
+		Columns: []string{"scanned_at", "target", "direct", "mapped", "coverage", "newly_mapped", "newly_unmapped"},
+		Fields:  []render.Field{{Name: "Scans", Value: strconv.Itoa(len(entries))}},
+		Data:    entries,
+	}
+	for _, e := range entries {
+		doc.Rows = append(doc.Rows, []string{
+			e.ScannedAt.Format(time.RFC3339), e.Target, strconv.Itoa(e.Direct), strconv.Itoa(e.Mapped),
+			strconv.Itoa(e.Coverage), strings.Join(e.NewlyMapped, " "), strings.Join(e.NewlyUnmapped, " "),
+		})
+	}
+	doc.Text = func(w io.Writer) error {
+		if len(entries) == 0 {
+			fmt.Fprintf(w, "No saved scans in %s\n", filepath.Join(progress.ProgressDir, scanhistory.Dir))
+			fmt.Fprintf(w, "Save one with: rinku scan %s --save\n", relPath(goModPath))
+			return nil
+		}
+		scans := "scans"
+		if len(entries) == 1 {
+			scans = "scan"
+		}
+		fmt.Fprintf(w, "Scan history (%d %s):\n", len(entries), scans)
+		for _, e := range entries {
+			fmt.Fprintf(w, "  %s  %3d%%  %d/%d mapped", e.ScannedAt.Local().Format("2006-01-02 15:04"), e.Coverage, e.Mapped, e.Direct)
+			if e.Target != "rust" {
+				fmt.Fprintf(w, " (%s)", e.Target)
+			}
+			fmt.Fprintln(w)
+			for _, dep := range e.NewlyMapped {
+				fmt.Fprintf(w, "    + %s\n", dep)
+			}
+			for _, dep := range e.NewlyUnmapped {
+				fmt.Fprintf(w, "    - %s (unmapped)\n", dep)
+			}
+			for _, dep := range e.Removed {
+				fmt.Fprintf(w, "    - %s (removed)\n", dep)
+			}
+		}
+		if len(entries) > 1 {
+			first, last := entries[0], entries[len(entries)-1]
+			fmt.Fprintf(w, "\nTrend: %d%% -> %d%% (%+d points) since %s\n", first.Coverage, last.Coverage,
+				last.Coverage-first.Coverage, first.ScannedAt.Local().Format("2006-01-02"))
+		}
+		return nil
+	}
+	return doc
+}
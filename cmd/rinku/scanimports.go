@@ -0,0 +1,278 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/gopkg"
+	"github.com/stephan/rinku/internal/gosrc"
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/scan"
+	"github.com/stephan/rinku/render"
+)
+
+// ScanImportsResult is the json and yaml output of scan --imports.
+type ScanImportsResult struct {
+	Module   string       `json:"module"`
+	Pattern  string       `json:"pattern"`
+	Files    int          `json:"files"`
+	Packages int          `json:"packages"` // imported packages outside the module
+	Mapped   int          `json:"mapped"`
+	Local    int          `json:"local"` // imported packages of the module itself
+	Imports  []ScanImport `json:"imports"`
+	// CategoryFilter and MinConfidence are --category and --min-confidence; the imports
+	// they leave out are neither listed nor counted.
+	CategoryFilter []string `json:"category_filter,omitempty"`
+	MinConfidence  float64  `json:"min_confidence,omitempty"`
+}
+
+// ScanImport is an imported package and its Rust equivalents, best first.
+type ScanImport struct {
+	Import     string   `json:"import"`
+	Kind       string   `json:"kind"`             // stdlib or module
+	Module     string   `json:"module,omitempty"` // the go.mod requirement providing it
+	Files      int      `json:"files"`            // files importing it
+	TestOnly   bool     `json:"test_only,omitempty"`
+	Status     string   `json:"status"` // mapped or unmapped
+	Category   string   `json:"category,omitempty"`
+	Confidence float64  `json:"confidence,omitempty"` // of the go.mod mapping used
+	Crates     []string `json:"crates"`
+	URLs       []string `json:"urls"`
+}
+
+// importedPackage collects the files importing a package.
+type importedPackage struct {
+	files []string // slash-separated, relative to the go.mod directory
+	test  bool     // imported by _test.go files only
+}
+
+// runImports maps the packages imported by the source files of --imports. Packages of
+// the standard library and subpackages with their own equivalent are looked up in
+// gopkg, the others through the module of go.mod that provides them.
+func (c *ScanCmd) runImports(r *rinku.Rinku, t scanTarget) error {
+	switch {
+	case t.name != "rust":
+		return fmt.Errorf("--imports maps to Rust crates only")
+	case c.Transitive || c.Source || c.Save || c.History:
+		return fmt.Errorf("--imports cannot be combined with --transitive, --source, --save or --history")
+	}
+	result, err := gomod.Parse(c.Path)
+	if err != nil {
+		return fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	dir := filepath.Dir(c.Path)
+	root, recursive, err := importsRoot(dir, c.Imports)
+	if err != nil {
+		return err
+	}
+	src, err := gosrc.ScanImports(root)
+	if err != nil {
+		return fmt.Errorf("scanning source files: %w", err)
+	}
+
+	prefix, err := filepath.Rel(dir, root)
+	if err != nil {
+		return fmt.Errorf("resolving %s: %w", c.Imports, err)
+	}
+	data := &ScanImportsResult{Module: result.Module, Pattern: c.Imports, Imports: []ScanImport{}, CategoryFilter: c.Category, MinConfidence: c.MinConfidence}
+	packages := make(map[string]*importedPackage)
+	for _, f := range src.Files {
+		if !recursive && path.Dir(f.Path) != "." {
+			continue
+		}
+		data.Files++
+		file := path.Join(filepath.ToSlash(prefix), f.Path)
+		for _, imp := range f.Imports {
+			if imp == "C" {
+				continue // cgo
+			}
+			p := packages[imp]
+			if p == nil {
+				p = &importedPackage{test: true}
+				packages[imp] = p
+			}
+			p.files = append(p.files, file)
+			p.test = p.test && f.Test
+		}
+	}
+
+	doc := &render.Document{
+		Command: "scan",
+		Title:   result.Module,
+		Columns: []string{"import", "kind", "module", "files", "crate", "url", "confidence"},
+		Data:    data,
+	}
+	var stdlib, modules []ScanImport
+	for imp, p := range packages {
+		if imp == result.Module || strings.HasPrefix(imp, result.Module+"/") {
+			data.Local++
+			continue
+		}
+		si := ScanImport{Import: imp, Kind: "stdlib", Files: len(p.files), TestOnly: p.test, Status: "mapped", Crates: []string{}, URLs: []string{}}
+		if !gopkg.IsStdlib(imp) {
+			si.Kind = "module"
+			si.Module = providingModule(result.Dependencies, imp)
+		}
+		if e, ok := gopkg.Lookup(imp); ok {
+			for _, target := range e.Rust {
+				si.Crates = append(si.Crates, target)
+				si.URLs = append(si.URLs, gopkg.URL(target))
+			}
+		} else if si.Kind == "module" {
+			m := scan.Map(r, t.backend, imp, cargo.ModulePathToGitHubURL(si.Module), c.Unsafe)
+			si.Category = m.Category
+			si.Confidence = m.Confidence
+			si.Crates = append(si.Crates, m.Targets...)
+			si.URLs = append(si.URLs, m.URLs...)
+		}
+		if !c.keep(si.Category, si.Confidence) {
+			continue
+		}
+		if len(si.URLs) == 0 {
+			si.Status = "unmapped"
+			level, kind := render.LevelWarning, "package"
+			if si.Kind == "stdlib" {
+				level, kind = render.LevelNote, "standard library package"
+			}
+			doc.Findings = append(doc.Findings, render.Finding{
+				Rule:    "unmapped-import",
+				Level:   level,
+				Message: fmt.Sprintf("no Rust equivalent found for %s %s", kind, imp),
+				File:    relPath(filepath.Join(dir, filepath.FromSlash(p.files[0]))),
+				Subject: imp,
+			})
+		} else {
+			data.Mapped++
+		}
+		if si.Kind == "stdlib" {
+			stdlib = append(stdlib, si)
+		} else {
+			modules = append(modules, si)
+		}
+	}
+	byImport := func(list []ScanImport) {
+		sort.Slice(list, func(i, j int) bool { return list[i].Import < list[j].Import })
+	}
+	byImport(stdlib)
+	byImport(modules)
+	data.Imports = append(append(data.Imports, stdlib...), modules...)
+	data.Packages = len(data.Imports)
+
+	for _, si := range data.Imports {
+		files := strconv.Itoa(si.Files)
+		if len(si.URLs) == 0 {
+			doc.Rows = append(doc.Rows, []string{si.Import, si.Kind, si.Module, files, "", "", ""})
+		}
+		for i, u := range si.URLs {
+			doc.Rows = append(doc.Rows, []string{si.Import, si.Kind, si.Module, files, si.Crates[i], u, formatConfidence(si.Confidence)})
+		}
+	}
+	doc.Fields = []render.Field{
+		{Name: "Module", Value: result.Module},
+		{Name: "Pattern", Value: c.Imports},
+		{Name: "Files", Value: strconv.Itoa(data.Files)},
+		{Name: "Imported packages", Value: strconv.Itoa(data.Packages)},
+		{Name: "Mapped", Value: strconv.Itoa(data.Mapped)},
+	}
+	doc.Fields = c.filterFields(doc.Fields, nil)
+	doc.Text = func(w io.Writer) error {
+		fmt.Fprintf(w, "Module: %s\n", result.Module)
+		for _, f := range c.filterFields(nil, nil) {
+			fmt.Fprintf(w, "%s: %s\n", f.Name, f.Value)
+		}
+		fmt.Fprintf(w, "Imports of %s: %d packages in %d files\n", c.Imports, data.Packages, data.Files)
+		writeImports(w, "Standard library", stdlib)
+		writeImports(w, "Modules", modules)
+		fmt.Fprintf(w, "\nMapped %d/%d imported packages", data.Mapped, data.Packages)
+		switch {
+		case data.Local == 1:
+			fmt.Fprintf(w, " (1 package of %s not counted)", result.Module)
+		case data.Local > 1:
+			fmt.Fprintf(w, " (%d packages of %s not counted)", data.Local, result.Module)
+		}
+		fmt.Fprintln(w)
+		return nil
+	}
+	if err := applySuppressions(doc); err != nil {
+		return err
+	}
+	return render.Render(os.Stdout, c.Format, doc)
+}
+
+// importsRoot resolves a pattern of --imports relative to the go.mod directory: ./...
+// or ./dir/... for a tree, ./dir for the files of one directory.
+func importsRoot(dir, pattern string) (string, bool, error) {
+	rel, recursive := strings.CutSuffix(pattern, "/...")
+	if rel == "..." {
+		rel, recursive = ".", true
+	}
+	if rel != "." && !strings.HasPrefix(rel, "./") {
+		return "", false, fmt.Errorf("--imports %s: use a relative package pattern such as ./... or ./cmd/...", pattern)
+	}
+	root := filepath.Join(dir, filepath.FromSlash(rel))
+	if rel, err := filepath.Rel(dir, root); err != nil || strings.HasPrefix(rel, "..") {
+		return "", false, fmt.Errorf("--imports %s is outside the module", pattern)
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return "", false, fmt.Errorf("--imports %s: %w", pattern, err)
+	}
+	if !info.IsDir() {
+		return "", false, fmt.Errorf("--imports %s is not a directory", pattern)
+	}
+	return root, recursive, nil
+}
+
+// providingModule returns the longest module path of go.mod that contains an import,
+// or the import itself if go.mod does not require its module.
+func providingModule(deps []gomod.Dependency, imp string) string {
+	module := ""
+	for _, dep := range deps {
+		if (imp == dep.Path || strings.HasPrefix(imp, dep.Path+"/")) && len(dep.Path) > len(module) {
+			module = dep.Path
+		}
+	}
+	if module == "" {
+		return imp
+	}
+	return module
+}
+
+// writeImports writes a section of the text output of scan --imports.
+func writeImports(w io.Writer, title string, imports []ScanImport) {
+	if len(imports) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%s:\n", title)
+	for _, si := range imports {
+		files := "1 file"
+		if si.Files != 1 {
+			files = fmt.Sprintf("%d files", si.Files)
+		}
+		if si.TestOnly {
+			files += ", tests only"
+		}
+		if si.Confidence > 0 {
+			files += ", confidence " + formatConfidence(si.Confidence)
+		}
+		if si.Category != "" {
+			fmt.Fprintf(w, "%s [%s] (%s)\n", si.Import, si.Category, files)
+		} else {
+			fmt.Fprintf(w, "%s (%s)\n", si.Import, files)
+		}
+		if len(si.URLs) == 0 {
+			fmt.Fprintf(w, "  -> (no mapping found)\n")
+		}
+		for i, u := range si.URLs {
+			fmt.Fprintf(w, "  -> %s (%s)\n", si.Crates[i], u)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/stephan/rinku/internal/github"
+	"github.com/stephan/rinku/internal/orgscan"
+	"github.com/stephan/rinku/internal/rinku"
+)
+
+type ScanOrgCmd struct {
+	Repos    string `type:"existingfile" help:"File listing repositories (owner/repo, GitHub URL or local path), one per line."`
+	Org      string `help:"Scan all non-archived repositories of a GitHub organization."`
+	Jobs     int    `default:"8" help:"Number of repositories to scan concurrently."`
+	Top      int    `default:"10" help:"Number of most common unmapped dependencies to show."`
+	Metadata bool   `help:"Show the stars, license and archived state of GitHub repositories (one API request per listed repository)."`
+	Unsafe   bool   `help:"Include libraries with known vulnerabilities."`
+}
+
+func (c *ScanOrgCmd) Run(r *rinku.Rinku) error {
+	if c.Repos == "" && c.Org == "" {
+		return errors.New("either --repos or --org is required")
+	}
+
+	ctx := context.Background()
+	client, tokenSource := githubClient()
+
+	var repos []string
+	if c.Repos != "" {
+		f, err := os.Open(c.Repos)
+		if err != nil {
+			return fmt.Errorf("opening repository list: %w", err)
+		}
+		repos, err = orgscan.ParseRepoList(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("reading repository list: %w", err)
+		}
+	}
+	// metadata of the GitHub repositories, from the org listing or fetched per repository
+	var mu sync.Mutex
+	metadata := make(map[string]github.Metadata)
+	if c.Org != "" {
+		orgRepos, err := client.OrgRepositories(ctx, c.Org)
+		if err != nil {
+			return err
+		}
+		for _, repo := range orgRepos {
+			repos = append(repos, repo.String())
+			metadata[repo.String()] = repo.Metadata
+		}
+	}
+	if len(repos) == 0 {
+		return errors.New("no repositories to scan")
+	}
+
+	var remote, listed int
+	for _, repo := range repos {
+		if _, ok := localGoMod(repo); !ok {
+			remote++
+			if _, ok := metadata[repo]; !ok {
+				listed++
+			}
+		}
+	}
+	if remote > 0 {
+		var fetched []string
+		if c.Org != "" {
+			fetched = append(fetched, fmt.Sprintf("the repository list of %s (API, with metadata)", c.Org))
+		}
+		fetched = append(fetched, fmt.Sprintf("go.mod of %d repositories (raw.githubusercontent.com)", remote))
+		apiRequests := 0
+		if c.Metadata && listed > 0 {
+			fetched = append(fetched, fmt.Sprintf("stars, license and archived state of %d listed repositories (API)", listed))
+			apiRequests = listed
+		}
+		reportGitHubAccess(ctx, os.Stderr, client, tokenSource, fetched, apiRequests)
+	}
+
+	fetch := func(ctx context.Context, repo string) ([]byte, error) {
+		if path, ok := localGoMod(repo); ok {
+			return os.ReadFile(path) //#nosec G304 -- path listed by the user
+		}
+		gh, err := github.ParseRepo(repo)
+		if err != nil {
+			return nil, err
+		}
+		data, err := client.File(ctx, gh, "go.mod")
+		if err != nil || !c.Metadata {
+			return data, err
+		}
+		mu.Lock()
+		_, known := metadata[repo]
+		mu.Unlock()
+		if !known {
+			m, err := client.Metadata(ctx, gh)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				return data, nil
+			}
+			mu.Lock()
+			metadata[repo] = *m
+			mu.Unlock()
+		}
+		return data, nil
+	}
+
+	results := orgscan.Scan(ctx, repos, fetch, r, c.Unsafe, c.Jobs)
+	orgscan.Rank(results)
+
+	fmt.Printf("Scanned %d repositories\n\n", len(results))
+	fmt.Printf("%-4s %-40s %6s %6s %9s", "RANK", "REPOSITORY", "DEPS", "MAPPED", "READINESS")
+	if c.Metadata {
+		fmt.Printf(" %6s %s", "STARS", "LICENSE")
+	}
+	fmt.Println()
+	var failed []orgscan.Result
+	for i, res := range results {
+		if res.Err != nil {
+			failed = append(failed, res)
+			continue
+		}
+		fmt.Printf("%-4d %-40s %6d %6d %8.0f%%", i+1, res.Repo, res.Direct, res.Mapped, res.Readiness()*100)
+		if c.Metadata {
+			fmt.Print(formatMetadata(metadata, res.Repo))
+		}
+		fmt.Println()
+	}
+
+	if blockers := orgscan.CommonBlockers(results, c.Top); len(blockers) > 0 {
+		fmt.Printf("\nMost common unmapped dependencies:\n")
+		for _, b := range blockers {
+			fmt.Printf("  %-50s used by %d: %s\n", b.Module, len(b.Repos), strings.Join(b.Repos, ", "))
+		}
+	}
+
+	if len(failed) > 0 {
+		fmt.Printf("\nFailed:\n")
+		for _, res := range failed {
+			fmt.Printf("  %s: %v\n", res.Repo, res.Err)
+		}
+	}
+	return nil
+}
+
+// formatMetadata returns the STARS and LICENSE columns of a repository, "-" where
+// unknown, and flags archived repositories.
+func formatMetadata(metadata map[string]github.Metadata, repo string) string {
+	m, ok := metadata[repo]
+	if !ok {
+		return fmt.Sprintf(" %6s %s", "-", "-")
+	}
+	license := m.License
+	if license == "" {
+		license = "-"
+	}
+	s := fmt.Sprintf(" %6d %-12s", m.Stars, license)
+	if m.Archived {
+		s += " archived"
+	}
+	return strings.TrimRight(s, " ")
+}
+
+// localGoMod resolves a repository list entry that refers to a local checkout.
+func localGoMod(entry string) (string, bool) {
+	info, err := os.Stat(entry)
+	if err != nil {
+		return "", false
+	}
+	if info.IsDir() {
+		return filepath.Join(entry, "go.mod"), true
+	}
+	return entry, true
+}
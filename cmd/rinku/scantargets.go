@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/scan"
+	"github.com/stephan/rinku/render"
+)
+
+// ScanTargets is the json and yaml output of scan with several target languages.
+type ScanTargets struct {
+	Languages []ScanLanguage `json:"languages"`
+}
+
+// ScanLanguage is the scan result of one target language.
+type ScanLanguage struct {
+	Language string       `json:"language"`
+	Result   *scan.Result `json:"result"`
+}
+
+// runTargets scans the manifest once per target language and renders the results
+// grouped by language, followed by the coverage of each.
+func (c *ScanCmd) runTargets(r *rinku.Rinku, targets []scanTarget) error {
+	data := &ScanTargets{Languages: make([]ScanLanguage, 0, len(targets))}
+	doc := &render.Document{
+		Command: "scan",
+		Columns: []string{"language", "dependency", "category", "equivalent", "url", "confidence"},
+		Data:    data,
+	}
+	names := make([]string, len(targets))
+	sections := make([]*render.Document, len(targets))
+	var coverage []render.Field
+	for i, t := range targets {
+		var section *render.Document
+		var err error
+		if c.SourceLang == "go" {
+			section, _, _, err = c.goModDocument(r, t)
+		} else {
+			section, err = c.manifestDocument(r, t)
+		}
+		if err != nil {
+			return err
+		}
+		result := section.Data.(*scan.Result)
+		names[i], sections[i] = t.name, section
+		data.Languages = append(data.Languages, ScanLanguage{Language: t.name, Result: result})
+		if i == 0 {
+			doc.Title = section.Title
+			// The fields of the manifest precede the counts, see scanDocument
+			for _, f := range section.Fields {
+				if f.Name == "Target language" || f.Name == "Direct dependencies" {
+					break
+				}
+				doc.Fields = append(doc.Fields, f)
+			}
+		}
+		for _, row := range section.Rows {
+			doc.Rows = append(doc.Rows, append([]string{t.name}, row...))
+		}
+		doc.Findings = append(doc.Findings, section.Findings...)
+		coverage = append(coverage, render.Field{Name: "Mapped to " + t.display, Value: fmt.Sprintf("%d/%d", result.Mapped, result.Direct)})
+	}
+	header := doc.Fields
+	doc.Fields = append(slices.Clone(header), render.Field{Name: "Target languages", Value: strings.Join(names, ", ")})
+	doc.Fields = append(doc.Fields, coverage...)
+	doc.Text = func(w io.Writer) error {
+		for _, f := range header {
+			fmt.Fprintf(w, "%s: %s\n", f.Name, f.Value)
+		}
+		for i, section := range sections {
+			fmt.Fprintf(w, "\n%s:\n", targets[i].display)
+			var buf bytes.Buffer
+			if err := section.Text(&buf); err != nil {
+				return err
+			}
+			// Each section starts with the header, and the target language unless rust
+			skip := len(header)
+			if targets[i].name != "rust" {
+				skip++
+			}
+			for _, line := range strings.SplitAfter(buf.String(), "\n")[skip:] {
+				switch line {
+				case "":
+				case "\n":
+					fmt.Fprint(w, line)
+				default:
+					fmt.Fprintf(w, "  %s", line)
+				}
+			}
+		}
+		fmt.Fprintf(w, "\nCoverage by language:\n")
+		for _, f := range coverage {
+			fmt.Fprintf(w, "  %-12s %s\n", strings.TrimPrefix(f.Name, "Mapped to "), f.Value)
+		}
+		return nil
+	}
+	if err := applySuppressions(doc); err != nil {
+		return err
+	}
+	return render.Render(os.Stdout, c.Format, doc)
+}
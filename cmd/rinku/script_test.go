@@ -0,0 +1,25 @@
+package main
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stephan/rinku/internal/clitest"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/script with the actual output")
+
+func TestMain(m *testing.M) {
+	clitest.Main(m, main)
+}
+
+// TestScript runs the CLI against the scripts in testdata/script. After an intended
+// output change, run go test ./cmd/rinku -run TestScript -update and review the diff.
+func TestScript(t *testing.T) {
+	clitest.Run(t, clitest.Params{
+		Dir:          "testdata/script",
+		Command:      "rinku",
+		Env:          []string{"RINKU_USER=test", "DO_NOT_TRACK=1"},
+		UpdateGolden: *update,
+	})
+}
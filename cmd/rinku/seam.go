@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/stephan/rinku/internal/scaffold"
+)
+
+type SeamCmd struct {
+	Path      string `arg:"" type:"existingfile" help:"Path to go.mod file."`
+	Package   string `arg:"" help:"Package to expose, as import path or directory relative to go.mod."`
+	Transport string `help:"Service protocol: http (axum, JSON) or grpc (tonic)." enum:"http,grpc" default:"http"`
+	CrateDir  string `help:"Directory for the Rust service crate, relative to go.mod (default: seam/<package>)."`
+	Force     bool   `help:"Overwrite existing files."`
+}
+
+func (c *SeamCmd) Run() error {
+	if c.CrateDir != "" {
+		if err := validateOutputPath(c.CrateDir); err != nil {
+			return err
+		}
+	}
+
+	pkg, root, err := loadScaffoldPackage(c.Path, c.Package)
+	if err != nil {
+		return err
+	}
+	seam, err := scaffold.GenerateSeam(pkg, scaffold.SeamOptions{
+		Transport: scaffold.Transport(c.Transport),
+		CrateDir:  filepath.ToSlash(c.CrateDir),
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeScaffold(root, seam.Files, c.Force); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nExposed %d functions from %s", len(seam.Bridged), pkg.ImportPath)
+	if len(seam.Skipped) > 0 {
+		fmt.Printf(", skipped %d:\n", len(seam.Skipped))
+		for _, s := range seam.Skipped {
+			fmt.Printf("  %s: %s\n", s.Func, s.Reason)
+		}
+	} else {
+		fmt.Println()
+	}
+	fmt.Printf("\nPort the handlers in src/main.rs and start the service with `cargo run`; SEAM_ADDR sets\n")
+	fmt.Printf("the listen address.")
+	if c.Transport == string(scaffold.TransportGRPC) {
+		fmt.Printf(" Run `go generate ./%s` for the gRPC stubs.", seam.ClientDir)
+	}
+	fmt.Printf(" Then switch callers to the client in %s one function at a time.\n", seam.ClientDir)
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/server"
+	"github.com/stephan/rinku/render"
+)
+
+type ServeCmd struct {
+	Addr   string `default:":8080" help:"Address to listen on."`
+	Unsafe bool   `help:"Include libraries with known vulnerabilities unless a request sets unsafe=false."`
+}
+
+func (c *ServeCmd) Run(r *rinku.Rinku) error {
+	logger := log.New(os.Stderr, "rinku serve: ", log.LstdFlags)
+	srv := &http.Server{
+		Addr:              c.Addr,
+		Handler:           &server.Handler{Lookup: r, Unsafe: c.Unsafe, APIVersion: render.APIVersion, Logger: logger},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	logger.Printf("listening on %s (web UI at /; /lookup, /reverse, /categories, /scan, /convert, /healthz, /metrics)", c.Addr)
+	if err := listenAndServe(srv); err != nil {
+		return fmt.Errorf("server: %w", err)
+	}
+	return nil
+}
+
+// listenAndServe runs srv until it fails or the process is interrupted, then shuts it
+// down gracefully.
+func listenAndServe(srv *http.Server) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
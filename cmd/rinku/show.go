@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/multistep"
+	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/internal/prompt"
+	"github.com/stephan/rinku/internal/requirements"
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/verify"
+	"github.com/stephan/rinku/render"
+)
+
+type MigrateShowCmd struct {
+	Step   string `arg:"" help:"Step ID to show."`
+	Format string `help:"Output format: text or json." enum:"text,json" default:"text"`
+}
+
+// StepView is a workflow step with everything an orchestrator needs to run it.
+type StepView struct {
+	ID            string            `json:"id"`
+	Index         int               `json:"index"` // 1-based position in the workflow
+	Total         int               `json:"total"`
+	Status        string            `json:"status"`
+	Estimate      string            `json:"estimate,omitempty"`
+	Content       string            `json:"content"`
+	Before        string            `json:"before,omitempty"`
+	After         string            `json:"after,omitempty"`
+	Prerequisites []StepRef         `json:"prerequisites"`
+	Next          string            `json:"next,omitempty"`
+	Gate          GateView          `json:"gate"`
+	Variables     map[string]string `json:"variables"`
+	Requirements  []RequirementRef  `json:"requirements"`
+	Artifacts     []string          `json:"artifacts,omitempty"`
+	Checklist     []ChecklistItem   `json:"checklist,omitempty"` // the "- [ ]" items of Content
+	Categories    []string          `json:"categories"`          // library tags the step works on
+	Dependencies  []StepDependency  `json:"dependencies"`        // go.mod dependencies matching Categories
+}
+
+// StepRef is another step and its status.
+type StepRef struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// GateView lists the requirement patterns that must be done to finish a step.
+type GateView struct {
+	Patterns []string `json:"patterns"`
+	Passes   bool     `json:"passes"`
+	Pending  []string `json:"pending,omitempty"`
+}
+
+// RequirementRef is a requirement associated with a step, either captured during it or
+// matched by its gate.
+type RequirementRef struct {
+	Path string `json:"path"`
+	Step string `json:"step,omitempty"` // step it was captured in
+	Done bool   `json:"done"`
+	Gate bool   `json:"gate"` // matched by the step's gate patterns
+}
+
+func (c *MigrateShowCmd) Run(r *rinku.Rinku) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	p, err := prompt.ForProject(cwd)
+	if err != nil {
+		return fmt.Errorf("failed to load migration prompt: %w", err)
+	}
+
+	view, err := buildStepView(r, cwd, p, c.Step)
+	if err != nil {
+		return err
+	}
+
+	if c.Format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(render.Versioned(render.APIVersion, "migrate show", view)); err != nil {
+			return fmt.Errorf("encoding step: %w", err)
+		}
+		return nil
+	}
+
+	writeStepOutput(os.Stdout, view.Before, view.Content, view.After, view.Categories, view.Dependencies)
+	return nil
+}
+
+// writeStepOutput writes a step as migrate --start prints it: the Before section, the
+// expanded content, the project dependencies of its categories and the After section.
+func writeStepOutput(w io.Writer, before, content, after string, categories []string, deps []StepDependency) {
+	if before != "" {
+		fmt.Fprintln(w, before)
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, content)
+	writeStepContext(w, categories, deps)
+	if after != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, after)
+	}
+}
+
+// buildStepView collects a step and its state without changing progress.
+func buildStepView(r *rinku.Rinku, cwd string, p *multistep.Prompt, id string) (*StepView, error) {
+	content, ok := p.GetStep(id)
+	if !ok {
+		return nil, fmt.Errorf("step '%s' not found", id)
+	}
+	content, err := expandStep(cwd, id, content)
+	if err != nil {
+		return nil, err
+	}
+	m, err := progress.Load(cwd)
+	if err != nil {
+		return nil, fmt.Errorf("loading progress: %w", err)
+	}
+	status := func(step string) string {
+		if m != nil {
+			if rec, ok := m.Steps[step]; ok {
+				return string(rec.Status)
+			}
+		}
+		return string(progress.StepPending)
+	}
+
+	order := p.Steps()
+	index := slices.Index(order, id)
+	view := &StepView{
+		ID:            id,
+		Index:         index + 1,
+		Total:         len(order),
+		Status:        status(id),
+		Content:       content,
+		Before:        p.Before(),
+		After:         p.After(),
+		Prerequisites: []StepRef{},
+		Gate:          GateView{Patterns: stepRequirementPaths[id]},
+		Variables:     stepVariables(cwd, id),
+		Requirements:  []RequirementRef{},
+		Categories:    p.Categories(id),
+		Dependencies:  []StepDependency{},
+	}
+	if view.Categories == nil {
+		view.Categories = []string{}
+	}
+	view.Dependencies = append(view.Dependencies, stepDependencies(r, cwd, view.Categories)...)
+	if d, ok := p.Estimate(id); ok {
+		view.Estimate = d.String()
+	}
+	if view.Gate.Patterns == nil {
+		view.Gate.Patterns = []string{}
+	}
+	// The workflow is linear: a step builds on the one before it.
+	if index > 0 {
+		view.Prerequisites = append(view.Prerequisites, StepRef{ID: order[index-1], Status: status(order[index-1])})
+	}
+	if index+1 < len(order) {
+		view.Next = order[index+1]
+	}
+
+	pending, err := gatePending(cwd, id)
+	if err != nil {
+		return nil, err
+	}
+	view.Gate.Pending = pending
+	view.Gate.Passes = len(pending) == 0
+
+	paths, err := requirements.List(cwd, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		gate := false
+		for _, pattern := range view.Gate.Patterns {
+			if verify.MatchPattern(pattern, path) {
+				gate = true
+			}
+		}
+		req, err := requirements.Get(cwd, path)
+		if err != nil {
+			return nil, err
+		}
+		if req == nil || (!gate && req.Step != id) {
+			continue
+		}
+		view.Requirements = append(view.Requirements, RequirementRef{Path: path, Step: req.Step, Done: req.Done, Gate: gate})
+	}
+
+	artifacts, err := progress.Artifacts(cwd, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range artifacts {
+		view.Artifacts = append(view.Artifacts, filepath.ToSlash(a.Path))
+	}
+	view.Checklist = stepChecklist(p, m, id)
+	return view, nil
+}
+
+// stepVariables are the project values available to step templates.
+func stepVariables(cwd, id string) map[string]string {
+	vars := map[string]string{
+		"step":        id,
+		"project_dir": cwd,
+	}
+	if result, err := gomod.Parse(filepath.Join(cwd, "go.mod")); err == nil {
+		vars["module"] = result.Module
+		vars["go_version"] = result.GoVersion
+	}
+	return vars
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/rinku"
+)
+
+// StepDependency is a go.mod dependency relevant to a step, with its Rust equivalents.
+type StepDependency struct {
+	Module   string   `json:"module"`
+	Tags     []string `json:"tags"`
+	Category string   `json:"category,omitempty"` // mapping category, empty if unmapped
+	Crates   []string `json:"crates"`             // empty if unmapped
+}
+
+// stepDependencies returns the direct dependencies of the go.mod in cwd with a tag
+// matching one of categories (path.Match patterns; "*" also matches untagged
+// dependencies). Projects without a readable go.mod have none.
+func stepDependencies(r *rinku.Rinku, cwd string, categories []string) []StepDependency {
+	if len(categories) == 0 {
+		return nil
+	}
+	result, err := gomod.Parse(filepath.Join(cwd, "go.mod"))
+	if err != nil {
+		return nil
+	}
+	var deps []StepDependency
+	for _, dep := range result.DirectDependencies() {
+		libURL := cargo.ModulePathToGitHubURL(dep.Path)
+		tags := r.Tags(libURL)
+		if !matchesCategories(categories, tags) {
+			continue
+		}
+		m := mapRust(r, dep.Path, libURL, false)
+		d := StepDependency{Module: dep.Path, Tags: tags, Category: m.Category, Crates: m.Targets}
+		if d.Tags == nil {
+			d.Tags = []string{}
+		}
+		if d.Crates == nil {
+			d.Crates = []string{}
+		}
+		deps = append(deps, d)
+	}
+	return deps
+}
+
+// matchesCategories reports whether any tag matches one of the patterns.
+func matchesCategories(patterns, tags []string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" {
+			return true
+		}
+		for _, tag := range tags {
+			if ok, _ := path.Match(pattern, tag); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeStepContext appends the dependency table shown with a step, so agents do not
+// have to run scan themselves. Nothing is written without dependencies.
+func writeStepContext(w io.Writer, categories []string, deps []StepDependency) {
+	if len(deps) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n## Project dependencies (%s)\n\n", strings.Join(categories, ", "))
+	fmt.Fprintln(w, "| Go module | Tags | Rust crates |")
+	fmt.Fprintln(w, "|---|---|---|")
+	for _, d := range deps {
+		crates := strings.Join(d.Crates, ", ")
+		if crates == "" {
+			crates = "(no mapping found, see `rinku lookup`)"
+		}
+		fmt.Fprintf(w, "| %s | %s | %s |\n", d.Module, strings.Join(d.Tags, ", "), crates)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/stephan/rinku/internal/requirements"
+)
+
+// expandStep executes the content of step id as a text/template. The step variables are
+// fields ({{.module}}) and {{requirements "api/*"}} inserts a checklist of the matching
+// requirements. Content without "{{" is returned unchanged.
+func expandStep(cwd, id, content string) (string, error) {
+	if !strings.Contains(content, "{{") {
+		return content, nil
+	}
+	tmpl, err := template.New(id).Option("missingkey=error").Funcs(template.FuncMap{
+		"requirements": func(pattern string) (string, error) {
+			return requirementsChecklist(cwd, pattern)
+		},
+	}).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("step %s: %w", id, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, stepVariables(cwd, id)); err != nil {
+		return "", fmt.Errorf("step %s: %w", id, err)
+	}
+	return b.String(), nil
+}
+
+// requirementsChecklist returns a Markdown checklist of the requirements matching
+// pattern, one "- [x] path: summary" line each, with the first line of the content as
+// summary.
+func requirementsChecklist(cwd, pattern string) (string, error) {
+	reqs, err := requirements.GetAll(cwd, pattern)
+	if err != nil {
+		return "", err
+	}
+	if len(reqs) == 0 {
+		return fmt.Sprintf("(no requirements match %s)", pattern), nil
+	}
+	var b strings.Builder
+	for i, req := range reqs {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		mark := "[ ]"
+		if req.Done {
+			mark = "[x]"
+		}
+		fmt.Fprintf(&b, "- %s %s", mark, req.Path)
+		if summary, _, _ := strings.Cut(strings.TrimSpace(req.Content), "\n"); summary != "" {
+			b.WriteString(": " + summary)
+		}
+	}
+	return b.String(), nil
+}
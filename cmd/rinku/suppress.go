@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/internal/suppress"
+	"github.com/stephan/rinku/render"
+)
+
+// applySuppressions marks the findings of doc accepted in the suppressions file of the
+// working directory, or by a "rinku:ignore" annotation in its go.mod, and lists them,
+// with expired entries, after the text output.
+func applySuppressions(doc *render.Document) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	s, sources, err := loadSuppressions(cwd)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return nil
+	}
+	doc.Findings = s.Apply(doc.Findings, time.Now())
+
+	var suppressed, expired []render.Finding
+	for _, f := range doc.Findings {
+		switch {
+		case f.Suppressed():
+			suppressed = append(suppressed, f)
+		case f.Rule == suppress.RuleExpired:
+			expired = append(expired, f)
+		}
+	}
+	if doc.Text == nil || len(suppressed)+len(expired) == 0 {
+		return nil
+	}
+	text := doc.Text
+	doc.Text = func(w io.Writer) error {
+		if err := text(w); err != nil {
+			return err
+		}
+		if len(suppressed) > 0 {
+			fmt.Fprintf(w, "\nSuppressed (%s):\n", strings.Join(sources, ", "))
+			for _, f := range suppressed {
+				fmt.Fprintf(w, "  %s\n    reason: %s\n", f.Message, f.Suppression)
+			}
+		}
+		for _, f := range expired {
+			fmt.Fprintf(w, "\nWarning: %s\n", f.Message)
+		}
+		return nil
+	}
+	return nil
+}
+
+// loadSuppressions returns the entries of the suppressions file of dir and the
+// "rinku:ignore" annotations of its go.mod, with the files they come from. It returns
+// nil if there are none.
+func loadSuppressions(dir string) (*suppress.Suppressions, []string, error) {
+	s, err := suppress.Load(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	var sources []string
+	if s != nil {
+		sources = append(sources, filepath.Join(progress.ProgressDir, suppress.File))
+	}
+	if ignores := goModIgnores(dir); len(ignores) > 0 {
+		if s == nil {
+			s = &suppress.Suppressions{}
+		}
+		s.Entries = append(s.Entries, ignores...)
+		sources = append(sources, "go.mod")
+	}
+	return s, sources, nil
+}
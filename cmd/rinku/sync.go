@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/stephan/rinku/internal/statesync"
+)
+
+type SyncCmd struct {
+	Remote SyncRemoteCmd `cmd:"" help:"Show or set the remote that .rinku state is pushed to."`
+	Push   SyncPushCmd   `cmd:"" help:"Push the .rinku state to the remote."`
+	Pull   SyncPullCmd   `cmd:"" help:"Restore the .rinku state from the remote."`
+}
+
+type SyncRemoteCmd struct {
+	URL string `arg:"" optional:"" help:"https:// endpoint, s3://bucket/key or git:<remote>[#branch]."`
+}
+
+type SyncPushCmd struct{}
+
+type SyncPullCmd struct{}
+
+// syncTimeout bounds a push or pull.
+const syncTimeout = 60 * time.Second
+
+func (c *SyncRemoteCmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	if c.URL == "" {
+		cfg, err := statesync.LoadConfig(cwd)
+		if err != nil {
+			return err
+		}
+		if cfg == nil {
+			fmt.Println("Sync is not configured.")
+			return nil
+		}
+		fmt.Println(cfg.Remote)
+		return nil
+	}
+
+	// Validate the remote before saving it.
+	if _, err := statesync.Open(c.URL, cwd); err != nil {
+		return err
+	}
+	if err := statesync.SaveConfig(cwd, &statesync.Config{Remote: c.URL}); err != nil {
+		return fmt.Errorf("saving sync config: %w", err)
+	}
+	fmt.Printf("Sync remote set to %s\n", c.URL)
+	return nil
+}
+
+func (c *SyncPushCmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+	defer cancel()
+	configured, err := statesync.Push(ctx, cwd)
+	if err != nil {
+		return err
+	}
+	if !configured {
+		return fmt.Errorf("sync is not configured\nHint: Run 'rinku sync remote <url>' first")
+	}
+	fmt.Println("Pushed .rinku state.")
+	return nil
+}
+
+func (c *SyncPullCmd) Run() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+	defer cancel()
+	n, err := statesync.Pull(ctx, cwd)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Pulled .rinku state (%d files).\n", n)
+	return nil
+}
+
+// autoSync pushes the state after a change if a remote is configured. A failed push
+// is reported but does not fail the command that changed the state.
+func autoSync(cwd string) {
+	ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+	defer cancel()
+	if _, err := statesync.Push(ctx, cwd); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: sync push failed: %v\n", err)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/telemetry"
+	"github.com/stephan/rinku/render"
+)
+
+type TelemetryCmd struct {
+	On     TelemetryOnCmd     `cmd:"" help:"Start counting commands and looked-up database entries."`
+	Off    TelemetryOffCmd    `cmd:"" help:"Stop counting and delete the counts not sent yet."`
+	Status TelemetryStatusCmd `cmd:"" help:"Show whether telemetry is on and the counts not sent yet."`
+}
+
+type TelemetryOnCmd struct{}
+
+type TelemetryOffCmd struct{}
+
+type TelemetryStatusCmd struct {
+	Format string `default:"text" help:"Output format: text, json, yaml, csv, markdown, html, sarif or porcelain."`
+}
+
+// telemetryTimeout bounds the report sent after a command.
+const telemetryTimeout = 2 * time.Second
+
+func (c *TelemetryOnCmd) Run() error {
+	if err := setTelemetry(true); err != nil {
+		return err
+	}
+	fmt.Println("Telemetry is on: rinku counts the commands you run (without arguments) and the")
+	fmt.Println("database entries your lookups find. No paths, module names or project details")
+	fmt.Println("are recorded. Run 'rinku telemetry status' to see the counts.")
+	if telemetry.Disabled() {
+		fmt.Printf("Note: %s is set, so nothing is recorded in this environment.\n", telemetry.DisableEnv)
+	}
+	return nil
+}
+
+func (c *TelemetryOffCmd) Run() error {
+	if err := setTelemetry(false); err != nil {
+		return err
+	}
+	fmt.Println("Telemetry is off; counts not sent yet were deleted.")
+	return nil
+}
+
+func setTelemetry(enabled bool) error {
+	path, err := telemetry.Path()
+	if err != nil {
+		return err
+	}
+	s, err := telemetry.Load(path)
+	if err != nil {
+		return err
+	}
+	s.SetEnabled(enabled, time.Now())
+	return s.Save(path)
+}
+
+func (c *TelemetryStatusCmd) Run() error {
+	path, err := telemetry.Path()
+	if err != nil {
+		return err
+	}
+	s, err := telemetry.Load(path)
+	if err != nil {
+		return err
+	}
+
+	status := "off"
+	switch {
+	case s.Enabled && telemetry.Disabled():
+		status = fmt.Sprintf("off (%s is set)", telemetry.DisableEnv)
+	case s.Enabled:
+		status = "on since " + s.Since.Format(time.DateOnly)
+	}
+	endpoint := telemetry.CurrentEndpoint()
+	if endpoint == "" {
+		endpoint = "none, counts stay on this machine"
+	}
+	doc := &render.Document{
+		Command: "telemetry",
+		Title:   "Telemetry",
+		Fields: []render.Field{
+			{Name: "Status", Value: status},
+			{Name: "Endpoint", Value: endpoint},
+			{Name: "State file", Value: path},
+		},
+		Columns: []string{"kind", "name", "count"},
+	}
+	if !s.SentAt.IsZero() {
+		doc.Fields = append(doc.Fields, render.Field{Name: "Last sent", Value: s.SentAt.Format(time.DateOnly)})
+	}
+	for _, kind := range []struct {
+		name   string
+		counts map[string]int
+	}{
+		{"command", s.Pending.Commands},
+		{"entry", s.Pending.Entries},
+		{"category", s.Pending.Categories},
+	} {
+		names := make([]string, 0, len(kind.counts))
+		for name := range kind.counts {
+			names = append(names, name)
+		}
+		// Most used first, ties by name
+		sort.Slice(names, func(i, j int) bool {
+			a, b := kind.counts[names[i]], kind.counts[names[j]]
+			return a > b || a == b && names[i] < names[j]
+		})
+		for _, name := range names {
+			doc.Rows = append(doc.Rows, []string{kind.name, name, strconv.Itoa(kind.counts[name])})
+		}
+	}
+	return render.Render(os.Stdout, c.Format, doc)
+}
+
+// openTelemetry returns the recorder of this process, nil if telemetry is off. Telemetry
+// never fails a command, so errors reading the state are ignored.
+func openTelemetry() *telemetry.Recorder {
+	path, err := telemetry.Path()
+	if err != nil {
+		return nil
+	}
+	rec, _ := telemetry.Open(path)
+	return rec
+}
+
+// observeTelemetry counts the database entries found by r's lookups in rec.
+func observeTelemetry(r *rinku.Rinku, rec *telemetry.Recorder) *rinku.Rinku {
+	if rec == nil {
+		return r
+	}
+	return r.Observe(func(targetLang, sourceURL string) {
+		rec.Lookup(targetLang, sourceURL, r.Category(sourceURL, targetLang))
+	})
+}
+
+// closeTelemetry saves the counts of this process and sends them if a report is due.
+func closeTelemetry(rec *telemetry.Recorder) {
+	ctx, cancel := context.WithTimeout(context.Background(), telemetryTimeout)
+	defer cancel()
+	_ = rec.Close(ctx, nil, time.Now())
+}
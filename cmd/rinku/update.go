@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/stephan/rinku/internal/database"
+	"github.com/stephan/rinku/internal/httpclient"
+)
+
+type UpdateCmd struct {
+	URL    string `help:"Base URL (https or file) of libs.json, mappings.json and checksums.txt; default $RINKU_DATABASE_URL or the latest release."`
+	Status bool   `help:"Show which database is in use instead of downloading."`
+	Remove bool   `help:"Delete the downloaded database and use the one built into the binary again."`
+}
+
+func (c *UpdateCmd) Run() error {
+	dir, err := database.Dir()
+	if err != nil {
+		return err
+	}
+	switch {
+	case c.Status:
+		db, err := database.Load(dir)
+		if err != nil {
+			return err
+		}
+		if db == nil {
+			fmt.Printf("Database: %s (built in)\n", dbVersion)
+			return nil
+		}
+		if !db.Newer(builtInReleased()) {
+			fmt.Printf("Database: %s (built in, %s)\n", dbVersion, released(builtInReleased()))
+			fmt.Printf("Downloaded: %s (%s) from %s, older than the built-in one\n", db.Version, released(db.Released), db.URL)
+			return nil
+		}
+		fmt.Printf("Database: %s (%d libraries, %d mappings)\n", db.Version, db.Libraries, db.Mappings)
+		fmt.Printf("Downloaded: %s from %s\n", db.UpdatedAt.Local().Format("2006-01-02 15:04"), db.URL)
+		fmt.Printf("Built in: %s\n", dbVersion)
+		return nil
+	case c.Remove:
+		removed, err := database.Remove(dir)
+		if err != nil {
+			return err
+		}
+		if removed {
+			fmt.Printf("Removed the downloaded database; using the built-in one (%s)\n", dbVersion)
+		} else {
+			fmt.Printf("No downloaded database; using the built-in one (%s)\n", dbVersion)
+		}
+		return nil
+	}
+
+	url := c.URL
+	if url == "" {
+		url = os.Getenv(database.URLEnv)
+	}
+	if url == "" {
+		url = database.URL
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	info, changed, err := database.Update(ctx, httpclient.Default(), url, dir, time.Now())
+	if err != nil {
+		return fmt.Errorf("updating the mapping database: %w", err)
+	}
+	if !changed {
+		fmt.Printf("The mapping database is up to date (%s)\n", info.Version)
+	} else {
+		fmt.Printf("Updated the mapping database to %s: %d libraries, %d mappings (built in: %s)\n", info.Version, info.Libraries, info.Mappings, dbVersion)
+	}
+	if !info.Newer(builtInReleased()) {
+		fmt.Printf("The built-in database (%s) is newer than the download (%s) and stays in use\n", released(builtInReleased()), released(info.Released))
+	}
+	return nil
+}
+
+// released formats the released time of a database.
+func released(t time.Time) string {
+	if t.IsZero() {
+		return "no release date"
+	}
+	return "released " + t.Format("2006-01-02")
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/internal/requirements"
+	"github.com/stephan/rinku/internal/schema"
+)
+
+type ReqValidateCmd struct {
+	Schema bool `help:"Print the requirement JSON schema instead of checking the files."`
+}
+
+type MigrateValidateStateCmd struct {
+	Schema bool `help:"Print the progress JSON schema instead of checking the files."`
+}
+
+// stateReport collects the files checked against their schema and the violations found.
+type stateReport struct {
+	files    int
+	invalid  map[string]bool
+	problems []string
+}
+
+func (c *ReqValidateCmd) Run() error {
+	if c.Schema {
+		_, err := os.Stdout.Write(schema.Document(schema.Requirement))
+		return err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	var r stateReport
+	if err := r.requirements(cwd); err != nil {
+		return err
+	}
+	return r.print()
+}
+
+func (c *MigrateValidateStateCmd) Run() error {
+	if c.Schema {
+		_, err := os.Stdout.Write(schema.Document(schema.Progress))
+		return err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	var r stateReport
+	if err := r.progress(cwd); err != nil {
+		return err
+	}
+	if err := r.requirements(cwd); err != nil {
+		return err
+	}
+	return r.print()
+}
+
+// check validates the file at path against a schema. It returns false if the file
+// does not match, so callers skip the checks that decode it.
+func (r *stateReport) check(path, name string, data []byte) bool {
+	r.files++
+	err := schema.Validate(name, data)
+	if err == nil {
+		return true
+	}
+	var se *schema.Error
+	if !errors.As(err, &se) {
+		r.addf(path, "%v", err)
+		return false
+	}
+	for _, v := range se.Violations {
+		r.addf(path, "%s", v)
+	}
+	return false
+}
+
+func (r *stateReport) addf(path, format string, args ...any) {
+	if r.invalid == nil {
+		r.invalid = make(map[string]bool)
+	}
+	r.invalid[path] = true
+	r.problems = append(r.problems, relPath(path)+": "+fmt.Sprintf(format, args...))
+}
+
+// progress checks progress.json, if there is one, and that its steps agree with the
+// step order.
+func (r *stateReport) progress(cwd string) error {
+	path := progress.ProgressPath(cwd)
+	data, err := os.ReadFile(path) //#nosec G304 -- cwd from os.Getwd()
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading progress: %w", err)
+	}
+	if !r.check(path, schema.Progress, data) {
+		return nil
+	}
+	var m progress.Migration
+	if err := json.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf("parsing progress: %w", err)
+	}
+	for _, id := range slices.Sorted(maps.Keys(m.Steps)) {
+		if step := m.Steps[id]; step.ID != id {
+			r.addf(path, "/steps/%s/id: is %q, but the step is recorded as %q", id, step.ID, id)
+		}
+	}
+	for i, id := range m.StepOrder {
+		if m.Steps[id] == nil {
+			r.addf(path, "/step_order/%d: step %q has no record in steps", i, id)
+		}
+	}
+	if m.CurrentStep != "" && m.Steps[m.CurrentStep] == nil {
+		r.addf(path, "/current_step: step %q has no record in steps", m.CurrentStep)
+	}
+	return nil
+}
+
+// requirements checks every requirement file and that it records the path it is
+// stored under.
+func (r *stateReport) requirements(cwd string) error {
+	paths, err := requirements.List(cwd, "")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(cwd, progress.ProgressDir, requirements.RequirementsDir)
+	for _, reqPath := range paths {
+		path := filepath.Join(dir, filepath.FromSlash(reqPath)+".json")
+		data, err := os.ReadFile(path) //#nosec G304 -- listed below the requirements directory
+		if err != nil {
+			return fmt.Errorf("reading requirement: %w", err)
+		}
+		if !r.check(path, schema.Requirement, data) {
+			continue
+		}
+		var req requirements.Requirement
+		if err := json.Unmarshal(data, &req); err != nil {
+			return fmt.Errorf("parsing requirement: %w", err)
+		}
+		if got := requirements.NormalizePath(req.Path); got != reqPath {
+			r.addf(path, "/path: is %q, but the file is stored as %q", req.Path, reqPath)
+		}
+	}
+	return nil
+}
+
+func (r *stateReport) print() error {
+	for _, p := range r.problems {
+		fmt.Println(p)
+	}
+	if len(r.problems) > 0 {
+		return fmt.Errorf("%d of %d files do not match their schema", len(r.invalid), r.files)
+	}
+	fmt.Printf("Checked %d files: all valid\n", r.files)
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/webhook"
+)
+
+type WebhookCmd struct {
+	Addr   string `default:":8080" help:"Address to listen on."`
+	Path   string `default:"/webhook" help:"URL path GitHub delivers events to."`
+	Unsafe bool   `help:"Include libraries with known vulnerabilities."`
+}
+
+func (c *WebhookCmd) Run(r *rinku.Rinku) error {
+	secret := os.Getenv("RINKU_WEBHOOK_SECRET")
+	if secret == "" {
+		return errors.New("RINKU_WEBHOOK_SECRET must be set to the webhook secret configured on GitHub")
+	}
+	gh, tokenSource := githubClient()
+	if tokenSource == "" {
+		return errors.New("GITHUB_TOKEN (or [github] token in .rinku.toml) must be set to a token that can read contents and write comments")
+	}
+	logger := log.New(os.Stderr, "rinku webhook: ", log.LstdFlags)
+
+	mux := http.NewServeMux()
+	mux.Handle(c.Path, &webhook.Handler{
+		Secret: []byte(secret),
+		GitHub: gh,
+		Lookup: r,
+		Unsafe: c.Unsafe,
+		Logger: logger,
+	})
+	srv := &http.Server{
+		Addr:              c.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	logger.Printf("listening on %s%s", c.Addr, c.Path)
+	if err := listenAndServe(srv); err != nil {
+		return fmt.Errorf("webhook server: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/stephan/rinku/internal/prompt"
+	"github.com/stephan/rinku/render"
+)
+
+type WorkflowsCmd struct {
+	List WorkflowsListCmd `cmd:"" help:"List the embedded workflow and the workflows in RINKU_PROMPTS_DIR."`
+}
+
+type WorkflowsListCmd struct {
+	Format string `default:"text" help:"Output format: text, json, yaml, csv, markdown, html, sarif or porcelain."`
+}
+
+func (c *WorkflowsListCmd) Run() error {
+	workflows, err := prompt.Catalog()
+	if err != nil {
+		return err
+	}
+	doc := &render.Document{
+		Command: "workflows",
+		Title:   "Workflows",
+		Columns: []string{"name", "steps", "description", "path"},
+		Data:    workflows,
+	}
+	for _, w := range workflows {
+		doc.Rows = append(doc.Rows, []string{w.Name, strconv.Itoa(w.Steps), w.Description, relPath(w.Path)})
+	}
+	doc.Text = func(w io.Writer) error {
+		for _, wf := range workflows {
+			steps := "steps"
+			if wf.Steps == 1 {
+				steps = "step"
+			}
+			fmt.Fprintf(w, "%s (%d %s)\n", wf.Name, wf.Steps, steps)
+			if wf.Description != "" {
+				fmt.Fprintf(w, "  %s\n", wf.Description)
+			}
+		}
+		if os.Getenv(prompt.CatalogEnv) == "" {
+			fmt.Fprintf(w, "\nHint: Set %s to a directory of workflow markdown files to add your own.\n", prompt.CatalogEnv)
+		} else {
+			fmt.Fprintln(w, "\nStart a migration with one: rinku migrate --start <step> --workflow <name>")
+		}
+		return nil
+	}
+	return render.Render(os.Stdout, c.Format, doc)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/stephan/rinku/internal/config"
+	"github.com/stephan/rinku/internal/workspace"
+	"github.com/stephan/rinku/render"
+)
+
+type WorkspaceCmd struct {
+	Dir        string `arg:"" optional:"" type:"existingdir" help:"Repository root of the Go monorepo (default: cwd)."`
+	Prefix     string `help:"Prefix of every crate name (overrides [crates] prefix in .rinku.toml)."`
+	Case       string `enum:",kebab,snake" default:"" help:"Case style of crate names: kebab or snake."`
+	Collisions string `enum:",parent,suffix,error" default:"" help:"Resolve equal names by prepending parent directories, numbering them or failing."`
+	Internal   bool   `help:"Also give each module's internal/<pkg> packages their own crate."`
+	Format     string `default:"text" help:"Output format: text, json, yaml, csv, markdown, html, sarif or porcelain."`
+}
+
+// Run previews the crates and workspace manifest of a Go monorepo without writing
+// anything. Flags override the [crates] policy of the .rinku.toml in the root.
+func (c *WorkspaceCmd) Run() error {
+	dir := c.Dir
+	if dir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("getting current directory: %w", err)
+		}
+		dir = cwd
+	}
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return err
+	}
+	policy := cfg.Crates
+	if c.Prefix != "" {
+		policy.Prefix = c.Prefix
+	}
+	if c.Case != "" {
+		policy.Case = c.Case
+	}
+	if c.Collisions != "" {
+		policy.Collisions = c.Collisions
+	}
+	policy.Internal = policy.Internal || c.Internal
+
+	units, err := workspace.Discover(dir, policy.Internal)
+	if err != nil {
+		return err
+	}
+	if len(units) == 0 {
+		return fmt.Errorf("no go.mod found below %s", dir)
+	}
+	layout, err := workspace.Build(units, policy)
+	if err != nil {
+		return err
+	}
+	return render.Render(os.Stdout, c.Format, workspaceDocument(layout))
+}
+
+// workspaceDocument returns one row per crate; json and yaml write the layout, text
+// also the workspace Cargo.toml.
+func workspaceDocument(l *workspace.Layout) *render.Document {
+	doc := &render.Document{
+		Command: "workspace",
+		Title:   "Workspace",
+		Fields: []render.Field{
+			{Name: "Crates", Value: fmt.Sprint(len(l.Crates))},
+			{Name: "Policy", Value: fmt.Sprintf("prefix %q, case %s, collisions %s", l.Policy.Prefix, l.Policy.Case, l.Policy.Collisions)},
+		},
+		Columns: []string{"crate", "kind", "path", "go", "dir", "deps"},
+		Data:    l,
+	}
+	for _, cr := range l.Crates {
+		doc.Rows = append(doc.Rows, []string{cr.Name, string(cr.Unit.Kind), cr.Path, cr.Unit.GoPath, cr.Unit.Dir, strings.Join(cr.Deps, ", ")})
+	}
+	doc.Text = func(w io.Writer) error {
+		fmt.Fprintf(w, "%-28s %-4s %-40s %s\n", "CRATE", "KIND", "GO", "DIR")
+		for _, cr := range l.Crates {
+			fmt.Fprintf(w, "%-28s %-4s %-40s %s\n", cr.Name, cr.Unit.Kind, cr.Unit.GoPath, cr.Unit.Dir)
+			if len(cr.Deps) > 0 {
+				fmt.Fprintf(w, "%-28s uses %s\n", "", strings.Join(cr.Deps, ", "))
+			}
+		}
+		fmt.Fprintf(w, "\nCargo.toml (preview, nothing written):\n\n%s", l.CargoToml())
+		return nil
+	}
+	return doc
+}
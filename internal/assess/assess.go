@@ -0,0 +1,86 @@
+// Package assess estimates the effort of porting a Go project to Rust from what a scan
+// and a source analysis find, for a first look at a candidate repository.
+package assess
+
+import "math"
+
+// Hours per unit of each effort driver. They are deliberately coarse: the estimate
+// ranks candidates and sizes a first conversation, it does not plan a migration.
+const (
+	SourceLinesPerHour = 60   // hand-ported Go code, including review
+	TestLinesPerHour   = 120  // tests port faster than the code they cover
+	HoursPerUnmapped   = 6.0  // choosing, prototyping or writing a replacement
+	HoursPerRiskFile   = 4.0  // redesigning reflect, unsafe, linkname or runtime tricks
+	HoursPerCgoFile    = 12.0 // binding the C code through a -sys crate or rewriting it
+	HoursPerGenerator  = 3.0  // replacing a go:generate step with a build script or macro
+	HoursPerDay        = 6.0  // focused engineering hours in a working day
+	WorkflowHours      = 8.0  // setup, requirements capture and final verification
+)
+
+// Inputs are the counts the estimate is based on. Generated code is not ported and is
+// excluded from the line counts; its generators are counted instead.
+type Inputs struct {
+	SourceLines int
+	TestLines   int
+	Unmapped    int // direct dependencies without a Rust mapping
+	RiskFiles   int // files with reflect, unsafe, linkname or runtime tricks, but no cgo
+	CgoFiles    int
+	Generators  int // go:generate directives
+}
+
+// Item is one effort driver of an estimate.
+type Item struct {
+	Name  string  `json:"name"`
+	Count int     `json:"count"`
+	Hours float64 `json:"hours"`
+}
+
+// Estimate is the effort of a port.
+type Estimate struct {
+	Items []Item  `json:"items"`
+	Hours float64 `json:"hours"`
+	Days  float64 `json:"days"`
+	Size  string  `json:"size"` // S, M, L or XL
+}
+
+// Effort estimates the hours to port a project: a fixed workflow overhead plus the
+// hours of every driver with a non-zero count.
+func Effort(in Inputs) Estimate {
+	e := Estimate{Items: []Item{{Name: "workflow", Count: 1, Hours: WorkflowHours}}}
+	add := func(name string, count int, hours float64) {
+		if count > 0 {
+			e.Items = append(e.Items, Item{Name: name, Count: count, Hours: round(hours)})
+		}
+	}
+	add("source lines", in.SourceLines, float64(in.SourceLines)/SourceLinesPerHour)
+	add("test lines", in.TestLines, float64(in.TestLines)/TestLinesPerHour)
+	add("unmapped dependencies", in.Unmapped, float64(in.Unmapped)*HoursPerUnmapped)
+	add("high-risk files", in.RiskFiles, float64(in.RiskFiles)*HoursPerRiskFile)
+	add("cgo files", in.CgoFiles, float64(in.CgoFiles)*HoursPerCgoFile)
+	add("go:generate directives", in.Generators, float64(in.Generators)*HoursPerGenerator)
+
+	for _, item := range e.Items {
+		e.Hours += item.Hours
+	}
+	e.Days = round(e.Hours / HoursPerDay)
+	e.Size = size(e.Days)
+	return e
+}
+
+// size buckets an estimate: up to a week, a month, a quarter, or more.
+func size(days float64) string {
+	switch {
+	case days <= 5:
+		return "S"
+	case days <= 20:
+		return "M"
+	case days <= 60:
+		return "L"
+	}
+	return "XL"
+}
+
+// round rounds to half hours (or days).
+func round(hours float64) float64 {
+	return math.Round(hours*2) / 2
+}
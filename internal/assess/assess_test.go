@@ -0,0 +1,43 @@
+package assess
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEffort(t *testing.T) {
+	e := Effort(Inputs{SourceLines: 6000, TestLines: 1200, Unmapped: 2, RiskFiles: 1, CgoFiles: 1, Generators: 3})
+	want := []Item{
+		{Name: "workflow", Count: 1, Hours: 8},
+		{Name: "source lines", Count: 6000, Hours: 100},
+		{Name: "test lines", Count: 1200, Hours: 10},
+		{Name: "unmapped dependencies", Count: 2, Hours: 12},
+		{Name: "high-risk files", Count: 1, Hours: 4},
+		{Name: "cgo files", Count: 1, Hours: 12},
+		{Name: "go:generate directives", Count: 3, Hours: 9},
+	}
+	if !reflect.DeepEqual(e.Items, want) {
+		t.Errorf("Items =\n%+v\nwant\n%+v", e.Items, want)
+	}
+	if e.Hours != 155 || e.Days != 26 || e.Size != "L" {
+		t.Errorf("Hours, Days, Size = %g, %g, %s; want 155, 26, L", e.Hours, e.Days, e.Size)
+	}
+}
+
+func TestEffort_Small(t *testing.T) {
+	e := Effort(Inputs{SourceLines: 100})
+	if len(e.Items) != 2 {
+		t.Errorf("Items = %+v, want workflow and source lines only", e.Items)
+	}
+	if e.Hours != 9.5 || e.Size != "S" {
+		t.Errorf("Hours, Size = %g, %s; want 9.5, S", e.Hours, e.Size)
+	}
+}
+
+func TestSize(t *testing.T) {
+	for days, want := range map[float64]string{0: "S", 5: "S", 5.5: "M", 20: "M", 60: "L", 61: "XL"} {
+		if got := size(days); got != want {
+			t.Errorf("size(%g) = %s, want %s", days, got, want)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package audit
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+// Kinds of exported API items.
+const (
+	APIFunc   = "func"
+	APIType   = "type"
+	APIMethod = "method"
+	APIConst  = "const"
+	APIVar    = "var"
+)
+
+// APIPackage is an importable package of a library with its exported API.
+type APIPackage struct {
+	Name  string
+	Dir   string // slash-separated, relative to the module root
+	Doc   string // first sentence of the package comment
+	Items []APIItem
+}
+
+// APIItem is an exported function, type, method, constant or variable.
+type APIItem struct {
+	Kind      string
+	Name      string // Type.Method for methods
+	Signature string // declaration without body, e.g. func New(addr string) (*Client, error)
+	Doc       string // first sentence of the doc comment
+	File      string
+	Line      int
+}
+
+// PublicAPI returns the exported API of every importable package: main packages,
+// internal packages and test files are skipped. Methods are listed for exported types
+// only. Packages are sorted by directory, items by name.
+func PublicAPI(tree *gosrc.Tree) []APIPackage {
+	pkgs := make(map[string]*APIPackage)
+	for _, f := range tree.Files {
+		dir := path.Dir(f.Path)
+		if f.Test || f.Package == "main" || isInternal(dir) {
+			continue
+		}
+		pkg := pkgs[dir]
+		if pkg == nil {
+			pkg = &APIPackage{Name: f.Package, Dir: dir}
+			pkgs[dir] = pkg
+		}
+		if f.AST.Doc != nil && pkg.Doc == "" {
+			pkg.Doc = firstSentence(f.AST.Doc.Text())
+		}
+		for _, decl := range f.AST.Decls {
+			pkg.Items = append(pkg.Items, apiItems(tree, decl)...)
+		}
+	}
+
+	var result []APIPackage
+	for _, pkg := range pkgs {
+		if len(pkg.Items) == 0 {
+			continue
+		}
+		sort.Slice(pkg.Items, func(i, j int) bool { return pkg.Items[i].Name < pkg.Items[j].Name })
+		result = append(result, *pkg)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Dir < result[j].Dir })
+	return result
+}
+
+func isInternal(dir string) bool {
+	for _, part := range strings.Split(dir, "/") {
+		if part == "internal" {
+			return true
+		}
+	}
+	return false
+}
+
+func apiItems(tree *gosrc.Tree, decl ast.Decl) []APIItem {
+	var items []APIItem
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		item := APIItem{Kind: APIFunc, Name: d.Name.Name, Doc: docSentence(d.Doc)}
+		if d.Recv != nil && len(d.Recv.List) > 0 {
+			recv := receiverName(d.Recv.List[0].Type)
+			if !ast.IsExported(recv) {
+				return nil
+			}
+			item.Kind, item.Name = APIMethod, recv+"."+d.Name.Name
+		}
+		if !d.Name.IsExported() {
+			return nil
+		}
+		sig := *d
+		sig.Body, sig.Doc = nil, nil
+		item.Signature = printNode(tree.Fset, &sig)
+		item.File, item.Line = tree.Position(d.Pos())
+		items = append(items, item)
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				if !s.Name.IsExported() {
+					continue
+				}
+				doc := s.Doc
+				if doc == nil {
+					doc = d.Doc
+				}
+				item := APIItem{Kind: APIType, Name: s.Name.Name, Doc: docSentence(doc), Signature: "type " + s.Name.Name + " " + typeKind(s)}
+				item.File, item.Line = tree.Position(s.Pos())
+				items = append(items, item)
+			case *ast.ValueSpec:
+				kind := APIVar
+				if d.Tok == token.CONST {
+					kind = APIConst
+				}
+				doc := s.Doc
+				if doc == nil {
+					doc = d.Doc
+				}
+				for _, name := range s.Names {
+					if !name.IsExported() {
+						continue
+					}
+					sig := kind + " " + name.Name
+					if s.Type != nil {
+						sig += " " + printNode(tree.Fset, s.Type)
+					}
+					item := APIItem{Kind: kind, Name: name.Name, Doc: docSentence(doc), Signature: sig}
+					item.File, item.Line = tree.Position(name.Pos())
+					items = append(items, item)
+				}
+			}
+		}
+	}
+	return items
+}
+
+// typeKind describes a type declaration without its body: struct, interface, = alias
+// or the underlying type.
+func typeKind(s *ast.TypeSpec) string {
+	if s.Assign.IsValid() {
+		return "= " + exprString(s.Type)
+	}
+	switch t := s.Type.(type) {
+	case *ast.StructType:
+		return "struct"
+	case *ast.InterfaceType:
+		return "interface"
+	case *ast.FuncType:
+		return "func"
+	default:
+		if name := exprString(t); name != "" {
+			return name
+		}
+		return "type"
+	}
+}
+
+func printNode(fset *token.FileSet, node any) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, fset, node)
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
+
+func docSentence(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	return firstSentence(doc.Text())
+}
+
+// firstSentence returns the text up to the first period followed by a space or the end,
+// on one line.
+func firstSentence(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if i := strings.Index(text, ". "); i >= 0 {
+		return text[:i+1]
+	}
+	return text
+}
+
+// RequirementPath returns the requirement of the package, pkg/<dir>, with the package
+// name for the module root.
+func (p *APIPackage) RequirementPath() string {
+	if p.Dir == "." {
+		return "pkg/" + p.Name
+	}
+	return "pkg/" + p.Dir
+}
+
+// Requirements returns a requirement for the package and for each of its items, such
+// as pkg/client and pkg/client/Client.Do.
+func (p *APIPackage) Requirements() []SurfaceRequirement {
+	content := p.Doc
+	if content == "" {
+		content = "Package " + p.Name + "."
+	}
+	counts := make(map[string]int)
+	for _, item := range p.Items {
+		counts[item.Kind]++
+	}
+	var parts []string
+	for _, kind := range []string{APIType, APIFunc, APIMethod, APIConst, APIVar} {
+		if counts[kind] > 0 {
+			label := kind
+			if counts[kind] > 1 {
+				label += "s"
+			}
+			parts = append(parts, fmt.Sprintf("%d %s", counts[kind], label))
+		}
+	}
+	reqs := []SurfaceRequirement{{
+		Path:    p.RequirementPath(),
+		Content: content + "\nExports: " + strings.Join(parts, ", "),
+	}}
+	for _, item := range p.Items {
+		c := item.Signature
+		if item.Doc != "" {
+			c += "\n" + item.Doc
+		}
+		reqs = append(reqs, SurfaceRequirement{
+			Path:    p.RequirementPath() + "/" + item.Name,
+			Content: c + fmt.Sprintf("\nSource: %s:%d", item.File, item.Line),
+		})
+	}
+	return reqs
+}
@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPublicAPI(t *testing.T) {
+	tree := parseTree(t, map[string]string{
+		"widget.go": `// Package widget renders widgets. It is fast.
+package widget
+
+// DefaultSize is used when no size is given.
+const DefaultSize = 10
+
+var ErrClosed error
+
+// Widget is a drawable thing.
+type Widget struct{ size int }
+
+type Size = int
+
+type options struct{}
+
+// New creates a widget.
+func New(size int) (*Widget, error) { return &Widget{size}, nil }
+
+// Draw renders the widget. It never fails.
+func (w *Widget) Draw() string { return "" }
+
+func (w *Widget) reset() {}
+
+func (o options) Apply() {}
+
+func helper() {}
+`,
+		"client/client.go": `package client
+
+type Client interface{ Do() error }
+`,
+		"internal/x/x.go": `package x
+
+func Exported() {}
+`,
+		"cmd/tool/main.go": `package main
+
+func Exported() {}
+`,
+		"widget_test.go": `package widget
+
+func TestHelper() {}
+`,
+	})
+
+	pkgs := PublicAPI(tree)
+	if len(pkgs) != 2 || pkgs[0].Dir != "." || pkgs[1].Dir != "client" {
+		t.Fatalf("packages = %+v", pkgs)
+	}
+	widget := pkgs[0]
+	if widget.Doc != "Package widget renders widgets." {
+		t.Errorf("package doc = %q", widget.Doc)
+	}
+
+	var items []string
+	for _, item := range widget.Items {
+		items = append(items, item.Kind+" "+item.Name)
+	}
+	want := []string{"const DefaultSize", "var ErrClosed", "func New", "type Size", "type Widget", "method Widget.Draw"}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("items = %v, want %v", items, want)
+	}
+	sigs := make(map[string]string)
+	for _, item := range widget.Items {
+		sigs[item.Name] = item.Signature
+	}
+	if got := sigs["New"]; got != "func New(size int) (*Widget, error)" {
+		t.Errorf("New signature = %q", got)
+	}
+	if got := sigs["Widget.Draw"]; got != "func (w *Widget) Draw() string" {
+		t.Errorf("Draw signature = %q", got)
+	}
+	if sigs["Size"] != "type Size = int" || sigs["Widget"] != "type Widget struct" || sigs["ErrClosed"] != "var ErrClosed error" {
+		t.Errorf("signatures = %v", sigs)
+	}
+
+	reqs := widget.Requirements()
+	if reqs[0].Path != "pkg/widget" || reqs[0].Content != "Package widget renders widgets.\nExports: 2 types, 1 func, 1 method, 1 const, 1 var" {
+		t.Errorf("package requirement = %+v", reqs[0])
+	}
+	if reqs[6].Path != "pkg/widget/Widget.Draw" || reqs[6].Content != "func (w *Widget) Draw() string\nDraw renders the widget.\nSource: widget.go:20" {
+		t.Errorf("method requirement = %+v", reqs[6])
+	}
+	if got := pkgs[1].Requirements()[1].Path; got != "pkg/client/Client" {
+		t.Errorf("client requirement = %q", got)
+	}
+}
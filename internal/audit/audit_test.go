@@ -0,0 +1,28 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+func parseTree(t *testing.T, files map[string]string) *gosrc.Tree {
+	t.Helper()
+	dir := t.TempDir()
+	for rel, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tree, err := gosrc.Parse(dir)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return tree
+}
@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"go/ast"
+	"strings"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+// Generator is a //go:generate directive; the generated code is rebuilt in Rust by a
+// build script or macro rather than ported.
+type Generator struct {
+	File    string
+	Line    int
+	Command string // directive arguments, e.g. "stringer -type=Color"
+	Tool    string // first word of Command with "go run" resolved, e.g. stringer
+}
+
+// Codegen returns the go:generate directives of the tree and the files marked
+// "Code generated ... DO NOT EDIT.", both in file order.
+func Codegen(tree *gosrc.Tree) (generators []Generator, generated []string) {
+	for _, f := range tree.Files {
+		if ast.IsGenerated(f.AST) {
+			generated = append(generated, f.Path)
+		}
+		for _, group := range f.AST.Comments {
+			for _, c := range group.List {
+				command, ok := strings.CutPrefix(c.Text, "//go:generate ")
+				if !ok {
+					continue
+				}
+				command = strings.TrimSpace(command)
+				_, line := tree.Position(c.Pos())
+				generators = append(generators, Generator{File: f.Path, Line: line, Command: command, Tool: generatorTool(command)})
+			}
+		}
+	}
+	return generators, generated
+}
+
+// generatorTool returns the program a directive runs: the last element of the package,
+// without a major version, for "go run pkg@version", otherwise the first word.
+func generatorTool(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) >= 3 && fields[0] == "go" && fields[1] == "run" {
+		for _, arg := range fields[2:] {
+			if strings.HasPrefix(arg, "-") {
+				continue
+			}
+			arg, _, _ = strings.Cut(arg, "@")
+			elems := strings.Split(strings.TrimRight(arg, "/"), "/")
+			last := elems[len(elems)-1]
+			if len(elems) > 1 && len(last) > 1 && last[0] == 'v' && strings.Trim(last[1:], "0123456789") == "" {
+				last = elems[len(elems)-2]
+			}
+			return last
+		}
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCodegen(t *testing.T) {
+	tree := parseTree(t, map[string]string{
+		"color/color.go": `package color
+
+//go:generate stringer -type=Color
+type Color int
+
+//go:generate go run github.com/vektra/mockery/v2@v2.40.1 --name=Store
+`,
+		"color/color_string.go": `// Code generated by "stringer -type=Color"; DO NOT EDIT.
+
+package color
+`,
+		"api/gen.go": `package api
+
+//go:generate go run ../tools/oapi
+`,
+	})
+
+	generators, generated := Codegen(tree)
+	want := []Generator{
+		{File: "api/gen.go", Line: 3, Command: "go run ../tools/oapi", Tool: "oapi"},
+		{File: "color/color.go", Line: 3, Command: "stringer -type=Color", Tool: "stringer"},
+		{File: "color/color.go", Line: 6, Command: "go run github.com/vektra/mockery/v2@v2.40.1 --name=Store", Tool: "mockery"},
+	}
+	if !reflect.DeepEqual(generators, want) {
+		t.Errorf("generators =\n%+v\nwant\n%+v", generators, want)
+	}
+	if !reflect.DeepEqual(generated, []string{"color/color_string.go"}) {
+		t.Errorf("generated = %v", generated)
+	}
+}
@@ -0,0 +1,144 @@
+package audit
+
+import (
+	"go/ast"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+// Primitive is a Go concurrency primitive. Its value is the name of the matching entry
+// in the idiom database, so `rinku idiom <primitive>` shows the Rust equivalent.
+type Primitive string
+
+const (
+	Goroutine Primitive = "goroutine"
+	Channel   Primitive = "channel"
+	Select    Primitive = "select"
+	Mutex     Primitive = "mutex"
+	RWMutex   Primitive = "rwmutex"
+	WaitGroup Primitive = "waitgroup"
+	Once      Primitive = "once"
+	Atomic    Primitive = "atomic"
+	ErrGroup  Primitive = "errgroup"
+)
+
+// Primitives lists all primitives in report order.
+var Primitives = []Primitive{Goroutine, Channel, Select, Mutex, RWMutex, WaitGroup, Once, Atomic, ErrGroup}
+
+// weights reflect how much redesign a use usually needs: goroutines, channels and selects
+// shape the architecture (async runtime, ownership across tasks), locks map more directly.
+var weights = map[Primitive]int{
+	Goroutine: 3,
+	Channel:   2,
+	Select:    3,
+	Mutex:     1,
+	RWMutex:   1,
+	WaitGroup: 1,
+	Once:      1,
+	Atomic:    1,
+	ErrGroup:  2,
+}
+
+// PackageConcurrency counts the concurrency primitives used in one package.
+type PackageConcurrency struct {
+	Dir     string // slash-separated, relative to the module root
+	Package string
+	Counts  map[Primitive]int
+}
+
+// Score is the weighted sum of the counts, a rough measure of concurrency complexity.
+func (p *PackageConcurrency) Score() int {
+	score := 0
+	for prim, n := range p.Counts {
+		score += weights[prim] * n
+	}
+	return score
+}
+
+// Summary returns the counts in report order, e.g. "goroutine ×2, channel ×1".
+func (p *PackageConcurrency) Summary() string {
+	var parts []string
+	for _, prim := range Primitives {
+		if n := p.Counts[prim]; n > 0 {
+			parts = append(parts, string(prim)+" ×"+strconv.Itoa(n))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Concurrency counts goroutine launches, channel types, selects and sync, sync/atomic and
+// errgroup usages per package in non-test files. Packages without any are left out; the
+// rest are sorted by score, highest first.
+func Concurrency(tree *gosrc.Tree) []PackageConcurrency {
+	byDir := make(map[string]*PackageConcurrency)
+	for _, f := range tree.Files {
+		if f.Test {
+			continue
+		}
+		dir := path.Dir(f.Path)
+		pc := byDir[dir]
+		if pc == nil {
+			pc = &PackageConcurrency{Dir: dir, Package: f.Package, Counts: make(map[Primitive]int)}
+			byDir[dir] = pc
+		}
+
+		imports := importNames(f.AST)
+		ast.Inspect(f.AST, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.GoStmt:
+				pc.Counts[Goroutine]++
+			case *ast.ChanType:
+				pc.Counts[Channel]++
+			case *ast.SelectStmt:
+				pc.Counts[Select]++
+			case *ast.SelectorExpr:
+				if x, ok := n.X.(*ast.Ident); ok && x.Obj == nil {
+					if prim, ok := selectorPrimitive(imports[x.Name], n.Sel.Name); ok {
+						pc.Counts[prim]++
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	var result []PackageConcurrency
+	for _, pc := range byDir {
+		if len(pc.Counts) > 0 {
+			result = append(result, *pc)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		si, sj := result[i].Score(), result[j].Score()
+		if si != sj {
+			return si > sj
+		}
+		return result[i].Dir < result[j].Dir
+	})
+	return result
+}
+
+func selectorPrimitive(importPath, name string) (Primitive, bool) {
+	switch importPath {
+	case "sync":
+		switch name {
+		case "Mutex":
+			return Mutex, true
+		case "RWMutex":
+			return RWMutex, true
+		case "WaitGroup":
+			return WaitGroup, true
+		case "Once", "OnceFunc", "OnceValue", "OnceValues":
+			return Once, true
+		}
+	case "sync/atomic":
+		return Atomic, true
+	case "golang.org/x/sync/errgroup":
+		return ErrGroup, true
+	}
+	return "", false
+}
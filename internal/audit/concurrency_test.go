@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stephan/rinku/internal/idiom"
+)
+
+func TestConcurrency(t *testing.T) {
+	tree := parseTree(t, map[string]string{
+		"worker/pool.go": `package worker
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type Pool struct {
+	mu   sync.Mutex
+	jobs chan func()
+	done atomic.Int64
+}
+
+func (p *Pool) Run(n int) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range p.jobs {
+				job()
+				p.done.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+}
+`,
+		"worker/wait.go": `package worker
+
+func wait(a, b <-chan int) int {
+	select {
+	case v := <-a:
+		return v
+	case v := <-b:
+		return v
+	}
+}
+`,
+		"cache/cache.go": `package cache
+
+import s "sync"
+
+type Cache struct {
+	mu   s.RWMutex
+	once s.Once
+}
+`,
+		"plain/plain.go": `package plain
+
+func Add(a, b int) int { return a + b }
+`,
+		"worker/pool_test.go": `package worker
+
+func spawn() { go func() {}() }
+`,
+	})
+
+	pkgs := Concurrency(tree)
+	if len(pkgs) != 2 {
+		t.Fatalf("len(Concurrency) = %d, want 2: %+v", len(pkgs), pkgs)
+	}
+
+	worker := pkgs[0]
+	if worker.Dir != "worker" {
+		t.Fatalf("first package = %s, want worker", worker.Dir)
+	}
+	want := map[Primitive]int{Goroutine: 1, Channel: 2, Select: 1, Mutex: 1, WaitGroup: 1, Atomic: 1}
+	for prim, n := range want {
+		if worker.Counts[prim] != n {
+			t.Errorf("worker %s = %d, want %d", prim, worker.Counts[prim], n)
+		}
+	}
+	if got := worker.Score(); got != 3+4+3+1+1+1 {
+		t.Errorf("Score() = %d, want 13", got)
+	}
+	if got, want := worker.Summary(), "goroutine ×1, channel ×2, select ×1, mutex ×1, waitgroup ×1, atomic ×1"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+
+	if got := pkgs[1].Summary(); got != "rwmutex ×1, once ×1" {
+		t.Errorf("cache Summary() = %q", got)
+	}
+}
+
+func TestPrimitivesHaveIdioms(t *testing.T) {
+	db, err := idiom.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	for _, prim := range Primitives {
+		if _, ok := db.Lookup(string(prim)); !ok {
+			t.Errorf("no idiom entry for %q", prim)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package audit
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+// Kinds of configuration keys.
+const (
+	KindEnv     = "env"
+	KindFeature = "feature"
+)
+
+// ConfigKey is an environment variable or feature flag the program reads.
+type ConfigKey struct {
+	Kind     string // KindEnv or KindFeature
+	Name     string
+	Via      string // how it is read, e.g. os.Getenv, envconfig tag, launchdarkly
+	Default  string // Go expression or tag value of the default, "" if none was found
+	Required bool
+	Binary   string // binary of the first use, "" if unknown
+	Uses     []Location
+}
+
+// Location is a position in the source tree.
+type Location struct {
+	File string
+	Line int
+}
+
+// flagSDK describes the evaluation methods of a feature flag SDK: the index of the flag
+// key argument and of the default, -1 for none.
+type flagSDK struct {
+	name    string
+	methods map[string][2]int
+}
+
+// variations are the LaunchDarkly-style evaluation methods, also used by GO Feature Flag.
+var variations = map[string][2]int{
+	"BoolVariation": {0, 2}, "StringVariation": {0, 2}, "IntVariation": {0, 2}, "Float64Variation": {0, 2},
+	"JSONVariation": {0, 2}, "BoolVariationDetail": {0, 2}, "StringVariationDetail": {0, 2},
+	"IntVariationDetail": {0, 2}, "Float64VariationDetail": {0, 2}, "JSONVariationDetail": {0, 2},
+}
+
+// flagSDKs maps import path prefixes of feature flag SDKs to their methods.
+var flagSDKs = map[string]flagSDK{
+	"github.com/launchdarkly/go-server-sdk":     {"launchdarkly", variations},
+	"gopkg.in/launchdarkly/go-server-sdk":       {"launchdarkly", variations},
+	"github.com/thomaspoignant/go-feature-flag": {"go-feature-flag", variations},
+	"github.com/open-feature/go-sdk": {"openfeature", map[string][2]int{
+		"BooleanValue": {1, 2}, "StringValue": {1, 2}, "IntValue": {1, 2}, "FloatValue": {1, 2}, "ObjectValue": {1, 2},
+		"BooleanValueDetails": {1, 2}, "StringValueDetails": {1, 2}, "IntValueDetails": {1, 2},
+		"FloatValueDetails": {1, 2}, "ObjectValueDetails": {1, 2},
+	}},
+	"github.com/Unleash/unleash-client-go": {"unleash", map[string][2]int{"IsEnabled": {0, -1}, "GetVariant": {0, -1}}},
+	"github.com/Flagsmith/flagsmith-go-client": {"flagsmith", map[string][2]int{
+		"IsFeatureEnabled": {0, -1}, "GetFeatureValue": {0, -1}, "GetFlag": {0, -1},
+	}},
+	"github.com/splitio/go-client": {"split", map[string][2]int{"Treatment": {1, -1}, "TreatmentWithConfig": {1, -1}}},
+}
+
+// envFuncs are the standard library functions reading an environment variable.
+var envFuncs = map[string]map[string]bool{
+	"os":      {"Getenv": true, "LookupEnv": true},
+	"syscall": {"Getenv": true},
+}
+
+// configScan collects keys across files.
+type configScan struct {
+	tree     *gosrc.Tree
+	keys     map[[2]string]*ConfigKey
+	wrappers map[string]int // dir + "." + func -> parameter index of the variable name
+}
+
+// ConfigKeys finds the environment variables read through os.Getenv, os.LookupEnv and
+// helpers wrapping them, the variables named in envconfig and env struct tags, and the
+// flags evaluated through common feature flag SDKs. Keys are merged across uses and
+// sorted by kind and name.
+func ConfigKeys(tree *gosrc.Tree) []ConfigKey {
+	cs := &configScan{tree: tree, keys: make(map[[2]string]*ConfigKey), wrappers: make(map[string]int)}
+
+	// Helpers such as getEnv(key, fallback string) are detected first, so calls to them
+	// count as uses in every file.
+	for _, f := range tree.Files {
+		if f.Test {
+			continue
+		}
+		imports := importNames(f.AST)
+		for _, decl := range f.AST.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil && fn.Recv == nil {
+				if idx := envParam(fn, imports); idx >= 0 {
+					cs.wrappers[path.Dir(f.Path)+"."+fn.Name.Name] = idx
+				}
+			}
+		}
+	}
+
+	binary := binaries(tree)
+	for _, f := range tree.Files {
+		if f.Test {
+			continue
+		}
+		dir := path.Dir(f.Path)
+		imports := importNames(f.AST)
+		sdks := make(map[string]flagSDK)
+		for name, importPath := range imports {
+			for prefix, sdk := range flagSDKs {
+				if strings.HasPrefix(importPath, prefix) {
+					sdks[name] = sdk
+				}
+			}
+		}
+		ast.Inspect(f.AST, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.BlockStmt:
+				cs.envDefaults(n, imports)
+			case *ast.CallExpr:
+				cs.call(n, dir, imports, sdks, binary(dir))
+			case *ast.StructType:
+				cs.tags(n, binary(dir))
+			}
+			return true
+		})
+	}
+
+	result := make([]ConfigKey, 0, len(cs.keys))
+	for _, k := range cs.keys {
+		sort.Slice(k.Uses, func(i, j int) bool {
+			if k.Uses[i].File != k.Uses[j].File {
+				return k.Uses[i].File < k.Uses[j].File
+			}
+			return k.Uses[i].Line < k.Uses[j].Line
+		})
+		result = append(result, *k)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// envParam returns the index of the string parameter a function passes to os.Getenv
+// or os.LookupEnv, or -1.
+func envParam(fn *ast.FuncDecl, imports map[string]string) int {
+	params := make(map[string]int)
+	i := 0
+	for _, field := range fn.Type.Params.List {
+		for _, name := range field.Names {
+			params[name.Name] = i
+			i++
+		}
+		if len(field.Names) == 0 {
+			i++
+		}
+	}
+	idx := -1
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || !isEnvCall(call, imports) || len(call.Args) == 0 {
+			return true
+		}
+		if id, ok := call.Args[0].(*ast.Ident); ok {
+			if p, ok := params[id.Name]; ok {
+				idx = p
+			}
+		}
+		return true
+	})
+	return idx
+}
+
+func isEnvCall(call *ast.CallExpr, imports map[string]string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	return ok && envFuncs[imports[x.Name]][sel.Sel.Name]
+}
+
+// key returns the entry for a key, creating it on first sight.
+func (cs *configScan) key(kind, name, via string) *ConfigKey {
+	k := cs.keys[[2]string{kind, name}]
+	if k == nil {
+		k = &ConfigKey{Kind: kind, Name: name, Via: via}
+		cs.keys[[2]string{kind, name}] = k
+	}
+	return k
+}
+
+// add records a use of a key.
+func (cs *configScan) add(kind, name, via, def, binary string, pos token.Pos) *ConfigKey {
+	k := cs.key(kind, name, via)
+	if k.Default == "" {
+		k.Default = def
+	}
+	if k.Binary == "" {
+		k.Binary = binary
+	}
+	file, line := cs.tree.Position(pos)
+	k.Uses = append(k.Uses, Location{File: file, Line: line})
+	return k
+}
+
+func (cs *configScan) call(call *ast.CallExpr, dir string, imports map[string]string, sdks map[string]flagSDK, binary string) {
+	if isEnvCall(call, imports) && len(call.Args) > 0 && isStringLit(call.Args[0]) {
+		sel := call.Fun.(*ast.SelectorExpr)
+		cs.add(KindEnv, stringLit(call.Args[0]), types.ExprString(sel), "", binary, call.Pos())
+		return
+	}
+
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		if idx, ok := cs.wrappers[dir+"."+fun.Name]; ok && idx < len(call.Args) && isStringLit(call.Args[idx]) {
+			def := ""
+			if len(call.Args) == idx+2 {
+				def = types.ExprString(call.Args[idx+1])
+			}
+			cs.add(KindEnv, stringLit(call.Args[idx]), fun.Name, def, binary, call.Pos())
+		}
+	case *ast.SelectorExpr:
+		name := fun.Sel.Name
+		// A package-level SDK function (unleash.IsEnabled) or a method on a client
+		// in a file importing the SDK (client.BoolVariation).
+		for _, sdk := range sdks {
+			args, ok := sdk.methods[name]
+			if !ok || args[0] >= len(call.Args) || !isStringLit(call.Args[args[0]]) {
+				continue
+			}
+			def := ""
+			if args[1] >= 0 && args[1] < len(call.Args) {
+				def = types.ExprString(call.Args[args[1]])
+			}
+			cs.add(KindFeature, stringLit(call.Args[args[0]]), sdk.name, def, binary, call.Pos())
+			return
+		}
+	}
+}
+
+// envDefaults fills in defaults assigned right after reading a variable:
+//
+//	port := os.Getenv("PORT")
+//	if port == "" {
+//		port = "8080"
+//	}
+func (cs *configScan) envDefaults(block *ast.BlockStmt, imports map[string]string) {
+	for i := 0; i+1 < len(block.List); i++ {
+		assign, ok := block.List[i].(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			continue
+		}
+		id, ok := assign.Lhs[0].(*ast.Ident)
+		call, isCall := assign.Rhs[0].(*ast.CallExpr)
+		if !ok || !isCall || !isEnvCall(call, imports) || len(call.Args) == 0 || !isStringLit(call.Args[0]) {
+			continue
+		}
+		ifStmt, ok := block.List[i+1].(*ast.IfStmt)
+		if !ok || !isEmptyCheck(ifStmt.Cond, id.Name) || len(ifStmt.Body.List) != 1 {
+			continue
+		}
+		set, ok := ifStmt.Body.List[0].(*ast.AssignStmt)
+		if !ok || len(set.Lhs) != 1 || len(set.Rhs) != 1 {
+			continue
+		}
+		if lhs, ok := set.Lhs[0].(*ast.Ident); ok && lhs.Name == id.Name {
+			// The read itself is recorded when Inspect reaches the call.
+			k := cs.key(KindEnv, stringLit(call.Args[0]), types.ExprString(call.Fun))
+			if k.Default == "" {
+				k.Default = types.ExprString(set.Rhs[0])
+			}
+		}
+	}
+}
+
+// isEmptyCheck reports whether cond is name == "".
+func isEmptyCheck(cond ast.Expr, name string) bool {
+	bin, ok := cond.(*ast.BinaryExpr)
+	if !ok || bin.Op != token.EQL {
+		return false
+	}
+	id, ok := bin.X.(*ast.Ident)
+	return ok && id.Name == name && isStringLit(bin.Y) && stringLit(bin.Y) == ""
+}
+
+// tags records variables named in struct tags of kelseyhightower/envconfig
+// (envconfig:"PORT" default:"8080" required:"true"), caarlos0/env
+// (env:"PORT,required" envDefault:"8080") and sethvargo/go-envconfig
+// (env:"PORT, default=8080").
+func (cs *configScan) tags(st *ast.StructType, binary string) {
+	for _, field := range st.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+		raw, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		tag := reflect.StructTag(raw)
+		if name, ok := tag.Lookup("envconfig"); ok && name != "" && name != "-" {
+			k := cs.add(KindEnv, strings.Split(name, ",")[0], "envconfig tag", tag.Get("default"), binary, field.Pos())
+			k.Required = k.Required || tag.Get("required") == "true"
+			continue
+		}
+		value, ok := tag.Lookup("env")
+		if !ok || value == "" || value == "-" {
+			continue
+		}
+		parts := strings.Split(value, ",")
+		def, required := tag.Get("envDefault"), false
+		for _, opt := range parts[1:] {
+			opt = strings.TrimSpace(opt)
+			switch {
+			case opt == "required":
+				required = true
+			case strings.HasPrefix(opt, "default="):
+				def = strings.TrimPrefix(opt, "default=")
+			}
+		}
+		name := strings.TrimSpace(parts[0])
+		if name == "" {
+			continue
+		}
+		k := cs.add(KindEnv, name, "env tag", def, binary, field.Pos())
+		k.Required = k.Required || required
+	}
+}
@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigKeys(t *testing.T) {
+	tree := parseTree(t, map[string]string{
+		"cmd/api/main.go": `package main
+
+import (
+	"os"
+
+	ld "github.com/launchdarkly/go-server-sdk/v7"
+)
+
+type Config struct {
+	Port  int    ` + "`envconfig:\"PORT\" default:\"8080\"`" + `
+	Token string ` + "`env:\"API_TOKEN,required\"`" + `
+	Debug bool   ` + "`env:\"DEBUG, default=false\"`" + `
+	Name  string ` + "`json:\"name\"`" + `
+}
+
+func getEnv(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return fallback
+}
+
+func main() {
+	region := os.Getenv("REGION")
+	if region == "" {
+		region = "eu-west-1"
+	}
+	_ = getEnv("LOG_LEVEL", "info")
+	_ = os.Getenv("REGION")
+
+	client, _ := ld.MakeClient("key", 0)
+	_, _ = client.BoolVariation("new-checkout", nil, false)
+}
+`,
+		"cmd/api/main_test.go": `package main
+
+import "os"
+
+var _ = os.Getenv("TEST_ONLY")
+`,
+	})
+
+	keys := ConfigKeys(tree)
+	got := make(map[string]ConfigKey)
+	var names []string
+	for _, k := range keys {
+		got[k.Name] = k
+		names = append(names, k.Kind+":"+k.Name)
+	}
+	want := []string{"env:API_TOKEN", "env:DEBUG", "env:LOG_LEVEL", "env:PORT", "env:REGION", "feature:new-checkout"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("keys = %v, want %v", names, want)
+	}
+
+	if k := got["REGION"]; k.Default != `"eu-west-1"` || len(k.Uses) != 2 || k.Via != "os.Getenv" || k.Binary != "api" {
+		t.Errorf("REGION = %+v", k)
+	}
+	if k := got["LOG_LEVEL"]; k.Default != `"info"` || k.Via != "getEnv" {
+		t.Errorf("LOG_LEVEL = %+v", k)
+	}
+	if k := got["PORT"]; k.Default != "8080" || k.Via != "envconfig tag" {
+		t.Errorf("PORT = %+v", k)
+	}
+	if k := got["API_TOKEN"]; !k.Required {
+		t.Errorf("API_TOKEN = %+v, want required", k)
+	}
+	if k := got["DEBUG"]; k.Default != "false" {
+		t.Errorf("DEBUG = %+v", k)
+	}
+	if k := got["new-checkout"]; k.Default != "false" || k.Via != "launchdarkly" {
+		t.Errorf("new-checkout = %+v", k)
+	}
+}
+
+func TestConfigKey_Requirement(t *testing.T) {
+	s := &Surface{Config: []ConfigKey{
+		{Kind: KindEnv, Name: "PORT", Via: "os.Getenv", Default: `"8080"`, Required: true, Uses: []Location{{"main.go", 3}, {"srv.go", 9}}},
+		{Kind: KindFeature, Name: "team/new-checkout", Via: "unleash", Binary: "web", Uses: []Location{{"h.go", 1}}},
+	}}
+	reqs := s.Requirements("svc")
+	if len(reqs) != 2 {
+		t.Fatalf("requirements = %+v", reqs)
+	}
+	if reqs[0].Path != "svc/config/env/PORT" || reqs[0].Content != "Environment variable: PORT (default \"8080\", required)\nRead via os.Getenv\nUsed at: main.go:3, srv.go:9" {
+		t.Errorf("env requirement = %+v", reqs[0])
+	}
+	if reqs[1].Path != "web/config/features/team_new-checkout" {
+		t.Errorf("feature path = %q", reqs[1].Path)
+	}
+}
@@ -0,0 +1,321 @@
+// Package audit analyzes Go source trees for constructs that need design work when
+// porting to Rust: interfaces, reflection and unsafe code, and concurrency.
+package audit
+
+import (
+	"go/ast"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+// Interface is an exported Go interface and how it would become a Rust trait.
+type Interface struct {
+	Name         string
+	Package      string // package name
+	Dir          string // slash-separated, relative to the module root
+	File         string
+	Line         int
+	Methods      []string
+	Embeds       []string // embedded interfaces from other packages, e.g. io.Reader
+	Implementers []string // Package.Type of project types with all the methods
+	Flags        []Finding
+}
+
+// Finding is a noteworthy use at a source location.
+type Finding struct {
+	Reason string
+	File   string
+	Line   int
+}
+
+// wellKnown lists method sets of standard interfaces commonly embedded in project interfaces.
+var wellKnown = map[string][]string{
+	"io.Reader":    {"Read"},
+	"io.Writer":    {"Write"},
+	"io.Closer":    {"Close"},
+	"io.Seeker":    {"Seek"},
+	"fmt.Stringer": {"String"},
+	"error":        {"Error"},
+}
+
+type typeKey struct {
+	dir  string
+	name string
+}
+
+// Interfaces lists the exported interfaces in non-test files, sorted by directory and name.
+// Implementers are matched by method names, so they are candidates rather than proof.
+func Interfaces(tree *gosrc.Tree) []Interface {
+	ifaces := make(map[typeKey]*Interface)
+	ifaceTypes := make(map[typeKey]*ast.InterfaceType)
+	methods := make(map[typeKey]map[string]bool) // concrete type -> method names
+	var concrete []typeKey
+	var asserts []assertion
+
+	for _, f := range tree.Files {
+		if f.Test {
+			continue
+		}
+		dir := path.Dir(f.Path)
+		ast.Inspect(f.AST, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.TypeSpec:
+				key := typeKey{dir, n.Name.Name}
+				if it, ok := n.Type.(*ast.InterfaceType); ok {
+					file, line := tree.Position(n.Pos())
+					ifaces[key] = &Interface{Name: n.Name.Name, Package: f.Package, Dir: dir, File: file, Line: line}
+					ifaceTypes[key] = it
+				} else {
+					concrete = append(concrete, key)
+				}
+			case *ast.FuncDecl:
+				if n.Recv == nil || len(n.Recv.List) == 0 {
+					return true
+				}
+				key := typeKey{dir, receiverName(n.Recv.List[0].Type)}
+				if methods[key] == nil {
+					methods[key] = make(map[string]bool)
+				}
+				methods[key][n.Name.Name] = true
+			case *ast.TypeAssertExpr:
+				if n.Type != nil {
+					asserts = append(asserts, newAssertion(tree, dir, n.Type, "type assertion"))
+				}
+			case *ast.TypeSwitchStmt:
+				for _, stmt := range n.Body.List {
+					for _, expr := range stmt.(*ast.CaseClause).List {
+						asserts = append(asserts, newAssertion(tree, dir, expr, "type switch"))
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	for key, iface := range ifaces {
+		resolveMethods(tree, key, iface, ifaceTypes, make(map[typeKey]bool))
+	}
+
+	var result []Interface
+	for key, iface := range ifaces {
+		if !ast.IsExported(iface.Name) {
+			continue
+		}
+		if len(iface.Methods) > 0 {
+			for _, ck := range concrete {
+				if hasAll(methods[ck], iface.Methods) {
+					iface.Implementers = append(iface.Implementers, packageName(tree, ck.dir)+"."+ck.name)
+				}
+			}
+		}
+		for _, a := range asserts {
+			if a.matches(key, iface.Package) {
+				iface.Flags = append(iface.Flags, Finding{
+					Reason: "runtime interface check (" + a.kind + "); Rust traits cannot be queried at runtime",
+					File:   a.file,
+					Line:   a.line,
+				})
+			}
+		}
+		sort.Strings(iface.Methods)
+		sort.Strings(iface.Implementers)
+		result = append(result, *iface)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Dir != result[j].Dir {
+			return result[i].Dir < result[j].Dir
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// resolveMethods fills in the method names of an interface, following embedded interfaces,
+// and flags methods whose signatures use dynamic types.
+func resolveMethods(tree *gosrc.Tree, key typeKey, iface *Interface, types map[typeKey]*ast.InterfaceType, seen map[typeKey]bool) {
+	if seen[key] {
+		return
+	}
+	seen[key] = true
+	it := types[key]
+	if it == nil {
+		return
+	}
+
+	for _, field := range it.Methods.List {
+		if len(field.Names) > 0 {
+			for _, name := range field.Names {
+				iface.Methods = appendUnique(iface.Methods, name.Name)
+			}
+			if ft, ok := field.Type.(*ast.FuncType); ok && usesDynamicType(ft) {
+				file, line := tree.Position(field.Pos())
+				iface.Flags = append(iface.Flags, Finding{
+					Reason: field.Names[0].Name + " uses any/interface{} or reflect types; needs generics or an enum",
+					File:   file,
+					Line:   line,
+				})
+			}
+			continue
+		}
+
+		switch t := field.Type.(type) {
+		case *ast.Ident:
+			if known, ok := wellKnown[t.Name]; ok && types[typeKey{key.dir, t.Name}] == nil {
+				iface.Embeds = appendUnique(iface.Embeds, t.Name)
+				for _, m := range known {
+					iface.Methods = appendUnique(iface.Methods, m)
+				}
+				continue
+			}
+			resolveMethods(tree, typeKey{key.dir, t.Name}, iface, types, seen)
+		case *ast.SelectorExpr:
+			name := exprString(t)
+			iface.Embeds = appendUnique(iface.Embeds, name)
+			for _, m := range wellKnown[name] {
+				iface.Methods = appendUnique(iface.Methods, m)
+			}
+		}
+	}
+}
+
+type assertion struct {
+	qualifier string // package qualifier, empty for same-package names
+	name      string
+	dir       string
+	kind      string
+	file      string
+	line      int
+}
+
+func newAssertion(tree *gosrc.Tree, dir string, expr ast.Expr, kind string) assertion {
+	a := assertion{dir: dir, kind: kind}
+	switch t := expr.(type) {
+	case *ast.Ident:
+		a.name = t.Name
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok {
+			a.qualifier, a.name = x.Name, t.Sel.Name
+		}
+	}
+	a.file, a.line = tree.Position(expr.Pos())
+	return a
+}
+
+func (a *assertion) matches(key typeKey, pkg string) bool {
+	if a.name != key.name {
+		return false
+	}
+	if a.qualifier == "" {
+		return a.dir == key.dir
+	}
+	return a.qualifier == pkg
+}
+
+func usesDynamicType(ft *ast.FuncType) bool {
+	dynamic := false
+	ast.Inspect(ft, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.InterfaceType:
+			if len(n.Methods.List) == 0 {
+				dynamic = true
+			}
+		case *ast.Ident:
+			if n.Name == "any" {
+				dynamic = true
+			}
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok && x.Name == "reflect" {
+				dynamic = true
+			}
+		}
+		return !dynamic
+	})
+	return dynamic
+}
+
+func receiverName(expr ast.Expr) string {
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.IndexExpr:
+			expr = t.X
+		case *ast.IndexListExpr:
+			expr = t.X
+		case *ast.ParenExpr:
+			expr = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+func exprString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return exprString(t.X) + "." + t.Sel.Name
+	default:
+		return ""
+	}
+}
+
+func packageName(tree *gosrc.Tree, dir string) string {
+	for _, f := range tree.Files {
+		if !f.Test && path.Dir(f.Path) == dir {
+			return f.Package
+		}
+	}
+	return path.Base(dir)
+}
+
+func hasAll(set map[string]bool, names []string) bool {
+	for _, n := range names {
+		if !set[n] {
+			return false
+		}
+	}
+	return true
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, existing := range list {
+		if existing == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// Qualified returns the interface name qualified by its package, e.g. store.Repository.
+func (i *Interface) Qualified() string {
+	return i.Package + "." + i.Name
+}
+
+// Rust returns a one-line trait sketch for the interface.
+func (i *Interface) Rust() string {
+	var bounds []string
+	for _, e := range i.Embeds {
+		switch e {
+		case "io.Reader":
+			bounds = append(bounds, "std::io::Read")
+		case "io.Writer":
+			bounds = append(bounds, "std::io::Write")
+		case "fmt.Stringer":
+			bounds = append(bounds, "std::fmt::Display")
+		case "error":
+			bounds = append(bounds, "std::error::Error")
+		}
+	}
+	if len(bounds) == 0 {
+		return "trait " + i.Name
+	}
+	return "trait " + i.Name + ": " + strings.Join(bounds, " + ")
+}
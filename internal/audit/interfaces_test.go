@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInterfaces(t *testing.T) {
+	tree := parseTree(t, map[string]string{
+		"store/store.go": `package store
+
+import "io"
+
+type Getter interface {
+	Get(id string) ([]byte, error)
+}
+
+// Repository is implemented by memRepo and sqlRepo.
+type Repository interface {
+	Getter
+	io.Closer
+	Put(id string, data []byte) error
+}
+
+type Hook interface {
+	Run(payload any) error
+}
+
+type notExported interface{ x() }
+
+type memRepo struct{}
+
+func (m *memRepo) Get(id string) ([]byte, error) { return nil, nil }
+func (m *memRepo) Put(id string, data []byte) error { return nil }
+func (m *memRepo) Close() error { return nil }
+
+type sqlRepo[T any] struct{}
+
+func (s sqlRepo[T]) Get(id string) ([]byte, error) { return nil, nil }
+func (s sqlRepo[T]) Put(id string, data []byte) error { return nil }
+func (s sqlRepo[T]) Close() error { return nil }
+func (s sqlRepo[T]) Extra() {}
+`,
+		"api/api.go": `package api
+
+import "example.com/app/store"
+
+func check(v any) bool {
+	_, ok := v.(store.Getter)
+	return ok
+}
+`,
+		"store/store_test.go": `package store
+
+type TestOnly interface{ T() }
+`,
+	})
+
+	ifaces := Interfaces(tree)
+	var names []string
+	byName := make(map[string]Interface)
+	for _, i := range ifaces {
+		names = append(names, i.Qualified())
+		byName[i.Name] = i
+	}
+	if want := []string{"store.Getter", "store.Hook", "store.Repository"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("interfaces = %v, want %v", names, want)
+	}
+
+	repo := byName["Repository"]
+	if want := []string{"Close", "Get", "Put"}; !reflect.DeepEqual(repo.Methods, want) {
+		t.Errorf("Repository methods = %v, want %v", repo.Methods, want)
+	}
+	if want := []string{"store.memRepo", "store.sqlRepo"}; !reflect.DeepEqual(repo.Implementers, want) {
+		t.Errorf("Repository implementers = %v, want %v", repo.Implementers, want)
+	}
+	if want := []string{"io.Closer"}; !reflect.DeepEqual(repo.Embeds, want) {
+		t.Errorf("Repository embeds = %v, want %v", repo.Embeds, want)
+	}
+
+	getter := byName["Getter"]
+	if len(getter.Flags) != 1 || getter.Flags[0].File != "api/api.go" || getter.Flags[0].Line != 6 {
+		t.Errorf("Getter flags = %+v, want the type assertion in api/api.go:6", getter.Flags)
+	}
+
+	hook := byName["Hook"]
+	if len(hook.Flags) != 1 || !strings.Contains(hook.Flags[0].Reason, "any") {
+		t.Errorf("Hook flags = %+v, want dynamic type flag", hook.Flags)
+	}
+	if len(hook.Implementers) != 0 {
+		t.Errorf("Hook implementers = %v, want none", hook.Implementers)
+	}
+}
+
+func TestInterface_Rust(t *testing.T) {
+	i := Interface{Name: "Store", Embeds: []string{"io.Reader", "fmt.Stringer"}}
+	if got := i.Rust(); got != "trait Store: std::io::Read + std::fmt::Display" {
+		t.Errorf("Rust() = %q", got)
+	}
+}
@@ -0,0 +1,168 @@
+package audit
+
+import (
+	"go/ast"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+// RiskKind classifies a construct that has no mechanical Rust translation.
+type RiskKind string
+
+const (
+	RiskReflect  RiskKind = "reflect"
+	RiskUnsafe   RiskKind = "unsafe"
+	RiskLinkname RiskKind = "go:linkname"
+	RiskRuntime  RiskKind = "runtime"
+	RiskCgo      RiskKind = "cgo"
+)
+
+// Risk is a single use of reflection, unsafe code, linkname, runtime internals or cgo.
+type Risk struct {
+	Kind   RiskKind
+	Detail string // e.g. reflect.ValueOf or the linkname directive
+	Line   int
+}
+
+// RiskFile is a source file with at least one risk, a candidate for redesign.
+type RiskFile struct {
+	File    string
+	Package string
+	Risks   []Risk
+}
+
+// runtimeTricks are runtime functions that depend on the Go scheduler, garbage collector
+// or stack layout.
+var runtimeTricks = map[string]bool{
+	"SetFinalizer":   true,
+	"KeepAlive":      true,
+	"LockOSThread":   true,
+	"UnlockOSThread": true,
+	"Goexit":         true,
+	"Gosched":        true,
+	"GC":             true,
+	"GOMAXPROCS":     true,
+	"Caller":         true,
+	"Callers":        true,
+	"FuncForPC":      true,
+	"Stack":          true,
+	"ReadMemStats":   true,
+	"NumGoroutine":   true,
+}
+
+// Risks lists the non-test files that use reflect, unsafe, //go:linkname, runtime
+// tricks or cgo, most risks first.
+func Risks(tree *gosrc.Tree) []RiskFile {
+	var result []RiskFile
+	for _, f := range tree.Files {
+		if f.Test {
+			continue
+		}
+		rf := RiskFile{File: f.Path, Package: f.Package}
+
+		names := make(map[string]RiskKind) // local import name -> kind
+		for name, p := range importNames(f.AST) {
+			switch p {
+			case "reflect":
+				names[name] = RiskReflect
+			case "unsafe":
+				names[name] = RiskUnsafe
+			case "runtime":
+				names[name] = RiskRuntime
+			}
+		}
+
+		if len(names) > 0 {
+			ast.Inspect(f.AST, func(n ast.Node) bool {
+				sel, ok := n.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				x, ok := sel.X.(*ast.Ident)
+				if !ok || x.Obj != nil {
+					return true
+				}
+				kind, ok := names[x.Name]
+				if !ok || (kind == RiskRuntime && !runtimeTricks[sel.Sel.Name]) {
+					return true
+				}
+				_, line := tree.Position(sel.Pos())
+				rf.Risks = append(rf.Risks, Risk{Kind: kind, Detail: string(kind) + "." + sel.Sel.Name, Line: line})
+				return true
+			})
+		}
+
+		for _, imp := range f.AST.Imports {
+			if imp.Path.Value == `"C"` {
+				_, line := tree.Position(imp.Pos())
+				rf.Risks = append(rf.Risks, Risk{Kind: RiskCgo, Detail: `import "C"`, Line: line})
+			}
+		}
+
+		for _, group := range f.AST.Comments {
+			for _, c := range group.List {
+				if strings.HasPrefix(c.Text, "//go:linkname ") {
+					_, line := tree.Position(c.Pos())
+					rf.Risks = append(rf.Risks, Risk{Kind: RiskLinkname, Detail: strings.TrimPrefix(c.Text, "//"), Line: line})
+				}
+			}
+		}
+
+		if len(rf.Risks) > 0 {
+			sort.SliceStable(rf.Risks, func(i, j int) bool { return rf.Risks[i].Line < rf.Risks[j].Line })
+			result = append(result, rf)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if len(result[i].Risks) != len(result[j].Risks) {
+			return len(result[i].Risks) > len(result[j].Risks)
+		}
+		return result[i].File < result[j].File
+	})
+	return result
+}
+
+// Kinds returns the risk kinds in the file with their counts, e.g. "reflect ×3, unsafe ×1".
+func (f *RiskFile) Kinds() string {
+	counts := make(map[RiskKind]int)
+	var kinds []RiskKind
+	for _, r := range f.Risks {
+		if counts[r.Kind] == 0 {
+			kinds = append(kinds, r.Kind)
+		}
+		counts[r.Kind]++
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+
+	parts := make([]string, len(kinds))
+	for i, k := range kinds {
+		parts[i] = string(k) + " ×" + strconv.Itoa(counts[k])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// importNames maps the local names of a file's imports to their paths.
+// Blank and dot imports are left out since no selector refers to them.
+func importNames(file *ast.File) map[string]string {
+	names := make(map[string]string)
+	for _, imp := range file.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path.Base(p)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if name == "_" || name == "." {
+			continue
+		}
+		names[name] = p
+	}
+	return names
+}
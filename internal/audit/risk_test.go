@@ -0,0 +1,108 @@
+package audit
+
+import "testing"
+
+func TestRisks(t *testing.T) {
+	tree := parseTree(t, map[string]string{
+		"codec/codec.go": `package codec
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+func Size(v any) uintptr {
+	rv := reflect.ValueOf(v)
+	return unsafe.Sizeof(rv)
+}
+
+func Kind(v any) reflect.Kind {
+	return reflect.TypeOf(v).Kind()
+}
+`,
+		"clock/clock.go": `package clock
+
+import (
+	"runtime"
+	_ "unsafe"
+)
+
+//go:linkname nanotime runtime.nanotime
+func nanotime() int64
+
+func Pin() {
+	runtime.LockOSThread()
+	_ = runtime.NumCPU()
+}
+`,
+		"plain/plain.go": `package plain
+
+import rt "runtime"
+
+func Collect() {
+	reflect := struct{ ValueOf int }{}
+	_ = reflect.ValueOf
+	rt.GC()
+}
+`,
+		"codec/codec_test.go": `package codec
+
+import "reflect"
+
+var _ = reflect.DeepEqual
+`,
+	})
+
+	files := Risks(tree)
+	if len(files) != 3 {
+		t.Fatalf("len(Risks) = %d, want 3: %+v", len(files), files)
+	}
+
+	codec := files[0]
+	if codec.File != "codec/codec.go" || len(codec.Risks) != 4 {
+		t.Fatalf("first file = %s with %d risks, want codec/codec.go with 4", codec.File, len(codec.Risks))
+	}
+	if got := codec.Kinds(); got != "reflect ×3, unsafe ×1" {
+		t.Errorf("Kinds() = %q", got)
+	}
+	if codec.Risks[0].Detail != "reflect.ValueOf" || codec.Risks[0].Line != 9 {
+		t.Errorf("first risk = %+v, want reflect.ValueOf on line 9", codec.Risks[0])
+	}
+
+	clock := files[1]
+	if clock.File != "clock/clock.go" {
+		t.Fatalf("second file = %s, want clock/clock.go", clock.File)
+	}
+	if got := clock.Kinds(); got != "go:linkname ×1, runtime ×1" {
+		t.Errorf("Kinds() = %q (runtime.NumCPU should not count)", got)
+	}
+	if clock.Risks[0].Detail != "go:linkname nanotime runtime.nanotime" {
+		t.Errorf("linkname detail = %q", clock.Risks[0].Detail)
+	}
+
+	plain := files[2]
+	if len(plain.Risks) != 1 || plain.Risks[0].Detail != "runtime.GC" {
+		t.Errorf("plain risks = %+v, want only runtime.GC via the rt alias", plain.Risks)
+	}
+}
+
+func TestRisks_Cgo(t *testing.T) {
+	tree := parseTree(t, map[string]string{
+		"zstd/zstd.go": `package zstd
+
+// #cgo LDFLAGS: -lzstd
+// #include <zstd.h>
+import "C"
+
+func Version() int { return int(C.ZSTD_versionNumber()) }
+`,
+	})
+
+	files := Risks(tree)
+	if len(files) != 1 || files[0].Kinds() != "cgo ×1" {
+		t.Fatalf("Risks = %+v, want one cgo file", files)
+	}
+	if r := files[0].Risks[0]; r.Line != 5 || r.Detail != `import "C"` {
+		t.Errorf("risk = %+v", r)
+	}
+}
@@ -0,0 +1,1062 @@
+package audit
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+// Surface is the externally visible behavior of a program: its commands, flags, HTTP
+// routes and configuration keys, found syntactically in non-test files.
+type Surface struct {
+	Commands  []Command
+	Flags     []Flag
+	Routes    []Route
+	Config    []ConfigKey
+	Telemetry *Telemetry
+}
+
+// Command is a cobra command.
+type Command struct {
+	Path   string // space-separated path below the root command, e.g. "db migrate"; "" for the root
+	Use    string
+	Short  string
+	Dir    string // package directory, relative to the module root
+	Binary string // main package directory name the command belongs to, "" if unknown
+	File   string
+	Line   int
+
+	name   string
+	parent *Command
+	isRoot bool
+}
+
+// Flag is a command-line flag defined through cobra/pflag or the standard flag package.
+type Flag struct {
+	Command    string // path of the command it belongs to, "" for the root or global flags
+	Name       string
+	Short      string
+	Type       string // e.g. string, int, duration, stringSlice
+	Default    string // Go expression of the default value
+	Usage      string
+	Persistent bool // inherited by subcommands
+	Dir        string
+	Binary     string
+	File       string
+	Line       int
+
+	cmd *Command
+}
+
+// Route is an HTTP route registration.
+type Route struct {
+	Method      string   // upper case; "" matches any method
+	Path        string   // with group prefixes applied
+	Handler     string   // Go expression of the handler
+	Middlewares []string // Go expressions of router, group and route middlewares, outermost first
+	Statuses    []int    // status codes written by the handler, if it could be resolved
+	Dir         string
+	Binary      string
+	File        string
+	Line        int
+}
+
+const cobraImport = "github.com/spf13/cobra"
+
+// flagTypes are the flag definition methods of the flag and pflag packages, without
+// their Var and P suffixes.
+var flagTypes = map[string]bool{
+	"Bool": true, "String": true, "Int": true, "Int8": true, "Int16": true, "Int32": true, "Int64": true,
+	"Uint": true, "Uint8": true, "Uint16": true, "Uint32": true, "Uint64": true, "Float32": true, "Float64": true,
+	"Duration": true, "StringSlice": true, "StringArray": true, "IntSlice": true, "Int32Slice": true,
+	"Int64Slice": true, "UintSlice": true, "Float64Slice": true, "BoolSlice": true, "DurationSlice": true,
+	"StringToString": true, "StringToInt": true, "Count": true, "IP": true, "IPNet": true, "IPSlice": true,
+	"BytesHex": true, "BytesBase64": true, "Func": true, "BoolFunc": true, "Text": true,
+}
+
+// flagMethod splits a flag method such as StringVarP into its type and whether it
+// takes a variable pointer and a shorthand.
+func flagMethod(name string) (typ string, isVar, short bool, ok bool) {
+	for _, suffix := range []string{"", "VarP", "Var", "P"} {
+		base, found := strings.CutSuffix(name, suffix)
+		if found && flagTypes[base] {
+			return base, strings.HasPrefix(suffix, "Var"), strings.HasSuffix(suffix, "P"), true
+		}
+	}
+	return "", false, false, false
+}
+
+// routeMethods maps router method names (gin, echo, chi, fiber, httprouter) to HTTP methods.
+var routeMethods = map[string]string{
+	"GET": "GET", "POST": "POST", "PUT": "PUT", "DELETE": "DELETE", "PATCH": "PATCH", "HEAD": "HEAD", "OPTIONS": "OPTIONS",
+	"Get": "GET", "Post": "POST", "Put": "PUT", "Delete": "DELETE", "Patch": "PATCH", "Head": "HEAD", "Options": "OPTIONS",
+	"Any": "", "Handle": "", "HandleFunc": "", "Handler": "", "HandlerFunc": "",
+}
+
+// funcKey identifies functions, and methods by name regardless of their receiver.
+type funcKey struct {
+	dir, name string
+	method    bool
+}
+
+// flagSet is what a flag definition is called on.
+type flagSet struct {
+	cmd        *Command
+	persistent bool
+	name       string // FlagSet name for flag.NewFlagSet, used as command path
+}
+
+// surfaceFile is the state while scanning one file.
+type surfaceFile struct {
+	tree        *gosrc.Tree
+	file        gosrc.ParsedFile
+	dir         string
+	imports     map[string]string
+	pkgCmds     map[string]*Command // dir + "." + name -> package-level command
+	funcCmds    map[string]*Command // dir + "." + func -> command returned by a constructor
+	byLit       map[*ast.CompositeLit]*Command
+	locals      map[string]*Command
+	sets        map[string]flagSet
+	routers     map[string]router // router variable -> prefix and middlewares
+	funcs       map[funcKey][]*ast.FuncDecl
+	funcImports map[*ast.FuncDecl]map[string]string
+	methods     map[*ast.CallExpr][]string
+	surface     *Surface
+}
+
+// ExtractSurface extracts cobra command trees, flag definitions and HTTP route registrations.
+// It works on syntax only: commands are followed through variables, constructor
+// functions and AddCommand calls, routes through Group, Route and PathPrefix prefixes.
+func ExtractSurface(tree *gosrc.Tree) *Surface {
+	s := &Surface{}
+	var commands []*Command
+	pkgCmds := make(map[string]*Command)
+	funcCmds := make(map[string]*Command)
+	byLit := make(map[*ast.CompositeLit]*Command)
+	funcs := make(map[funcKey][]*ast.FuncDecl)
+	funcImports := make(map[*ast.FuncDecl]map[string]string)
+
+	// First pass: command literals, package-level command variables and constructors.
+	for _, f := range tree.Files {
+		if f.Test {
+			continue
+		}
+		dir := path.Dir(f.Path)
+		imports := importNames(f.AST)
+		ast.Inspect(f.AST, func(n ast.Node) bool {
+			if lit, ok := n.(*ast.CompositeLit); ok && isCobraCommand(lit.Type, imports) {
+				cmd := newCommand(tree, dir, lit)
+				byLit[lit] = cmd
+				commands = append(commands, cmd)
+			}
+			return true
+		})
+		for _, decl := range f.AST.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					vs, ok := spec.(*ast.ValueSpec)
+					if !ok {
+						continue
+					}
+					for i, name := range vs.Names {
+						if i < len(vs.Values) {
+							if cmd := byLit[compositeLit(vs.Values[i])]; cmd != nil {
+								pkgCmds[dir+"."+name.Name] = cmd
+							}
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				key := funcKey{dir: dir, name: d.Name.Name, method: d.Recv != nil}
+				funcs[key] = append(funcs[key], d)
+				funcImports[d] = imports
+				if d.Body != nil && d.Recv == nil {
+					if cmd := returnedCommand(d.Body, byLit); cmd != nil {
+						funcCmds[dir+"."+d.Name.Name] = cmd
+					}
+				}
+			}
+		}
+	}
+
+	// Second pass: AddCommand, flags and routes, in source order.
+	for _, f := range tree.Files {
+		if f.Test {
+			continue
+		}
+		sf := &surfaceFile{
+			tree:        tree,
+			file:        f,
+			dir:         path.Dir(f.Path),
+			imports:     importNames(f.AST),
+			pkgCmds:     pkgCmds,
+			funcCmds:    funcCmds,
+			byLit:       byLit,
+			locals:      make(map[string]*Command),
+			sets:        make(map[string]flagSet),
+			routers:     make(map[string]router),
+			funcs:       funcs,
+			funcImports: funcImports,
+			methods:     make(map[*ast.CallExpr][]string),
+			surface:     s,
+		}
+		ast.Inspect(f.AST, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				sf.assign(n)
+			case *ast.CallExpr:
+				return sf.call(n)
+			}
+			return true
+		})
+	}
+
+	resolveCommandPaths(commands)
+	binary := binaries(tree)
+	for _, cmd := range commands {
+		cmd.Binary = binary(cmd.Dir)
+	}
+	for i := range s.Flags {
+		s.Flags[i].Binary = binary(s.Flags[i].Dir)
+	}
+	for i := range s.Routes {
+		s.Routes[i].Binary = binary(s.Routes[i].Dir)
+	}
+	for _, cmd := range commands {
+		if !cmd.isRoot {
+			s.Commands = append(s.Commands, *cmd)
+		}
+	}
+	for i := range s.Flags {
+		if c := s.Flags[i].cmd; c != nil && !c.isRoot {
+			s.Flags[i].Command = c.Path
+		}
+	}
+
+	s.Config = ConfigKeys(tree)
+	s.Telemetry = CollectTelemetry(tree)
+
+	sort.SliceStable(s.Commands, func(i, j int) bool { return s.Commands[i].Path < s.Commands[j].Path })
+	sort.SliceStable(s.Flags, func(i, j int) bool {
+		if s.Flags[i].Command != s.Flags[j].Command {
+			return s.Flags[i].Command < s.Flags[j].Command
+		}
+		return s.Flags[i].Name < s.Flags[j].Name
+	})
+	sort.SliceStable(s.Routes, func(i, j int) bool {
+		if s.Routes[i].Path != s.Routes[j].Path {
+			return s.Routes[i].Path < s.Routes[j].Path
+		}
+		return s.Routes[i].Method < s.Routes[j].Method
+	})
+	return s
+}
+
+func isCobraCommand(expr ast.Expr, imports map[string]string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Command" {
+		return false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	return ok && imports[x.Name] == cobraImport
+}
+
+// commandLit returns the composite literal of &cobra.Command{...} or cobra.Command{...}.
+func compositeLit(expr ast.Expr) *ast.CompositeLit {
+	if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		expr = u.X
+	}
+	lit, _ := expr.(*ast.CompositeLit)
+	return lit
+}
+
+func newCommand(tree *gosrc.Tree, dir string, lit *ast.CompositeLit) *Command {
+	file, line := tree.Position(lit.Pos())
+	cmd := &Command{Dir: dir, File: file, Line: line}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		switch key.Name {
+		case "Use":
+			cmd.Use = stringLit(kv.Value)
+		case "Short":
+			cmd.Short = stringLit(kv.Value)
+		}
+	}
+	if fields := strings.Fields(cmd.Use); len(fields) > 0 {
+		cmd.name = fields[0]
+	}
+	return cmd
+}
+
+// returnedCommand finds the command a constructor function returns.
+func returnedCommand(body *ast.BlockStmt, byLit map[*ast.CompositeLit]*Command) *Command {
+	locals := make(map[string]*Command)
+	var result *Command
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && i < len(n.Rhs) {
+					if cmd := byLit[compositeLit(n.Rhs[i])]; cmd != nil {
+						locals[id.Name] = cmd
+					}
+				}
+			}
+		case *ast.ReturnStmt:
+			if len(n.Results) == 0 {
+				return true
+			}
+			if cmd := byLit[compositeLit(n.Results[0])]; cmd != nil {
+				result = cmd
+			} else if id, ok := n.Results[0].(*ast.Ident); ok && locals[id.Name] != nil {
+				result = locals[id.Name]
+			}
+		}
+		return true
+	})
+	return result
+}
+
+// command resolves an expression to a command: a variable or a constructor call.
+func (sf *surfaceFile) command(expr ast.Expr) *Command {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if cmd := sf.locals[e.Name]; cmd != nil {
+			return cmd
+		}
+		return sf.pkgCmds[sf.dir+"."+e.Name]
+	case *ast.CallExpr:
+		if id, ok := e.Fun.(*ast.Ident); ok {
+			return sf.funcCmds[sf.dir+"."+id.Name]
+		}
+	case *ast.UnaryExpr, *ast.CompositeLit:
+		return sf.byLit[compositeLit(e)]
+	}
+	return nil
+}
+
+func (sf *surfaceFile) assign(n *ast.AssignStmt) {
+	for i, lhs := range n.Lhs {
+		id, ok := lhs.(*ast.Ident)
+		if !ok || i >= len(n.Rhs) {
+			continue
+		}
+		rhs := n.Rhs[i]
+		if cmd := sf.byLit[compositeLit(rhs)]; cmd != nil {
+			sf.locals[id.Name] = cmd
+			continue
+		}
+		call, ok := rhs.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		if cmd := sf.command(call); cmd != nil {
+			sf.locals[id.Name] = cmd
+			continue
+		}
+		if set, ok := sf.flagSet(call); ok {
+			sf.sets[id.Name] = set
+			continue
+		}
+		// Router groups: r.Group("/v1"), r.PathPrefix("/api").Subrouter(), r.With(auth).
+		if rt, ok := sf.group(call); ok {
+			sf.routers[id.Name] = rt
+		}
+	}
+}
+
+// flagSet recognizes cmd.Flags(), cmd.PersistentFlags() and flag.NewFlagSet("name", ...).
+func (sf *surfaceFile) flagSet(call *ast.CallExpr) (flagSet, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return flagSet{}, false
+	}
+	switch sel.Sel.Name {
+	case "Flags", "PersistentFlags", "LocalFlags":
+		if cmd := sf.command(sel.X); cmd != nil {
+			return flagSet{cmd: cmd, persistent: sel.Sel.Name == "PersistentFlags"}, true
+		}
+	case "NewFlagSet":
+		if x, ok := sel.X.(*ast.Ident); ok && isFlagPackage(sf.imports[x.Name]) && len(call.Args) > 0 {
+			return flagSet{name: stringLit(call.Args[0])}, true
+		}
+	}
+	return flagSet{}, false
+}
+
+func isFlagPackage(importPath string) bool {
+	return importPath == "flag" || importPath == "github.com/spf13/pflag"
+}
+
+// router is what routes registered on a router variable inherit.
+type router struct {
+	prefix      string
+	middlewares []string
+}
+
+// with returns a router below rt with an additional prefix and middlewares.
+func (rt router) with(prefix string, middlewares []ast.Expr) router {
+	sub := router{prefix: rt.prefix + prefix, middlewares: slices.Clone(rt.middlewares)}
+	for _, mw := range middlewares {
+		sub.middlewares = append(sub.middlewares, types.ExprString(mw))
+	}
+	return sub
+}
+
+// group returns the router created by call, such as r.Group("/v1", auth).
+func (sf *surfaceFile) group(call *ast.CallExpr) (router, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return router{}, false
+	}
+	switch sel.Sel.Name {
+	case "Group", "PathPrefix":
+		if len(call.Args) == 0 || !isStringLit(call.Args[0]) {
+			return router{}, false
+		}
+		return sf.router(sel.X).with(stringLit(call.Args[0]), call.Args[1:]), true
+	case "With":
+		// chi: r.With(auth).Get(...)
+		return sf.router(sel.X).with("", call.Args), true
+	case "Subrouter":
+		if inner, ok := sel.X.(*ast.CallExpr); ok {
+			return sf.group(inner)
+		}
+	}
+	return router{}, false
+}
+
+// router returns the state of a router expression.
+func (sf *surfaceFile) router(expr ast.Expr) router {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return sf.routers[e.Name]
+	case *ast.CallExpr:
+		if rt, ok := sf.group(e); ok {
+			return rt
+		}
+	}
+	return router{}
+}
+
+// inline walks the body of a chi Route or Group callback with its router parameter
+// bound to rt.
+func (sf *surfaceFile) inline(fn *ast.FuncLit, rt router) {
+	if len(fn.Type.Params.List) == 0 || len(fn.Type.Params.List[0].Names) == 0 {
+		return
+	}
+	param := fn.Type.Params.List[0].Names[0].Name
+	saved, had := sf.routers[param]
+	sf.routers[param] = rt
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			sf.assign(n)
+		case *ast.CallExpr:
+			return sf.call(n)
+		}
+		return true
+	})
+	if had {
+		sf.routers[param] = saved
+	} else {
+		delete(sf.routers, param)
+	}
+}
+
+// call handles AddCommand, flag definitions and route registrations. It returns false
+// when it walked the children itself.
+func (sf *surfaceFile) call(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return true
+	}
+	name := sel.Sel.Name
+
+	switch {
+	case name == "AddCommand":
+		if parent := sf.command(sel.X); parent != nil {
+			for _, arg := range call.Args {
+				if child := sf.command(arg); child != nil && child != parent {
+					child.parent = parent
+				}
+			}
+		}
+		return true
+	case name == "Methods":
+		// gorilla/mux: r.HandleFunc("/users", h).Methods("GET", "POST")
+		if inner, ok := sel.X.(*ast.CallExpr); ok {
+			for _, arg := range call.Args {
+				if isStringLit(arg) {
+					sf.methods[inner] = append(sf.methods[inner], strings.ToUpper(stringLit(arg)))
+				}
+			}
+		}
+		return true
+	case name == "Route" && len(call.Args) == 2 && isStringLit(call.Args[0]):
+		// chi: r.Route("/v1", func(r chi.Router) { ... })
+		if fn, ok := call.Args[1].(*ast.FuncLit); ok {
+			sf.inline(fn, sf.router(sel.X).with(stringLit(call.Args[0]), nil))
+			return false
+		}
+	case name == "Group" && len(call.Args) == 1:
+		// chi: r.Group(func(r chi.Router) { r.Use(auth); ... })
+		if fn, ok := call.Args[0].(*ast.FuncLit); ok {
+			sf.inline(fn, sf.router(sel.X))
+			return false
+		}
+	case name == "Use":
+		if id, ok := sel.X.(*ast.Ident); ok {
+			sf.routers[id.Name] = sf.router(id).with("", call.Args)
+		}
+		return true
+	}
+
+	if sf.flag(call, sel) {
+		return true
+	}
+	sf.route(call, sel)
+	return true
+}
+
+// flag records a flag definition such as cmd.Flags().StringVarP(&port, "port", "p", "8080", "usage").
+func (sf *surfaceFile) flag(call *ast.CallExpr, sel *ast.SelectorExpr) bool {
+	typ, isVar, short, ok := flagMethod(sel.Sel.Name)
+	if !ok {
+		return false
+	}
+
+	var set flagSet
+	switch x := sel.X.(type) {
+	case *ast.Ident:
+		if s, ok := sf.sets[x.Name]; ok {
+			set = s
+		} else if !isFlagPackage(sf.imports[x.Name]) {
+			return false
+		}
+	case *ast.CallExpr:
+		s, ok := sf.flagSet(x)
+		if !ok {
+			return false
+		}
+		set = s
+	default:
+		return false
+	}
+
+	idx := 0
+	if isVar {
+		idx++ // pointer to the variable
+	}
+	if idx >= len(call.Args) || !isStringLit(call.Args[idx]) {
+		return false
+	}
+	file, line := sf.tree.Position(call.Pos())
+	f := Flag{
+		Name:       stringLit(call.Args[idx]),
+		Type:       strings.ToLower(typ[:1]) + typ[1:],
+		Persistent: set.persistent,
+		Dir:        sf.dir,
+		File:       file,
+		Line:       line,
+		cmd:        set.cmd,
+	}
+	if set.name != "" {
+		f.Command = set.name
+	}
+	if short && idx+1 < len(call.Args) {
+		idx++
+		f.Short = stringLit(call.Args[idx])
+	}
+	rest := call.Args[idx+1:]
+	switch {
+	case typ == "Func" || typ == "BoolFunc" || typ == "Count":
+		if len(rest) > 0 {
+			f.Usage = stringLit(rest[0])
+		}
+	case len(rest) >= 2:
+		f.Default = types.ExprString(rest[0])
+		f.Usage = stringLit(rest[len(rest)-1])
+	case len(rest) == 1:
+		f.Usage = stringLit(rest[0])
+	}
+	sf.surface.Flags = append(sf.surface.Flags, f)
+	return true
+}
+
+// route records a route registration such as r.GET("/users", list) or
+// mux.HandleFunc("POST /users", create).
+func (sf *surfaceFile) route(call *ast.CallExpr, sel *ast.SelectorExpr) {
+	method, ok := routeMethods[sel.Sel.Name]
+	if !ok || len(call.Args) < 2 || !isStringLit(call.Args[0]) {
+		return
+	}
+	pattern := stringLit(call.Args[0])
+	handlers := call.Args[1:]
+	if method == "" && len(call.Args) >= 3 && isStringLit(call.Args[1]) && pattern == strings.ToUpper(pattern) {
+		// gin and httprouter: r.Handle("GET", "/users", h)
+		method, pattern, handlers = pattern, stringLit(call.Args[1]), call.Args[2:]
+	} else if method == "" {
+		// net/http patterns since Go 1.22: "GET /users/{id}", optionally with a host.
+		if m, rest, ok := strings.Cut(pattern, " "); ok && strings.ToUpper(m) == m {
+			method, pattern = m, strings.TrimSpace(rest)
+		}
+	}
+	if !strings.HasPrefix(pattern, "/") {
+		return
+	}
+
+	// The handler comes last and route middlewares before it, except for echo, which
+	// takes the handler first.
+	handler, middlewares := handlers[len(handlers)-1], handlers[:len(handlers)-1]
+	if sf.usesEcho() {
+		handler, middlewares = handlers[0], handlers[1:]
+	}
+	rt := sf.router(sel.X).with(pattern, middlewares)
+
+	file, line := sf.tree.Position(call.Pos())
+	statuses := sf.statuses(handler)
+	methods := sf.methods[call]
+	if len(methods) == 0 {
+		methods = []string{method}
+	}
+	for _, m := range methods {
+		sf.surface.Routes = append(sf.surface.Routes, Route{
+			Method:      m,
+			Path:        rt.prefix,
+			Handler:     handlerName(handler),
+			Middlewares: rt.middlewares,
+			Statuses:    statuses,
+			Dir:         sf.dir,
+			File:        file,
+			Line:        line,
+		})
+	}
+}
+
+// handlerName returns the Go expression of a handler, "func literal" for inline ones.
+func handlerName(handler ast.Expr) string {
+	if _, ok := handler.(*ast.FuncLit); ok {
+		return "func literal"
+	}
+	return types.ExprString(handler)
+}
+
+func (sf *surfaceFile) usesEcho() bool {
+	for _, p := range sf.imports {
+		if strings.HasPrefix(p, "github.com/labstack/echo") {
+			return true
+		}
+	}
+	return false
+}
+
+// statuses returns the HTTP status codes a handler writes: net/http Status constants
+// and integer literals passed to response methods in its body. Handlers are followed
+// into functions and methods of the same package, and of imported packages of the
+// module, but not further.
+func (sf *surfaceFile) statuses(handler ast.Expr) []int {
+	switch h := handler.(type) {
+	case *ast.FuncLit:
+		return statusCodes([]*ast.BlockStmt{h.Body}, sf.imports)
+	case *ast.CallExpr:
+		// A constructor returning the handler, or http.HandlerFunc(h).
+		if decls := sf.lookupFunc(h.Fun); len(decls) > 0 {
+			return sf.statusesIn(decls)
+		}
+		if len(h.Args) == 1 {
+			return sf.statuses(h.Args[0])
+		}
+		return nil
+	}
+	return sf.statusesIn(sf.lookupFunc(handler))
+}
+
+func (sf *surfaceFile) statusesIn(decls []*ast.FuncDecl) []int {
+	if len(decls) == 0 {
+		return nil
+	}
+	var bodies []*ast.BlockStmt
+	for _, d := range decls {
+		if d.Body != nil {
+			bodies = append(bodies, d.Body)
+		}
+	}
+	// Status constants resolve through the imports of the file declaring the handler.
+	return statusCodes(bodies, sf.funcImports[decls[0]])
+}
+
+// lookupFunc resolves a handler expression to function or method declarations:
+// getUser, h.getUser (any receiver named getUser in the package) or users.Get.
+func (sf *surfaceFile) lookupFunc(expr ast.Expr) []*ast.FuncDecl {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return sf.funcs[funcKey{dir: sf.dir, name: e.Name}]
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok {
+			if importPath, ok := sf.imports[x.Name]; ok {
+				// The longest matching directory wins, so nested packages with the
+				// same name resolve the same way on every run.
+				var found []*ast.FuncDecl
+				dir := ""
+				for key, decls := range sf.funcs {
+					if !key.method && key.name == e.Sel.Name && strings.HasSuffix(importPath, "/"+key.dir) && len(key.dir) > len(dir) {
+						found, dir = decls, key.dir
+					}
+				}
+				return found
+			}
+		}
+		return sf.funcs[funcKey{dir: sf.dir, name: e.Sel.Name, method: true}]
+	}
+	return nil
+}
+
+// responseCalls are methods and functions whose integer arguments are status codes.
+var responseCalls = map[string]bool{
+	"WriteHeader": true, "Error": true, "Redirect": true, "JSON": true, "IndentedJSON": true,
+	"XML": true, "String": true, "HTML": true, "Data": true, "Status": true, "AbortWithStatus": true,
+	"AbortWithStatusJSON": true, "AbortWithError": true, "NoContent": true, "Blob": true, "Render": true,
+	"SendStatus": true, "NewHTTPError": true, "Respond": true,
+}
+
+func statusCodes(bodies []*ast.BlockStmt, imports map[string]string) []int {
+	seen := make(map[int]bool)
+	for _, body := range bodies {
+		ast.Inspect(body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.SelectorExpr:
+				if x, ok := n.X.(*ast.Ident); ok && imports[x.Name] == "net/http" {
+					if code, ok := httpStatus[n.Sel.Name]; ok {
+						seen[code] = true
+					}
+				}
+			case *ast.CallExpr:
+				if !responseCalls[callName(n)] {
+					return true
+				}
+				for _, arg := range n.Args {
+					if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.INT {
+						if code, err := strconv.Atoi(lit.Value); err == nil && code >= 100 && code <= 599 {
+							seen[code] = true
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+	var codes []int
+	for code := range seen {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	return codes
+}
+
+func callName(call *ast.CallExpr) string {
+	switch f := call.Fun.(type) {
+	case *ast.Ident:
+		return f.Name
+	case *ast.SelectorExpr:
+		return f.Sel.Name
+	}
+	return ""
+}
+
+// httpStatus maps the net/http status constants to their codes.
+var httpStatus = map[string]int{
+	"StatusContinue": 100, "StatusSwitchingProtocols": 101, "StatusProcessing": 102, "StatusEarlyHints": 103,
+	"StatusOK": 200, "StatusCreated": 201, "StatusAccepted": 202, "StatusNonAuthoritativeInfo": 203,
+	"StatusNoContent": 204, "StatusResetContent": 205, "StatusPartialContent": 206, "StatusMultiStatus": 207,
+	"StatusAlreadyReported": 208, "StatusIMUsed": 226,
+	"StatusMultipleChoices": 300, "StatusMovedPermanently": 301, "StatusFound": 302, "StatusSeeOther": 303,
+	"StatusNotModified": 304, "StatusUseProxy": 305, "StatusTemporaryRedirect": 307, "StatusPermanentRedirect": 308,
+	"StatusBadRequest": 400, "StatusUnauthorized": 401, "StatusPaymentRequired": 402, "StatusForbidden": 403,
+	"StatusNotFound": 404, "StatusMethodNotAllowed": 405, "StatusNotAcceptable": 406, "StatusProxyAuthRequired": 407,
+	"StatusRequestTimeout": 408, "StatusConflict": 409, "StatusGone": 410, "StatusLengthRequired": 411,
+	"StatusPreconditionFailed": 412, "StatusRequestEntityTooLarge": 413, "StatusRequestURITooLong": 414,
+	"StatusUnsupportedMediaType": 415, "StatusRequestedRangeNotSatisfiable": 416, "StatusExpectationFailed": 417,
+	"StatusTeapot": 418, "StatusMisdirectedRequest": 421, "StatusUnprocessableEntity": 422, "StatusLocked": 423,
+	"StatusFailedDependency": 424, "StatusTooEarly": 425, "StatusUpgradeRequired": 426, "StatusPreconditionRequired": 428,
+	"StatusTooManyRequests": 429, "StatusRequestHeaderFieldsTooLarge": 431, "StatusUnavailableForLegalReasons": 451,
+	"StatusInternalServerError": 500, "StatusNotImplemented": 501, "StatusBadGateway": 502, "StatusServiceUnavailable": 503,
+	"StatusGatewayTimeout": 504, "StatusHTTPVersionNotSupported": 505, "StatusVariantAlsoNegotiates": 506,
+	"StatusInsufficientStorage": 507, "StatusLoopDetected": 508, "StatusNotExtended": 510,
+	"StatusNetworkAuthenticationRequired": 511,
+}
+
+// resolveCommandPaths sets the path of every command from its AddCommand parents.
+// A command without parent is a root if it has subcommands or is the only one.
+func resolveCommandPaths(commands []*Command) {
+	hasChildren := make(map[*Command]bool)
+	orphans := 0
+	for _, c := range commands {
+		if c.parent != nil {
+			hasChildren[c.parent] = true
+		} else {
+			orphans++
+		}
+	}
+	for _, c := range commands {
+		if c.parent == nil && (hasChildren[c] || orphans == 1) {
+			c.isRoot = true
+		}
+	}
+	for _, c := range commands {
+		var parts []string
+		for cur, depth := c, 0; cur != nil && !cur.isRoot && depth < 32; cur, depth = cur.parent, depth+1 {
+			parts = append([]string{cur.name}, parts...)
+		}
+		c.Path = strings.Join(parts, " ")
+	}
+}
+
+// binaries returns a function mapping a package directory to the binary it is part
+// of: the directory itself for main packages, otherwise the only main package, if any.
+// Main packages at the module root map to "", like unknown ones.
+func binaries(tree *gosrc.Tree) func(dir string) string {
+	mains := make(map[string]bool)
+	for _, f := range tree.Files {
+		if !f.Test && f.Package == "main" {
+			mains[path.Dir(f.Path)] = true
+		}
+	}
+	only := ""
+	if len(mains) == 1 {
+		for dir := range mains {
+			only = dir
+		}
+	}
+	return func(dir string) string {
+		if !mains[dir] {
+			dir = only
+		}
+		if dir == "" || dir == "." {
+			return ""
+		}
+		return path.Base(dir)
+	}
+}
+
+// SetBinary attributes every command, flag and route to the named binary.
+func (s *Surface) SetBinary(name string) {
+	for i := range s.Commands {
+		s.Commands[i].Binary = name
+	}
+	for i := range s.Flags {
+		s.Flags[i].Binary = name
+	}
+	for i := range s.Routes {
+		s.Routes[i].Binary = name
+	}
+	for i := range s.Config {
+		s.Config[i].Binary = name
+	}
+	if t := s.Telemetry; t != nil {
+		for i := range t.Metrics {
+			t.Metrics[i].Binary = name
+		}
+		for i := range t.Logs {
+			t.Logs[i].Binary = name
+		}
+		for i := range t.Spans {
+			t.Spans[i].Binary = name
+		}
+	}
+}
+
+// SurfaceRequirement is a requirement derived from the surface.
+type SurfaceRequirement struct {
+	Path    string
+	Content string
+}
+
+// urlParamRe matches :name and *name parameters of gin, echo and httprouter routes.
+var urlParamRe = regexp.MustCompile(`([:*])([A-Za-z_][A-Za-z0-9_]*)?`)
+
+// Requirements returns one requirement per command, flag, route, configuration key and
+// metric, and one for the log fields and the spans of each binary,
+// named like <binary>/cli/commands/db/migrate, <binary>/cli/flags/--port,
+// <binary>/api/routes/GET/users/{id}, <binary>/config/env/PORT and
+// <binary>/observability/metrics/http_requests_total. Items without a known binary
+// use fallback.
+func (s *Surface) Requirements(fallback string) []SurfaceRequirement {
+	bin := func(b string) string {
+		if b == "" {
+			return fallback
+		}
+		return b
+	}
+	var reqs []SurfaceRequirement
+	for _, c := range s.Commands {
+		content := "Command: " + c.Use
+		if c.Short != "" {
+			content += "\n" + c.Short
+		}
+		reqs = append(reqs, SurfaceRequirement{
+			Path:    bin(c.Binary) + "/cli/" + commandPrefix(c.Path),
+			Content: content + fmt.Sprintf("\nSource: %s:%d", c.File, c.Line),
+		})
+	}
+	for _, f := range s.Flags {
+		prefix := "cli/"
+		if f.Command != "" {
+			prefix += commandPrefix(f.Command) + "/"
+		}
+		content := "Flag: --" + f.Name
+		if f.Short != "" {
+			content += ", -" + f.Short
+		}
+		content += " (" + f.Type
+		if f.Default != "" {
+			content += ", default " + f.Default
+		}
+		content += ")"
+		if f.Persistent {
+			content += ", inherited by subcommands"
+		}
+		if f.Usage != "" {
+			content += "\n" + f.Usage
+		}
+		reqs = append(reqs, SurfaceRequirement{
+			Path:    bin(f.Binary) + "/" + prefix + "flags/--" + f.Name,
+			Content: content + fmt.Sprintf("\nSource: %s:%d", f.File, f.Line),
+		})
+	}
+	for _, r := range s.Routes {
+		content := fmt.Sprintf("Route: %s %s -> %s", r.MethodName(), r.Path, r.Handler)
+		if len(r.Middlewares) > 0 {
+			content += "\nMiddleware: " + strings.Join(r.Middlewares, ", ")
+		}
+		if len(r.Statuses) > 0 {
+			content += "\nStatus: " + r.StatusList()
+		}
+		reqs = append(reqs, SurfaceRequirement{
+			Path:    r.RequirementPath(bin(r.Binary)),
+			Content: content + fmt.Sprintf("\nSource: %s:%d", r.File, r.Line),
+		})
+	}
+	for _, k := range s.Config {
+		reqs = append(reqs, SurfaceRequirement{
+			Path:    bin(k.Binary) + "/" + k.RequirementPath(),
+			Content: k.describe(),
+		})
+	}
+	if s.Telemetry != nil {
+		reqs = append(reqs, s.Telemetry.requirements(bin)...)
+	}
+	return reqs
+}
+
+// RequirementPath returns the requirement path below the binary: config/env/PORT or
+// config/features/new-checkout.
+func (k *ConfigKey) RequirementPath() string {
+	category := "env"
+	if k.Kind == KindFeature {
+		category = "features"
+	}
+	return "config/" + category + "/" + strings.ReplaceAll(k.Name, "/", "_")
+}
+
+func (k *ConfigKey) describe() string {
+	what := "Environment variable"
+	if k.Kind == KindFeature {
+		what = "Feature flag"
+	}
+	var attrs []string
+	if k.Default != "" {
+		attrs = append(attrs, "default "+k.Default)
+	}
+	if k.Required {
+		attrs = append(attrs, "required")
+	}
+	content := what + ": " + k.Name
+	if len(attrs) > 0 {
+		content += " (" + strings.Join(attrs, ", ") + ")"
+	}
+	uses := make([]string, len(k.Uses))
+	for i, u := range k.Uses {
+		uses[i] = fmt.Sprintf("%s:%d", u.File, u.Line)
+	}
+	return content + "\nRead via " + k.Via + "\nUsed at: " + strings.Join(uses, ", ")
+}
+
+// MethodName returns the method, ANY for routes matching every method.
+func (r *Route) MethodName() string {
+	if r.Method == "" {
+		return "ANY"
+	}
+	return r.Method
+}
+
+// RequirementPath returns the requirement the route is captured in, e.g.
+// <binary>/api/routes/GET/users/{id}. Parameters written as :id or *path become
+// {id} and {path...}, which are valid in file names on every platform.
+func (r *Route) RequirementPath(binary string) string {
+	route := strings.Trim(urlParamRe.ReplaceAllStringFunc(r.Path, func(p string) string {
+		if p[0] == '*' {
+			if len(p) == 1 {
+				return "{path...}"
+			}
+			return "{" + p[1:] + "...}"
+		}
+		return "{" + p[1:] + "}"
+	}), "/")
+	reqPath := binary + "/api/routes/" + r.MethodName()
+	if route != "" {
+		reqPath += "/" + route
+	}
+	return reqPath
+}
+
+// StatusList returns the status codes as a comma-separated list.
+func (r *Route) StatusList() string {
+	codes := make([]string, len(r.Statuses))
+	for i, code := range r.Statuses {
+		codes[i] = strconv.Itoa(code)
+	}
+	return strings.Join(codes, ", ")
+}
+
+// commandPrefix turns "db migrate" into "commands/db/migrate".
+func commandPrefix(cmdPath string) string {
+	return "commands/" + strings.ReplaceAll(cmdPath, " ", "/")
+}
+
+func isStringLit(expr ast.Expr) bool {
+	lit, ok := expr.(*ast.BasicLit)
+	return ok && lit.Kind == token.STRING
+}
+
+// stringLit returns the value of a string literal, or "" for anything else.
+func stringLit(expr ast.Expr) string {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return ""
+	}
+	s, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,233 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractSurface_Cobra(t *testing.T) {
+	tree := parseTree(t, map[string]string{
+		"cmd/app/main.go": `package main
+
+import "github.com/spf13/cobra"
+
+var port int
+
+var rootCmd = &cobra.Command{Use: "app", Short: "Runs the app"}
+
+func newServeCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "serve [addr]", Short: "Start the server"}
+	cmd.Flags().IntVarP(&port, "port", "p", 8080, "port to listen on")
+	fs := cmd.Flags()
+	fs.Bool("tls", false, "enable TLS")
+	_ = cmd.Flags().MarkHidden("tls")
+	return cmd
+}
+
+func init() {
+	rootCmd.PersistentFlags().String("config", "", "config file")
+	db := &cobra.Command{Use: "db"}
+	db.AddCommand(&cobra.Command{Use: "migrate"})
+	rootCmd.AddCommand(newServeCmd(), db)
+}
+
+func main() { _ = rootCmd.Execute() }
+`,
+		"cmd/app/main_test.go": `package main
+
+import "github.com/spf13/cobra"
+
+var testCmd = &cobra.Command{Use: "test"}
+`,
+	})
+
+	s := ExtractSurface(tree)
+	var commands []string
+	for _, c := range s.Commands {
+		commands = append(commands, c.Path)
+	}
+	if want := []string{"db", "db migrate", "serve"}; !reflect.DeepEqual(commands, want) {
+		t.Errorf("commands = %v, want %v", commands, want)
+	}
+
+	var flags []string
+	for _, f := range s.Flags {
+		flags = append(flags, f.Command+":"+f.Name+":"+f.Short+":"+f.Type+":"+f.Default)
+	}
+	want := []string{":config::string:\"\"", "serve:port:p:int:8080", "serve:tls::bool:false"}
+	if !reflect.DeepEqual(flags, want) {
+		t.Errorf("flags = %v, want %v", flags, want)
+	}
+	if !s.Flags[0].Persistent || s.Flags[1].Usage != "port to listen on" {
+		t.Errorf("flag details = %+v", s.Flags[:2])
+	}
+	if s.Flags[1].Binary != "app" || s.Flags[1].File != "cmd/app/main.go" {
+		t.Errorf("flag location = %+v", s.Flags[1])
+	}
+}
+
+func TestExtractSurface_StdlibFlags(t *testing.T) {
+	tree := parseTree(t, map[string]string{
+		"main.go": `package main
+
+import (
+	"flag"
+	"os"
+)
+
+func main() {
+	verbose := flag.Bool("v", false, "verbose output")
+	serve := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := serve.String("addr", ":8080", "listen address")
+	_, _ = verbose, addr
+	flag.Parse()
+	_ = serve.Parse(os.Args[2:])
+}
+`,
+	})
+
+	s := ExtractSurface(tree)
+	if len(s.Flags) != 2 {
+		t.Fatalf("flags = %+v, want 2", s.Flags)
+	}
+	if f := s.Flags[0]; f.Command != "" || f.Name != "v" || f.Type != "bool" {
+		t.Errorf("flag 0 = %+v", f)
+	}
+	if f := s.Flags[1]; f.Command != "serve" || f.Name != "addr" || f.Default != `":8080"` {
+		t.Errorf("flag 1 = %+v", f)
+	}
+}
+
+func TestExtractSurface_Routes(t *testing.T) {
+	tree := parseTree(t, map[string]string{
+		"server/routes.go": `package server
+
+import "net/http"
+
+func Routes(r *gin.Engine, m *mux.Router, c chi.Router) {
+	http.HandleFunc("GET /health", health)
+	http.Handle("/static/", http.FileServer(http.Dir(".")))
+
+	v1 := r.Group("/v1")
+	v1.GET("/users/:id", getUser)
+	r.Handle("DELETE", "/v1/users/:id", deleteUser)
+
+	api := m.PathPrefix("/api").Subrouter()
+	api.HandleFunc("/items", items).Methods("GET", "POST")
+
+	c.Route("/admin", func(r chi.Router) {
+		r.Post("/reload", reload)
+	})
+	cache.Get("key", 1)
+}
+`,
+	})
+
+	s := ExtractSurface(tree)
+	var routes []string
+	for _, r := range s.Routes {
+		routes = append(routes, r.Method+" "+r.Path+" "+r.Handler)
+	}
+	want := []string{
+		"POST /admin/reload reload",
+		"GET /api/items items",
+		"POST /api/items items",
+		"GET /health health",
+		` /static/ http.FileServer(http.Dir("."))`,
+		"DELETE /v1/users/:id deleteUser",
+		"GET /v1/users/:id getUser",
+	}
+	if !reflect.DeepEqual(routes, want) {
+		t.Errorf("routes =\n%q\nwant\n%q", routes, want)
+	}
+}
+
+func TestSurface_Requirements(t *testing.T) {
+	s := &Surface{
+		Commands: []Command{{Path: "db migrate", Use: "migrate", Short: "Apply migrations", Binary: "app", File: "main.go", Line: 3}},
+		Flags: []Flag{
+			{Name: "port", Short: "p", Type: "int", Default: "8080", Usage: "port", File: "main.go", Line: 5},
+			{Command: "serve", Name: "tls", Type: "bool", Binary: "app", File: "main.go", Line: 6},
+		},
+		Routes: []Route{
+			{Method: "GET", Path: "/users/:id", Handler: "getUser", File: "routes.go", Line: 9},
+			{Path: "/static/*filepath", Handler: "files", File: "routes.go", Line: 10},
+			{Method: "GET", Path: "/", Handler: "index", File: "routes.go", Line: 11},
+		},
+	}
+
+	var paths []string
+	reqs := s.Requirements("svc")
+	for _, r := range reqs {
+		paths = append(paths, r.Path)
+	}
+	want := []string{
+		"app/cli/commands/db/migrate",
+		"svc/cli/flags/--port",
+		"app/cli/commands/serve/flags/--tls",
+		"svc/api/routes/GET/users/{id}",
+		"svc/api/routes/ANY/static/{filepath...}",
+		"svc/api/routes/GET",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths = %q, want %q", paths, want)
+	}
+	if got := reqs[1].Content; got != "Flag: --port, -p (int, default 8080)\nport\nSource: main.go:5" {
+		t.Errorf("flag content = %q", got)
+	}
+	if got := reqs[3].Content; got != "Route: GET /users/:id -> getUser\nSource: routes.go:9" {
+		t.Errorf("route content = %q", got)
+	}
+}
+
+func TestExtractSurface_MiddlewaresAndStatuses(t *testing.T) {
+	tree := parseTree(t, map[string]string{
+		"api/api.go": `package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct{}
+
+func (h *Handler) getUser(c *gin.Context) {
+	if c.Param("id") == "" {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	c.JSON(200, nil)
+}
+
+func Routes(r *gin.Engine, h *Handler, c chi.Router) {
+	r.Use(gin.Logger())
+	v1 := r.Group("/v1", auth)
+	v1.GET("/users/:id", limit, h.getUser)
+	r.POST("/login", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	c.Group(func(r chi.Router) {
+		r.Use(admin)
+		r.With(audit).Delete("/cache", flush)
+	})
+}
+`,
+	})
+
+	s := ExtractSurface(tree)
+	got := make(map[string]Route)
+	for _, r := range s.Routes {
+		got[r.Method+" "+r.Path] = r
+	}
+	if r := got["GET /v1/users/:id"]; !reflect.DeepEqual(r.Middlewares, []string{"gin.Logger()", "auth", "limit"}) ||
+		!reflect.DeepEqual(r.Statuses, []int{200, 400}) || r.Handler != "h.getUser" {
+		t.Errorf("GET /v1/users/:id = %+v", r)
+	}
+	if r := got["POST /login"]; !reflect.DeepEqual(r.Statuses, []int{204}) || len(r.Middlewares) != 1 {
+		t.Errorf("POST /login = %+v", r)
+	}
+	if r := got["DELETE /cache"]; !reflect.DeepEqual(r.Middlewares, []string{"admin", "audit"}) || r.Statuses != nil {
+		t.Errorf("DELETE /cache = %+v", r)
+	}
+}
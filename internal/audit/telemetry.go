@@ -0,0 +1,377 @@
+package audit
+
+import (
+	"fmt"
+	"go/ast"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+// Telemetry is what a program exports for operations: metrics, structured log fields
+// and trace spans. Dashboards and alerts depend on their names, so each is a migration
+// requirement.
+type Telemetry struct {
+	Metrics []Metric
+	Logs    []LogField
+	Spans   []Span
+	// Instrumentation lists the OpenTelemetry contrib packages in use, such as
+	// go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp.
+	Instrumentation []string
+}
+
+// Metric is a registered Prometheus or OpenTelemetry metric.
+type Metric struct {
+	Name    string // full name, e.g. http_requests_total
+	Type    string // counter, gauge, histogram, summary or updowncounter
+	Help    string
+	Labels  []string
+	Library string // prometheus or opentelemetry
+	Binary  string
+	File    string
+	Line    int
+}
+
+// LogField is a structured log field key.
+type LogField struct {
+	Key       string
+	Libraries []string // slog, zap, zerolog, logrus
+	Uses      int
+	Binary    string // binary of the first use
+	File      string // first use
+	Line      int
+}
+
+// Span is an OpenTelemetry span started with a literal name.
+type Span struct {
+	Name   string
+	Binary string
+	File   string
+	Line   int
+}
+
+// Rust crates suggested for each kind of telemetry.
+var (
+	MetricCrates = map[string][]string{
+		"prometheus":    {"metrics", "metrics-exporter-prometheus"},
+		"opentelemetry": {"opentelemetry", "opentelemetry_sdk", "opentelemetry-otlp"},
+	}
+	LogCrates   = []string{"tracing", "tracing-subscriber"}
+	TraceCrates = []string{"tracing", "tracing-opentelemetry", "opentelemetry", "opentelemetry-otlp"}
+)
+
+const (
+	prometheusImport = "github.com/prometheus/client_golang/prometheus"
+	otelImport       = "go.opentelemetry.io/otel"
+	otelContrib      = "go.opentelemetry.io/contrib/instrumentation/"
+)
+
+// promConstructorRe matches prometheus.NewCounterVec, promauto.NewGauge and factory methods.
+var promConstructorRe = regexp.MustCompile(`^New(Counter|Gauge|Histogram|Summary)(Vec)?$`)
+
+// otelInstrumentRe matches OpenTelemetry meter methods such as Int64Counter.
+var otelInstrumentRe = regexp.MustCompile(`^(?:Int64|Float64)(?:Observable)?(Counter|UpDownCounter|Histogram|Gauge)$`)
+
+// logLibraries maps logging import paths to a short name.
+var logLibraries = map[string]string{
+	"log/slog":                   "slog",
+	"golang.org/x/exp/slog":      "slog",
+	"go.uber.org/zap":            "zap",
+	"github.com/rs/zerolog":      "zerolog",
+	"github.com/rs/zerolog/log":  "zerolog",
+	"github.com/sirupsen/logrus": "logrus",
+}
+
+// keyValueMethods take a message followed by alternating keys and values (slog, zap's
+// sugared logger and logr).
+var keyValueMethods = map[string]bool{
+	"Debug": true, "Info": true, "Warn": true, "Error": true, "Log": true,
+	"DebugContext": true, "InfoContext": true, "WarnContext": true, "ErrorContext": true,
+	"Debugw": true, "Infow": true, "Warnw": true, "Errorw": true, "Fatalw": true, "Panicw": true,
+}
+
+// zerologFields are the zerolog event methods taking a field key.
+var zerologFields = map[string]bool{
+	"Str": true, "Strs": true, "Stringer": true, "Int": true, "Int8": true, "Int16": true, "Int32": true,
+	"Int64": true, "Ints": true, "Uint": true, "Uint8": true, "Uint16": true, "Uint32": true, "Uint64": true,
+	"Float32": true, "Float64": true, "Bool": true, "Bools": true, "Dur": true, "Time": true, "TimeDiff": true,
+	"Interface": true, "Any": true, "Bytes": true, "Hex": true, "RawJSON": true, "IPAddr": true,
+	"MACAddr": true, "Object": true, "Dict": true, "Array": true, "AnErr": true,
+}
+
+// CollectTelemetry finds Prometheus and OpenTelemetry metric registrations, structured
+// log field keys of slog, zap, zerolog and logrus, and OpenTelemetry spans.
+func CollectTelemetry(tree *gosrc.Tree) *Telemetry {
+	t := &Telemetry{}
+	binary := binaries(tree)
+	fields := make(map[string]*LogField)
+	metrics := make(map[[2]string]bool)
+	contrib := make(map[string]bool)
+
+	for _, f := range tree.Files {
+		if f.Test {
+			continue
+		}
+		dir := path.Dir(f.Path)
+		bin := binary(dir)
+		imports := importNames(f.AST)
+		var promFile, otelFile bool
+		logLibs := make(map[string]bool)
+		for _, p := range imports {
+			promFile = promFile || strings.HasPrefix(p, prometheusImport)
+			otelFile = otelFile || strings.HasPrefix(p, otelImport)
+			if lib, ok := logLibraries[p]; ok {
+				logLibs[lib] = true
+			}
+			if strings.HasPrefix(p, otelContrib) && !contrib[p] {
+				contrib[p] = true
+				t.Instrumentation = append(t.Instrumentation, p)
+			}
+		}
+
+		addField := func(key, lib string, node ast.Node) {
+			if key == "" {
+				return
+			}
+			lf := fields[key]
+			if lf == nil {
+				file, line := tree.Position(node.Pos())
+				lf = &LogField{Key: key, Binary: bin, File: file, Line: line}
+				fields[key] = lf
+			}
+			lf.Uses++
+			lf.Libraries = appendUnique(lf.Libraries, lib)
+		}
+		addMetric := func(m Metric, node ast.Node) {
+			if m.Name == "" || metrics[[2]string{bin, m.Name}] {
+				return
+			}
+			metrics[[2]string{bin, m.Name}] = true
+			m.Binary = bin
+			m.File, m.Line = tree.Position(node.Pos())
+			t.Metrics = append(t.Metrics, m)
+		}
+
+		ast.Inspect(f.AST, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			name := sel.Sel.Name
+			pkg := ""
+			if x, ok := sel.X.(*ast.Ident); ok {
+				pkg = imports[x.Name]
+			}
+
+			switch {
+			case promFile && promConstructorRe.MatchString(name) && len(call.Args) > 0:
+				m := promMetric(call)
+				m.Type = strings.ToLower(promConstructorRe.FindStringSubmatch(name)[1])
+				addMetric(m, call)
+			case otelFile && otelInstrumentRe.MatchString(name) && len(call.Args) > 0 && isStringLit(call.Args[0]):
+				typ := strings.ToLower(otelInstrumentRe.FindStringSubmatch(name)[1])
+				addMetric(Metric{Name: stringLit(call.Args[0]), Type: typ, Library: "opentelemetry"}, call)
+			case otelFile && name == "Start" && len(call.Args) >= 2 && isStringLit(call.Args[1]):
+				file, line := tree.Position(call.Pos())
+				t.Spans = append(t.Spans, Span{Name: stringLit(call.Args[1]), Binary: bin, File: file, Line: line})
+			}
+
+			// Structured log fields.
+			switch {
+			case (logLibraries[pkg] == "slog" || logLibraries[pkg] == "zap") && !keyValueMethods[name] && name != "With":
+				// slog.String("key", v), zap.Int("key", v)
+				if len(call.Args) > 0 && isStringLit(call.Args[0]) && !strings.HasPrefix(name, "New") {
+					addField(stringLit(call.Args[0]), logLibraries[pkg], call)
+				}
+			case (logLibs["slog"] || logLibs["zap"]) && (keyValueMethods[name] || name == "With"):
+				lib := "slog"
+				if strings.HasSuffix(name, "w") || !logLibs["slog"] {
+					lib = "zap"
+				}
+				args := call.Args
+				switch {
+				case name == "With":
+					// key-value pairs only
+				case name == "Log" && len(args) > 2:
+					args = args[2:] // ctx, level, msg
+				case strings.HasSuffix(name, "Context") && len(args) > 0:
+					args = args[1:]
+				}
+				if name != "With" {
+					if len(args) == 0 || !isStringLit(args[0]) {
+						return true
+					}
+					args = args[1:] // message
+				}
+				for i := 0; i < len(args); {
+					if isStringLit(args[i]) {
+						addField(stringLit(args[i]), lib, args[i])
+						i += 2
+					} else {
+						i++
+					}
+				}
+			case logLibs["zerolog"] && zerologFields[name] && len(call.Args) > 0 && isStringLit(call.Args[0]):
+				addField(stringLit(call.Args[0]), "zerolog", call)
+			case logLibs["logrus"] && name == "WithField" && len(call.Args) > 0 && isStringLit(call.Args[0]):
+				addField(stringLit(call.Args[0]), "logrus", call)
+			case logLibs["logrus"] && name == "WithFields" && len(call.Args) == 1:
+				if lit, ok := call.Args[0].(*ast.CompositeLit); ok {
+					for _, elt := range lit.Elts {
+						if kv, ok := elt.(*ast.KeyValueExpr); ok && isStringLit(kv.Key) {
+							addField(stringLit(kv.Key), "logrus", kv)
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	for _, lf := range fields {
+		sort.Strings(lf.Libraries)
+		t.Logs = append(t.Logs, *lf)
+	}
+	sort.Slice(t.Metrics, func(i, j int) bool {
+		a, b := t.Metrics[i], t.Metrics[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	sort.Slice(t.Logs, func(i, j int) bool { return t.Logs[i].Key < t.Logs[j].Key })
+	sort.SliceStable(t.Spans, func(i, j int) bool { return t.Spans[i].Name < t.Spans[j].Name })
+	sort.Strings(t.Instrumentation)
+	return t
+}
+
+// promMetric reads the name, help and labels of a Prometheus constructor call such as
+// prometheus.NewCounterVec(prometheus.CounterOpts{Namespace: "app", Name: "x"}, []string{"code"}).
+func promMetric(call *ast.CallExpr) Metric {
+	m := Metric{Library: "prometheus"}
+	opts := compositeLit(call.Args[0])
+	if opts == nil {
+		return m
+	}
+	var parts [3]string
+	for _, elt := range opts.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		switch key.Name {
+		case "Namespace":
+			parts[0] = stringLit(kv.Value)
+		case "Subsystem":
+			parts[1] = stringLit(kv.Value)
+		case "Name":
+			parts[2] = stringLit(kv.Value)
+		case "Help":
+			m.Help = stringLit(kv.Value)
+		}
+	}
+	if parts[2] == "" {
+		return m
+	}
+	var name []string
+	for _, p := range parts {
+		if p != "" {
+			name = append(name, p)
+		}
+	}
+	m.Name = strings.Join(name, "_")
+	if len(call.Args) > 1 {
+		if labels, ok := call.Args[1].(*ast.CompositeLit); ok {
+			for _, elt := range labels.Elts {
+				if isStringLit(elt) {
+					m.Labels = append(m.Labels, stringLit(elt))
+				}
+			}
+		}
+	}
+	return m
+}
+
+// requirements returns one requirement per metric and one for the log fields and the
+// spans of each binary. bin resolves "" to the fallback binary.
+func (t *Telemetry) requirements(bin func(string) string) []SurfaceRequirement {
+	var reqs []SurfaceRequirement
+	for _, m := range t.Metrics {
+		content := fmt.Sprintf("Metric: %s (%s", m.Name, m.Type)
+		if len(m.Labels) > 0 {
+			content += ", labels: " + strings.Join(m.Labels, ", ")
+		}
+		content += ")"
+		if m.Help != "" {
+			content += "\nHelp: " + m.Help
+		}
+		content += fmt.Sprintf("\nLibrary: %s\nRust crates: %s\nSource: %s:%d",
+			m.Library, strings.Join(MetricCrates[m.Library], ", "), m.File, m.Line)
+		reqs = append(reqs, SurfaceRequirement{Path: bin(m.Binary) + "/observability/metrics/" + m.Name, Content: content})
+	}
+
+	var logBins []string
+	logs := make(map[string][]LogField)
+	for _, lf := range t.Logs {
+		b := bin(lf.Binary)
+		if _, ok := logs[b]; !ok {
+			logBins = append(logBins, b)
+		}
+		logs[b] = append(logs[b], lf)
+	}
+	for _, b := range logBins {
+		var libs []string
+		var lines []string
+		for _, lf := range logs[b] {
+			for _, lib := range lf.Libraries {
+				libs = appendUnique(libs, lib)
+			}
+			lines = append(lines, fmt.Sprintf("  %s (first at %s:%d, %d total)", lf.Key, lf.File, lf.Line, lf.Uses))
+		}
+		sort.Strings(libs)
+		reqs = append(reqs, SurfaceRequirement{
+			Path: b + "/observability/logs",
+			Content: fmt.Sprintf("Structured log fields (%s), keep the keys for log queries and alerts:\n%s\nRust crates: %s",
+				strings.Join(libs, ", "), strings.Join(lines, "\n"), strings.Join(LogCrates, ", ")),
+		})
+	}
+
+	var spanBins []string
+	spans := make(map[string][]string)
+	for _, sp := range t.Spans {
+		b := bin(sp.Binary)
+		if _, ok := spans[b]; !ok {
+			spanBins = append(spanBins, b)
+		}
+		spans[b] = append(spans[b], fmt.Sprintf("  %s (%s:%d)", sp.Name, sp.File, sp.Line))
+	}
+	if len(spanBins) == 0 && len(t.Instrumentation) > 0 {
+		spanBins = append(spanBins, bin(""))
+	}
+	for _, b := range spanBins {
+		content := "OpenTelemetry tracing"
+		if len(spans[b]) > 0 {
+			content += ", spans:\n" + strings.Join(spans[b], "\n")
+		}
+		if len(t.Instrumentation) > 0 {
+			content += "\nInstrumentation: " + strings.Join(t.Instrumentation, ", ")
+		}
+		content += "\nRust crates: " + strings.Join(TraceCrates, ", ")
+		reqs = append(reqs, SurfaceRequirement{Path: b + "/observability/traces", Content: content})
+	}
+	return reqs
+}
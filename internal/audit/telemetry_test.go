@@ -0,0 +1,109 @@
+package audit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCollectTelemetry(t *testing.T) {
+	tree := parseTree(t, map[string]string{
+		"cmd/api/main.go": `package main
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+var requests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "api",
+	Name:      "requests_total",
+	Help:      "Requests served.",
+}, []string{"method", "code"})
+
+var latency = prometheus.NewHistogram(prometheus.HistogramOpts{Name: "latency_seconds"})
+
+func handle(ctx context.Context, meter metric.Meter) {
+	ctx, span := otel.Tracer("api").Start(ctx, "handle request")
+	defer span.End()
+	_, _ = meter.Int64UpDownCounter("inflight")
+	slog.InfoContext(ctx, "served", "request_id", 1, slog.Int("status", 200))
+	slog.Info("served", "request_id", 2)
+	_ = otelhttp.NewHandler
+}
+`,
+		"worker/worker.go": `package worker
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/sirupsen/logrus"
+)
+
+func run() {
+	log.Info().Str("job", "x").Int("attempt", 1).Msg("started")
+	logrus.WithFields(logrus.Fields{"queue": "default"}).Info("polling")
+}
+`,
+	})
+
+	tel := CollectTelemetry(tree)
+
+	var metrics []string
+	for _, m := range tel.Metrics {
+		metrics = append(metrics, m.Library+":"+m.Type+":"+m.Name+":"+strings.Join(m.Labels, ","))
+	}
+	want := []string{
+		"prometheus:counter:api_requests_total:method,code",
+		"opentelemetry:updowncounter:inflight:",
+		"prometheus:histogram:latency_seconds:",
+	}
+	if !reflect.DeepEqual(metrics, want) {
+		t.Errorf("metrics = %v, want %v", metrics, want)
+	}
+	if tel.Metrics[0].Help != "Requests served." || tel.Metrics[0].Binary != "api" {
+		t.Errorf("metric details = %+v", tel.Metrics[0])
+	}
+
+	var fields []string
+	for _, lf := range tel.Logs {
+		fields = append(fields, lf.Key+":"+strings.Join(lf.Libraries, ","))
+	}
+	want = []string{"attempt:zerolog", "job:zerolog", "queue:logrus", "request_id:slog", "status:slog"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("log fields = %v, want %v", fields, want)
+	}
+	if tel.Logs[3].Uses != 2 {
+		t.Errorf("request_id uses = %d, want 2", tel.Logs[3].Uses)
+	}
+
+	if len(tel.Spans) != 1 || tel.Spans[0].Name != "handle request" {
+		t.Errorf("spans = %+v", tel.Spans)
+	}
+	if !reflect.DeepEqual(tel.Instrumentation, []string{"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"}) {
+		t.Errorf("instrumentation = %v", tel.Instrumentation)
+	}
+
+	reqs := (&Surface{Telemetry: tel}).Requirements("svc")
+	var paths []string
+	for _, r := range reqs {
+		paths = append(paths, r.Path)
+	}
+	want = []string{
+		"api/observability/metrics/api_requests_total",
+		"api/observability/metrics/inflight",
+		"api/observability/metrics/latency_seconds",
+		"api/observability/logs",
+		"api/observability/traces",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+	if !strings.Contains(reqs[0].Content, "Rust crates: metrics, metrics-exporter-prometheus") {
+		t.Errorf("metric content = %q", reqs[0].Content)
+	}
+}
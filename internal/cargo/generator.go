@@ -6,6 +6,7 @@ import (
 	"io"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/afero"
@@ -24,7 +25,19 @@ type MappedDependency struct {
 	GoDep        gomod.Dependency
 	RustTargets  []string
 	CrateNames   []string
+	Versions     []string // version requirement per crate; missing or empty means "*"
 	RequiredDeps []types.RequiredDep
+	// Origin names where the mapping comes from if not the database, such as
+	// .rinku/mappings.json. The comments of its crates note it.
+	Origin string
+}
+
+// version returns the version requirement for the i-th crate.
+func (m *MappedDependency) version(i int) string {
+	if i < len(m.Versions) && m.Versions[i] != "" {
+		return m.Versions[i]
+	}
+	return "*"
 }
 
 type UnmappedDependency struct {
@@ -32,8 +45,12 @@ type UnmappedDependency struct {
 }
 
 type GenerateResult struct {
-	Mapped   []MappedDependency
-	Unmapped []UnmappedDependency
+	Mapped          []MappedDependency
+	Unmapped        []UnmappedDependency
+	DevDependencies []types.RequiredDep
+	// Features are the features of the crates whose use in the Go source was analyzed,
+	// by crate name. Those crates are written without their default features.
+	Features map[string][]string
 }
 
 func MapDependencies(deps []gomod.Dependency, lookup Lookup, unsafe bool) *GenerateResult {
@@ -92,15 +109,33 @@ func ExtractCrateName(githubURL string) string {
 	}
 
 	repoName := parts[2]
-	// Handle subpaths like github.com/tokio-rs/tracing/tree/master/tracing-appender
-	if len(parts) >= 5 && parts[3] == "tree" {
-		repoName = parts[len(parts)-1]
+	// A crate in a subpath, github.com/tokio-rs/tracing/tree/master/tracing-appender, is
+	// named by its directory, which Normalize drops with the viewer path
+	if dir := subpath(githubURL); dir != "" {
+		repoName = dir
 	}
 
 	// Common Rust repo conventions: -rs suffix, hyphens become underscores
 	return strings.ReplaceAll(strings.TrimSuffix(repoName, "-rs"), "-", "_")
 }
 
+// subpath returns the last directory after /tree/<ref> of a repository URL, or "".
+func subpath(githubURL string) string {
+	u := strings.ToLower(githubURL)
+	if i := strings.IndexAny(u, "?#"); i >= 0 {
+		u = u[:i]
+	}
+	_, viewed, ok := strings.Cut(strings.TrimRight(u, "/"), "/tree/")
+	if !ok {
+		return ""
+	}
+	_, dir, ok := strings.Cut(viewed, "/")
+	if !ok {
+		return ""
+	}
+	return dir[strings.LastIndexByte(dir, '/')+1:]
+}
+
 func sanitizeCrateName(name string) (string, bool) {
 	if name == "" || strings.ContainsAny(name, "\n\r\"=[]{}") {
 		return "", false
@@ -123,22 +158,37 @@ func GenerateCargoToml(w io.Writer, moduleName string, result *GenerateResult) e
 	fmt.Fprintln(w, `edition = "2021"`)
 	fmt.Fprintln(w, "\n[dependencies]")
 
-	sort.Slice(result.Mapped, func(i, j int) bool {
-		if len(result.Mapped[i].CrateNames) > 0 && len(result.Mapped[j].CrateNames) > 0 {
-			return result.Mapped[i].CrateNames[0] < result.Mapped[j].CrateNames[0]
+	// Sorted by primary crate name, then Go module path; entries without a crate name
+	// come last.
+	sort.SliceStable(result.Mapped, func(i, j int) bool {
+		a, b := result.Mapped[i], result.Mapped[j]
+		if (len(a.CrateNames) > 0) != (len(b.CrateNames) > 0) {
+			return len(a.CrateNames) > 0
+		}
+		if len(a.CrateNames) > 0 && a.CrateNames[0] != b.CrateNames[0] {
+			return a.CrateNames[0] < b.CrateNames[0]
 		}
-		return false
+		return a.GoDep.Path < b.GoDep.Path
 	})
 
 	// Track which crates we've already output to avoid duplicates
 	outputCrates := make(map[string]bool)
 
 	for _, mapped := range result.Mapped {
+		origin := ""
+		if mapped.Origin != "" {
+			origin = " (" + mapped.Origin + ")"
+		}
 		n := min(len(mapped.CrateNames), len(mapped.RustTargets))
 		for i := 0; i < n; i++ {
 			if safeName, ok := sanitizeCrateName(mapped.CrateNames[i]); ok {
-				fmt.Fprintf(w, "%s = \"*\"  # from %s -> %s\n",
-					safeName, mapped.GoDep.Path, mapped.RustTargets[i])
+				if features, ok := result.Features[safeName]; ok {
+					fmt.Fprintf(w, "%s = { version = %q, default-features = false, features = %s }  # from %s -> %s%s\n",
+						safeName, mapped.version(i), featureList(features), mapped.GoDep.Path, mapped.RustTargets[i], origin)
+				} else {
+					fmt.Fprintf(w, "%s = %q  # from %s -> %s%s\n",
+						safeName, mapped.version(i), mapped.GoDep.Path, mapped.RustTargets[i], origin)
+				}
 				outputCrates[safeName] = true
 			} else {
 				fmt.Fprintf(w, "# WARNING: invalid crate name skipped for %s\n", mapped.GoDep.Path)
@@ -169,35 +219,61 @@ func GenerateCargoToml(w io.Writer, moduleName string, result *GenerateResult) e
 
 		for _, name := range reqNames {
 			dep := requiredDeps[name]
-			if len(dep.Features) > 0 {
-				fmt.Fprintf(w, "%s = { version = \"*\", features = [", name)
-				for i, f := range dep.Features {
-					if i > 0 {
-						fmt.Fprint(w, ", ")
-					}
-					fmt.Fprintf(w, "%q", f)
-				}
-				fmt.Fprint(w, "] }")
-			} else {
-				fmt.Fprintf(w, "%s = \"*\"", name)
-			}
-			if dep.Reason != "" {
-				fmt.Fprintf(w, "  # required: %s", dep.Reason)
+			features, analyzed := result.Features[name]
+			if analyzed {
+				dep.Features = features
 			}
-			fmt.Fprintln(w)
+			writeDependency(w, name, dep, !analyzed, "required")
 		}
 	}
 
 	if len(result.Unmapped) > 0 {
+		sort.SliceStable(result.Unmapped, func(i, j int) bool {
+			return result.Unmapped[i].GoDep.Path < result.Unmapped[j].GoDep.Path
+		})
 		fmt.Fprintln(w, "\n# TODO: Find equivalents for these Go dependencies:")
 		for _, unmapped := range result.Unmapped {
 			fmt.Fprintf(w, "# TODO: find equivalent for %s\n", unmapped.GoDep.Path)
 		}
 	}
 
+	if len(result.DevDependencies) > 0 {
+		fmt.Fprintln(w, "\n[dev-dependencies]")
+		for _, dep := range result.DevDependencies {
+			if safeName, ok := sanitizeCrateName(dep.Crate); ok {
+				writeDependency(w, safeName, dep, true, "test")
+			}
+		}
+	}
+
 	return nil
 }
 
+// writeDependency writes a single dependency line, with features and a reason comment if present.
+func writeDependency(w io.Writer, name string, dep types.RequiredDep, defaultFeatures bool, reasonLabel string) {
+	switch {
+	case !defaultFeatures:
+		fmt.Fprintf(w, "%s = { version = \"*\", default-features = false, features = %s }", name, featureList(dep.Features))
+	case len(dep.Features) > 0:
+		fmt.Fprintf(w, "%s = { version = \"*\", features = %s }", name, featureList(dep.Features))
+	default:
+		fmt.Fprintf(w, "%s = \"*\"", name)
+	}
+	if dep.Reason != "" {
+		fmt.Fprintf(w, "  # %s: %s", reasonLabel, dep.Reason)
+	}
+	fmt.Fprintln(w)
+}
+
+// featureList returns features as a TOML array, e.g. ["json", "query"].
+func featureList(features []string) string {
+	quoted := make([]string, len(features))
+	for i, f := range features {
+		quoted[i] = strconv.Quote(f)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
 func WriteCargoTomlFS(fs afero.Fs, path string, moduleName string, result *GenerateResult) (err error) {
 	file, err := fs.Create(path)
 	if err != nil {
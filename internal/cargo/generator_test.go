@@ -91,6 +91,16 @@ func TestExtractCrateName(t *testing.T) {
 			input: "https://github.com/tokio-rs/tracing/tree/master/tracing-appender",
 			want:  "tracing_appender",
 		},
+		{
+			name:  "crate in nested subpath",
+			input: "https://github.com/BurntSushi/ripgrep/tree/master/crates/ignore/",
+			want:  "ignore",
+		},
+		{
+			name:  "tree ref without subpath",
+			input: "https://github.com/clap-rs/clap/tree/master",
+			want:  "clap",
+		},
 	}
 
 	for _, tt := range tests {
@@ -215,6 +225,172 @@ func TestGenerateCargoToml(t *testing.T) {
 	}
 }
 
+func TestGenerateCargoToml_Order(t *testing.T) {
+	// Two Go modules mapping to the same crate are ordered by module path.
+	result := &GenerateResult{
+		Mapped: []MappedDependency{
+			{GoDep: gomod.Dependency{Path: "github.com/z/log"}, RustTargets: []string{"https://github.com/tokio-rs/tracing"}, CrateNames: []string{"tracing"}},
+			{GoDep: gomod.Dependency{Path: "github.com/a/log"}, RustTargets: []string{"https://github.com/tokio-rs/tracing"}, CrateNames: []string{"tracing"}},
+			{GoDep: gomod.Dependency{Path: "github.com/spf13/cobra"}, RustTargets: []string{"https://github.com/clap-rs/clap"}, CrateNames: []string{"clap"}},
+		},
+		Unmapped: []UnmappedDependency{
+			{GoDep: gomod.Dependency{Path: "github.com/y/lib"}},
+			{GoDep: gomod.Dependency{Path: "github.com/b/lib"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := GenerateCargoToml(&buf, "test-module", result); err != nil {
+		t.Fatalf("GenerateCargoToml() error = %v", err)
+	}
+	output := buf.String()
+	order := []string{"clap = ", "from github.com/a/log", "from github.com/z/log", "equivalent for github.com/b/lib", "equivalent for github.com/y/lib"}
+	last := -1
+	for _, s := range order {
+		idx := strings.Index(output, s)
+		if idx <= last {
+			t.Fatalf("%q out of order in:\n%s", s, output)
+		}
+		last = idx
+	}
+}
+
+func TestGenerateCargoToml_DevDependencies(t *testing.T) {
+	result := &GenerateResult{
+		DevDependencies: []types.RequiredDep{
+			{Crate: "mockall", Reason: "generated mocks (gomock)"},
+			{Crate: "tokio", Features: []string{"test-util"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := GenerateCargoToml(&buf, "test-module", result); err != nil {
+		t.Fatalf("GenerateCargoToml() error = %v", err)
+	}
+	output := buf.String()
+
+	if !strings.Contains(output, "\n[dev-dependencies]\n") {
+		t.Fatal("Output should contain [dev-dependencies] section")
+	}
+	devSection := output[strings.Index(output, "[dev-dependencies]"):]
+	if !strings.Contains(devSection, `mockall = "*"  # test: generated mocks (gomock)`) {
+		t.Errorf("dev-dependencies should contain mockall, got:\n%s", devSection)
+	}
+	if !strings.Contains(devSection, `tokio = { version = "*", features = ["test-util"] }`) {
+		t.Errorf("dev-dependencies should contain tokio with features, got:\n%s", devSection)
+	}
+}
+
+func TestGenerateCargoToml_Versions(t *testing.T) {
+	result := &GenerateResult{
+		Mapped: []MappedDependency{
+			{
+				GoDep:       gomod.Dependency{Path: "github.com/spf13/cobra"},
+				RustTargets: []string{"https://github.com/clap-rs/clap"},
+				CrateNames:  []string{"clap"},
+				Versions:    []string{"4.5.20"},
+			},
+			{
+				GoDep:       gomod.Dependency{Path: "github.com/sirupsen/logrus"},
+				RustTargets: []string{"https://github.com/rust-lang/log"},
+				CrateNames:  []string{"log"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := GenerateCargoToml(&buf, "test-module", result); err != nil {
+		t.Fatalf("GenerateCargoToml() error = %v", err)
+	}
+	output := buf.String()
+
+	if !strings.Contains(output, `clap = "4.5.20"  # from github.com/spf13/cobra`) {
+		t.Errorf("Output should use the pinned version, got:\n%s", output)
+	}
+	if !strings.Contains(output, `log = "*"  # from github.com/sirupsen/logrus`) {
+		t.Errorf("Output should default to \"*\" without a version, got:\n%s", output)
+	}
+}
+
+func TestGenerateCargoToml_Features(t *testing.T) {
+	result := &GenerateResult{
+		Mapped: []MappedDependency{
+			{
+				GoDep:        gomod.Dependency{Path: "github.com/gin-gonic/gin"},
+				RustTargets:  []string{"https://github.com/tokio-rs/axum"},
+				CrateNames:   []string{"axum"},
+				Versions:     []string{"0.7"},
+				RequiredDeps: []types.RequiredDep{{Crate: "tokio", Features: []string{"full"}, Reason: "async runtime for axum"}},
+			},
+		},
+		Features: map[string][]string{
+			"axum":  {"http1", "json", "tokio"},
+			"tokio": {"macros", "rt-multi-thread"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := GenerateCargoToml(&buf, "test-module", result); err != nil {
+		t.Fatalf("GenerateCargoToml() error = %v", err)
+	}
+	output := buf.String()
+
+	if !strings.Contains(output, `axum = { version = "0.7", default-features = false, features = ["http1", "json", "tokio"] }  # from github.com/gin-gonic/gin -> https://github.com/tokio-rs/axum`) {
+		t.Errorf("Output should request the analyzed features of axum, got:\n%s", output)
+	}
+	if !strings.Contains(output, `tokio = { version = "*", default-features = false, features = ["macros", "rt-multi-thread"] }  # required: async runtime for axum`) {
+		t.Errorf("Output should replace the features of the required tokio, got:\n%s", output)
+	}
+
+	m, err := ParseManifest(strings.NewReader(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Dependencies[0].Name != "axum" || m.Dependencies[0].Version != "0.7" || m.Dependencies[0].GoSource != "github.com/gin-gonic/gin" {
+		t.Errorf("ParseManifest() = %+v", m.Dependencies[0])
+	}
+}
+
+func TestGenerateCargoToml_Origin(t *testing.T) {
+	result := &GenerateResult{
+		Mapped: []MappedDependency{
+			{
+				GoDep:       gomod.Dependency{Path: "github.com/acme/auth"},
+				RustTargets: []string{"https://git.acme.dev/rust/auth"},
+				CrateNames:  []string{"acme-auth"},
+				Origin:      ".rinku/mappings.json",
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := GenerateCargoToml(&buf, "test-module", result); err != nil {
+		t.Fatalf("GenerateCargoToml() error = %v", err)
+	}
+	want := `acme-auth = "*"  # from github.com/acme/auth -> https://git.acme.dev/rust/auth (.rinku/mappings.json)`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Output should note the origin of the mapping, got:\n%s", buf.String())
+	}
+
+	m, err := ParseManifest(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Dependencies[0].RustURL != "https://git.acme.dev/rust/auth" {
+		t.Errorf("ParseManifest() RustURL = %q", m.Dependencies[0].RustURL)
+	}
+}
+
+func TestGenerateCargoToml_NoDevDependencies(t *testing.T) {
+	var buf bytes.Buffer
+	if err := GenerateCargoToml(&buf, "test-module", &GenerateResult{}); err != nil {
+		t.Fatalf("GenerateCargoToml() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "[dev-dependencies]") {
+		t.Error("Output should not contain [dev-dependencies] without dev dependencies")
+	}
+}
+
 func TestWriteCargoTomlFS(t *testing.T) {
 	fs := afero.NewMemMapFs()
 
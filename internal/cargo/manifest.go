@@ -0,0 +1,99 @@
+package cargo
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ManifestDependency is a dependency entry read from a Cargo.toml.
+type ManifestDependency struct {
+	Name     string
+	Version  string // version requirement, "*" if unspecified
+	Dev      bool   // from [dev-dependencies]
+	GoSource string // Go module path from a rinku "# from" comment, if present
+	RustURL  string // target URL from a rinku "# from" comment, if present
+}
+
+// Manifest is the subset of a Cargo.toml that rinku works with.
+type Manifest struct {
+	PackageName  string
+	Dependencies []ManifestDependency // sorted by name, dev-dependencies last
+}
+
+type rawManifest struct {
+	Package struct {
+		Name string `toml:"name"`
+	} `toml:"package"`
+	Dependencies    map[string]any `toml:"dependencies"`
+	DevDependencies map[string]any `toml:"dev-dependencies"`
+}
+
+// fromCommentRe matches the provenance comment written by GenerateCargoToml.
+var fromCommentRe = regexp.MustCompile(`^\s*([A-Za-z0-9_-]+)\s*=.*#\s*from\s+(\S+)\s+->\s+(\S+)`)
+
+// ParseManifest reads a Cargo.toml, including the provenance comments rinku adds.
+func ParseManifest(r io.Reader) (*Manifest, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw rawManifest
+	if err := toml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing Cargo.toml: %w", err)
+	}
+
+	type provenance struct{ goSource, rustURL string }
+	sources := make(map[string]provenance)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if m := fromCommentRe.FindStringSubmatch(scanner.Text()); m != nil {
+			sources[m[1]] = provenance{goSource: m[2], rustURL: m[3]}
+		}
+	}
+
+	m := &Manifest{PackageName: raw.Package.Name}
+	for _, section := range []struct {
+		deps map[string]any
+		dev  bool
+	}{{raw.Dependencies, false}, {raw.DevDependencies, true}} {
+		names := make([]string, 0, len(section.deps))
+		for name := range section.deps {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			dep := ManifestDependency{
+				Name:    name,
+				Version: dependencyVersion(section.deps[name]),
+				Dev:     section.dev,
+			}
+			if p, ok := sources[name]; ok && !section.dev {
+				dep.GoSource = p.goSource
+				dep.RustURL = p.rustURL
+			}
+			m.Dependencies = append(m.Dependencies, dep)
+		}
+	}
+	return m, nil
+}
+
+// dependencyVersion extracts the version requirement from a string or table entry.
+func dependencyVersion(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case map[string]any:
+		if s, ok := v["version"].(string); ok {
+			return s
+		}
+	}
+	return "*"
+}
@@ -0,0 +1,71 @@
+package cargo
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stephan/rinku/internal/gomod"
+)
+
+func TestParseManifest(t *testing.T) {
+	input := `# Generated by rinku
+[package]
+name = "converted_project"
+version = "0.1.0"
+
+[dependencies]
+clap = "*"  # from github.com/spf13/cobra -> https://github.com/clap-rs/clap
+tokio = { version = "1", features = ["full"] }  # required: async runtime
+serde = { features = ["derive"] }
+
+[dev-dependencies]
+mockall = "0.12"
+`
+	m, err := ParseManifest(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseManifest failed: %v", err)
+	}
+
+	if m.PackageName != "converted_project" {
+		t.Errorf("PackageName = %q, want converted_project", m.PackageName)
+	}
+	want := []ManifestDependency{
+		{Name: "clap", Version: "*", GoSource: "github.com/spf13/cobra", RustURL: "https://github.com/clap-rs/clap"},
+		{Name: "serde", Version: "*"},
+		{Name: "tokio", Version: "1"},
+		{Name: "mockall", Version: "0.12", Dev: true},
+	}
+	if !reflect.DeepEqual(m.Dependencies, want) {
+		t.Errorf("Dependencies = %+v, want %+v", m.Dependencies, want)
+	}
+}
+
+func TestParseManifest_RoundTrip(t *testing.T) {
+	result := &GenerateResult{
+		Mapped: []MappedDependency{{
+			GoDep:       gomod.Dependency{Path: "github.com/spf13/cobra"},
+			RustTargets: []string{"https://github.com/clap-rs/clap"},
+			CrateNames:  []string{"clap"},
+		}},
+	}
+	var buf bytes.Buffer
+	if err := GenerateCargoToml(&buf, "example", result); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := ParseManifest(&buf)
+	if err != nil {
+		t.Fatalf("ParseManifest failed: %v", err)
+	}
+	if len(m.Dependencies) != 1 || m.Dependencies[0].GoSource != "github.com/spf13/cobra" {
+		t.Errorf("Dependencies = %+v, want clap from github.com/spf13/cobra", m.Dependencies)
+	}
+}
+
+func TestParseManifest_Invalid(t *testing.T) {
+	if _, err := ParseManifest(strings.NewReader("[dependencies\n")); err == nil {
+		t.Error("expected error for invalid TOML")
+	}
+}
@@ -0,0 +1,176 @@
+package cargo
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	urlpkg "github.com/stephan/rinku/internal/url"
+)
+
+// ReverseLookup finds Go libraries for Rust crates.
+type ReverseLookup interface {
+	ReverseLookup(targetURL, sourceLang string, unsafe bool) []string
+	PackageURL(lang, name string) string
+}
+
+// GoDependency is a Cargo.toml dependency with Go equivalents.
+type GoDependency struct {
+	Crate   ManifestDependency
+	RustURL string
+	Modules []string // Go module paths, best first
+}
+
+// ReverseResult is the mapping of a Cargo.toml to Go modules.
+type ReverseResult struct {
+	Mapped   []GoDependency
+	Stdlib   []ManifestDependency // crates whose equivalent is the Go standard library
+	Unmapped []ManifestDependency
+}
+
+// goStdlib is the URL the database uses for the Go standard library.
+const goStdlib = "github.com/golang/go"
+
+// MapToGo maps the dependencies of a Cargo.toml to Go modules. A crate is identified
+// by the provenance comment rinku wrote for it, which also names the Go module it came
+// from, or else by its name in the database.
+func MapToGo(m *Manifest, lookup ReverseLookup, unsafe bool) *ReverseResult {
+	result := &ReverseResult{}
+	for _, dep := range m.Dependencies {
+		rustURL := dep.RustURL
+		if rustURL == "" {
+			rustURL = lookup.PackageURL("rust", dep.Name)
+		}
+		var modules []string
+		if dep.GoSource != "" {
+			modules = append(modules, dep.GoSource)
+		}
+		stdlib := false
+		if rustURL != "" {
+			for _, goURL := range lookup.ReverseLookup(rustURL, "go", unsafe) {
+				module := GoModulePath(goURL)
+				if module == goStdlib {
+					stdlib = true
+				} else if !slices.Contains(modules, module) {
+					modules = append(modules, module)
+				}
+			}
+		}
+		if len(modules) == 0 {
+			if stdlib {
+				result.Stdlib = append(result.Stdlib, dep)
+			} else {
+				result.Unmapped = append(result.Unmapped, dep)
+			}
+			continue
+		}
+		result.Mapped = append(result.Mapped, GoDependency{Crate: dep, RustURL: rustURL, Modules: modules})
+	}
+	return result
+}
+
+// ResolveTarget returns the library URL of the target of a reverse lookup: a library
+// URL as is, or the Rust library of a crates.io URL (crates.io/crates/serde) or bare
+// crate name (serde), found by its name in the database. It fails for a crate the
+// database does not list.
+func ResolveTarget(lookup ReverseLookup, target string) (string, error) {
+	name, ok := crateRef(target)
+	if !ok {
+		return target, nil
+	}
+	rustURL := lookup.PackageURL("rust", name)
+	if rustURL == "" {
+		return "", fmt.Errorf("no Rust library named %q in the database", name)
+	}
+	return rustURL, nil
+}
+
+// crateRef returns the crate name of a crates.io URL, with or without scheme, www. or
+// a version after the name, or of a bare crate name. It reports false for anything
+// else, such as a GitHub URL.
+func crateRef(target string) (string, bool) {
+	ref := strings.TrimPrefix(strings.TrimPrefix(target, "https://"), "http://")
+	ref = strings.TrimPrefix(ref, "www.")
+	if rest, ok := strings.CutPrefix(ref, "crates.io/crates/"); ok {
+		name, _, _ := strings.Cut(rest, "/")
+		return name, name != ""
+	}
+	if target == "" || strings.ContainsFunc(target, func(c rune) bool {
+		return !(c == '-' || c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z')
+	}) {
+		return "", false
+	}
+	return target, true
+}
+
+// GoModulePath returns the module path of a Go library URL, reversing
+// ModulePathToGitHubURL for the golang.org/x mirrors.
+func GoModulePath(libURL string) string {
+	path := urlpkg.Normalize(libURL)
+	if pkg, ok := strings.CutPrefix(path, "github.com/golang/"); ok && !strings.Contains(pkg, "/") {
+		switch pkg {
+		case "go", "mock", "protobuf", "snappy", "glog", "groupcache":
+			// github.com/golang modules that are not golang.org/x mirrors
+		default:
+			return "golang.org/x/" + pkg
+		}
+	}
+	return path
+}
+
+// GenerateGoMod writes a go.mod draft requiring the first Go module of every mapped
+// crate. Versions are "latest", which go mod tidy resolves; crates covered by the
+// standard library and unmapped crates are left as comments.
+func GenerateGoMod(w io.Writer, module string, result *ReverseResult) error {
+	fmt.Fprintln(w, "// Generated by rinku - https://github.com/marvai-dev/rinku")
+	fmt.Fprintln(w, "// Requirements are \"latest\": run go mod tidy to resolve them.")
+	fmt.Fprintf(w, "\nmodule %s\n\ngo 1.22\n", module)
+
+	// one requirement per module, sorted by path; a later crate mapping to the same
+	// module is noted on its line
+	crates := make(map[string][]GoDependency)
+	for _, m := range result.Mapped {
+		crates[m.Modules[0]] = append(crates[m.Modules[0]], m)
+	}
+	modules := make([]string, 0, len(crates))
+	for module := range crates {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	if len(modules) > 0 {
+		fmt.Fprintln(w, "\nrequire (")
+		for _, module := range modules {
+			var from []string
+			for _, m := range crates[module] {
+				from = append(from, m.Crate.Name)
+			}
+			fmt.Fprintf(w, "\t%s latest // from %s", module, strings.Join(from, ", "))
+			if m := crates[module][0]; m.RustURL != "" {
+				fmt.Fprintf(w, " -> %s", m.RustURL)
+			}
+			if alternatives := crates[module][0].Modules[1:]; len(alternatives) > 0 {
+				fmt.Fprintf(w, " (or %s)", strings.Join(alternatives, ", "))
+			}
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, ")")
+	}
+
+	if len(result.Stdlib) > 0 {
+		fmt.Fprintln(w, "\n// Covered by the Go standard library:")
+		for _, d := range result.Stdlib {
+			fmt.Fprintf(w, "// %s\n", d.Name)
+		}
+	}
+
+	if len(result.Unmapped) > 0 {
+		fmt.Fprintln(w, "\n// TODO: Find equivalents for these Rust crates:")
+		for _, u := range result.Unmapped {
+			fmt.Fprintf(w, "// TODO: find equivalent for %s\n", u.Name)
+		}
+	}
+	return nil
+}
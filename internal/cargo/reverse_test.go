@@ -0,0 +1,139 @@
+package cargo
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type reverseLookup struct {
+	reverse  map[string][]string // rust URL -> Go URLs
+	packages map[string]string   // crate name -> rust URL
+}
+
+func (l reverseLookup) ReverseLookup(targetURL, sourceLang string, unsafe bool) []string {
+	return l.reverse[targetURL]
+}
+
+func (l reverseLookup) PackageURL(lang, name string) string {
+	return l.packages[name]
+}
+
+func TestGoModulePath(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/spf13/cobra":        "github.com/spf13/cobra",
+		"https://github.com/golang/crypto":      "golang.org/x/crypto",
+		"https://github.com/golang/mock":        "github.com/golang/mock",
+		"https://github.com/golang/go":          "github.com/golang/go",
+		"https://www.github.com/Gin-Gonic/Gin/": "github.com/gin-gonic/gin",
+	}
+	for in, want := range tests {
+		if got := GoModulePath(in); got != want {
+			t.Errorf("GoModulePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestMapToGo(t *testing.T) {
+	lookup := reverseLookup{
+		reverse: map[string][]string{
+			"https://github.com/clap-rs/clap":      {"https://github.com/spf13/cobra", "https://github.com/urfave/cli"},
+			"https://github.com/RustCrypto/hashes": {"https://github.com/golang/crypto", "https://github.com/golang/go"},
+			"https://github.com/rust-lang/regex":   {"https://github.com/golang/go"},
+		},
+		packages: map[string]string{
+			"clap":  "https://github.com/clap-rs/clap",
+			"sha2":  "https://github.com/RustCrypto/hashes",
+			"regex": "https://github.com/rust-lang/regex",
+		},
+	}
+	m := &Manifest{PackageName: "app", Dependencies: []ManifestDependency{
+		{Name: "axum", Version: "*", GoSource: "github.com/gin-gonic/gin", RustURL: "https://github.com/tokio-rs/axum"},
+		{Name: "clap", Version: "4"},
+		{Name: "regex", Version: "1"},
+		{Name: "sha2", Version: "0.10"},
+		{Name: "acme-billing", Version: "0.3"},
+	}}
+
+	result := MapToGo(m, lookup, false)
+
+	var modules [][]string
+	for _, g := range result.Mapped {
+		modules = append(modules, g.Modules)
+	}
+	want := [][]string{
+		{"github.com/gin-gonic/gin"}, // from the provenance comment
+		{"github.com/spf13/cobra", "github.com/urfave/cli"},
+		{"golang.org/x/crypto"},
+	}
+	if !reflect.DeepEqual(modules, want) {
+		t.Errorf("modules = %v, want %v", modules, want)
+	}
+	if len(result.Stdlib) != 1 || result.Stdlib[0].Name != "regex" {
+		t.Errorf("Stdlib = %+v, want regex", result.Stdlib)
+	}
+	if len(result.Unmapped) != 1 || result.Unmapped[0].Name != "acme-billing" {
+		t.Errorf("Unmapped = %+v, want acme-billing", result.Unmapped)
+	}
+}
+
+func TestResolveTarget(t *testing.T) {
+	lookup := reverseLookup{packages: map[string]string{"serde": "https://github.com/serde-rs/serde"}}
+	tests := map[string]string{
+		"https://github.com/clap-rs/clap":        "https://github.com/clap-rs/clap",
+		"github.com/clap-rs/clap":                "github.com/clap-rs/clap",
+		"serde":                                  "https://github.com/serde-rs/serde",
+		"crates.io/crates/serde":                 "https://github.com/serde-rs/serde",
+		"https://crates.io/crates/serde/1.0.203": "https://github.com/serde-rs/serde",
+		"https://www.crates.io/crates/serde/":    "https://github.com/serde-rs/serde",
+	}
+	for in, want := range tests {
+		if got, err := ResolveTarget(lookup, in); got != want || err != nil {
+			t.Errorf("ResolveTarget(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"serde_yamlx", "crates.io/crates/acme"} {
+		if _, err := ResolveTarget(lookup, in); err == nil || !strings.Contains(err.Error(), "no Rust library named") {
+			t.Errorf("ResolveTarget(%q) = %v, want an unknown crate", in, err)
+		}
+	}
+}
+
+func TestGenerateGoMod(t *testing.T) {
+	result := &ReverseResult{
+		Mapped: []GoDependency{
+			{Crate: ManifestDependency{Name: "clap"}, RustURL: "https://github.com/clap-rs/clap", Modules: []string{"github.com/spf13/cobra", "github.com/urfave/cli"}},
+			{Crate: ManifestDependency{Name: "axum"}, RustURL: "https://github.com/tokio-rs/axum", Modules: []string{"github.com/gin-gonic/gin"}},
+			{Crate: ManifestDependency{Name: "structopt"}, Modules: []string{"github.com/spf13/cobra"}},
+		},
+		Stdlib:   []ManifestDependency{{Name: "regex"}},
+		Unmapped: []ManifestDependency{{Name: "acme-billing"}},
+	}
+	var buf bytes.Buffer
+	if err := GenerateGoMod(&buf, "example.com/app", result); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `// Generated by rinku - https://github.com/marvai-dev/rinku
+// Requirements are "latest": run go mod tidy to resolve them.
+
+module example.com/app
+
+go 1.22
+
+require (
+	github.com/gin-gonic/gin latest // from axum -> https://github.com/tokio-rs/axum
+	github.com/spf13/cobra latest // from clap, structopt -> https://github.com/clap-rs/clap (or github.com/urfave/cli)
+)
+
+// Covered by the Go standard library:
+// regex
+
+// TODO: Find equivalents for these Rust crates:
+// TODO: find equivalent for acme-billing
+`
+	if buf.String() != want {
+		t.Errorf("GenerateGoMod() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
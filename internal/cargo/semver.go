@@ -0,0 +1,157 @@
+package cargo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed semantic version. Pre-release and build metadata are ignored.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// ParseVersion parses "1.2.3", "1.2" or "1" (missing parts are zero).
+func ParseVersion(s string) (Version, error) {
+	v, _, err := parsePartial(s)
+	return v, err
+}
+
+// String returns the version as major.minor.patch.
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1, 0 or 1.
+func (v Version) Compare(o Version) int {
+	for _, d := range [3]int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// parsePartial parses a possibly partial version and reports how many parts were given.
+func parsePartial(s string) (Version, int, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "v")
+	if i := strings.IndexAny(s, "-+"); i != -1 {
+		s = s[:i]
+	}
+	if s == "" {
+		return Version{}, 0, fmt.Errorf("empty version")
+	}
+
+	var nums [3]int
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return Version{}, 0, fmt.Errorf("invalid version: %s", s)
+	}
+	n := 0
+	for i, p := range parts {
+		if p == "*" || p == "x" || p == "X" {
+			break
+		}
+		v, err := strconv.Atoi(p)
+		if err != nil || v < 0 {
+			return Version{}, 0, fmt.Errorf("invalid version: %s", s)
+		}
+		nums[i] = v
+		n++
+	}
+	return Version{nums[0], nums[1], nums[2]}, n, nil
+}
+
+// MatchesRequirement reports whether version satisfies a Cargo version requirement
+// such as "1.2", "^0.4", "~1.2.3", "=1.0.0", ">=1, <2" or "*".
+func MatchesRequirement(req, version string) (bool, error) {
+	v, err := ParseVersion(version)
+	if err != nil {
+		return false, err
+	}
+
+	for _, comparator := range strings.Split(req, ",") {
+		ok, err := matchComparator(strings.TrimSpace(comparator), v)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func matchComparator(c string, v Version) (bool, error) {
+	if c == "" || c == "*" {
+		return true, nil
+	}
+
+	op := ""
+	for _, prefix := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(c, prefix) {
+			op = prefix
+			c = strings.TrimSpace(c[len(prefix):])
+			break
+		}
+	}
+
+	base, n, err := parsePartial(c)
+	if err != nil {
+		return false, err
+	}
+	if n == 0 {
+		return true, nil // bare wildcard like "*" after an operator
+	}
+
+	switch op {
+	case ">=":
+		return v.Compare(base) >= 0, nil
+	case ">":
+		return v.Compare(base) > 0, nil
+	case "<=":
+		return v.Compare(base) <= 0, nil
+	case "<":
+		return v.Compare(base) < 0, nil
+	case "=":
+		return v.Compare(base) >= 0 && v.Compare(upperBound(base, n)) < 0, nil
+	case "~":
+		return v.Compare(base) >= 0 && v.Compare(tildeUpper(base, n)) < 0, nil
+	default: // caret, the Cargo default
+		return v.Compare(base) >= 0 && v.Compare(caretUpper(base, n)) < 0, nil
+	}
+}
+
+// upperBound returns the exclusive upper bound for an exact or partial "=" requirement.
+func upperBound(base Version, n int) Version {
+	switch n {
+	case 1:
+		return Version{base.Major + 1, 0, 0}
+	case 2:
+		return Version{base.Major, base.Minor + 1, 0}
+	default:
+		return Version{base.Major, base.Minor, base.Patch + 1}
+	}
+}
+
+func tildeUpper(base Version, n int) Version {
+	if n == 1 {
+		return Version{base.Major + 1, 0, 0}
+	}
+	return Version{base.Major, base.Minor + 1, 0}
+}
+
+func caretUpper(base Version, n int) Version {
+	switch {
+	case base.Major > 0 || n == 1:
+		return Version{base.Major + 1, 0, 0}
+	case base.Minor > 0 || n == 2:
+		return Version{0, base.Minor + 1, 0}
+	default:
+		return Version{0, 0, base.Patch + 1}
+	}
+}
@@ -0,0 +1,66 @@
+package cargo
+
+import "testing"
+
+func TestMatchesRequirement(t *testing.T) {
+	tests := []struct {
+		req     string
+		version string
+		want    bool
+	}{
+		{"*", "4.5.20", true},
+		{"4", "4.5.20", true},
+		{"4.2", "4.5.20", true},
+		{"3", "4.5.20", false},
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "1.2.2", false},
+		{"^0.4", "0.4.22", true},
+		{"0.4", "0.5.0", false},
+		{"0.0.3", "0.0.4", false},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1", "1.9.0", true},
+		{"=1.0.0", "1.0.0", true},
+		{"=1.0.0", "1.0.1", false},
+		{">=1, <2", "1.5.0", true},
+		{">=1, <2", "2.0.0", false},
+		{">0.9", "0.9.0", false},
+		{"1.*", "1.4.0", true},
+		{"1.*", "2.0.0", false},
+		{"1", "1.0.0-beta.2", true},
+	}
+
+	for _, tt := range tests {
+		got, err := MatchesRequirement(tt.req, tt.version)
+		if err != nil {
+			t.Errorf("MatchesRequirement(%q, %q) error: %v", tt.req, tt.version, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("MatchesRequirement(%q, %q) = %v, want %v", tt.req, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestMatchesRequirement_Invalid(t *testing.T) {
+	for _, tt := range []struct{ req, version string }{
+		{"1", "not-a-version"},
+		{"abc", "1.0.0"},
+		{"1.2.3.4", "1.0.0"},
+	} {
+		if _, err := MatchesRequirement(tt.req, tt.version); err == nil {
+			t.Errorf("MatchesRequirement(%q, %q) expected error", tt.req, tt.version)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	a, _ := ParseVersion("1.2.3")
+	b, _ := ParseVersion("1.10.0")
+	if a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 {
+		t.Errorf("Compare ordering wrong for %v and %v", a, b)
+	}
+	if a.String() != "1.2.3" {
+		t.Errorf("String() = %q, want 1.2.3", a.String())
+	}
+}
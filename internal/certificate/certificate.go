@@ -0,0 +1,209 @@
+// Package certificate writes the completion certificate of a migration,
+// .rinku/completion.json: a signed summary of the steps, requirement coverage and final
+// verify results that an organization can archive as evidence the workflow was
+// followed.
+package certificate
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/natefinch/atomic"
+
+	"github.com/stephan/rinku/internal/progress"
+)
+
+// File is the certificate below progress.ProgressDir.
+const File = "completion.json"
+
+// KeyEnv holds the key certificates are signed with.
+const KeyEnv = "RINKU_SIGNING_KEY"
+
+// Signature algorithms. Without a key the certificate carries a plain SHA-256 digest,
+// which detects accidental edits but not deliberate ones.
+const (
+	AlgorithmHMAC   = "hmac-sha256"
+	AlgorithmDigest = "sha256"
+)
+
+const currentVersion = 1
+
+// Certificate summarizes a completed migration.
+type Certificate struct {
+	Version      int          `json:"version"`
+	Project      string       `json:"project"`
+	Module       string       `json:"module,omitempty"`
+	StartedAt    time.Time    `json:"started_at"`
+	CompletedAt  time.Time    `json:"completed_at"`
+	IssuedAt     time.Time    `json:"issued_at"`
+	IssuedBy     string       `json:"issued_by,omitempty"`
+	Database     string       `json:"database"` // version of the mapping database
+	Steps        []Step       `json:"steps"`
+	Requirements Requirements `json:"requirements"`
+	VerifyResult VerifyResult `json:"verify"`
+	Signature    *Signature   `json:"signature,omitempty"`
+}
+
+// Step is the record of one workflow step.
+type Step struct {
+	ID             string    `json:"id"`
+	Status         string    `json:"status"` // completed or skipped
+	CompletedAt    time.Time `json:"completed_at,omitzero"`
+	CompletedBy    string    `json:"completed_by,omitempty"`
+	ElapsedSeconds int64     `json:"elapsed_seconds"`
+}
+
+// Requirements is the requirement coverage at completion.
+type Requirements struct {
+	Total    int      `json:"total"`
+	Done     int      `json:"done"`
+	Coverage int      `json:"coverage"` // percent done
+	Pending  []string `json:"pending"`  // requirements not done
+}
+
+// VerifyResult holds the results of rinku verify at completion.
+type VerifyResult struct {
+	Tags       []string   `json:"tags"`
+	Categories []Category `json:"categories"`
+}
+
+// Category is the coverage of one expected requirement category.
+type Category struct {
+	Category string `json:"category"`
+	Pattern  string `json:"pattern"`
+	Captured int    `json:"captured"`
+	Done     int    `json:"done"`
+}
+
+// Signature authenticates the rest of the certificate.
+type Signature struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"value"` // hex
+}
+
+// New returns a certificate of the steps of a completed migration.
+func New(project string, m *progress.Migration, now time.Time) *Certificate {
+	c := &Certificate{
+		Version:   currentVersion,
+		Project:   project,
+		StartedAt: m.StartedAt.UTC(),
+		IssuedAt:  now.UTC(),
+		Steps:     []Step{},
+	}
+	for _, id := range m.StepOrder {
+		rec := m.Steps[id]
+		if rec == nil {
+			continue
+		}
+		s := Step{ID: id, Status: string(rec.Status), CompletedBy: rec.CompletedBy, ElapsedSeconds: int64(rec.Elapsed(now) / time.Second)}
+		if rec.CompletedAt != nil {
+			s.CompletedAt = rec.CompletedAt.UTC()
+			if s.CompletedAt.After(c.CompletedAt) {
+				c.CompletedAt = s.CompletedAt
+			}
+		}
+		c.Steps = append(c.Steps, s)
+	}
+	return c
+}
+
+// Sign sets the signature: an HMAC-SHA256 with key, or a SHA-256 digest if key is
+// empty.
+func (c *Certificate) Sign(key []byte) error {
+	c.Signature = nil
+	sum, err := c.sum(key)
+	if err != nil {
+		return err
+	}
+	c.Signature = &Signature{Algorithm: algorithm(key), Value: hex.EncodeToString(sum)}
+	return nil
+}
+
+// Verify checks the signature with key. A certificate signed with a key does not
+// verify without it, and a digest does not verify with one.
+func (c *Certificate) Verify(key []byte) error {
+	sig := c.Signature
+	if sig == nil {
+		return errors.New("certificate is not signed")
+	}
+	if sig.Algorithm != algorithm(key) {
+		if sig.Algorithm == AlgorithmHMAC {
+			return fmt.Errorf("certificate is signed with a key; set %s", KeyEnv)
+		}
+		return fmt.Errorf("certificate has %s signature, want %s", sig.Algorithm, algorithm(key))
+	}
+	got, err := hex.DecodeString(sig.Value)
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+	c.Signature = nil
+	want, err := c.sum(key)
+	c.Signature = sig
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(got, want) {
+		return errors.New("signature does not match: the certificate was changed or signed with another key")
+	}
+	return nil
+}
+
+// sum returns the HMAC or digest of the certificate without its signature.
+func (c *Certificate) sum(key []byte) ([]byte, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling certificate: %w", err)
+	}
+	if len(key) == 0 {
+		sum := sha256.Sum256(data)
+		return sum[:], nil
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil), nil
+}
+
+func algorithm(key []byte) string {
+	if len(key) == 0 {
+		return AlgorithmDigest
+	}
+	return AlgorithmHMAC
+}
+
+// Path returns the certificate file of a project.
+func Path(projectDir string) string {
+	return filepath.Join(projectDir, progress.ProgressDir, File)
+}
+
+// Write saves a certificate to .rinku/completion.json.
+func Write(projectDir string, c *Certificate) error {
+	if err := os.MkdirAll(filepath.Join(projectDir, progress.ProgressDir), 0750); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling certificate: %w", err)
+	}
+	return atomic.WriteFile(Path(projectDir), bytes.NewReader(append(data, '\n')))
+}
+
+// Load reads .rinku/completion.json.
+func Load(projectDir string) (*Certificate, error) {
+	data, err := os.ReadFile(Path(projectDir)) //#nosec G304 -- projectDir from os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("reading certificate: %w", err)
+	}
+	var c Certificate
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parsing certificate: %w", err)
+	}
+	return &c, nil
+}
@@ -0,0 +1,83 @@
+package certificate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stephan/rinku/internal/progress"
+)
+
+func completed(t *testing.T) *progress.Migration {
+	t.Helper()
+	m := progress.New("/test", []string{"1", "2", "3"})
+	for _, id := range []string{"1", "3"} {
+		if err := m.StartStep(id, "alice"); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.CompleteStep(id, "", "alice"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m.Steps["2"].Status = progress.StepSkipped
+	return m
+}
+
+func TestNew(t *testing.T) {
+	m := completed(t)
+	c := New("app", m, time.Now())
+	if len(c.Steps) != 3 || c.Steps[1].Status != "skipped" || c.Steps[2].CompletedBy != "alice" {
+		t.Errorf("Steps = %+v", c.Steps)
+	}
+	if !c.CompletedAt.Equal(m.Steps["3"].CompletedAt.UTC()) {
+		t.Errorf("CompletedAt = %v, want the last completion %v", c.CompletedAt, m.Steps["3"].CompletedAt)
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	dir := t.TempDir()
+	key := []byte("secret")
+	c := New("app", completed(t), time.Now())
+	c.Requirements = Requirements{Total: 4, Done: 4, Coverage: 100}
+	if err := c.Sign(key); err != nil {
+		t.Fatal(err)
+	}
+	if err := Write(dir, c); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.Verify(key); err != nil {
+		t.Errorf("Verify with the signing key: %v", err)
+	}
+	if err := loaded.Verify([]byte("other")); err == nil {
+		t.Error("Verify with another key should fail")
+	}
+	if err := loaded.Verify(nil); err == nil {
+		t.Error("Verify of a keyed signature without key should fail")
+	}
+
+	loaded.Requirements.Done = 3
+	if err := loaded.Verify(key); err == nil {
+		t.Error("Verify of a changed certificate should fail")
+	}
+}
+
+func TestSign_Digest(t *testing.T) {
+	c := New("app", completed(t), time.Now())
+	if err := c.Sign(nil); err != nil {
+		t.Fatal(err)
+	}
+	if c.Signature.Algorithm != AlgorithmDigest {
+		t.Errorf("Algorithm = %q, want %q", c.Signature.Algorithm, AlgorithmDigest)
+	}
+	if err := c.Verify(nil); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	c.Project = "other"
+	if err := c.Verify(nil); err == nil {
+		t.Error("Verify of a changed certificate should fail")
+	}
+}
@@ -0,0 +1,325 @@
+// Package clitest runs a command-line program against txtar scripts and compares its
+// output to golden files.
+//
+// Each script is a txtar archive: the comment holds one command per line, the files are
+// extracted into an empty working directory first. Commands:
+//
+//	rinku args...         run the program (the name is Params.Command)
+//	! rinku args...       run it and expect a non-zero exit status
+//	cmp stdout|stderr f   compare the last output with file f (rewritten with -update)
+//	stdout|stderr regexp  the last output matches regexp; ! negates
+//	exists f              file f exists in the working directory
+//	env KEY=VALUE         set an environment variable for later commands
+//	stdin f               feed file f to the standard input of the next run
+//
+// Arguments may be quoted with single quotes. $WORK in arguments and the output is the
+// working directory.
+//
+// The commands and their syntax are a subset of those of
+// github.com/rogpeppe/go-internal/testscript, so scripts carry over if rinku ever needs
+// more of it. It is not used because of two differences the golden files rely on: the
+// working directory in the output is written as $WORK, so cmp files do not depend on
+// the temporary directory, and -update also adds a cmp file the script does not have
+// yet, so a new golden file is written by the first run. Both would need a fork of
+// testscript's cmp; the few commands here need nothing beyond the standard library.
+package clitest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// envMain tells a re-executed test binary to run the program instead of the tests.
+const envMain = "CLITEST_MAIN"
+
+// Main runs main instead of the tests when the test binary is executed by a script.
+// Call it from TestMain:
+//
+//	func TestMain(m *testing.M) { clitest.Main(m, main) }
+func Main(m *testing.M, main func()) {
+	if os.Getenv(envMain) != "" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// Params configures Run.
+type Params struct {
+	Dir     string   // directory with the *.txtar scripts
+	Command string   // name of the program in scripts
+	Env     []string // KEY=VALUE pairs set for every script
+	// UpdateGolden rewrites the files compared by cmp with the actual output instead
+	// of failing.
+	UpdateGolden bool
+}
+
+// Run runs each script in p.Dir as a parallel subtest named after the file.
+func Run(t *testing.T, p Params) {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(p.Dir, "*.txtar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("no scripts in %s", p.Dir)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".txtar")
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			runScript(t, p, exe, file)
+		})
+	}
+}
+
+// state is a script being run.
+type state struct {
+	p       Params
+	exe     string
+	archive *Archive
+	work    string
+	env     []string
+	stdin   string // file fed to the next run, if set
+	stdout  string
+	stderr  string
+	updated bool
+}
+
+func runScript(t *testing.T, p Params, exe, file string) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &state{p: p, exe: exe, archive: Parse(data), work: t.TempDir()}
+	s.env = append(os.Environ(), "HOME="+s.work, "WORK="+s.work, envMain+"=1")
+	s.env = append(s.env, p.Env...)
+	for _, f := range s.archive.Files {
+		path := filepath.Join(s.work, filepath.FromSlash(f.Name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, f.Data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, line := range strings.Split(string(s.archive.Comment), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		neg := false
+		if rest, ok := strings.CutPrefix(line, "!"); ok {
+			neg, line = true, strings.TrimSpace(rest)
+		}
+		args, err := splitArgs(line)
+		if err == nil {
+			for j := range args {
+				args[j] = strings.ReplaceAll(args[j], "$WORK", s.work)
+			}
+			err = s.exec(neg, args)
+		}
+		if err != nil {
+			t.Fatalf("%s:%d: %s: %v", filepath.Base(file), i+1, line, err)
+		}
+	}
+
+	if s.updated {
+		if err := os.WriteFile(file, Format(s.archive), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("updated %s", file)
+	}
+}
+
+func (s *state) exec(neg bool, args []string) error {
+	switch cmd := args[0]; cmd {
+	case s.p.Command:
+		return s.run(neg, args[1:])
+	case "cmp":
+		if neg || len(args) != 3 {
+			return errors.New("usage: cmp stdout|stderr file")
+		}
+		return s.cmp(args[1], args[2])
+	case "stdout", "stderr":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: %s regexp", cmd)
+		}
+		return s.match(neg, cmd, args[1])
+	case "exists":
+		if len(args) != 2 {
+			return errors.New("usage: exists file")
+		}
+		_, err := os.Stat(filepath.Join(s.work, args[1]))
+		if neg != (err != nil) {
+			return fmt.Errorf("exists %s: %v", args[1], err == nil)
+		}
+		return nil
+	case "env":
+		if neg || len(args) != 2 || !strings.Contains(args[1], "=") {
+			return errors.New("usage: env KEY=VALUE")
+		}
+		s.env = append(s.env, args[1])
+		return nil
+	case "stdin":
+		if neg || len(args) != 2 {
+			return errors.New("usage: stdin file")
+		}
+		if s.archive.file(args[1]) == nil {
+			return fmt.Errorf("no file %s in the script", args[1])
+		}
+		s.stdin = args[1]
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+// run executes the program with args in the working directory.
+func (s *state) run(neg bool, args []string) error {
+	cmd := exec.Command(s.exe, args...)
+	cmd.Dir = s.work
+	cmd.Env = s.env
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if s.stdin != "" {
+		cmd.Stdin = bytes.NewReader(s.archive.file(s.stdin).Data)
+		s.stdin = ""
+	}
+	err := cmd.Run()
+	s.stdout = strings.ReplaceAll(stdout.String(), s.work, "$WORK")
+	s.stderr = strings.ReplaceAll(stderr.String(), s.work, "$WORK")
+
+	var exitErr *exec.ExitError
+	switch {
+	case err != nil && !errors.As(err, &exitErr):
+		return err
+	case neg && err == nil:
+		return errors.New("unexpected success")
+	case !neg && err != nil:
+		return fmt.Errorf("%v\nstderr:\n%s", err, s.stderr)
+	}
+	return nil
+}
+
+func (s *state) output(name string) (string, error) {
+	switch name {
+	case "stdout":
+		return s.stdout, nil
+	case "stderr":
+		return s.stderr, nil
+	}
+	return "", fmt.Errorf("unknown output %q", name)
+}
+
+// cmp compares an output with an archive file, or replaces the file if golden files are
+// updated.
+func (s *state) cmp(name, file string) error {
+	got, err := s.output(name)
+	if err != nil {
+		return err
+	}
+	f := s.archive.file(file)
+	if s.p.UpdateGolden {
+		if f == nil {
+			s.archive.Files = append(s.archive.Files, File{Name: file})
+			f = &s.archive.Files[len(s.archive.Files)-1]
+		}
+		if string(f.Data) != got {
+			f.Data = []byte(got)
+			s.updated = true
+		}
+		return nil
+	}
+	if f == nil {
+		return fmt.Errorf("no file %s in the script (run with -update to create it)", file)
+	}
+	if want := string(fixNL(f.Data)); string(fixNL([]byte(got))) != want {
+		return fmt.Errorf("%s differs from %s (run with -update to accept)\n%s", name, file, diff(want, got))
+	}
+	return nil
+}
+
+func (s *state) match(neg bool, name, pattern string) error {
+	got, err := s.output(name)
+	if err != nil {
+		return err
+	}
+	re, err := regexp.Compile(`(?m)` + pattern)
+	if err != nil {
+		return err
+	}
+	if re.MatchString(got) == neg {
+		if neg {
+			return fmt.Errorf("%s unexpectedly matches %q:\n%s", name, pattern, got)
+		}
+		return fmt.Errorf("%s does not match %q:\n%s", name, pattern, got)
+	}
+	return nil
+}
+
+// diff returns the lines of want and got from the first difference on, prefixed with
+// - and +.
+func diff(want, got string) string {
+	wl := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	gl := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	i := 0
+	for i < len(wl) && i < len(gl) && wl[i] == gl[i] {
+		i++
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "first difference at line %d:\n", i+1)
+	for _, l := range wl[i:min(len(wl), i+5)] {
+		b.WriteString("- " + l + "\n")
+	}
+	for _, l := range gl[i:min(len(gl), i+5)] {
+		b.WriteString("+ " + l + "\n")
+	}
+	return b.String()
+}
+
+// splitArgs splits a script line into words; single quotes group words and a doubled
+// quote inside them is a literal quote.
+func splitArgs(line string) ([]string, error) {
+	var args []string
+	var word strings.Builder
+	inWord, quoted := false, false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quoted && c == '\'' && i+1 < len(line) && line[i+1] == '\'':
+			word.WriteByte('\'')
+			i++
+		case c == '\'':
+			quoted, inWord = !quoted, true
+		case !quoted && (c == ' ' || c == '\t'):
+			if inWord {
+				args = append(args, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	if quoted {
+		return nil, errors.New("unterminated quote")
+	}
+	if inWord {
+		args = append(args, word.String())
+	}
+	return args, nil
+}
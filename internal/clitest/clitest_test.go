@@ -0,0 +1,85 @@
+package clitest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	a := Parse([]byte("# comment\nrinku scan go.mod\n-- go.mod --\nmodule x\n-- out.golden --\nno newline"))
+	if string(a.Comment) != "# comment\nrinku scan go.mod\n" {
+		t.Errorf("comment = %q", a.Comment)
+	}
+	want := []File{{"go.mod", []byte("module x\n")}, {"out.golden", []byte("no newline\n")}}
+	if !reflect.DeepEqual(a.Files, want) {
+		t.Errorf("files = %q, want %q", a.Files, want)
+	}
+
+	if got := string(Format(a)); got != "# comment\nrinku scan go.mod\n-- go.mod --\nmodule x\n-- out.golden --\nno newline\n" {
+		t.Errorf("Format = %q", got)
+	}
+}
+
+func TestParse_NotMarkers(t *testing.T) {
+	a := Parse([]byte("--  --\n-- x\n--x--\n"))
+	if len(a.Files) != 0 {
+		t.Errorf("files = %q, want none", a.Files)
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"rinku scan go.mod", []string{"rinku", "scan", "go.mod"}},
+		{"stdout  '^Mapped 2/3'", []string{"stdout", "^Mapped 2/3"}},
+		{"stderr 'don''t'", []string{"stderr", "don't"}},
+		{"env A=''", []string{"env", "A="}},
+	}
+	for _, tt := range tests {
+		got, err := splitArgs(tt.line)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitArgs(%q) = %q, %v; want %q", tt.line, got, err, tt.want)
+		}
+	}
+	if _, err := splitArgs("stdout 'open"); err == nil {
+		t.Error("unterminated quote: no error")
+	}
+}
+
+func TestCmp(t *testing.T) {
+	s := &state{archive: Parse([]byte("-- out.golden --\nold\n")), stdout: "new\n"}
+	err := s.cmp("stdout", "out.golden")
+	if err == nil || !strings.Contains(err.Error(), "- old\n+ new") {
+		t.Errorf("err = %v, want diff", err)
+	}
+	if err := s.cmp("stdout", "missing.golden"); err == nil || !strings.Contains(err.Error(), "-update") {
+		t.Errorf("err = %v, want hint to run with -update", err)
+	}
+
+	s.p.UpdateGolden = true
+	if err := s.cmp("stdout", "out.golden"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.cmp("stdout", "missing.golden"); err != nil {
+		t.Fatal(err)
+	}
+	if !s.updated || string(Format(s.archive)) != "-- out.golden --\nnew\n-- missing.golden --\nnew\n" {
+		t.Errorf("archive = %q", Format(s.archive))
+	}
+}
+
+func TestMatch(t *testing.T) {
+	s := &state{stdout: "Module: x\nMapped 2/3 direct dependencies\n"}
+	if err := s.match(false, "stdout", "^Mapped 2/3"); err != nil {
+		t.Error(err)
+	}
+	if err := s.match(true, "stdout", "^Mapped 2/3"); err == nil {
+		t.Error("negated match: no error")
+	}
+	if err := s.match(false, "stderr", "."); err == nil {
+		t.Error("empty stderr matched")
+	}
+}
@@ -0,0 +1,87 @@
+package clitest
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Archive is a txtar archive: a comment (the script) followed by files, each starting
+// with a "-- name --" line.
+type Archive struct {
+	Comment []byte
+	Files   []File
+}
+
+type File struct {
+	Name string
+	Data []byte
+}
+
+// Parse parses a txtar archive. Like golang.org/x/tools/txtar, it never fails: text
+// before the first marker is the comment, and a final line without newline gets one.
+func Parse(data []byte) *Archive {
+	a := &Archive{}
+	var name string
+	a.Comment, name, data = findMarker(data)
+	for name != "" {
+		f := File{Name: name}
+		f.Data, name, data = findMarker(data)
+		a.Files = append(a.Files, f)
+	}
+	return a
+}
+
+// Format returns the txtar form of a, the inverse of Parse.
+func Format(a *Archive) []byte {
+	var buf bytes.Buffer
+	buf.Write(fixNL(a.Comment))
+	for _, f := range a.Files {
+		buf.WriteString("-- " + f.Name + " --\n")
+		buf.Write(fixNL(f.Data))
+	}
+	return buf.Bytes()
+}
+
+// file returns the archive file with the given name, or nil.
+func (a *Archive) file(name string) *File {
+	for i := range a.Files {
+		if a.Files[i].Name == name {
+			return &a.Files[i]
+		}
+	}
+	return nil
+}
+
+// findMarker returns the text before the next file marker line, the file name of the
+// marker and the text after it. name is empty if there is no further marker.
+func findMarker(data []byte) (before []byte, name string, after []byte) {
+	var i int
+	for {
+		if name, after = markerName(data[i:]); name != "" {
+			return fixNL(data[:i]), name, after
+		}
+		j := bytes.IndexByte(data[i:], '\n')
+		if j < 0 {
+			return fixNL(data), "", nil
+		}
+		i += j + 1
+	}
+}
+
+// markerName returns the file name if data starts with a marker line, and the text
+// after the line.
+func markerName(data []byte) (name string, after []byte) {
+	line, rest, _ := bytes.Cut(data, []byte("\n"))
+	s := strings.TrimRight(string(line), "\r")
+	if !strings.HasPrefix(s, "-- ") || !strings.HasSuffix(s, " --") || len(s) < 7 {
+		return "", nil
+	}
+	return strings.TrimSpace(s[3 : len(s)-3]), rest
+}
+
+func fixNL(data []byte) []byte {
+	if len(data) == 0 || data[len(data)-1] == '\n' {
+		return data
+	}
+	return append(data[:len(data):len(data)], '\n')
+}
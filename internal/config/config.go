@@ -0,0 +1,141 @@
+// Package config loads the optional .rinku.toml project configuration.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/stephan/rinku/internal/httpclient"
+	"github.com/stephan/rinku/internal/workspace"
+)
+
+// FileName is the project configuration file, next to go.mod.
+const FileName = ".rinku.toml"
+
+// Config is the project configuration. The zero value is the default.
+type Config struct {
+	Notify       []Notify          `toml:"notify"`
+	Crates       workspace.Policy  `toml:"crates"` // crate naming of rinku workspace
+	HTTP         httpclient.Policy `toml:"http"`   // retries and concurrency of API requests
+	GitHub       GitHub            `toml:"github"`
+	Migration    Migration         `toml:"migration"` // choices of rinku init
+	Requirements Requirements      `toml:"requirements"`
+}
+
+// Migration strategies of the [migration] table.
+const (
+	StrategyRewrite     = "rewrite"     // port the whole program, then switch over
+	StrategyIncremental = "incremental" // replace packages one at a time behind FFI or RPC seams
+)
+
+// Migration records how a project is migrated, the [migration] table written by
+// rinku init:
+//
+//	[migration]
+//	strategy = "incremental"
+//	go_mod = "go.mod"
+//	strict_order = true
+//	auto_advance = true
+//	strict_checklist = true
+type Migration struct {
+	Strategy string `toml:"strategy"` // StrategyRewrite or StrategyIncremental
+	GoMod    string `toml:"go_mod"`   // go.mod of the main module, relative to the project
+	// StrictOrder refuses migrate --finish while earlier steps are open.
+	StrictOrder bool `toml:"strict_order"`
+	// AutoAdvance moves the current step to the next open one on migrate --finish.
+	AutoAdvance bool `toml:"auto_advance"`
+	// StrictChecklist refuses migrate --finish while "- [ ]" items of the step are not
+	// checked with migrate check.
+	StrictChecklist bool `toml:"strict_checklist"`
+}
+
+// Requirements configures rinku req, the [requirements] table:
+//
+//	[requirements]
+//	namespace = "steps/{step}"
+//
+// With a namespace, requirements set while a step is in progress are stored below it,
+// with {step} replaced by the step ID, e.g. steps/2a/svc/cli/flags/--port.
+type Requirements struct {
+	Namespace string `toml:"namespace"`
+}
+
+// GitHub configures requests to the GitHub API, the [github] table:
+//
+//	[github]
+//	token = "${RINKU_GITHUB_TOKEN}"
+//
+// A GITHUB_TOKEN environment variable takes precedence.
+type GitHub struct {
+	Token string `toml:"token"` // environment variables are expanded
+}
+
+// Notify is a notification hook, a [[notify]] table:
+//
+//	[[notify]]
+//	url = "${SLACK_WEBHOOK_URL}"
+//	format = "slack"
+//	events = ["step_completed", "coverage", "migration_finished"]
+//	coverage_thresholds = [50, 100]
+type Notify struct {
+	URL        string   `toml:"url"`                 // environment variables are expanded
+	Format     string   `toml:"format"`              // "json" (default) or "slack"
+	Events     []string `toml:"events"`              // all events if empty
+	Thresholds []int    `toml:"coverage_thresholds"` // percent of requirements done
+}
+
+// Load reads .rinku.toml from projectDir. A missing file yields the default config.
+func Load(projectDir string) (*Config, error) {
+	path := filepath.Join(projectDir, FileName)
+	data, err := os.ReadFile(path) //#nosec G304 -- projectDir from os.Getwd()
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", FileName, err)
+	}
+
+	var c Config
+	md, err := toml.Decode(string(data), &c)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", FileName, err)
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("parsing %s: unknown key %s", FileName, undecoded[0])
+	}
+	for i := range c.Notify {
+		n := &c.Notify[i]
+		n.URL = os.ExpandEnv(n.URL)
+		if n.URL == "" {
+			return nil, fmt.Errorf("%s: notify #%d has no url", FileName, i+1)
+		}
+		switch n.Format {
+		case "":
+			n.Format = "json"
+		case "json", "slack":
+		default:
+			return nil, fmt.Errorf("%s: notify #%d: unknown format %q (json or slack)", FileName, i+1, n.Format)
+		}
+	}
+	c.GitHub.Token = os.ExpandEnv(c.GitHub.Token)
+	switch c.Migration.Strategy {
+	case "", StrategyRewrite, StrategyIncremental:
+	default:
+		return nil, fmt.Errorf("%s: migration: unknown strategy %q (rewrite or incremental)", FileName, c.Migration.Strategy)
+	}
+	if c.Requirements.Namespace != "" && !strings.Contains(c.Requirements.Namespace, "{step}") {
+		return nil, fmt.Errorf("%s: requirements: namespace %q does not contain {step}", FileName, c.Requirements.Namespace)
+	}
+	if err := c.Crates.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: crates: %w", FileName, err)
+	}
+	if err := c.HTTP.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: http: %w", FileName, err)
+	}
+	return &c, nil
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoad_Missing(t *testing.T) {
+	c, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(c.Notify) != 0 {
+		t.Errorf("Notify = %v, want none", c.Notify)
+	}
+}
+
+func TestLoad_Notify(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TEST_HOOK", "https://hooks.example.com/abc")
+	content := `
+[[notify]]
+url = "${TEST_HOOK}"
+format = "slack"
+events = ["step_completed"]
+
+[[notify]]
+url = "https://dash.example.com/events"
+coverage_thresholds = [50, 100]
+`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(c.Notify) != 2 {
+		t.Fatalf("len(Notify) = %d, want 2", len(c.Notify))
+	}
+	if c.Notify[0].URL != "https://hooks.example.com/abc" {
+		t.Errorf("URL = %q, want expanded env", c.Notify[0].URL)
+	}
+	if c.Notify[1].Format != "json" {
+		t.Errorf("default Format = %q, want json", c.Notify[1].Format)
+	}
+	if len(c.Notify[1].Thresholds) != 2 {
+		t.Errorf("Thresholds = %v", c.Notify[1].Thresholds)
+	}
+}
+
+func TestLoad_Crates(t *testing.T) {
+	dir := t.TempDir()
+	content := "[crates]\nprefix = \"acme-\"\ncase = \"snake\"\ninternal = true\n"
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if c.Crates.Prefix != "acme-" || c.Crates.Case != "snake" || !c.Crates.Internal {
+		t.Errorf("Crates = %+v", c.Crates)
+	}
+}
+
+func TestLoad_HTTP(t *testing.T) {
+	dir := t.TempDir()
+	content := "[http]\nmax_retries = 5\nmax_concurrency = 2\nmax_delay = \"2m\"\n"
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if c.HTTP.MaxRetries != 5 || c.HTTP.MaxConcurrency != 2 || c.HTTP.MaxDelay != 2*time.Minute {
+		t.Errorf("HTTP = %+v", c.HTTP)
+	}
+}
+
+func TestLoad_GitHub(t *testing.T) {
+	t.Setenv("RINKU_TEST_GITHUB_TOKEN", "ghp_test")
+	dir := t.TempDir()
+	content := "[github]\ntoken = \"${RINKU_TEST_GITHUB_TOKEN}\"\n"
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if c.GitHub.Token != "ghp_test" {
+		t.Errorf("Token = %q, want expanded env", c.GitHub.Token)
+	}
+}
+
+func TestLoad_Migration(t *testing.T) {
+	dir := t.TempDir()
+	content := "[migration]\nstrategy = \"incremental\"\ngo_mod = \"svc/go.mod\"\nstrict_order = true\nauto_advance = true\n"
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if c.Migration.Strategy != StrategyIncremental || c.Migration.GoMod != "svc/go.mod" || !c.Migration.StrictOrder || !c.Migration.AutoAdvance {
+		t.Errorf("Migration = %+v", c.Migration)
+	}
+}
+
+func TestLoad_Requirements(t *testing.T) {
+	dir := t.TempDir()
+	content := "[requirements]\nnamespace = \"steps/{step}\"\n"
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if c.Requirements.Namespace != "steps/{step}" {
+		t.Errorf("Namespace = %q", c.Requirements.Namespace)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	for _, content := range []string{
+		"[[notify]]\nformat = \"slack\"\n",
+		"[[notify]]\nurl = \"https://x\"\nformat = \"xml\"\n",
+		"[[notify]]\nurl = \"https://x\"\nthreshold = 5\n",
+		"[crates]\ncase = \"camel\"\n",
+		"[crates]\ncollisions = \"merge\"\n",
+		"[http]\nmax_retries = -1\n",
+		"[http]\ntimeout = \"soon\"\n",
+		"[migration]\nstrategy = \"big-bang\"\n",
+		"[requirements]\nnamespace = \"phase\"\n",
+	} {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(dir); err == nil {
+			t.Errorf("expected error for %q", content)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// Package configgen generates Rust configuration scaffolding from Go config files.
+package configgen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Loader names a Rust configuration crate.
+type Loader string
+
+const (
+	LoaderFigment  Loader = "figment"
+	LoaderConfigRS Loader = "config-rs"
+)
+
+// Library is a Go configuration library and the Rust loader that best matches it.
+type Library struct {
+	Name       string
+	ModulePath string
+	Loader     Loader
+}
+
+// Libraries lists the Go configuration libraries that are detected.
+var Libraries = []Library{
+	{Name: "viper", ModulePath: "github.com/spf13/viper", Loader: LoaderConfigRS},
+	{Name: "koanf", ModulePath: "github.com/knadh/koanf", Loader: LoaderFigment},
+	{Name: "envconfig", ModulePath: "github.com/kelseyhightower/envconfig", Loader: LoaderFigment},
+	{Name: "cleanenv", ModulePath: "github.com/ilyakaznacheev/cleanenv", Loader: LoaderFigment},
+	{Name: "godotenv", ModulePath: "github.com/joho/godotenv", Loader: LoaderFigment},
+}
+
+// DetectLibraries returns the configuration libraries among the given module paths.
+func DetectLibraries(modulePaths []string) []Library {
+	var result []Library
+	for _, lib := range Libraries {
+		for _, p := range modulePaths {
+			if p == lib.ModulePath || strings.HasPrefix(p, lib.ModulePath+"/") {
+				result = append(result, lib)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Format is a configuration file format.
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatTOML Format = "toml"
+	FormatJSON Format = "json"
+	FormatEnv  Format = "env"
+)
+
+// ConfigFile is a discovered configuration file.
+type ConfigFile struct {
+	Path   string // slash-separated, relative to the search root
+	Format Format
+}
+
+// maxSearchDepth limits how deep FindConfigFiles descends below the root.
+const maxSearchDepth = 3
+
+var configStemRe = regexp.MustCompile(`^(config|settings|app|application)([._-].*)?$`)
+
+// FindConfigFiles looks for configuration files under root, sorted by path.
+// Structured files must be named like config.yaml or settings.toml, or live in a
+// config/ or configs/ directory. Env files are .env, .env.* and *.env.
+func FindConfigFiles(root string) ([]ConfigFile, error) {
+	var files []ConfigFile
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			name := d.Name()
+			if strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata" || name == "node_modules" {
+				return filepath.SkipDir
+			}
+			if strings.Count(rel, "/") >= maxSearchDepth-1 {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if format, ok := classify(rel); ok {
+			files = append(files, ConfigFile{Path: rel, Format: format})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+func classify(rel string) (Format, bool) {
+	name := filepath.Base(rel)
+	if name == ".env" || strings.HasPrefix(name, ".env.") || strings.HasSuffix(name, ".env") {
+		return FormatEnv, true
+	}
+
+	ext := filepath.Ext(name)
+	var format Format
+	switch ext {
+	case ".yaml", ".yml":
+		format = FormatYAML
+	case ".toml":
+		format = FormatTOML
+	case ".json":
+		format = FormatJSON
+	default:
+		return "", false
+	}
+
+	dir := filepath.Base(filepath.Dir(rel))
+	stem := strings.TrimSuffix(name, ext)
+	if dir == "config" || dir == "configs" || configStemRe.MatchString(strings.ToLower(stem)) {
+		return format, true
+	}
+	return "", false
+}
+
+// ParseFile reads a configuration file into a generic value tree.
+func ParseFile(path string, format Format) (map[string]any, error) {
+	data, err := os.ReadFile(path) //#nosec G304 -- path discovered under the project directory
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	values, err := Parse(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// Parse decodes configuration data of the given format.
+func Parse(data []byte, format Format) (map[string]any, error) {
+	values := make(map[string]any)
+	switch format {
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, &values); err != nil {
+			return nil, err
+		}
+	case FormatTOML:
+		if err := toml.Unmarshal(data, &values); err != nil {
+			return nil, err
+		}
+	case FormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&values); err != nil {
+			return nil, err
+		}
+	case FormatEnv:
+		return parseEnv(data)
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+	return values, nil
+}
+
+// parseEnv reads KEY=VALUE lines, inferring scalar types from the values.
+// Keys are lowercased because figment and config-rs both lowercase environment variables.
+func parseEnv(data []byte) (map[string]any, error) {
+	values := make(map[string]any)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		values[key] = envValue(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func envValue(s string) any {
+	if b, err := strconv.ParseBool(s); err == nil && (s == "true" || s == "false") {
+		return b
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && strings.Contains(s, ".") {
+		return f
+	}
+	return s
+}
@@ -0,0 +1,213 @@
+package configgen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDetectLibraries(t *testing.T) {
+	libs := DetectLibraries([]string{
+		"github.com/spf13/cobra",
+		"github.com/spf13/viper",
+		"github.com/knadh/koanf/v2",
+	})
+
+	var names []string
+	for _, lib := range libs {
+		names = append(names, lib.Name)
+	}
+	want := []string{"viper", "koanf"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("DetectLibraries names = %v, want %v", names, want)
+	}
+}
+
+func TestFindConfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, rel := range []string{
+		"config.yaml",
+		"settings.local.toml",
+		"configs/prod.json",
+		".env",
+		".env.example",
+		"package.json",
+		"docs/readme.yaml",
+		".github/config.yml",
+		"a/b/c/config.yaml",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x: 1\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := FindConfigFiles(dir)
+	if err != nil {
+		t.Fatalf("FindConfigFiles failed: %v", err)
+	}
+
+	want := []ConfigFile{
+		{Path: ".env", Format: FormatEnv},
+		{Path: ".env.example", Format: FormatEnv},
+		{Path: "config.yaml", Format: FormatYAML},
+		{Path: "configs/prod.json", Format: FormatJSON},
+		{Path: "settings.local.toml", Format: FormatTOML},
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("FindConfigFiles = %v, want %v", files, want)
+	}
+}
+
+func TestParse_Formats(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		data   string
+	}{
+		{"yaml", FormatYAML, "server:\n  port: 8080\n  debug: true\n"},
+		{"toml", FormatTOML, "[server]\nport = 8080\ndebug = true\n"},
+		{"json", FormatJSON, `{"server": {"port": 8080, "debug": true}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := Parse([]byte(tt.data), tt.format)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			s := NewSchema()
+			s.Add(values)
+
+			var buf bytes.Buffer
+			if err := Generate(&buf, s, Options{Loader: LoaderFigment}); err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			out := buf.String()
+			for _, want := range []string{"pub server: Server,", "pub struct Server {", "pub port: i64,", "pub debug: bool,"} {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestParse_Env(t *testing.T) {
+	values, err := Parse([]byte("# comment\nDATABASE_URL=postgres://localhost\nexport PORT=8080\nDEBUG=\"true\"\nRATIO=0.5\n"), FormatEnv)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	want := map[string]any{
+		"database_url": "postgres://localhost",
+		"port":         int64(8080),
+		"debug":        true,
+		"ratio":        0.5,
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("Parse(env) = %v, want %v", values, want)
+	}
+}
+
+func TestGenerate_SchemaDetails(t *testing.T) {
+	values, err := Parse([]byte(`
+maxConns: 10
+type: web
+timeout: null
+ratio: 1
+hosts: [a, b]
+upstreams:
+  - name: api
+    weight: 1
+  - name: auth
+    weight: 0.5
+`), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	s := NewSchema()
+	s.Add(values)
+	s.Add(map[string]any{"ratio": 1.5})
+
+	var buf bytes.Buffer
+	if err := Generate(&buf, s, Options{Loader: LoaderFigment}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"#[serde(rename = \"maxConns\")]\n    pub max_conns: i64,",
+		"pub r#type: String,",
+		"pub timeout: Option<String>,",
+		"pub ratio: f64,",
+		"pub hosts: Vec<String>,",
+		"pub upstreams: Vec<UpstreamsItem>,",
+		"pub struct UpstreamsItem {",
+		"pub weight: f64,",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `rename = "type"`) {
+		t.Error("raw identifiers should not need a rename")
+	}
+}
+
+func TestGenerate_Loaders(t *testing.T) {
+	s := NewSchema()
+	s.Add(map[string]any{"port": 8080})
+	sources := []ConfigFile{{Path: "config.yaml", Format: FormatYAML}, {Path: ".env", Format: FormatEnv}}
+
+	var figment bytes.Buffer
+	if err := Generate(&figment, s, Options{Loader: LoaderFigment, Sources: sources}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	for _, want := range []string{
+		`use figment::{providers::{Env, Format, Yaml}, Figment};`,
+		`.merge(Yaml::file("config.yaml"))`,
+		`dotenvy::dotenv().ok();`,
+		`figment = { version = "0.10", features = ["env", "yaml"] }`,
+	} {
+		if !strings.Contains(figment.String(), want) {
+			t.Errorf("figment output missing %q:\n%s", want, figment.String())
+		}
+	}
+
+	var configRS bytes.Buffer
+	if err := Generate(&configRS, s, Options{Loader: LoaderConfigRS, Sources: sources}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	for _, want := range []string{
+		`.add_source(::config::File::with_name("config.yaml").required(false))`,
+		`.add_source(::config::Environment::default())`,
+		`config = "0.14"`,
+	} {
+		if !strings.Contains(configRS.String(), want) {
+			t.Errorf("config-rs output missing %q:\n%s", want, configRS.String())
+		}
+	}
+}
+
+func TestFieldName(t *testing.T) {
+	tests := map[string]string{
+		"port":         "port",
+		"maxConns":     "max_conns",
+		"max-conns":    "max_conns",
+		"DATABASE_URL": "database_url",
+		"2fa":          "field_2fa",
+		"match":        "r#match",
+		"--":           "field",
+	}
+	for input, want := range tests {
+		if got := fieldName(input); got != want {
+			t.Errorf("fieldName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,425 @@
+package configgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type kind int
+
+const (
+	kindNull kind = iota
+	kindString
+	kindInt
+	kindFloat
+	kindBool
+	kindList
+	kindMap
+)
+
+// node is an inferred schema for one configuration value.
+type node struct {
+	kind     kind
+	optional bool
+	elem     *node            // element schema for lists
+	fields   map[string]*node // field schemas for maps
+}
+
+// Schema is the merged, inferred schema of one or more configuration sources.
+type Schema struct {
+	root *node
+}
+
+// NewSchema returns an empty schema.
+func NewSchema() *Schema {
+	return &Schema{root: &node{kind: kindMap, fields: make(map[string]*node)}}
+}
+
+// Add merges the values of one configuration source into the schema.
+func (s *Schema) Add(values map[string]any) {
+	s.root = merge(s.root, infer(values))
+}
+
+// Empty reports whether the schema has no fields.
+func (s *Schema) Empty() bool {
+	return len(s.root.fields) == 0
+}
+
+func infer(v any) *node {
+	switch v := v.(type) {
+	case nil:
+		return &node{kind: kindNull}
+	case bool:
+		return &node{kind: kindBool}
+	case int, int64, uint64:
+		return &node{kind: kindInt}
+	case float64:
+		return &node{kind: kindFloat}
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return &node{kind: kindInt}
+		}
+		return &node{kind: kindFloat}
+	case string, time.Time:
+		return &node{kind: kindString}
+	case map[string]any:
+		n := &node{kind: kindMap, fields: make(map[string]*node)}
+		for k, fv := range v {
+			n.fields[k] = infer(fv)
+		}
+		return n
+	case []map[string]any:
+		n := &node{kind: kindList}
+		for _, item := range v {
+			n.elem = merge(n.elem, infer(item))
+		}
+		return n
+	case []any:
+		n := &node{kind: kindList}
+		for _, item := range v {
+			n.elem = merge(n.elem, infer(item))
+		}
+		return n
+	default:
+		return &node{kind: kindString}
+	}
+}
+
+// merge combines two schemas for the same key. Conflicting scalars widen to string.
+func merge(a, b *node) *node {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.kind == kindNull {
+		c := *b
+		c.optional = true
+		return &c
+	}
+	if b.kind == kindNull {
+		c := *a
+		c.optional = true
+		return &c
+	}
+
+	optional := a.optional || b.optional
+	switch {
+	case a.kind == b.kind && a.kind == kindMap:
+		n := &node{kind: kindMap, optional: optional, fields: make(map[string]*node)}
+		for k, f := range a.fields {
+			n.fields[k] = f
+		}
+		for k, f := range b.fields {
+			n.fields[k] = merge(n.fields[k], f)
+		}
+		return n
+	case a.kind == b.kind && a.kind == kindList:
+		return &node{kind: kindList, optional: optional, elem: merge(a.elem, b.elem)}
+	case a.kind == b.kind:
+		return &node{kind: a.kind, optional: optional}
+	case (a.kind == kindInt && b.kind == kindFloat) || (a.kind == kindFloat && b.kind == kindInt):
+		return &node{kind: kindFloat, optional: optional}
+	default:
+		return &node{kind: kindString, optional: optional}
+	}
+}
+
+// Options control Rust code generation.
+type Options struct {
+	Loader  Loader
+	Sources []ConfigFile // files the generated loader reads, in merge order
+}
+
+// rustStruct is a struct to be emitted.
+type rustStruct struct {
+	name   string
+	fields []rustField
+}
+
+type rustField struct {
+	name string // Rust identifier
+	key  string // original config key
+	typ  string
+}
+
+// Generate writes a Rust config module for the schema.
+func Generate(w io.Writer, s *Schema, opts Options) error {
+	g := &generator{used: make(map[string]bool)}
+	g.structFor("Config", s.root)
+
+	fmt.Fprintln(w, "// Generated by rinku - https://github.com/marvai-dev/rinku")
+	if len(opts.Sources) > 0 {
+		var paths []string
+		for _, src := range opts.Sources {
+			paths = append(paths, src.Path)
+		}
+		fmt.Fprintf(w, "// Inferred from: %s\n", strings.Join(paths, ", "))
+	}
+	fmt.Fprintln(w, "//")
+	fmt.Fprintln(w, "// Cargo.toml:")
+	for _, line := range cargoLines(opts) {
+		fmt.Fprintf(w, "//   %s\n", line)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "use serde::Deserialize;")
+	fmt.Fprintln(w)
+
+	for _, st := range g.structs {
+		fmt.Fprintln(w, "#[derive(Debug, Clone, Deserialize)]")
+		fmt.Fprintf(w, "pub struct %s {\n", st.name)
+		for _, f := range st.fields {
+			if strings.TrimPrefix(f.name, "r#") != f.key {
+				fmt.Fprintf(w, "    #[serde(rename = %q)]\n", f.key)
+			}
+			fmt.Fprintf(w, "    pub %s: %s,\n", f.name, f.typ)
+		}
+		fmt.Fprintln(w, "}")
+		fmt.Fprintln(w)
+	}
+
+	return writeLoader(w, opts)
+}
+
+func cargoLines(opts Options) []string {
+	lines := []string{`serde = { version = "1", features = ["derive"] }`}
+	formats := make(map[Format]bool)
+	for _, src := range opts.Sources {
+		formats[src.Format] = true
+	}
+
+	switch opts.Loader {
+	case LoaderConfigRS:
+		lines = append(lines, `config = "0.14"`)
+	default:
+		features := []string{"env"}
+		for _, f := range []Format{FormatJSON, FormatTOML, FormatYAML} {
+			if formats[f] {
+				features = append(features, string(f))
+			}
+		}
+		sort.Strings(features)
+		lines = append(lines, fmt.Sprintf(`figment = { version = "0.10", features = [%s] }`, quoteJoin(features)))
+	}
+	if formats[FormatEnv] {
+		lines = append(lines, `dotenvy = "0.15"`)
+	}
+	return lines
+}
+
+func quoteJoin(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = fmt.Sprintf("%q", item)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func writeLoader(w io.Writer, opts Options) error {
+	hasEnvFile := false
+	var files []ConfigFile
+	for _, src := range opts.Sources {
+		if src.Format == FormatEnv {
+			hasEnvFile = true
+			continue
+		}
+		files = append(files, src)
+	}
+
+	fmt.Fprintln(w, "impl Config {")
+	switch opts.Loader {
+	case LoaderConfigRS:
+		fmt.Fprintln(w, "    pub fn load() -> Result<Self, ::config::ConfigError> {")
+		if hasEnvFile {
+			fmt.Fprintln(w, "        dotenvy::dotenv().ok();")
+		}
+		fmt.Fprintln(w, "        ::config::Config::builder()")
+		for _, f := range files {
+			fmt.Fprintf(w, "            .add_source(::config::File::with_name(%q).required(false))\n", f.Path)
+		}
+		fmt.Fprintln(w, "            .add_source(::config::Environment::default())")
+		fmt.Fprintln(w, "            .build()?")
+		fmt.Fprintln(w, "            .try_deserialize()")
+	case LoaderFigment, "":
+		providers := []string{"Env"}
+		for _, f := range files {
+			providers = append(providers, figmentProvider(f.Format))
+		}
+		providers = unique(providers)
+		if len(providers) > 1 {
+			providers = append(providers, "Format")
+		}
+		sort.Strings(providers)
+		fmt.Fprintf(w, "    pub fn load() -> Result<Self, figment::Error> {\n")
+		fmt.Fprintf(w, "        use figment::{providers::{%s}, Figment};\n\n", strings.Join(providers, ", "))
+		if hasEnvFile {
+			fmt.Fprintln(w, "        dotenvy::dotenv().ok();")
+		}
+		fmt.Fprintln(w, "        Figment::new()")
+		for _, f := range files {
+			fmt.Fprintf(w, "            .merge(%s::file(%q))\n", figmentProvider(f.Format), f.Path)
+		}
+		fmt.Fprintln(w, "            .merge(Env::raw())")
+		fmt.Fprintln(w, "            .extract()")
+	default:
+		return fmt.Errorf("unknown loader: %s", opts.Loader)
+	}
+	fmt.Fprintln(w, "    }")
+	fmt.Fprintln(w, "}")
+	return nil
+}
+
+func figmentProvider(f Format) string {
+	switch f {
+	case FormatTOML:
+		return "Toml"
+	case FormatJSON:
+		return "Json"
+	default:
+		return "Yaml"
+	}
+}
+
+func unique(items []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+type generator struct {
+	structs []rustStruct
+	used    map[string]bool
+}
+
+// structFor emits a struct for a map node and returns its name.
+func (g *generator) structFor(name string, n *node) string {
+	name = g.uniqueName(name)
+	idx := len(g.structs)
+	g.structs = append(g.structs, rustStruct{name: name})
+
+	keys := make([]string, 0, len(n.fields))
+	for k := range n.fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var fields []rustField
+	for _, key := range keys {
+		fields = append(fields, rustField{
+			name: fieldName(key),
+			key:  key,
+			typ:  g.typeFor(key, n.fields[key]),
+		})
+	}
+	g.structs[idx].fields = fields
+	return name
+}
+
+func (g *generator) uniqueName(name string) string {
+	candidate := name
+	for i := 2; g.used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	g.used[candidate] = true
+	return candidate
+}
+
+func (g *generator) typeFor(key string, n *node) string {
+	var typ string
+	switch n.kind {
+	case kindMap:
+		typ = g.structFor(typeName(key), n)
+	case kindList:
+		elem := "String"
+		if n.elem != nil {
+			elemNode := *n.elem
+			elemNode.optional = false
+			elem = g.typeFor(key+"_item", &elemNode)
+		}
+		typ = "Vec<" + elem + ">"
+	case kindInt:
+		typ = "i64"
+	case kindFloat:
+		typ = "f64"
+	case kindBool:
+		typ = "bool"
+	default:
+		typ = "String"
+	}
+	if n.optional || n.kind == kindNull {
+		typ = "Option<" + typ + ">"
+	}
+	return typ
+}
+
+var rustKeywords = map[string]bool{
+	"as": true, "async": true, "await": true, "break": true, "const": true, "continue": true,
+	"crate": true, "dyn": true, "else": true, "enum": true, "extern": true, "false": true,
+	"fn": true, "for": true, "if": true, "impl": true, "in": true, "let": true, "loop": true,
+	"match": true, "mod": true, "move": true, "mut": true, "pub": true, "ref": true,
+	"return": true, "self": true, "static": true, "struct": true, "super": true, "trait": true,
+	"true": true, "type": true, "unsafe": true, "use": true, "where": true, "while": true,
+}
+
+// fieldName converts a config key to a snake_case Rust identifier.
+func fieldName(key string) string {
+	var sb strings.Builder
+	prevLower := false
+	for _, r := range key {
+		switch {
+		case unicode.IsUpper(r):
+			if prevLower {
+				sb.WriteByte('_')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+			prevLower = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(r)
+			prevLower = unicode.IsLower(r) || unicode.IsDigit(r)
+		default:
+			if sb.Len() > 0 && !strings.HasSuffix(sb.String(), "_") {
+				sb.WriteByte('_')
+			}
+			prevLower = false
+		}
+	}
+	name := strings.Trim(sb.String(), "_")
+	if name == "" {
+		return "field"
+	}
+	if unicode.IsDigit(rune(name[0])) {
+		name = "field_" + name
+	}
+	if rustKeywords[name] {
+		return "r#" + name
+	}
+	return name
+}
+
+// typeName converts a config key to a CamelCase Rust type name.
+func typeName(key string) string {
+	var sb strings.Builder
+	for _, part := range strings.Split(fieldName(strings.TrimPrefix(key, "r#")), "_") {
+		part = strings.TrimPrefix(part, "r#")
+		if part == "" {
+			continue
+		}
+		sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	if sb.Len() == 0 {
+		return "Section"
+	}
+	return sb.String()
+}
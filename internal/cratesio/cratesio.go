@@ -0,0 +1,111 @@
+// Package cratesio is a minimal client for the crates.io registry API.
+package cratesio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/stephan/rinku/internal/httpclient"
+)
+
+const (
+	DefaultBaseURL = "https://crates.io/api/v1"
+
+	// userAgent identifies rinku as required by the crates.io crawler policy.
+	userAgent = "rinku (https://github.com/marvai-dev/rinku)"
+)
+
+// ErrNotFound is returned when a crate does not exist on crates.io.
+var ErrNotFound = errors.New("crate not found")
+
+// Crate holds the registry metadata rinku uses.
+type Crate struct {
+	Name             string    `json:"name"`
+	MaxVersion       string    `json:"max_version"`
+	MaxStableVersion string    `json:"max_stable_version"`
+	Repository       string    `json:"repository"`
+	Downloads        int64     `json:"downloads"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// LatestVersion returns the newest stable version, falling back to the newest version.
+func (c *Crate) LatestVersion() string {
+	if c.MaxStableVersion != "" {
+		return c.MaxStableVersion
+	}
+	return c.MaxVersion
+}
+
+// Client queries crates.io.
+type Client struct {
+	BaseURL    string
+	IndexURL   string // sparse index, used by IndexEntries
+	HTTPClient *http.Client
+}
+
+// New returns a client for the public crates.io API.
+func New() *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		IndexURL:   DefaultIndexURL,
+		HTTPClient: httpclient.Default(),
+	}
+}
+
+// Crate fetches metadata for a crate by name.
+func (c *Client) Crate(ctx context.Context, name string) (*Crate, error) {
+	var body struct {
+		Crate Crate `json:"crate"`
+	}
+	if err := c.get(ctx, "/crates/"+url.PathEscape(name), &body); err != nil {
+		return nil, fmt.Errorf("fetching crate %s: %w", name, err)
+	}
+	return &body.Crate, nil
+}
+
+// Release is one published version of a crate.
+type Release struct {
+	Num       string    `json:"num"`
+	CreatedAt time.Time `json:"created_at"`
+	Yanked    bool      `json:"yanked"`
+}
+
+// Releases fetches all published versions of a crate with their publication times,
+// newest first.
+func (c *Client) Releases(ctx context.Context, name string) ([]Release, error) {
+	var body struct {
+		Versions []Release `json:"versions"`
+	}
+	if err := c.get(ctx, "/crates/"+url.PathEscape(name)+"/versions", &body); err != nil {
+		return nil, fmt.Errorf("fetching versions of %s: %w", name, err)
+	}
+	return body.Versions, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
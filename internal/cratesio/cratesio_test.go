@@ -0,0 +1,123 @@
+package cratesio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c := New()
+	c.BaseURL = srv.URL
+	return c
+}
+
+func TestCrate(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/crates/clap" {
+			t.Errorf("path = %q, want /crates/clap", r.URL.Path)
+		}
+		if r.Header.Get("User-Agent") == "" {
+			t.Error("User-Agent header should be set")
+		}
+		w.Write([]byte(`{"crate": {"name": "clap", "max_version": "5.0.0-beta.1", "max_stable_version": "4.5.20"}}`))
+	})
+
+	crate, err := c.Crate(context.Background(), "clap")
+	if err != nil {
+		t.Fatalf("Crate failed: %v", err)
+	}
+	if crate.LatestVersion() != "4.5.20" {
+		t.Errorf("LatestVersion() = %q, want 4.5.20", crate.LatestVersion())
+	}
+}
+
+func TestReleases(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/crates/axum/versions" {
+			t.Errorf("path = %q, want /crates/axum/versions", r.URL.Path)
+		}
+		w.Write([]byte(`{"versions": [
+			{"num": "0.7.0", "created_at": "2023-11-27T15:00:00Z", "yanked": false},
+			{"num": "0.6.20", "created_at": "2023-08-01T10:00:00Z", "yanked": true}
+		]}`))
+	})
+
+	releases, err := c.Releases(context.Background(), "axum")
+	if err != nil {
+		t.Fatalf("Releases failed: %v", err)
+	}
+	if len(releases) != 2 || releases[0].Num != "0.7.0" || !releases[1].Yanked || releases[1].CreatedAt.Month() != 8 {
+		t.Errorf("Releases() = %+v", releases)
+	}
+}
+
+func TestCrate_LatestFallsBackToMaxVersion(t *testing.T) {
+	crate := &Crate{MaxVersion: "0.1.0-alpha"}
+	if crate.LatestVersion() != "0.1.0-alpha" {
+		t.Errorf("LatestVersion() = %q, want 0.1.0-alpha", crate.LatestVersion())
+	}
+}
+
+func TestCrate_NotFound(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	_, err := c.Crate(context.Background(), "does-not-exist")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCrate_ServerError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	if _, err := c.Crate(context.Background(), "clap"); err == nil {
+		t.Error("expected error for 500 response")
+	}
+}
+
+func TestIndexEntries(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/an/yh/anyhow" {
+			t.Errorf("path = %q, want /an/yh/anyhow", r.URL.Path)
+		}
+		w.Write([]byte(`{"name":"anyhow","vers":"1.0.0","deps":[],"yanked":false}
+{"name":"anyhow","vers":"1.0.1","deps":[{"name":"bt","req":"^0.3","optional":false,"kind":"normal","target":null,"package":"backtrace"}],"yanked":true}
+`))
+	})
+	c.IndexURL = c.BaseURL
+
+	entries, err := c.IndexEntries(context.Background(), "anyhow")
+	if err != nil {
+		t.Fatalf("IndexEntries failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("len(entries) = %d, want 2", len(entries))
+	}
+	if !entries[1].Yanked || entries[1].Deps[0].Crate() != "backtrace" {
+		t.Errorf("entries[1] = %+v, want yanked with a renamed backtrace dependency", entries[1])
+	}
+}
+
+func TestIndexPath(t *testing.T) {
+	tests := map[string]string{
+		"a":     "1/a",
+		"io":    "2/io",
+		"syn":   "3/s/syn",
+		"Serde": "se/rd/serde",
+	}
+	for name, want := range tests {
+		if got := indexPath(name); got != want {
+			t.Errorf("indexPath(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package cratesio
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultIndexURL is the crates.io sparse index.
+const DefaultIndexURL = "https://index.crates.io"
+
+// IndexEntry is one published version of a crate in the registry index.
+type IndexEntry struct {
+	Name    string     `json:"name"`
+	Version string     `json:"vers"`
+	Deps    []IndexDep `json:"deps"`
+	Yanked  bool       `json:"yanked"`
+}
+
+// IndexDep is a dependency declared by an index entry.
+type IndexDep struct {
+	Name     string `json:"name"`
+	Req      string `json:"req"`
+	Optional bool   `json:"optional"`
+	Kind     string `json:"kind"`              // normal, dev or build; empty means normal
+	Target   string `json:"target"`            // platform cfg, empty for all platforms
+	Package  string `json:"package,omitempty"` // actual crate name when the dependency is renamed
+}
+
+// Crate returns the name of the depended-on crate, honoring renames.
+func (d *IndexDep) Crate() string {
+	if d.Package != "" {
+		return d.Package
+	}
+	return d.Name
+}
+
+// IndexEntries fetches all published versions of a crate from the sparse index.
+// The index has no rate limit, unlike the API, so it suits walking dependency trees.
+func (c *Client) IndexEntries(ctx context.Context, name string) ([]IndexEntry, error) {
+	base := c.IndexURL
+	if base == "" {
+		base = DefaultIndexURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/"+indexPath(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching index for %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("fetching index for %s: %w", name, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching index for %s: unexpected status: %s", name, resp.Status)
+	}
+
+	var entries []IndexEntry
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var e IndexEntry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, fmt.Errorf("parsing index for %s: %w", name, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading index for %s: %w", name, err)
+	}
+	return entries, nil
+}
+
+// indexPath returns the sparse index path of a crate, e.g. "cl/ap/clap" or "3/s/syn".
+func indexPath(name string) string {
+	name = strings.ToLower(name)
+	switch len(name) {
+	case 1:
+		return "1/" + name
+	case 2:
+		return "2/" + name
+	case 3:
+		return "3/" + name[:1] + "/" + name
+	default:
+		return name[:2] + "/" + name[2:4] + "/" + name
+	}
+}
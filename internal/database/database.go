@@ -0,0 +1,271 @@
+// Package database builds the lookup indexes of the library database, libs.json and
+// mappings.json, and keeps the copy rinku update downloads below the user's config
+// directory. A downloaded database replaces the one compiled into the binary unless the
+// binary's is newer, so new mappings reach users without a new release.
+package database
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/natefinch/atomic"
+
+	"github.com/stephan/rinku/internal/types"
+)
+
+// Files of the database. ChecksumsFile lists their SHA-256 in sha256sum format, like
+// the checksums.txt of a release.
+const (
+	LibsFile      = "libs.json"
+	MappingsFile  = "mappings.json"
+	ChecksumsFile = "checksums.txt"
+	infoFile      = "database.json"
+)
+
+// URLEnv overrides URL.
+const URLEnv = "RINKU_DATABASE_URL"
+
+// URL is where rinku update downloads the database files from: a base URL, http(s) or
+// file, below which LibsFile, MappingsFile and ChecksumsFile are found.
+var URL = "https://github.com/marvai-dev/rinku/releases/latest/download"
+
+// Info describes a downloaded database.
+type Info struct {
+	URL       string    `json:"url"`
+	Version   string    `json:"version"`           // see Version
+	Released  time.Time `json:"released,omitzero"` // of mappings.json, see Newer
+	UpdatedAt time.Time `json:"updated_at"`
+	Libraries int       `json:"libraries"`
+	Mappings  int       `json:"mappings"`
+}
+
+// Newer reports whether the database is newer than the built-in one released at
+// builtIn, the released time of their mappings.json. A database without a release time
+// is older than one with it, and of two released at the same time the downloaded one is
+// used.
+func (i *Info) Newer(builtIn time.Time) bool {
+	return !i.Released.Before(builtIn)
+}
+
+// Database is a downloaded database with its indexes.
+type Database struct {
+	Info
+	Indexes IndexResult
+}
+
+// Dir returns the directory of the downloaded database, e.g. ~/.config/rinku/database.
+func Dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("finding config directory: %w", err)
+	}
+	return filepath.Join(dir, "rinku", "database"), nil
+}
+
+// Version returns the first 12 hex digits of the SHA-256 of the database files. It
+// identifies the database in certificates and reports.
+func Version(files ...[]byte) string {
+	h := sha256.New()
+	for _, data := range files {
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// Parse builds the indexes of the content of libs.json and mappings.json.
+func Parse(libs, mappings []byte) (IndexResult, error) {
+	var libsFile types.LibsFile
+	if err := json.Unmarshal(libs, &libsFile); err != nil {
+		return IndexResult{}, fmt.Errorf("parsing %s: %w", LibsFile, err)
+	}
+	var mappingsFile types.MappingsFile
+	if err := json.Unmarshal(mappings, &mappingsFile); err != nil {
+		return IndexResult{}, fmt.Errorf("parsing %s: %w", MappingsFile, err)
+	}
+	if len(libsFile.Libs) == 0 || len(mappingsFile.Mappings) == 0 {
+		return IndexResult{}, errors.New("the database has no libraries or no mappings")
+	}
+	idx := BuildIndexes(libsFile.Libs, mappingsFile.Mappings)
+	idx.Released = mappingsFile.Released
+	return idx, nil
+}
+
+// Load reads the database downloaded to dir. It returns nil, nil if there is none;
+// Newer tells whether to prefer it over the built-in one.
+func Load(dir string) (*Database, error) {
+	data, err := os.ReadFile(filepath.Join(dir, infoFile)) //#nosec G304 -- dir from Dir
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading database: %w", err)
+	}
+	var db Database
+	if err := json.Unmarshal(data, &db.Info); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", infoFile, err)
+	}
+	libs, err := os.ReadFile(filepath.Join(dir, LibsFile)) //#nosec G304 -- dir from Dir
+	if err != nil {
+		return nil, fmt.Errorf("reading database: %w", err)
+	}
+	mappings, err := os.ReadFile(filepath.Join(dir, MappingsFile)) //#nosec G304 -- dir from Dir
+	if err != nil {
+		return nil, fmt.Errorf("reading database: %w", err)
+	}
+	if v := Version(libs, mappings); v != db.Version {
+		return nil, fmt.Errorf("database in %s is version %s, but %s records %s", dir, v, infoFile, db.Version)
+	}
+	if db.Indexes, err = Parse(libs, mappings); err != nil {
+		return nil, err
+	}
+	// database.json of rinku versions before Released lacks it
+	db.Released = db.Indexes.Released
+	return &db, nil
+}
+
+// Update downloads the database files from baseURL, checks them against its
+// checksums file and that they parse, and replaces the database in dir. It returns the
+// info of the new database, and whether it differs from the one installed before.
+// client is http.DefaultClient if nil.
+func Update(ctx context.Context, client *http.Client, baseURL, dir string, now time.Time) (*Info, bool, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	sums, err := fetch(ctx, client, baseURL, ChecksumsFile)
+	if err != nil {
+		return nil, false, err
+	}
+	want, err := parseChecksums(sums)
+	if err != nil {
+		return nil, false, err
+	}
+	files := make(map[string][]byte)
+	for _, name := range []string{LibsFile, MappingsFile} {
+		data, err := fetch(ctx, client, baseURL, name)
+		if err != nil {
+			return nil, false, err
+		}
+		if err := verify(name, data, want); err != nil {
+			return nil, false, err
+		}
+		files[name] = data
+	}
+	idx, err := Parse(files[LibsFile], files[MappingsFile])
+	if err != nil {
+		return nil, false, fmt.Errorf("downloaded database: %w", err)
+	}
+
+	info := &Info{
+		URL:       baseURL,
+		Version:   Version(files[LibsFile], files[MappingsFile]),
+		Released:  idx.Released,
+		UpdatedAt: now.UTC(),
+		Libraries: idx.LibrariesCount,
+		Mappings:  idx.MappingsCount,
+	}
+	previous, _ := Load(dir)
+	changed := previous == nil || previous.Version != info.Version
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, false, fmt.Errorf("creating database directory: %w", err)
+	}
+	// database.json goes last: until it matches, Load reports the files as corrupt
+	// rather than mixing two versions.
+	for _, name := range []string{LibsFile, MappingsFile} {
+		if err := atomic.WriteFile(filepath.Join(dir, name), bytes.NewReader(files[name])); err != nil {
+			return nil, false, fmt.Errorf("writing %s: %w", name, err)
+		}
+	}
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return nil, false, fmt.Errorf("marshaling %s: %w", infoFile, err)
+	}
+	if err := atomic.WriteFile(filepath.Join(dir, infoFile), bytes.NewReader(append(data, '\n'))); err != nil {
+		return nil, false, fmt.Errorf("writing %s: %w", infoFile, err)
+	}
+	return info, changed, nil
+}
+
+// Remove deletes the database in dir, so the compiled-in one is used again. It
+// reports whether there was one.
+func Remove(dir string) (bool, error) {
+	if _, err := os.Stat(filepath.Join(dir, infoFile)); os.IsNotExist(err) {
+		return false, nil
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return false, fmt.Errorf("removing database: %w", err)
+	}
+	return true, nil
+}
+
+// fetch returns the content of the file name below baseURL.
+func fetch(ctx context.Context, client *http.Client, baseURL, name string) ([]byte, error) {
+	if dir, ok := strings.CutPrefix(baseURL, "file://"); ok {
+		data, err := os.ReadFile(filepath.Join(filepath.FromSlash(dir), name)) //#nosec G304 -- URL chosen by the user
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		return data, nil
+	}
+	u := strings.TrimSuffix(baseURL, "/") + "/" + name
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", name, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: %s", u, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// parseChecksums reads lines of "<sha256>  <file>"; a "*" before the file marks
+// binary mode in sha256sum output.
+func parseChecksums(data []byte) (map[string]string, error) {
+	sums := make(map[string]string)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		sum, name, ok := strings.Cut(strings.TrimSpace(sc.Text()), " ")
+		if !ok {
+			continue
+		}
+		name = strings.TrimPrefix(strings.TrimSpace(name), "*")
+		sums[filepath.Base(name)] = strings.ToLower(sum)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", ChecksumsFile, err)
+	}
+	return sums, nil
+}
+
+// verify checks data, the content of the file name, against its checksum.
+func verify(name string, data []byte, sums map[string]string) error {
+	want, ok := sums[name]
+	if !ok {
+		return fmt.Errorf("%s lists no checksum for %s", ChecksumsFile, name)
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("checksum mismatch for %s: got %s, want %s", name, got, want)
+	}
+	return nil
+}
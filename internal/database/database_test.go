@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testLibs     = `{"libs": {"go:spf13/cobra": {"url": "https://github.com/spf13/cobra", "lang": "go"}, "rust:acme/cli": {"url": "https://github.com/acme/cli", "lang": "rust"}}}`
+	testMappings = `{"released": "2026-10-01T00:00:00Z", "mappings": [{"source": "go:spf13/cobra", "targets": ["rust:acme/cli"], "category": "cli"}]}`
+)
+
+func checksum(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func serve(t *testing.T, files map[string]string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[strings.TrimPrefix(r.URL.Path, "/db/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(data))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL + "/db"
+}
+
+func TestUpdate(t *testing.T) {
+	base := serve(t, map[string]string{
+		LibsFile:      testLibs,
+		MappingsFile:  testMappings,
+		ChecksumsFile: checksum(testLibs) + "  libs.json\n" + checksum(testMappings) + " *dist/mappings.json\n",
+	})
+	dir := filepath.Join(t.TempDir(), "database")
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+
+	if db, err := Load(dir); db != nil || err != nil {
+		t.Fatalf("Load before Update = %v, %v", db, err)
+	}
+	info, changed, err := Update(context.Background(), nil, base, dir, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed || info.Version != Version([]byte(testLibs), []byte(testMappings)) || info.Libraries != 2 || info.Mappings != 1 {
+		t.Errorf("Update = %+v, changed %v", info, changed)
+	}
+	if _, changed, err := Update(context.Background(), nil, base, dir, now); err != nil || changed {
+		t.Errorf("second Update: changed %v, %v", changed, err)
+	}
+
+	db, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.URL != base || !db.UpdatedAt.Equal(now) || !db.Released.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Info = %+v", db.Info)
+	}
+	if got := db.Indexes.Forward["rust:github.com/spf13/cobra"]; len(got) != 1 || got[0] != "https://github.com/acme/cli" {
+		t.Errorf("Forward = %v", db.Indexes.Forward)
+	}
+
+	// An edited file no longer matches the recorded version.
+	if err := os.WriteFile(filepath.Join(dir, MappingsFile), []byte(`{"mappings": []}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "records "+info.Version) {
+		t.Errorf("Load of an edited database = %v", err)
+	}
+
+	if removed, err := Remove(dir); !removed || err != nil {
+		t.Errorf("Remove = %v, %v", removed, err)
+	}
+	if removed, err := Remove(dir); removed || err != nil {
+		t.Errorf("second Remove = %v, %v", removed, err)
+	}
+}
+
+func TestUpdate_Rejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"no checksums", map[string]string{LibsFile: testLibs, MappingsFile: testMappings}, "404 Not Found"},
+		{"missing checksum", map[string]string{
+			LibsFile: testLibs, MappingsFile: testMappings,
+			ChecksumsFile: checksum(testLibs) + "  libs.json\n",
+		}, "checksums.txt lists no checksum for mappings.json"},
+		{"mismatch", map[string]string{
+			LibsFile: testLibs, MappingsFile: testMappings,
+			ChecksumsFile: checksum(testLibs) + "  libs.json\n" + checksum(testLibs) + "  mappings.json\n",
+		}, "checksum mismatch for mappings.json"},
+		{"empty", map[string]string{
+			LibsFile: testLibs, MappingsFile: `{"mappings": []}`,
+			ChecksumsFile: checksum(testLibs) + "  libs.json\n" + checksum(`{"mappings": []}`) + "  mappings.json\n",
+		}, "no libraries or no mappings"},
+	}
+	for _, tt := range tests {
+		dir := filepath.Join(t.TempDir(), "database")
+		_, _, err := Update(context.Background(), nil, serve(t, tt.files), dir, time.Now())
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Update = %v, want %q", tt.name, err, tt.want)
+		}
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("%s: Update created %s", tt.name, dir)
+		}
+	}
+}
+
+func TestNewer(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name              string
+		released, builtIn time.Time
+		want              bool
+	}{
+		{"download newer", day(14), day(1), true},
+		{"built-in newer", day(1), day(14), false},
+		{"same release", day(14), day(14), true},
+		{"download without release", time.Time{}, day(14), false},
+		{"built-in without release", day(14), time.Time{}, true},
+		{"neither released", time.Time{}, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		if got := (&Info{Released: tt.released}).Newer(tt.builtIn); got != tt.want {
+			t.Errorf("%s: Newer = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
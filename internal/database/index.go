@@ -1,8 +1,13 @@
-package main
+package database
 
 import (
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/manifest"
+	"github.com/stephan/rinku/internal/rinku"
 	"github.com/stephan/rinku/internal/types"
 	"github.com/stephan/rinku/internal/url"
 )
@@ -16,6 +21,10 @@ type IndexResult struct {
 	KnownCrateNames map[string]string              // normalized_url -> crate_name (for Rust libraries)
 	Tags            map[string][]string            // normalized_url -> tags (for all libraries)
 	RequiredDeps    map[string][]types.RequiredDep // target_lang:source_url -> required deps
+	Categories      map[string]string              // target_lang:source_url -> mapping category
+	Confidences     map[string]float64             // target_lang:source_url -> mapping confidence
+	Packages        map[string]string              // lang:package_name -> library URL (js, python and Rust crates)
+	Released        time.Time                      // of mappings.json, set by Parse
 	UnsafeCount     int
 	MappingsCount   int
 	LibrariesCount  int
@@ -30,6 +39,9 @@ func BuildIndexes(libs map[string]types.Library, mappings []types.Mapping) Index
 		KnownCrateNames: make(map[string]string),
 		Tags:            make(map[string][]string),
 		RequiredDeps:    make(map[string][]types.RequiredDep),
+		Categories:      make(map[string]string),
+		Confidences:     make(map[string]float64),
+		Packages:        make(map[string]string),
 		LibrariesCount:  len(libs),
 		MappingsCount:   len(mappings),
 	}
@@ -48,7 +60,12 @@ func BuildIndexes(libs map[string]types.Library, mappings []types.Mapping) Index
 		if len(lib.Tags) > 0 {
 			result.Tags[normalizedURL] = lib.Tags
 		}
+		// Build package names map, so manifests that list names can be looked up
+		for _, pkg := range lib.Packages {
+			result.Packages[lib.Lang+":"+manifest.NormalizeName(lib.Lang, pkg)] = lib.URL
+		}
 	}
+	addCrateNames(result.Packages, libs)
 
 	for _, mapping := range mappings {
 		sourceLib, sourceExists := libs[mapping.Source]
@@ -81,6 +98,14 @@ func BuildIndexes(libs map[string]types.Library, mappings []types.Mapping) Index
 			if !sourceUnsafe && !targetUnsafe {
 				result.Forward[forwardKey] = append(result.Forward[forwardKey], targetURL)
 			}
+			// The first mapping with a category names the source library's role
+			if _, ok := result.Categories[forwardKey]; !ok && mapping.Category != "" {
+				result.Categories[forwardKey] = mapping.Category
+			}
+			// and the first with a confidence how well its targets replace it
+			if _, ok := result.Confidences[forwardKey]; !ok && mapping.Confidence > 0 {
+				result.Confidences[forwardKey] = mapping.Confidence
+			}
 
 			// Reverse index: given target URL, find sources in source language
 			// Key: source_lang:normalized_target_url
@@ -109,3 +134,49 @@ func BuildIndexes(libs map[string]types.Library, mappings []types.Mapping) Index
 
 	return result
 }
+
+// Index returns the indexes as the lookup data of a Rinku.
+func (r *IndexResult) Index() rinku.Index {
+	return rinku.Index{
+		Safe:         r.Forward,
+		All:          r.ForwardAll,
+		ReverseSafe:  r.Reverse,
+		ReverseAll:   r.ReverseAll,
+		CrateNames:   r.KnownCrateNames,
+		Tags:         r.Tags,
+		RequiredDeps: r.RequiredDeps,
+		Categories:   r.Categories,
+		Confidences:  r.Confidences,
+		Packages:     r.Packages,
+	}
+}
+
+// addCrateNames adds the crate names of the Rust libraries to packages, so the
+// dependencies of a Cargo.toml can be looked up. Explicit crate names win over the
+// name derived from the URL; of two libraries deriving the same name, the lower URL wins.
+func addCrateNames(packages map[string]string, libs map[string]types.Library) {
+	var rust []types.Library
+	for _, lib := range libs {
+		if lib.Lang == "rust" {
+			rust = append(rust, lib)
+		}
+	}
+	sort.Slice(rust, func(i, j int) bool { return rust[i].URL < rust[j].URL })
+
+	add := func(name, libURL string) {
+		key := "rust:" + manifest.NormalizeName(manifest.Rust, name)
+		if _, ok := packages[key]; !ok && name != "" {
+			packages[key] = libURL
+		}
+	}
+	for _, lib := range rust {
+		if lib.CrateName != "" {
+			add(lib.CrateName, lib.URL)
+		}
+	}
+	for _, lib := range rust {
+		if lib.CrateName == "" {
+			add(cargo.ExtractCrateName(lib.URL), lib.URL)
+		}
+	}
+}
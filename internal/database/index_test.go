@@ -1,10 +1,15 @@
-package main
+package database
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
+	"github.com/stephan/rinku/internal/rinku"
 	"github.com/stephan/rinku/internal/types"
+	"github.com/stephan/rinku/internal/url"
 )
 
 func TestBuildIndexes(t *testing.T) {
@@ -30,9 +35,10 @@ func TestBuildIndexes(t *testing.T) {
 
 	mappings := []types.Mapping{
 		{
-			Source:   "go:spf13/cobra",
-			Targets:  []string{"rust:clap-rs/clap"},
-			Category: "cli",
+			Source:     "go:spf13/cobra",
+			Targets:    []string{"rust:clap-rs/clap"},
+			Category:   "cli",
+			Confidence: 0.95,
 		},
 		{
 			Source:   "go:golang/net",
@@ -87,6 +93,69 @@ func TestBuildIndexes(t *testing.T) {
 	if !reflect.DeepEqual(result.ReverseAll, wantReverseAll) {
 		t.Errorf("ReverseAll = %v, want %v", result.ReverseAll, wantReverseAll)
 	}
+
+	// Check categories (keyed like the forward index, unsafe mappings included)
+	wantCategories := map[string]string{
+		"rust:github.com/spf13/cobra": "cli",
+		"rust:github.com/golang/net":  "http_client",
+	}
+	if !reflect.DeepEqual(result.Categories, wantCategories) {
+		t.Errorf("Categories = %v, want %v", result.Categories, wantCategories)
+	}
+
+	// Mappings without a confidence have no entry
+	wantConfidences := map[string]float64{"rust:github.com/spf13/cobra": 0.95}
+	if !reflect.DeepEqual(result.Confidences, wantConfidences) {
+		t.Errorf("Confidences = %v, want %v", result.Confidences, wantConfidences)
+	}
+}
+
+func TestBuildIndexes_Packages(t *testing.T) {
+	libs := map[string]types.Library{
+		"python:yaml/pyyaml": {
+			URL:      "https://github.com/yaml/pyyaml",
+			Lang:     "python",
+			Packages: []string{"PyYAML"},
+		},
+		"js:prisma/prisma": {
+			URL:      "https://github.com/prisma/prisma",
+			Lang:     "js",
+			Packages: []string{"prisma", "@prisma/client"},
+		},
+	}
+
+	result := BuildIndexes(libs, nil)
+
+	want := map[string]string{
+		"python:pyyaml":     "https://github.com/yaml/pyyaml",
+		"js:prisma":         "https://github.com/prisma/prisma",
+		"js:@prisma/client": "https://github.com/prisma/prisma",
+	}
+	if !reflect.DeepEqual(result.Packages, want) {
+		t.Errorf("Packages = %v, want %v", result.Packages, want)
+	}
+}
+
+func TestBuildIndexes_CrateNames(t *testing.T) {
+	libs := map[string]types.Library{
+		"rust:serde-rs/json":    {URL: "https://github.com/serde-rs/json", Lang: "rust", CrateName: "serde_json"},
+		"rust:clap-rs/clap":     {URL: "https://github.com/clap-rs/clap", Lang: "rust"},
+		"rust:acme/serde_json":  {URL: "https://github.com/acme/serde_json", Lang: "rust"},
+		"rust:tokio-rs/tracing": {URL: "https://github.com/tokio-rs/tracing", Lang: "rust"},
+		"rust:zzz/tracing":      {URL: "https://github.com/zzz/tracing", Lang: "rust"},
+		"go:spf13/cobra":        {URL: "https://github.com/spf13/cobra", Lang: "go"},
+	}
+
+	result := BuildIndexes(libs, nil)
+
+	want := map[string]string{
+		"rust:serde_json": "https://github.com/serde-rs/json", // explicit name wins
+		"rust:clap":       "https://github.com/clap-rs/clap",
+		"rust:tracing":    "https://github.com/tokio-rs/tracing", // lower URL wins
+	}
+	if !reflect.DeepEqual(result.Packages, want) {
+		t.Errorf("Packages = %v, want %v", result.Packages, want)
+	}
 }
 
 func TestBuildIndexes_NormalizesURLs(t *testing.T) {
@@ -116,6 +185,63 @@ func TestBuildIndexes_NormalizesURLs(t *testing.T) {
 	}
 }
 
+// TestBuildIndexes_LookupParity checks that URLs written differently in libs.json and
+// in a lookup meet at the same key: the generator and the lookup both normalize.
+func TestBuildIndexes_LookupParity(t *testing.T) {
+	libs := map[string]types.Library{
+		"go:spf13/cobra":    {URL: "https://github.com/Spf13/Cobra.git", Lang: "go", Tags: []string{"cli"}},
+		"rust:clap-rs/clap": {URL: "https://github.com/clap-rs/clap/tree/master", Lang: "rust", CrateName: "clap"},
+	}
+	mappings := []types.Mapping{{Source: "go:spf13/cobra", Targets: []string{"rust:clap-rs/clap"}, Category: "cli"}}
+	idx := BuildIndexes(libs, mappings)
+	r := rinku.New(rinku.WithIndex(idx.Index()))
+
+	for _, u := range []string{
+		"https://github.com/spf13/cobra",
+		"github.com/spf13/cobra.git",
+		"https://www.github.com/spf13/cobra/?tab=readme-ov-file",
+		"https://github.com/spf13/cobra#installing",
+		"https://github.com/spf13/cobra/tree/main",
+	} {
+		if got := r.Lookup(u, "rust", false); len(got) != 1 {
+			t.Errorf("Lookup(%q) = %v, want clap", u, got)
+		}
+		if got := r.Category(u, "rust"); got != "cli" {
+			t.Errorf("Category(%q) = %q, want cli", u, got)
+		}
+		if got := r.Tags(u); len(got) != 1 {
+			t.Errorf("Tags(%q) = %v, want [cli]", u, got)
+		}
+	}
+	if got := r.CrateName("https://github.com/clap-rs/clap.git"); got != "clap" {
+		t.Errorf("CrateName = %q, want clap", got)
+	}
+	if got := r.ReverseLookup("https://github.com/clap-rs/clap#readme", "go", false); len(got) != 1 {
+		t.Errorf("ReverseLookup = %v, want cobra", got)
+	}
+}
+
+// TestLibsJSON_NoNormalizedCollisions guards the database: two libraries whose URLs
+// normalize to the same key would silently share mappings, crate names and tags.
+func TestLibsJSON_NoNormalizedCollisions(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "cmd", "rinku", "libs.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f types.LibsFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[string]string)
+	for id, lib := range f.Libs {
+		key := lib.Lang + ":" + url.Normalize(lib.URL)
+		if other, ok := seen[key]; ok {
+			t.Errorf("%s and %s both normalize to %s", id, other, key)
+		}
+		seen[key] = id
+	}
+}
+
 func TestBuildIndexes_SkipsNonePlaceholder(t *testing.T) {
 	libs := map[string]types.Library{
 		"go:foo/bar": {
@@ -0,0 +1,201 @@
+// Package features derives the cargo features of Rust crates from the Go packages and
+// package-level identifiers a project uses, so a generated Cargo.toml asks for what the
+// code needs instead of the default or "full" feature set of each crate.
+package features
+
+import (
+	"go/ast"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+// Rule enables features of a crate when the Go source uses an import.
+type Rule struct {
+	Import   string   // Go import path; subpackages and major version suffixes match too
+	Name     string   // package-level identifier of Import, e.g. NewSHA1; empty for any use
+	Features []string // cargo features the use needs
+}
+
+// Crate holds the feature rules of a Rust crate.
+type Crate struct {
+	Name  string
+	Base  []string // features any use of the crate needs
+	Rules []Rule
+}
+
+// Crates lists the crates whose features are derived from the Go source. The other
+// crates keep their default features.
+var Crates = []Crate{
+	{Name: "axum", Base: []string{"http1", "tokio"}, Rules: []Rule{
+		{Import: "github.com/gin-gonic/gin", Features: []string{"json", "query"}},
+		{Import: "github.com/gin-gonic/gin/binding", Name: "Form", Features: []string{"form"}},
+		{Import: "github.com/gin-gonic/gin/binding", Name: "FormPost", Features: []string{"form"}},
+		{Import: "github.com/gin-gonic/gin/binding", Name: "FormMultipart", Features: []string{"multipart"}},
+		{Import: "github.com/labstack/echo", Features: []string{"json", "query"}},
+		{Import: "mime/multipart", Features: []string{"multipart"}},
+		{Import: "github.com/gorilla/websocket", Features: []string{"ws"}},
+		{Import: "nhooyr.io/websocket", Features: []string{"ws"}},
+		{Import: "golang.org/x/net/http2", Features: []string{"http2"}},
+	}},
+	{Name: "tokio", Base: []string{"macros", "rt-multi-thread"}, Rules: []Rule{
+		{Import: "net", Features: []string{"net"}},
+		{Import: "os/signal", Features: []string{"signal"}},
+		{Import: "os/exec", Features: []string{"process"}},
+		{Import: "bufio", Features: []string{"io-util"}},
+		{Import: "io", Name: "Copy", Features: []string{"io-util"}},
+		{Import: "io", Name: "ReadAll", Features: []string{"io-util"}},
+		{Import: "time", Name: "Sleep", Features: []string{"time"}},
+		{Import: "time", Name: "After", Features: []string{"time"}},
+		{Import: "time", Name: "AfterFunc", Features: []string{"time"}},
+		{Import: "time", Name: "NewTimer", Features: []string{"time"}},
+		{Import: "time", Name: "NewTicker", Features: []string{"time"}},
+		{Import: "time", Name: "Tick", Features: []string{"time"}},
+		{Import: "context", Name: "WithTimeout", Features: []string{"time"}},
+		{Import: "sync", Features: []string{"sync"}},
+		{Import: "os", Name: "Open", Features: []string{"fs"}},
+		{Import: "os", Name: "OpenFile", Features: []string{"fs"}},
+		{Import: "os", Name: "Create", Features: []string{"fs"}},
+		{Import: "os", Name: "ReadFile", Features: []string{"fs"}},
+		{Import: "os", Name: "WriteFile", Features: []string{"fs"}},
+		{Import: "os", Name: "ReadDir", Features: []string{"fs"}},
+		{Import: "os", Name: "MkdirAll", Features: []string{"fs"}},
+		{Import: "os", Name: "Remove", Features: []string{"fs"}},
+		{Import: "os", Name: "RemoveAll", Features: []string{"fs"}},
+	}},
+	{Name: "sea-orm", Base: []string{"macros", "runtime-tokio-rustls"}, Rules: []Rule{
+		{Import: "gorm.io/driver/postgres", Features: []string{"sqlx-postgres"}},
+		{Import: "gorm.io/driver/mysql", Features: []string{"sqlx-mysql"}},
+		{Import: "gorm.io/driver/sqlite", Features: []string{"sqlx-sqlite"}},
+		{Import: "github.com/glebarez/sqlite", Features: []string{"sqlx-sqlite"}},
+	}},
+	{Name: "uuid", Base: []string{"std"}, Rules: []Rule{
+		{Import: "github.com/google/uuid", Name: "New", Features: []string{"v4"}},
+		{Import: "github.com/google/uuid", Name: "NewRandom", Features: []string{"v4"}},
+		{Import: "github.com/google/uuid", Name: "NewString", Features: []string{"v4"}},
+		{Import: "github.com/google/uuid", Name: "NewUUID", Features: []string{"v1"}},
+		{Import: "github.com/google/uuid", Name: "NewMD5", Features: []string{"v3"}},
+		{Import: "github.com/google/uuid", Name: "NewSHA1", Features: []string{"v5"}},
+		{Import: "github.com/google/uuid", Name: "NewV6", Features: []string{"v6"}},
+		{Import: "github.com/google/uuid", Name: "NewV7", Features: []string{"v7"}},
+	}},
+	{Name: "redis", Base: []string{"tokio-comp"}, Rules: []Rule{
+		{Import: "github.com/redis/go-redis", Name: "NewClusterClient", Features: []string{"cluster-async"}},
+		{Import: "github.com/redis/go-redis", Name: "NewFailoverClient", Features: []string{"sentinel"}},
+		{Import: "github.com/redis/go-redis", Name: "NewScript", Features: []string{"script"}},
+		{Import: "github.com/go-redis/redis", Name: "NewClusterClient", Features: []string{"cluster-async"}},
+		{Import: "github.com/go-redis/redis", Name: "NewFailoverClient", Features: []string{"sentinel"}},
+		{Import: "github.com/go-redis/redis", Name: "NewScript", Features: []string{"script"}},
+	}},
+}
+
+// Lookup returns the rules of a crate. Hyphens and underscores in the name are the
+// same, as for cargo.
+func Lookup(crate string) (Crate, bool) {
+	name := strings.ReplaceAll(crate, "_", "-")
+	for _, c := range Crates {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Crate{}, false
+}
+
+// Usage is what the non-test Go files of a project import and which package-level
+// identifiers of the imports they refer to.
+type Usage struct {
+	imports map[string]bool
+	names   map[string]bool // import path + "." + identifier
+}
+
+// Scan parses the Go files under root.
+func Scan(root string) (*Usage, error) {
+	tree, err := gosrc.Parse(root)
+	if err != nil {
+		return nil, err
+	}
+	return NewUsage(tree), nil
+}
+
+// NewUsage collects the usage of the non-test files of a parsed tree.
+func NewUsage(tree *gosrc.Tree) *Usage {
+	u := &Usage{imports: make(map[string]bool), names: make(map[string]bool)}
+	for _, f := range tree.Files {
+		if f.Test {
+			continue
+		}
+		byName := make(map[string]string)
+		for _, imp := range f.AST.Imports {
+			path := strings.Trim(imp.Path.Value, "`\"")
+			u.imports[path] = true
+			name := packageName(path)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			byName[name] = path
+		}
+		ast.Inspect(f.AST, func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if x, ok := sel.X.(*ast.Ident); ok {
+				if path, ok := byName[x.Name]; ok {
+					u.names[path+"."+sel.Sel.Name] = true
+				}
+			}
+			return true
+		})
+	}
+	return u
+}
+
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// packageName guesses the name of an imported package from its path, e.g. redis for
+// github.com/redis/go-redis/v9.
+func packageName(path string) string {
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if majorVersion.MatchString(name) && len(elems) > 1 {
+		name = elems[len(elems)-2]
+	}
+	return strings.TrimPrefix(name, "go-")
+}
+
+// Features returns the sorted features of a crate that the usage calls for: the base
+// features and those of every matching rule. ok is false for a crate without rules.
+func (u *Usage) Features(crate string) (features []string, ok bool) {
+	c, ok := Lookup(crate)
+	if !ok {
+		return nil, false
+	}
+	features = slices.Clone(c.Base)
+	for _, r := range c.Rules {
+		if u.uses(r) {
+			features = append(features, r.Features...)
+		}
+	}
+	slices.Sort(features)
+	return slices.Compact(features), true
+}
+
+func (u *Usage) uses(r Rule) bool {
+	for path := range u.imports {
+		if !matchImport(r.Import, path) {
+			continue
+		}
+		if r.Name == "" || u.names[path+"."+r.Name] {
+			return true
+		}
+	}
+	return false
+}
+
+// matchImport reports whether path is pattern, one of its subpackages or a major
+// version of it.
+func matchImport(pattern, path string) bool {
+	return path == pattern || strings.HasPrefix(path, pattern+"/")
+}
@@ -0,0 +1,91 @@
+package features
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFeatures(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "main.go", `package main
+
+import (
+	"os/signal"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	redis "github.com/redis/go-redis/v9"
+	_ "gorm.io/driver/postgres"
+)
+
+func main() {
+	r := gin.Default()
+	_ = uuid.NewSHA1(uuid.NameSpaceURL, nil)
+	_ = redis.NewClusterClient(nil)
+	var d time.Duration // no timer: time alone does not need tokio's time
+	_, _ = r, d
+	signal.Ignore()
+}
+`)
+	writeFile(t, dir, "main_test.go", `package main
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestMain(t *testing.T) { _ = uuid.New(); _ = exec.Command("true") }
+`)
+	u, err := Scan(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		crate string
+		want  []string
+	}{
+		{"axum", []string{"http1", "json", "query", "tokio"}},
+		{"tokio", []string{"macros", "rt-multi-thread", "signal"}},
+		{"sea_orm", []string{"macros", "runtime-tokio-rustls", "sqlx-postgres"}},
+		{"uuid", []string{"std", "v5"}}, // uuid.New is only called by a test
+		{"redis", []string{"cluster-async", "tokio-comp"}},
+	}
+	for _, tt := range tests {
+		got, ok := u.Features(tt.crate)
+		if !ok || !slices.Equal(got, tt.want) {
+			t.Errorf("Features(%q) = %v, %v, want %v", tt.crate, got, ok, tt.want)
+		}
+	}
+	if got, ok := u.Features("serde"); ok {
+		t.Errorf("Features(serde) = %v, want no rules", got)
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	for path, want := range map[string]string{
+		"github.com/redis/go-redis/v9": "redis",
+		"github.com/labstack/echo/v4":  "echo",
+		"gorm.io/driver/postgres":      "postgres",
+		"os/signal":                    "signal",
+	} {
+		if got := packageName(path); got != want {
+			t.Errorf("packageName(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
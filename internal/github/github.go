@@ -0,0 +1,311 @@
+// Package github is a minimal client for fetching repository files and listings from GitHub.
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/stephan/rinku/internal/httpclient"
+)
+
+const (
+	DefaultAPIURL = "https://api.github.com"
+	DefaultRawURL = "https://raw.githubusercontent.com"
+
+	userAgent = "rinku (https://github.com/marvai-dev/rinku)"
+
+	// maxFileSize bounds files fetched from repositories.
+	maxFileSize = 1 << 20
+)
+
+// TokenEnv is the environment variable commands read a GitHub token from.
+const TokenEnv = "GITHUB_TOKEN"
+
+// ErrNotFound is returned when a repository or file does not exist.
+var ErrNotFound = errors.New("not found")
+
+// Repo identifies a GitHub repository.
+type Repo struct {
+	Owner string
+	Name  string
+}
+
+func (r Repo) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// ParseRepo accepts "owner/repo" or a github.com URL.
+func ParseRepo(s string) (Repo, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "https://")
+	s = strings.TrimPrefix(s, "http://")
+	s = strings.TrimPrefix(s, "github.com/")
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "/"), ".git")
+
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return Repo{}, fmt.Errorf("invalid repository %q: want owner/repo or https://github.com/owner/repo", s)
+	}
+	return Repo{Owner: parts[0], Name: parts[1]}, nil
+}
+
+// Client queries GitHub. If Token is set it is sent with every request, which
+// raises rate limits and gives access to private repositories.
+type Client struct {
+	APIURL     string
+	RawURL     string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// New returns a client for the public GitHub API.
+func New() *Client {
+	return &Client{
+		APIURL:     DefaultAPIURL,
+		RawURL:     DefaultRawURL,
+		HTTPClient: httpclient.Default(),
+	}
+}
+
+// File fetches a file from the default branch of a repository.
+func (c *Client) File(ctx context.Context, repo Repo, path string) ([]byte, error) {
+	return c.FileAt(ctx, repo, "HEAD", path)
+}
+
+// FileAt fetches a file at a branch, tag or commit.
+func (c *Client) FileAt(ctx context.Context, repo Repo, ref, path string) ([]byte, error) {
+	u := fmt.Sprintf("%s/%s/%s/%s/%s", c.RawURL, url.PathEscape(repo.Owner), url.PathEscape(repo.Name), url.PathEscape(ref), path)
+	resp, err := c.do(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s from %s: %w", path, repo, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFileSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s from %s: %w", path, repo, err)
+	}
+	return data, nil
+}
+
+// OrgRepos lists the non-archived repositories of an organization.
+func (c *Client) OrgRepos(ctx context.Context, org string) ([]Repo, error) {
+	listed, err := c.OrgRepositories(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	repos := make([]Repo, len(listed))
+	for i, r := range listed {
+		repos[i] = r.Repo
+	}
+	return repos, nil
+}
+
+// Repository is a repository of a listing with its metadata.
+type Repository struct {
+	Repo
+	Metadata
+}
+
+// OrgRepositories lists the non-archived repositories of an organization with their
+// metadata, which the listing includes, so no request per repository is needed.
+func (c *Client) OrgRepositories(ctx context.Context, org string) ([]Repository, error) {
+	var repos []Repository
+	for page := 1; ; page++ {
+		u := fmt.Sprintf("%s/orgs/%s/repos?per_page=100&page=%d", c.APIURL, url.PathEscape(org), page)
+		resp, err := c.do(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, fmt.Errorf("listing repositories of %s: %w", org, err)
+		}
+
+		var batch []struct {
+			Name  string `json:"name"`
+			Owner struct {
+				Login string `json:"login"`
+			} `json:"owner"`
+			repoInfo
+		}
+		err = json.NewDecoder(resp.Body).Decode(&batch)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decoding repositories of %s: %w", org, err)
+		}
+
+		for _, r := range batch {
+			if !r.Archived {
+				repos = append(repos, Repository{Repo: Repo{Owner: r.Owner.Login, Name: r.Name}, Metadata: r.metadata()})
+			}
+		}
+		if len(batch) < 100 {
+			return repos, nil
+		}
+	}
+}
+
+// Metadata is the repository information shown next to scan results.
+type Metadata struct {
+	Archived bool
+	Stars    int
+	License  string // SPDX identifier, "" if GitHub detected none
+}
+
+// Metadata fetches the archived state, stars and license of a repository.
+func (c *Client) Metadata(ctx context.Context, repo Repo) (*Metadata, error) {
+	u := fmt.Sprintf("%s/repos/%s/%s", c.APIURL, url.PathEscape(repo.Owner), url.PathEscape(repo.Name))
+	resp, err := c.do(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching metadata of %s: %w", repo, err)
+	}
+	defer resp.Body.Close()
+
+	var info repoInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("decoding metadata of %s: %w", repo, err)
+	}
+	m := info.metadata()
+	return &m, nil
+}
+
+// repoInfo holds the metadata fields of GitHub's repository objects.
+type repoInfo struct {
+	Archived bool `json:"archived"`
+	Stars    int  `json:"stargazers_count"`
+	License  *struct {
+		SPDXID string `json:"spdx_id"`
+	} `json:"license"`
+}
+
+func (r *repoInfo) metadata() Metadata {
+	m := Metadata{Archived: r.Archived, Stars: r.Stars}
+	if r.License != nil && r.License.SPDXID != "NOASSERTION" {
+		m.License = r.License.SPDXID
+	}
+	return m
+}
+
+// Rate is the REST API rate limit of the client's token, or of its IP address
+// without one.
+type Rate struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RateLimit returns the current REST API rate limit. The query itself is not
+// counted against it.
+func (c *Client) RateLimit(ctx context.Context) (*Rate, error) {
+	resp, err := c.do(ctx, http.MethodGet, c.APIURL+"/rate_limit", nil)
+	if err != nil {
+		return nil, fmt.Errorf("querying rate limit: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Resources struct {
+			Core struct {
+				Limit     int   `json:"limit"`
+				Remaining int   `json:"remaining"`
+				Reset     int64 `json:"reset"`
+			} `json:"core"`
+		} `json:"resources"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding rate limit: %w", err)
+	}
+	core := body.Resources.Core
+	return &Rate{Limit: core.Limit, Remaining: core.Remaining, Reset: time.Unix(core.Reset, 0)}, nil
+}
+
+// PullRequestFiles lists the paths changed by a pull request.
+func (c *Client) PullRequestFiles(ctx context.Context, repo Repo, number int) ([]string, error) {
+	var paths []string
+	for page := 1; ; page++ {
+		u := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/files?per_page=100&page=%d",
+			c.APIURL, url.PathEscape(repo.Owner), url.PathEscape(repo.Name), number, page)
+		resp, err := c.do(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, fmt.Errorf("listing files of %s#%d: %w", repo, number, err)
+		}
+
+		var batch []struct {
+			Filename string `json:"filename"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&batch)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decoding files of %s#%d: %w", repo, number, err)
+		}
+
+		for _, f := range batch {
+			paths = append(paths, f.Filename)
+		}
+		if len(batch) < 100 {
+			return paths, nil
+		}
+	}
+}
+
+// CreateIssueComment posts a comment on an issue or pull request.
+func (c *Client) CreateIssueComment(ctx context.Context, repo Repo, number int, body string) error {
+	u := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments", c.APIURL, url.PathEscape(repo.Owner), url.PathEscape(repo.Name), number)
+	return c.postComment(ctx, u, body)
+}
+
+// CreateCommitComment posts a comment on a commit.
+func (c *Client) CreateCommitComment(ctx context.Context, repo Repo, sha, body string) error {
+	u := fmt.Sprintf("%s/repos/%s/%s/commits/%s/comments", c.APIURL, url.PathEscape(repo.Owner), url.PathEscape(repo.Name), url.PathEscape(sha))
+	return c.postComment(ctx, u, body)
+}
+
+func (c *Client) postComment(ctx context.Context, u, body string) error {
+	data, err := json.Marshal(map[string]string{"body": body})
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(ctx, http.MethodPost, u, data)
+	if err != nil {
+		return fmt.Errorf("posting comment: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, u string, body []byte) (*http.Response, error) {
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, r)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return resp, nil
+}
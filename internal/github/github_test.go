@@ -0,0 +1,191 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c := New()
+	c.APIURL = srv.URL
+	c.RawURL = srv.URL
+	return c
+}
+
+func TestParseRepo(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Repo
+		wantErr bool
+	}{
+		{"spf13/cobra", Repo{"spf13", "cobra"}, false},
+		{"https://github.com/spf13/cobra", Repo{"spf13", "cobra"}, false},
+		{"github.com/spf13/cobra.git", Repo{"spf13", "cobra"}, false},
+		{" https://github.com/spf13/cobra/ ", Repo{"spf13", "cobra"}, false},
+		{"cobra", Repo{}, true},
+		{"https://github.com/spf13/cobra/tree/main", Repo{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRepo(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRepo(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRepo(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFile(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/acme/api/HEAD/go.mod" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("module github.com/acme/api\n"))
+	})
+
+	data, err := c.File(context.Background(), Repo{"acme", "api"}, "go.mod")
+	if err != nil {
+		t.Fatalf("File failed: %v", err)
+	}
+	if string(data) != "module github.com/acme/api\n" {
+		t.Errorf("File() = %q", data)
+	}
+
+	if _, err := c.File(context.Background(), Repo{"acme", "missing"}, "go.mod"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("File() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestOrgRepos_PaginatesAndSkipsArchived(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "1" {
+			fmt.Fprint(w, "[")
+			for i := 0; i < 100; i++ {
+				if i > 0 {
+					fmt.Fprint(w, ",")
+				}
+				fmt.Fprintf(w, `{"name": "repo%d", "archived": %v, "owner": {"login": "acme"}, "stargazers_count": %d}`, i, i == 0, i)
+			}
+			fmt.Fprint(w, "]")
+			return
+		}
+		w.Write([]byte(`[{"name": "last", "owner": {"login": "acme"}, "license": {"spdx_id": "MIT"}}]`))
+	})
+
+	repos, err := c.OrgRepos(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("OrgRepos failed: %v", err)
+	}
+	if len(repos) != 100 {
+		t.Errorf("len(repos) = %d, want 100 (99 + 1, archived skipped)", len(repos))
+	}
+	if repos[len(repos)-1] != (Repo{"acme", "last"}) {
+		t.Errorf("last repo = %v", repos[len(repos)-1])
+	}
+
+	listed, err := c.OrgRepositories(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("OrgRepositories failed: %v", err)
+	}
+	if listed[0].Stars != 1 || listed[len(listed)-1].License != "MIT" {
+		t.Errorf("metadata = %+v, %+v", listed[0], listed[len(listed)-1])
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	var auth string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		switch r.URL.Path {
+		case "/repos/acme/api":
+			w.Write([]byte(`{"archived": true, "stargazers_count": 42, "license": {"spdx_id": "Apache-2.0"}}`))
+		case "/repos/acme/custom":
+			w.Write([]byte(`{"stargazers_count": 1, "license": {"spdx_id": "NOASSERTION"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	c.Token = "secret"
+
+	m, err := c.Metadata(context.Background(), Repo{"acme", "api"})
+	if err != nil {
+		t.Fatalf("Metadata failed: %v", err)
+	}
+	if *m != (Metadata{Archived: true, Stars: 42, License: "Apache-2.0"}) {
+		t.Errorf("Metadata() = %+v", *m)
+	}
+	if auth != "Bearer secret" {
+		t.Errorf("Authorization = %q, want the token", auth)
+	}
+
+	if m, _ := c.Metadata(context.Background(), Repo{"acme", "custom"}); m == nil || m.License != "" {
+		t.Errorf("Metadata() = %+v, want no license for NOASSERTION", m)
+	}
+	if _, err := c.Metadata(context.Background(), Repo{"acme", "missing"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Metadata() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rate_limit" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"resources": {"core": {"limit": 60, "remaining": 12, "reset": 1700000000}}}`))
+	})
+
+	rate, err := c.RateLimit(context.Background())
+	if err != nil {
+		t.Fatalf("RateLimit failed: %v", err)
+	}
+	if rate.Limit != 60 || rate.Remaining != 12 || rate.Reset.Unix() != 1700000000 {
+		t.Errorf("RateLimit() = %+v", *rate)
+	}
+}
+
+func TestPullRequestFiles(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/api/pulls/7/files" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`[{"filename": "go.mod"}, {"filename": "cmd/main.go"}]`))
+	})
+
+	files, err := c.PullRequestFiles(context.Background(), Repo{"acme", "api"}, 7)
+	if err != nil {
+		t.Fatalf("PullRequestFiles failed: %v", err)
+	}
+	if len(files) != 2 || files[0] != "go.mod" {
+		t.Errorf("files = %q", files)
+	}
+}
+
+func TestCreateIssueComment_SendsToken(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/acme/api/issues/7/comments" {
+			t.Errorf("got %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q, want Bearer secret", got)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{}`))
+	})
+	c.Token = "secret"
+
+	if err := c.CreateIssueComment(context.Background(), Repo{"acme", "api"}, 7, "hello"); err != nil {
+		t.Fatalf("CreateIssueComment failed: %v", err)
+	}
+}
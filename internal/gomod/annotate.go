@@ -0,0 +1,150 @@
+package gomod
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// Annotation kinds rinku writes into go.mod.
+const (
+	AnnotationIgnore = "ignore" // no Rust equivalent is wanted; the value is the reason
+	AnnotationUse    = "use"    // the value is the accepted Rust crate
+	AnnotationReject = "reject" // every proposed crate was rejected; the value is the reason
+	AnnotationDefer  = "defer"  // the decision is postponed
+)
+
+const annotationPrefix = "rinku:"
+
+// Annotation is a "// rinku:<kind> [value]" comment on a require line of go.mod. It
+// shares the line comment with other text, separated by semicolons like
+// "// indirect; rinku:ignore vendored", so the go command still sees the line as
+// indirect.
+type Annotation struct {
+	Module string
+	Kind   string
+	Value  string
+}
+
+// String returns the comment text of the annotation without the slashes.
+func (a Annotation) String() string {
+	if a.Value == "" {
+		return annotationPrefix + a.Kind
+	}
+	return annotationPrefix + a.Kind + " " + a.Value
+}
+
+// Annotations returns the rinku annotations of the require lines in a go.mod, in file
+// order.
+func Annotations(data []byte) ([]Annotation, error) {
+	f, err := modfile.Parse("go.mod", data, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result []Annotation
+	for _, r := range f.Require {
+		_, annotations := splitComment(r.Syntax)
+		for _, a := range annotations {
+			a.Module = r.Mod.Path
+			result = append(result, a)
+		}
+	}
+	return result, nil
+}
+
+// SetAnnotation returns the go.mod with annotation a on the require line of a.Module,
+// replacing an annotation of the same kind. Everything else, including the order and
+// formatting of the other lines and their comments, is kept.
+func SetAnnotation(data []byte, a Annotation) ([]byte, error) {
+	if a.Kind == "" || strings.ContainsAny(a.Kind, " ;") {
+		return nil, fmt.Errorf("invalid annotation kind %q", a.Kind)
+	}
+	if strings.ContainsAny(a.Value, ";\n") {
+		return nil, fmt.Errorf("annotation value %q must not contain a semicolon or newline", a.Value)
+	}
+	return editAnnotations(data, a.Module, func(annotations []Annotation) []Annotation {
+		annotations = dropKind(annotations, a.Kind)
+		return append(annotations, Annotation{Kind: a.Kind, Value: strings.TrimSpace(a.Value)})
+	})
+}
+
+// RemoveAnnotation returns the go.mod without the annotation of kind on the require
+// line of module.
+func RemoveAnnotation(data []byte, module, kind string) ([]byte, error) {
+	return editAnnotations(data, module, func(annotations []Annotation) []Annotation {
+		return dropKind(annotations, kind)
+	})
+}
+
+func editAnnotations(data []byte, module string, edit func([]Annotation) []Annotation) ([]byte, error) {
+	f, err := modfile.Parse("go.mod", data, nil)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, r := range f.Require {
+		if r.Mod.Path != module {
+			continue
+		}
+		found = true
+		other, annotations := splitComment(r.Syntax)
+		joinComment(r.Syntax, other, edit(annotations))
+	}
+	if !found {
+		return nil, fmt.Errorf("%s is not required in go.mod", module)
+	}
+	return modfile.Format(f.Syntax), nil
+}
+
+// splitComment splits the line comment of a require line into its other parts, e.g.
+// "indirect", and its annotations.
+func splitComment(line *modfile.Line) (other []string, annotations []Annotation) {
+	if len(line.Suffix) == 0 {
+		return nil, nil
+	}
+	text := strings.TrimPrefix(line.Suffix[0].Token, "//")
+	for _, part := range strings.Split(text, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		rest, ok := strings.CutPrefix(part, annotationPrefix)
+		if !ok {
+			other = append(other, part)
+			continue
+		}
+		kind, value, _ := strings.Cut(rest, " ")
+		annotations = append(annotations, Annotation{Kind: kind, Value: strings.TrimSpace(value)})
+	}
+	return other, annotations
+}
+
+// joinComment sets the line comment of a require line, or removes it if it is empty.
+func joinComment(line *modfile.Line, other []string, annotations []Annotation) {
+	parts := other
+	for _, a := range annotations {
+		parts = append(parts, a.String())
+	}
+	if len(parts) == 0 {
+		line.Suffix = nil
+		return
+	}
+	comment := modfile.Comment{Token: "// " + strings.Join(parts, "; "), Suffix: true}
+	if len(line.Suffix) == 0 {
+		line.Suffix = []modfile.Comment{comment}
+		return
+	}
+	comment.Start = line.Suffix[0].Start
+	line.Suffix[0] = comment
+}
+
+func dropKind(annotations []Annotation, kind string) []Annotation {
+	result := annotations[:0]
+	for _, a := range annotations {
+		if a.Kind != kind {
+			result = append(result, a)
+		}
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+package gomod
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const annotatedGoMod = `module example.com/app
+
+go 1.22
+
+require (
+	// the CLI
+	github.com/spf13/cobra v1.8.0
+	github.com/lib/pq v1.10.9 // indirect
+	example.com/private/soap v0.3.0 // rinku:ignore replaced by REST
+)
+`
+
+func TestAnnotations(t *testing.T) {
+	got, err := Annotations([]byte(annotatedGoMod))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Annotation{{Module: "example.com/private/soap", Kind: AnnotationIgnore, Value: "replaced by REST"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Annotations() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSetAnnotation(t *testing.T) {
+	data, err := SetAnnotation([]byte(annotatedGoMod), Annotation{Module: "github.com/spf13/cobra", Kind: AnnotationUse, Value: "clap"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err = SetAnnotation(data, Annotation{Module: "github.com/lib/pq", Kind: AnnotationDefer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err = SetAnnotation(data, Annotation{Module: "example.com/private/soap", Kind: AnnotationIgnore, Value: "partner API retired"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `module example.com/app
+
+go 1.22
+
+require (
+	// the CLI
+	github.com/spf13/cobra v1.8.0 // rinku:use clap
+	github.com/lib/pq v1.10.9 // indirect; rinku:defer
+	example.com/private/soap v0.3.0 // rinku:ignore partner API retired
+)
+`
+	if string(data) != want {
+		t.Errorf("SetAnnotation() =\n%s\nwant\n%s", data, want)
+	}
+
+	// the line stays indirect for the go command and for Parse
+	result, err := ParseReader(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Dependencies[1].Indirect {
+		t.Errorf("github.com/lib/pq is no longer indirect")
+	}
+
+	if _, err := SetAnnotation(data, Annotation{Module: "github.com/unknown", Kind: AnnotationIgnore}); err == nil {
+		t.Error("annotating a module that is not required: no error")
+	}
+	if _, err := SetAnnotation(data, Annotation{Module: "github.com/lib/pq", Kind: AnnotationIgnore, Value: "a; b"}); err == nil {
+		t.Error("value with a semicolon: no error")
+	}
+}
+
+func TestRemoveAnnotation(t *testing.T) {
+	data, err := RemoveAnnotation([]byte(annotatedGoMod), "example.com/private/soap", AnnotationIgnore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations, err := Annotations(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(annotations) != 0 {
+		t.Errorf("Annotations() = %+v after RemoveAnnotation", annotations)
+	}
+	if want := "\texample.com/private/soap v0.3.0\n"; !strings.Contains(string(data), want) {
+		t.Errorf("RemoveAnnotation() =\n%s", data)
+	}
+}
@@ -0,0 +1,91 @@
+package gomod
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// ParseSum returns the modules whose source a go.sum records a hash for, sorted by path.
+// Modules listed only with a "/go.mod" hash took part in version selection but none of
+// their code is built, so they are left out. A module required at several versions is
+// returned once, at its highest version in file order.
+func ParseSum(path string) ([]Dependency, error) {
+	return ParseSumFS(afero.NewOsFs(), path)
+}
+
+// ParseSumFS parses a go.sum from a filesystem (useful for testing).
+func ParseSumFS(fs afero.Fs, path string) ([]Dependency, error) {
+	file, err := fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseSumReader(file)
+}
+
+func ParseSumReader(r io.Reader) ([]Dependency, error) {
+	versions := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		// go.sum is sorted, so a later line has the higher version
+		versions[fields[0]] = fields[1]
+		if len(versions) > MaxDependencies {
+			return nil, ErrTooManyDependencies
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	deps := make([]Dependency, 0, len(versions))
+	for path, version := range versions {
+		deps = append(deps, Dependency{Path: path, Version: version})
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Path < deps[j].Path })
+	return deps, nil
+}
+
+// ParseModList returns the modules of the output of "go list -m all", sorted by path:
+// every line but the first, which is the main module. Replacements ("=> ...") are
+// reported under the module they replace.
+func ParseModList(path string) ([]Dependency, error) {
+	return ParseModListFS(afero.NewOsFs(), path)
+}
+
+// ParseModListFS parses "go list -m all" output from a filesystem (useful for testing).
+func ParseModListFS(fs afero.Fs, path string) ([]Dependency, error) {
+	file, err := fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseModListReader(file)
+}
+
+func ParseModListReader(r io.Reader) ([]Dependency, error) {
+	var deps []Dependency
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// The main module and modules of the workspace have no version
+		if len(fields) < 2 || fields[1] == "=>" {
+			continue
+		}
+		deps = append(deps, Dependency{Path: fields[0], Version: fields[1]})
+		if len(deps) > MaxDependencies {
+			return nil, ErrTooManyDependencies
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Path < deps[j].Path })
+	return deps, nil
+}
@@ -0,0 +1,51 @@
+package gomod
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSumReader(t *testing.T) {
+	input := `github.com/spf13/cobra v1.8.0 h1:7aJaZx1B85qltLMc546zn58BxxfZdR/W22ej9CFoEf0=
+github.com/spf13/cobra v1.8.0/go.mod h1:WXLWApfZ71AjXPya3WOlMsY9yMs7YeiHhFVlvLyhcho=
+github.com/spf13/pflag v1.0.5 h1:iy+VFUOCP1a+8yFto/drg2CJ5u0yRoB7fZw3DKv/JXA=
+github.com/spf13/pflag v1.0.5/go.mod h1:McXfInJRrz4CZXVZOBLb0bTZqETkiAhM9Iw0y3An2Bg=
+golang.org/x/sys v0.1.0/go.mod h1:oPkhp1MJrh7nUepCBck5+mAzfO9JrbApNNgaTdGDITg=
+golang.org/x/text v0.3.0 h1:g61tztE5qeGQ89tm6NTjjM9VPIm088od1l6aSorWRWg=
+golang.org/x/text v0.14.0 h1:ScX5w1eTa3QqT8oi6+ziP7dTV1S2+ALU0bI+0zXKWiQ=
+`
+	got, err := ParseSumReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseSumReader failed: %v", err)
+	}
+	want := []Dependency{
+		{Path: "github.com/spf13/cobra", Version: "v1.8.0"},
+		{Path: "github.com/spf13/pflag", Version: "v1.0.5"},
+		{Path: "golang.org/x/text", Version: "v0.14.0"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseSumReader() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseModListReader(t *testing.T) {
+	input := `example.com/app
+github.com/spf13/pflag v1.0.5
+github.com/spf13/cobra v1.8.0
+example.com/local => ../local
+golang.org/x/text v0.14.0 => golang.org/x/text v0.15.0
+`
+	got, err := ParseModListReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseModListReader failed: %v", err)
+	}
+	want := []Dependency{
+		{Path: "github.com/spf13/cobra", Version: "v1.8.0"},
+		{Path: "github.com/spf13/pflag", Version: "v1.0.5"},
+		{Path: "golang.org/x/text", Version: "v0.14.0"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseModListReader() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,82 @@
+package gomod
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+var (
+	ErrUnclosedUseBlock = errors.New("unclosed use block")
+
+	useSingleRe   = regexp.MustCompile(`^use\s+(\S+)`)
+	useBlockStart = regexp.MustCompile(`^use\s*\(`)
+)
+
+// WorkResult is the content of a go.work file.
+type WorkResult struct {
+	GoVersion string
+	Use       []string // module directories, relative to the go.work
+}
+
+// ParseWork parses a go.work file.
+func ParseWork(path string) (*WorkResult, error) {
+	return ParseWorkFS(afero.NewOsFs(), path)
+}
+
+// ParseWorkFS parses a go.work from a filesystem (useful for testing).
+func ParseWorkFS(fs afero.Fs, path string) (*WorkResult, error) {
+	file, err := fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseWorkReader(file)
+}
+
+func ParseWorkReader(r io.Reader) (*WorkResult, error) {
+	result := &WorkResult{}
+	scanner := bufio.NewScanner(r)
+	inBlock := false
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" {
+			continue
+		}
+
+		switch {
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock:
+			result.Use = append(result.Use, unquote(line))
+		case useBlockStart.MatchString(line):
+			inBlock = true
+		default:
+			if m := useSingleRe.FindStringSubmatch(line); m != nil {
+				result.Use = append(result.Use, unquote(m[1]))
+			} else if m := goVersionRe.FindStringSubmatch(line); m != nil {
+				result.GoVersion = m[1]
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if inBlock {
+		return nil, ErrUnclosedUseBlock
+	}
+	return result, nil
+}
+
+func unquote(s string) string {
+	return strings.Trim(s, "\"`")
+}
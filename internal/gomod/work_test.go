@@ -0,0 +1,30 @@
+package gomod
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseWorkReader(t *testing.T) {
+	input := `go 1.22
+
+use ./cmd/api // the service
+use (
+	./lib
+	"./tools"
+)
+`
+	got, err := ParseWorkReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseWorkReader failed: %v", err)
+	}
+	want := &WorkResult{GoVersion: "1.22", Use: []string{"./cmd/api", "./lib", "./tools"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseWorkReader() = %+v, want %+v", got, want)
+	}
+
+	if _, err := ParseWorkReader(strings.NewReader("use (\n\t./lib\n")); err != ErrUnclosedUseBlock {
+		t.Errorf("unclosed block: err = %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+// Package gopkg maps Go import paths to Rust at the granularity of packages: the
+// standard library, which the mapping database only knows as a whole, and the
+// subpackages of modules such as golang.org/x/crypto whose module mapping covers only
+// part of them.
+package gopkg
+
+import (
+	"strings"
+)
+
+// Equivalent is the Rust counterpart of a Go package and its subpackages.
+type Equivalent struct {
+	Path string   // import path
+	Rust []string // crates, or std:: modules, best first
+}
+
+// Equivalents lists the known packages. Lookup picks the longest matching path, so a
+// subpackage listed here overrides its parent.
+var Equivalents = []Equivalent{
+	// Standard library
+	{Path: "archive/tar", Rust: []string{"tar"}},
+	{Path: "archive/zip", Rust: []string{"zip"}},
+	{Path: "bufio", Rust: []string{"std::io"}},
+	{Path: "bytes", Rust: []string{"bytes", "std::vec"}},
+	{Path: "compress/flate", Rust: []string{"flate2"}},
+	{Path: "compress/gzip", Rust: []string{"flate2"}},
+	{Path: "compress/zlib", Rust: []string{"flate2"}},
+	{Path: "container/heap", Rust: []string{"std::collections"}},
+	{Path: "container/list", Rust: []string{"std::collections"}},
+	{Path: "context", Rust: []string{"tokio-util", "tokio"}},
+	{Path: "crypto", Rust: []string{"rustls", "ring"}},
+	{Path: "crypto/aes", Rust: []string{"aes-gcm"}},
+	{Path: "crypto/cipher", Rust: []string{"aes-gcm", "chacha20poly1305"}},
+	{Path: "crypto/ecdsa", Rust: []string{"p256", "ecdsa"}},
+	{Path: "crypto/ed25519", Rust: []string{"ed25519-dalek"}},
+	{Path: "crypto/hmac", Rust: []string{"hmac"}},
+	{Path: "crypto/md5", Rust: []string{"md-5"}},
+	{Path: "crypto/rand", Rust: []string{"rand", "getrandom"}},
+	{Path: "crypto/rsa", Rust: []string{"rsa"}},
+	{Path: "crypto/sha1", Rust: []string{"sha1"}},
+	{Path: "crypto/sha256", Rust: []string{"sha2"}},
+	{Path: "crypto/sha512", Rust: []string{"sha2"}},
+	{Path: "crypto/subtle", Rust: []string{"subtle"}},
+	{Path: "crypto/tls", Rust: []string{"rustls", "tokio-rustls"}},
+	{Path: "crypto/x509", Rust: []string{"rustls-pemfile", "x509-parser"}},
+	{Path: "database/sql", Rust: []string{"sqlx"}},
+	{Path: "embed", Rust: []string{"include_dir", "rust-embed"}},
+	{Path: "encoding/base32", Rust: []string{"data-encoding"}},
+	{Path: "encoding/base64", Rust: []string{"base64"}},
+	{Path: "encoding/binary", Rust: []string{"byteorder"}},
+	{Path: "encoding/csv", Rust: []string{"csv"}},
+	{Path: "encoding/gob", Rust: []string{"bincode"}},
+	{Path: "encoding/hex", Rust: []string{"hex"}},
+	{Path: "encoding/json", Rust: []string{"serde_json"}},
+	{Path: "encoding/pem", Rust: []string{"pem"}},
+	{Path: "encoding/xml", Rust: []string{"quick-xml"}},
+	{Path: "errors", Rust: []string{"thiserror", "anyhow"}},
+	{Path: "expvar", Rust: []string{"metrics"}},
+	{Path: "flag", Rust: []string{"clap"}},
+	{Path: "fmt", Rust: []string{"std::fmt"}},
+	{Path: "hash", Rust: []string{"std::hash"}},
+	{Path: "hash/crc32", Rust: []string{"crc32fast"}},
+	{Path: "hash/fnv", Rust: []string{"fnv"}},
+	{Path: "html", Rust: []string{"html-escape"}},
+	{Path: "html/template", Rust: []string{"askama", "tera"}},
+	{Path: "image", Rust: []string{"image"}},
+	{Path: "io", Rust: []string{"std::io"}},
+	{Path: "io/fs", Rust: []string{"std::fs"}},
+	{Path: "log", Rust: []string{"log"}},
+	{Path: "log/slog", Rust: []string{"tracing"}},
+	{Path: "maps", Rust: []string{"std::collections"}},
+	{Path: "math", Rust: []string{"std::f64"}},
+	{Path: "math/big", Rust: []string{"num-bigint"}},
+	{Path: "math/rand", Rust: []string{"rand"}},
+	{Path: "mime", Rust: []string{"mime"}},
+	{Path: "mime/multipart", Rust: []string{"multer"}},
+	{Path: "net", Rust: []string{"std::net", "tokio"}},
+	{Path: "net/http", Rust: []string{"axum", "reqwest", "hyper"}},
+	{Path: "net/http/httptest", Rust: []string{"axum-test", "wiremock"}},
+	{Path: "net/http/httputil", Rust: []string{"hyper"}},
+	{Path: "net/http/pprof", Rust: []string{"pprof"}},
+	{Path: "net/mail", Rust: []string{"mail-parser"}},
+	{Path: "net/netip", Rust: []string{"std::net"}},
+	{Path: "net/rpc", Rust: []string{"tarpc"}},
+	{Path: "net/smtp", Rust: []string{"lettre"}},
+	{Path: "net/url", Rust: []string{"url"}},
+	{Path: "os", Rust: []string{"std::fs", "std::env"}},
+	{Path: "os/exec", Rust: []string{"std::process"}},
+	{Path: "os/signal", Rust: []string{"tokio", "signal-hook"}},
+	{Path: "os/user", Rust: []string{"whoami"}},
+	{Path: "path", Rust: []string{"std::path"}},
+	{Path: "path/filepath", Rust: []string{"std::path", "walkdir", "glob"}},
+	{Path: "reflect", Rust: []string{"serde"}},
+	{Path: "regexp", Rust: []string{"regex"}},
+	{Path: "runtime", Rust: []string{"std::thread"}},
+	{Path: "slices", Rust: []string{"std::slice"}},
+	{Path: "sort", Rust: []string{"std::slice"}},
+	{Path: "strconv", Rust: []string{"std::str"}},
+	{Path: "strings", Rust: []string{"std::str"}},
+	{Path: "sync", Rust: []string{"std::sync", "parking_lot"}},
+	{Path: "sync/atomic", Rust: []string{"std::sync::atomic"}},
+	{Path: "syscall", Rust: []string{"libc", "nix"}},
+	{Path: "testing", Rust: []string{"rstest"}},
+	{Path: "testing/quick", Rust: []string{"proptest", "quickcheck"}},
+	{Path: "text/tabwriter", Rust: []string{"tabwriter"}},
+	{Path: "text/template", Rust: []string{"tera", "handlebars"}},
+	{Path: "time", Rust: []string{"std::time", "chrono"}},
+	{Path: "unicode", Rust: []string{"std::char"}},
+	{Path: "unicode/utf8", Rust: []string{"std::str"}},
+	{Path: "unsafe", Rust: []string{"std::mem", "std::ptr"}},
+
+	// Subpackages of golang.org/x modules
+	{Path: "golang.org/x/crypto/acme/autocert", Rust: []string{"rustls-acme"}},
+	{Path: "golang.org/x/crypto/argon2", Rust: []string{"argon2"}},
+	{Path: "golang.org/x/crypto/bcrypt", Rust: []string{"bcrypt"}},
+	{Path: "golang.org/x/crypto/blake2b", Rust: []string{"blake2"}},
+	{Path: "golang.org/x/crypto/chacha20poly1305", Rust: []string{"chacha20poly1305"}},
+	{Path: "golang.org/x/crypto/curve25519", Rust: []string{"x25519-dalek"}},
+	{Path: "golang.org/x/crypto/ed25519", Rust: []string{"ed25519-dalek"}},
+	{Path: "golang.org/x/crypto/hkdf", Rust: []string{"hkdf"}},
+	{Path: "golang.org/x/crypto/nacl", Rust: []string{"crypto_box"}},
+	{Path: "golang.org/x/crypto/pbkdf2", Rust: []string{"pbkdf2"}},
+	{Path: "golang.org/x/crypto/scrypt", Rust: []string{"scrypt"}},
+	{Path: "golang.org/x/crypto/sha3", Rust: []string{"sha3"}},
+	{Path: "golang.org/x/crypto/ssh", Rust: []string{"russh"}},
+	{Path: "golang.org/x/crypto/ssh/terminal", Rust: []string{"crossterm"}},
+	{Path: "golang.org/x/net/context", Rust: []string{"tokio-util", "tokio"}},
+	{Path: "golang.org/x/net/html", Rust: []string{"html5ever", "scraper"}},
+	{Path: "golang.org/x/net/http2", Rust: []string{"h2"}},
+	{Path: "golang.org/x/net/proxy", Rust: []string{"tokio-socks"}},
+	{Path: "golang.org/x/net/websocket", Rust: []string{"tokio-tungstenite"}},
+	{Path: "golang.org/x/oauth2", Rust: []string{"oauth2"}},
+	{Path: "golang.org/x/sync/errgroup", Rust: []string{"tokio"}},
+	{Path: "golang.org/x/sync/semaphore", Rust: []string{"tokio"}},
+	{Path: "golang.org/x/sync/singleflight", Rust: []string{"async-singleflight"}},
+	{Path: "golang.org/x/term", Rust: []string{"crossterm"}},
+	{Path: "golang.org/x/text/cases", Rust: []string{"heck"}},
+	{Path: "golang.org/x/text/language", Rust: []string{"unic-langid"}},
+	{Path: "golang.org/x/text/unicode/norm", Rust: []string{"unicode-normalization"}},
+	{Path: "golang.org/x/time/rate", Rust: []string{"governor"}},
+}
+
+// IsStdlib reports whether an import path belongs to the standard library: its first
+// element has no dot, unlike a module path.
+func IsStdlib(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// Lookup returns the equivalent of an import path: the listed package with the
+// longest path that is the import path or one of its parents.
+func Lookup(path string) (Equivalent, bool) {
+	var best Equivalent
+	found := false
+	for _, e := range Equivalents {
+		if (path == e.Path || strings.HasPrefix(path, e.Path+"/")) && len(e.Path) > len(best.Path) {
+			best, found = e, true
+		}
+	}
+	return best, found
+}
+
+// URL returns the documentation of a target of an equivalent: the std module on
+// doc.rust-lang.org or the crate on crates.io.
+func URL(target string) string {
+	if rest, ok := strings.CutPrefix(target, "std::"); ok {
+		return "https://doc.rust-lang.org/std/" + strings.ReplaceAll(rest, "::", "/") + "/"
+	}
+	return "https://crates.io/crates/" + target
+}
@@ -0,0 +1,52 @@
+package gopkg
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		path string
+		want string // first target, empty if none
+	}{
+		{"encoding/json", "serde_json"},
+		{"net/http", "axum"},
+		{"net/http/httptest", "axum-test"},
+		{"net/http/cookiejar", "axum"}, // falls back to the parent package
+		{"golang.org/x/crypto/ssh", "russh"},
+		{"golang.org/x/crypto/ssh/agent", "russh"},
+		{"golang.org/x/crypto/md4", ""}, // left to the module mapping
+		{"encoding", ""},
+		{"github.com/spf13/cobra", ""},
+	}
+	for _, tt := range tests {
+		e, ok := Lookup(tt.path)
+		got := ""
+		if ok {
+			got = e.Rust[0]
+		}
+		if got != tt.want {
+			t.Errorf("Lookup(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestIsStdlib(t *testing.T) {
+	for path, want := range map[string]bool{
+		"fmt":                     true,
+		"net/http":                true,
+		"golang.org/x/crypto/ssh": false,
+		"example.com/app/pkg":     false,
+	} {
+		if got := IsStdlib(path); got != want {
+			t.Errorf("IsStdlib(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestURL(t *testing.T) {
+	if got := URL("std::sync::atomic"); got != "https://doc.rust-lang.org/std/sync/atomic/" {
+		t.Errorf("URL(std::sync::atomic) = %s", got)
+	}
+	if got := URL("serde_json"); got != "https://crates.io/crates/serde_json" {
+		t.Errorf("URL(serde_json) = %s", got)
+	}
+}
@@ -0,0 +1,113 @@
+// Package goproxy is a minimal client for the Go module proxy protocol.
+package goproxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/httpclient"
+)
+
+const DefaultBaseURL = "https://proxy.golang.org"
+
+// ErrNotFound is returned when the proxy does not know a module version.
+var ErrNotFound = errors.New("module version not found")
+
+// Client queries a Go module proxy.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// New returns a client for proxy.golang.org.
+func New() *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: httpclient.Default(),
+	}
+}
+
+// Info is the metadata of a module version.
+type Info struct {
+	Version string    `json:"Version"`
+	Time    time.Time `json:"Time"` // when the version was published
+}
+
+// GoMod fetches and parses the go.mod of a module version.
+func (c *Client) GoMod(ctx context.Context, module, version string) (*gomod.ParseResult, error) {
+	body, err := c.open(ctx, module, version, ".mod", "go.mod")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	result, err := gomod.ParseReader(body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing go.mod of %s@%s: %w", module, version, err)
+	}
+	return result, nil
+}
+
+// Info fetches the canonical version and publication time of a module version.
+func (c *Client) Info(ctx context.Context, module, version string) (*Info, error) {
+	body, err := c.open(ctx, module, version, ".info", "info")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var info Info
+	if err := json.NewDecoder(body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("parsing info of %s@%s: %w", module, version, err)
+	}
+	return &info, nil
+}
+
+// open requests the file with extension ext (.mod or .info) of a module version; what
+// names it in errors.
+func (c *Client) open(ctx context.Context, module, version, ext, what string) (io.ReadCloser, error) {
+	u := c.BaseURL + "/" + escape(module) + "/@v/" + escape(version) + ext
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s of %s@%s: %w", what, module, version, err)
+	}
+
+	// The proxy answers 410 Gone for versions it refuses to serve.
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		resp.Body.Close()
+		return nil, fmt.Errorf("fetching %s of %s@%s: %w", what, module, version, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("fetching %s of %s@%s: unexpected status: %s", what, module, version, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// escape applies the proxy's case encoding: upper-case letters become '!' plus the
+// lower-case letter, so paths stay unambiguous on case-insensitive file systems.
+func escape(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if unicode.IsUpper(r) {
+			sb.WriteByte('!')
+			sb.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,66 @@
+package goproxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGoMod(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/github.com/!burnt!sushi/toml/@v/v1.3.2.mod" {
+			t.Errorf("path = %q", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("module github.com/BurntSushi/toml\n\ngo 1.18\n\nrequire (\n\tgolang.org/x/text v0.3.0\n\tgolang.org/x/sys v0.1.0 // indirect\n)\n"))
+	}))
+	defer srv.Close()
+
+	c := New()
+	c.BaseURL = srv.URL
+	mod, err := c.GoMod(context.Background(), "github.com/BurntSushi/toml", "v1.3.2")
+	if err != nil {
+		t.Fatalf("GoMod failed: %v", err)
+	}
+	if mod.Module != "github.com/BurntSushi/toml" || len(mod.Dependencies) != 2 {
+		t.Errorf("GoMod = %+v, want module with 2 requirements", mod)
+	}
+}
+
+func TestGoMod_Gone(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer srv.Close()
+
+	c := New()
+	c.BaseURL = srv.URL
+	if _, err := c.GoMod(context.Background(), "example.com/m", "v0.0.1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/github.com/gin-gonic/gin/@v/v1.9.1.info" {
+			t.Errorf("path = %q", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"Version":"v1.9.1","Time":"2023-06-12T01:10:17Z"}`))
+	}))
+	defer srv.Close()
+
+	c := New()
+	c.BaseURL = srv.URL
+	info, err := c.Info(context.Background(), "github.com/gin-gonic/gin", "v1.9.1")
+	if err != nil {
+		t.Fatalf("Info failed: %v", err)
+	}
+	if info.Version != "v1.9.1" || info.Time.Year() != 2023 {
+		t.Errorf("Info = %+v", info)
+	}
+}
@@ -0,0 +1,162 @@
+// Package gosrc scans Go source trees.
+package gosrc
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// File holds what was extracted from a single Go source file.
+type File struct {
+	Path    string // slash-separated, relative to the scan root
+	Package string
+	Test    bool // true for _test.go files
+	Imports []string
+}
+
+// Result holds all scanned files, sorted by path.
+type Result struct {
+	Files []File
+}
+
+// Walk calls fn for every .go file under root.
+// Hidden directories, vendor/, testdata/ and directories starting with _ are skipped,
+// matching what the go tool ignores. rel is the slash-separated path relative to root.
+func Walk(root string, fn func(path, rel string) error) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && skipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".go") {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		return fn(path, filepath.ToSlash(rel))
+	})
+}
+
+func skipDir(name string) bool {
+	return name == "vendor" || name == "testdata" ||
+		strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// ScanImports parses the import declarations of every Go file under root.
+func ScanImports(root string) (*Result, error) {
+	result := &Result{}
+	err := walkParsed(root, token.NewFileSet(), parser.ImportsOnly, func(file File, _ *ast.File) {
+		result.Files = append(result.Files, file)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(result.Files, func(i, j int) bool {
+		return result.Files[i].Path < result.Files[j].Path
+	})
+	return result, nil
+}
+
+// ParsedFile is a fully parsed Go source file.
+type ParsedFile struct {
+	File
+	AST *ast.File
+}
+
+// Tree holds the parsed files of a source tree, sorted by path.
+type Tree struct {
+	Fset  *token.FileSet
+	Files []ParsedFile
+}
+
+// Parse fully parses every Go file under root, including comments.
+func Parse(root string) (*Tree, error) {
+	tree := &Tree{Fset: token.NewFileSet()}
+	err := walkParsed(root, tree.Fset, parser.ParseComments, func(file File, f *ast.File) {
+		tree.Files = append(tree.Files, ParsedFile{File: file, AST: f})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(tree.Files, func(i, j int) bool {
+		return tree.Files[i].Path < tree.Files[j].Path
+	})
+	return tree, nil
+}
+
+// Position returns the slash-separated file path and line of pos, relative to the root.
+func (t *Tree) Position(pos token.Pos) (string, int) {
+	p := t.Fset.Position(pos)
+	return p.Filename, p.Line
+}
+
+func walkParsed(root string, fset *token.FileSet, mode parser.Mode, fn func(File, *ast.File)) error {
+	return Walk(root, func(path, rel string) error {
+		src, err := os.ReadFile(path) //#nosec G304 -- path found by walking the project directory
+		if err != nil {
+			return err
+		}
+		f, err := parser.ParseFile(fset, rel, src, mode)
+		if err != nil {
+			return err
+		}
+		file := File{
+			Path:    rel,
+			Package: f.Name.Name,
+			Test:    strings.HasSuffix(rel, "_test.go"),
+		}
+		for _, imp := range f.Imports {
+			p, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				continue
+			}
+			file.Imports = append(file.Imports, p)
+		}
+		fn(file, f)
+		return nil
+	})
+}
+
+// SourceImports returns the sorted, unique imports of non-test files.
+func (r *Result) SourceImports() []string {
+	return r.imports(false)
+}
+
+// TestImports returns the sorted, unique imports of _test.go files.
+func (r *Result) TestImports() []string {
+	return r.imports(true)
+}
+
+func (r *Result) imports(test bool) []string {
+	seen := make(map[string]struct{})
+	for _, f := range r.Files {
+		if f.Test != test {
+			continue
+		}
+		for _, imp := range f.Imports {
+			seen[imp] = struct{}{}
+		}
+	}
+	result := make([]string, 0, len(seen))
+	for imp := range seen {
+		result = append(result, imp)
+	}
+	sort.Strings(result)
+	return result
+}
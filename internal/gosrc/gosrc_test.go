@@ -0,0 +1,101 @@
+package gosrc
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanImports(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "main.go", `package main
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+)
+`)
+	writeFile(t, dir, "api/api_test.go", `package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+`)
+	writeFile(t, dir, "vendor/x/x.go", `package x
+
+import "github.com/vendored/dep"
+`)
+	writeFile(t, dir, "testdata/fixture.go", `package fixture
+
+import "github.com/fixture/dep"
+`)
+
+	result, err := ScanImports(dir)
+	if err != nil {
+		t.Fatalf("ScanImports failed: %v", err)
+	}
+
+	if len(result.Files) != 2 {
+		t.Fatalf("len(Files) = %d, want 2", len(result.Files))
+	}
+	if result.Files[0].Path != "api/api_test.go" || !result.Files[0].Test {
+		t.Errorf("Files[0] = %+v, want test file api/api_test.go", result.Files[0])
+	}
+	if result.Files[1].Package != "main" {
+		t.Errorf("Files[1].Package = %q, want main", result.Files[1].Package)
+	}
+
+	wantSource := []string{"fmt", "github.com/spf13/cobra"}
+	if got := result.SourceImports(); !reflect.DeepEqual(got, wantSource) {
+		t.Errorf("SourceImports() = %v, want %v", got, wantSource)
+	}
+	wantTest := []string{"github.com/stretchr/testify/assert", "net/http/httptest", "testing"}
+	if got := result.TestImports(); !reflect.DeepEqual(got, wantTest) {
+		t.Errorf("TestImports() = %v, want %v", got, wantTest)
+	}
+}
+
+func TestScanImports_InvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "broken.go", "not go code")
+
+	if _, err := ScanImports(dir); err == nil {
+		t.Error("expected error for unparsable file")
+	}
+}
+
+func TestParse(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "b/b.go", "package b\n\n// B is exported.\ntype B struct{}\n")
+	writeFile(t, dir, "a.go", "package a\n\nfunc A() {}\n")
+
+	tree, err := Parse(dir)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(tree.Files) != 2 || tree.Files[0].Path != "a.go" || tree.Files[1].Path != "b/b.go" {
+		t.Fatalf("files = %+v", tree.Files)
+	}
+	if tree.Files[1].AST.Comments == nil {
+		t.Error("comments should be parsed")
+	}
+	decl := tree.Files[0].AST.Decls[0]
+	if path, line := tree.Position(decl.Pos()); path != "a.go" || line != 3 {
+		t.Errorf("Position() = %s:%d, want a.go:3", path, line)
+	}
+}
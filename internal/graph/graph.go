@@ -0,0 +1,165 @@
+// Package graph builds the import graph of a Go module's packages and exports it as
+// Graphviz DOT or Mermaid.
+package graph
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+// Package is a package of the module and what it imports.
+type Package struct {
+	Dir        string   `json:"dir"` // slash-separated, relative to the module root
+	ImportPath string   `json:"import_path"`
+	Main       bool     `json:"main,omitempty"`
+	Imports    []string `json:"imports"`  // Dirs of imported packages of the module
+	External   []string `json:"external"` // go.mod modules providing the other imports
+}
+
+// Graph is the package import graph of a module, packages sorted by directory.
+type Graph struct {
+	Module   string    `json:"module"`
+	Packages []Package `json:"packages"`
+}
+
+// Build returns the graph of the non-test files of module. Imports are attributed to
+// the longest of modules (the go.mod requirements) that prefixes them; standard library
+// and unknown imports are left out.
+func Build(module string, files []gosrc.File, modules []string) *Graph {
+	known := make(map[string]bool, len(modules))
+	for _, m := range modules {
+		known[m] = true
+	}
+
+	pkgs := make(map[string]*Package)
+	imports := make(map[string]map[string]bool)
+	external := make(map[string]map[string]bool)
+	for _, f := range files {
+		if f.Test {
+			continue
+		}
+		dir := path.Dir(f.Path)
+		p := pkgs[dir]
+		if p == nil {
+			importPath := module
+			if dir != "." {
+				importPath = module + "/" + dir
+			}
+			p = &Package{Dir: dir, ImportPath: importPath}
+			pkgs[dir] = p
+			imports[dir] = make(map[string]bool)
+			external[dir] = make(map[string]bool)
+		}
+		if f.Package == "main" {
+			p.Main = true
+		}
+		for _, imp := range f.Imports {
+			switch {
+			case imp == module:
+				imports[dir]["."] = true
+			case strings.HasPrefix(imp, module+"/"):
+				imports[dir][strings.TrimPrefix(imp, module+"/")] = true
+			default:
+				if mod := owningModule(imp, known); mod != "" {
+					external[dir][mod] = true
+				}
+			}
+		}
+	}
+
+	g := &Graph{Module: module}
+	for dir, p := range pkgs {
+		p.Imports = []string{}
+		for imp := range imports[dir] {
+			// imports of directories without non-test files do not build; leave them out
+			if pkgs[imp] != nil && imp != dir {
+				p.Imports = append(p.Imports, imp)
+			}
+		}
+		p.External = sortedKeys(external[dir])
+		sort.Strings(p.Imports)
+		g.Packages = append(g.Packages, *p)
+	}
+	sort.Slice(g.Packages, func(i, j int) bool { return g.Packages[i].Dir < g.Packages[j].Dir })
+	return g
+}
+
+// DOT writes the graph in Graphviz format, one box per package labeled with its
+// directory and external modules; main packages are bold.
+func (g *Graph) DOT(w io.Writer) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "digraph %q {\n", g.Module)
+	sb.WriteString("  rankdir=LR;\n  node [shape=box, fontname=\"Helvetica\"];\n")
+	for _, p := range g.Packages {
+		label := p.Dir
+		if len(p.External) > 0 {
+			label += "\n" + strings.Join(p.External, "\n")
+		}
+		style := ""
+		if p.Main {
+			style = ", style=bold"
+		}
+		fmt.Fprintf(&sb, "  %q [label=%q%s];\n", p.Dir, label, style)
+	}
+	for _, p := range g.Packages {
+		for _, imp := range p.Imports {
+			fmt.Fprintf(&sb, "  %q -> %q;\n", p.Dir, imp)
+		}
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Mermaid writes the graph as a Mermaid flowchart for Markdown files and issues. Node
+// ids are p0, p1, … in package order since Mermaid ids cannot contain slashes.
+func (g *Graph) Mermaid(w io.Writer) error {
+	ids := make(map[string]string, len(g.Packages))
+	for i, p := range g.Packages {
+		ids[p.Dir] = fmt.Sprintf("p%d", i)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for _, p := range g.Packages {
+		label := p.Dir
+		if p.Main {
+			label = "<b>" + label + "</b>"
+		}
+		if len(p.External) > 0 {
+			label += "<br/><small>" + strings.Join(p.External, "<br/>") + "</small>"
+		}
+		fmt.Fprintf(&sb, "  %s[\"%s\"]\n", ids[p.Dir], strings.ReplaceAll(label, `"`, "#quot;"))
+	}
+	for _, p := range g.Packages {
+		for _, imp := range p.Imports {
+			fmt.Fprintf(&sb, "  %s --> %s\n", ids[p.Dir], ids[imp])
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// owningModule returns the module of known that provides an import path.
+func owningModule(imp string, known map[string]bool) string {
+	for p := imp; p != "." && p != "/"; p = path.Dir(p) {
+		if known[p] {
+			return p
+		}
+	}
+	return ""
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
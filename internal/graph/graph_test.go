@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+var files = []gosrc.File{
+	{Path: "main.go", Package: "main", Imports: []string{"fmt", "example.com/app/internal/api", "github.com/spf13/cobra"}},
+	{Path: "internal/api/api.go", Package: "api", Imports: []string{"net/http", "example.com/app/internal/store", "github.com/go-chi/chi/v5/middleware"}},
+	{Path: "internal/api/api_test.go", Package: "api", Test: true, Imports: []string{"github.com/stretchr/testify/assert", "example.com/app/internal/fake"}},
+	{Path: "internal/store/store.go", Package: "store", Imports: []string{"github.com/jackc/pgx/v5", "github.com/jackc/pgx/v5/pgxpool", "example.com/app/internal/missing"}},
+}
+
+var modules = []string{"github.com/spf13/cobra", "github.com/go-chi/chi/v5", "github.com/jackc/pgx/v5", "github.com/stretchr/testify"}
+
+func TestBuild(t *testing.T) {
+	g := Build("example.com/app", files, modules)
+	want := []Package{
+		{Dir: ".", ImportPath: "example.com/app", Main: true, Imports: []string{"internal/api"}, External: []string{"github.com/spf13/cobra"}},
+		{Dir: "internal/api", ImportPath: "example.com/app/internal/api", Imports: []string{"internal/store"}, External: []string{"github.com/go-chi/chi/v5"}},
+		{Dir: "internal/store", ImportPath: "example.com/app/internal/store", Imports: []string{}, External: []string{"github.com/jackc/pgx/v5"}},
+	}
+	if !reflect.DeepEqual(g.Packages, want) {
+		t.Errorf("Packages =\n%+v\nwant\n%+v", g.Packages, want)
+	}
+}
+
+func TestDOT(t *testing.T) {
+	var sb strings.Builder
+	if err := Build("example.com/app", files, modules).DOT(&sb); err != nil {
+		t.Fatal(err)
+	}
+	out := sb.String()
+	for _, want := range []string{
+		"digraph \"example.com/app\" {\n",
+		"  \".\" [label=\".\\ngithub.com/spf13/cobra\", style=bold];\n",
+		"  \"internal/store\" [label=\"internal/store\\ngithub.com/jackc/pgx/v5\"];\n",
+		"  \".\" -> \"internal/api\";\n",
+		"  \"internal/api\" -> \"internal/store\";\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestMermaid(t *testing.T) {
+	var sb strings.Builder
+	if err := Build("example.com/app", files, modules).Mermaid(&sb); err != nil {
+		t.Fatal(err)
+	}
+	want := `flowchart LR
+  p0["<b>.</b><br/><small>github.com/spf13/cobra</small>"]
+  p1["internal/api<br/><small>github.com/go-chi/chi/v5</small>"]
+  p2["internal/store<br/><small>github.com/jackc/pgx/v5</small>"]
+  p0 --> p1
+  p1 --> p2
+`
+	if got := sb.String(); got != want {
+		t.Errorf("Mermaid =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,347 @@
+// Package httpclient is the outbound HTTP client of rinku's enrichment and update
+// features: crates.io, its sparse index, GitHub, the Go module proxy and OSV. Its
+// transport retries transient failures with exponential backoff within a retry budget,
+// waits out the rate limits servers announce (Retry-After, X-RateLimit-Reset) and
+// bounds the concurrent requests per host, so a large scan slows down instead of
+// getting the client banned.
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Policy tunes the retries and concurrency of a Transport. It is the [http] table of
+// .rinku.toml; the zero value is the default:
+//
+//	[http]
+//	max_retries = 5
+//	max_concurrency = 2
+//	max_delay = "2m"
+type Policy struct {
+	MaxRetries     int           `toml:"max_retries"`     // retries of one request; default 3
+	RetryBudget    int           `toml:"retry_budget"`    // retries of all requests of a run; default 50
+	BaseDelay      time.Duration `toml:"base_delay"`      // first backoff, doubled per retry; default 500ms
+	MaxDelay       time.Duration `toml:"max_delay"`       // longest wait, including for rate limits; default 1m
+	MaxConcurrency int           `toml:"max_concurrency"` // requests in flight per host; default 4
+	Timeout        time.Duration `toml:"timeout"`         // per attempt, until the body is read; default 30s
+}
+
+// WithDefaults returns the policy with unset settings set to their defaults.
+func (p Policy) WithDefaults() Policy {
+	if p.MaxRetries == 0 {
+		p.MaxRetries = 3
+	}
+	if p.RetryBudget == 0 {
+		p.RetryBudget = 50
+	}
+	if p.BaseDelay == 0 {
+		p.BaseDelay = 500 * time.Millisecond
+	}
+	if p.MaxDelay == 0 {
+		p.MaxDelay = time.Minute
+	}
+	if p.MaxConcurrency == 0 {
+		p.MaxConcurrency = 4
+	}
+	if p.Timeout == 0 {
+		p.Timeout = 30 * time.Second
+	}
+	return p
+}
+
+// Validate reports negative settings.
+func (p Policy) Validate() error {
+	for _, s := range []struct {
+		name  string
+		value int64
+	}{
+		{"max_retries", int64(p.MaxRetries)},
+		{"retry_budget", int64(p.RetryBudget)},
+		{"base_delay", int64(p.BaseDelay)},
+		{"max_delay", int64(p.MaxDelay)},
+		{"max_concurrency", int64(p.MaxConcurrency)},
+		{"timeout", int64(p.Timeout)},
+	} {
+		if s.value < 0 {
+			return fmt.Errorf("%s must not be negative", s.name)
+		}
+	}
+	return nil
+}
+
+// Transport is an http.RoundTripper that applies a Policy. It is safe for concurrent
+// use; the retry budget and concurrency limits are shared by all its requests.
+type Transport struct {
+	Base   http.RoundTripper // http.DefaultTransport if nil
+	Policy Policy
+
+	mu      sync.Mutex
+	hosts   map[string]*host
+	retries int // retries spent from the budget
+
+	now   func() time.Time                                 // time.Now unless testing
+	sleep func(ctx context.Context, d time.Duration) error // a timer unless testing
+}
+
+// host is the state of the requests to one host.
+type host struct {
+	slots   chan struct{} // one per request in flight
+	resetAt time.Time     // announced end of an exhausted rate limit
+}
+
+// New returns a client using a Transport with policy p.
+func New(p Policy) *http.Client {
+	return &http.Client{Transport: &Transport{Policy: p}}
+}
+
+var (
+	defaultMu     sync.Mutex
+	defaultClient = New(Policy{})
+)
+
+// Default returns the client shared by rinku's API clients.
+func Default() *http.Client {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	return defaultClient
+}
+
+// SetDefault makes Default return a client with policy p, e.g. from .rinku.toml.
+// Clients created before keep the previous one.
+func SetDefault(p Policy) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultClient = New(p)
+}
+
+type retryableKey struct{}
+
+// Retryable marks a request that is not idempotent by its method, e.g. a POST query,
+// as safe to retry.
+func Retryable(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), retryableKey{}, true))
+}
+
+// RoundTrip sends req, retrying it while the policy allows.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	p := t.Policy.WithDefaults()
+	h := t.host(req.URL.Host, p.MaxConcurrency)
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+		if err := t.waitRateLimit(ctx, h, p); err != nil {
+			return nil, err
+		}
+		resp, err := t.send(req, h, p)
+		if resp != nil {
+			t.noteRateLimit(h, resp)
+		}
+		delay, ok := t.retryDelay(req, resp, err, attempt, p)
+		if !ok {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			_ = resp.Body.Close()
+		}
+		if err := t.sleepFor(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send makes one attempt, holding a slot of the host until the body is closed.
+func (t *Transport) send(req *http.Request, h *host, p Policy) (*http.Response, error) {
+	ctx := req.Context()
+	select {
+	case h.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		<-h.slots
+		return nil, err
+	}
+	resp.Body = &body{ReadCloser: resp.Body, done: func() { cancel(); <-h.slots }}
+	return resp, nil
+}
+
+// body releases the attempt's timeout and host slot when closed.
+type body struct {
+	io.ReadCloser
+	once sync.Once
+	done func()
+}
+
+func (b *body) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.done)
+	return err
+}
+
+// retryDelay reports whether the outcome of an attempt is retried and after how long:
+// network errors and 429 or 5xx gateway responses are, as are 403 responses with an
+// exhausted GitHub rate limit, if the request can be replayed, the budgets allow it
+// and the wait the server asks for is at most MaxDelay.
+func (t *Transport) retryDelay(req *http.Request, resp *http.Response, err error, attempt int, p Policy) (time.Duration, bool) {
+	if attempt >= p.MaxRetries || !replayable(req) {
+		return 0, false
+	}
+	var delay time.Duration
+	switch {
+	case err != nil:
+		if req.Context().Err() != nil || errors.Is(err, context.Canceled) {
+			return 0, false
+		}
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusBadGateway,
+		resp.StatusCode == http.StatusServiceUnavailable,
+		resp.StatusCode == http.StatusGatewayTimeout:
+		delay = t.announcedDelay(resp)
+	case resp.StatusCode == http.StatusForbidden && (resp.Header.Get("Retry-After") != "" || resp.Header.Get("X-RateLimit-Remaining") == "0"):
+		delay = t.announcedDelay(resp)
+	default:
+		return 0, false
+	}
+	if delay == 0 {
+		backoff := p.BaseDelay << attempt
+		if backoff <= 0 || backoff > p.MaxDelay {
+			backoff = p.MaxDelay
+		}
+		delay = backoff/2 + rand.N(backoff/2+1) //#nosec G404 -- jitter, not security
+	}
+	if delay > p.MaxDelay {
+		return 0, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.retries >= p.RetryBudget {
+		return 0, false
+	}
+	t.retries++
+	return delay, true
+}
+
+// replayable reports whether req is idempotent, by method or by Retryable, and its
+// body, if any, can be sent again.
+func replayable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, "":
+	default:
+		if marked, _ := req.Context().Value(retryableKey{}).(bool); !marked {
+			return false
+		}
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// announcedDelay returns the wait a response asks for in Retry-After (seconds or a
+// date) or X-RateLimit-Reset (Unix time, with X-RateLimit-Remaining 0), or 0.
+func (t *Transport) announcedDelay(resp *http.Response) time.Duration {
+	now := t.clock()
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return max(time.Duration(secs)*time.Second, time.Millisecond)
+		}
+		if at, err := http.ParseTime(v); err == nil {
+			return max(at.Sub(now), time.Millisecond)
+		}
+	}
+	if reset, ok := rateLimitReset(resp); ok {
+		return max(reset.Sub(now), time.Millisecond)
+	}
+	return 0
+}
+
+// rateLimitReset returns when an exhausted rate limit resets, from the X-RateLimit
+// headers of GitHub and similar APIs.
+func rateLimitReset(resp *http.Response) (time.Time, bool) {
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return time.Time{}, false
+	}
+	secs, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(secs, 0), true
+}
+
+// noteRateLimit remembers an exhausted rate limit, so later requests to the host wait
+// for the reset instead of being rejected.
+func (t *Transport) noteRateLimit(h *host, resp *http.Response) {
+	if reset, ok := rateLimitReset(resp); ok {
+		t.mu.Lock()
+		h.resetAt = reset
+		t.mu.Unlock()
+	}
+}
+
+// waitRateLimit waits for the announced reset of the host's rate limit, unless it is
+// further away than MaxDelay: then the request is sent and fails fast.
+func (t *Transport) waitRateLimit(ctx context.Context, h *host, p Policy) error {
+	t.mu.Lock()
+	wait := h.resetAt.Sub(t.clock())
+	t.mu.Unlock()
+	if wait <= 0 || wait > p.MaxDelay {
+		return nil
+	}
+	return t.sleepFor(ctx, wait)
+}
+
+func (t *Transport) host(name string, slots int) *host {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.hosts == nil {
+		t.hosts = make(map[string]*host)
+	}
+	h, ok := t.hosts[name]
+	if !ok {
+		h = &host{slots: make(chan struct{}, slots)}
+		t.hosts[name] = h
+	}
+	return h
+}
+
+func (t *Transport) clock() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
+
+func (t *Transport) sleepFor(ctx context.Context, d time.Duration) error {
+	if t.sleep != nil {
+		return t.sleep(ctx, d)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,228 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testClient returns a client whose transport records its waits instead of sleeping.
+func testClient(p Policy, now time.Time) (*http.Client, *[]time.Duration) {
+	var mu sync.Mutex
+	var waits []time.Duration
+	t := &Transport{
+		Policy: p,
+		now:    func() time.Time { return now },
+		sleep: func(_ context.Context, d time.Duration) error {
+			mu.Lock()
+			defer mu.Unlock()
+			waits = append(waits, d)
+			return nil
+		},
+	}
+	return &http.Client{Transport: t}, &waits
+}
+
+// server calls handle with the number of each request, counting from 1.
+func server(t *testing.T, handle func(n int, w http.ResponseWriter, r *http.Request)) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var n atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handle(int(n.Add(1)), w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &n
+}
+
+func get(t *testing.T, c *http.Client, url string) *http.Response {
+	t.Helper()
+	resp, err := c.Get(url)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return resp
+}
+
+func TestRetry_Transient(t *testing.T) {
+	srv, n := server(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		if n < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	c, waits := testClient(Policy{BaseDelay: 100 * time.Millisecond}, time.Now())
+	if resp := get(t, c, srv.URL); resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+	if n.Load() != 3 || len(*waits) != 2 {
+		t.Fatalf("requests = %d, waits = %v", n.Load(), *waits)
+	}
+	// exponential backoff with jitter: [base/2, base], then [base, 2*base]
+	if w := (*waits)[0]; w < 50*time.Millisecond || w > 100*time.Millisecond {
+		t.Errorf("first wait = %v", w)
+	}
+	if w := (*waits)[1]; w < 100*time.Millisecond || w > 200*time.Millisecond {
+		t.Errorf("second wait = %v", w)
+	}
+}
+
+func TestRetry_GivesUp(t *testing.T) {
+	srv, n := server(t, func(_ int, w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	c, _ := testClient(Policy{MaxRetries: 2}, time.Now())
+	if resp := get(t, c, srv.URL); resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("status = %d", resp.StatusCode)
+	}
+	if n.Load() != 3 {
+		t.Errorf("requests = %d, want 3", n.Load())
+	}
+}
+
+func TestRetry_NotRetried(t *testing.T) {
+	srv, n := server(t, func(_ int, w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	c, _ := testClient(Policy{}, time.Now())
+	get(t, c, srv.URL+"/missing")
+	resp, err := c.Post(srv.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if n.Load() != 2 {
+		t.Errorf("requests = %d, want 2 (no retries of 404 or POST)", n.Load())
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	var bodies []string
+	srv, _ := server(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		if n == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	c, _ := testClient(Policy{}, time.Now())
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(`{"q":1}`))
+	resp, err := c.Do(Retryable(req))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || len(bodies) != 2 || bodies[1] != `{"q":1}` {
+		t.Errorf("status = %d, bodies = %q", resp.StatusCode, bodies)
+	}
+}
+
+func TestRetry_RetryAfter(t *testing.T) {
+	srv, _ := server(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/long":
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case n == 1:
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+	c, waits := testClient(Policy{}, time.Now())
+	if resp := get(t, c, srv.URL); resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+	if len(*waits) != 1 || (*waits)[0] != 7*time.Second {
+		t.Errorf("waits = %v, want [7s]", *waits)
+	}
+
+	// a wait beyond MaxDelay returns the response instead
+	if resp := get(t, c, srv.URL+"/long"); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want 429", resp.StatusCode)
+	}
+}
+
+func TestRateLimit_GitHub(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	reset := strconv.FormatInt(now.Add(20*time.Second).Unix(), 10)
+	srv, n := server(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		switch n {
+		case 1: // last request of the window
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", reset)
+		case 2:
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", reset)
+			w.WriteHeader(http.StatusForbidden)
+		}
+	})
+	c, waits := testClient(Policy{}, now)
+	get(t, c, srv.URL)
+	if resp := get(t, c, srv.URL); resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+	// waits for the reset before the second request, and again after its 403
+	if n.Load() != 3 || len(*waits) != 3 || (*waits)[0] != 20*time.Second || (*waits)[1] != 20*time.Second {
+		t.Errorf("requests = %d, waits = %v", n.Load(), *waits)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	srv, n := server(t, func(_ int, w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	c, _ := testClient(Policy{RetryBudget: 2}, time.Now())
+	get(t, c, srv.URL) // 1 attempt + 2 retries
+	get(t, c, srv.URL) // budget spent: 1 attempt
+	if n.Load() != 4 {
+		t.Errorf("requests = %d, want 4", n.Load())
+	}
+}
+
+func TestMaxConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv, _ := server(t, func(_ int, w http.ResponseWriter, r *http.Request) {
+		cur := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := peak.Load()
+			if cur <= old || peak.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	})
+	c, _ := testClient(Policy{MaxConcurrency: 2}, time.Now())
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			get(t, c, srv.URL)
+		}()
+	}
+	wg.Wait()
+	if p := peak.Load(); p > 2 {
+		t.Errorf("peak concurrency = %d, want at most 2", p)
+	}
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	if err := (Policy{}).Validate(); err != nil {
+		t.Errorf("zero policy: %v", err)
+	}
+	if err := (Policy{MaxConcurrency: -1}).Validate(); err == nil || !strings.Contains(err.Error(), "max_concurrency") {
+		t.Errorf("Validate() = %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+// Package idiom provides a database of Go idioms and their Rust equivalents.
+package idiom
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//go:embed idioms.json
+var idiomsData []byte
+
+// Idiom describes how a Go language pattern translates to Rust.
+type Idiom struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+	Go      string   `json:"go"`
+	Rust    string   `json:"rust"`
+	Crates  []string `json:"crates,omitempty"`
+	Notes   string   `json:"notes,omitempty"`
+}
+
+// File is the on-disk format of the idiom database.
+type File struct {
+	Idioms []Idiom `json:"idioms"`
+}
+
+// Database holds idioms indexed by name and alias.
+type Database struct {
+	idioms []Idiom
+	byName map[string]int // lowercased name or alias -> index into idioms
+}
+
+// Load returns the embedded idiom database.
+func Load() (*Database, error) {
+	return Parse(idiomsData)
+}
+
+// Parse builds a Database from JSON data.
+func Parse(data []byte) (*Database, error) {
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing idioms: %w", err)
+	}
+
+	sort.SliceStable(f.Idioms, func(i, j int) bool {
+		return f.Idioms[i].Name < f.Idioms[j].Name
+	})
+
+	db := &Database{idioms: f.Idioms, byName: make(map[string]int)}
+	for i, idiom := range f.Idioms {
+		if idiom.Name == "" {
+			return nil, fmt.Errorf("idiom without name")
+		}
+		for _, key := range append([]string{idiom.Name}, idiom.Aliases...) {
+			key = strings.ToLower(key)
+			if _, exists := db.byName[key]; exists {
+				return nil, fmt.Errorf("duplicate idiom name or alias: %s", key)
+			}
+			db.byName[key] = i
+		}
+	}
+	return db, nil
+}
+
+// Lookup finds an idiom by name or alias (case insensitive).
+func (d *Database) Lookup(name string) (Idiom, bool) {
+	i, ok := d.byName[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Idiom{}, false
+	}
+	return d.idioms[i], true
+}
+
+// All returns all idioms sorted by name.
+func (d *Database) All() []Idiom {
+	result := make([]Idiom, len(d.idioms))
+	copy(result, d.idioms)
+	return result
+}
@@ -0,0 +1,81 @@
+package idiom
+
+import "testing"
+
+func TestLoad_EmbeddedDatabase(t *testing.T) {
+	db, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	for _, name := range []string{"goroutine", "channel", "context", "error-wrapping", "waitgroup"} {
+		if _, ok := db.Lookup(name); !ok {
+			t.Errorf("Lookup(%q) not found", name)
+		}
+	}
+}
+
+func TestLookup_AliasesAndCase(t *testing.T) {
+	db, err := Parse([]byte(`{"idioms": [
+		{"name": "waitgroup", "aliases": ["sync.WaitGroup"], "go": "wg.Wait()", "rust": "JoinSet"}
+	]}`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"waitgroup", true},
+		{"WaitGroup", true},
+		{"sync.waitgroup", true},
+		{"  sync.WaitGroup ", true},
+		{"mutex", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		got, ok := db.Lookup(tt.input)
+		if ok != tt.want {
+			t.Errorf("Lookup(%q) ok = %v, want %v", tt.input, ok, tt.want)
+		}
+		if ok && got.Name != "waitgroup" {
+			t.Errorf("Lookup(%q).Name = %q, want waitgroup", tt.input, got.Name)
+		}
+	}
+}
+
+func TestParse_DuplicateAlias(t *testing.T) {
+	_, err := Parse([]byte(`{"idioms": [
+		{"name": "channel", "aliases": ["chan"]},
+		{"name": "chan"}
+	]}`))
+	if err == nil {
+		t.Error("expected error for duplicate alias")
+	}
+}
+
+func TestParse_MissingName(t *testing.T) {
+	_, err := Parse([]byte(`{"idioms": [{"go": "x", "rust": "y"}]}`))
+	if err == nil {
+		t.Error("expected error for idiom without name")
+	}
+}
+
+func TestAll_SortedByName(t *testing.T) {
+	db, err := Parse([]byte(`{"idioms": [{"name": "select"}, {"name": "channel"}, {"name": "mutex"}]}`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	all := db.All()
+	want := []string{"channel", "mutex", "select"}
+	if len(all) != len(want) {
+		t.Fatalf("len(All()) = %d, want %d", len(all), len(want))
+	}
+	for i, name := range want {
+		if all[i].Name != name {
+			t.Errorf("All()[%d].Name = %q, want %q", i, all[i].Name, name)
+		}
+	}
+}
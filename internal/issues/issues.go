@@ -0,0 +1,263 @@
+// Package issues turns open migration work, unmapped dependencies and pending
+// requirements, into issues for a team's tracker.
+package issues
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/stephan/rinku/internal/pattern"
+	"github.com/stephan/rinku/internal/requirements"
+	"github.com/stephan/rinku/internal/verify"
+)
+
+// Issue is a work item, shaped like the payload of GitHub's create-issue API plus a
+// suggested epic.
+type Issue struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Labels []string `json:"labels"`
+	Epic   string   `json:"epic"` // e.g. "Dependencies: web" or "Requirements: cli"
+}
+
+// Dependency is a direct Go dependency without a Rust mapping.
+type Dependency struct {
+	Module     string
+	Version    string
+	URL        string   // repository URL for rinku lookup
+	Tags       []string // categories of the library, if known
+	ImportedBy []string // project packages importing it
+}
+
+// Unmapped returns one issue per dependency, asking for a Rust replacement.
+func Unmapped(module string, deps []Dependency, labels []string) []Issue {
+	labels = append([]string{}, labels...) // [] rather than null in JSON
+	issues := make([]Issue, 0, len(deps))
+	for _, d := range deps {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "`%s` %s, a direct dependency of `%s`, has no Rust equivalent in the rinku database.\n", d.Module, d.Version, module)
+		if len(d.Tags) > 0 {
+			fmt.Fprintf(&sb, "\nTags: %s\n", strings.Join(d.Tags, ", "))
+		}
+		if len(d.ImportedBy) > 0 {
+			sb.WriteString("\nImported by:\n\n")
+			for _, pkg := range d.ImportedBy {
+				fmt.Fprintf(&sb, "- `%s`\n", pkg)
+			}
+		}
+		sb.WriteString("\n- [ ] Find a crate, or decide to port or drop the functionality")
+		if d.URL != "" {
+			fmt.Fprintf(&sb, " (`rinku lookup %s`)", d.URL)
+		}
+		sb.WriteString("\n- [ ] Record the decision in `.rinku/mappings.lock.json` with `rinku lock`\n")
+		epic := "untagged"
+		if len(d.Tags) > 0 {
+			epic = d.Tags[0]
+		}
+		issues = append(issues, Issue{
+			Title:  "Find a Rust replacement for " + d.Module,
+			Body:   sb.String(),
+			Labels: slices.Clone(labels),
+			Epic:   "Dependencies: " + epic,
+		})
+	}
+	return issues
+}
+
+// Requirements returns one issue per group of pending requirements, grouped by the first
+// depth segments of their parent path (svc/cli for svc/cli/flags/--port). Requirements
+// without a parent form a group of their own.
+func Requirements(reqs []*requirements.Requirement, depth int, labels []string) []Issue {
+	labels = append([]string{}, labels...)
+	groups := make(map[string][]*requirements.Requirement)
+	for _, req := range reqs {
+		if req.Done {
+			continue
+		}
+		key := groupKey(req.Path, depth)
+		groups[key] = append(groups[key], req)
+	}
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	issues := make([]Issue, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		sort.Slice(group, func(i, j int) bool { return group[i].Path < group[j].Path })
+		var sb strings.Builder
+		noun := "requirements"
+		if len(group) == 1 {
+			noun = "requirement"
+		}
+		fmt.Fprintf(&sb, "%d pending %s below `%s` must hold for the Rust port:\n\n", len(group), noun, key)
+		for _, req := range group {
+			fmt.Fprintf(&sb, "- [ ] `%s`", req.Path)
+			if summary, _, _ := strings.Cut(strings.TrimSpace(req.Content), "\n"); summary != "" {
+				sb.WriteString(": " + summary)
+			}
+			sb.WriteByte('\n')
+		}
+		sb.WriteString("\nMark each one with `rinku req done <path>` once the port satisfies it.\n")
+		issues = append(issues, Issue{
+			Title:  fmt.Sprintf("Port %s (%d %s)", key, len(group), noun),
+			Body:   sb.String(),
+			Labels: slices.Clone(labels),
+			Epic:   "Requirements: " + category(key),
+		})
+	}
+	return issues
+}
+
+func groupKey(reqPath string, depth int) string {
+	parent := path.Dir(reqPath)
+	if parent == "." {
+		return reqPath
+	}
+	segs := strings.Split(parent, "/")
+	if depth > 0 && len(segs) > depth {
+		segs = segs[:depth]
+	}
+	return strings.Join(segs, "/")
+}
+
+// category returns the requirement category of a group: the area of the verify pattern
+// it falls under (cli for app/cli, db for db/queries), else its first segment.
+func category(group string) string {
+	var areas []string
+	for _, patterns := range verify.TagToCategoryMap {
+		areas = append(areas, patterns...)
+	}
+	sort.Strings(areas)
+	for _, area := range areas {
+		if pattern.Match(area, group) || pattern.Match(area+"/**", group) {
+			return strings.TrimPrefix(area, "*/")
+		}
+	}
+	first, _, _ := strings.Cut(group, "/")
+	return first
+}
+
+// LinkReport appends a link to the published rinku report (e.g. the dashboard HTML) to
+// every issue body.
+func LinkReport(issues []Issue, url string) {
+	for i := range issues {
+		issues[i].Body += fmt.Sprintf("\nMigration report: %s\n", url)
+	}
+}
+
+// WriteJSON writes the issues as a JSON array.
+func WriteJSON(w io.Writer, issues []Issue) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(issues)
+}
+
+// WriteGitHubCLI writes a shell script with one gh issue create command per issue, for
+// repo (owner/name) or the repository of the working directory if empty.
+func WriteGitHubCLI(w io.Writer, issues []Issue, repo string) error {
+	var sb strings.Builder
+	sb.WriteString("#!/bin/sh\n# Generated by rinku issues. Review, then run with sh.\nset -e\n")
+	for _, issue := range issues {
+		sb.WriteString("\ngh issue create")
+		if repo != "" {
+			sb.WriteString(" --repo " + shellQuote(repo))
+		}
+		sb.WriteString(" \\\n  --title " + shellQuote(issue.Title))
+		for _, label := range issue.Labels {
+			sb.WriteString(" \\\n  --label " + shellQuote(label))
+		}
+		sb.WriteString(" \\\n  --body " + shellQuote(issue.Body) + "\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// shellQuote quotes s for POSIX shells; single quotes keep backticks and $ literal.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// WriteJira writes a CSV for Jira's importer: one Epic row per epic, then the issues as
+// Tasks whose Parent Id is their epic's Issue Id. Labels repeat the Labels column, as
+// Jira expects, with spaces replaced since Jira labels cannot contain them.
+func WriteJira(w io.Writer, issues []Issue) error {
+	labelColumns := 0
+	for _, issue := range issues {
+		labelColumns = max(labelColumns, len(issue.Labels))
+	}
+	header := []string{"Issue Id", "Parent Id", "Issue Type", "Summary", "Description"}
+	for i := 0; i < labelColumns; i++ {
+		header = append(header, "Labels")
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	row := func(id, parent, kind, summary, description string, labels []string) error {
+		record := []string{id, parent, kind, summary, description}
+		for i := 0; i < labelColumns; i++ {
+			label := ""
+			if i < len(labels) {
+				label = strings.ReplaceAll(labels[i], " ", "-")
+			}
+			record = append(record, label)
+		}
+		return cw.Write(record)
+	}
+
+	epicIDs := make(map[string]string)
+	for _, epic := range epics(issues) {
+		id := fmt.Sprint(len(epicIDs) + 1)
+		epicIDs[epic] = id
+		if err := row(id, "", "Epic", epic, "", nil); err != nil {
+			return err
+		}
+	}
+	for i, issue := range issues {
+		id := fmt.Sprint(len(epicIDs) + i + 1)
+		if err := row(id, epicIDs[issue.Epic], "Task", issue.Title, issue.Body, issue.Labels); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteLinear writes a CSV for Linear's importer. Epics become projects; new issues
+// start in Todo.
+func WriteLinear(w io.Writer, issues []Issue) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"Title", "Description", "Status", "Priority", "Labels", "Project"}); err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		if err := cw.Write([]string{issue.Title, issue.Body, "Todo", "", strings.Join(issue.Labels, ","), issue.Epic}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// epics returns the distinct epics of issues in order of first use.
+func epics(issues []Issue) []string {
+	var list []string
+	seen := make(map[string]bool)
+	for _, issue := range issues {
+		if issue.Epic != "" && !seen[issue.Epic] {
+			seen[issue.Epic] = true
+			list = append(list, issue.Epic)
+		}
+	}
+	return list
+}
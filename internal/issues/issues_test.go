@@ -0,0 +1,149 @@
+package issues
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stephan/rinku/internal/requirements"
+)
+
+func TestUnmapped(t *testing.T) {
+	got := Unmapped("example.com/app", []Dependency{{
+		Module:     "example.com/private/sdk",
+		Version:    "v0.3.0",
+		URL:        "https://example.com/private/sdk",
+		Tags:       []string{"http"},
+		ImportedBy: []string{"example.com/app/internal/billing"},
+	}}, []string{"migration"})
+	if len(got) != 1 {
+		t.Fatalf("len = %d, want 1", len(got))
+	}
+	if got[0].Title != "Find a Rust replacement for example.com/private/sdk" {
+		t.Errorf("Title = %q", got[0].Title)
+	}
+	for _, want := range []string{"`example.com/private/sdk` v0.3.0, a direct dependency of `example.com/app`", "Tags: http", "- `example.com/app/internal/billing`", "`rinku lookup https://example.com/private/sdk`"} {
+		if !strings.Contains(got[0].Body, want) {
+			t.Errorf("Body missing %q:\n%s", want, got[0].Body)
+		}
+	}
+	if len(got[0].Labels) != 1 || got[0].Labels[0] != "migration" {
+		t.Errorf("Labels = %v", got[0].Labels)
+	}
+	if got[0].Epic != "Dependencies: http" {
+		t.Errorf("Epic = %q", got[0].Epic)
+	}
+}
+
+func TestRequirements(t *testing.T) {
+	reqs := []*requirements.Requirement{
+		{Path: "svc/cli/flags/--port", Content: "Listens on --port\nDefault 8080"},
+		{Path: "svc/cli/flags/--host", Content: "Binds --host"},
+		{Path: "svc/api/routes/GET_users", Content: "Lists users", Done: true},
+		{Path: "svc/api/routes/POST_users", Content: "Creates users"},
+		{Path: "tests", Content: "All Go tests have a Rust counterpart"},
+	}
+	got := Requirements(reqs, 2, nil)
+	titles := make([]string, len(got))
+	for i, issue := range got {
+		titles[i] = issue.Title
+	}
+	want := []string{"Port svc/api (1 requirement)", "Port svc/cli (2 requirements)", "Port tests (1 requirement)"}
+	if strings.Join(titles, "|") != strings.Join(want, "|") {
+		t.Errorf("titles = %q, want %q", titles, want)
+	}
+	if body := got[1].Body; !strings.Contains(body, "- [ ] `svc/cli/flags/--host`: Binds --host\n- [ ] `svc/cli/flags/--port`: Listens on --port\n") {
+		t.Errorf("Body =\n%s", body)
+	}
+	if got[1].Epic != "Requirements: cli" {
+		t.Errorf("Epic = %q", got[1].Epic)
+	}
+	if got[0].Labels == nil {
+		t.Error("Labels = nil, want empty for JSON")
+	}
+
+	if got := Requirements(reqs, 0, nil); got[1].Title != "Port svc/cli/flags (2 requirements)" {
+		t.Errorf("depth 0 title = %q", got[1].Title)
+	}
+}
+
+func TestWriteGitHubCLI(t *testing.T) {
+	var sb strings.Builder
+	err := WriteGitHubCLI(&sb, []Issue{{Title: "Port it's done", Body: "Run `x` with $HOME", Labels: []string{"a", "b"}}}, "acme/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `gh issue create --repo 'acme/app' \
+  --title 'Port it'\''s done' \
+  --label 'a' \
+  --label 'b' \
+  --body 'Run ` + "`x`" + ` with $HOME'
+`
+	if !strings.HasSuffix(sb.String(), want) {
+		t.Errorf("script =\n%s\nwant suffix\n%s", sb.String(), want)
+	}
+}
+
+func TestCategory(t *testing.T) {
+	for group, want := range map[string]string{
+		"app/cli":          "cli",
+		"svc/api":          "api",
+		"db/queries":       "db",
+		"codegen/protobuf": "codegen/protobuf",
+		"config/env":       "config",
+		"tests":            "tests",
+	} {
+		if got := category(group); got != want {
+			t.Errorf("category(%q) = %q, want %q", group, got, want)
+		}
+	}
+}
+
+var exportIssues = []Issue{
+	{Title: "Find a Rust replacement for example.com/sdk", Body: "No mapping.\n", Labels: []string{"rinku migration", "rust"}, Epic: "Dependencies: web"},
+	{Title: "Port app/cli (1 requirement)", Body: "- [ ] `app/cli/flags/--port`\n", Labels: []string{"rust"}, Epic: "Requirements: cli"},
+	{Title: "Find a Rust replacement for example.com/auth", Body: "No mapping.\n", Epic: "Dependencies: web"},
+}
+
+func TestWriteJira(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteJira(&sb, exportIssues); err != nil {
+		t.Fatal(err)
+	}
+	want := `Issue Id,Parent Id,Issue Type,Summary,Description,Labels,Labels
+1,,Epic,Dependencies: web,,,
+2,,Epic,Requirements: cli,,,
+3,1,Task,Find a Rust replacement for example.com/sdk,"No mapping.
+",rinku-migration,rust
+4,2,Task,Port app/cli (1 requirement),"- [ ] ` + "`app/cli/flags/--port`" + `
+",rust,
+5,1,Task,Find a Rust replacement for example.com/auth,"No mapping.
+",,
+`
+	if got := sb.String(); got != want {
+		t.Errorf("WriteJira =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteLinear(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteLinear(&sb, exportIssues[:2]); err != nil {
+		t.Fatal(err)
+	}
+	want := `Title,Description,Status,Priority,Labels,Project
+Find a Rust replacement for example.com/sdk,"No mapping.
+",Todo,,"rinku migration,rust",Dependencies: web
+Port app/cli (1 requirement),"- [ ] ` + "`app/cli/flags/--port`" + `
+",Todo,,rust,Requirements: cli
+`
+	if got := sb.String(); got != want {
+		t.Errorf("WriteLinear =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestLinkReport(t *testing.T) {
+	list := []Issue{{Body: "Body.\n"}}
+	LinkReport(list, "https://ci.example.com/rinku/dashboard.html")
+	if want := "Body.\n\nMigration report: https://ci.example.com/rinku/dashboard.html\n"; list[0].Body != want {
+		t.Errorf("Body = %q, want %q", list[0].Body, want)
+	}
+}
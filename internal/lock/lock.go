@@ -0,0 +1,178 @@
+// Package lock records the Rust target and version chosen for each Go dependency,
+// so Cargo.toml generation is reproducible across machines and database updates.
+package lock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/natefinch/atomic"
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/progress"
+)
+
+const LockFile = "mappings.lock.json"
+
+// Source records how a mapping decision was made.
+type Source string
+
+const (
+	SourceAuto   Source = "auto"   // first target from the mapping database
+	SourceManual Source = "manual" // chosen explicitly by the user
+)
+
+// Decision is the outcome of reviewing a mapping with `rinku decide`.
+type Decision string
+
+const (
+	DecisionAccepted Decision = "accepted" // the locked crate was chosen
+	DecisionRejected Decision = "rejected" // no suggested crate fits; treat as unmapped
+	DecisionDeferred Decision = "deferred" // revisit later; the locked crate is used meanwhile
+)
+
+// Entry is the locked mapping for one Go module.
+type Entry struct {
+	GoModule  string    `json:"go_module"`
+	GoVersion string    `json:"go_version,omitempty"`
+	Crate     string    `json:"crate,omitempty"`
+	RustURL   string    `json:"rust_url,omitempty"`
+	Version   string    `json:"version,omitempty"`
+	Source    Source    `json:"source"`
+	Decision  Decision  `json:"decision,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	LockedAt  time.Time `json:"locked_at"`
+}
+
+// Decided reports whether the entry has a final accept or reject decision.
+func (e *Entry) Decided() bool {
+	return e.Decision == DecisionAccepted || e.Decision == DecisionRejected
+}
+
+// Lock holds the locked mappings keyed by Go module path.
+type Lock struct {
+	Version int               `json:"version"`
+	Entries map[string]*Entry `json:"entries"`
+}
+
+const currentVersion = 1
+
+// New returns an empty lock.
+func New() *Lock {
+	return &Lock{Version: currentVersion, Entries: make(map[string]*Entry)}
+}
+
+// Path returns the path to the lock file for a project directory.
+func Path(projectDir string) string {
+	return filepath.Join(projectDir, progress.ProgressDir, LockFile)
+}
+
+// Load reads the lock file. Returns nil, nil if no lock file exists.
+func Load(projectDir string) (*Lock, error) {
+	data, err := os.ReadFile(Path(projectDir)) //#nosec G304 -- projectDir from os.Getwd(), not user input
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading lock file: %w", err)
+	}
+
+	var l Lock
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("parsing lock file: %w", err)
+	}
+	if l.Entries == nil {
+		l.Entries = make(map[string]*Entry)
+	}
+	return &l, nil
+}
+
+// Save atomically writes the lock file.
+func (l *Lock) Save(projectDir string) error {
+	dir := filepath.Join(projectDir, progress.ProgressDir)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("creating %s directory: %w", progress.ProgressDir, err)
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling lock file: %w", err)
+	}
+	return atomic.WriteFile(Path(projectDir), bytes.NewReader(append(data, '\n')))
+}
+
+// Get returns the entry for a Go module.
+func (l *Lock) Get(goModule string) (*Entry, bool) {
+	e, ok := l.Entries[goModule]
+	return e, ok
+}
+
+// Set stores an entry, replacing any existing entry for the same module.
+func (l *Lock) Set(e Entry) {
+	l.Entries[e.GoModule] = &e
+}
+
+// Prune removes entries for modules not in keep and returns the removed module paths.
+func (l *Lock) Prune(keep []string) []string {
+	wanted := make(map[string]bool, len(keep))
+	for _, m := range keep {
+		wanted[m] = true
+	}
+	var removed []string
+	for m := range l.Entries {
+		if !wanted[m] {
+			removed = append(removed, m)
+			delete(l.Entries, m)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// Apply replaces the database mappings in result with the locked choices and returns
+// the number of dependencies that were locked. Dependencies the database no longer maps
+// are restored from the lock; rejected dependencies become unmapped.
+func (l *Lock) Apply(result *cargo.GenerateResult) int {
+	applied := 0
+	var mapped []cargo.MappedDependency
+	var rejected []cargo.UnmappedDependency
+	for _, m := range result.Mapped {
+		e, ok := l.Entries[m.GoDep.Path]
+		switch {
+		case !ok:
+		case e.Decision == DecisionRejected:
+			rejected = append(rejected, cargo.UnmappedDependency{GoDep: m.GoDep})
+			applied++
+			continue
+		default:
+			m.RustTargets = []string{e.RustURL}
+			m.CrateNames = []string{e.Crate}
+			m.Versions = []string{e.Version}
+			applied++
+		}
+		mapped = append(mapped, m)
+	}
+	result.Mapped = mapped
+
+	var unmapped []cargo.UnmappedDependency
+	for _, u := range result.Unmapped {
+		e, ok := l.Entries[u.GoDep.Path]
+		if !ok || e.Decision == DecisionRejected {
+			unmapped = append(unmapped, u)
+			continue
+		}
+		result.Mapped = append(result.Mapped, cargo.MappedDependency{
+			GoDep:       u.GoDep,
+			RustTargets: []string{e.RustURL},
+			CrateNames:  []string{e.Crate},
+			Versions:    []string{e.Version},
+		})
+		applied++
+	}
+	result.Unmapped = append(unmapped, rejected...)
+	return applied
+}
@@ -0,0 +1,136 @@
+package lock
+
+import (
+	"testing"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/gomod"
+)
+
+func TestLoad_NoFile(t *testing.T) {
+	l, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if l != nil {
+		t.Errorf("Load() = %+v, want nil", l)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	l := New()
+	l.Set(Entry{GoModule: "github.com/spf13/cobra", Crate: "clap", RustURL: "https://github.com/clap-rs/clap", Version: "4.5.20", Source: SourceAuto})
+	if err := l.Save(dir); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	e, ok := loaded.Get("github.com/spf13/cobra")
+	if !ok {
+		t.Fatal("entry not found after reload")
+	}
+	if e.Crate != "clap" || e.Version != "4.5.20" || e.Source != SourceAuto {
+		t.Errorf("entry = %+v", e)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	l := New()
+	l.Set(Entry{GoModule: "a"})
+	l.Set(Entry{GoModule: "b"})
+	l.Set(Entry{GoModule: "c"})
+
+	removed := l.Prune([]string{"b"})
+	if len(removed) != 2 || removed[0] != "a" || removed[1] != "c" {
+		t.Errorf("removed = %v, want [a c]", removed)
+	}
+	if _, ok := l.Get("b"); !ok {
+		t.Error("kept entry was removed")
+	}
+}
+
+func TestApply(t *testing.T) {
+	l := New()
+	l.Set(Entry{GoModule: "github.com/sirupsen/logrus", Crate: "log", RustURL: "https://github.com/rust-lang/log", Version: "0.4.22"})
+	l.Set(Entry{GoModule: "example.com/dropped", Crate: "legacy", RustURL: "https://github.com/x/legacy", Version: "1.0.0"})
+
+	result := &cargo.GenerateResult{
+		Mapped: []cargo.MappedDependency{
+			{
+				GoDep:       gomod.Dependency{Path: "github.com/sirupsen/logrus"},
+				RustTargets: []string{"https://github.com/tokio-rs/tracing"},
+				CrateNames:  []string{"tracing"},
+			},
+			{
+				GoDep:       gomod.Dependency{Path: "github.com/spf13/cobra"},
+				RustTargets: []string{"https://github.com/clap-rs/clap"},
+				CrateNames:  []string{"clap"},
+			},
+		},
+		Unmapped: []cargo.UnmappedDependency{
+			{GoDep: gomod.Dependency{Path: "example.com/dropped"}},
+			{GoDep: gomod.Dependency{Path: "example.com/other"}},
+		},
+	}
+
+	if n := l.Apply(result); n != 2 {
+		t.Errorf("Apply() = %d, want 2", n)
+	}
+	if got := result.Mapped[0].CrateNames[0]; got != "log" {
+		t.Errorf("locked crate = %q, want log", got)
+	}
+	if got := result.Mapped[1].CrateNames[0]; got != "clap" {
+		t.Errorf("unlocked crate = %q, want clap", got)
+	}
+	if len(result.Mapped) != 3 || result.Mapped[2].CrateNames[0] != "legacy" {
+		t.Errorf("dependency unmapped by the database should be restored from the lock")
+	}
+	if len(result.Unmapped) != 1 || result.Unmapped[0].GoDep.Path != "example.com/other" {
+		t.Errorf("Unmapped = %+v", result.Unmapped)
+	}
+}
+
+func TestApply_Rejected(t *testing.T) {
+	l := New()
+	l.Set(Entry{GoModule: "github.com/spf13/viper", Decision: DecisionRejected, Reason: "config handled by figment"})
+
+	result := &cargo.GenerateResult{
+		Mapped: []cargo.MappedDependency{
+			{
+				GoDep:       gomod.Dependency{Path: "github.com/spf13/viper"},
+				RustTargets: []string{"https://github.com/mehcode/config-rs"},
+				CrateNames:  []string{"config"},
+			},
+		},
+	}
+
+	l.Apply(result)
+	if len(result.Mapped) != 0 {
+		t.Errorf("rejected dependency should not be mapped, got %+v", result.Mapped)
+	}
+	if len(result.Unmapped) != 1 || result.Unmapped[0].GoDep.Path != "github.com/spf13/viper" {
+		t.Errorf("Unmapped = %+v, want viper", result.Unmapped)
+	}
+}
+
+func TestEntry_Decided(t *testing.T) {
+	tests := []struct {
+		decision Decision
+		want     bool
+	}{
+		{"", false},
+		{DecisionDeferred, false},
+		{DecisionAccepted, true},
+		{DecisionRejected, true},
+	}
+	for _, tt := range tests {
+		e := Entry{Decision: tt.decision}
+		if got := e.Decided(); got != tt.want {
+			t.Errorf("Entry{Decision: %q}.Decided() = %v, want %v", tt.decision, got, tt.want)
+		}
+	}
+}
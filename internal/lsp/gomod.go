@@ -0,0 +1,56 @@
+package lsp
+
+import (
+	"strings"
+)
+
+// requireLine is a module requirement found in a go.mod document.
+type requireLine struct {
+	Line    int // zero-based
+	Start   int // column of the module path
+	End     int // column after the module path
+	Path    string
+	Version string
+}
+
+// parseRequires finds require directives in go.mod text, keeping their positions.
+func parseRequires(text string) []requireLine {
+	var result []requireLine
+	inBlock := false
+	for i, line := range strings.Split(text, "\n") {
+		code, _, _ := strings.Cut(line, "//")
+		fields := strings.Fields(code)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var path, version string
+		switch {
+		case inBlock && fields[0] == ")":
+			inBlock = false
+			continue
+		case inBlock:
+			if len(fields) < 2 {
+				continue
+			}
+			path, version = fields[0], fields[1]
+		case fields[0] == "require" && len(fields) >= 2 && strings.HasPrefix(fields[1], "("):
+			inBlock = true
+			continue
+		case fields[0] == "require" && len(fields) >= 3:
+			path, version = fields[1], fields[2]
+		default:
+			continue
+		}
+
+		start := strings.Index(line, path)
+		result = append(result, requireLine{
+			Line:    i,
+			Start:   start,
+			End:     start + len(path),
+			Path:    path,
+			Version: version,
+		})
+	}
+	return result
+}
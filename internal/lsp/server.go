@@ -0,0 +1,282 @@
+// Package lsp implements a small language server that annotates go.mod files with
+// their Rust equivalents. It speaks JSON-RPC over stdio so editor extensions can
+// show mappings without reimplementing lookup logic.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/stephan/rinku/internal/cargo"
+)
+
+// Lookup is the subset of the mapping database the server needs.
+type Lookup interface {
+	Lookup(sourceURL, targetLang string, unsafe bool) []string
+	CrateName(rustURL string) string
+	Confidence(sourceURL, targetLang string) float64
+}
+
+// Server answers hover and code lens requests for go.mod documents.
+type Server struct {
+	lookup Lookup
+	unsafe bool
+
+	mu   sync.Mutex
+	docs map[string]string // document URI -> text
+}
+
+// NewServer returns a server backed by the given lookup. If unsafe is set, libraries
+// with known vulnerabilities are included in results.
+func NewServer(lookup Lookup, unsafe bool) *Server {
+	return &Server{lookup: lookup, unsafe: unsafe, docs: make(map[string]string)}
+}
+
+// Serve reads requests from r and writes responses to w until the client sends
+// exit or closes the stream.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	br := bufio.NewReader(r)
+	for {
+		body, err := readMessage(br)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading message: %w", err)
+		}
+
+		var req message
+		if err := json.Unmarshal(body, &req); err != nil {
+			if err := writeMessage(w, &message{ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+		if req.Method == "exit" {
+			return nil
+		}
+
+		result, rerr := s.handle(&req)
+		if req.isNotification() {
+			continue
+		}
+		resp := &message{ID: req.ID, Result: result, Error: rerr}
+		if rerr == nil && result == nil {
+			resp.Result = json.RawMessage("null")
+		}
+		if err := writeMessage(w, resp); err != nil {
+			return fmt.Errorf("writing response: %w", err)
+		}
+	}
+}
+
+type textDocumentItem struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+type textDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+// Position is a zero-based line and character offset.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Range is a span within a document.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Hover is the result of a hover request.
+type Hover struct {
+	Contents MarkupContent `json:"contents"`
+	Range    *Range        `json:"range,omitempty"`
+}
+
+// MarkupContent is markdown text shown by the editor.
+type MarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// CodeLens is an annotation shown above a line.
+type CodeLens struct {
+	Range   Range    `json:"range"`
+	Command *Command `json:"command,omitempty"`
+}
+
+// Command is an editor command attached to a code lens.
+type Command struct {
+	Title     string `json:"title"`
+	Command   string `json:"command"`
+	Arguments []any  `json:"arguments,omitempty"`
+}
+
+func (s *Server) handle(req *message) (any, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		return map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync": 1, // full document sync
+				"hoverProvider":    true,
+				"codeLensProvider": map[string]any{},
+			},
+			"serverInfo": map[string]any{"name": "rinku"},
+		}, nil
+	case "shutdown", "initialized", "$/cancelRequest", "textDocument/didSave":
+		return nil, nil
+	case "textDocument/didOpen":
+		var p struct {
+			TextDocument textDocumentItem `json:"textDocument"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		s.setDocument(p.TextDocument.URI, p.TextDocument.Text)
+		return nil, nil
+	case "textDocument/didChange":
+		var p struct {
+			TextDocument   textDocumentIdentifier `json:"textDocument"`
+			ContentChanges []struct {
+				Text string `json:"text"`
+			} `json:"contentChanges"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		if n := len(p.ContentChanges); n > 0 {
+			s.setDocument(p.TextDocument.URI, p.ContentChanges[n-1].Text)
+		}
+		return nil, nil
+	case "textDocument/didClose":
+		var p struct {
+			TextDocument textDocumentIdentifier `json:"textDocument"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		s.mu.Lock()
+		delete(s.docs, p.TextDocument.URI)
+		s.mu.Unlock()
+		return nil, nil
+	case "textDocument/hover":
+		var p struct {
+			TextDocument textDocumentIdentifier `json:"textDocument"`
+			Position     Position               `json:"position"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		if h := s.hover(p.TextDocument.URI, p.Position); h != nil {
+			return h, nil
+		}
+		return nil, nil
+	case "textDocument/codeLens":
+		var p struct {
+			TextDocument textDocumentIdentifier `json:"textDocument"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.codeLenses(p.TextDocument.URI), nil
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+}
+
+func invalidParams(err error) *rpcError {
+	return &rpcError{Code: codeInvalidParams, Message: err.Error()}
+}
+
+func (s *Server) setDocument(uri, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.docs[uri] = text
+}
+
+func (s *Server) requires(uri string) []requireLine {
+	s.mu.Lock()
+	text, ok := s.docs[uri]
+	s.mu.Unlock()
+	if !ok || !strings.HasSuffix(uri, "go.mod") {
+		return nil
+	}
+	return parseRequires(text)
+}
+
+// target is a Rust library mapped from a Go module.
+type target struct {
+	crate string
+	url   string
+}
+
+// targets returns the Rust libraries modulePath maps to and the confidence of the
+// mapping.
+func (s *Server) targets(modulePath string) ([]target, float64) {
+	sourceURL := cargo.ModulePathToGitHubURL(modulePath)
+	var result []target
+	for _, rustURL := range s.lookup.Lookup(sourceURL, "rust", s.unsafe) {
+		name := s.lookup.CrateName(rustURL)
+		if name == "" {
+			name = cargo.ExtractCrateName(rustURL)
+		}
+		result = append(result, target{crate: name, url: rustURL})
+	}
+	if len(result) == 0 {
+		return nil, 0
+	}
+	return result, s.lookup.Confidence(sourceURL, "rust")
+}
+
+func (s *Server) hover(uri string, pos Position) *Hover {
+	for _, req := range s.requires(uri) {
+		if req.Line != pos.Line {
+			continue
+		}
+		var lines []string
+		targets, confidence := s.targets(req.Path)
+		for _, t := range targets {
+			lines = append(lines, fmt.Sprintf("maps to %s — %s, confidence %.1f", t.crate, t.url, confidence))
+		}
+		if len(lines) == 0 {
+			lines = []string{"no Rust mapping found"}
+		}
+		return &Hover{
+			Contents: MarkupContent{Kind: "markdown", Value: strings.Join(lines, "\n\n")},
+			Range:    &Range{Start: Position{req.Line, req.Start}, End: Position{req.Line, req.End}},
+		}
+	}
+	return nil
+}
+
+func (s *Server) codeLenses(uri string) []CodeLens {
+	lenses := []CodeLens{}
+	for _, req := range s.requires(uri) {
+		targets, confidence := s.targets(req.Path)
+		if len(targets) == 0 {
+			continue
+		}
+		var names []string
+		for _, t := range targets {
+			names = append(names, t.crate)
+		}
+		lenses = append(lenses, CodeLens{
+			Range: Range{Start: Position{req.Line, req.Start}, End: Position{req.Line, req.End}},
+			Command: &Command{
+				Title:     fmt.Sprintf("Rust: %s (confidence %.1f)", strings.Join(names, ", "), confidence),
+				Command:   "rinku.openMapping",
+				Arguments: []any{targets[0].url},
+			},
+		})
+	}
+	return lenses
+}
@@ -0,0 +1,166 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type fakeLookup map[string][]string
+
+func (f fakeLookup) Lookup(sourceURL, targetLang string, unsafe bool) []string {
+	return f[sourceURL]
+}
+
+func (f fakeLookup) CrateName(rustURL string) string {
+	return ""
+}
+
+func (f fakeLookup) Confidence(sourceURL, targetLang string) float64 {
+	if sourceURL == "https://github.com/spf13/cobra" {
+		return 0.9
+	}
+	return 0.7
+}
+
+const testGoMod = `module example.com/app
+
+go 1.22
+
+require github.com/spf13/cobra v1.8.0
+
+require (
+	github.com/sirupsen/logrus v1.9.3 // indirect
+	example.com/unmapped v0.1.0
+)
+`
+
+func frame(t *testing.T, msgs ...string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	for _, m := range msgs {
+		fmt.Fprintf(&buf, "Content-Length: %d\r\n\r\n%s", len(m), m)
+	}
+	return &buf
+}
+
+func serve(t *testing.T, msgs ...string) []message {
+	t.Helper()
+	lookup := fakeLookup{
+		"https://github.com/spf13/cobra":     {"https://github.com/clap-rs/clap"},
+		"https://github.com/sirupsen/logrus": {"https://github.com/tokio-rs/tracing"},
+	}
+	var out bytes.Buffer
+	if err := NewServer(lookup, false).Serve(frame(t, msgs...), &out); err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+
+	var responses []message
+	r := bufio.NewReader(&out)
+	for {
+		body, err := readMessage(r)
+		if err != nil {
+			break
+		}
+		var m message
+		if err := json.Unmarshal(body, &m); err != nil {
+			t.Fatalf("decoding response: %v", err)
+		}
+		responses = append(responses, m)
+	}
+	return responses
+}
+
+func didOpen(text string) string {
+	data, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"method":  "textDocument/didOpen",
+		"params": map[string]any{
+			"textDocument": map[string]any{"uri": "file:///app/go.mod", "text": text},
+		},
+	})
+	return string(data)
+}
+
+func TestServe_Initialize(t *testing.T) {
+	responses := serve(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	)
+	if len(responses) != 2 {
+		t.Fatalf("got %d responses, want 2 (notifications get none)", len(responses))
+	}
+	caps, _ := json.Marshal(responses[0].Result)
+	if !strings.Contains(string(caps), `"hoverProvider":true`) {
+		t.Errorf("initialize result missing hoverProvider: %s", caps)
+	}
+}
+
+func TestServe_Hover(t *testing.T) {
+	responses := serve(t,
+		didOpen(testGoMod),
+		`{"jsonrpc":"2.0","id":1,"method":"textDocument/hover","params":{"textDocument":{"uri":"file:///app/go.mod"},"position":{"line":4,"character":12}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"textDocument/hover","params":{"textDocument":{"uri":"file:///app/go.mod"},"position":{"line":0,"character":0}}}`,
+	)
+	if len(responses) != 2 {
+		t.Fatalf("got %d responses, want 2", len(responses))
+	}
+
+	hover, _ := json.Marshal(responses[0].Result)
+	if !strings.Contains(string(hover), "maps to clap — https://github.com/clap-rs/clap, confidence 0.9") {
+		t.Errorf("hover = %s", hover)
+	}
+	if responses[1].Result != nil {
+		t.Errorf("hover on module line = %v, want null", responses[1].Result)
+	}
+}
+
+func TestServe_CodeLens(t *testing.T) {
+	responses := serve(t,
+		didOpen(testGoMod),
+		`{"jsonrpc":"2.0","id":1,"method":"textDocument/codeLens","params":{"textDocument":{"uri":"file:///app/go.mod"}}}`,
+	)
+	if len(responses) != 1 {
+		t.Fatalf("got %d responses, want 1", len(responses))
+	}
+
+	data, _ := json.Marshal(responses[0].Result)
+	var lenses []CodeLens
+	if err := json.Unmarshal(data, &lenses); err != nil {
+		t.Fatalf("decoding lenses: %v", err)
+	}
+	if len(lenses) != 2 {
+		t.Fatalf("got %d lenses, want 2 (unmapped module has none): %s", len(lenses), data)
+	}
+	if lenses[1].Range.Start.Line != 7 || lenses[1].Command.Title != "Rust: tracing (confidence 0.7)" {
+		t.Errorf("lenses[1] = %+v", lenses[1])
+	}
+}
+
+func TestServe_UnknownMethod(t *testing.T) {
+	responses := serve(t, `{"jsonrpc":"2.0","id":1,"method":"workspace/symbol","params":{}}`)
+	if len(responses) != 1 || responses[0].Error == nil || responses[0].Error.Code != codeMethodNotFound {
+		t.Errorf("responses = %+v, want method not found error", responses)
+	}
+}
+
+func TestParseRequires(t *testing.T) {
+	reqs := parseRequires(testGoMod)
+	want := []string{"github.com/spf13/cobra", "github.com/sirupsen/logrus", "example.com/unmapped"}
+	if len(reqs) != len(want) {
+		t.Fatalf("got %d requires, want %d", len(reqs), len(want))
+	}
+	for i, path := range want {
+		if reqs[i].Path != path {
+			t.Errorf("reqs[%d].Path = %q, want %q", i, reqs[i].Path, path)
+		}
+	}
+	if reqs[0].Line != 4 || reqs[0].Start != 8 || reqs[0].Version != "v1.8.0" {
+		t.Errorf("reqs[0] = %+v", reqs[0])
+	}
+}
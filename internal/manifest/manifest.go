@@ -0,0 +1,169 @@
+// Package manifest parses the dependency manifests of Python and Node projects, so they
+// can be mapped like go.mod: requirements.txt and package.json.
+package manifest
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// Source languages as used for Library.Lang in libs.json.
+const (
+	Python = "python"
+	JS     = "js"
+	Rust   = "rust" // crates, as listed in Cargo.toml
+)
+
+const MaxDependencies = 10000
+
+var (
+	ErrTooManyDependencies = errors.New("too many dependencies (limit: 10000)")
+
+	// name, optional [extras], then the version specifier up to an environment marker
+	requirementRe = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*([^;]*)`)
+	directRefRe   = regexp.MustCompile(`^\s*[A-Za-z0-9][A-Za-z0-9._-]*\s*(?:\[[^\]]*\])?\s*$`)
+	pypiSepRe     = regexp.MustCompile(`[-_.]+`)
+)
+
+type Dependency struct {
+	Name    string
+	Version string // specifier as written, e.g. >=2.31 or ^4.18.2
+	Dev     bool   // devDependencies of package.json
+}
+
+type ParseResult struct {
+	Lang         string
+	Name         string // package.json name, empty for requirements.txt
+	Dependencies []Dependency
+}
+
+// Lang returns the source language of a manifest file name, or "" for other files.
+func Lang(path string) string {
+	base := filepath.Base(path)
+	switch {
+	case base == "package.json":
+		return JS
+	case strings.HasPrefix(base, "requirements") && strings.HasSuffix(base, ".txt"):
+		return Python
+	}
+	return ""
+}
+
+func Parse(path string) (*ParseResult, error) {
+	return ParseFS(afero.NewOsFs(), path)
+}
+
+// ParseFS parses a requirements.txt or package.json from a filesystem (useful for
+// testing). The format is picked by file name, see Lang.
+func ParseFS(fs afero.Fs, path string) (*ParseResult, error) {
+	lang := Lang(path)
+	if lang == "" {
+		return nil, fmt.Errorf("%s is not a requirements.txt or package.json", filepath.Base(path))
+	}
+	file, err := fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if lang == JS {
+		return ParsePackageJSON(file)
+	}
+	return ParseRequirements(file)
+}
+
+// ParseRequirements parses a pip requirements file. Options (-r, -e, --index-url),
+// URLs and local paths are skipped, as they name no PyPI package.
+func ParseRequirements(r io.Reader) (*ParseResult, error) {
+	result := &ParseResult{Lang: Python}
+	scanner := bufio.NewScanner(r)
+	var line string
+	for scanner.Scan() {
+		text := scanner.Text()
+		if strings.HasSuffix(text, `\`) {
+			line += strings.TrimSuffix(text, `\`)
+			continue
+		}
+		line += text
+		for _, sep := range []string{" #", " --"} { // comments and per-requirement options like --hash
+			if i := strings.Index(line, sep); i >= 0 {
+				line = line[:i]
+			}
+		}
+		// A direct reference (name @ url) names the package, but has no version
+		if name, _, ok := strings.Cut(line, "@"); ok && directRefRe.MatchString(name) {
+			line = name
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") || strings.Contains(line, "://") {
+			line = ""
+			continue
+		}
+
+		if m := requirementRe.FindStringSubmatch(line); m != nil {
+			result.Dependencies = append(result.Dependencies, Dependency{Name: m[1], Version: strings.TrimSpace(m[2])})
+			if len(result.Dependencies) >= MaxDependencies {
+				return nil, ErrTooManyDependencies
+			}
+		}
+		line = ""
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ParsePackageJSON parses the dependencies and devDependencies of a package.json,
+// each sorted by name.
+func ParsePackageJSON(r io.Reader) (*ParseResult, error) {
+	var pkg struct {
+		Name            string            `json:"name"`
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.NewDecoder(r).Decode(&pkg); err != nil {
+		return nil, fmt.Errorf("parsing package.json: %w", err)
+	}
+	if len(pkg.Dependencies)+len(pkg.DevDependencies) > MaxDependencies {
+		return nil, ErrTooManyDependencies
+	}
+
+	result := &ParseResult{Lang: JS, Name: pkg.Name}
+	for _, deps := range []struct {
+		m   map[string]string
+		dev bool
+	}{{pkg.Dependencies, false}, {pkg.DevDependencies, true}} {
+		names := make([]string, 0, len(deps.m))
+		for name := range deps.m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			result.Dependencies = append(result.Dependencies, Dependency{Name: name, Version: deps.m[name], Dev: deps.dev})
+		}
+	}
+	return result, nil
+}
+
+// NormalizeName returns the canonical form of a package name for lookups. PyPI names
+// are case-insensitive and treat runs of -, _ and . alike (PyYAML and pyyaml, or
+// python_dotenv and python-dotenv, are one package); crates.io treats - and _ alike
+// and ignores case; npm names are kept as-is.
+func NormalizeName(lang, name string) string {
+	switch lang {
+	case Python:
+		return pypiSepRe.ReplaceAllString(strings.ToLower(name), "-")
+	case Rust:
+		return strings.ReplaceAll(strings.ToLower(name), "-", "_")
+	}
+	return name
+}
@@ -0,0 +1,108 @@
+package manifest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestParseRequirements(t *testing.T) {
+	input := `# app dependencies
+click>=8.0
+PyYAML==6.0.1 ; python_version >= "3.8"
+python_dotenv  # loads .env
+requests[security] >= 2.31 \
+    --hash=sha256:abc
+mylib @ https://example.com/mylib.whl
+
+-r dev.txt
+-e ./local
+git+https://github.com/foo/bar.git
+`
+	result, err := ParseRequirements(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Dependency{
+		{Name: "click", Version: ">=8.0"},
+		{Name: "PyYAML", Version: "==6.0.1"},
+		{Name: "python_dotenv"},
+		{Name: "requests", Version: ">= 2.31"},
+		{Name: "mylib"},
+	}
+	if result.Lang != Python || !reflect.DeepEqual(result.Dependencies, want) {
+		t.Errorf("ParseRequirements() = %s %+v, want %+v", result.Lang, result.Dependencies, want)
+	}
+}
+
+func TestParsePackageJSON(t *testing.T) {
+	input := `{
+  "name": "svc",
+  "dependencies": {"express": "^4.18.2", "@nestjs/core": "^10", "dotenv": "16"},
+  "devDependencies": {"typescript": "^5", "jest": "^29"}
+}`
+	result, err := ParsePackageJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Dependency{
+		{Name: "@nestjs/core", Version: "^10"},
+		{Name: "dotenv", Version: "16"},
+		{Name: "express", Version: "^4.18.2"},
+		{Name: "jest", Version: "^29", Dev: true},
+		{Name: "typescript", Version: "^5", Dev: true},
+	}
+	if result.Name != "svc" || result.Lang != JS || !reflect.DeepEqual(result.Dependencies, want) {
+		t.Errorf("ParsePackageJSON() = %+v", result)
+	}
+
+	if _, err := ParsePackageJSON(strings.NewReader("{")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestParseFS(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	_ = afero.WriteFile(fs, "app/requirements-dev.txt", []byte("pytest\n"), 0644)
+	_ = afero.WriteFile(fs, "web/package.json", []byte(`{"dependencies": {"axios": "1"}}`), 0644)
+
+	py, err := ParseFS(fs, "app/requirements-dev.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(py.Dependencies) != 1 || py.Dependencies[0].Name != "pytest" {
+		t.Errorf("requirements-dev.txt = %+v", py.Dependencies)
+	}
+	js, err := ParseFS(fs, "web/package.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(js.Dependencies) != 1 || js.Dependencies[0].Name != "axios" {
+		t.Errorf("package.json = %+v", js.Dependencies)
+	}
+	if _, err := ParseFS(fs, "go.mod"); err == nil {
+		t.Error("expected error for go.mod")
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		lang, name, want string
+	}{
+		{Python, "PyYAML", "pyyaml"},
+		{Python, "python_dotenv", "python-dotenv"},
+		{Python, "zope.interface", "zope-interface"},
+		{Python, "a-_-b", "a-b"},
+		{JS, "@nestjs/core", "@nestjs/core"},
+		{JS, "socket.io", "socket.io"},
+		{Rust, "serde-json", "serde_json"},
+		{Rust, "Inflector", "inflector"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeName(tt.lang, tt.name); got != tt.want {
+			t.Errorf("NormalizeName(%q, %q) = %q, want %q", tt.lang, tt.name, got, tt.want)
+		}
+	}
+}
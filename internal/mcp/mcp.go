@@ -0,0 +1,255 @@
+// Package mcp implements a Model Context Protocol server over stdio: newline-delimited
+// JSON-RPC 2.0 with the initialize handshake and the tools/list and tools/call methods,
+// so agents can call rinku operations as tools and get structured results back.
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+)
+
+// ProtocolVersion is the MCP revision the server implements.
+const ProtocolVersion = "2025-06-18"
+
+// JSON-RPC error codes used by the server.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// maxMessage limits the size of one request line.
+const maxMessage = 16 << 20
+
+// Tool is an operation offered to the client.
+type Tool struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	InputSchema Schema `json:"inputSchema"`
+
+	// Call runs the tool with arguments that passed the schema. An error is reported to
+	// the client as a tool result with isError set, so the model can react to it.
+	Call func(args Arguments) (string, error) `json:"-"`
+}
+
+// Schema is the JSON Schema of the arguments of a tool, an object of properties.
+type Schema struct {
+	Type       string              `json:"type"` // always object
+	Properties map[string]Property `json:"properties"`
+	Required   []string            `json:"required,omitempty"`
+}
+
+// Property is an argument of a tool.
+type Property struct {
+	Type        string   `json:"type"` // string, boolean or integer
+	Description string   `json:"description"`
+	Enum        []string `json:"enum,omitempty"`
+}
+
+// Object returns the schema of an object with the properties; required lists the
+// properties that must be given.
+func Object(properties map[string]Property, required ...string) Schema {
+	if properties == nil {
+		properties = map[string]Property{}
+	}
+	return Schema{Type: "object", Properties: properties, Required: required}
+}
+
+// Arguments are the arguments of a tool call.
+type Arguments map[string]any
+
+// String returns a string argument, or "" if it was not given.
+func (a Arguments) String(name string) string {
+	s, _ := a[name].(string)
+	return s
+}
+
+// Bool returns a boolean argument, or false if it was not given.
+func (a Arguments) Bool(name string) bool {
+	b, _ := a[name].(bool)
+	return b
+}
+
+// Int returns an integer argument, or 0 if it was not given.
+func (a Arguments) Int(name string) int {
+	f, _ := a[name].(float64)
+	return int(f)
+}
+
+// validate checks the arguments against the schema of a tool.
+func (a Arguments) validate(s Schema) error {
+	for _, name := range s.Required {
+		if _, ok := a[name]; !ok {
+			return fmt.Errorf("missing argument %q", name)
+		}
+	}
+	for name, v := range a {
+		p, ok := s.Properties[name]
+		if !ok {
+			return fmt.Errorf("unknown argument %q", name)
+		}
+		switch x := v.(type) {
+		case string:
+			if p.Type != "string" {
+				return fmt.Errorf("argument %q must be of type %s", name, p.Type)
+			}
+			if p.Enum != nil && !slices.Contains(p.Enum, x) {
+				return fmt.Errorf("argument %q must be one of %v", name, p.Enum)
+			}
+		case bool:
+			if p.Type != "boolean" {
+				return fmt.Errorf("argument %q must be of type %s", name, p.Type)
+			}
+		case float64:
+			if p.Type != "integer" || x != math.Trunc(x) {
+				return fmt.Errorf("argument %q must be of type %s", name, p.Type)
+			}
+		default:
+			return fmt.Errorf("argument %q must be of type %s", name, p.Type)
+		}
+	}
+	return nil
+}
+
+// Server answers MCP requests with a fixed set of tools.
+type Server struct {
+	name    string
+	version string
+	tools   []Tool
+}
+
+// NewServer returns a server named name offering tools.
+func NewServer(name, version string, tools []Tool) *Server {
+	return &Server{name: name, version: version, tools: tools}
+}
+
+// message is a JSON-RPC 2.0 request, notification or response.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// content is a block of a tool result.
+type content struct {
+	Type string `json:"type"` // text
+	Text string `json:"text"`
+}
+
+// callResult is the result of tools/call.
+type callResult struct {
+	Content []content `json:"content"`
+	IsError bool      `json:"isError"`
+}
+
+// Serve reads one request per line from r and writes the responses to w until r is
+// closed. Tool calls run one at a time, in the order they arrive.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxMessage)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var req message
+		if err := json.Unmarshal(line, &req); err != nil {
+			if err := writeMessage(w, &message{ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+		result, rerr := s.handle(&req)
+		if len(req.ID) == 0 {
+			continue // notification
+		}
+		if err := writeMessage(w, &message{ID: req.ID, Result: result, Error: rerr}); err != nil {
+			return fmt.Errorf("writing response: %w", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading request: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) handle(req *message) (any, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		var p struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		if len(req.Params) > 0 {
+			if err := json.Unmarshal(req.Params, &p); err != nil {
+				return nil, invalidParams(err)
+			}
+		}
+		return map[string]any{
+			"protocolVersion": ProtocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": s.name, "version": s.version},
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "notifications/initialized", "notifications/cancelled":
+		return nil, nil
+	case "tools/list":
+		return map[string]any{"tools": s.tools}, nil
+	case "tools/call":
+		var p struct {
+			Name      string    `json:"name"`
+			Arguments Arguments `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		i := slices.IndexFunc(s.tools, func(t Tool) bool { return t.Name == p.Name })
+		if i < 0 {
+			return nil, &rpcError{Code: codeInvalidParams, Message: "unknown tool: " + p.Name}
+		}
+		if p.Arguments == nil {
+			p.Arguments = Arguments{}
+		}
+		if err := p.Arguments.validate(s.tools[i].InputSchema); err != nil {
+			return nil, invalidParams(err)
+		}
+		text, err := s.tools[i].Call(p.Arguments)
+		if err != nil {
+			return callResult{Content: []content{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+		}
+		return callResult{Content: []content{{Type: "text", Text: text}}}, nil
+	case "":
+		return nil, &rpcError{Code: codeInvalidRequest, Message: "missing method"}
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+}
+
+func invalidParams(err error) *rpcError {
+	return &rpcError{Code: codeInvalidParams, Message: err.Error()}
+}
+
+// writeMessage writes one message as a line of JSON.
+func writeMessage(w io.Writer, m *message) error {
+	m.JSONRPC = "2.0"
+	body, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(body, '\n'))
+	return err
+}
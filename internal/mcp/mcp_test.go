@@ -0,0 +1,101 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testTools = []Tool{
+	{
+		Name:        "lookup",
+		Description: "Look up a library.",
+		InputSchema: Object(map[string]Property{
+			"url":      {Type: "string", Description: "Library URL."},
+			"language": {Type: "string", Description: "Target.", Enum: []string{"rust", "ts"}},
+			"limit":    {Type: "integer", Description: "Results."},
+		}, "url"),
+		Call: func(args Arguments) (string, error) {
+			if args.String("url") == "fail" {
+				return "", errors.New("lookup failed")
+			}
+			return args.String("url") + " " + args.String("language"), nil
+		},
+	},
+}
+
+func serve(t *testing.T, requests ...string) []message {
+	t.Helper()
+	var out bytes.Buffer
+	if err := NewServer("rinku", "test", testTools).Serve(strings.NewReader(strings.Join(requests, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+	var responses []message
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var m message
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			t.Fatalf("invalid response %q: %v", scanner.Text(), err)
+		}
+		responses = append(responses, m)
+	}
+	return responses
+}
+
+func TestServe_Handshake(t *testing.T) {
+	responses := serve(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+	)
+	if len(responses) != 2 {
+		t.Fatalf("got %d responses, want 2 (notifications get none)", len(responses))
+	}
+	init, _ := json.Marshal(responses[0].Result)
+	if !strings.Contains(string(init), `"protocolVersion":"2025-06-18"`) || !strings.Contains(string(init), `"tools":{}`) {
+		t.Errorf("initialize = %s", init)
+	}
+	list, _ := json.Marshal(responses[1].Result)
+	if !strings.Contains(string(list), `"name":"lookup"`) || !strings.Contains(string(list), `"required":["url"]`) {
+		t.Errorf("tools/list = %s", list)
+	}
+}
+
+func TestServe_Call(t *testing.T) {
+	responses := serve(t,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"lookup","arguments":{"url":"cobra","language":"ts"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"lookup","arguments":{"url":"fail"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"lookup","arguments":{"language":"go"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"lookup","arguments":{"url":"x","limit":1.5}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"nope"}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"resources/list"}`,
+		`not json`,
+	)
+	if len(responses) != 7 {
+		t.Fatalf("got %d responses, want 7", len(responses))
+	}
+	result := func(i int) string {
+		data, _ := json.Marshal(responses[i].Result)
+		return string(data)
+	}
+	if got := result(0); got != `{"content":[{"text":"cobra ts","type":"text"}],"isError":false}` {
+		t.Errorf("call = %s", got)
+	}
+	if got := result(1); got != `{"content":[{"text":"lookup failed","type":"text"}],"isError":true}` {
+		t.Errorf("failing call = %s", got)
+	}
+	for i, want := range map[int]string{2: `missing argument "url"`, 3: `argument "limit" must be of type integer`, 4: "unknown tool: nope"} {
+		if e := responses[i].Error; e == nil || e.Code != codeInvalidParams || e.Message != want {
+			t.Errorf("response %d error = %+v, want %q", i, e, want)
+		}
+	}
+	if e := responses[5].Error; e == nil || e.Code != codeMethodNotFound {
+		t.Errorf("unknown method error = %+v", e)
+	}
+	if e := responses[6].Error; e == nil || e.Code != codeParseError {
+		t.Errorf("parse error = %+v", e)
+	}
+}
@@ -0,0 +1,227 @@
+// Package modmap proposes Rust module paths for the packages of a Go module, so a port
+// uses consistent crate::module names.
+package modmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/natefinch/atomic"
+	"github.com/stephan/rinku/internal/gosrc"
+	"github.com/stephan/rinku/internal/progress"
+)
+
+const MapFile = "module-map.json"
+
+// Kind is the kind of Rust target a Go package becomes.
+type Kind string
+
+const (
+	KindLib Kind = "lib" // a module of the library crate
+	KindBin Kind = "bin" // a binary target
+)
+
+// Entry maps one Go package to its Rust location.
+type Entry struct {
+	GoPackage  string `json:"go_package"` // import path
+	Dir        string `json:"dir"`        // slash-separated, relative to the module root
+	Kind       Kind   `json:"kind"`
+	RustPath   string `json:"rust_path"`  // crate::a::b for libraries, the binary name for bins
+	File       string `json:"file"`       // suggested source file
+	Visibility string `json:"visibility"` // pub or pub(crate)
+}
+
+// Map is the module map of a Go module.
+type Map struct {
+	Module  string  `json:"module"`
+	Entries []Entry `json:"entries"`
+}
+
+// strippedPrefixes are Go layout directories that have no meaning in a Rust crate.
+var strippedPrefixes = []string{"internal", "pkg"}
+
+// Build proposes a Rust location for every non-test package in files.
+func Build(modulePath string, files []gosrc.File) *Map {
+	packages := make(map[string]string) // dir -> package name
+	for _, f := range files {
+		if f.Test {
+			continue
+		}
+		packages[path.Dir(f.Path)] = f.Package
+	}
+
+	dirs := make([]string, 0, len(packages))
+	for dir := range packages {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	m := &Map{Module: modulePath}
+	var libs []int
+	for _, dir := range dirs {
+		e := Entry{GoPackage: importPath(modulePath, dir), Dir: dir, Visibility: "pub"}
+		if isInternal(dir) {
+			e.Visibility = "pub(crate)" // Go's internal/ rule is closest to crate visibility
+		}
+
+		switch {
+		case packages[dir] == "main" && dir == ".":
+			e.Kind, e.RustPath, e.File = KindBin, binName(modulePath), "src/main.rs"
+		case packages[dir] == "main":
+			name := identifier(path.Base(dir))
+			e.Kind, e.RustPath, e.File = KindBin, name, "src/bin/"+name+".rs"
+		case dir == ".":
+			e.Kind, e.RustPath, e.File = KindLib, "crate", "src/lib.rs"
+		default:
+			e.Kind = KindLib
+			libs = append(libs, len(m.Entries))
+		}
+		m.Entries = append(m.Entries, e)
+	}
+
+	assignModulePaths(m.Entries, libs)
+	return m
+}
+
+// assignModulePaths sets RustPath and File for library packages. Layout prefixes like
+// internal/ and pkg/ are dropped unless that would make two packages collide.
+func assignModulePaths(entries []Entry, libs []int) {
+	segments := make(map[int][]string, len(libs))
+	owners := make(map[string][]int)
+	for _, i := range libs {
+		segs := moduleSegments(entries[i].Dir, true)
+		segments[i] = segs
+		key := strings.Join(segs, "::")
+		owners[key] = append(owners[key], i)
+	}
+	for _, idxs := range owners {
+		if len(idxs) > 1 {
+			for _, i := range idxs {
+				segments[i] = moduleSegments(entries[i].Dir, false)
+			}
+		}
+	}
+
+	for _, i := range libs {
+		segs := segments[i]
+		entries[i].RustPath = "crate::" + strings.Join(segs, "::")
+		entries[i].File = "src/" + strings.Join(segs, "/") + ".rs"
+	}
+}
+
+func moduleSegments(dir string, strip bool) []string {
+	var segs []string
+	for _, part := range strings.Split(dir, "/") {
+		if strip && isStrippedPrefix(part) {
+			continue
+		}
+		segs = append(segs, identifier(part))
+	}
+	if len(segs) == 0 {
+		// e.g. a package directly in internal/ or pkg/
+		segs = []string{identifier(path.Base(dir))}
+	}
+	return segs
+}
+
+func isInternal(dir string) bool {
+	for _, part := range strings.Split(dir, "/") {
+		if part == "internal" {
+			return true
+		}
+	}
+	return false
+}
+
+func isStrippedPrefix(part string) bool {
+	for _, p := range strippedPrefixes {
+		if part == p {
+			return true
+		}
+	}
+	return false
+}
+
+func importPath(modulePath, dir string) string {
+	if dir == "." {
+		return modulePath
+	}
+	return modulePath + "/" + dir
+}
+
+func binName(modulePath string) string {
+	base := path.Base(modulePath)
+	if len(base) > 1 && base[0] == 'v' && strings.Trim(base[1:], "0123456789") == "" {
+		base = path.Base(path.Dir(modulePath)) // drop major version suffix
+	}
+	return strings.ReplaceAll(identifier(base), "_", "-")
+}
+
+var rustKeywords = map[string]bool{
+	"as": true, "async": true, "await": true, "break": true, "const": true, "continue": true,
+	"crate": true, "dyn": true, "else": true, "enum": true, "extern": true, "false": true,
+	"fn": true, "for": true, "if": true, "impl": true, "in": true, "let": true, "loop": true,
+	"match": true, "mod": true, "move": true, "mut": true, "pub": true, "ref": true,
+	"return": true, "self": true, "static": true, "struct": true, "super": true, "trait": true,
+	"true": true, "type": true, "unsafe": true, "use": true, "where": true, "while": true,
+}
+
+// identifier converts a directory name to a snake_case Rust module name.
+func identifier(name string) string {
+	var sb strings.Builder
+	prevLower := false
+	for _, r := range name {
+		switch {
+		case unicode.IsUpper(r):
+			if prevLower {
+				sb.WriteByte('_')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+			prevLower = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(r)
+			prevLower = unicode.IsLower(r) || unicode.IsDigit(r)
+		default:
+			if sb.Len() > 0 && !strings.HasSuffix(sb.String(), "_") {
+				sb.WriteByte('_')
+			}
+			prevLower = false
+		}
+	}
+	id := strings.Trim(sb.String(), "_")
+	switch {
+	case id == "":
+		return "module"
+	case unicode.IsDigit(rune(id[0])):
+		return "m" + id
+	case rustKeywords[id]:
+		return id + "_"
+	}
+	return id
+}
+
+// Path returns the path to the module map file for a project directory.
+func Path(projectDir string) string {
+	return filepath.Join(projectDir, progress.ProgressDir, MapFile)
+}
+
+// Save atomically writes the module map.
+func (m *Map) Save(projectDir string) error {
+	dir := filepath.Join(projectDir, progress.ProgressDir)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("creating %s directory: %w", progress.ProgressDir, err)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling module map: %w", err)
+	}
+	return atomic.WriteFile(Path(projectDir), bytes.NewReader(append(data, '\n')))
+}
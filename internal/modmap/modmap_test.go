@@ -0,0 +1,85 @@
+package modmap
+
+import (
+	"testing"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+func TestBuild(t *testing.T) {
+	files := []gosrc.File{
+		{Path: "main.go", Package: "main"},
+		{Path: "cmd/admin-tool/main.go", Package: "main"},
+		{Path: "internal/store/store.go", Package: "store"},
+		{Path: "internal/store/store_test.go", Package: "store", Test: true},
+		{Path: "internal/httpAPI/v2/server.go", Package: "v2"},
+		{Path: "pkg/client/client.go", Package: "client"},
+		{Path: "pkg/type/type.go", Package: "typ"},
+		{Path: "testonly/x_test.go", Package: "testonly", Test: true},
+	}
+
+	m := Build("github.com/acme/shop/v3", files)
+
+	want := map[string]Entry{
+		".":                   {Kind: KindBin, RustPath: "shop", File: "src/main.rs", Visibility: "pub"},
+		"cmd/admin-tool":      {Kind: KindBin, RustPath: "admin_tool", File: "src/bin/admin_tool.rs", Visibility: "pub"},
+		"internal/store":      {Kind: KindLib, RustPath: "crate::store", File: "src/store.rs", Visibility: "pub(crate)"},
+		"internal/httpAPI/v2": {Kind: KindLib, RustPath: "crate::http_api::v2", File: "src/http_api/v2.rs", Visibility: "pub(crate)"},
+		"pkg/client":          {Kind: KindLib, RustPath: "crate::client", File: "src/client.rs", Visibility: "pub"},
+		"pkg/type":            {Kind: KindLib, RustPath: "crate::type_", File: "src/type_.rs", Visibility: "pub"},
+	}
+	if len(m.Entries) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(m.Entries), len(want), m.Entries)
+	}
+	for _, e := range m.Entries {
+		w, ok := want[e.Dir]
+		if !ok {
+			t.Errorf("unexpected entry for %s", e.Dir)
+			continue
+		}
+		if e.Kind != w.Kind || e.RustPath != w.RustPath || e.File != w.File || e.Visibility != w.Visibility {
+			t.Errorf("%s = %+v, want %+v", e.Dir, e, w)
+		}
+	}
+	if m.Entries[0].GoPackage != "github.com/acme/shop/v3" {
+		t.Errorf("root GoPackage = %q", m.Entries[0].GoPackage)
+	}
+}
+
+func TestBuild_CollidingPrefixes(t *testing.T) {
+	files := []gosrc.File{
+		{Path: "lib.go", Package: "shop"},
+		{Path: "internal/auth/auth.go", Package: "auth"},
+		{Path: "pkg/auth/auth.go", Package: "auth"},
+	}
+
+	m := Build("example.com/shop", files)
+
+	got := make(map[string]string)
+	for _, e := range m.Entries {
+		got[e.Dir] = e.RustPath
+	}
+	if got["."] != "crate" {
+		t.Errorf("root = %q, want crate", got["."])
+	}
+	if got["internal/auth"] != "crate::internal::auth" || got["pkg/auth"] != "crate::pkg::auth" {
+		t.Errorf("colliding packages should keep their prefixes, got %v", got)
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"httpAPI":   "http_api",
+		"go-redis":  "go_redis",
+		"v2":        "v2",
+		"2fa":       "m2fa",
+		"mod":       "mod_",
+		"UserStore": "user_store",
+		"...":       "module",
+	}
+	for input, want := range tests {
+		if got := identifier(input); got != want {
+			t.Errorf("identifier(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
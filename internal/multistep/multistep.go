@@ -4,32 +4,78 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Prompt holds parsed steps from a markdown prompt file.
 type Prompt struct {
+	name         string // from the frontmatter; catalogs default it to the file name
+	description  string
 	steps        map[string]string
 	order        []string
 	introduction string // Content from "# Introduction" section, entry point
 	before       string // Content from "# Before" section, shown before each step
 	after        string // Content from "# After" section, shown after each step
+	estimates    map[string]time.Duration
+	categories   map[string][]string // step ID -> library tags the step works on
+}
+
+// estimateAnnotation matches a "<!-- estimate: 2h -->" line inside a step.
+var estimateAnnotation = regexp.MustCompile(`^\s*<!--\s*estimate:\s*(\S+)\s*-->\s*$`)
+
+// checklistItem matches a "- [ ] Run the tests" or "- [x] ..." line inside a step.
+var checklistItem = regexp.MustCompile(`^\s*[-*] \[[ xX]\]\s+(\S.*?)\s*$`)
+
+// categoriesAnnotation matches a "<!-- categories: sql, orm -->" line inside a step.
+var categoriesAnnotation = regexp.MustCompile(`^\s*<!--\s*categories:\s*(.*?)\s*-->\s*$`)
+
+// frontmatter is the optional YAML block at the top of a prompt file.
+type frontmatter struct {
+	Name        string              `yaml:"name"`        // listed by rinku workflows list
+	Description string              `yaml:"description"` // one line
+	Estimates   map[string]string   `yaml:"estimates"`   // step ID -> duration, e.g. "1": 30m
+	Categories  map[string][]string `yaml:"categories"`  // step ID -> library tags, e.g. "16": [cli]
 }
 
 // Parse parses steps from markdown content.
 // Steps are identified by headers like "# Step 1" or "# Step Find Tests".
 // Special "# Before" and "# After" sections are shown before/after each step when using --start.
+// A step declares its estimated duration with a "<!-- estimate: 2h -->" line, or the file
+// starts with a YAML frontmatter block listing "estimates" per step ID; annotations win.
+// Categories, the library tags a step works on, are declared the same way with
+// "<!-- categories: sql, orm -->" or the "categories" frontmatter key.
 func Parse(content string) (*Prompt, error) {
 	p := &Prompt{
-		steps: make(map[string]string),
-		order: []string{},
+		steps:      make(map[string]string),
+		order:      []string{},
+		estimates:  make(map[string]time.Duration),
+		categories: make(map[string][]string),
+	}
+
+	content, err := parseFrontmatter(p, content)
+	if err != nil {
+		return nil, err
 	}
 
 	var currentSection string // "before" or step ID
 	var currentContent strings.Builder
 
 	for _, line := range strings.Split(content, "\n") {
-		if isIntroductionHeader(line) {
+		if m := estimateAnnotation.FindStringSubmatch(line); m != nil && isStepSection(currentSection) {
+			d, err := parseEstimate(m[1])
+			if err != nil {
+				return nil, fmt.Errorf("step %s: %w", currentSection, err)
+			}
+			p.estimates[currentSection] = d
+		} else if m := categoriesAnnotation.FindStringSubmatch(line); m != nil && isStepSection(currentSection) {
+			p.categories[currentSection] = splitCategories(m[1])
+		} else if isIntroductionHeader(line) {
 			// Save previous section if any
 			if currentSection != "" {
 				saveSection(p, currentSection, currentContent.String())
@@ -76,6 +122,71 @@ func Parse(content string) (*Prompt, error) {
 	return p, nil
 }
 
+// parseFrontmatter reads a leading "---" YAML block and returns the content after it.
+func parseFrontmatter(p *Prompt, content string) (string, error) {
+	rest, ok := strings.CutPrefix(content, "---\n")
+	if !ok {
+		return content, nil
+	}
+	block, body, ok := strings.Cut(rest, "\n---\n")
+	if !ok {
+		return "", errors.New("unterminated frontmatter")
+	}
+	var fm frontmatter
+	if err := yaml.Unmarshal([]byte(block), &fm); err != nil {
+		return "", fmt.Errorf("parsing frontmatter: %w", err)
+	}
+	p.name, p.description = fm.Name, strings.TrimSpace(fm.Description)
+	for id, value := range fm.Estimates {
+		d, err := parseEstimate(value)
+		if err != nil {
+			return "", fmt.Errorf("frontmatter estimate for step %s: %w", id, err)
+		}
+		p.estimates[id] = d
+	}
+	for id, tags := range fm.Categories {
+		p.categories[id] = tags
+	}
+	return body, nil
+}
+
+// splitCategories splits a comma-separated list of tags.
+func splitCategories(list string) []string {
+	var tags []string
+	for _, tag := range strings.Split(list, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// parseEstimate parses a duration like "45m" or "1h30m". A "d" suffix counts
+// working days of 8 hours, e.g. "2d".
+func parseEstimate(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid estimate %q", value)
+		}
+		return time.Duration(n * float64(8*time.Hour)), nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid estimate %q", value)
+	}
+	return d, nil
+}
+
+// isStepSection reports whether section is a step rather than a special section.
+func isStepSection(section string) bool {
+	switch section {
+	case "", "introduction", "before", "after":
+		return false
+	}
+	return true
+}
+
 func saveSection(p *Prompt, section, content string) {
 	content = strings.TrimSpace(content)
 	switch section {
@@ -151,12 +262,62 @@ func ParseFile(path string) (*Prompt, error) {
 	return Parse(string(content))
 }
 
+// Name returns the workflow name of the frontmatter, empty if it declares none.
+func (p *Prompt) Name() string {
+	return p.name
+}
+
+// Description returns the workflow description of the frontmatter.
+func (p *Prompt) Description() string {
+	return p.description
+}
+
 // GetStep returns the content for a step ID.
 func (p *Prompt) GetStep(id string) (string, bool) {
 	content, ok := p.steps[id]
 	return content, ok
 }
 
+// Estimate returns the estimated duration of a step, if the prompt declares one.
+func (p *Prompt) Estimate(id string) (time.Duration, bool) {
+	d, ok := p.estimates[id]
+	return d, ok
+}
+
+// Estimates returns the declared estimates by step ID.
+func (p *Prompt) Estimates() map[string]time.Duration {
+	result := make(map[string]time.Duration, len(p.estimates))
+	for id, d := range p.estimates {
+		result[id] = d
+	}
+	return result
+}
+
+// Categories returns the library tags a step works on, such as cli or sql. Tags may be
+// path.Match patterns, e.g. "codegen:*".
+func (p *Prompt) Categories(id string) []string {
+	return slices.Clone(p.categories[id])
+}
+
+// Checklist returns the text of the checklist items of a step, the "- [ ]" and
+// "- [x]" lines outside code blocks, in order. Item n of rinku migrate check is the
+// n-th, counted from 1. Whether an item is done is tracked in progress, so the mark
+// in the prompt does not matter.
+func (p *Prompt) Checklist(id string) []string {
+	var items []string
+	fenced := false
+	for _, line := range strings.Split(p.steps[id], "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			fenced = !fenced
+			continue
+		}
+		if m := checklistItem.FindStringSubmatch(line); m != nil && !fenced {
+			items = append(items, m[1])
+		}
+	}
+	return items
+}
+
 // Introduction returns the content of the "# Introduction" section.
 func (p *Prompt) Introduction() string {
 	return p.introduction
@@ -195,3 +356,92 @@ func (p *Prompt) Bootstrap(command string) string {
 	}
 	return fmt.Sprintf("Execute '%s %s'. This will return instructions. Execute those instructions.", command, first)
 }
+
+// Agent selects the bootstrap format for a coding agent.
+type Agent string
+
+const (
+	AgentGeneric Agent = "generic" // plain Markdown, for pasting into any chat or system prompt
+	AgentClaude  Agent = "claude"  // XML-tagged sections, e.g. for CLAUDE.md
+	AgentCursor  Agent = "cursor"  // a Cursor project rule (.cursor/rules/*.mdc)
+)
+
+// Agents lists the supported bootstrap formats.
+var Agents = []Agent{AgentGeneric, AgentClaude, AgentCursor}
+
+// Command is a tool command the agent may run.
+type Command struct {
+	Usage       string // e.g. "rinku migrate --start <step>"
+	Description string
+}
+
+// BootstrapOptions describe the tooling around a prompt.
+type BootstrapOptions struct {
+	Command     string // command that prints a step, e.g. "rinku migrate"
+	Description string // one-line summary of the workflow, used where a format needs one
+	Commands    []Command
+	Guardrails  []string
+}
+
+// AgentBootstrap returns a system-prompt style preamble for agent: the Introduction
+// section, the available commands, the guardrails and the instruction to start.
+func (p *Prompt) AgentBootstrap(agent Agent, opts BootstrapOptions) (string, error) {
+	start := p.Bootstrap(opts.Command)
+	if start == "" {
+		return "", errors.New("no steps found")
+	}
+
+	var sb strings.Builder
+	switch agent {
+	case AgentGeneric, "":
+		writeMarkdownBootstrap(&sb, p.introduction, opts, start)
+	case AgentCursor:
+		sb.WriteString("---\n")
+		fmt.Fprintf(&sb, "description: %s\n", opts.Description)
+		sb.WriteString("globs:\nalwaysApply: true\n---\n\n")
+		writeMarkdownBootstrap(&sb, p.introduction, opts, start)
+	case AgentClaude:
+		if p.introduction != "" {
+			fmt.Fprintf(&sb, "<introduction>\n%s\n</introduction>\n\n", p.introduction)
+		}
+		if len(opts.Commands) > 0 {
+			sb.WriteString("<commands>\n")
+			for _, c := range opts.Commands {
+				fmt.Fprintf(&sb, "- `%s` - %s\n", c.Usage, c.Description)
+			}
+			sb.WriteString("</commands>\n\n")
+		}
+		if len(opts.Guardrails) > 0 {
+			sb.WriteString("<guardrails>\n")
+			for _, g := range opts.Guardrails {
+				fmt.Fprintf(&sb, "- %s\n", g)
+			}
+			sb.WriteString("</guardrails>\n\n")
+		}
+		fmt.Fprintf(&sb, "<start>\n%s\n</start>\n", start)
+	default:
+		return "", fmt.Errorf("unknown agent %q (supported: generic, claude, cursor)", agent)
+	}
+	return sb.String(), nil
+}
+
+func writeMarkdownBootstrap(sb *strings.Builder, introduction string, opts BootstrapOptions, start string) {
+	if introduction != "" {
+		fmt.Fprintf(sb, "# Introduction\n\n%s\n\n", introduction)
+	}
+	if len(opts.Commands) > 0 {
+		sb.WriteString("# Commands\n\n")
+		for _, c := range opts.Commands {
+			fmt.Fprintf(sb, "- `%s` - %s\n", c.Usage, c.Description)
+		}
+		sb.WriteString("\n")
+	}
+	if len(opts.Guardrails) > 0 {
+		sb.WriteString("# Guardrails\n\n")
+		for _, g := range opts.Guardrails {
+			fmt.Fprintf(sb, "- %s\n", g)
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(sb, "# Start\n\n%s\n", start)
+}
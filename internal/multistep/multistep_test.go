@@ -1,7 +1,10 @@
 package multistep
 
 import (
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestParse_NumericSteps(t *testing.T) {
@@ -299,3 +302,176 @@ Another valid step.
 		t.Errorf("expected steps [1, 4], got %v", steps)
 	}
 }
+
+func TestAgentBootstrap(t *testing.T) {
+	content := `# Introduction
+You migrate a project.
+
+# Step 1
+Do something.
+`
+	p, _ := Parse(content)
+	opts := BootstrapOptions{
+		Command:     "rinku migrate --start",
+		Description: "Go to Rust migration",
+		Commands:    []Command{{Usage: "rinku migrate --status", Description: "Show progress."}},
+		Guardrails:  []string{"Stay in the current directory."},
+	}
+
+	tests := []struct {
+		agent Agent
+		want  []string
+	}{
+		{AgentGeneric, []string{
+			"# Introduction\n\nYou migrate a project.\n",
+			"# Commands\n\n- `rinku migrate --status` - Show progress.\n",
+			"# Guardrails\n\n- Stay in the current directory.\n",
+			"# Start\n\nExecute 'rinku migrate --start 1'.",
+		}},
+		{AgentCursor, []string{
+			"---\ndescription: Go to Rust migration\nglobs:\nalwaysApply: true\n---\n\n# Introduction",
+			"# Start\n\nExecute 'rinku migrate --start 1'.",
+		}},
+		{AgentClaude, []string{
+			"<introduction>\nYou migrate a project.\n</introduction>",
+			"<commands>\n- `rinku migrate --status` - Show progress.\n</commands>",
+			"<guardrails>\n- Stay in the current directory.\n</guardrails>",
+			"<start>\nExecute 'rinku migrate --start 1'.",
+		}},
+	}
+	for _, tt := range tests {
+		got, err := p.AgentBootstrap(tt.agent, opts)
+		if err != nil {
+			t.Fatalf("AgentBootstrap(%s) failed: %v", tt.agent, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("AgentBootstrap(%s) missing %q:\n%s", tt.agent, want, got)
+			}
+		}
+	}
+
+	if _, err := p.AgentBootstrap("vim", opts); err == nil {
+		t.Error("AgentBootstrap(vim) succeeded, want error")
+	}
+}
+
+func TestParse_Estimates(t *testing.T) {
+	content := `---
+estimates:
+  "1": 30m
+  "2": 1d
+---
+# Before
+Read the requirements.
+
+# Step 1
+<!-- estimate: 45m -->
+First step content.
+
+# Step 2
+Second step content.
+
+# Step 3
+Third step content.
+`
+	p, err := Parse(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if d, ok := p.Estimate("1"); !ok || d != 45*time.Minute {
+		t.Errorf("Estimate(1) = %v, %v, want 45m (annotation overrides frontmatter)", d, ok)
+	}
+	if d, ok := p.Estimate("2"); !ok || d != 8*time.Hour {
+		t.Errorf("Estimate(2) = %v, %v, want 8h", d, ok)
+	}
+	if _, ok := p.Estimate("3"); ok {
+		t.Error("step 3 should have no estimate")
+	}
+	if c, _ := p.GetStep("1"); c != "First step content." {
+		t.Errorf("annotation should be stripped, got %q", c)
+	}
+	if p.Before() != "Read the requirements." {
+		t.Errorf("Before() = %q", p.Before())
+	}
+}
+
+func TestParse_InvalidEstimate(t *testing.T) {
+	for _, content := range []string{
+		"# Step 1\n<!-- estimate: soon -->\nContent.\n",
+		"---\nestimates:\n  \"1\": -1h\n---\n# Step 1\nContent.\n",
+		"---\nestimates: {}\n# Step 1\nContent.\n",
+	} {
+		if _, err := Parse(content); err == nil {
+			t.Errorf("expected error for %q", content)
+		}
+	}
+}
+
+func TestParse_Categories(t *testing.T) {
+	content := `---
+categories:
+  "1": [cli]
+  "2": ["codegen:*"]
+---
+# Step 1
+<!-- categories: sql, orm -->
+First step content.
+
+# Step 2
+Second step content.
+
+# Step 3
+Third step content.
+`
+	p, err := Parse(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := p.Categories("1"); !slices.Equal(got, []string{"sql", "orm"}) {
+		t.Errorf("Categories(1) = %v, want [sql orm] (annotation overrides frontmatter)", got)
+	}
+	if got := p.Categories("2"); !slices.Equal(got, []string{"codegen:*"}) {
+		t.Errorf("Categories(2) = %v", got)
+	}
+	if got := p.Categories("3"); got != nil {
+		t.Errorf("Categories(3) = %v, want none", got)
+	}
+	if c, _ := p.GetStep("1"); c != "First step content." {
+		t.Errorf("annotation should be stripped, got %q", c)
+	}
+}
+
+func TestParseFrontmatterName(t *testing.T) {
+	p, err := Parse("---\nname: fintech\ndescription: Go to Rust with the compliance review steps\n---\n# Step 1\nFirst.\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name() != "fintech" || p.Description() != "Go to Rust with the compliance review steps" {
+		t.Errorf("Name, Description = %q, %q", p.Name(), p.Description())
+	}
+	applied, err := p.Apply(&Overrides{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied.Name() != "fintech" {
+		t.Errorf("Apply dropped the name: %q", applied.Name())
+	}
+}
+
+func TestChecklist(t *testing.T) {
+	content := "# Step 1\nPort the handlers.\n\n- [ ] Run the tests\n  - [x] Update the README \n* [ ] Tag the release\n\n```md\n- [ ] not an item\n```\n- [] malformed\n"
+	p, err := Parse(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"Run the tests", "Update the README", "Tag the release"}
+	if got := p.Checklist("1"); !slices.Equal(got, want) {
+		t.Errorf("Checklist(1) = %q, want %q", got, want)
+	}
+	if got := p.Checklist("2"); got != nil {
+		t.Errorf("Checklist of unknown step = %q", got)
+	}
+}
@@ -0,0 +1,161 @@
+package multistep
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Overrides adapt the steps of a prompt without forking the prompt file. They are
+// applied in the order insert, move, disable, so a custom step can be placed after
+// another custom step and a moved step may follow one that is disabled later.
+type Overrides struct {
+	Insert  []CustomStep `yaml:"insert"`  // new steps, each placed after an existing one
+	Move    []Move       `yaml:"move"`    // steps placed after another step
+	Disable []string     `yaml:"disable"` // step IDs removed from the workflow
+}
+
+// CustomStep is a step that is not in the prompt file.
+type CustomStep struct {
+	ID         string   `yaml:"id"`
+	After      string   `yaml:"after"`      // step ID the new step follows
+	Content    string   `yaml:"content"`    // instructions shown by --start
+	File       string   `yaml:"file"`       // markdown file with the instructions, instead of content
+	Estimate   string   `yaml:"estimate"`   // e.g. "45m" or "1d", see the prompt estimates
+	Categories []string `yaml:"categories"` // library tags the step works on, e.g. [sql, orm]
+}
+
+// Move places Step directly after After.
+type Move struct {
+	Step  string `yaml:"step"`
+	After string `yaml:"after"`
+}
+
+// LoadOverrides reads overrides from a YAML file. A custom step's file is relative to
+// the directory of path and is read into its content.
+func LoadOverrides(path string) (*Overrides, error) {
+	data, err := os.ReadFile(path) //#nosec G304 -- caller provides trusted path
+	if err != nil {
+		return nil, fmt.Errorf("reading overrides: %w", err)
+	}
+	var o Overrides
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&o); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing %s: %w", filepath.Base(path), err)
+	}
+	for i, s := range o.Insert {
+		if s.File == "" {
+			continue
+		}
+		if s.Content != "" {
+			return nil, fmt.Errorf("custom step %s: set content or file, not both", s.ID)
+		}
+		content, err := os.ReadFile(filepath.Join(filepath.Dir(path), s.File)) //#nosec G304 -- relative to the trusted overrides file
+		if err != nil {
+			return nil, fmt.Errorf("custom step %s: %w", s.ID, err)
+		}
+		o.Insert[i].Content = string(content)
+	}
+	return &o, nil
+}
+
+// Apply returns a copy of the prompt with the overrides applied. Unknown step IDs,
+// duplicate custom steps and custom steps without instructions are errors.
+func (p *Prompt) Apply(o *Overrides) (*Prompt, error) {
+	result := &Prompt{
+		name:         p.name,
+		description:  p.description,
+		steps:        make(map[string]string, len(p.steps)),
+		order:        p.Steps(),
+		introduction: p.introduction,
+		before:       p.before,
+		after:        p.after,
+		estimates:    p.Estimates(),
+		categories:   make(map[string][]string, len(p.categories)),
+	}
+	for id, content := range p.steps {
+		result.steps[id] = content
+	}
+	for id, tags := range p.categories {
+		result.categories[id] = tags
+	}
+
+	for _, s := range o.Insert {
+		if s.ID == "" {
+			return nil, errors.New("custom step without id")
+		}
+		if _, ok := result.steps[s.ID]; ok {
+			return nil, fmt.Errorf("custom step %s: a step with this id already exists", s.ID)
+		}
+		content := strings.TrimSpace(s.Content)
+		if content == "" {
+			return nil, fmt.Errorf("custom step %s: no content or file", s.ID)
+		}
+		if s.Estimate != "" {
+			d, err := parseEstimate(s.Estimate)
+			if err != nil {
+				return nil, fmt.Errorf("custom step %s: %w", s.ID, err)
+			}
+			result.estimates[s.ID] = d
+		}
+		order, err := insertAfter(result.order, s.ID, s.After)
+		if err != nil {
+			return nil, fmt.Errorf("custom step %s: %w", s.ID, err)
+		}
+		result.order = order
+		result.steps[s.ID] = content
+		if len(s.Categories) > 0 {
+			result.categories[s.ID] = s.Categories
+		}
+	}
+
+	for _, mv := range o.Move {
+		i := slices.Index(result.order, mv.Step)
+		if i < 0 {
+			return nil, fmt.Errorf("move: step %s not found", mv.Step)
+		}
+		if mv.Step == mv.After {
+			return nil, fmt.Errorf("move: step %s cannot follow itself", mv.Step)
+		}
+		order, err := insertAfter(slices.Delete(result.order, i, i+1), mv.Step, mv.After)
+		if err != nil {
+			return nil, fmt.Errorf("move step %s: %w", mv.Step, err)
+		}
+		result.order = order
+	}
+
+	for _, id := range o.Disable {
+		i := slices.Index(result.order, id)
+		if i < 0 {
+			return nil, fmt.Errorf("disable: step %s not found", id)
+		}
+		result.order = slices.Delete(result.order, i, i+1)
+		delete(result.steps, id)
+		delete(result.estimates, id)
+		delete(result.categories, id)
+	}
+	if len(result.order) == 0 {
+		return nil, errors.New("overrides disable every step")
+	}
+	return result, nil
+}
+
+// insertAfter returns order with id inserted directly after the step after.
+func insertAfter(order []string, id, after string) ([]string, error) {
+	if after == "" {
+		return nil, errors.New("after is required")
+	}
+	i := slices.Index(order, after)
+	if i < 0 {
+		return nil, fmt.Errorf("step %s not found", after)
+	}
+	return slices.Insert(order, i+1, id), nil
+}
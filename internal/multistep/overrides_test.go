@@ -0,0 +1,134 @@
+package multistep
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const overridesPrompt = `# Step 1
+Analyze.
+
+# Step 2
+Generate code.
+<!-- estimate: 2h -->
+
+# Step 3
+Port tests.
+
+# Step 4
+Clean up.
+`
+
+func TestApply(t *testing.T) {
+	p, err := Parse(overridesPrompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.Apply(&Overrides{
+		Insert: []CustomStep{
+			{ID: "Security Review", After: "3", Content: "Run cargo audit.\n", Estimate: "1h", Categories: []string{"auth"}},
+			{ID: "Sign-off", After: "Security Review", Content: "Ask the team lead."},
+		},
+		Move:    []Move{{Step: "4", After: "1"}},
+		Disable: []string{"2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"1", "4", "3", "Security Review", "Sign-off"}
+	if !slices.Equal(got.Steps(), want) {
+		t.Errorf("Steps() = %v, want %v", got.Steps(), want)
+	}
+	if c, ok := got.GetStep("Security Review"); !ok || c != "Run cargo audit." {
+		t.Errorf("custom step content = %q, %v", c, ok)
+	}
+	if _, ok := got.GetStep("2"); ok {
+		t.Error("disabled step 2 still has content")
+	}
+	if d, ok := got.Estimate("Security Review"); !ok || d != time.Hour {
+		t.Errorf("custom step estimate = %v, %v", d, ok)
+	}
+	if c := got.Categories("Security Review"); !slices.Equal(c, []string{"auth"}) {
+		t.Errorf("custom step categories = %v", c)
+	}
+	if _, ok := got.Estimate("2"); ok {
+		t.Error("disabled step 2 still has an estimate")
+	}
+
+	// The original prompt is unchanged
+	if !slices.Equal(p.Steps(), []string{"1", "2", "3", "4"}) {
+		t.Errorf("original Steps() = %v", p.Steps())
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	p, err := Parse(overridesPrompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		o    Overrides
+		want string
+	}{
+		{"unknown after", Overrides{Insert: []CustomStep{{ID: "x", After: "9", Content: "x"}}}, "step 9 not found"},
+		{"missing after", Overrides{Insert: []CustomStep{{ID: "x", Content: "x"}}}, "after is required"},
+		{"duplicate id", Overrides{Insert: []CustomStep{{ID: "2", After: "1", Content: "x"}}}, "already exists"},
+		{"no content", Overrides{Insert: []CustomStep{{ID: "x", After: "1"}}}, "no content or file"},
+		{"bad estimate", Overrides{Insert: []CustomStep{{ID: "x", After: "1", Content: "x", Estimate: "soon"}}}, "invalid estimate"},
+		{"unknown move", Overrides{Move: []Move{{Step: "9", After: "1"}}}, "move: step 9 not found"},
+		{"move after itself", Overrides{Move: []Move{{Step: "1", After: "1"}}}, "cannot follow itself"},
+		{"unknown disable", Overrides{Disable: []string{"9"}}, "disable: step 9 not found"},
+		{"everything disabled", Overrides{Disable: []string{"1", "2", "3", "4"}}, "every step"},
+	}
+	for _, tt := range tests {
+		if _, err := p.Apply(&tt.o); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "review.md"), []byte("Review the port.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "overrides.yaml")
+	yaml := `insert:
+  - id: Review
+    after: 3
+    file: review.md
+move:
+  - step: 4
+    after: 1
+disable: [2]
+`
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	o, err := LoadOverrides(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Insert) != 1 || o.Insert[0].After != "3" || o.Insert[0].Content != "Review the port.\n" {
+		t.Errorf("Insert = %+v", o.Insert)
+	}
+	if len(o.Move) != 1 || o.Move[0] != (Move{Step: "4", After: "1"}) {
+		t.Errorf("Move = %+v", o.Move)
+	}
+	if !slices.Equal(o.Disable, []string{"2"}) {
+		t.Errorf("Disable = %v", o.Disable)
+	}
+
+	if err := os.WriteFile(path, []byte("remove: [2]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadOverrides(path); err == nil {
+		t.Error("unknown field: no error")
+	}
+}
@@ -0,0 +1,174 @@
+// Package notify posts migration milestones to webhook URLs, as JSON or as
+// Slack-compatible messages.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/natefinch/atomic"
+	"github.com/stephan/rinku/internal/config"
+	"github.com/stephan/rinku/internal/progress"
+)
+
+// Event kinds, as used in the events list of a [[notify]] table.
+const (
+	StepCompleted     = "step_completed"
+	Coverage          = "coverage"
+	MigrationFinished = "migration_finished"
+	Test              = "test" // sent to every hook by rinku notify test
+)
+
+// DefaultThresholds are the coverage percentages notified when a hook lists none.
+var DefaultThresholds = []int{25, 50, 75, 100}
+
+// StateFile is the file below .rinku remembering the last notified coverage.
+const StateFile = "notify.json"
+
+// Event is a milestone of a migration.
+type Event struct {
+	Kind      string `json:"event"`
+	Project   string `json:"project"`
+	Step      string `json:"step,omitempty"`
+	Completed int    `json:"completed"` // steps
+	Total     int    `json:"total"`
+	Coverage  int    `json:"coverage"`            // percent of requirements done
+	Previous  int    `json:"-"`                   // coverage before the change
+	Threshold int    `json:"threshold,omitempty"` // crossed threshold, per hook
+	Actor     string `json:"actor,omitempty"`
+	Text      string `json:"text"`
+}
+
+// Notifier sends events to the configured hooks.
+type Notifier struct {
+	Hooks  []config.Notify
+	Client *http.Client // http.DefaultClient if nil
+}
+
+// Send posts the event to every hook subscribed to it and returns the failures.
+// Coverage events only reach hooks with a threshold between the previous and the
+// new coverage.
+func (n *Notifier) Send(ctx context.Context, e Event) error {
+	var errs []error
+	for _, hook := range n.Hooks {
+		if e.Kind != Test && len(hook.Events) > 0 && !slices.Contains(hook.Events, e.Kind) {
+			continue
+		}
+		ev := e
+		if e.Kind == Coverage {
+			thresholds := hook.Thresholds
+			if len(thresholds) == 0 {
+				thresholds = DefaultThresholds
+			}
+			ev.Threshold = Crossed(thresholds, e.Previous, e.Coverage)
+			if ev.Threshold == 0 {
+				continue
+			}
+		}
+		ev.Text = ev.message()
+		if err := n.post(ctx, hook, ev); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Crossed returns the highest threshold t with before < t <= after, or 0.
+func Crossed(thresholds []int, before, after int) int {
+	crossed := 0
+	for _, t := range thresholds {
+		if before < t && t <= after && t > crossed {
+			crossed = t
+		}
+	}
+	return crossed
+}
+
+func (e Event) message() string {
+	by := ""
+	if e.Actor != "" {
+		by = " by " + e.Actor
+	}
+	switch e.Kind {
+	case StepCompleted:
+		return fmt.Sprintf("%s: step %s completed%s (%d/%d steps)", e.Project, e.Step, by, e.Completed, e.Total)
+	case Coverage:
+		return fmt.Sprintf("%s: %d%% of requirements done, passing %d%%", e.Project, e.Coverage, e.Threshold)
+	case MigrationFinished:
+		return fmt.Sprintf("%s: migration finished%s, all %d steps done", e.Project, by, e.Total)
+	case Test:
+		return fmt.Sprintf("%s: test notification from rinku%s", e.Project, by)
+	default:
+		return fmt.Sprintf("%s: %s", e.Project, e.Kind)
+	}
+}
+
+func (n *Notifier) post(ctx context.Context, hook config.Notify, e Event) error {
+	var payload any = e
+	if hook.Format == "slack" {
+		payload = struct {
+			Text string `json:"text"`
+		}{e.Text}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("notifying %s: %w", req.URL.Host, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("notifying %s: %s", req.URL.Host, resp.Status)
+	}
+	return nil
+}
+
+// state is what notify remembers between commands.
+type state struct {
+	Coverage int `json:"coverage"`
+}
+
+// LastCoverage returns the coverage recorded by SaveCoverage, 0 if none.
+func LastCoverage(projectDir string) int {
+	data, err := os.ReadFile(filepath.Join(projectDir, progress.ProgressDir, StateFile)) //#nosec G304 -- projectDir from os.Getwd()
+	if err != nil {
+		return 0
+	}
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return 0
+	}
+	return s.Coverage
+}
+
+// SaveCoverage records the coverage the next coverage event is compared against.
+func SaveCoverage(projectDir string, coverage int) error {
+	dir := filepath.Join(projectDir, progress.ProgressDir)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	data, err := json.Marshal(state{Coverage: coverage})
+	if err != nil {
+		return fmt.Errorf("marshaling notify state: %w", err)
+	}
+	return atomic.WriteFile(filepath.Join(dir, StateFile), bytes.NewReader(append(data, '\n')))
+}
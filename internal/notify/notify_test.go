@@ -0,0 +1,126 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stephan/rinku/internal/config"
+)
+
+// recorder collects posted JSON bodies.
+type recorder struct {
+	mu     sync.Mutex
+	bodies []map[string]any
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var body map[string]any
+	_ = json.NewDecoder(req.Body).Decode(&body)
+	r.mu.Lock()
+	r.bodies = append(r.bodies, body)
+	r.mu.Unlock()
+}
+
+func TestSend_FiltersEvents(t *testing.T) {
+	slack := &recorder{}
+	dash := &recorder{}
+	slackSrv := httptest.NewServer(slack)
+	defer slackSrv.Close()
+	dashSrv := httptest.NewServer(dash)
+	defer dashSrv.Close()
+
+	n := &Notifier{Hooks: []config.Notify{
+		{URL: slackSrv.URL, Format: "slack", Events: []string{MigrationFinished}},
+		{URL: dashSrv.URL, Format: "json"},
+	}}
+	if err := n.Send(context.Background(), Event{Kind: StepCompleted, Project: "app", Step: "3", Completed: 3, Total: 26, Actor: "bob"}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if err := n.Send(context.Background(), Event{Kind: MigrationFinished, Project: "app", Completed: 26, Total: 26}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if len(slack.bodies) != 1 {
+		t.Fatalf("slack received %d messages, want 1", len(slack.bodies))
+	}
+	if got := slack.bodies[0]["text"]; got != "app: migration finished, all 26 steps done" {
+		t.Errorf("slack text = %q", got)
+	}
+	if _, ok := slack.bodies[0]["event"]; ok {
+		t.Error("slack payload should only carry text")
+	}
+	if len(dash.bodies) != 2 {
+		t.Fatalf("dashboard received %d messages, want 2", len(dash.bodies))
+	}
+	if dash.bodies[0]["event"] != StepCompleted || dash.bodies[0]["step"] != "3" {
+		t.Errorf("json payload = %v", dash.bodies[0])
+	}
+	if got := dash.bodies[0]["text"]; got != "app: step 3 completed by bob (3/26 steps)" {
+		t.Errorf("json text = %q", got)
+	}
+}
+
+func TestSend_CoverageThresholds(t *testing.T) {
+	rec := &recorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+	n := &Notifier{Hooks: []config.Notify{{URL: srv.URL, Format: "json", Thresholds: []int{50, 100}}}}
+
+	for _, step := range [][2]int{{10, 40}, {40, 80}, {80, 90}, {90, 100}} {
+		if err := n.Send(context.Background(), Event{Kind: Coverage, Project: "app", Previous: step[0], Coverage: step[1]}); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+	if len(rec.bodies) != 2 {
+		t.Fatalf("received %d messages, want 2 (50%% and 100%%)", len(rec.bodies))
+	}
+	if rec.bodies[0]["threshold"] != float64(50) || rec.bodies[1]["threshold"] != float64(100) {
+		t.Errorf("thresholds = %v, %v", rec.bodies[0]["threshold"], rec.bodies[1]["threshold"])
+	}
+}
+
+func TestSend_ReportsFailures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusForbidden)
+	}))
+	defer srv.Close()
+	n := &Notifier{Hooks: []config.Notify{{URL: srv.URL, Format: "json"}}}
+	if err := n.Send(context.Background(), Event{Kind: Test, Project: "app"}); err == nil {
+		t.Error("expected error for rejected notification")
+	}
+}
+
+func TestCrossed(t *testing.T) {
+	tests := []struct {
+		before, after, want int
+	}{
+		{0, 24, 0},
+		{0, 60, 50},
+		{50, 60, 0},
+		{49, 50, 50},
+		{60, 40, 0},
+		{0, 100, 100},
+	}
+	for _, tt := range tests {
+		if got := Crossed(DefaultThresholds, tt.before, tt.after); got != tt.want {
+			t.Errorf("Crossed(%d, %d) = %d, want %d", tt.before, tt.after, got, tt.want)
+		}
+	}
+}
+
+func TestCoverageState(t *testing.T) {
+	dir := t.TempDir()
+	if got := LastCoverage(dir); got != 0 {
+		t.Errorf("LastCoverage without state = %d", got)
+	}
+	if err := SaveCoverage(dir, 42); err != nil {
+		t.Fatal(err)
+	}
+	if got := LastCoverage(dir); got != 42 {
+		t.Errorf("LastCoverage = %d, want 42", got)
+	}
+}
@@ -0,0 +1,68 @@
+// Package npm is a minimal client for the npm registry (https://registry.npmjs.org).
+package npm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/stephan/rinku/internal/httpclient"
+)
+
+const (
+	DefaultBaseURL = "https://registry.npmjs.org"
+
+	userAgent = "rinku (https://github.com/marvai-dev/rinku)"
+)
+
+// ErrNotFound is returned when a package does not exist on npm.
+var ErrNotFound = errors.New("package not found")
+
+// Client queries the npm registry.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// New returns a client for the public npm registry.
+func New() *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: httpclient.Default(),
+	}
+}
+
+// LatestVersion returns the version of a package tagged latest.
+func (c *Client) LatestVersion(ctx context.Context, name string) (string, error) {
+	// scoped names keep their @ but escape the slash: @scope%2fname
+	u := c.BaseURL + "/" + strings.ReplaceAll(name, "/", "%2f") + "/latest"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetching package %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("fetching package %s: %w", name, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetching package %s: unexpected status: %s", name, resp.Status)
+	}
+	var body struct {
+		Version string `json:"version"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding package %s: %w", name, err)
+	}
+	return body.Version, nil
+}
@@ -0,0 +1,38 @@
+package npm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLatestVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/express/latest":
+			w.Write([]byte(`{"name": "express", "version": "4.21.1"}`))
+		case "/@nestjs%2fcore/latest":
+			w.Write([]byte(`{"name": "@nestjs/core", "version": "10.4.5"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	c := New()
+	c.BaseURL = srv.URL
+
+	for name, want := range map[string]string{"express": "4.21.1", "@nestjs/core": "10.4.5"} {
+		v, err := c.LatestVersion(context.Background(), name)
+		if err != nil {
+			t.Fatalf("LatestVersion(%s) failed: %v", name, err)
+		}
+		if v != want {
+			t.Errorf("LatestVersion(%s) = %q, want %q", name, v, want)
+		}
+	}
+	if _, err := c.LatestVersion(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("LatestVersion() error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,151 @@
+// Package orgscan scans many repositories' go.mod files and ranks them by migration readiness.
+package orgscan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/gomod"
+)
+
+// FetchFunc returns the go.mod contents of a repository.
+type FetchFunc func(ctx context.Context, repo string) ([]byte, error)
+
+// Result is the scan outcome for one repository.
+type Result struct {
+	Repo     string
+	Module   string
+	Direct   int
+	Mapped   int
+	Unmapped []string // module paths without a Rust mapping
+	Err      error
+}
+
+// Readiness returns the fraction of direct dependencies with a Rust mapping.
+// A repository without dependencies is fully ready.
+func (r *Result) Readiness() float64 {
+	if r.Direct == 0 {
+		return 1
+	}
+	return float64(r.Mapped) / float64(r.Direct)
+}
+
+// ParseRepoList reads one repository per line, ignoring blank lines and # comments.
+func ParseRepoList(r io.Reader) ([]string, error) {
+	var repos []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			repos = append(repos, line)
+		}
+	}
+	return repos, scanner.Err()
+}
+
+// Scan fetches and maps each repository's go.mod using up to jobs concurrent workers.
+// Results are returned in input order; failures are recorded in Result.Err.
+func Scan(ctx context.Context, repos []string, fetch FetchFunc, lookup cargo.Lookup, unsafe bool, jobs int) []Result {
+	if jobs < 1 {
+		jobs = 1
+	}
+	results := make([]Result, len(repos))
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				results[i] = scanOne(ctx, repos[i], fetch, lookup, unsafe)
+			}
+		}()
+	}
+	for i := range repos {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+	return results
+}
+
+func scanOne(ctx context.Context, repo string, fetch FetchFunc, lookup cargo.Lookup, unsafe bool) Result {
+	result := Result{Repo: repo}
+	data, err := fetch(ctx, repo)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	parsed, err := gomod.ParseReader(bytes.NewReader(data))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	deps := parsed.DirectDependencies()
+	mapping := cargo.MapDependencies(deps, lookup, unsafe)
+	result.Module = parsed.Module
+	result.Direct = len(deps)
+	result.Mapped = len(mapping.Mapped)
+	for _, u := range mapping.Unmapped {
+		result.Unmapped = append(result.Unmapped, u.GoDep.Path)
+	}
+	return result
+}
+
+// Rank sorts successful results by readiness (highest first), then by fewest unmapped
+// dependencies and repository name. Failed results are moved to the end.
+func Rank(results []Result) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := &results[i], &results[j]
+		if (a.Err == nil) != (b.Err == nil) {
+			return a.Err == nil
+		}
+		if a.Readiness() != b.Readiness() {
+			return a.Readiness() > b.Readiness()
+		}
+		if len(a.Unmapped) != len(b.Unmapped) {
+			return len(a.Unmapped) < len(b.Unmapped)
+		}
+		return a.Repo < b.Repo
+	})
+}
+
+// Blocker is an unmapped dependency and the repositories that use it.
+type Blocker struct {
+	Module string
+	Repos  []string
+}
+
+// CommonBlockers returns the unmapped dependencies shared by the most repositories,
+// at most limit entries (all if limit <= 0).
+func CommonBlockers(results []Result, limit int) []Blocker {
+	byModule := make(map[string][]string)
+	for _, r := range results {
+		for _, m := range r.Unmapped {
+			byModule[m] = append(byModule[m], r.Repo)
+		}
+	}
+
+	blockers := make([]Blocker, 0, len(byModule))
+	for m, repos := range byModule {
+		sort.Strings(repos)
+		blockers = append(blockers, Blocker{Module: m, Repos: repos})
+	}
+	sort.Slice(blockers, func(i, j int) bool {
+		if len(blockers[i].Repos) != len(blockers[j].Repos) {
+			return len(blockers[i].Repos) > len(blockers[j].Repos)
+		}
+		return blockers[i].Module < blockers[j].Module
+	})
+	if limit > 0 && len(blockers) > limit {
+		blockers = blockers[:limit]
+	}
+	return blockers
+}
@@ -0,0 +1,110 @@
+package orgscan
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stephan/rinku/internal/types"
+)
+
+type fakeLookup map[string][]string
+
+func (f fakeLookup) Lookup(sourceURL, targetLang string, unsafe bool) []string {
+	return f[sourceURL]
+}
+
+func (f fakeLookup) CrateName(rustURL string) string { return "" }
+
+func (f fakeLookup) RequiredDeps(sourceURL, targetLang string) []types.RequiredDep { return nil }
+
+var testLookup = fakeLookup{
+	"https://github.com/spf13/cobra":     {"https://github.com/clap-rs/clap"},
+	"https://github.com/sirupsen/logrus": {"https://github.com/tokio-rs/tracing"},
+}
+
+var testGoMods = map[string]string{
+	"acme/ready": "module acme/ready\n\nrequire github.com/spf13/cobra v1.8.0\n",
+	"acme/half": `module acme/half
+
+require (
+	github.com/spf13/cobra v1.8.0
+	example.com/internal/auth v0.3.0
+)
+`,
+	"acme/legacy": `module acme/legacy
+
+require (
+	github.com/sirupsen/logrus v1.9.3
+	example.com/internal/auth v0.3.0
+	example.com/internal/billing v1.0.0
+)
+`,
+}
+
+func fakeFetch(ctx context.Context, repo string) ([]byte, error) {
+	data, ok := testGoMods[repo]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return []byte(data), nil
+}
+
+func TestParseRepoList(t *testing.T) {
+	repos, err := ParseRepoList(strings.NewReader("# platform repos\nacme/api\n\n  https://github.com/acme/web  # frontend\n"))
+	if err != nil {
+		t.Fatalf("ParseRepoList failed: %v", err)
+	}
+	if len(repos) != 2 || repos[0] != "acme/api" || repos[1] != "https://github.com/acme/web" {
+		t.Errorf("repos = %q", repos)
+	}
+}
+
+func TestScanAndRank(t *testing.T) {
+	repos := []string{"acme/legacy", "acme/missing", "acme/half", "acme/ready"}
+	results := Scan(context.Background(), repos, fakeFetch, testLookup, false, 2)
+
+	if len(results) != len(repos) {
+		t.Fatalf("len(results) = %d, want %d", len(results), len(repos))
+	}
+	for i, r := range results {
+		if r.Repo != repos[i] {
+			t.Errorf("results[%d].Repo = %q, want input order %q", i, r.Repo, repos[i])
+		}
+	}
+
+	Rank(results)
+	want := []string{"acme/ready", "acme/half", "acme/legacy", "acme/missing"}
+	for i, repo := range want {
+		if results[i].Repo != repo {
+			t.Errorf("rank %d = %q, want %q", i+1, results[i].Repo, repo)
+		}
+	}
+	if results[3].Err == nil {
+		t.Error("missing repository should have an error")
+	}
+	if got := results[2].Readiness(); math.Abs(got-1.0/3) > 1e-9 {
+		t.Errorf("legacy readiness = %v, want 1/3", got)
+	}
+}
+
+func TestCommonBlockers(t *testing.T) {
+	results := Scan(context.Background(), []string{"acme/legacy", "acme/half", "acme/ready"}, fakeFetch, testLookup, false, 1)
+
+	blockers := CommonBlockers(results, 1)
+	if len(blockers) != 1 {
+		t.Fatalf("len(blockers) = %d, want 1", len(blockers))
+	}
+	if blockers[0].Module != "example.com/internal/auth" || len(blockers[0].Repos) != 2 {
+		t.Errorf("blockers[0] = %+v, want auth used by 2 repos", blockers[0])
+	}
+}
+
+func TestReadiness_NoDependencies(t *testing.T) {
+	r := Result{}
+	if r.Readiness() != 1 {
+		t.Errorf("Readiness() = %v, want 1", r.Readiness())
+	}
+}
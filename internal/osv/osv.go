@@ -0,0 +1,120 @@
+// Package osv is a minimal client for the OSV vulnerability database (https://osv.dev).
+package osv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stephan/rinku/internal/httpclient"
+)
+
+const (
+	DefaultBaseURL = "https://api.osv.dev/v1"
+
+	userAgent = "rinku (https://github.com/marvai-dev/rinku)"
+)
+
+// Ecosystem names as used by OSV.
+const (
+	EcosystemGo       = "Go"
+	EcosystemCratesIO = "crates.io"
+)
+
+// Vulnerability is an advisory affecting a package version.
+type Vulnerability struct {
+	ID       string    `json:"id"`
+	Summary  string    `json:"summary"`
+	Aliases  []string  `json:"aliases"`
+	Modified time.Time `json:"modified"`
+}
+
+// Client queries the OSV API.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// New returns a client for the public OSV API.
+func New() *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: httpclient.Default(),
+	}
+}
+
+type queryRequest struct {
+	Version   string       `json:"version,omitempty"`
+	Package   queryPackage `json:"package"`
+	PageToken string       `json:"page_token,omitempty"`
+}
+
+type queryPackage struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+type queryResponse struct {
+	Vulns         []Vulnerability `json:"vulns"`
+	NextPageToken string          `json:"next_page_token"`
+}
+
+// Query returns the advisories affecting a package at the given version.
+// Go versions may carry the leading "v" used in go.mod.
+func (c *Client) Query(ctx context.Context, ecosystem, name, version string) ([]Vulnerability, error) {
+	req := queryRequest{
+		Version: normalizeVersion(ecosystem, version),
+		Package: queryPackage{Name: name, Ecosystem: ecosystem},
+	}
+
+	var vulns []Vulnerability
+	for {
+		var resp queryResponse
+		if err := c.post(ctx, "/query", req, &resp); err != nil {
+			return nil, fmt.Errorf("querying advisories for %s: %w", name, err)
+		}
+		vulns = append(vulns, resp.Vulns...)
+		if resp.NextPageToken == "" {
+			return vulns, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
+
+// normalizeVersion converts a version to the form OSV stores for the ecosystem.
+func normalizeVersion(ecosystem, version string) string {
+	if ecosystem == EcosystemGo {
+		version = strings.TrimPrefix(version, "v")
+		version = strings.TrimSuffix(version, "+incompatible")
+	}
+	return version
+}
+
+func (c *Client) post(ctx context.Context, path string, body, v any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Content-Type", "application/json")
+
+	// Queries do not change anything, so they are retried like GETs
+	resp, err := c.HTTPClient.Do(httpclient.Retryable(req))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
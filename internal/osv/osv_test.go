@@ -0,0 +1,91 @@
+package osv
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c := New()
+	c.BaseURL = srv.URL
+	return c
+}
+
+func TestQuery(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/query" {
+			t.Errorf("got %s %s, want POST /query", r.Method, r.URL.Path)
+		}
+		var req queryRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decoding request: %v", err)
+		}
+		if req.Package.Ecosystem != EcosystemGo || req.Package.Name != "github.com/gin-gonic/gin" {
+			t.Errorf("package = %+v", req.Package)
+		}
+		if req.Version != "1.6.0" {
+			t.Errorf("version = %q, want 1.6.0 (leading v stripped)", req.Version)
+		}
+		w.Write([]byte(`{"vulns": [{"id": "GO-2020-0001", "summary": "Arbitrary log line injection"}]}`))
+	})
+
+	vulns, err := c.Query(context.Background(), EcosystemGo, "github.com/gin-gonic/gin", "v1.6.0")
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(vulns) != 1 || vulns[0].ID != "GO-2020-0001" {
+		t.Errorf("vulns = %+v", vulns)
+	}
+}
+
+func TestQuery_Paginates(t *testing.T) {
+	calls := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var req queryRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.PageToken == "" {
+			w.Write([]byte(`{"vulns": [{"id": "RUSTSEC-1"}], "next_page_token": "p2"}`))
+			return
+		}
+		w.Write([]byte(`{"vulns": [{"id": "RUSTSEC-2"}]}`))
+	})
+
+	vulns, err := c.Query(context.Background(), EcosystemCratesIO, "hyper", "0.14.0")
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if calls != 2 || len(vulns) != 2 {
+		t.Errorf("calls = %d, vulns = %d, want 2 and 2", calls, len(vulns))
+	}
+}
+
+func TestQuery_NoAdvisories(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+
+	vulns, err := c.Query(context.Background(), EcosystemCratesIO, "clap", "4.5.0")
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(vulns) != 0 {
+		t.Errorf("vulns = %+v, want none", vulns)
+	}
+}
+
+func TestQuery_ServerError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	if _, err := c.Query(context.Background(), EcosystemGo, "x", "v1.0.0"); err == nil {
+		t.Error("expected error for server failure")
+	}
+}
@@ -0,0 +1,78 @@
+// Package pattern matches requirement paths against glob patterns.
+//
+// Patterns are "/"-separated like requirement paths and match a prefix of the path:
+// api/cli matches api/cli and api/cli/flags. Within a segment, * matches any run of
+// characters, ? one character and [a-z] or [^0-9] a character class, as in path.Match.
+// A ** segment matches any number of segments, including none, so api/**/routes
+// matches api/routes and api/v1/admin/routes.
+package pattern
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Match reports whether pattern matches path or one of its parent paths. Malformed
+// patterns match nothing; use Validate to report them.
+func Match(pattern, p string) bool {
+	_, ok := MatchPrefix(pattern, p)
+	return ok
+}
+
+// MatchPrefix returns the shortest prefix of path that pattern matches, e.g.
+// api/v1/routes for api/**/routes and api/v1/routes/GET/users.
+func MatchPrefix(pattern, p string) (string, bool) {
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" {
+		return "", true
+	}
+	segs := strings.Split(strings.Trim(p, "/"), "/")
+	n, ok := match(strings.Split(pattern, "/"), segs, 0)
+	if !ok {
+		return "", false
+	}
+	return strings.Join(segs[:n], "/"), true
+}
+
+// match matches pattern segments against segs[i:] and returns the number of path
+// segments consumed by the shortest match.
+func match(pats, segs []string, i int) (int, bool) {
+	if len(pats) == 0 {
+		return i, true
+	}
+	if pats[0] == "**" {
+		for j := i; j <= len(segs); j++ {
+			if n, ok := match(pats[1:], segs, j); ok {
+				return n, true
+			}
+		}
+		return 0, false
+	}
+	if i == len(segs) {
+		return 0, false
+	}
+	if ok, err := path.Match(pats[0], segs[i]); err != nil || !ok {
+		return 0, false
+	}
+	return match(pats[1:], segs, i+1)
+}
+
+// HasMeta reports whether pattern contains wildcards or character classes.
+func HasMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// Validate returns an error for a malformed pattern, such as an unclosed [ class or
+// ** combined with other characters in a segment.
+func Validate(pattern string) error {
+	for _, seg := range strings.Split(strings.Trim(pattern, "/"), "/") {
+		if strings.Contains(seg, "**") && seg != "**" {
+			return fmt.Errorf("invalid pattern %q: ** must be a whole segment", pattern)
+		}
+		if _, err := path.Match(seg, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package pattern
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"", "api/cli", true},
+		{"api", "api/cli", true},
+		{"api/", "api/cli", true},
+		{"api/cli", "api/cli", true},
+		{"api/cli/flags", "api/cli", false},
+		{"api", "apis/cli", false},
+		{"*/cli", "svc/cli/flags/--port", true},
+		{"*/cli", "svc/api", false},
+		{"svc/cli/flags/--p*", "svc/cli/flags/--port", true},
+		{"svc/cli/flags/--p*", "svc/cli/flags/--host", false},
+		{"api/v?", "api/v1/users", true},
+		{"api/v?", "api/v10/users", false},
+		{"api/v[0-9]/users", "api/v2/users", true},
+		{"api/v[^0-9]/users", "api/v2/users", false},
+		{"api/**/routes", "api/routes", true},
+		{"api/**/routes", "api/v1/routes", true},
+		{"api/**/routes", "api/v1/admin/routes/GET/users", true},
+		{"api/**/routes", "api/v1/admin", false},
+		{"**/observability", "svc/observability/metrics/x", true},
+		{"**", "anything/at/all", true},
+		{"**/a/**/b", "x/a/y/z/b", true},
+		{"**/a/**/b", "x/a/y/z", false},
+		{"api/[", "api/[", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestMatchPrefix(t *testing.T) {
+	tests := []struct{ pattern, path, want string }{
+		{"*/cli", "svc/cli/flags/--port", "svc/cli"},
+		{"api/**/routes", "api/v1/routes/GET/users", "api/v1/routes"},
+		{"api/**", "api/v1/routes", "api"},
+		{"", "api/cli", ""},
+	}
+	for _, tt := range tests {
+		got, ok := MatchPrefix(tt.pattern, tt.path)
+		if !ok || got != tt.want {
+			t.Errorf("MatchPrefix(%q, %q) = %q, %v, want %q", tt.pattern, tt.path, got, ok, tt.want)
+		}
+	}
+}
+
+func TestHasMeta(t *testing.T) {
+	for p, want := range map[string]bool{"api/cli": false, "*/cli": true, "api/v?": true, "api/[ab]": true, "api/**/x": true} {
+		if got := HasMeta(p); got != want {
+			t.Errorf("HasMeta(%q) = %v, want %v", p, got, want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, p := range []string{"api/cli", "*/cli", "api/**/routes", "api/v[0-9]"} {
+		if err := Validate(p); err != nil {
+			t.Errorf("Validate(%q) = %v", p, err)
+		}
+	}
+	for _, p := range []string{"api/[", "api/a**/routes"} {
+		if err := Validate(p); err == nil {
+			t.Errorf("Validate(%q) = nil, want error", p)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+// Package phases groups a Go project's dependencies and packages into migration phases,
+// leaf utilities first and frameworks last.
+package phases
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+// Tier orders dependencies by how much of the program is built on top of them.
+type Tier int
+
+const (
+	TierUtility Tier = iota
+	TierInfrastructure
+	TierFramework
+)
+
+// Name returns the phase title for the tier.
+func (t Tier) Name() string {
+	switch t {
+	case TierUtility:
+		return "Utilities"
+	case TierInfrastructure:
+		return "Infrastructure"
+	default:
+		return "Frameworks and entry points"
+	}
+}
+
+// frameworkTags mark libraries that shape the program structure (request handling, CLI
+// parsing, dependency injection, generated code) and are therefore migrated last.
+var frameworkTags = map[string]bool{
+	"web": true, "cli": true, "grpc": true, "graphql": true, "orm": true, "di": true, "async": true,
+}
+
+// infrastructureTags mark libraries that talk to the outside world or configure the
+// program: usually wrapped by application code rather than built upon.
+var infrastructureTags = map[string]bool{
+	"sql": true, "config": true, "logging": true, "observability": true, "cloud": true, "auth": true,
+	"filesystem": true, "container": true, "websocket": true, "templating": true, "http": true,
+	"ai": true, "testing": true,
+}
+
+// Classify returns the tier for a library with the given tags. Libraries without
+// tags are treated as utilities.
+func Classify(tags []string) Tier {
+	tier := TierUtility
+	for _, tag := range tags {
+		switch {
+		case frameworkTags[tag] || strings.HasPrefix(tag, "codegen:"):
+			return TierFramework
+		case infrastructureTags[tag]:
+			tier = TierInfrastructure
+		}
+	}
+	return tier
+}
+
+// Dependency is a direct Go dependency and its Rust crates, empty when unmapped.
+type Dependency struct {
+	GoModule string
+	Crates   []string
+	Tags     []string
+}
+
+// Package is a Go package of the project.
+type Package struct {
+	Dir        string // slash-separated, relative to the module root
+	ImportPath string
+	Main       bool
+	Level      int // longest chain of project imports below this package; 0 for leaves
+}
+
+// Phase is one migration phase.
+type Phase struct {
+	Number       int
+	Tier         Tier
+	Dependencies []Dependency
+	Packages     []Package // dependencies before dependents
+}
+
+// ID returns the step ID of the phase, e.g. "phase-1".
+func (p *Phase) ID() string {
+	return fmt.Sprintf("phase-%d", p.Number)
+}
+
+// Plan is the ordered list of non-empty phases.
+type Plan struct {
+	Module string
+	Phases []Phase
+}
+
+// Build assigns each dependency to the phase of its tier and each package to the latest
+// phase among the dependencies it imports and the packages it imports, so no package is
+// migrated before anything it builds on. Main packages always go into the last tier.
+func Build(module string, files []gosrc.File, deps []Dependency) *Plan {
+	depTier := make(map[string]Tier, len(deps))
+	for _, d := range deps {
+		depTier[d.GoModule] = Classify(d.Tags)
+	}
+
+	pkgs := make(map[string]*Package)
+	internal := make(map[string]map[string]bool) // dir -> imported project dirs
+	external := make(map[string][]string)        // dir -> imported dependency modules
+	for _, f := range files {
+		if f.Test {
+			continue
+		}
+		dir := path.Dir(f.Path)
+		if pkgs[dir] == nil {
+			importPath := module
+			if dir != "." {
+				importPath = module + "/" + dir
+			}
+			pkgs[dir] = &Package{Dir: dir, ImportPath: importPath}
+			internal[dir] = make(map[string]bool)
+		}
+		if f.Package == "main" {
+			pkgs[dir].Main = true
+		}
+		for _, imp := range f.Imports {
+			if imp == module {
+				internal[dir]["."] = true
+			} else if strings.HasPrefix(imp, module+"/") {
+				internal[dir][strings.TrimPrefix(imp, module+"/")] = true
+			} else if mod := owningModule(imp, depTier); mod != "" {
+				external[dir] = append(external[dir], mod)
+			}
+		}
+	}
+
+	tiers := make(map[string]Tier)
+	var visit func(dir string, seen map[string]bool) Tier
+	visit = func(dir string, seen map[string]bool) Tier {
+		if t, ok := tiers[dir]; ok {
+			return t
+		}
+		if seen[dir] {
+			return TierUtility // import cycles do not compile; be defensive anyway
+		}
+		seen[dir] = true
+
+		p := pkgs[dir]
+		tier := TierUtility
+		if p.Main {
+			tier = TierFramework
+		}
+		for _, mod := range external[dir] {
+			tier = max(tier, depTier[mod])
+		}
+		for imp := range internal[dir] {
+			if pkgs[imp] == nil {
+				continue
+			}
+			tier = max(tier, visit(imp, seen))
+			p.Level = max(p.Level, pkgs[imp].Level+1)
+		}
+		tiers[dir] = tier
+		return tier
+	}
+
+	plan := &Plan{Module: module}
+	byTier := make(map[Tier]*Phase)
+	phase := func(t Tier) *Phase {
+		if byTier[t] == nil {
+			byTier[t] = &Phase{Tier: t}
+		}
+		return byTier[t]
+	}
+
+	for _, d := range deps {
+		p := phase(depTier[d.GoModule])
+		p.Dependencies = append(p.Dependencies, d)
+	}
+	dirs := make([]string, 0, len(pkgs))
+	for dir := range pkgs {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		t := visit(dir, make(map[string]bool))
+		p := phase(t)
+		p.Packages = append(p.Packages, *pkgs[dir])
+	}
+
+	for _, t := range []Tier{TierUtility, TierInfrastructure, TierFramework} {
+		p := byTier[t]
+		if p == nil {
+			continue
+		}
+		sort.Slice(p.Dependencies, func(i, j int) bool { return p.Dependencies[i].GoModule < p.Dependencies[j].GoModule })
+		sort.SliceStable(p.Packages, func(i, j int) bool { return p.Packages[i].Level < p.Packages[j].Level })
+		p.Number = len(plan.Phases) + 1
+		plan.Phases = append(plan.Phases, *p)
+	}
+	return plan
+}
+
+// owningModule returns the dependency module that provides an import path.
+func owningModule(imp string, modules map[string]Tier) string {
+	for p := imp; p != "." && p != "/"; p = path.Dir(p) {
+		if _, ok := modules[p]; ok {
+			return p
+		}
+	}
+	return ""
+}
+
+// Markdown writes the plan as a multistep prompt with one "# Step phase-N" section per
+// phase, so it can be walked like the built-in migration workflow.
+func (p *Plan) Markdown(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("# Introduction\n\n")
+	fmt.Fprintf(&sb, "Phased migration plan for %s.\n\n", p.Module)
+	sb.WriteString("Work through the phases in order. Each phase only builds on crates and modules ported in earlier phases, so the Rust project compiles and its tests pass at the end of every phase.\n")
+
+	for i, phase := range p.Phases {
+		fmt.Fprintf(&sb, "\n# Step %s\n\n", phase.ID())
+		fmt.Fprintf(&sb, "## Phase %d: %s\n\n", phase.Number, phase.Tier.Name())
+
+		if len(phase.Dependencies) > 0 {
+			sb.WriteString("Add the Rust equivalents of these dependencies:\n\n")
+			for _, d := range phase.Dependencies {
+				if len(d.Crates) == 0 {
+					fmt.Fprintf(&sb, "- %s (no Rust mapping, decide on a replacement first)\n", d.GoModule)
+					continue
+				}
+				fmt.Fprintf(&sb, "- %s → %s\n", d.GoModule, strings.Join(d.Crates, ", "))
+			}
+			sb.WriteString("\n")
+		}
+
+		if len(phase.Packages) > 0 {
+			sb.WriteString("Port these packages, in order:\n\n")
+			for _, pkg := range phase.Packages {
+				suffix := ""
+				if pkg.Main {
+					suffix = " (binary)"
+				}
+				fmt.Fprintf(&sb, "- %s%s\n", pkg.ImportPath, suffix)
+			}
+			sb.WriteString("\n")
+		}
+
+		if i+1 < len(p.Phases) {
+			fmt.Fprintf(&sb, "When this phase builds and its tests pass, continue with %s.\n", p.Phases[i+1].ID())
+		} else {
+			sb.WriteString("This is the last phase. When it builds and its tests pass, the migration is complete.\n")
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,106 @@
+package phases
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stephan/rinku/internal/gosrc"
+	"github.com/stephan/rinku/internal/multistep"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		tags []string
+		want Tier
+	}{
+		{nil, TierUtility},
+		{[]string{"sql"}, TierInfrastructure},
+		{[]string{"sql", "orm"}, TierFramework},
+		{[]string{"codegen:sqlc"}, TierFramework},
+		{[]string{"unknown"}, TierUtility},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.tags); got != tt.want {
+			t.Errorf("Classify(%v) = %v, want %v", tt.tags, got, tt.want)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	files := []gosrc.File{
+		{Path: "internal/strutil/strutil.go", Package: "strutil", Imports: []string{"strings", "github.com/google/uuid"}},
+		{Path: "internal/store/store.go", Package: "store", Imports: []string{"example.com/app/internal/strutil", "github.com/jackc/pgx/v5/pgxpool"}},
+		{Path: "internal/model/model.go", Package: "model", Imports: []string{"example.com/app/internal/strutil"}},
+		{Path: "internal/api/api.go", Package: "api", Imports: []string{"example.com/app/internal/store", "github.com/labstack/echo/v4"}},
+		{Path: "main.go", Package: "main", Imports: []string{"example.com/app/internal/model"}},
+		{Path: "internal/store/store_test.go", Package: "store", Test: true, Imports: []string{"github.com/labstack/echo/v4"}},
+	}
+	deps := []Dependency{
+		{GoModule: "github.com/labstack/echo/v4", Crates: []string{"axum"}, Tags: []string{"web"}},
+		{GoModule: "github.com/jackc/pgx/v5", Crates: []string{"sqlx"}, Tags: []string{"sql"}},
+		{GoModule: "github.com/google/uuid", Crates: []string{"uuid"}},
+		{GoModule: "github.com/acme/internal-sdk"},
+	}
+
+	plan := Build("example.com/app", files, deps)
+	if len(plan.Phases) != 3 {
+		t.Fatalf("len(Phases) = %d, want 3", len(plan.Phases))
+	}
+
+	want := []struct {
+		deps []string
+		pkgs []string
+	}{
+		{[]string{"github.com/acme/internal-sdk", "github.com/google/uuid"}, []string{"internal/strutil", "internal/model"}},
+		{[]string{"github.com/jackc/pgx/v5"}, []string{"internal/store"}},
+		{[]string{"github.com/labstack/echo/v4"}, []string{".", "internal/api"}},
+	}
+	for i, w := range want {
+		phase := plan.Phases[i]
+		if phase.Number != i+1 {
+			t.Errorf("phase %d Number = %d", i, phase.Number)
+		}
+		var gotDeps, gotPkgs []string
+		for _, d := range phase.Dependencies {
+			gotDeps = append(gotDeps, d.GoModule)
+		}
+		for _, p := range phase.Packages {
+			gotPkgs = append(gotPkgs, p.Dir)
+		}
+		if strings.Join(gotDeps, " ") != strings.Join(w.deps, " ") {
+			t.Errorf("phase %d deps = %v, want %v", i+1, gotDeps, w.deps)
+		}
+		if strings.Join(gotPkgs, " ") != strings.Join(w.pkgs, " ") {
+			t.Errorf("phase %d packages = %v, want %v", i+1, gotPkgs, w.pkgs)
+		}
+	}
+}
+
+func TestMarkdown_ParsesAsSteps(t *testing.T) {
+	plan := Build("example.com/app", []gosrc.File{
+		{Path: "util/util.go", Package: "util"},
+		{Path: "main.go", Package: "main", Imports: []string{"example.com/app/util"}},
+	}, []Dependency{{GoModule: "github.com/google/uuid", Crates: []string{"uuid"}}})
+
+	var buf bytes.Buffer
+	if err := plan.Markdown(&buf); err != nil {
+		t.Fatalf("Markdown failed: %v", err)
+	}
+
+	prompt, err := multistep.Parse(buf.String())
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got := strings.Join(prompt.Steps(), ","); got != "phase-1,phase-2" {
+		t.Fatalf("Steps() = %s", got)
+	}
+	first, _ := prompt.GetStep("phase-1")
+	if !strings.Contains(first, "github.com/google/uuid → uuid") || !strings.Contains(first, "continue with phase-2") {
+		t.Errorf("phase-1 content:\n%s", first)
+	}
+	last, _ := prompt.GetStep("phase-2")
+	if !strings.Contains(last, "example.com/app (binary)") {
+		t.Errorf("phase-2 content:\n%s", last)
+	}
+}
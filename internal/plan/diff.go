@@ -0,0 +1,152 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change.
+const contextLines = 3
+
+type editKind byte
+
+const (
+	editEqual  editKind = ' '
+	editDelete editKind = '-'
+	editInsert editKind = '+'
+)
+
+type edit struct {
+	kind editKind
+	line string
+}
+
+// Diff returns a unified diff between old and new text. It returns "" if both are equal.
+func Diff(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+	edits := lineEdits(splitLines(oldText), splitLines(newText))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range hunks(edits) {
+		sb.WriteString(h)
+	}
+	return sb.String()
+}
+
+// splitLines splits text into newline-terminated lines. A missing final newline is
+// added so it does not show up as a change on its own.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if last := len(lines) - 1; lines[last] == "" {
+		lines = lines[:last]
+	} else {
+		lines[last] += "\n"
+	}
+	return lines
+}
+
+// lineEdits computes a line-level edit script from the longest common subsequence.
+func lineEdits(a, b []string) []edit {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var edits []edit
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, edit{editEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, edit{editDelete, a[i]})
+			i++
+		default:
+			edits = append(edits, edit{editInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		edits = append(edits, edit{editDelete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		edits = append(edits, edit{editInsert, b[j]})
+	}
+	return edits
+}
+
+// hunks groups edits into unified diff hunks with surrounding context.
+func hunks(edits []edit) []string {
+	var result []string
+	for start := 0; start < len(edits); {
+		// Find the next change.
+		first := start
+		for first < len(edits) && edits[first].kind == editEqual {
+			first++
+		}
+		if first == len(edits) {
+			break
+		}
+
+		// Extend the hunk while changes are within 2*contextLines of each other.
+		last := first
+		for k := first; k < len(edits); k++ {
+			if edits[k].kind != editEqual {
+				last = k
+			} else if k-last > 2*contextLines {
+				break
+			}
+		}
+
+		from := max(first-contextLines, start)
+		to := min(last+contextLines+1, len(edits))
+
+		oldStart, newStart := 1, 1
+		for _, e := range edits[:from] {
+			if e.kind != editInsert {
+				oldStart++
+			}
+			if e.kind != editDelete {
+				newStart++
+			}
+		}
+		oldCount, newCount := 0, 0
+		var body strings.Builder
+		for _, e := range edits[from:to] {
+			if e.kind != editInsert {
+				oldCount++
+			}
+			if e.kind != editDelete {
+				newCount++
+			}
+			body.WriteByte(byte(e.kind))
+			body.WriteString(e.line)
+		}
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+		result = append(result, fmt.Sprintf("@@ -%d,%d +%d,%d @@\n%s", oldStart, oldCount, newStart, newCount, body.String()))
+		start = to
+	}
+	return result
+}
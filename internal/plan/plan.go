@@ -0,0 +1,181 @@
+// Package plan records file changes computed by `rinku plan` so they can be reviewed
+// and later executed by `rinku apply`.
+package plan
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/natefinch/atomic"
+	"github.com/stephan/rinku/internal/progress"
+)
+
+const PlanFile = "plan.json"
+
+// ActionKind describes what applying an action does to its file.
+type ActionKind string
+
+const (
+	ActionCreate ActionKind = "create"
+	ActionUpdate ActionKind = "update"
+	ActionNoop   ActionKind = "noop"
+)
+
+// Action is one file write in a plan.
+type Action struct {
+	Path     string     `json:"path"` // relative to the project directory
+	Kind     ActionKind `json:"kind"`
+	Content  string     `json:"content"`
+	BaseHash string     `json:"base_hash,omitempty"` // sha256 of the file when planned; empty for create
+	current  string     // file contents when planned
+}
+
+// Plan is a reviewed set of file writes.
+type Plan struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Source    string    `json:"source"` // go.mod the plan was computed from
+	Actions   []Action  `json:"actions"`
+}
+
+const currentVersion = 1
+
+// New returns an empty plan for the given go.mod.
+func New(source string) *Plan {
+	return &Plan{Version: currentVersion, CreatedAt: time.Now(), Source: source}
+}
+
+// Path returns the path to the plan file for a project directory.
+func Path(projectDir string) string {
+	return filepath.Join(projectDir, progress.ProgressDir, PlanFile)
+}
+
+// Add plans writing content to relPath, comparing against the file's current contents.
+func (p *Plan) Add(projectDir, relPath string, content []byte) error {
+	a := Action{Path: filepath.ToSlash(relPath), Content: string(content)}
+	existing, err := os.ReadFile(filepath.Join(projectDir, relPath)) //#nosec G304 -- path validated by the caller
+	switch {
+	case os.IsNotExist(err):
+		a.Kind = ActionCreate
+	case err != nil:
+		return fmt.Errorf("reading %s: %w", relPath, err)
+	default:
+		a.current = string(existing)
+		a.BaseHash = hash(existing)
+		a.Kind = ActionUpdate
+		if bytes.Equal(existing, content) {
+			a.Kind = ActionNoop
+		}
+	}
+	p.Actions = append(p.Actions, a)
+	return nil
+}
+
+// Diff returns a unified diff of the action against the file as it was when planned.
+func (a *Action) Diff() string {
+	oldName := "a/" + a.Path
+	if a.Kind == ActionCreate {
+		oldName = "/dev/null"
+	}
+	return Diff(oldName, "b/"+a.Path, a.current, a.Content)
+}
+
+// Changes returns the number of actions that modify files.
+func (p *Plan) Changes() int {
+	n := 0
+	for _, a := range p.Actions {
+		if a.Kind != ActionNoop {
+			n++
+		}
+	}
+	return n
+}
+
+// Load reads the saved plan. Returns nil, nil if no plan exists.
+func Load(projectDir string) (*Plan, error) {
+	data, err := os.ReadFile(Path(projectDir)) //#nosec G304 -- projectDir from os.Getwd(), not user input
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading plan: %w", err)
+	}
+
+	var p Plan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parsing plan: %w", err)
+	}
+	return &p, nil
+}
+
+// Save atomically writes the plan file.
+func (p *Plan) Save(projectDir string) error {
+	dir := filepath.Join(projectDir, progress.ProgressDir)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("creating %s directory: %w", progress.ProgressDir, err)
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling plan: %w", err)
+	}
+	return atomic.WriteFile(Path(projectDir), bytes.NewReader(append(data, '\n')))
+}
+
+// Delete removes the plan file.
+func Delete(projectDir string) error {
+	if err := os.Remove(Path(projectDir)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Apply executes the plan's actions. It first checks that no target file changed since
+// the plan was made, so a stale plan never overwrites newer edits.
+func (p *Plan) Apply(projectDir string) error {
+	for _, a := range p.Actions {
+		if err := a.checkBase(projectDir); err != nil {
+			return err
+		}
+	}
+	for _, a := range p.Actions {
+		if a.Kind == ActionNoop {
+			continue
+		}
+		path := filepath.Join(projectDir, filepath.FromSlash(a.Path))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			return fmt.Errorf("creating directory for %s: %w", a.Path, err)
+		}
+		if err := atomic.WriteFile(path, bytes.NewReader([]byte(a.Content))); err != nil {
+			return fmt.Errorf("writing %s: %w", a.Path, err)
+		}
+	}
+	return nil
+}
+
+func (a *Action) checkBase(projectDir string) error {
+	existing, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(a.Path))) //#nosec G304 -- path from a plan created in this project
+	switch {
+	case os.IsNotExist(err):
+		if a.Kind != ActionCreate {
+			return fmt.Errorf("%s was removed since the plan was made; run rinku plan again", a.Path)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("reading %s: %w", a.Path, err)
+	case a.Kind == ActionCreate || hash(existing) != a.BaseHash:
+		return fmt.Errorf("%s changed since the plan was made; run rinku plan again", a.Path)
+	}
+	return nil
+}
+
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,143 @@
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	oldText := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	newText := "a\nb\nc\nd\nE\nf\ng\nh\ni\nj\nk\n"
+
+	got := Diff("a/x", "b/x", oldText, newText)
+	want := `--- a/x
++++ b/x
+@@ -2,9 +2,10 @@
+ b
+ c
+ d
+-e
++E
+ f
+ g
+ h
+ i
+ j
++k
+`
+	if got != want {
+		t.Errorf("Diff() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestDiff_SeparateHunks(t *testing.T) {
+	var oldLines, newLines []string
+	for i := 0; i < 20; i++ {
+		line := string(rune('a' + i))
+		oldLines = append(oldLines, line)
+		if i == 1 || i == 18 {
+			line = strings.ToUpper(line)
+		}
+		newLines = append(newLines, line)
+	}
+
+	got := Diff("a/x", "b/x", strings.Join(oldLines, "\n")+"\n", strings.Join(newLines, "\n")+"\n")
+	if n := strings.Count(got, "@@ -"); n != 2 {
+		t.Errorf("got %d hunks, want 2:\n%s", n, got)
+	}
+	if !strings.Contains(got, "@@ -1,5 +1,5 @@") || !strings.Contains(got, "@@ -16,5 +16,5 @@") {
+		t.Errorf("unexpected hunk headers:\n%s", got)
+	}
+}
+
+func TestDiff_Create(t *testing.T) {
+	got := Diff("/dev/null", "b/x", "", "one\ntwo\n")
+	if !strings.Contains(got, "@@ -0,0 +1,2 @@\n+one\n+two\n") {
+		t.Errorf("Diff() =\n%s", got)
+	}
+}
+
+func TestDiff_Equal(t *testing.T) {
+	if got := Diff("a", "b", "same\n", "same\n"); got != "" {
+		t.Errorf("Diff() = %q, want empty", got)
+	}
+}
+
+func TestPlan_AddSaveApply(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte("old\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	p := New("go.mod")
+	if err := p.Add(dir, "Cargo.toml", []byte("new\n")); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := p.Add(dir, "src/config.rs", []byte("// config\n")); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if p.Actions[0].Kind != ActionUpdate || p.Actions[1].Kind != ActionCreate {
+		t.Errorf("kinds = %s, %s; want update, create", p.Actions[0].Kind, p.Actions[1].Kind)
+	}
+	if !strings.Contains(p.Actions[0].Diff(), "-old\n+new\n") {
+		t.Errorf("Diff() = %q", p.Actions[0].Diff())
+	}
+	if err := p.Save(dir); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := loaded.Apply(dir); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	for path, want := range map[string]string{"Cargo.toml": "new\n", "src/config.rs": "// config\n"} {
+		got, err := os.ReadFile(filepath.Join(dir, path))
+		if err != nil || string(got) != want {
+			t.Errorf("%s = %q, %v; want %q", path, got, err, want)
+		}
+	}
+}
+
+func TestPlan_ApplyRefusesStalePlan(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Cargo.toml")
+	if err := os.WriteFile(path, []byte("old\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	p := New("go.mod")
+	if err := p.Add(dir, "Cargo.toml", []byte("new\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("edited by hand\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Apply(dir); err == nil {
+		t.Fatal("expected Apply to refuse a stale plan")
+	}
+	got, _ := os.ReadFile(path)
+	if string(got) != "edited by hand\n" {
+		t.Errorf("file was overwritten: %q", got)
+	}
+}
+
+func TestPlan_Noop(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte("same\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	p := New("go.mod")
+	if err := p.Add(dir, "Cargo.toml", []byte("same\n")); err != nil {
+		t.Fatal(err)
+	}
+	if p.Actions[0].Kind != ActionNoop || p.Changes() != 0 {
+		t.Errorf("kind = %s, changes = %d; want noop, 0", p.Actions[0].Kind, p.Changes())
+	}
+}
@@ -0,0 +1,41 @@
+package progress
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ActorEnv overrides who is recorded as starting, finishing or noting steps and
+// requirements, e.g. RINKU_USER=agent:claude for an agent sharing a checkout.
+const ActorEnv = "RINKU_USER"
+
+// Actor returns who is changing the migration state in projectDir: the ActorEnv
+// override, the git user of the project, or the login name. It returns "" when
+// none is known.
+func Actor(projectDir string) string {
+	if actor := strings.TrimSpace(os.Getenv(ActorEnv)); actor != "" {
+		return actor
+	}
+	for _, key := range []string{"user.name", "user.email"} {
+		if value := gitConfig(projectDir, key); value != "" {
+			return value
+		}
+	}
+	for _, env := range []string{"USER", "USERNAME"} {
+		if value := strings.TrimSpace(os.Getenv(env)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// gitConfig reads a git config value as seen from dir, or "" if git is unavailable
+// or the key is unset.
+func gitConfig(dir, key string) string {
+	out, err := exec.Command("git", "-C", dir, "config", "--get", key).Output() //#nosec G204 -- fixed git subcommand
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,144 @@
+package progress
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/natefinch/atomic"
+)
+
+// ArtifactsDir is the directory below .rinku holding one directory of artifacts per step.
+const ArtifactsDir = "artifacts"
+
+// artifactTimeFormat prefixes artifact file names so they sort chronologically.
+const artifactTimeFormat = "20060102-150405"
+
+// Artifact is a file recorded for a step: a transcript, a decision, a generated diff.
+type Artifact struct {
+	Step    string
+	Name    string // file name without the timestamp prefix
+	Path    string // relative to the project directory
+	Size    int64
+	AddedAt time.Time
+}
+
+// ArtifactDir returns the directory holding the artifacts of a step.
+func ArtifactDir(projectDir, step string) string {
+	return filepath.Join(projectDir, ProgressDir, ArtifactsDir, step)
+}
+
+// Attach stores content as an artifact of step. The file is named after name with a
+// timestamp prefix, and never replaces an earlier artifact.
+func Attach(projectDir, step, name string, content []byte, now time.Time) (*Artifact, error) {
+	if err := validateStepID(step); err != nil {
+		return nil, err
+	}
+	name = artifactName(name)
+
+	dir := ArtifactDir(projectDir, step)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("creating artifact directory: %w", err)
+	}
+
+	prefix := now.UTC().Format(artifactTimeFormat)
+	file := prefix + "-" + name
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(dir, file)); errors.Is(err, os.ErrNotExist) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("checking artifact: %w", err)
+		}
+		ext := filepath.Ext(name)
+		file = fmt.Sprintf("%s-%s-%d%s", prefix, strings.TrimSuffix(name, ext), i, ext)
+	}
+
+	path := filepath.Join(dir, file)
+	if err := atomic.WriteFile(path, bytes.NewReader(content)); err != nil {
+		return nil, fmt.Errorf("writing artifact: %w", err)
+	}
+	a := &Artifact{
+		Step:    step,
+		Name:    name,
+		Path:    filepath.Join(ProgressDir, ArtifactsDir, step, file),
+		Size:    int64(len(content)),
+		AddedAt: now.UTC().Truncate(time.Second),
+	}
+	if err := AppendEvent(projectDir, Event{Time: now.UTC(), Type: EventArtifactAttached, Actor: Actor(projectDir), Step: step, Data: map[string]any{"path": filepath.ToSlash(a.Path), "size": a.Size}}); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Artifacts lists the artifacts of a step, oldest first. A step without artifacts
+// returns nil.
+func Artifacts(projectDir, step string) ([]Artifact, error) {
+	if err := validateStepID(step); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(ArtifactDir(projectDir, step))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading artifacts: %w", err)
+	}
+
+	var result []Artifact
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, fmt.Errorf("reading artifact %s: %w", e.Name(), err)
+		}
+		a := Artifact{
+			Step:    step,
+			Name:    e.Name(),
+			Path:    filepath.Join(ProgressDir, ArtifactsDir, step, e.Name()),
+			Size:    info.Size(),
+			AddedAt: info.ModTime(),
+		}
+		if len(e.Name()) > len(artifactTimeFormat)+1 {
+			if t, err := time.Parse(artifactTimeFormat, e.Name()[:len(artifactTimeFormat)]); err == nil {
+				a.Name = e.Name()[len(artifactTimeFormat)+1:]
+				a.AddedAt = t
+			}
+		}
+		result = append(result, a)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+	return result, nil
+}
+
+// validateStepID rejects step IDs that cannot be used as a directory name.
+func validateStepID(step string) error {
+	if step == "" || step == "." || step == ".." || strings.ContainsAny(step, `/\`) {
+		return fmt.Errorf("invalid step ID %q", step)
+	}
+	return nil
+}
+
+// artifactName reduces name to a safe file name.
+func artifactName(name string) string {
+	name = filepath.Base(filepath.Clean(name))
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+	name = strings.TrimLeft(name, ".")
+	if name == "" {
+		return "artifact"
+	}
+	return name
+}
@@ -0,0 +1,82 @@
+package progress
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAttach(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 1, 14, 30, 0, 0, time.UTC)
+
+	a, err := Attach(dir, "3", "transcript.md", []byte("# Session\n"), now)
+	if err != nil {
+		t.Fatalf("Attach failed: %v", err)
+	}
+	want := filepath.Join(".rinku", "artifacts", "3", "20260301-143000-transcript.md")
+	if a.Path != want || a.Name != "transcript.md" || a.Size != 10 {
+		t.Errorf("artifact = %+v, want path %s", a, want)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, a.Path))
+	if err != nil || string(data) != "# Session\n" {
+		t.Errorf("content = %q, %v", data, err)
+	}
+
+	// Same name in the same second keeps both.
+	b, err := Attach(dir, "3", "transcript.md", []byte("second"), now)
+	if err != nil {
+		t.Fatalf("Attach failed: %v", err)
+	}
+	if b.Path == a.Path || filepath.Base(b.Path) != "20260301-143000-transcript-2.md" {
+		t.Errorf("second artifact path = %s", b.Path)
+	}
+}
+
+func TestAttach_SanitizesNames(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 1, 14, 30, 0, 0, time.UTC)
+
+	a, err := Attach(dir, "Codegen", "../../etc/my notes.txt", []byte("x"), now)
+	if err != nil {
+		t.Fatalf("Attach failed: %v", err)
+	}
+	if a.Name != "my_notes.txt" {
+		t.Errorf("Name = %q, want my_notes.txt", a.Name)
+	}
+
+	for _, step := range []string{"", "..", "a/b", `a\b`} {
+		if _, err := Attach(dir, step, "x.md", nil, now); err == nil {
+			t.Errorf("Attach(%q) succeeded, want error", step)
+		}
+	}
+}
+
+func TestArtifacts(t *testing.T) {
+	dir := t.TempDir()
+
+	got, err := Artifacts(dir, "3")
+	if err != nil || got != nil {
+		t.Fatalf("Artifacts without directory = %v, %v", got, err)
+	}
+
+	first := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	if _, err := Attach(dir, "3", "decision.md", []byte("use tokio"), first.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Attach(dir, "3", "plan.diff", []byte("+x"), first); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = Artifacts(dir, "3")
+	if err != nil {
+		t.Fatalf("Artifacts failed: %v", err)
+	}
+	if len(got) != 2 || got[0].Name != "plan.diff" || got[1].Name != "decision.md" {
+		t.Fatalf("Artifacts = %+v, want plan.diff then decision.md", got)
+	}
+	if !got[0].AddedAt.Equal(first) || got[1].Size != 9 {
+		t.Errorf("Artifacts = %+v", got)
+	}
+}
@@ -0,0 +1,156 @@
+package progress
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EventsFile is the append-only log of state changes below .rinku. Unlike
+// progress.json it is never rewritten, so dashboards and audits can follow it.
+const EventsFile = "events.jsonl"
+
+// EventType names a state change.
+type EventType string
+
+const (
+	EventMigrationStarted    EventType = "migration_started"
+	EventMigrationReset      EventType = "migration_reset"
+	EventStepStarted         EventType = "step_started"
+	EventStepCompleted       EventType = "step_completed"
+	EventStepSkipped         EventType = "step_skipped"
+	EventChecklistItem       EventType = "checklist_item"
+	EventRequirementSet      EventType = "requirement_set"
+	EventRequirementDone     EventType = "requirement_done"
+	EventRequirementResolved EventType = "requirement_resolved"
+	EventArtifactAttached    EventType = "artifact_attached"
+	EventVerify              EventType = "verify"
+)
+
+// Event is one line of the event log.
+type Event struct {
+	Time        time.Time      `json:"time"`
+	Type        EventType      `json:"type"`
+	Actor       string         `json:"actor,omitempty"`
+	Step        string         `json:"step,omitempty"`
+	Requirement string         `json:"requirement,omitempty"`
+	Note        string         `json:"note,omitempty"`
+	Data        map[string]any `json:"data,omitempty"` // type-specific values, e.g. verify counts
+}
+
+// String returns a one-line summary like
+// "2026-01-05 09:40:00 step_completed step 1 by alice: Analyzed".
+func (e *Event) String() string {
+	var b strings.Builder
+	b.WriteString(e.Time.Local().Format("2006-01-02 15:04:05") + " " + string(e.Type))
+	if e.Step != "" {
+		b.WriteString(" step " + e.Step)
+	}
+	if e.Requirement != "" {
+		b.WriteString(" " + e.Requirement)
+	}
+	if len(e.Data) > 0 {
+		keys := make([]string, 0, len(e.Data))
+		for k := range e.Data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&b, " %s=%v", k, e.Data[k])
+		}
+	}
+	if e.Actor != "" {
+		b.WriteString(" by " + e.Actor)
+	}
+	if e.Note != "" {
+		b.WriteString(": " + e.Note)
+	}
+	return b.String()
+}
+
+// EventsPath returns the path to events.jsonl for a project directory.
+func EventsPath(projectDir string) string {
+	return filepath.Join(projectDir, ProgressDir, EventsFile)
+}
+
+// AppendEvent appends events to the log, setting a missing Time to now. Each event is
+// written as a single line in one write, so concurrent writers do not interleave.
+func AppendEvent(projectDir string, events ...Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Join(projectDir, ProgressDir), 0750); err != nil {
+		return fmt.Errorf("creating %s directory: %w", ProgressDir, err)
+	}
+	f, err := os.OpenFile(EventsPath(projectDir), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o640) //#nosec G304 -- projectDir from os.Getwd(), not user input
+	if err != nil {
+		return fmt.Errorf("opening event log: %w", err)
+	}
+	defer f.Close()
+
+	now := time.Now().UTC()
+	for _, e := range events {
+		if e.Time.IsZero() {
+			e.Time = now
+		}
+		line, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("marshaling event: %w", err)
+		}
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("writing event log: %w", err)
+		}
+	}
+	return f.Close()
+}
+
+// Events reads the event log, oldest first. A project without a log returns nil.
+func Events(projectDir string) ([]Event, error) {
+	events, _, err := EventsSince(projectDir, 0)
+	return events, err
+}
+
+// EventsSince reads the events written after byte offset of the log and returns the
+// offset to continue from. A line still being written (without newline) is left for
+// the next call, so followers can poll.
+func EventsSince(projectDir string, offset int64) ([]Event, int64, error) {
+	f, err := os.Open(EventsPath(projectDir)) //#nosec G304 -- projectDir from os.Getwd(), not user input
+	if os.IsNotExist(err) {
+		return nil, offset, nil
+	}
+	if err != nil {
+		return nil, offset, fmt.Errorf("opening event log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, fmt.Errorf("reading event log: %w", err)
+	}
+
+	var events []Event
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			return events, offset, nil
+		}
+		if err != nil {
+			return nil, offset, fmt.Errorf("reading event log: %w", err)
+		}
+		offset += int64(len(line))
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, offset, fmt.Errorf("parsing event log at byte %d: %w", offset-int64(len(line)), err)
+		}
+		events = append(events, e)
+	}
+}
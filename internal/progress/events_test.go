@@ -0,0 +1,138 @@
+package progress
+
+import (
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+func eventTypes(t *testing.T, dir string) []EventType {
+	t.Helper()
+	events, err := Events(dir)
+	if err != nil {
+		t.Fatalf("Events failed: %v", err)
+	}
+	var types []EventType
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestEvents_NoLog(t *testing.T) {
+	events, err := Events(t.TempDir())
+	if err != nil || events != nil {
+		t.Errorf("Events = %v, %v, want nil, nil", events, err)
+	}
+}
+
+func TestAppendEvent(t *testing.T) {
+	dir := t.TempDir()
+	at := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	if err := AppendEvent(dir, Event{Time: at, Type: EventVerify, Data: map[string]any{"missing": 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendEvent(dir, Event{Type: EventRequirementDone, Requirement: "app/cli"}); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := Events(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	if !events[0].Time.Equal(at) || events[0].Data["missing"] != float64(1) {
+		t.Errorf("first event = %+v", events[0])
+	}
+	if events[1].Time.IsZero() || events[1].Requirement != "app/cli" {
+		t.Errorf("second event = %+v", events[1])
+	}
+}
+
+func TestEventsSince_PartialLine(t *testing.T) {
+	dir := t.TempDir()
+	if err := AppendEvent(dir, Event{Type: EventStepStarted, Step: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	events, offset, err := EventsSince(dir, 0)
+	if err != nil || len(events) != 1 {
+		t.Fatalf("EventsSince = %v, %v", events, err)
+	}
+
+	// A writer is halfway through the next line
+	f, err := os.OpenFile(EventsPath(dir), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(`{"type":"step_completed",`); err != nil {
+		t.Fatal(err)
+	}
+	events, next, err := EventsSince(dir, offset)
+	if err != nil || len(events) != 0 || next != offset {
+		t.Fatalf("partial line: events %v, offset %d (want %d), err %v", events, next, offset, err)
+	}
+
+	if _, err := f.WriteString(`"step":"1"}` + "\n"); err != nil {
+		t.Fatal(err)
+	}
+	events, _, err = EventsSince(dir, offset)
+	if err != nil || len(events) != 1 || events[0].Type != EventStepCompleted {
+		t.Fatalf("completed line: %v, %v", events, err)
+	}
+}
+
+func TestSave_AppendsEvents(t *testing.T) {
+	dir := t.TempDir()
+	m := New(dir, []string{"1", "2"})
+	if err := m.StartStep("1", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if types := eventTypes(t, dir); len(types) != 0 {
+		t.Errorf("events before Save: %v", types)
+	}
+	if err := m.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.CompleteStep("1", "notes", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Save(dir); err != nil { // nothing changed: no new events
+		t.Fatal(err)
+	}
+	if err := Delete(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	got := eventTypes(t, dir)
+	want := []EventType{EventMigrationStarted, EventStepStarted, EventStepCompleted, EventMigrationReset}
+	if !slices.Equal(got, want) {
+		t.Fatalf("types = %v, want %v", got, want)
+	}
+
+	events, _ := Events(dir)
+	if e := events[2]; e.Step != "1" || e.Actor != "bob" || e.Note != "notes" {
+		t.Errorf("step_completed = %+v", e)
+	}
+}
+
+func TestEvent_String(t *testing.T) {
+	e := Event{
+		Time:  time.Date(2026, 1, 5, 9, 40, 0, 0, time.Local),
+		Type:  EventStepCompleted,
+		Actor: "alice",
+		Step:  "1",
+		Note:  "Analyzed",
+		Data:  map[string]any{"b": 2, "a": "x"},
+	}
+	want := "2026-01-05 09:40:00 step_completed step 1 a=x b=2 by alice: Analyzed"
+	if got := e.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
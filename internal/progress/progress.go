@@ -2,7 +2,12 @@ package progress
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/stephan/rinku/internal/reason"
 )
 
 // StepStatus represents the state of a migration step.
@@ -22,6 +27,34 @@ type StepRecord struct {
 	StartedAt   *time.Time `json:"started_at,omitempty"`
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
 	Notes       string     `json:"notes,omitempty"`
+	StartedBy   string     `json:"started_by,omitempty"`
+	CompletedBy string     `json:"completed_by,omitempty"`
+	NotedBy     string     `json:"noted_by,omitempty"`
+	// ElapsedSeconds accumulates the time spent on the step over all start/finish
+	// cycles, excluding the session still running.
+	ElapsedSeconds int64 `json:"elapsed_seconds,omitempty"`
+	// Checked are the checklist items of the step content that are done, 1-based and
+	// sorted.
+	Checked []int `json:"checked,omitempty"`
+	// SkipCode classifies why a skipped step was skipped; Notes explains it.
+	SkipCode reason.Code `json:"skip_code,omitempty"`
+}
+
+// Elapsed returns the time spent on the step, including the running session of a
+// step in progress.
+func (s *StepRecord) Elapsed(now time.Time) time.Duration {
+	d := time.Duration(s.ElapsedSeconds) * time.Second
+	if s.Status == StepInProgress && s.StartedAt != nil && now.After(*s.StartedAt) {
+		d += now.Sub(*s.StartedAt)
+	}
+	return d
+}
+
+// stopClock adds the running session to the accumulated time.
+func (s *StepRecord) stopClock(now time.Time) {
+	if s.Status == StepInProgress && s.StartedAt != nil && now.After(*s.StartedAt) {
+		s.ElapsedSeconds += int64(now.Sub(*s.StartedAt).Round(time.Second) / time.Second)
+	}
 }
 
 // Migration represents the full migration progress state.
@@ -29,9 +62,25 @@ type Migration struct {
 	Version     int                    `json:"version"`
 	StartedAt   time.Time              `json:"started_at"`
 	ProjectPath string                 `json:"project_path"`
+	Workflow    string                 `json:"workflow,omitempty"` // catalog workflow, empty for the embedded one
 	CurrentStep string                 `json:"current_step"`
 	Steps       map[string]*StepRecord `json:"steps"`
 	StepOrder   []string               `json:"step_order"`
+
+	// StrictOrder makes CompleteStep refuse a step while earlier steps of StepOrder are
+	// neither completed nor skipped. It is not saved; callers set it from the project
+	// configuration.
+	StrictOrder bool `json:"-"`
+	// AutoAdvance makes CompleteStep of the current step move CurrentStep to NextStep.
+	// Like StrictOrder, it is set by callers and not saved.
+	AutoAdvance bool `json:"-"`
+	// StrictChecklist makes CompleteStep refuse a step with unchecked items in
+	// Checklists, the number of checklist items per step of the workflow. Both are set
+	// by callers.
+	StrictChecklist bool           `json:"-"`
+	Checklists      map[string]int `json:"-"`
+
+	events []Event // state changes appended to the event log by Save
 }
 
 const currentVersion = 1
@@ -58,40 +107,257 @@ func New(projectDir string, stepOrder []string) *Migration {
 	if len(stepOrder) > 0 {
 		m.CurrentStep = stepOrder[0]
 	}
+	m.events = append(m.events, Event{Time: now.UTC(), Type: EventMigrationStarted})
 
 	return m
 }
 
-// StartStep marks a step as in_progress and sets it as the current step.
-func (m *Migration) StartStep(id string) error {
+// SetStepOrder adopts a changed workflow: steps new in order are added as pending and
+// pending steps missing from it are dropped. Steps with history that are no longer in
+// order keep their records and stay at the end, so nothing done is lost.
+func (m *Migration) SetStepOrder(order []string) {
+	inOrder := make(map[string]bool, len(order))
+	for _, id := range order {
+		inOrder[id] = true
+		if m.Steps[id] == nil {
+			m.Steps[id] = &StepRecord{ID: id, Status: StepPending}
+		}
+	}
+	result := append([]string(nil), order...)
+	for _, id := range m.StepOrder {
+		if inOrder[id] {
+			continue
+		}
+		if step := m.Steps[id]; step != nil && step.Status == StepPending {
+			delete(m.Steps, id)
+			continue
+		}
+		result = append(result, id)
+	}
+	m.StepOrder = result
+}
+
+// StartStep marks a step as in_progress and sets it as the current step. by is
+// who started it (see Actor).
+func (m *Migration) StartStep(id, by string) error {
 	step, ok := m.Steps[id]
 	if !ok {
 		return fmt.Errorf("step '%s' not found", id)
 	}
 
 	now := time.Now()
+	step.stopClock(now)
 	step.Status = StepInProgress
 	step.StartedAt = &now
+	step.StartedBy = by
 	m.CurrentStep = id
+	m.events = append(m.events, Event{Time: now.UTC(), Type: EventStepStarted, Actor: by, Step: id})
+	return nil
+}
+
+// OrderError is returned by CompleteStep in StrictOrder when earlier steps are open.
+type OrderError struct {
+	Step     string
+	Blockers []string // earlier steps neither completed nor skipped, in order
+}
+
+func (e *OrderError) Error() string {
+	return fmt.Sprintf("step %s cannot be completed before %s", e.Step, strings.Join(e.Blockers, ", "))
+}
+
+// Blockers returns the steps before id in StepOrder that are neither completed nor
+// skipped.
+func (m *Migration) Blockers(id string) []string {
+	var blockers []string
+	for _, prev := range m.StepOrder {
+		if prev == id {
+			return blockers
+		}
+		if step := m.Steps[prev]; step != nil && step.Status != StepCompleted && step.Status != StepSkipped {
+			blockers = append(blockers, prev)
+		}
+	}
 	return nil
 }
 
-// CompleteStep marks a step as completed with optional notes.
-func (m *Migration) CompleteStep(id string, notes string) error {
+// ChecklistError is returned by CompleteStep in StrictChecklist when checklist items
+// of the step are not checked.
+type ChecklistError struct {
+	Step      string
+	Unchecked []int // 1-based item numbers
+}
+
+func (e *ChecklistError) Error() string {
+	items := make([]string, len(e.Unchecked))
+	for i, n := range e.Unchecked {
+		items[i] = strconv.Itoa(n)
+	}
+	noun := "items"
+	if len(items) == 1 {
+		noun = "item"
+	}
+	return fmt.Sprintf("step %s has unchecked checklist %s %s", e.Step, noun, strings.Join(items, ", "))
+}
+
+// Unchecked returns the checklist items of a step, numbered from 1, that are not
+// checked, given the number of items in its content.
+func (m *Migration) Unchecked(id string, items int) []int {
+	var checked []int
+	if step := m.Steps[id]; step != nil {
+		checked = step.Checked
+	}
+	var unchecked []int
+	for n := 1; n <= items; n++ {
+		if !slices.Contains(checked, n) {
+			unchecked = append(unchecked, n)
+		}
+	}
+	return unchecked
+}
+
+// CheckItem marks checklist item n of a step as done, or as open again if checked is
+// false. by is who changed it (see Actor).
+func (m *Migration) CheckItem(id string, n int, checked bool, by string) error {
 	step, ok := m.Steps[id]
 	if !ok {
 		return fmt.Errorf("step '%s' not found", id)
 	}
+	if n < 1 {
+		return fmt.Errorf("checklist item %d: items are numbered from 1", n)
+	}
+	i, found := slices.BinarySearch(step.Checked, n)
+	switch {
+	case checked && !found:
+		step.Checked = slices.Insert(step.Checked, i, n)
+	case !checked && found:
+		step.Checked = slices.Delete(step.Checked, i, i+1)
+	default:
+		return nil
+	}
+	m.events = append(m.events, Event{Time: time.Now().UTC(), Type: EventChecklistItem, Actor: by, Step: id, Data: map[string]any{"item": n, "checked": checked}})
+	return nil
+}
+
+// CompleteStep marks a step as completed with optional notes. by is who completed
+// it (see Actor). In StrictOrder, open earlier steps are an *OrderError; in
+// StrictChecklist, unchecked items a *ChecklistError.
+func (m *Migration) CompleteStep(id, notes, by string) error {
+	step, ok := m.Steps[id]
+	if !ok {
+		return fmt.Errorf("step '%s' not found", id)
+	}
+	if m.StrictOrder {
+		if blockers := m.Blockers(id); len(blockers) > 0 {
+			return &OrderError{Step: id, Blockers: blockers}
+		}
+	}
+	if m.StrictChecklist {
+		if unchecked := m.Unchecked(id, m.Checklists[id]); len(unchecked) > 0 {
+			return &ChecklistError{Step: id, Unchecked: unchecked}
+		}
+	}
 
 	now := time.Now()
+	step.stopClock(now)
 	step.Status = StepCompleted
 	step.CompletedAt = &now
+	step.CompletedBy = by
 	if notes != "" {
 		step.Notes = notes
+		step.NotedBy = by
+	}
+	m.events = append(m.events, Event{Time: now.UTC(), Type: EventStepCompleted, Actor: by, Step: id, Note: notes})
+	if m.AutoAdvance && m.CurrentStep == id {
+		if next := m.NextStep(); next != "" {
+			m.CurrentStep = next
+		}
+	}
+	return nil
+}
+
+// SkipStep marks a step as skipped, with a reason code and the note explaining it.
+// Unlike CompleteStep, it ignores StrictOrder and StrictChecklist: skipping is the way
+// out of a step that cannot be done.
+func (m *Migration) SkipStep(id string, code reason.Code, notes, by string) error {
+	step, ok := m.Steps[id]
+	if !ok {
+		return fmt.Errorf("step '%s' not found", id)
+	}
+	if _, err := reason.Parse(string(code)); err != nil {
+		return err
+	}
+	if notes == "" {
+		return fmt.Errorf("skipping step %s needs a note explaining why", id)
+	}
+
+	now := time.Now()
+	step.stopClock(now)
+	step.Status = StepSkipped
+	step.CompletedAt = &now
+	step.CompletedBy = by
+	step.SkipCode = code
+	step.Notes = notes
+	step.NotedBy = by
+	m.events = append(m.events, Event{Time: now.UTC(), Type: EventStepSkipped, Actor: by, Step: id, Note: string(code) + ": " + notes})
+	if m.AutoAdvance && m.CurrentStep == id {
+		if next := m.NextStep(); next != "" {
+			m.CurrentStep = next
+		}
 	}
 	return nil
 }
 
+// SkipCounts counts the skipped steps by reason code.
+func (m *Migration) SkipCounts() reason.Counts {
+	counts := make(reason.Counts)
+	for _, step := range m.Steps {
+		if step.Status == StepSkipped {
+			counts[step.SkipCode]++
+		}
+	}
+	return counts
+}
+
+// NextStep returns the first step after CurrentStep in StepOrder that is neither
+// completed nor skipped, or "" if there is none.
+func (m *Migration) NextStep() string {
+	i := slices.Index(m.StepOrder, m.CurrentStep)
+	for _, id := range m.StepOrder[i+1:] {
+		if step := m.Steps[id]; step != nil && step.Status != StepCompleted && step.Status != StepSkipped {
+			return id
+		}
+	}
+	return ""
+}
+
+// PreviousStep returns the step before CurrentStep in StepOrder, whatever its status,
+// or "" for the first step.
+func (m *Migration) PreviousStep() string {
+	if i := slices.Index(m.StepOrder, m.CurrentStep); i > 0 {
+		return m.StepOrder[i-1]
+	}
+	return ""
+}
+
+// Contributors returns everyone recorded on a step, in order of first appearance.
+func (m *Migration) Contributors() []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, id := range m.StepOrder {
+		step := m.Steps[id]
+		if step == nil {
+			continue
+		}
+		for _, by := range []string{step.StartedBy, step.CompletedBy, step.NotedBy} {
+			if by != "" && !seen[by] {
+				seen[by] = true
+				result = append(result, by)
+			}
+		}
+	}
+	return result
+}
+
 // GetCurrentStep returns the current step ID.
 func (m *Migration) GetCurrentStep() string {
 	return m.CurrentStep
@@ -112,3 +378,49 @@ func (m *Migration) IsComplete() bool {
 	completed, total := m.Progress()
 	return completed == total
 }
+
+// Forecast is the projected remaining time of a migration.
+type Forecast struct {
+	Remaining   time.Duration // estimated time left, adjusted by Pace
+	Pace        float64       // actual/estimated time of finished steps; 1 without history
+	Unestimated int           // unfinished steps without an estimate
+	Finish      time.Time     // now + Remaining
+}
+
+// Forecast projects the remaining time from per-step estimates. Finished steps with an
+// estimate set the pace, which scales the estimates of the unfinished steps; the time
+// already spent on a step in progress is subtracted from its share.
+func (m *Migration) Forecast(estimates map[string]time.Duration, now time.Time) Forecast {
+	var actual, estimated time.Duration
+	for _, id := range m.StepOrder {
+		step := m.Steps[id]
+		est, ok := estimates[id]
+		if !ok || step == nil || step.Status != StepCompleted || step.ElapsedSeconds == 0 {
+			continue
+		}
+		actual += step.Elapsed(now)
+		estimated += est
+	}
+
+	f := Forecast{Pace: 1}
+	if estimated > 0 {
+		f.Pace = float64(actual) / float64(estimated)
+	}
+	for _, id := range m.StepOrder {
+		step := m.Steps[id]
+		if step == nil || step.Status == StepCompleted || step.Status == StepSkipped {
+			continue
+		}
+		est, ok := estimates[id]
+		if !ok {
+			f.Unestimated++
+			continue
+		}
+		left := time.Duration(float64(est)*f.Pace) - step.Elapsed(now)
+		if left > 0 {
+			f.Remaining += left
+		}
+	}
+	f.Finish = now.Add(f.Remaining)
+	return f
+}
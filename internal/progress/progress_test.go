@@ -1,9 +1,15 @@
 package progress
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/stephan/rinku/internal/reason"
 )
 
 func TestNew_InitializesAllStepsPending(t *testing.T) {
@@ -44,7 +50,7 @@ func TestNew_EmptySteps(t *testing.T) {
 func TestStartStep(t *testing.T) {
 	m := New("/test", []string{"1", "2", "3"})
 
-	err := m.StartStep("2")
+	err := m.StartStep("2", "")
 	if err != nil {
 		t.Fatalf("StartStep failed: %v", err)
 	}
@@ -64,16 +70,40 @@ func TestStartStep(t *testing.T) {
 func TestStartStep_NotFound(t *testing.T) {
 	m := New("/test", []string{"1", "2"})
 
-	err := m.StartStep("nonexistent")
+	err := m.StartStep("nonexistent", "")
 	if err == nil {
 		t.Error("expected error for nonexistent step")
 	}
 }
 
+func TestSetStepOrder(t *testing.T) {
+	m := New("/test", []string{"1", "2", "3"})
+	if err := m.CompleteStep("2", "", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	m.SetStepOrder([]string{"1", "review", "3"})
+
+	if want := []string{"1", "review", "3", "2"}; !slices.Equal(m.StepOrder, want) {
+		t.Errorf("StepOrder = %v, want %v", m.StepOrder, want)
+	}
+	if step := m.Steps["review"]; step == nil || step.Status != StepPending {
+		t.Errorf("review = %+v, want pending", step)
+	}
+	if m.Steps["2"] == nil {
+		t.Error("completed step 2 was dropped")
+	}
+
+	m.SetStepOrder([]string{"1", "3"})
+	if m.Steps["review"] != nil {
+		t.Error("pending step review was kept")
+	}
+}
+
 func TestCompleteStep(t *testing.T) {
 	m := New("/test", []string{"1", "2"})
 
-	err := m.CompleteStep("1", "done")
+	err := m.CompleteStep("1", "done", "")
 	if err != nil {
 		t.Fatalf("CompleteStep failed: %v", err)
 	}
@@ -93,7 +123,7 @@ func TestCompleteStep(t *testing.T) {
 func TestCompleteStep_EmptyNotes(t *testing.T) {
 	m := New("/test", []string{"1"})
 
-	err := m.CompleteStep("1", "")
+	err := m.CompleteStep("1", "", "")
 	if err != nil {
 		t.Fatalf("CompleteStep failed: %v", err)
 	}
@@ -106,12 +136,146 @@ func TestCompleteStep_EmptyNotes(t *testing.T) {
 func TestCompleteStep_NotFound(t *testing.T) {
 	m := New("/test", []string{"1"})
 
-	err := m.CompleteStep("nonexistent", "")
+	err := m.CompleteStep("nonexistent", "", "")
 	if err == nil {
 		t.Error("expected error for nonexistent step")
 	}
 }
 
+func TestCompleteStep_StrictOrder(t *testing.T) {
+	m := New("/test", []string{"1", "2", "3", "4"})
+	if err := m.CompleteStep("3", "", ""); err != nil {
+		t.Fatalf("out of order without StrictOrder: %v", err)
+	}
+
+	m.StrictOrder = true
+	m.Steps["2"].Status = StepSkipped
+	err := m.CompleteStep("4", "", "")
+	var orderErr *OrderError
+	if !errors.As(err, &orderErr) {
+		t.Fatalf("CompleteStep(4) = %v, want *OrderError", err)
+	}
+	if orderErr.Step != "4" || !slices.Equal(orderErr.Blockers, []string{"1"}) {
+		t.Errorf("OrderError = %+v, want step 4 blocked by 1", orderErr)
+	}
+	if m.Steps["4"].Status != StepPending {
+		t.Errorf("refused step is %s, want pending", m.Steps["4"].Status)
+	}
+
+	if err := m.CompleteStep("1", "", ""); err != nil {
+		t.Fatalf("CompleteStep(1): %v", err)
+	}
+	if err := m.CompleteStep("4", "", ""); err != nil {
+		t.Errorf("CompleteStep(4) after 1: %v", err)
+	}
+}
+
+func TestCompleteStep_StrictChecklist(t *testing.T) {
+	m := New("/test", []string{"1", "2"})
+	m.Checklists = map[string]int{"1": 3}
+	if err := m.CheckItem("1", 2, true, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.CheckItem("1", 0, true, "alice"); err == nil {
+		t.Error("CheckItem(0) should fail")
+	}
+	if got := m.Unchecked("1", 3); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("Unchecked = %v, want [1 3]", got)
+	}
+
+	m.StrictChecklist = true
+	err := m.CompleteStep("1", "", "")
+	var checklistErr *ChecklistError
+	if !errors.As(err, &checklistErr) || !slices.Equal(checklistErr.Unchecked, []int{1, 3}) {
+		t.Fatalf("CompleteStep(1) = %v, want *ChecklistError for items 1 and 3", err)
+	}
+	for _, n := range []int{3, 1} {
+		if err := m.CheckItem("1", n, true, "alice"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !slices.Equal(m.Steps["1"].Checked, []int{1, 2, 3}) {
+		t.Errorf("Checked = %v, want sorted [1 2 3]", m.Steps["1"].Checked)
+	}
+	if err := m.CompleteStep("1", "", ""); err != nil {
+		t.Errorf("CompleteStep(1) with every item checked: %v", err)
+	}
+	if err := m.CompleteStep("2", "", ""); err != nil {
+		t.Errorf("CompleteStep(2) without checklist: %v", err)
+	}
+
+	if err := m.CheckItem("1", 2, false, "alice"); err != nil || !slices.Equal(m.Steps["1"].Checked, []int{1, 3}) {
+		t.Errorf("unchecking item 2: %v, Checked = %v", err, m.Steps["1"].Checked)
+	}
+}
+
+func TestCompleteStep_AutoAdvance(t *testing.T) {
+	m := New("/test", []string{"1", "2", "3", "4"})
+	m.Steps["2"].Status = StepSkipped
+	if m.PreviousStep() != "" || m.NextStep() != "3" {
+		t.Errorf("at 1: previous %q, next %q", m.PreviousStep(), m.NextStep())
+	}
+
+	// Without AutoAdvance, the current step stays.
+	if err := m.CompleteStep("1", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if m.CurrentStep != "1" {
+		t.Errorf("CurrentStep = %q, want 1", m.CurrentStep)
+	}
+
+	m.AutoAdvance = true
+	m.CurrentStep = "3"
+	if err := m.CompleteStep("4", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if m.CurrentStep != "3" {
+		t.Errorf("completing another step moved CurrentStep to %q", m.CurrentStep)
+	}
+	if m.PreviousStep() != "2" || m.NextStep() != "" {
+		t.Errorf("at 3: previous %q, next %q", m.PreviousStep(), m.NextStep())
+	}
+	m.Steps["4"].Status = StepPending
+	if err := m.CompleteStep("3", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if m.CurrentStep != "4" {
+		t.Errorf("CurrentStep = %q, want 4", m.CurrentStep)
+	}
+	if err := m.CompleteStep("4", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if m.CurrentStep != "4" {
+		t.Errorf("CurrentStep after the last step = %q, want 4", m.CurrentStep)
+	}
+}
+
+func TestSkipStep(t *testing.T) {
+	m := New("/test", []string{"1", "2", "3"})
+	m.StrictOrder = true
+	if err := m.SkipStep("2", reason.Deferred, "", "alice"); err == nil {
+		t.Error("SkipStep without a note should fail")
+	}
+	if err := m.SkipStep("2", "later", "after the launch", "alice"); err == nil {
+		t.Error("SkipStep with an unknown code should fail")
+	}
+	if err := m.SkipStep("2", reason.Deferred, "after the launch", "alice"); err != nil {
+		t.Fatalf("SkipStep ignores StrictOrder: %v", err)
+	}
+	step := m.Steps["2"]
+	if step.Status != StepSkipped || step.SkipCode != reason.Deferred || step.Notes != "after the launch" || step.CompletedBy != "alice" {
+		t.Errorf("skipped step = %+v", step)
+	}
+	if last := m.events[len(m.events)-1]; last.Type != EventStepSkipped || last.Note != "deferred: after the launch" {
+		t.Errorf("last event = %+v", last)
+	}
+
+	m.Steps["3"].Status = StepSkipped // skipped before reason codes
+	if got := m.SkipCounts().String(); got != "deferred 1, no code 1" {
+		t.Errorf("SkipCounts() = %q", got)
+	}
+}
+
 func TestProgress(t *testing.T) {
 	m := New("/test", []string{"1", "2", "3", "4"})
 
@@ -144,13 +308,108 @@ func TestIsComplete(t *testing.T) {
 	}
 }
 
+func TestAttribution(t *testing.T) {
+	m := New("/test", []string{"1", "2"})
+
+	_ = m.StartStep("1", "alice")
+	_ = m.CompleteStep("1", "", "agent:claude")
+	_ = m.StartStep("2", "alice")
+	_ = m.CompleteStep("2", "needs review", "bob")
+
+	if got := m.Steps["1"].StartedBy; got != "alice" {
+		t.Errorf("StartedBy = %q, want %q", got, "alice")
+	}
+	if got := m.Steps["1"].NotedBy; got != "" {
+		t.Errorf("NotedBy without note = %q, want empty", got)
+	}
+	if got := m.Steps["2"].NotedBy; got != "bob" {
+		t.Errorf("NotedBy = %q, want %q", got, "bob")
+	}
+	want := []string{"alice", "agent:claude", "bob"}
+	if got := m.Contributors(); !slices.Equal(got, want) {
+		t.Errorf("Contributors() = %v, want %v", got, want)
+	}
+}
+
+func TestActor_EnvOverride(t *testing.T) {
+	t.Setenv(ActorEnv, "  carol ")
+	if got := Actor(t.TempDir()); got != "carol" {
+		t.Errorf("Actor() = %q, want %q", got, "carol")
+	}
+}
+
+func TestCompleteStep_AccumulatesElapsed(t *testing.T) {
+	m := New("/test", []string{"1"})
+	if err := m.StartStep("1", ""); err != nil {
+		t.Fatal(err)
+	}
+	started := time.Now().Add(-90 * time.Minute)
+	m.Steps["1"].StartedAt = &started
+	if err := m.CompleteStep("1", "", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopening the step adds to the earlier session.
+	if err := m.StartStep("1", ""); err != nil {
+		t.Fatal(err)
+	}
+	started = time.Now().Add(-30 * time.Minute)
+	m.Steps["1"].StartedAt = &started
+	if err := m.CompleteStep("1", "", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := m.Steps["1"].Elapsed(time.Now()); got != 2*time.Hour {
+		t.Errorf("Elapsed = %v, want 2h", got)
+	}
+}
+
+func TestForecast(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	m := New("/test", []string{"1", "2", "3", "4"})
+	m.Steps["1"].Status = StepCompleted
+	m.Steps["1"].ElapsedSeconds = int64((3 * time.Hour).Seconds())
+	started := now.Add(-time.Hour)
+	m.Steps["2"].Status = StepInProgress
+	m.Steps["2"].StartedAt = &started
+
+	estimates := map[string]time.Duration{
+		"1": 2 * time.Hour,
+		"2": 2 * time.Hour,
+		"3": time.Hour,
+	}
+	f := m.Forecast(estimates, now)
+
+	if f.Pace != 1.5 {
+		t.Errorf("Pace = %v, want 1.5", f.Pace)
+	}
+	// Step 2: 2h*1.5 - 1h spent = 2h; step 3: 1h*1.5.
+	if want := 3*time.Hour + 30*time.Minute; f.Remaining != want {
+		t.Errorf("Remaining = %v, want %v", f.Remaining, want)
+	}
+	if f.Unestimated != 1 {
+		t.Errorf("Unestimated = %d, want 1", f.Unestimated)
+	}
+	if !f.Finish.Equal(now.Add(f.Remaining)) {
+		t.Errorf("Finish = %v", f.Finish)
+	}
+}
+
+func TestForecast_NoHistory(t *testing.T) {
+	m := New("/test", []string{"1", "2"})
+	f := m.Forecast(map[string]time.Duration{"1": time.Hour, "2": time.Hour}, time.Now())
+	if f.Pace != 1 || f.Remaining != 2*time.Hour {
+		t.Errorf("Forecast = %+v, want pace 1 and 2h remaining", f)
+	}
+}
+
 func TestSaveAndLoad(t *testing.T) {
 	dir := t.TempDir()
 	steps := []string{"1", "2", "3"}
 	m := New(dir, steps)
-	_ = m.StartStep("1")
-	_ = m.CompleteStep("1", "first done")
-	_ = m.StartStep("2")
+	_ = m.StartStep("1", "")
+	_ = m.CompleteStep("1", "first done", "")
+	_ = m.StartStep("2", "")
 
 	err := m.Save(dir)
 	if err != nil {
@@ -186,6 +445,20 @@ func TestSaveAndLoad(t *testing.T) {
 	}
 }
 
+func TestSave_RejectsInvalid(t *testing.T) {
+	dir := t.TempDir()
+	m := New(dir, []string{"1"})
+	m.Steps["1"].Status = "done"
+
+	err := m.Save(dir)
+	if err == nil || !strings.Contains(err.Error(), `/steps/1/status: must be one of pending, in_progress, completed, skipped, not "done"`) {
+		t.Fatalf("Save error = %v", err)
+	}
+	if Exists(dir) {
+		t.Error("invalid progress was written")
+	}
+}
+
 func TestLoad_NoFile(t *testing.T) {
 	dir := t.TempDir()
 
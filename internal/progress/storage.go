@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 
 	"github.com/natefinch/atomic"
+	"github.com/stephan/rinku/internal/schema"
 )
 
 const (
@@ -38,7 +39,9 @@ func Load(projectDir string) (*Migration, error) {
 	return &m, nil
 }
 
-// Save atomically writes progress to disk.
+// Save atomically writes progress to disk, then appends the state changes made since
+// New, Load or the last Save to the event log. A migration that does not match the
+// progress schema is not written.
 func (m *Migration) Save(projectDir string) error {
 	dir := filepath.Join(projectDir, ProgressDir)
 	if err := os.MkdirAll(dir, 0750); err != nil {
@@ -49,18 +52,32 @@ func (m *Migration) Save(projectDir string) error {
 	if err != nil {
 		return fmt.Errorf("marshaling progress: %w", err)
 	}
+	if err := schema.Validate(schema.Progress, data); err != nil {
+		return fmt.Errorf("refusing to write progress: %w", err)
+	}
 
 	path := ProgressPath(projectDir)
-	return atomic.WriteFile(path, bytes.NewReader(append(data, '\n')))
+	if err := atomic.WriteFile(path, bytes.NewReader(append(data, '\n'))); err != nil {
+		return err
+	}
+	if err := AppendEvent(projectDir, m.events...); err != nil {
+		return err
+	}
+	m.events = nil
+	return nil
 }
 
-// Delete removes the progress file.
+// Delete removes the progress file. The event log is kept and records the reset.
 func Delete(projectDir string) error {
 	path := ProgressPath(projectDir)
-	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+	err := os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
 		return err
 	}
-	return nil
+	return AppendEvent(projectDir, Event{Type: EventMigrationReset, Actor: Actor(projectDir)})
 }
 
 // Exists checks if a progress file exists.
@@ -0,0 +1,139 @@
+// Package projectmap loads .rinku/mappings.json, the mappings of a single project that
+// add to or override the mapping database, e.g. for private modules or a team's choice
+// of crate.
+package projectmap
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/internal/rinku"
+	"github.com/stephan/rinku/internal/types"
+	"github.com/stephan/rinku/internal/url"
+)
+
+const File = "mappings.json"
+
+// Mapping maps a dependency of the project to its equivalents in a target language.
+type Mapping struct {
+	Source     string              `json:"source"`          // Go module path or library URL
+	Lang       string              `json:"lang,omitempty"`  // target language, rust if empty
+	Targets    []string            `json:"targets"`         // library URLs, best first; empty for "no equivalent"
+	Crate      string              `json:"crate,omitempty"` // crate name of a single Rust target
+	Category   string              `json:"category,omitempty"`
+	Confidence float64             `json:"confidence,omitempty"` // 0 to 1 as in the database; 1 if omitted, the project chose the targets
+	Requires   []types.RequiredDep `json:"requires,omitempty"`
+}
+
+// Mappings is the content of .rinku/mappings.json.
+type Mappings struct {
+	Mappings []Mapping `json:"mappings"`
+}
+
+// Path returns the path of the mapping file of a project directory.
+func Path(projectDir string) string {
+	return filepath.Join(projectDir, progress.ProgressDir, File)
+}
+
+// Load reads the mapping file of a project. It returns nil, nil if there is none.
+func Load(projectDir string) (*Mappings, error) {
+	data, err := os.ReadFile(Path(projectDir)) //#nosec G304 -- projectDir from os.Getwd()
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", File, err)
+	}
+	var m Mappings
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", File, err)
+	}
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", File, err)
+	}
+	return &m, nil
+}
+
+func (m *Mapping) lang() string {
+	if m.Lang == "" {
+		return "rust"
+	}
+	return rinku.Lang(m.Lang)
+}
+
+// sourceURL returns the library URL of the source: a Go module path is converted as
+// for the dependencies of a go.mod.
+func (m *Mapping) sourceURL() string {
+	if strings.Contains(m.Source, "://") {
+		return m.Source
+	}
+	return cargo.ModulePathToGitHubURL(m.Source)
+}
+
+// key is the forward index key of the mapping.
+func (m *Mapping) key() string {
+	return m.lang() + ":" + url.Normalize(m.sourceURL())
+}
+
+func (m *Mappings) validate() error {
+	seen := make(map[string]int)
+	for i, mapping := range m.Mappings {
+		if mapping.Source == "" {
+			return fmt.Errorf("mapping %d: missing source", i+1)
+		}
+		if slices.Contains(mapping.Targets, "") {
+			return fmt.Errorf("mapping %d (%s): empty target", i+1, mapping.Source)
+		}
+		if mapping.Crate != "" && (mapping.lang() != "rust" || len(mapping.Targets) != 1) {
+			return fmt.Errorf("mapping %d (%s): crate names the crate of a single Rust target", i+1, mapping.Source)
+		}
+		if mapping.Confidence < 0 || mapping.Confidence > 1 {
+			return fmt.Errorf("mapping %d (%s): confidence %v is not between 0 and 1", i+1, mapping.Source, mapping.Confidence)
+		}
+		if j, dup := seen[mapping.key()]; dup {
+			return fmt.Errorf("mapping %d (%s): %s is already mapped to %s by mapping %d", i+1, mapping.Source, mapping.Source, mapping.lang(), j)
+		}
+		seen[mapping.key()] = i + 1
+	}
+	return nil
+}
+
+// Index returns the mappings as an overlay of the mapping database, see
+// rinku.WithOverlay. Project mappings apply whether or not vulnerable libraries are
+// included: the project vouches for its targets. An empty target list replaces the
+// database entry with none.
+func (m *Mappings) Index() rinku.Index {
+	idx := rinku.Index{
+		Safe:         make(map[string][]string),
+		All:          make(map[string][]string),
+		CrateNames:   make(map[string]string),
+		RequiredDeps: make(map[string][]types.RequiredDep),
+		Categories:   make(map[string]string),
+		Confidences:  make(map[string]float64),
+	}
+	for _, mapping := range m.Mappings {
+		key := mapping.key()
+		targets := append([]string{}, mapping.Targets...)
+		idx.Safe[key], idx.All[key] = targets, targets
+		if mapping.Crate != "" {
+			idx.CrateNames[url.Normalize(mapping.Targets[0])] = mapping.Crate
+		}
+		if mapping.Category != "" {
+			idx.Categories[key] = mapping.Category
+		}
+		idx.Confidences[key] = 1
+		if mapping.Confidence > 0 {
+			idx.Confidences[key] = mapping.Confidence
+		}
+		if len(mapping.Requires) > 0 {
+			idx.RequiredDeps[key] = mapping.Requires
+		}
+	}
+	return idx
+}
@@ -0,0 +1,93 @@
+package projectmap
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stephan/rinku/internal/rinku"
+)
+
+func writeMappings(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Dir(Path(dir)), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(Path(dir), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoad(t *testing.T) {
+	if m, err := Load(t.TempDir()); m != nil || err != nil {
+		t.Fatalf("Load without file = %v, %v", m, err)
+	}
+
+	dir := writeMappings(t, `{"mappings": [
+	  {"source": "github.com/acme/auth/v2", "targets": ["https://git.acme.dev/rust/auth"], "crate": "acme-auth", "category": "auth"},
+	  {"source": "https://github.com/spf13/cobra", "targets": ["https://github.com/acme/cli"], "confidence": 0.7},
+	  {"source": "golang.org/x/exp", "targets": []},
+	  {"source": "github.com/acme/auth", "lang": "ts", "targets": ["https://github.com/acme/auth-js"]}
+	]}`)
+	m, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := rinku.Index{Safe: map[string][]string{
+		"rust:github.com/spf13/cobra": {"https://github.com/clap-rs/clap"},
+		"rust:github.com/golang/exp":  {"https://github.com/rust-lang/rust"},
+	}}
+	r := rinku.New(rinku.WithIndex(base), rinku.WithOverlay(m.Index()))
+
+	tests := []struct {
+		source, lang string
+		unsafe       bool
+		want         []string
+	}{
+		{"https://github.com/acme/auth", "rust", true, []string{"https://git.acme.dev/rust/auth"}},
+		{"https://github.com/spf13/cobra", "rust", false, []string{"https://github.com/acme/cli"}},
+		{"https://github.com/golang/exp", "rust", false, nil},
+		{"https://github.com/acme/auth", "js", false, []string{"https://github.com/acme/auth-js"}},
+	}
+	for _, tt := range tests {
+		if got := r.Lookup(tt.source, tt.lang, tt.unsafe); !slices.Equal(got, tt.want) {
+			t.Errorf("Lookup(%s, %s) = %v, want %v", tt.source, tt.lang, got, tt.want)
+		}
+		if !r.Overlaid(tt.source, tt.lang) {
+			t.Errorf("Overlaid(%s, %s) = false", tt.source, tt.lang)
+		}
+	}
+	if got := r.CrateName("https://git.acme.dev/rust/auth"); got != "acme-auth" {
+		t.Errorf("CrateName = %q", got)
+	}
+	if got := r.Category("https://github.com/acme/auth", "rust"); got != "auth" {
+		t.Errorf("Category = %q", got)
+	}
+	if got := r.Confidence("https://github.com/spf13/cobra", "rust"); got != 0.7 {
+		t.Errorf("Confidence = %v", got)
+	}
+	if got := r.Confidence("https://github.com/acme/auth", "rust"); got != 1 {
+		t.Errorf("Confidence without a value = %v, want 1", got)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct{ content, want string }{
+		{`{"mappings": [`, "parsing mappings.json"},
+		{`{"mappings": [{"targets": ["https://github.com/acme/cli"]}]}`, "mapping 1: missing source"},
+		{`{"mappings": [{"source": "github.com/acme/a", "targets": ["x", "y"], "crate": "a"}]}`, "crate names the crate of a single Rust target"},
+		{`{"mappings": [{"source": "github.com/acme/a", "targets": [], "confidence": 1.5}]}`, "mapping 1 (github.com/acme/a): confidence 1.5 is not between 0 and 1"},
+		{`{"mappings": [{"source": "github.com/acme/a", "targets": []}, {"source": "https://github.com/acme/a", "targets": []}]}`,
+			"mapping 2 (https://github.com/acme/a): https://github.com/acme/a is already mapped to rust by mapping 1"},
+	}
+	for _, tt := range tests {
+		_, err := Load(writeMappings(t, tt.content))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Load(%s) = %v, want %q", tt.content, err, tt.want)
+		}
+	}
+}
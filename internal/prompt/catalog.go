@@ -0,0 +1,96 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/stephan/rinku/internal/multistep"
+)
+
+// CatalogEnv names a directory of workflow markdown files, e.g. an organization's
+// library of vetted workflows, offered next to the embedded one.
+const CatalogEnv = "RINKU_PROMPTS_DIR"
+
+// DefaultWorkflow is the name of the embedded migration workflow.
+const DefaultWorkflow = "default"
+
+// Workflow is an entry of the workflow catalog.
+type Workflow struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Path        string `json:"path,omitempty"` // empty for the embedded workflow
+	Steps       int    `json:"steps"`
+}
+
+// Catalog returns the embedded workflow followed by the workflows of the
+// RINKU_PROMPTS_DIR directory, sorted by name.
+func Catalog() ([]Workflow, error) {
+	return CatalogDir(os.Getenv(CatalogEnv))
+}
+
+// CatalogDir returns the embedded workflow followed by the *.md workflows in dir, if
+// dir is not empty. A workflow is named by the "name" key of its frontmatter, or else
+// by its file name without extension; two workflows of the same name are an error.
+func CatalogDir(dir string) ([]Workflow, error) {
+	p, err := Migration()
+	if err != nil {
+		return nil, err
+	}
+	embedded := Workflow{Name: DefaultWorkflow, Description: p.Description(), Steps: len(p.Steps())}
+	if dir == "" {
+		return []Workflow{embedded}, nil
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return nil, fmt.Errorf("listing %s: %w", CatalogEnv, err)
+	}
+	if paths == nil {
+		if _, err := os.Stat(dir); err != nil {
+			return nil, fmt.Errorf("%s: %w", CatalogEnv, err)
+		}
+	}
+
+	seen := map[string]string{DefaultWorkflow: "the embedded workflow"}
+	var workflows []Workflow
+	for _, path := range paths {
+		p, err := multistep.ParseFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		w := Workflow{Name: p.Name(), Description: p.Description(), Path: path, Steps: len(p.Steps())}
+		if w.Name == "" {
+			w.Name = strings.TrimSuffix(filepath.Base(path), ".md")
+		}
+		if other, dup := seen[w.Name]; dup {
+			return nil, fmt.Errorf("%s: workflow %q is already defined by %s", filepath.Base(path), w.Name, other)
+		}
+		seen[w.Name] = filepath.Base(path)
+		workflows = append(workflows, w)
+	}
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].Name < workflows[j].Name })
+	return append([]Workflow{embedded}, workflows...), nil
+}
+
+// Load returns the workflow of the catalog named name; "" and "default" are the
+// embedded workflow.
+func Load(name string) (*multistep.Prompt, error) {
+	if name == "" || name == DefaultWorkflow {
+		return Migration()
+	}
+	workflows, err := Catalog()
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range workflows {
+		if w.Name == name {
+			return multistep.ParseFile(w.Path)
+		}
+	}
+	if os.Getenv(CatalogEnv) == "" {
+		return nil, fmt.Errorf("unknown workflow %q: set %s to the directory of the workflow catalog", name, CatalogEnv)
+	}
+	return nil, fmt.Errorf("unknown workflow %q; rinku workflows list shows the catalog", name)
+}
@@ -0,0 +1,65 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeWorkflow(t *testing.T, dir, file, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCatalogDir(t *testing.T) {
+	dir := t.TempDir()
+	writeWorkflow(t, dir, "b.md", "---\nname: zeta\ndescription: Last\n---\n# Step 1\nOne.\n")
+	writeWorkflow(t, dir, "a.md", "# Step 1\nOne.\n\n# Step 2\nTwo.\n")
+	writeWorkflow(t, dir, "notes.txt", "not a workflow")
+
+	workflows, err := CatalogDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, w := range workflows {
+		names = append(names, w.Name)
+	}
+	if got := strings.Join(names, ","); got != "default,a,zeta" {
+		t.Errorf("names = %s, want default,a,zeta", got)
+	}
+	if workflows[1].Steps != 2 || workflows[2].Description != "Last" {
+		t.Errorf("workflows = %+v", workflows)
+	}
+}
+
+func TestCatalogDir_Duplicate(t *testing.T) {
+	dir := t.TempDir()
+	writeWorkflow(t, dir, "a.md", "---\nname: default\n---\n# Step 1\nOne.\n")
+	if _, err := CatalogDir(dir); err == nil || !strings.Contains(err.Error(), "already defined by the embedded workflow") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeWorkflow(t, dir, "small.md", "# Step only\nOne.\n")
+	t.Setenv(CatalogEnv, dir)
+
+	p, err := Load("small")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Steps(); len(got) != 1 || got[0] != "only" {
+		t.Errorf("Steps() = %v", got)
+	}
+	if _, err := Load("missing"); err == nil {
+		t.Error("Load of an unknown workflow should fail")
+	}
+	if p, err := Load(DefaultWorkflow); err != nil || len(p.Steps()) < 2 {
+		t.Errorf("Load(default) = %v, %v", p, err)
+	}
+}
@@ -2,14 +2,68 @@ package prompt
 
 import (
 	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/stephan/rinku/internal/multistep"
+	"github.com/stephan/rinku/internal/progress"
 )
 
 //go:embed migration-prompt.md
 var migrationPrompt string
 
+// OverridesFile below .rinku reorders, disables or inserts steps of the migration
+// workflow for one project (see multistep.Overrides).
+const OverridesFile = "workflow-overrides.yaml"
+
 // Migration returns the parsed migration workflow prompt.
 func Migration() (*multistep.Prompt, error) {
 	return multistep.Parse(migrationPrompt)
 }
+
+// ForProject returns the migration workflow of the project, the catalog workflow its
+// migration was started with or the embedded one, with the project's overrides applied.
+func ForProject(projectDir string) (*multistep.Prompt, error) {
+	m, err := progress.Load(projectDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading progress: %w", err)
+	}
+	name := ""
+	if m != nil {
+		name = m.Workflow
+	}
+	return ForWorkflow(projectDir, name)
+}
+
+// ForWorkflow returns the catalog workflow named name with the project's overrides
+// applied.
+func ForWorkflow(projectDir, name string) (*multistep.Prompt, error) {
+	path := filepath.Join(projectDir, progress.ProgressDir, OverridesFile)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return Load(name)
+	}
+	return applyOverrides(name, path)
+}
+
+// WithOverrides returns the migration workflow with the overrides file at path applied,
+// e.g. to preview a workflow before installing it as the project's OverridesFile.
+func WithOverrides(path string) (*multistep.Prompt, error) {
+	return applyOverrides("", path)
+}
+
+func applyOverrides(name, path string) (*multistep.Prompt, error) {
+	p, err := Load(name)
+	if err != nil {
+		return nil, err
+	}
+	o, err := multistep.LoadOverrides(path)
+	if err != nil {
+		return nil, err
+	}
+	p, err = p.Apply(o)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return p, nil
+}
@@ -0,0 +1,68 @@
+// Package pypi is a minimal client for the PyPI JSON API (https://pypi.org).
+package pypi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/stephan/rinku/internal/httpclient"
+)
+
+const (
+	DefaultBaseURL = "https://pypi.org/pypi"
+
+	userAgent = "rinku (https://github.com/marvai-dev/rinku)"
+)
+
+// ErrNotFound is returned when a project does not exist on PyPI.
+var ErrNotFound = errors.New("project not found")
+
+// Client queries PyPI.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// New returns a client for the public PyPI API.
+func New() *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: httpclient.Default(),
+	}
+}
+
+// LatestVersion returns the newest release of a project, excluding pre-releases.
+func (c *Client) LatestVersion(ctx context.Context, name string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/"+url.PathEscape(name)+"/json", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetching project %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("fetching project %s: %w", name, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetching project %s: unexpected status: %s", name, resp.Status)
+	}
+	var body struct {
+		Info struct {
+			Version string `json:"version"`
+		} `json:"info"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding project %s: %w", name, err)
+	}
+	return body.Info.Version, nil
+}
@@ -0,0 +1,33 @@
+package pypi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLatestVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/requests/json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"info": {"name": "requests", "version": "2.32.3"}}`))
+	}))
+	defer srv.Close()
+	c := New()
+	c.BaseURL = srv.URL
+
+	v, err := c.LatestVersion(context.Background(), "requests")
+	if err != nil {
+		t.Fatalf("LatestVersion failed: %v", err)
+	}
+	if v != "2.32.3" {
+		t.Errorf("LatestVersion() = %q, want 2.32.3", v)
+	}
+	if _, err := c.LatestVersion(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("LatestVersion() error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,73 @@
+// Package reason is the taxonomy of why a migration step is skipped or a finding is
+// suppressed. Every skip and suppression carries one of its codes next to the free
+// text, so status and report can count the deferred work.
+package reason
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Code classifies a skipped step or a suppression.
+type Code string
+
+const (
+	NotApplicable Code = "not-applicable" // the work does not exist in this project
+	Deferred      Code = "deferred"       // still to do, after the migration
+	OutOfScope    Code = "out-of-scope"   // decided not to port
+	Blocked       Code = "blocked"        // waiting on something outside the project
+)
+
+// Codes lists the codes in the order they are reported.
+var Codes = []Code{NotApplicable, Deferred, OutOfScope, Blocked}
+
+// Parse returns the code named s.
+func Parse(s string) (Code, error) {
+	for _, c := range Codes {
+		if string(c) == s {
+			return c, nil
+		}
+	}
+	if s == "" {
+		return "", fmt.Errorf("missing reason code: use %s", List())
+	}
+	return "", fmt.Errorf("unknown reason code %q: use %s", s, List())
+}
+
+// List returns the codes for messages: "not-applicable, deferred, out-of-scope or
+// blocked".
+func List() string {
+	names := make([]string, len(Codes))
+	for i, c := range Codes {
+		names[i] = string(c)
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+// Counts counts skips or suppressions by code.
+type Counts map[Code]int
+
+// Total returns the number of counted items.
+func (c Counts) Total() int {
+	n := 0
+	for _, v := range c {
+		n += v
+	}
+	return n
+}
+
+// String lists the counts in the order of Codes, e.g. "deferred 2, blocked 1". Items
+// without a code, recorded before codes were required, count as "no code".
+func (c Counts) String() string {
+	var parts []string
+	for _, code := range Codes {
+		if n := c[code]; n > 0 {
+			parts = append(parts, string(code)+" "+strconv.Itoa(n))
+		}
+	}
+	if n := c[""]; n > 0 {
+		parts = append(parts, "no code "+strconv.Itoa(n))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,30 @@
+package reason
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	if c, err := Parse("out-of-scope"); c != OutOfScope || err != nil {
+		t.Errorf("Parse(out-of-scope) = %q, %v", c, err)
+	}
+	for s, want := range map[string]string{
+		"":      "missing reason code: use not-applicable, deferred, out-of-scope or blocked",
+		"later": `unknown reason code "later": use not-applicable, deferred, out-of-scope or blocked`,
+	} {
+		if _, err := Parse(s); err == nil || err.Error() != want {
+			t.Errorf("Parse(%q) = %v, want %q", s, err, want)
+		}
+	}
+}
+
+func TestCounts(t *testing.T) {
+	c := Counts{Blocked: 1, Deferred: 2, "": 1}
+	if got, want := c.String(), "deferred 2, blocked 1, no code 1"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if c.Total() != 4 {
+		t.Errorf("Total() = %d, want 4", c.Total())
+	}
+	if s := (Counts{}).String(); s != "" {
+		t.Errorf("empty String() = %q", s)
+	}
+}
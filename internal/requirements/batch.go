@@ -0,0 +1,99 @@
+package requirements
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+	"github.com/stephan/rinku/internal/pattern"
+)
+
+// GetAll returns all requirements matching pattern (all for ""), sorted by path. It
+// walks the store once and decodes the files concurrently, instead of one List and a
+// Get per path.
+func GetAll(projectDir, pat string) ([]*Requirement, error) {
+	return GetAllFS(afero.NewOsFs(), projectDir, pat)
+}
+
+// GetAllFS is GetAll on a filesystem (useful for testing). The Path of each requirement
+// is the one its file is stored under, with "/" separators.
+func GetAllFS(fsys afero.Fs, projectDir, pat string) ([]*Requirement, error) {
+	baseDir := requirementsDir(projectDir)
+	if err := pattern.Validate(NormalizePath(pat)); err != nil {
+		return nil, err
+	}
+	if _, err := fsys.Stat(baseDir); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var files, paths []string
+	err := afero.Walk(fsys, baseDir, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(p, ".json") {
+			return nil
+		}
+		rel, err := filepath.Rel(baseDir, p)
+		if err != nil {
+			return err
+		}
+		reqPath := strings.TrimSuffix(filepath.ToSlash(rel), ".json")
+		if pat != "" && !matchPattern(pat, reqPath) {
+			return nil
+		}
+		files = append(files, p)
+		paths = append(paths, reqPath)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing requirements: %w", err)
+	}
+
+	// Walk visits files in lexical order, so paths are sorted already.
+	reqs := make([]*Requirement, len(files))
+	errs := make([]error, len(files))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), len(files)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				reqs[i], errs[i] = readRequirement(fsys, files[i], paths[i])
+			}
+		}()
+	}
+	for i := range files {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return reqs, nil
+}
+
+// readRequirement decodes the requirement file at file, stored under reqPath.
+func readRequirement(fsys afero.Fs, file, reqPath string) (*Requirement, error) {
+	data, err := afero.ReadFile(fsys, file)
+	if err != nil {
+		return nil, fmt.Errorf("reading requirement: %w", err)
+	}
+	var req Requirement
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("parsing requirement %s: %w", reqPath, err)
+	}
+	req.Path = reqPath
+	return &req, nil
+}
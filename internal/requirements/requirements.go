@@ -11,4 +11,17 @@ type Requirement struct {
 	UpdatedAt time.Time  `json:"updated_at"`
 	Done      bool       `json:"done"`
 	DoneAt    *time.Time `json:"done_at,omitempty"`
+	CreatedBy string     `json:"created_by,omitempty"`
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	DoneBy    string     `json:"done_by,omitempty"`
+	// Resolution records how a public API item was carried over, see Resolve.
+	Resolution string `json:"resolution,omitempty"`
+	RenamedTo  string `json:"renamed_to,omitempty"`
 }
+
+// Resolutions of public API items.
+const (
+	Ported  = "ported"
+	Renamed = "renamed"
+	Dropped = "dropped"
+)
@@ -1,10 +1,16 @@
 package requirements
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/spf13/afero"
 	"github.com/stephan/rinku/internal/progress"
 )
 
@@ -78,7 +84,7 @@ func TestSet_AutoTagsStep(t *testing.T) {
 
 	// Create progress with current step
 	m := progress.New(dir, []string{"1", "2a", "2b"})
-	_ = m.StartStep("2a")
+	_ = m.StartStep("2a", "")
 	_ = m.Save(dir)
 
 	// Set requirement
@@ -93,6 +99,91 @@ func TestSet_AutoTagsStep(t *testing.T) {
 	}
 }
 
+func TestSetAndDone_RecordActor(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Setenv(progress.ActorEnv, "alice")
+	if err := Set(dir, "api/cli", "v1"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	t.Setenv(progress.ActorEnv, "agent:claude")
+	if err := Set(dir, "api/cli", "v2"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	t.Setenv(progress.ActorEnv, "bob")
+	if err := Done(dir, "api/cli"); err != nil {
+		t.Fatalf("Done failed: %v", err)
+	}
+
+	req, _ := Get(dir, "api/cli")
+	if req.CreatedBy != "alice" {
+		t.Errorf("CreatedBy = %q, want %q", req.CreatedBy, "alice")
+	}
+	if req.DoneBy != "bob" || req.UpdatedBy != "bob" {
+		t.Errorf("DoneBy, UpdatedBy = %q, %q, want bob", req.DoneBy, req.UpdatedBy)
+	}
+}
+
+func TestSetDoneResolve_RecordEvents(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(progress.ActorEnv, "alice")
+	if err := Set(dir, "pkg/client/Dial", "func"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Resolve(dir, "pkg/client/Dial", Renamed, "Client::connect"); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := progress.Events(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []progress.EventType
+	for _, e := range events {
+		types = append(types, e.Type)
+		if e.Requirement != "pkg/client/Dial" || e.Actor != "alice" {
+			t.Errorf("event = %+v", e)
+		}
+	}
+	want := []progress.EventType{progress.EventRequirementSet, progress.EventRequirementDone, progress.EventRequirementResolved}
+	if !slices.Equal(types, want) {
+		t.Fatalf("types = %v, want %v", types, want)
+	}
+	if got := events[2].Data["renamed_to"]; got != "Client::connect" {
+		t.Errorf("renamed_to = %v", got)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	for _, p := range []string{"pkg/client/New", "pkg/client/Dial", "pkg/client/Client.Do"} {
+		if err := Set(dir, p, "func"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := Resolve(dir, "pkg/client/New", Ported, ""); err != nil {
+		t.Fatalf("Resolve ported failed: %v", err)
+	}
+	if err := Resolve(dir, "pkg/client/Dial", Renamed, "Client::connect"); err != nil {
+		t.Fatalf("Resolve renamed failed: %v", err)
+	}
+	req, _ := Get(dir, "pkg/client/Dial")
+	if !req.Done || req.Resolution != Renamed || req.RenamedTo != "Client::connect" {
+		t.Errorf("renamed requirement = %+v", req)
+	}
+
+	if err := Resolve(dir, "pkg/client/Client.Do", Renamed, ""); err == nil {
+		t.Error("expected error for renamed without new name")
+	}
+	if err := Resolve(dir, "pkg/client/Client.Do", "kept", ""); err == nil {
+		t.Error("expected error for unknown resolution")
+	}
+	if err := Resolve(dir, "pkg/client/Missing", Dropped, ""); err == nil {
+		t.Error("expected error for missing requirement")
+	}
+}
+
 func TestSet_NestedPath(t *testing.T) {
 	dir := t.TempDir()
 
@@ -239,3 +330,291 @@ func TestList_WildcardMiddle(t *testing.T) {
 		t.Errorf("paths = %v, want [api/v1/users api/v2/users]", paths)
 	}
 }
+
+func TestNormalizePath(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"api/cli", "api/cli"},
+		{`api\cli`, "api/cli"},
+		{`api\cli\`, "api/cli"},
+		{"/api//cli/", "api/cli"},
+		{"./api/cli", "api/cli"},
+		{"API/Cli", "API/Cli"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizePath(tt.in); got != tt.want {
+			t.Errorf("NormalizePath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// writeReq stores a requirement file on fsys the way save lays it out.
+func writeReq(t *testing.T, fsys afero.Fs, file, reqPath string) {
+	t.Helper()
+	full := filepath.Join(requirementsDir("proj"), filepath.FromSlash(file)+".json")
+	data := []byte(`{"path": "` + reqPath + `", "content": "x"}`)
+	if err := afero.WriteFile(fsys, full, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListFS_SlashSeparators(t *testing.T) {
+	fsys := afero.NewMemMapFs()
+	writeReq(t, fsys, "svc/cli/flags/--port", "svc/cli/flags/--port")
+	writeReq(t, fsys, "svc/api/routes", "svc/api/routes")
+
+	paths, err := ListFS(fsys, "proj", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(paths, []string{"svc/api/routes", "svc/cli/flags/--port"}) {
+		t.Errorf("ListFS = %v", paths)
+	}
+	// Patterns typed with Windows separators match the same paths.
+	paths, _ = ListFS(fsys, "proj", `*\cli`)
+	if !reflect.DeepEqual(paths, []string{"svc/cli/flags/--port"}) {
+		t.Errorf(`ListFS(*\cli) = %v`, paths)
+	}
+}
+
+func TestGetFS_Case(t *testing.T) {
+	fsys := afero.NewMemMapFs()
+	writeReq(t, fsys, "api/cli", "api/cli")
+	// What a case-insensitive filesystem returns when API/cli is read after api/cli was
+	// written: the file of api/cli.
+	writeReq(t, fsys, "API/cli", "api/cli")
+
+	req, err := GetFS(fsys, "proj", `api\cli`)
+	if err != nil || req == nil || req.Path != "api/cli" {
+		t.Fatalf(`GetFS(api\cli) = %+v, %v`, req, err)
+	}
+	req, err = GetFS(fsys, "proj", "API/cli")
+	if err != nil || req != nil {
+		t.Errorf("GetFS(API/cli) = %+v, %v, want not found", req, err)
+	}
+}
+
+func TestCaseConflict(t *testing.T) {
+	fsys := afero.NewMemMapFs()
+	writeReq(t, fsys, "api/cli", "api/cli")
+	base := requirementsDir("proj")
+
+	tests := []struct{ path, want string }{
+		{"api/cli", ""},
+		{"api/web", ""},
+		{"worker/cli", ""},
+		{"API/web", "api"},
+		{"api/CLI", "api/cli"},
+		{"api/cli/flags", ""},
+	}
+	for _, tt := range tests {
+		got, err := caseConflict(fsys, base, tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("caseConflict(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSet_CaseConflict(t *testing.T) {
+	dir := t.TempDir()
+	if err := Set(dir, `api\cli`, "flags"); err != nil {
+		t.Fatal(err)
+	}
+	req, _ := Get(dir, "api/cli")
+	if req == nil || req.Path != "api/cli" {
+		t.Fatalf("Get(api/cli) = %+v", req)
+	}
+	if err := Set(dir, "API/cli", "flags"); err == nil || !strings.Contains(err.Error(), "differs only in case from existing api") {
+		t.Errorf("Set(API/cli) error = %v", err)
+	}
+	if err := Set(dir, "api/cli", "updated"); err != nil {
+		t.Errorf("Set(api/cli) error = %v", err)
+	}
+}
+
+func TestList_Globs(t *testing.T) {
+	dir := t.TempDir()
+	for _, p := range []string{"svc/api/routes/GET/users", "svc/api/v1/routes/POST/users", "svc/cli/flags/--port", "svc/cli/flags/--host"} {
+		if err := Set(dir, p, "x"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	paths, err := List(dir, "svc/api/**/routes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(paths, []string{"svc/api/routes/GET/users", "svc/api/v1/routes/POST/users"}) {
+		t.Errorf("List(svc/api/**/routes) = %v", paths)
+	}
+	paths, _ = List(dir, "*/cli/flags/--p*")
+	if !reflect.DeepEqual(paths, []string{"svc/cli/flags/--port"}) {
+		t.Errorf("List(*/cli/flags/--p*) = %v", paths)
+	}
+	if _, err := List(dir, "svc/[api"); err == nil {
+		t.Error("List with malformed pattern should fail")
+	}
+}
+
+func TestSummary(t *testing.T) {
+	dir := t.TempDir()
+	for _, p := range []string{"svc/cli/flags/--port", "svc/cli/flags/--host", "svc/api/routes/GET/users", "notes"} {
+		if err := Set(dir, p, "x"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := Done(dir, "svc/cli/flags/--port"); err != nil {
+		t.Fatal(err)
+	}
+	done, _ := Get(dir, "svc/cli/flags/--port")
+
+	s, err := Summary(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Total != 4 || s.Done != 1 || s.Coverage() != 25 {
+		t.Errorf("totals = %d/%d, coverage %d", s.Done, s.Total, s.Coverage())
+	}
+	if !reflect.DeepEqual(s.Pending, []string{"notes", "svc/api/routes/GET/users", "svc/cli/flags/--host"}) {
+		t.Errorf("pending = %v", s.Pending)
+	}
+	if !s.LastUpdated.Equal(*done.DoneAt) {
+		t.Errorf("last updated = %v, want %v", s.LastUpdated, *done.DoneAt)
+	}
+
+	var prefixes []string
+	for _, p := range s.Prefixes {
+		prefixes = append(prefixes, fmt.Sprintf("%s %d/%d", p.Prefix, p.Done, p.Total))
+	}
+	want := []string{"svc 1/3", "svc/api 0/1", "svc/api/routes 0/1", "svc/api/routes/GET 0/1", "svc/cli 1/2", "svc/cli/flags 1/2"}
+	if !reflect.DeepEqual(prefixes, want) {
+		t.Errorf("prefixes = %v, want %v", prefixes, want)
+	}
+}
+
+func TestSummary_Empty(t *testing.T) {
+	s, err := Summary(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Total != 0 || s.Coverage() != 0 || s.Pending == nil || s.Prefixes == nil {
+		t.Errorf("empty summary = %+v", s)
+	}
+}
+
+func TestGetAllFS(t *testing.T) {
+	fsys := afero.NewMemMapFs()
+	for _, p := range []string{"svc/cli/flags/--port", "svc/api/routes", "worker/cli/run", "notes"} {
+		writeReq(t, fsys, p, p)
+	}
+	writeReq(t, fsys, "svc/cli/moved", "old/path")
+
+	reqs, err := GetAllFS(fsys, "proj", "*/cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, req := range reqs {
+		paths = append(paths, req.Path)
+	}
+	want := []string{"svc/cli/flags/--port", "svc/cli/moved", "worker/cli/run"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+
+	all, err := GetAllFS(fsys, "proj", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 5 || all[0].Path != "notes" || all[0].Content != "x" {
+		t.Errorf("all = %d requirements, first %+v", len(all), all[0])
+	}
+
+	if _, err := GetAllFS(fsys, "proj", "svc/[cli"); err == nil {
+		t.Error("expected error for malformed pattern")
+	}
+	if err := afero.WriteFile(fsys, filepath.Join(requirementsDir("proj"), "broken.json"), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetAllFS(fsys, "proj", ""); err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("err = %v, want parse error naming broken", err)
+	}
+}
+
+func TestGetAllFS_Empty(t *testing.T) {
+	reqs, err := GetAllFS(afero.NewMemMapFs(), "proj", "")
+	if err != nil || reqs != nil {
+		t.Errorf("GetAllFS() = %v, %v; want nil, nil", reqs, err)
+	}
+}
+
+func TestNamespaced(t *testing.T) {
+	tests := []struct {
+		template, step, path, want string
+	}{
+		{"steps/{step}", "2a", "svc/cli/flags/--port", "steps/2a/svc/cli/flags/--port"},
+		{"steps/{step}", "2a", "steps/2a/svc/cli", "steps/2a/svc/cli"},
+		{"steps/{step}", "2a", "steps/2b/svc/cli", "steps/2a/steps/2b/svc/cli"},
+		{"{step}/", "Security Review", "/auth/", "Security Review/auth"},
+		{"steps/{step}", "", "svc/cli", "svc/cli"},
+		{"", "2a", "svc/cli", "svc/cli"},
+	}
+	for _, tt := range tests {
+		if got := Namespaced(tt.template, tt.step, tt.path); got != tt.want {
+			t.Errorf("Namespaced(%q, %q, %q) = %q, want %q", tt.template, tt.step, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestStripNamespace(t *testing.T) {
+	tests := []struct {
+		template, path, want string
+	}{
+		{"steps/{step}", "steps/2a/svc/cli/flags/--port", "svc/cli/flags/--port"},
+		{"steps/{step}", "steps/2a", "steps/2a"},
+		{"steps/{step}", "svc/cli", "svc/cli"},
+		{"phase-{step}", "phase-3/svc/api", "svc/api"},
+		{"", "steps/2a/svc/cli", "steps/2a/svc/cli"},
+	}
+	for _, tt := range tests {
+		if got := StripNamespace(tt.template, tt.path); got != tt.want {
+			t.Errorf("StripNamespace(%q, %q) = %q, want %q", tt.template, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestBurndown(t *testing.T) {
+	at := func(s string) time.Time {
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+	doneAt := at("2026-03-04T09:00:00Z")
+	reqs := []*Requirement{
+		{Path: "a", CreatedAt: at("2026-03-01T10:00:00Z"), Done: true, DoneAt: &doneAt},
+		{Path: "b", CreatedAt: at("2026-03-01T23:30:00Z")},
+		{Path: "c", CreatedAt: at("2026-03-02T08:00:00Z"), UpdatedAt: at("2026-03-02T09:00:00Z"), Done: true},
+	}
+
+	var got []string
+	for _, d := range Burndown(reqs, time.UTC) {
+		got = append(got, fmt.Sprintf("%s %d/%d %d", d.Date, d.Done, d.Total, d.Remaining))
+	}
+	want := []string{"2026-03-01 0/2 2", "2026-03-02 1/3 2", "2026-03-03 1/3 2", "2026-03-04 2/3 1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Burndown = %v, want %v", got, want)
+	}
+
+	// One hour east of UTC, b was created on March 2.
+	if days := Burndown(reqs, time.FixedZone("CET", 3600)); days[0].Total != 1 {
+		t.Errorf("first day in CET = %+v, want 1 requirement", days[0])
+	}
+	if days := Burndown(nil, time.UTC); len(days) != 0 {
+		t.Errorf("Burndown(nil) = %v", days)
+	}
+}
@@ -6,13 +6,17 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/natefinch/atomic"
+	"github.com/spf13/afero"
+	"github.com/stephan/rinku/internal/pattern"
 	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/internal/schema"
 )
 
 const (
@@ -26,10 +30,47 @@ type SafeReqPath struct {
 	p string
 }
 
+// NormalizePath returns the canonical form of a requirement path or pattern: segments
+// separated by "/" (a "\" from Windows input counts as a separator), cleaned, without
+// leading or trailing slashes. Case is kept; see Set for how casing is handled.
+func NormalizePath(reqPath string) string {
+	p := path.Clean("/" + strings.ReplaceAll(reqPath, `\`, "/"))
+	return strings.TrimPrefix(p, "/")
+}
+
+// Namespaced returns reqPath below the namespace of a step: template, such as
+// "steps/{step}", with {step} replaced by the step ID. An empty template or step, or a
+// path already in the namespace, leave reqPath unchanged.
+func Namespaced(template, step, reqPath string) string {
+	reqPath = NormalizePath(reqPath)
+	if template == "" || step == "" {
+		return reqPath
+	}
+	ns := NormalizePath(strings.ReplaceAll(template, "{step}", step))
+	if reqPath == ns || strings.HasPrefix(reqPath, ns+"/") {
+		return reqPath
+	}
+	return ns + "/" + reqPath
+}
+
+// StripNamespace returns reqPath without the namespace of any step, the inverse of
+// Namespaced: steps/3/svc/cli for template "steps/{step}" becomes svc/cli.
+func StripNamespace(template, reqPath string) string {
+	reqPath = NormalizePath(reqPath)
+	if template == "" {
+		return reqPath
+	}
+	ns := NormalizePath(strings.ReplaceAll(template, "{step}", "*"))
+	if prefix, ok := pattern.MatchPrefix(ns, reqPath); ok && prefix != reqPath {
+		return strings.TrimPrefix(reqPath, prefix+"/")
+	}
+	return reqPath
+}
+
 // newSafeReqPath creates a SafeReqPath after validating the path doesn't escape baseDir.
 func newSafeReqPath(projectDir, reqPath string) (SafeReqPath, error) {
 	baseDir := filepath.Join(projectDir, progress.ProgressDir, RequirementsDir)
-	fullPath := filepath.Join(baseDir, filepath.Clean(reqPath)+".json")
+	fullPath := filepath.Join(baseDir, filepath.FromSlash(path.Clean(strings.ReplaceAll(reqPath, `\`, "/")))+".json")
 
 	// Ensure the path is still under baseDir (prevent directory traversal)
 	if !strings.HasPrefix(fullPath, baseDir+string(filepath.Separator)) {
@@ -43,9 +84,27 @@ func (s SafeReqPath) Path() string {
 	return s.p
 }
 
-// Set creates or updates a requirement.
+// Set creates or updates a requirement, attributed to progress.Actor, and records it in
+// the event log.
+//
+// Paths are case-sensitive, but a path that differs only in case from an existing
+// one (api/cli and API/cli, or a new API/x next to api/cli) is rejected: on Windows
+// and macOS both would be stored in the same file or directory.
 func Set(projectDir, reqPath, content string) error {
+	if _, err := newSafeReqPath(projectDir, reqPath); err != nil {
+		return err
+	}
+	reqPath = NormalizePath(reqPath)
+	conflict, err := caseConflict(afero.NewOsFs(), requirementsDir(projectDir), reqPath)
+	if err != nil {
+		return err
+	}
+	if conflict != "" {
+		return fmt.Errorf("requirement path %s differs only in case from existing %s\nHint: Use the existing casing; paths that differ only in case collide on case-insensitive filesystems", reqPath, conflict)
+	}
+
 	now := time.Now()
+	by := progress.Actor(projectDir)
 
 	// Try to load existing requirement to preserve created_at
 	existing, _ := Get(projectDir, reqPath)
@@ -56,22 +115,35 @@ func Set(projectDir, reqPath, content string) error {
 		Step:      getCurrentStep(projectDir),
 		CreatedAt: now,
 		UpdatedAt: now,
+		CreatedBy: by,
+		UpdatedBy: by,
 	}
 
 	if existing != nil {
 		req.CreatedAt = existing.CreatedAt
+		req.CreatedBy = existing.CreatedBy
 	}
 
-	return save(projectDir, req)
+	if err := save(projectDir, req); err != nil {
+		return err
+	}
+	return progress.AppendEvent(projectDir, progress.Event{Time: now.UTC(), Type: progress.EventRequirementSet, Actor: by, Step: req.Step, Requirement: reqPath})
 }
 
 // Get retrieves a requirement by path.
 func Get(projectDir, reqPath string) (*Requirement, error) {
+	return GetFS(afero.NewOsFs(), projectDir, reqPath)
+}
+
+// GetFS retrieves a requirement from a filesystem (useful for testing). A file whose
+// recorded path differs in case, which a case-insensitive filesystem returns for
+// API/cli when api/cli exists, is not found, as on case-sensitive systems.
+func GetFS(fsys afero.Fs, projectDir, reqPath string) (*Requirement, error) {
 	safePath, err := newSafeReqPath(projectDir, reqPath)
 	if err != nil {
 		return nil, err
 	}
-	data, err := os.ReadFile(safePath.Path()) //#nosec G304 -- path validated by newSafeReqPath
+	data, err := afero.ReadFile(fsys, safePath.Path())
 	if os.IsNotExist(err) {
 		return nil, nil
 	}
@@ -83,24 +155,39 @@ func Get(projectDir, reqPath string) (*Requirement, error) {
 	if err := json.Unmarshal(data, &req); err != nil {
 		return nil, fmt.Errorf("parsing requirement: %w", err)
 	}
+	if req.Path != "" {
+		req.Path = NormalizePath(req.Path)
+		if req.Path != NormalizePath(reqPath) {
+			return nil, nil
+		}
+	}
 	return &req, nil
 }
 
 // List returns all requirement paths, optionally filtered by pattern.
-// Pattern supports * as a wildcard for a single path segment.
-func List(projectDir, pattern string) ([]string, error) {
-	baseDir := filepath.Join(projectDir, progress.ProgressDir, RequirementsDir)
+// Pattern supports the globs of package pattern, such as */cli and api/**/routes.
+func List(projectDir, pat string) ([]string, error) {
+	return ListFS(afero.NewOsFs(), projectDir, pat)
+}
 
-	if _, err := os.Stat(baseDir); os.IsNotExist(err) {
+// ListFS lists requirement paths on a filesystem (useful for testing). Paths are
+// returned with "/" separators on every OS.
+func ListFS(fsys afero.Fs, projectDir, pat string) ([]string, error) {
+	baseDir := requirementsDir(projectDir)
+	if err := pattern.Validate(NormalizePath(pat)); err != nil {
+		return nil, err
+	}
+
+	if _, err := fsys.Stat(baseDir); os.IsNotExist(err) {
 		return nil, nil
 	}
 
 	var paths []string
-	err := filepath.WalkDir(baseDir, func(path string, d fs.DirEntry, err error) error {
+	err := afero.Walk(fsys, baseDir, func(path string, info fs.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		if d.IsDir() {
+		if info.IsDir() {
 			return nil
 		}
 		if !strings.HasSuffix(path, ".json") {
@@ -112,10 +199,10 @@ func List(projectDir, pattern string) ([]string, error) {
 		if err != nil {
 			return err
 		}
-		reqPath := strings.TrimSuffix(relPath, ".json")
+		reqPath := strings.TrimSuffix(filepath.ToSlash(relPath), ".json")
 
 		// Filter by pattern if provided
-		if pattern != "" && !matchPattern(pattern, reqPath) {
+		if pat != "" && !matchPattern(pat, reqPath) {
 			return nil
 		}
 
@@ -143,7 +230,8 @@ func Delete(projectDir, reqPath string) error {
 	return nil
 }
 
-// Done marks a requirement as done.
+// Done marks a requirement as done, attributed to progress.Actor, and records it in the
+// event log.
 func Done(projectDir, reqPath string) error {
 	req, err := Get(projectDir, reqPath)
 	if err != nil {
@@ -154,14 +242,93 @@ func Done(projectDir, reqPath string) error {
 	}
 
 	now := time.Now()
+	by := progress.Actor(projectDir)
 	req.Done = true
 	req.DoneAt = &now
+	req.DoneBy = by
 	req.UpdatedAt = now
+	req.UpdatedBy = by
 
-	return save(projectDir, req)
+	if err := save(projectDir, req); err != nil {
+		return err
+	}
+	return progress.AppendEvent(projectDir, progress.Event{Time: now.UTC(), Type: progress.EventRequirementDone, Actor: by, Requirement: req.Path})
 }
 
-// save writes a requirement to disk atomically.
+// Resolve marks a requirement as done with a resolution: ported, renamed (to the name
+// in renamedTo) or dropped. A dropped item is done too, as the decision is made.
+func Resolve(projectDir, reqPath, resolution, renamedTo string) error {
+	switch resolution {
+	case Ported, Dropped:
+		if renamedTo != "" {
+			return fmt.Errorf("a new name only applies to %s items", Renamed)
+		}
+	case Renamed:
+		if renamedTo == "" {
+			return fmt.Errorf("%s items need the new name", Renamed)
+		}
+	default:
+		return fmt.Errorf("invalid resolution %q (want %s, %s or %s)", resolution, Ported, Renamed, Dropped)
+	}
+	if err := Done(projectDir, reqPath); err != nil {
+		return err
+	}
+	req, err := Get(projectDir, reqPath)
+	if err != nil {
+		return err
+	}
+	req.Resolution = resolution
+	req.RenamedTo = renamedTo
+	if err := save(projectDir, req); err != nil {
+		return err
+	}
+	data := map[string]any{"resolution": resolution}
+	if renamedTo != "" {
+		data["renamed_to"] = renamedTo
+	}
+	return progress.AppendEvent(projectDir, progress.Event{Type: progress.EventRequirementResolved, Actor: req.DoneBy, Requirement: req.Path, Data: data})
+}
+
+func requirementsDir(projectDir string) string {
+	return filepath.Join(projectDir, progress.ProgressDir, RequirementsDir)
+}
+
+// caseConflict returns the existing requirement path or directory that matches reqPath
+// when case is ignored but differs in case from it, or "" if there is none.
+func caseConflict(fsys afero.Fs, baseDir, reqPath string) (string, error) {
+	dir := baseDir
+	var existing []string
+	parts := strings.Split(reqPath, "/")
+	for i, part := range parts {
+		name := part
+		if i == len(parts)-1 {
+			name += ".json"
+		}
+		entries, err := afero.ReadDir(fsys, dir)
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("checking requirement path: %w", err)
+		}
+		exact := false
+		for _, e := range entries {
+			if e.Name() == name {
+				exact = true
+			} else if strings.EqualFold(e.Name(), name) {
+				return strings.Join(append(existing, strings.TrimSuffix(e.Name(), ".json")), "/"), nil
+			}
+		}
+		if !exact {
+			return "", nil
+		}
+		existing = append(existing, part)
+		dir = filepath.Join(dir, part)
+	}
+	return "", nil
+}
+
+// save writes a requirement to disk atomically, if it matches the requirement schema.
 func save(projectDir string, req *Requirement) error {
 	safePath, err := newSafeReqPath(projectDir, req.Path)
 	if err != nil {
@@ -177,6 +344,9 @@ func save(projectDir string, req *Requirement) error {
 	if err != nil {
 		return fmt.Errorf("marshaling requirement: %w", err)
 	}
+	if err := schema.Validate(schema.Requirement, data); err != nil {
+		return fmt.Errorf("refusing to write requirement %s: %w", req.Path, err)
+	}
 
 	return atomic.WriteFile(safePath.Path(), bytes.NewReader(append(data, '\n')))
 }
@@ -190,33 +360,9 @@ func getCurrentStep(projectDir string) string {
 	return m.GetCurrentStep()
 }
 
-// matchPattern checks if a requirement path matches the pattern.
-// * matches any single path segment (not including /).
-// Pattern acts as a prefix match (pattern can be shorter than path).
-// Trailing slashes are handled for prefix matching (e.g., "api/" matches "api/cli").
-func matchPattern(pattern, path string) bool {
-	// Handle trailing slash for prefix matching
-	pattern = strings.TrimSuffix(pattern, "/")
-	if pattern == "" {
-		return true // Empty pattern matches all
-	}
-
-	patternParts := strings.Split(pattern, "/")
-	pathParts := strings.Split(path, "/")
-
-	// Pattern must be a prefix match
-	if len(patternParts) > len(pathParts) {
-		return false
-	}
-
-	for i, pp := range patternParts {
-		if pp == "*" {
-			continue // Wildcard matches anything
-		}
-		if pp != pathParts[i] {
-			return false
-		}
-	}
-
-	return true
+// matchPattern checks if a requirement path matches the pattern, see package pattern:
+// * matches within a segment, ** any number of segments, and the pattern acts as a
+// prefix match (e.g., "api/" matches "api/cli").
+func matchPattern(pat, reqPath string) bool {
+	return pattern.Match(NormalizePath(pat), reqPath)
 }
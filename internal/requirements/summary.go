@@ -0,0 +1,149 @@
+package requirements
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// Totals counts the requirements of a project, see Summary.
+type Totals struct {
+	Total       int            `json:"total"`
+	Done        int            `json:"done"`
+	LastUpdated time.Time      `json:"last_updated,omitzero"`
+	Pending     []string       `json:"pending"`  // paths of requirements not done, sorted
+	Prefixes    []PrefixTotals `json:"prefixes"` // every parent path, sorted
+}
+
+// PrefixTotals counts the requirements below a parent path such as svc or svc/cli.
+type PrefixTotals struct {
+	Prefix      string    `json:"prefix"`
+	Total       int       `json:"total"`
+	Done        int       `json:"done"`
+	LastUpdated time.Time `json:"last_updated,omitzero"`
+}
+
+// Coverage returns the share of done requirements in percent, 0 without requirements.
+func (t *Totals) Coverage() int {
+	if t.Total == 0 {
+		return 0
+	}
+	return t.Done * 100 / t.Total
+}
+
+// Summary counts all requirements, done ones and pending paths, with a breakdown per
+// parent path (svc/cli/flags/--port counts for svc, svc/cli and svc/cli/flags) and the
+// latest update or completion time. It reads every requirement file once, see GetAll.
+func Summary(projectDir string) (*Totals, error) {
+	return SummaryFS(afero.NewOsFs(), projectDir)
+}
+
+// SummaryFS is Summary on a filesystem (useful for testing).
+func SummaryFS(fsys afero.Fs, projectDir string) (*Totals, error) {
+	reqs, err := GetAllFS(fsys, projectDir, "")
+	if err != nil {
+		return nil, fmt.Errorf("summarizing requirements: %w", err)
+	}
+
+	t := &Totals{Pending: []string{}, Prefixes: []PrefixTotals{}}
+	prefixes := make(map[string]*PrefixTotals)
+	for _, req := range reqs {
+		updated := req.UpdatedAt
+		if req.DoneAt != nil && req.DoneAt.After(updated) {
+			updated = *req.DoneAt
+		}
+		t.Total++
+		if req.Done {
+			t.Done++
+		} else {
+			t.Pending = append(t.Pending, req.Path)
+		}
+		if updated.After(t.LastUpdated) {
+			t.LastUpdated = updated
+		}
+
+		segs := strings.Split(req.Path, "/")
+		for i := 1; i < len(segs); i++ {
+			prefix := strings.Join(segs[:i], "/")
+			pt := prefixes[prefix]
+			if pt == nil {
+				pt = &PrefixTotals{Prefix: prefix}
+				prefixes[prefix] = pt
+			}
+			pt.Total++
+			if req.Done {
+				pt.Done++
+			}
+			if updated.After(pt.LastUpdated) {
+				pt.LastUpdated = updated
+			}
+		}
+	}
+
+	sort.Strings(t.Pending)
+	for _, pt := range prefixes {
+		t.Prefixes = append(t.Prefixes, *pt)
+	}
+	sort.Slice(t.Prefixes, func(i, j int) bool { return t.Prefixes[i].Prefix < t.Prefixes[j].Prefix })
+	return t, nil
+}
+
+// BurndownDay counts the requirements that existed, and those done, at the end of a day.
+type BurndownDay struct {
+	Date      string `json:"date"` // YYYY-MM-DD
+	Total     int    `json:"total"`
+	Done      int    `json:"done"`
+	Remaining int    `json:"remaining"`
+}
+
+// Burndown returns one entry per calendar day in loc, from the day the first requirement
+// was created to the day of the latest creation or completion, with days without changes
+// filled in. A requirement counts from its CreatedAt and as done from its DoneAt; one
+// marked done without DoneAt counts as done from its UpdatedAt.
+func Burndown(reqs []*Requirement, loc *time.Location) []BurndownDay {
+	day := func(t time.Time) time.Time {
+		t = t.In(loc)
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	}
+	created := make(map[time.Time]int)
+	done := make(map[time.Time]int)
+	var first, last time.Time
+	see := func(d time.Time) {
+		if first.IsZero() || d.Before(first) {
+			first = d
+		}
+		if d.After(last) {
+			last = d
+		}
+	}
+	for _, req := range reqs {
+		c := day(req.CreatedAt)
+		created[c]++
+		see(c)
+		if !req.Done {
+			continue
+		}
+		at := req.UpdatedAt
+		if req.DoneAt != nil {
+			at = *req.DoneAt
+		}
+		d := day(at)
+		done[d]++
+		see(d)
+	}
+
+	days := []BurndownDay{}
+	if first.IsZero() {
+		return days
+	}
+	var total, doneTotal int
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		total += created[d]
+		doneTotal += done[d]
+		days = append(days, BurndownDay{Date: d.Format(time.DateOnly), Total: total, Done: doneTotal, Remaining: total - doneTotal})
+	}
+	return days
+}
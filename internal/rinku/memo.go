@@ -0,0 +1,94 @@
+package rinku
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/stephan/rinku/internal/types"
+	"github.com/stephan/rinku/internal/url"
+)
+
+// memoKey identifies a forward lookup: the normalized source URL, the lowercase target
+// language and whether vulnerable libraries are included.
+type memoKey struct {
+	url    string
+	lang   string
+	unsafe bool
+}
+
+// memo caches the results of Lookup, RequiredDeps, Category and Confidence for one
+// run, see Memoize. URLs that normalize to the same key share an entry.
+type memo struct {
+	mu           sync.Mutex
+	normalized   map[string]string // source URL as given -> normalized URL
+	lookups      map[memoKey][]string
+	requiredDeps map[memoKey][]types.RequiredDep
+	categories   map[memoKey]string
+	confidences  map[memoKey]float64
+	stats        CacheStats
+}
+
+// CacheStats counts the lookups answered by a memoized Rinku.
+type CacheStats struct {
+	Hits   int
+	Misses int
+}
+
+// Lookups returns the number of cached calls.
+func (s CacheStats) Lookups() int {
+	return s.Hits + s.Misses
+}
+
+// HitRate returns the share of calls answered from the cache, 0 without calls.
+func (s CacheStats) HitRate() float64 {
+	if s.Lookups() == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Lookups())
+}
+
+// Memoize returns a Rinku sharing r's indexes that remembers forward lookups, for a
+// command mapping many dependencies, e.g. workspace members requiring the same modules.
+// It is safe for concurrent use; CacheStats reports its hits.
+func (r *Rinku) Memoize() *Rinku {
+	m := *r
+	m.memo = &memo{
+		normalized:   make(map[string]string),
+		lookups:      make(map[memoKey][]string),
+		requiredDeps: make(map[memoKey][]types.RequiredDep),
+		categories:   make(map[memoKey]string),
+		confidences:  make(map[memoKey]float64),
+	}
+	return &m
+}
+
+// CacheStats returns the hits and misses of a Rinku returned by Memoize; zero for
+// others.
+func (r *Rinku) CacheStats() CacheStats {
+	if r.memo == nil {
+		return CacheStats{}
+	}
+	r.memo.mu.Lock()
+	defer r.memo.mu.Unlock()
+	return r.memo.stats
+}
+
+// cached returns the entry of cache for the lookup, calling compute on a miss.
+func cached[V any](m *memo, cache map[memoKey]V, sourceURL, lang string, unsafe bool, compute func() V) V {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	normalized, ok := m.normalized[sourceURL]
+	if !ok {
+		normalized = url.Normalize(sourceURL)
+		m.normalized[sourceURL] = normalized
+	}
+	key := memoKey{url: normalized, lang: strings.ToLower(lang), unsafe: unsafe}
+	if v, ok := cache[key]; ok {
+		m.stats.Hits++
+		return v
+	}
+	m.stats.Misses++
+	v := compute()
+	cache[key] = v
+	return v
+}
@@ -0,0 +1,113 @@
+package rinku
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stephan/rinku/internal/types"
+)
+
+func TestMemoize(t *testing.T) {
+	index := map[string][]string{"rust:github.com/spf13/cobra": {"https://github.com/clap-rs/clap"}}
+	all := map[string][]string{
+		"rust:github.com/spf13/cobra": {"https://github.com/clap-rs/clap"},
+		"rust:github.com/golang/net":  {"https://github.com/hyperium/hyper"},
+	}
+	requiredDeps := map[string][]types.RequiredDep{"rust:github.com/spf13/cobra": {{Crate: "clap", Features: []string{"derive"}}}}
+	categories := map[string]string{"rust:github.com/spf13/cobra": "cli"}
+	r := New(WithIndex(Index{
+		Safe:         index,
+		All:          all,
+		RequiredDeps: requiredDeps,
+		Categories:   categories,
+	}))
+	m := r.Memoize()
+
+	want := []string{"https://github.com/clap-rs/clap"}
+	if got := m.Lookup("https://github.com/spf13/cobra", "rust", false); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Lookup = %v, want %v", got, want)
+	}
+	// Aliases of the same URL share the entry
+	if got := m.Lookup("github.com/spf13/cobra/", "Rust", false); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Lookup (alias) = %v, want %v", got, want)
+	}
+	if s := m.CacheStats(); s != (CacheStats{Hits: 1, Misses: 1}) {
+		t.Errorf("CacheStats = %+v, want 1 hit, 1 miss", s)
+	}
+
+	// unsafe is part of the key
+	if got := m.Lookup("https://github.com/golang/net", "rust", false); got != nil {
+		t.Errorf("safe Lookup = %v, want none", got)
+	}
+	if got := m.Lookup("https://github.com/golang/net", "rust", true); len(got) != 1 {
+		t.Errorf("unsafe Lookup = %v, want hyper", got)
+	}
+	if got := m.Category("https://github.com/spf13/cobra", "rust"); got != "cli" {
+		t.Errorf("Category = %q", got)
+	}
+	if got := m.RequiredDeps("https://github.com/spf13/cobra", "rust"); len(got) != 1 || got[0].Crate != "clap" {
+		t.Errorf("RequiredDeps = %v", got)
+	}
+	if got := m.Category("https://github.com/spf13/cobra", "rust"); got != "cli" {
+		t.Errorf("Category (cached) = %q", got)
+	}
+	if s := m.CacheStats(); s.Hits != 2 || s.Misses != 5 || s.Lookups() != 7 {
+		t.Errorf("CacheStats = %+v, want 2 hits, 5 misses", s)
+	}
+
+	// The original is not memoized
+	r.Lookup("https://github.com/spf13/cobra", "rust", false)
+	if s := r.CacheStats(); s != (CacheStats{}) {
+		t.Errorf("CacheStats of r = %+v, want zero", s)
+	}
+}
+
+func TestCacheStats_HitRate(t *testing.T) {
+	if got := (CacheStats{}).HitRate(); got != 0 {
+		t.Errorf("HitRate without lookups = %v", got)
+	}
+	if got := (CacheStats{Hits: 1, Misses: 3}).HitRate(); got != 0.25 {
+		t.Errorf("HitRate = %v, want 0.25", got)
+	}
+}
+
+func TestObserve(t *testing.T) {
+	index := map[string][]string{"rust:github.com/spf13/cobra": {"https://github.com/clap-rs/clap"}}
+	var seen []string
+	r := New(WithIndex(Index{Safe: index, All: index}))
+	o := r.Observe(func(targetLang, sourceURL string) { seen = append(seen, targetLang+" "+sourceURL) })
+
+	o.Lookup("https://github.com/spf13/cobra", "rust", false)
+	o.Memoize().Lookup("https://github.com/spf13/cobra/", "rust", false)
+	o.Lookup("https://github.com/acme/private", "rust", false) // no entry, not observed
+	r.Lookup("https://github.com/spf13/cobra", "rust", false)  // the original is not observed
+	want := []string{"rust github.com/spf13/cobra", "rust github.com/spf13/cobra"}
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("observed %v, want %v", seen, want)
+	}
+}
+
+func TestObserve_OverlayAndResolver(t *testing.T) {
+	index := map[string][]string{"rust:github.com/spf13/cobra": {"https://github.com/clap-rs/clap"}}
+	overlay := map[string][]string{
+		"rust:github.com/acme/auth":   {"https://git.acme.dev/rust/auth"},
+		"rust:github.com/spf13/cobra": {"https://github.com/acme/acme-cli"},
+	}
+	resolver := ResolverFunc(func(sourceURL, targetLang string, includeUnsafe bool) ([]string, error) {
+		return []string{"https://git.acme.dev/rust/billing"}, nil
+	})
+	var seen []string
+	r := New(WithIndex(Index{Safe: index, All: index}), WithOverlay(Index{Safe: overlay, All: overlay}), WithResolver(resolver)).
+		Observe(func(targetLang, sourceURL string) { seen = append(seen, targetLang+" "+sourceURL) })
+
+	for _, u := range []string{"https://github.com/acme/auth", "https://github.com/spf13/cobra", "https://github.com/acme/billing"} {
+		for _, unsafe := range []bool{false, true} {
+			if got := r.Lookup(u, "rust", unsafe); len(got) != 1 {
+				t.Fatalf("Lookup(%s) = %v", u, got)
+			}
+		}
+	}
+	if len(seen) != 0 {
+		t.Errorf("observed %v, want no overlay or resolver hits", seen)
+	}
+}
@@ -0,0 +1,126 @@
+package rinku
+
+import (
+	"runtime"
+	"strings"
+	"time"
+)
+
+// IndexProfile is the size of one in-memory index.
+type IndexProfile struct {
+	Name    string
+	Keys    int
+	Targets int    // library URLs over all keys
+	Bytes   uint64 // heap bytes of the map and its slices
+}
+
+// LookupProfile is the measured throughput of a lookup method.
+type LookupProfile struct {
+	Name          string
+	Lookups       int
+	Elapsed       time.Duration
+	AllocsPerCall float64
+}
+
+// PerSecond returns lookups per second.
+func (p LookupProfile) PerSecond() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+	return float64(p.Lookups) / p.Elapsed.Seconds()
+}
+
+// NsPerOp returns the average duration of one lookup in nanoseconds.
+func (p LookupProfile) NsPerOp() float64 {
+	if p.Lookups == 0 {
+		return 0
+	}
+	return float64(p.Elapsed.Nanoseconds()) / float64(p.Lookups)
+}
+
+// Profile describes the memory footprint and lookup throughput of the indexes.
+type Profile struct {
+	Indexes []IndexProfile
+	Lookups []LookupProfile
+}
+
+// Bytes returns the heap footprint of all indexes.
+func (p *Profile) Bytes() uint64 {
+	var total uint64
+	for _, idx := range p.Indexes {
+		total += idx.Bytes
+	}
+	return total
+}
+
+// Profile measures the four mapping indexes. The footprint of each one is the bytes
+// allocated by rebuilding it; strings are shared with the original, so it counts the map
+// and slices only, which is what a resident server holds beyond the URL data. Lookup
+// and ReverseLookup are run over every source and target URL of the index for about
+// d each, bypassing a Memoize cache.
+func (r *Rinku) Profile(d time.Duration) *Profile {
+	p := &Profile{}
+	for _, idx := range []struct {
+		name string
+		m    map[string][]string
+	}{
+		{"forward (safe)", r.safe},
+		{"forward (all)", r.all},
+		{"reverse (safe)", r.reverseSafe},
+		{"reverse (all)", r.reverseAll},
+	} {
+		targets := 0
+		for _, urls := range idx.m {
+			targets += len(urls)
+		}
+		p.Indexes = append(p.Indexes, IndexProfile{Name: idx.name, Keys: len(idx.m), Targets: targets, Bytes: heapBytes(idx.m)})
+	}
+
+	p.Lookups = append(p.Lookups,
+		measureLookups("Lookup", lookupArgs(r.all), d, func(lang, u string) { r.lookup(u, lang, false) }),
+		measureLookups("ReverseLookup", lookupArgs(r.reverseAll), d, func(lang, u string) { r.ReverseLookup(u, lang, true) }))
+	return p
+}
+
+// heapBytes returns the bytes allocated by copying m.
+func heapBytes(m map[string][]string) uint64 {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	clone := make(map[string][]string, len(m))
+	for k, v := range m {
+		clone[k] = append([]string(nil), v...)
+	}
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(clone)
+	return after.TotalAlloc - before.TotalAlloc
+}
+
+// lookupArgs turns the "lang:normalized_url" keys of an index into lookup arguments.
+func lookupArgs(m map[string][]string) [][2]string {
+	args := make([][2]string, 0, len(m))
+	for key := range m {
+		lang, u, _ := strings.Cut(key, ":")
+		args = append(args, [2]string{lang, "https://" + u})
+	}
+	return args
+}
+
+func measureLookups(name string, args [][2]string, d time.Duration, lookup func(lang, u string)) LookupProfile {
+	p := LookupProfile{Name: name}
+	if len(args) == 0 {
+		return p
+	}
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for time.Since(start) < d {
+		for _, a := range args {
+			lookup(a[0], a[1])
+		}
+		p.Lookups += len(args)
+	}
+	p.Elapsed = time.Since(start)
+	runtime.ReadMemStats(&after)
+	p.AllocsPerCall = float64(after.Mallocs-before.Mallocs) / float64(p.Lookups)
+	return p
+}
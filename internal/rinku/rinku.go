@@ -1,8 +1,11 @@
 package rinku
 
 import (
+	"log"
+	"sort"
 	"strings"
 
+	"github.com/stephan/rinku/internal/manifest"
 	"github.com/stephan/rinku/internal/types"
 	"github.com/stephan/rinku/internal/url"
 )
@@ -16,17 +19,161 @@ type Rinku struct {
 	crateNames   map[string]string               // normalized_url -> crate_name
 	tags         map[string][]string             // normalized_url -> tags
 	requiredDeps map[string][]types.RequiredDep  // target_lang:source_url -> required deps
+	categories   map[string]string               // target_lang:source_url -> mapping category
+	confidences  map[string]float64              // target_lang:source_url -> mapping confidence
+	packages     map[string]string               // lang:package_name -> library URL
+	packageNames map[string][]string             // lang:normalized_url -> package names, sorted
+	overlaid     map[string]bool                 // forward keys of overlay entries
+	resolver     Resolver                        // nil unless set by WithResolver
+	logger       *log.Logger                     // nil unless set by WithLogger
+	memo         *memo                           // nil unless created by Memoize
+	observe      func(targetLang, sourceURL string) // nil unless created by Observe
 }
 
-func New(safe, all, reverseSafe, reverseAll map[string][]string, crateNames map[string]string, tags map[string][]string, requiredDeps map[string][]types.RequiredDep) *Rinku {
-	return &Rinku{
-		safe:         safe,
-		all:          all,
-		reverseSafe:  reverseSafe,
-		reverseAll:   reverseAll,
-		crateNames:   crateNames,
-		tags:         tags,
-		requiredDeps: requiredDeps,
+// Index is the lookup data of a Rinku, as generated from the library database. Keys of
+// language-qualified maps are "lang:normalized_url"; nil maps are empty.
+type Index struct {
+	Safe         map[string][]string // forward mappings, excluding vulnerable libraries
+	All          map[string][]string // forward mappings, including vulnerable libraries
+	ReverseSafe  map[string][]string
+	ReverseAll   map[string][]string
+	CrateNames   map[string]string   // normalized_url -> crate_name
+	Tags         map[string][]string // normalized_url -> tags
+	RequiredDeps map[string][]types.RequiredDep
+	Categories   map[string]string
+	Confidences  map[string]float64 // mapping confidence from 0 to 1
+	Packages     map[string]string  // lang:package_name -> library URL
+}
+
+// Resolver answers the forward lookups the index has no entry for, e.g. from a remote
+// database. Its error is logged and the lookup treated as a miss.
+type Resolver interface {
+	Resolve(sourceURL, targetLang string, includeUnsafe bool) ([]string, error)
+}
+
+// ResolverFunc adapts a function to a Resolver.
+type ResolverFunc func(sourceURL, targetLang string, includeUnsafe bool) ([]string, error)
+
+func (f ResolverFunc) Resolve(sourceURL, targetLang string, includeUnsafe bool) ([]string, error) {
+	return f(sourceURL, targetLang, includeUnsafe)
+}
+
+// Option configures a Rinku created by New.
+type Option func(*options)
+
+type options struct {
+	index    Index
+	overlays []Index
+	resolver Resolver
+	logger   *log.Logger
+}
+
+// WithIndex sets the index lookups are answered from.
+func WithIndex(idx Index) Option {
+	return func(o *options) { o.index = idx }
+}
+
+// WithOverlay layers entries over the index, e.g. project overrides: an entry of the
+// overlay replaces the index entry with the same key. Later overlays win.
+func WithOverlay(idx Index) Option {
+	return func(o *options) { o.overlays = append(o.overlays, idx) }
+}
+
+// WithResolver consults r for forward lookups without an index entry.
+func WithResolver(r Resolver) Option {
+	return func(o *options) { o.resolver = r }
+}
+
+// WithLogger logs replaced index entries and resolver errors to l.
+func WithLogger(l *log.Logger) Option {
+	return func(o *options) { o.logger = l }
+}
+
+// New returns a Rinku configured by opts; without WithIndex it knows no libraries.
+func New(opts ...Option) *Rinku {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	r := &Rinku{resolver: o.resolver, logger: o.logger}
+	idx := o.index
+	for _, over := range o.overlays {
+		for _, m := range []map[string][]string{over.Safe, over.All} {
+			for k := range m {
+				if r.overlaid == nil {
+					r.overlaid = make(map[string]bool)
+				}
+				r.overlaid[k] = true
+			}
+		}
+		idx = Index{
+			Safe:         merge(r, idx.Safe, over.Safe),
+			All:          merge(r, idx.All, over.All),
+			ReverseSafe:  merge(r, idx.ReverseSafe, over.ReverseSafe),
+			ReverseAll:   merge(r, idx.ReverseAll, over.ReverseAll),
+			CrateNames:   merge(r, idx.CrateNames, over.CrateNames),
+			Tags:         merge(r, idx.Tags, over.Tags),
+			RequiredDeps: merge(r, idx.RequiredDeps, over.RequiredDeps),
+			Categories:   merge(r, idx.Categories, over.Categories),
+			Confidences:  merge(r, idx.Confidences, over.Confidences),
+			Packages:     merge(r, idx.Packages, over.Packages),
+		}
+	}
+	r.safe, r.all = idx.Safe, idx.All
+	r.reverseSafe, r.reverseAll = idx.ReverseSafe, idx.ReverseAll
+	r.crateNames, r.tags = idx.CrateNames, idx.Tags
+	r.requiredDeps, r.categories, r.packages = idx.RequiredDeps, idx.Categories, idx.Packages
+	r.confidences = idx.Confidences
+	r.packageNames = make(map[string][]string)
+	for key, libURL := range r.packages {
+		lang, name, _ := strings.Cut(key, ":")
+		names := lang + ":" + url.Normalize(libURL)
+		r.packageNames[names] = append(r.packageNames[names], name)
+	}
+	for _, names := range r.packageNames {
+		sort.Strings(names)
+	}
+	return r
+}
+
+// NewFromMaps returns a Rinku with the given index maps.
+//
+// Deprecated: use New(WithIndex(Index{...})).
+func NewFromMaps(safe, all, reverseSafe, reverseAll map[string][]string, crateNames map[string]string, tags map[string][]string, requiredDeps map[string][]types.RequiredDep, categories, packages map[string]string) *Rinku {
+	return New(WithIndex(Index{
+		Safe:         safe,
+		All:          all,
+		ReverseSafe:  reverseSafe,
+		ReverseAll:   reverseAll,
+		CrateNames:   crateNames,
+		Tags:         tags,
+		RequiredDeps: requiredDeps,
+		Categories:   categories,
+		Packages:     packages,
+	}))
+}
+
+// merge returns base with the entries of over, copying base rather than modifying it.
+func merge[V any](r *Rinku, base, over map[string]V) map[string]V {
+	if len(over) == 0 {
+		return base
+	}
+	merged := make(map[string]V, len(base)+len(over))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range over {
+		if _, ok := base[k]; ok {
+			r.logf("overlay replaces %s", k)
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+func (r *Rinku) logf(format string, args ...any) {
+	if r.logger != nil {
+		r.logger.Printf(format, args...)
 	}
 }
 
@@ -43,24 +190,151 @@ func (r *Rinku) Tags(libURL string) []string {
 }
 
 func (r *Rinku) Lookup(sourceURL, targetLang string, includeUnsafe bool) []string {
-	key := strings.ToLower(targetLang) + ":" + url.Normalize(sourceURL)
+	var targets []string
+	if r.memo != nil {
+		targets = cached(r.memo, r.memo.lookups, sourceURL, targetLang, includeUnsafe, func() []string {
+			return r.lookup(sourceURL, targetLang, includeUnsafe)
+		})
+	} else {
+		targets = r.lookup(sourceURL, targetLang, includeUnsafe)
+	}
+	if r.observe != nil && len(targets) > 0 && r.fromDatabase(sourceURL, targetLang, includeUnsafe) {
+		r.observe(targetLang, url.Normalize(sourceURL))
+	}
+	return targets
+}
+
+// fromDatabase reports whether the lookup is answered by the index rather than an
+// overlay or the resolver.
+func (r *Rinku) fromDatabase(sourceURL, targetLang string, includeUnsafe bool) bool {
+	if r.Overlaid(sourceURL, targetLang) {
+		return false
+	}
+	if includeUnsafe {
+		return len(get(r.all, targetLang, sourceURL)) > 0
+	}
+	return len(get(r.safe, targetLang, sourceURL)) > 0
+}
+
+// Observe returns a Rinku sharing r's indexes that calls f with the target language and
+// normalized source URL of every Lookup finding a database entry, e.g. to count the
+// entries users hit. Lookups without a result, and those answered by an overlay or the
+// resolver, are not observed: their URLs may name private modules. f must be safe for
+// concurrent use if the Rinku is.
+func (r *Rinku) Observe(f func(targetLang, sourceURL string)) *Rinku {
+	o := *r
+	o.observe = f
+	return &o
+}
+
+func (r *Rinku) lookup(sourceURL, targetLang string, includeUnsafe bool) []string {
+	var targets []string
 	if includeUnsafe {
-		return r.all[key]
+		targets = get(r.all, targetLang, sourceURL)
+	} else {
+		targets = get(r.safe, targetLang, sourceURL)
+	}
+	if len(targets) > 0 || r.resolver == nil {
+		return targets
 	}
-	return r.safe[key]
+	targets, err := r.resolver.Resolve(sourceURL, targetLang, includeUnsafe)
+	if err != nil {
+		r.logf("resolving %s: %v", sourceURL, err)
+		return nil
+	}
+	return targets
+}
+
+// Overlaid reports whether the forward lookup of sourceURL in targetLang is answered by
+// an overlay rather than the index, e.g. to mark project overrides in the output.
+func (r *Rinku) Overlaid(sourceURL, targetLang string) bool {
+	return get(r.overlaid, targetLang, sourceURL)
 }
 
 func (r *Rinku) ReverseLookup(targetURL, sourceLang string, includeUnsafe bool) []string {
-	key := strings.ToLower(sourceLang) + ":" + url.Normalize(targetURL)
 	if includeUnsafe {
-		return r.reverseAll[key]
+		return get(r.reverseAll, sourceLang, targetURL)
 	}
-	return r.reverseSafe[key]
+	return get(r.reverseSafe, sourceLang, targetURL)
 }
 
 // RequiredDeps returns the required dependencies for a lookup.
 // Uses the same key format as Lookup: targetLang:sourceURL
 func (r *Rinku) RequiredDeps(sourceURL, targetLang string) []types.RequiredDep {
-	key := strings.ToLower(targetLang) + ":" + url.Normalize(sourceURL)
-	return r.requiredDeps[key]
+	if r.memo != nil {
+		return cached(r.memo, r.memo.requiredDeps, sourceURL, targetLang, false, func() []types.RequiredDep {
+			return get(r.requiredDeps, targetLang, sourceURL)
+		})
+	}
+	return get(r.requiredDeps, targetLang, sourceURL)
+}
+
+// Category returns the category of the mapping for a lookup, such as cli, http_client
+// or orm. Uses the same key format as Lookup; returns "" for uncategorized mappings.
+func (r *Rinku) Category(sourceURL, targetLang string) string {
+	if r.memo != nil {
+		return cached(r.memo, r.memo.categories, sourceURL, targetLang, false, func() string {
+			return get(r.categories, targetLang, sourceURL)
+		})
+	}
+	return get(r.categories, targetLang, sourceURL)
+}
+
+// Confidence returns how certain the database is that the targets of a lookup replace
+// the source library, from 0 to 1. Uses the same key format as Lookup; returns 0 if the
+// mapping records no confidence.
+func (r *Rinku) Confidence(sourceURL, targetLang string) float64 {
+	if r.memo != nil {
+		return cached(r.memo, r.memo.confidences, sourceURL, targetLang, false, func() float64 {
+			return get(r.confidences, targetLang, sourceURL)
+		})
+	}
+	return get(r.confidences, targetLang, sourceURL)
+}
+
+// Sources returns the normalized URLs of the libraries with a mapping to targetLang,
+// including vulnerable targets, sorted.
+func (r *Rinku) Sources(targetLang string) []string {
+	prefix := Lang(targetLang) + ":"
+	var sources []string
+	for key := range r.all {
+		if source, ok := strings.CutPrefix(key, prefix); ok {
+			sources = append(sources, source)
+		}
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// PackageURL returns the library URL of an npm (lang js) or PyPI (lang python) package
+// name, or "" if the package is not in the database.
+func (r *Rinku) PackageURL(lang, name string) string {
+	lang = Lang(lang)
+	return r.packages[lang+":"+manifest.NormalizeName(lang, name)]
+}
+
+// PackageNames returns the package names of a library in a registry language (js,
+// python or rust), sorted, or nil if the database lists none.
+func (r *Rinku) PackageNames(lang, libURL string) []string {
+	return get(r.packageNames, lang, libURL)
+}
+
+// Lang returns the database language of a language name. TypeScript uses the js
+// libraries: npm publishes one package for both.
+func Lang(name string) string {
+	switch name = strings.ToLower(name); name {
+	case "ts", "typescript", "javascript":
+		return "js"
+	}
+	return name
+}
+
+// get looks up the "lang:normalized_url" key without allocating it: the key is built
+// in a stack buffer and the map index with a []byte conversion is not copied.
+func get[V any](m map[string]V, lang, libURL string) V {
+	var buf [128]byte
+	key := append(buf[:0], Lang(lang)...)
+	key = append(key, ':')
+	key = append(key, url.Normalize(libURL)...)
+	return m[string(key)]
 }
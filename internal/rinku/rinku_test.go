@@ -1,8 +1,17 @@
 package rinku
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/stephan/rinku/internal/types"
+	"github.com/stephan/rinku/internal/url"
 )
 
 func TestLookup(t *testing.T) {
@@ -26,7 +35,12 @@ func TestLookup(t *testing.T) {
 		"go:github.com/hyperium/hyper":  {"https://github.com/golang/net"}, // disabled in reverseIndex
 	}
 
-	r := New(index, indexAll, reverseIndex, reverseIndexAll, nil, nil, nil)
+	r := New(WithIndex(Index{
+		Safe:        index,
+		All:         indexAll,
+		ReverseSafe: reverseIndex,
+		ReverseAll:  reverseIndexAll,
+	}))
 
 	tests := []struct {
 		name       string
@@ -121,7 +135,12 @@ func TestReverseLookup(t *testing.T) {
 		"go:github.com/hyperium/hyper": {"https://github.com/golang/net"},
 	}
 
-	r := New(index, indexAll, reverseIndex, reverseIndexAll, nil, nil, nil)
+	r := New(WithIndex(Index{
+		Safe:        index,
+		All:         indexAll,
+		ReverseSafe: reverseIndex,
+		ReverseAll:  reverseIndexAll,
+	}))
 
 	tests := []struct {
 		name       string
@@ -184,3 +203,261 @@ func TestReverseLookup(t *testing.T) {
 		})
 	}
 }
+
+func TestNew_Options(t *testing.T) {
+	base := Index{
+		Safe:       map[string][]string{"rust:github.com/spf13/cobra": {"https://github.com/clap-rs/clap"}},
+		Categories: map[string]string{"rust:github.com/spf13/cobra": "cli"},
+	}
+	var logs bytes.Buffer
+	var resolved []string
+	r := New(
+		WithIndex(base),
+		WithOverlay(Index{Safe: map[string][]string{
+			"rust:github.com/spf13/cobra": {"https://github.com/acme/cli"},
+			"rust:github.com/acme/log":    {"https://github.com/tokio-rs/tracing"},
+		}}),
+		WithResolver(ResolverFunc(func(sourceURL, targetLang string, includeUnsafe bool) ([]string, error) {
+			resolved = append(resolved, sourceURL)
+			if sourceURL == "https://github.com/acme/broken" {
+				return nil, errors.New("offline")
+			}
+			return []string{"https://github.com/acme/remote"}, nil
+		})),
+		WithLogger(log.New(&logs, "", 0)),
+	)
+
+	if got := r.Lookup("https://github.com/spf13/cobra", "rust", false); !reflect.DeepEqual(got, []string{"https://github.com/acme/cli"}) {
+		t.Errorf("overlaid Lookup = %v", got)
+	}
+	if got := r.Lookup("https://github.com/acme/log", "rust", false); len(got) != 1 {
+		t.Errorf("added Lookup = %v", got)
+	}
+	if got := r.Category("https://github.com/spf13/cobra", "rust"); got != "cli" {
+		t.Errorf("Category() = %q, want the base entry", got)
+	}
+	if !r.Overlaid("https://github.com/spf13/cobra", "rust") || r.Overlaid("https://github.com/spf13/cobra", "js") {
+		t.Error("Overlaid(cobra) should hold for rust only")
+	}
+	if len(base.Safe["rust:github.com/spf13/cobra"]) != 1 || base.Safe["rust:github.com/acme/log"] != nil {
+		t.Errorf("overlay modified the base index: %v", base.Safe)
+	}
+
+	if got := r.Lookup("https://github.com/acme/other", "rust", false); !reflect.DeepEqual(got, []string{"https://github.com/acme/remote"}) {
+		t.Errorf("resolved Lookup = %v", got)
+	}
+	if got := r.Lookup("https://github.com/acme/broken", "rust", false); got != nil {
+		t.Errorf("failed resolve = %v, want nil", got)
+	}
+	if len(resolved) != 2 {
+		t.Errorf("resolver called for %v, want only the index misses", resolved)
+	}
+	if want := "overlay replaces rust:github.com/spf13/cobra\nresolving https://github.com/acme/broken: offline\n"; logs.String() != want {
+		t.Errorf("logs = %q, want %q", logs.String(), want)
+	}
+
+	if got := New().Lookup("https://github.com/spf13/cobra", "rust", false); got != nil {
+		t.Errorf("Lookup without index = %v", got)
+	}
+}
+
+func TestNewFromMaps(t *testing.T) {
+	index := map[string][]string{"rust:github.com/spf13/cobra": {"https://github.com/clap-rs/clap"}}
+	r := NewFromMaps(index, index, nil, nil, nil, nil, nil, map[string]string{"rust:github.com/spf13/cobra": "cli"}, nil)
+	if got := r.Lookup("https://github.com/spf13/cobra", "rust", false); len(got) != 1 || r.Category("https://github.com/spf13/cobra", "rust") != "cli" {
+		t.Errorf("NewFromMaps: Lookup = %v", got)
+	}
+}
+
+func TestCategory(t *testing.T) {
+	r := New(WithIndex(Index{Categories: map[string]string{"rust:github.com/spf13/cobra": "cli"}}))
+	if got := r.Category("https://www.github.com/spf13/cobra", "rust"); got != "cli" {
+		t.Errorf("Category() = %q, want cli", got)
+	}
+	if got := r.Category("https://github.com/spf13/cobra", "go"); got != "" {
+		t.Errorf("Category() for other language = %q, want empty", got)
+	}
+}
+
+func TestConfidence(t *testing.T) {
+	r := New(WithIndex(Index{Confidences: map[string]float64{"rust:github.com/spf13/cobra": 0.95}}))
+	for _, r := range []*Rinku{r, r.Memoize()} {
+		if got := r.Confidence("https://www.github.com/spf13/cobra", "rust"); got != 0.95 {
+			t.Errorf("Confidence() = %v, want 0.95", got)
+		}
+		if got := r.Confidence("https://github.com/spf13/cobra", "ts"); got != 0 {
+			t.Errorf("Confidence() for other language = %v, want 0", got)
+		}
+	}
+}
+
+func TestSources(t *testing.T) {
+	r := New(WithIndex(Index{
+		Safe: map[string][]string{"rust:github.com/spf13/cobra": {"https://github.com/clap-rs/clap"}},
+		All: map[string][]string{
+			"rust:github.com/spf13/cobra":      {"https://github.com/clap-rs/clap"},
+			"rust:github.com/dgrijalva/jwt-go": {"https://github.com/keats/jsonwebtoken"},
+			"js:github.com/spf13/cobra":        {"https://github.com/tj/commander.js"},
+		},
+	}))
+	if got, want := r.Sources("rust"), []string{"github.com/dgrijalva/jwt-go", "github.com/spf13/cobra"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Sources(rust) = %v, want %v", got, want)
+	}
+	if got := r.Sources("ts"); !reflect.DeepEqual(got, []string{"github.com/spf13/cobra"}) {
+		t.Errorf("Sources(ts) = %v", got)
+	}
+}
+
+func TestPackageURL(t *testing.T) {
+	r := New(WithIndex(Index{Packages: map[string]string{
+		"python:pyyaml":  "https://github.com/yaml/pyyaml",
+		"js:@nestjs/core": "https://github.com/nestjs/nest",
+	}}))
+	if got := r.PackageURL("python", "PyYAML"); got != "https://github.com/yaml/pyyaml" {
+		t.Errorf("PackageURL(python, PyYAML) = %q", got)
+	}
+	if got := r.PackageURL("js", "@nestjs/core"); got != "https://github.com/nestjs/nest" {
+		t.Errorf("PackageURL(js, @nestjs/core) = %q", got)
+	}
+	if got := r.PackageNames("js", "https://github.com/NestJS/nest/"); !reflect.DeepEqual(got, []string{"@nestjs/core"}) {
+		t.Errorf("PackageNames(js, nest) = %v", got)
+	}
+	if got := r.PackageURL("python", "@nestjs/core"); got != "" {
+		t.Errorf("PackageURL for other language = %q, want empty", got)
+	}
+}
+
+func TestLookup_TypeScript(t *testing.T) {
+	r := New(WithIndex(Index{
+		Safe:       map[string][]string{"js:github.com/spf13/cobra": {"https://github.com/tj/commander.js"}},
+		Categories: map[string]string{"js:github.com/spf13/cobra": "cli_framework"},
+		Packages:   map[string]string{"js:commander": "https://github.com/tj/commander.js"},
+	}))
+	for _, lang := range []string{"js", "ts", "TypeScript", "javascript"} {
+		if got := r.Lookup("https://github.com/spf13/cobra", lang, false); !reflect.DeepEqual(got, []string{"https://github.com/tj/commander.js"}) {
+			t.Errorf("Lookup(cobra, %s) = %v", lang, got)
+		}
+		if got := r.Category("https://github.com/spf13/cobra", lang); got != "cli_framework" {
+			t.Errorf("Category(cobra, %s) = %q", lang, got)
+		}
+	}
+	if got := r.PackageURL("ts", "commander"); got != "https://github.com/tj/commander.js" {
+		t.Errorf("PackageURL(ts, commander) = %q", got)
+	}
+	if got := r.Lookup("https://github.com/spf13/cobra", "rust", false); got != nil {
+		t.Errorf("Lookup(cobra, rust) = %v, want none", got)
+	}
+}
+
+func TestLookupDoesNotAllocate(t *testing.T) {
+	safe, all, reverseSafe, reverseAll, deps := benchIndexes(100)
+	r := New(WithIndex(Index{
+		Safe:         safe,
+		All:          all,
+		ReverseSafe:  reverseSafe,
+		ReverseAll:   reverseAll,
+		RequiredDeps: deps,
+	}))
+	allocs := testing.AllocsPerRun(100, func() {
+		r.Lookup("https://www.github.com/org1/golib1/", "rust", false)
+		r.ReverseLookup("https://github.com/org1/crate1", "go", true)
+		r.RequiredDeps("https://github.com/org0/golib0", "rust")
+	})
+	if allocs != 0 {
+		t.Errorf("allocs per lookup = %v, want 0", allocs)
+	}
+
+	long := "github.com/org/" + strings.Repeat("x", 200)
+	r = New(WithIndex(Index{
+		Safe: map[string][]string{"rust:" + long: {"https://github.com/a/b"}},
+	}))
+	if got := r.Lookup("https://"+long, "rust", false); len(got) != 1 {
+		t.Errorf("Lookup of long URL = %v", got)
+	}
+}
+
+func TestProfile(t *testing.T) {
+	safe, all, reverseSafe, reverseAll, deps := benchIndexes(100)
+	r := New(WithIndex(Index{
+		Safe:         safe,
+		All:          all,
+		ReverseSafe:  reverseSafe,
+		ReverseAll:   reverseAll,
+		RequiredDeps: deps,
+	}))
+	p := r.Profile(time.Millisecond)
+
+	if len(p.Indexes) != 4 || p.Indexes[0].Keys != 90 || p.Indexes[1].Keys != 100 || p.Indexes[1].Targets != 100 {
+		t.Errorf("indexes = %+v", p.Indexes)
+	}
+	if p.Bytes() == 0 {
+		t.Error("footprint = 0")
+	}
+	if len(p.Lookups) != 2 || p.Lookups[0].Lookups < 100 || p.Lookups[0].PerSecond() == 0 {
+		t.Errorf("lookups = %+v", p.Lookups)
+	}
+}
+
+// benchIndexes returns indexes shaped like the generated database with n mappings per
+// direction, every tenth one disabled in the safe indexes.
+func benchIndexes(n int) (safe, all, reverseSafe, reverseAll map[string][]string, deps map[string][]types.RequiredDep) {
+	safe, all = make(map[string][]string), make(map[string][]string)
+	reverseSafe, reverseAll = make(map[string][]string), make(map[string][]string)
+	deps = make(map[string][]types.RequiredDep)
+	for i := 0; i < n; i++ {
+		goURL := fmt.Sprintf("https://github.com/org%d/golib%d", i%50, i)
+		rustURL := fmt.Sprintf("https://github.com/org%d/crate%d", i%50, i)
+		key := "rust:" + url.Normalize(goURL)
+		reverseKey := "go:" + url.Normalize(rustURL)
+		all[key] = []string{rustURL}
+		reverseAll[reverseKey] = []string{goURL}
+		if i%10 != 0 {
+			safe[key] = []string{rustURL}
+			reverseSafe[reverseKey] = []string{goURL}
+		}
+		if i%20 == 0 {
+			deps[key] = []types.RequiredDep{{Crate: "tokio", Features: []string{"full"}}}
+		}
+	}
+	return safe, all, reverseSafe, reverseAll, deps
+}
+
+func BenchmarkLookup(b *testing.B) {
+	safe, all, reverseSafe, reverseAll, deps := benchIndexes(5000)
+	r := New(WithIndex(Index{
+		Safe:         safe,
+		All:          all,
+		ReverseSafe:  reverseSafe,
+		ReverseAll:   reverseAll,
+		RequiredDeps: deps,
+	}))
+	urls := make([]string, 100)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://github.com/org%d/golib%d", i%50, i*37)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Lookup(urls[i%len(urls)], "rust", false)
+	}
+}
+
+func BenchmarkReverseLookup(b *testing.B) {
+	safe, all, reverseSafe, reverseAll, deps := benchIndexes(5000)
+	r := New(WithIndex(Index{
+		Safe:         safe,
+		All:          all,
+		ReverseSafe:  reverseSafe,
+		ReverseAll:   reverseAll,
+		RequiredDeps: deps,
+	}))
+	urls := make([]string, 100)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://github.com/org%d/crate%d", i%50, i*37)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.ReverseLookup(urls[i%len(urls)], "go", true)
+	}
+}
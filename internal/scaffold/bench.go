@@ -0,0 +1,280 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+// Benchmark is a Benchmark function found in a package's test files.
+type Benchmark struct {
+	Name string   // e.g. BenchmarkReverse
+	Subs []string // names passed to b.Run as string literals, as go test reports them
+	Body string   // Go source of the function
+	File string
+	Line int
+}
+
+// Names returns the names go test reports for the benchmark: its own name, or one
+// Name/sub per sub-benchmark.
+func (b *Benchmark) Names() []string {
+	if len(b.Subs) == 0 {
+		return []string{b.Name}
+	}
+	names := make([]string, len(b.Subs))
+	for i, sub := range b.Subs {
+		names[i] = b.Name + "/" + sub
+	}
+	return names
+}
+
+// benchmarks collects the benchmark functions of a test file.
+func benchmarks(tree *gosrc.Tree, file *ast.File) []Benchmark {
+	var result []Benchmark
+	for _, decl := range file.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || fd.Recv != nil || fd.Body == nil || !isBenchmarkName(fd.Name.Name) {
+			continue
+		}
+		b := benchParam(fd.Type)
+		if b == "" {
+			continue
+		}
+		bench := Benchmark{Name: fd.Name.Name, Subs: subBenchmarks(fd.Body, b)}
+		bench.File, bench.Line = tree.Position(fd.Pos())
+		var buf bytes.Buffer
+		_ = printer.Fprint(&buf, tree.Fset, &printer.CommentedNode{Node: fd, Comments: file.Comments})
+		bench.Body = buf.String()
+		result = append(result, bench)
+	}
+	return result
+}
+
+// isBenchmarkName applies the go test rule: Benchmark, optionally followed by a name
+// that does not start with a lower-case letter.
+func isBenchmarkName(name string) bool {
+	rest, ok := strings.CutPrefix(name, "Benchmark")
+	if !ok {
+		return false
+	}
+	if rest == "" {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(rest)
+	return !unicode.IsLower(r)
+}
+
+// benchParam returns the name of the *testing.B parameter, or "" if the signature is
+// not func(*testing.B).
+func benchParam(ft *ast.FuncType) string {
+	if ft.Results != nil || ft.Params == nil || len(ft.Params.List) != 1 {
+		return ""
+	}
+	field := ft.Params.List[0]
+	star, ok := field.Type.(*ast.StarExpr)
+	if !ok {
+		return ""
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "B" {
+		return ""
+	}
+	if len(field.Names) != 1 {
+		return "_"
+	}
+	return field.Names[0].Name
+}
+
+// subBenchmarks returns the literal names of b.Run calls in body, rewritten the way
+// go test reports them.
+func subBenchmarks(body *ast.BlockStmt, b string) []string {
+	var subs []string
+	seen := map[string]bool{}
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Run" {
+			return true
+		}
+		if recv, ok := sel.X.(*ast.Ident); !ok || recv.Name != b {
+			return true
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok {
+			return true
+		}
+		name, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return true
+		}
+		name = strings.ReplaceAll(name, " ", "_")
+		if !seen[name] {
+			seen[name] = true
+			subs = append(subs, name)
+		}
+		return true
+	})
+	return subs
+}
+
+// BenchOptions configure benchmark harness generation.
+type BenchOptions struct {
+	CrateDir string // slash-separated, relative to the module root, e.g. bench/strutil
+	Count    int    // go test -count, default 5
+}
+
+// Bench is a generated benchmark harness: a criterion benchmark per Go benchmark, a
+// script running the Go benchmarks and a script comparing both sides.
+type Bench struct {
+	Files      []File
+	Benchmarks []string // Go benchmarks with a Rust counterpart
+}
+
+// GenerateBench generates the paired benchmark harness for the benchmarks of pkg.
+func GenerateBench(pkg *Package, opts BenchOptions) (*Bench, error) {
+	if len(pkg.Benchmarks) == 0 {
+		return nil, fmt.Errorf("no Benchmark functions in the test files of %s", pkg.ImportPath)
+	}
+	if opts.CrateDir == "" {
+		opts.CrateDir = "bench/" + pkg.Name
+	}
+	if opts.Count <= 0 {
+		opts.Count = 5
+	}
+
+	result := &Bench{
+		Files: []File{
+			{Path: path.Join(opts.CrateDir, "Cargo.toml"), Content: []byte(benchCargoToml(pkg))},
+			{Path: path.Join(opts.CrateDir, "src", "lib.rs"), Content: []byte(benchLib(pkg))},
+			{Path: path.Join(opts.CrateDir, "benches", pkg.Name+".rs"), Content: []byte(criterionBenches(pkg))},
+			{Path: path.Join(opts.CrateDir, "go-bench.sh"), Content: []byte(goBenchScript(pkg, opts))},
+			{Path: path.Join(opts.CrateDir, "compare.sh"), Content: []byte(compareScript(pkg))},
+		},
+	}
+	for _, b := range pkg.Benchmarks {
+		result.Benchmarks = append(result.Benchmarks, b.Name)
+	}
+	return result, nil
+}
+
+func benchCargoToml(pkg *Package) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Benchmarks for the Rust port of %s, generated by rinku bench.\n", pkg.ImportPath)
+	fmt.Fprintf(&sb, "[package]\nname = %q\nversion = \"0.1.0\"\nedition = \"2021\"\npublish = false\n\n", pkg.Name+"-bench")
+	sb.WriteString("[dependencies]\n")
+	fmt.Fprintf(&sb, "# The ported crate, e.g. %s = { path = \"../../%s\" }\n\n", pkg.Name, pkg.Name)
+	sb.WriteString("[dev-dependencies]\ncriterion = \"0.5\"\n\n")
+	fmt.Fprintf(&sb, "[[bench]]\nname = %q\nharness = false\n", pkg.Name)
+	return sb.String()
+}
+
+func benchLib(pkg *Package) string {
+	return fmt.Sprintf("//! Benchmark-only crate for the Rust port of %s; see benches/%s.rs.\n", pkg.ImportPath, pkg.Name)
+}
+
+func criterionBenches(pkg *Package) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "//! Criterion benchmarks mirroring the Go benchmarks of %s, generated by rinku bench.\n", pkg.ImportPath)
+	sb.WriteString("//!\n")
+	sb.WriteString("//! Every benchmark is named after its Go counterpart so compare.sh can pair the results.\n")
+	sb.WriteString("//! Replace each todo!() with the same operation on the Rust port, passing inputs and\n")
+	sb.WriteString("//! results through std::hint::black_box so the work is not optimized away.\n\n")
+	sb.WriteString("use criterion::{criterion_group, criterion_main, Criterion};\n")
+
+	var fns []string
+	for _, b := range pkg.Benchmarks {
+		fn := rustIdent(b.Name)
+		fns = append(fns, fn)
+
+		fmt.Fprintf(&sb, "\n// Go (%s:%d):\n//\n", path.Base(b.File), b.Line)
+		for _, line := range strings.Split(b.Body, "\n") {
+			sb.WriteString(strings.TrimRight("// "+strings.ReplaceAll(line, "\t", "    "), " ") + "\n")
+		}
+		fmt.Fprintf(&sb, "fn %s(c: &mut Criterion) {\n", fn)
+		if len(b.Subs) == 0 {
+			fmt.Fprintf(&sb, "    c.bench_function(%q, |b| b.iter(|| todo!(\"port %s\")));\n", b.Name, b.Name)
+		} else {
+			fmt.Fprintf(&sb, "    let mut group = c.benchmark_group(%q);\n", b.Name)
+			for _, sub := range b.Subs {
+				fmt.Fprintf(&sb, "    group.bench_function(%q, |b| b.iter(|| todo!(\"port %s/%s\")));\n", sub, b.Name, sub)
+			}
+			sb.WriteString("    group.finish();\n")
+		}
+		sb.WriteString("}\n")
+	}
+
+	fmt.Fprintf(&sb, "\ncriterion_group!(benches, %s);\ncriterion_main!(benches);\n", strings.Join(fns, ", "))
+	return sb.String()
+}
+
+func goBenchScript(pkg *Package, opts BenchOptions) string {
+	var names []string
+	for _, b := range pkg.Benchmarks {
+		names = append(names, b.Name)
+	}
+	pkgPath := "./" + pkg.Dir
+	if pkg.Dir == "." {
+		pkgPath = "."
+	}
+
+	var sb strings.Builder
+	sb.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&sb, "# Runs the Go benchmarks of %s and writes them to results/go.txt.\n", pkg.ImportPath)
+	sb.WriteString("# Generated by rinku bench. Extra arguments are passed to go test, e.g. -benchtime=2s.\n")
+	sb.WriteString("set -eu\n")
+	sb.WriteString("cd \"$(dirname \"$0\")\"\n")
+	sb.WriteString("mkdir -p results\n")
+	fmt.Fprintf(&sb, "(cd %s && go test -run '^$' -bench '^(%s)$' -benchmem -count %d \"$@\" %s) | tee results/go.txt\n",
+		relPath(opts.CrateDir, "."), strings.Join(names, "|"), opts.Count, pkgPath)
+	return sb.String()
+}
+
+func compareScript(pkg *Package) string {
+	var names []string
+	for _, b := range pkg.Benchmarks {
+		for _, name := range b.Names() {
+			names = append(names, shellQuote(name))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&sb, "# Runs the Go and Rust benchmarks of %s and compares time per operation.\n", pkg.ImportPath)
+	sb.WriteString("# Generated by rinku bench. Pass --no-run to compare the last results only.\n")
+	sb.WriteString("set -eu\n")
+	sb.WriteString("cd \"$(dirname \"$0\")\"\n\n")
+	sb.WriteString("if [ \"${1:-}\" != \"--no-run\" ]; then\n")
+	sb.WriteString("    sh ./go-bench.sh\n")
+	fmt.Fprintf(&sb, "    cargo bench --bench %s -- --noplot\n", pkg.Name)
+	sb.WriteString("fi\n\n")
+	sb.WriteString("printf '%-48s %14s %14s %10s\\n' benchmark 'go ns/op' 'rust ns/iter' 'go/rust'\n")
+	fmt.Fprintf(&sb, "for name in %s; do\n", strings.Join(names, " "))
+	sb.WriteString("    # Mean over -count runs, ignoring the -GOMAXPROCS suffix.\n")
+	sb.WriteString("    go=$(awk -v n=\"$name\" '{ sub(/-[0-9]+$/, \"\", $1) } $1 == n && $4 == \"ns/op\" { s += $3; k++ } END { if (k) printf \"%.1f\", s / k }' results/go.txt 2>/dev/null || true)\n")
+	sb.WriteString("    # Criterion writes the mean first in estimates.json.\n")
+	sb.WriteString("    est=\"target/criterion/$name/new/estimates.json\"\n")
+	sb.WriteString("    rust=\"\"\n")
+	sb.WriteString("    if [ -f \"$est\" ]; then\n")
+	sb.WriteString("        rust=$(grep -o '\"point_estimate\":[0-9.e+-]*' \"$est\" | head -n 1 | cut -d: -f2)\n")
+	sb.WriteString("    fi\n")
+	sb.WriteString("    ratio=$(awk -v g=\"$go\" -v r=\"$rust\" 'BEGIN { if (g != \"\" && r != \"\" && r > 0) printf \"%.2fx\", g / r; else print \"-\" }')\n")
+	sb.WriteString("    printf '%-48s %14s %14s %10s\\n' \"$name\" \"${go:--}\" \"$(awk -v r=\"$rust\" 'BEGIN { if (r != \"\") printf \"%.1f\", r; else print \"-\" }')\" \"$ratio\"\n")
+	sb.WriteString("done\n")
+	return sb.String()
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,374 @@
+package scaffold
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ffiScalar maps a Go scalar type to its cgo and Rust C ABI types.
+type ffiScalar struct {
+	cgo  string
+	rust string
+}
+
+var ffiScalars = map[string]ffiScalar{
+	"int":     {"C.int64_t", "i64"},
+	"int64":   {"C.int64_t", "i64"},
+	"int32":   {"C.int32_t", "i32"},
+	"int16":   {"C.int16_t", "i16"},
+	"int8":    {"C.int8_t", "i8"},
+	"uint":    {"C.uint64_t", "u64"},
+	"uint64":  {"C.uint64_t", "u64"},
+	"uint32":  {"C.uint32_t", "u32"},
+	"uint16":  {"C.uint16_t", "u16"},
+	"uint8":   {"C.uint8_t", "u8"},
+	"byte":    {"C.uint8_t", "u8"},
+	"float64": {"C.double", "f64"},
+	"float32": {"C.float", "f32"},
+	"bool":    {"C.bool", "bool"},
+}
+
+// FFIOptions configure bridge generation.
+type FFIOptions struct {
+	CrateDir string // slash-separated, relative to the module root, e.g. ffi/strutil
+}
+
+// FFI is a generated C ABI bridge: a Rust staticlib crate whose header is produced by
+// cbindgen, and a cgo wrapper in the Go package behind the "rust" build tag.
+type FFI struct {
+	Files   []File
+	Bridged []string // Go function names
+	Skipped []Skipped
+	Library string // static library name, e.g. strutil_ffi
+}
+
+// GenerateFFI generates the bridge for the exported functions of pkg. Functions with
+// parameters other than scalars, strings and byte slices, or with more than one result
+// besides a trailing error, are skipped.
+func GenerateFFI(pkg *Package, opts FFIOptions) (*FFI, error) {
+	if opts.CrateDir == "" {
+		opts.CrateDir = "ffi/" + pkg.Name
+	}
+	lib := pkg.Name + "_ffi"
+	result := &FFI{Library: lib}
+
+	var bridged []Func
+	for _, fn := range pkg.Funcs {
+		if reason := ffiUnsupported(&fn); reason != "" {
+			result.Skipped = append(result.Skipped, Skipped{Func: fn.Name, Reason: reason})
+			continue
+		}
+		bridged = append(bridged, fn)
+		result.Bridged = append(result.Bridged, fn.Name)
+	}
+	if len(bridged) == 0 {
+		return nil, fmt.Errorf("no exported functions in %s can be bridged", pkg.ImportPath)
+	}
+
+	result.Files = []File{
+		{Path: path.Join(opts.CrateDir, "Cargo.toml"), Content: []byte(ffiCargoToml(pkg, lib))},
+		{Path: path.Join(opts.CrateDir, "cbindgen.toml"), Content: []byte(ffiCbindgenToml(lib))},
+		{Path: path.Join(opts.CrateDir, "build.rs"), Content: []byte(ffiBuildRs(lib))},
+		{Path: path.Join(opts.CrateDir, "src", "lib.rs"), Content: []byte(ffiLibRs(pkg, bridged, result.Skipped, lib))},
+		{Path: path.Join(pkg.Dir, pkg.Name+"_rust.go"), Content: []byte(ffiGoWrapper(pkg, bridged, opts.CrateDir, lib))},
+	}
+	return result, nil
+}
+
+func ffiUnsupported(fn *Func) string {
+	switch {
+	case fn.Generic:
+		return "generic functions have no C ABI"
+	case fn.Variadic:
+		return "variadic parameters are not bridged"
+	}
+	for _, p := range fn.Params {
+		if _, ok := ffiScalars[p.Type]; !ok && p.Type != "string" && p.Type != "[]byte" {
+			return "parameter " + p.Name + " has type " + p.Type
+		}
+	}
+	values := fn.Values()
+	if len(values) > 1 {
+		return "more than one result"
+	}
+	for _, r := range values {
+		if _, ok := ffiScalars[r.Type]; !ok && r.Type != "string" {
+			return "result type " + r.Type
+		}
+	}
+	return ""
+}
+
+func ffiCargoToml(pkg *Package, lib string) string {
+	return fmt.Sprintf(`# C ABI bridge for %s, generated by rinku ffi.
+[package]
+name = %q
+version = "0.1.0"
+edition = "2021"
+
+[lib]
+name = %q
+crate-type = ["staticlib"]
+
+[build-dependencies]
+cbindgen = "0.27"
+`, pkg.ImportPath, strings.ReplaceAll(lib, "_", "-"), lib)
+}
+
+func ffiCbindgenToml(lib string) string {
+	return fmt.Sprintf(`language = "C"
+include_guard = %q
+usize_is_size_t = true
+`, strings.ToUpper(lib)+"_H")
+}
+
+func ffiBuildRs(lib string) string {
+	return fmt.Sprintf(`// Writes the C header used by the Go cgo wrapper.
+fn main() {
+    let crate_dir = std::env::var("CARGO_MANIFEST_DIR").unwrap();
+    cbindgen::generate(crate_dir)
+        .expect("generating C header")
+        .write_to_file("include/%s.h");
+}
+`, lib)
+}
+
+func ffiSymbol(pkg *Package, fn *Func) string {
+	return pkg.Name + "_" + snake(fn.Name)
+}
+
+func ffiLibRs(pkg *Package, funcs []Func, skipped []Skipped, lib string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "//! C ABI bridge for the Go package %s, generated by rinku ffi.\n", pkg.ImportPath)
+	sb.WriteString("//!\n")
+	fmt.Fprintf(&sb, "//! Port each function body, then run `cargo build --release` to produce lib%s.a\n", lib)
+	fmt.Fprintf(&sb, "//! and include/%s.h. Panics must not unwind into Go: report failures through\n", lib)
+	sb.WriteString("//! the `err` out-parameter instead.\n")
+	if len(skipped) > 0 {
+		sb.WriteString("//!\n//! Not bridged:\n")
+		for _, s := range skipped {
+			fmt.Fprintf(&sb, "//! - %s: %s\n", s.Func, s.Reason)
+		}
+	}
+
+	needsCStr := false
+	for _, fn := range funcs {
+		for _, p := range fn.Params {
+			if p.Type == "string" {
+				needsCStr = true
+			}
+		}
+	}
+	if needsCStr {
+		sb.WriteString("\nuse std::ffi::{c_char, CStr, CString};\n")
+	} else {
+		sb.WriteString("\nuse std::ffi::{c_char, CString};\n")
+	}
+
+	fmt.Fprintf(&sb, `
+/// Frees a string returned by this library.
+#[no_mangle]
+pub extern "C" fn %s_free_string(s: *mut c_char) {
+    if !s.is_null() {
+        unsafe { drop(CString::from_raw(s)) };
+    }
+}
+`, pkg.Name)
+
+	for _, fn := range funcs {
+		var params, locals []string
+		var body strings.Builder
+		for _, p := range fn.Params {
+			name := rustIdent(p.Name)
+			switch p.Type {
+			case "string":
+				params = append(params, name+": *const c_char")
+				fmt.Fprintf(&body, "    let %s = unsafe { CStr::from_ptr(%s) }.to_string_lossy();\n", name, name)
+			case "[]byte":
+				params = append(params, name+": *const u8", name+"_len: usize")
+				fmt.Fprintf(&body, "    let %s: &[u8] = if %s_len == 0 { &[] } else { unsafe { std::slice::from_raw_parts(%s, %s_len) } };\n", name, name, name, name)
+			default:
+				params = append(params, name+": "+ffiScalars[p.Type].rust)
+			}
+			locals = append(locals, name)
+		}
+		if fn.ReturnsError() {
+			params = append(params, "err: *mut *mut c_char")
+			locals = append(locals, "err")
+		}
+
+		ret := ""
+		if values := fn.Values(); len(values) == 1 {
+			if values[0].Type == "string" {
+				ret = " -> *mut c_char"
+			} else {
+				ret = " -> " + ffiScalars[values[0].Type].rust
+			}
+		}
+
+		sb.WriteString("\n")
+		if fn.Doc != "" {
+			writeDoc(&sb, "///", fn.Doc)
+			sb.WriteString("///\n")
+		}
+		fmt.Fprintf(&sb, "/// Go: %s\n", fn.Signature())
+		if fn.ReturnsError() {
+			sb.WriteString("/// On failure, set `*err` to `CString::new(message).unwrap().into_raw()`.\n")
+		}
+		sb.WriteString("#[no_mangle]\n")
+		fmt.Fprintf(&sb, "pub extern \"C\" fn %s(%s)%s {\n", ffiSymbol(pkg, &fn), strings.Join(params, ", "), ret)
+		sb.WriteString(body.String())
+		switch len(locals) {
+		case 0:
+		case 1:
+			fmt.Fprintf(&sb, "    let _ = %s;\n", locals[0])
+		default:
+			fmt.Fprintf(&sb, "    let _ = (%s);\n", strings.Join(locals, ", "))
+		}
+		fmt.Fprintf(&sb, "    todo!(\"port %s.%s\")\n}\n", pkg.Name, fn.Name)
+	}
+	return sb.String()
+}
+
+func ffiGoWrapper(pkg *Package, funcs []Func, crateDir, lib string) string {
+	rel := relPath(pkg.Dir, crateDir)
+	needsUnsafe, needsErrors := false, false
+	for _, fn := range funcs {
+		if fn.ReturnsError() {
+			needsErrors = true
+		}
+		for _, p := range fn.Params {
+			if p.Type == "string" || p.Type == "[]byte" {
+				needsUnsafe = true
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("//go:build rust\n\n")
+	fmt.Fprintf(&sb, "// Code generated by rinku ffi. Bridges %s to the Rust port in %s.\n", pkg.Name, crateDir)
+	fmt.Fprintf(&sb, "// Build the crate with `cargo build --release` in %s, then build Go with -tags rust.\n\n", crateDir)
+	fmt.Fprintf(&sb, "package %s\n\n", pkg.Name)
+	sb.WriteString("/*\n")
+	fmt.Fprintf(&sb, "#cgo LDFLAGS: -L${SRCDIR}/%s/target/release -l%s -lm -ldl -lpthread\n", rel, lib)
+	sb.WriteString("#include <stdlib.h>\n")
+	fmt.Fprintf(&sb, "#include \"%s/include/%s.h\"\n", rel, lib)
+	sb.WriteString("*/\nimport \"C\"\n")
+
+	var imports []string
+	if needsErrors {
+		imports = append(imports, `"errors"`)
+	}
+	if needsUnsafe {
+		imports = append(imports, `"unsafe"`)
+	}
+	switch len(imports) {
+	case 0:
+	case 1:
+		fmt.Fprintf(&sb, "\nimport %s\n", imports[0])
+	default:
+		fmt.Fprintf(&sb, "\nimport (\n\t%s\n)\n", strings.Join(imports, "\n\t"))
+	}
+
+	usesBytes := false
+	for _, fn := range funcs {
+		symbol := ffiSymbol(pkg, &fn)
+		values := fn.Values()
+
+		var params, args, results []string
+		var pre strings.Builder
+		for _, p := range fn.Params {
+			params = append(params, p.Name+" "+p.Type)
+			local := "c" + strings.ToUpper(p.Name[:1]) + p.Name[1:]
+			switch p.Type {
+			case "string":
+				fmt.Fprintf(&pre, "\t%s := C.CString(%s)\n\tdefer C.free(unsafe.Pointer(%s))\n", local, p.Name, local)
+				args = append(args, local)
+			case "[]byte":
+				usesBytes = true
+				fmt.Fprintf(&pre, "\t%sPtr, %sLen := cBytes(%s)\n", local, local, p.Name)
+				args = append(args, local+"Ptr", local+"Len")
+			default:
+				args = append(args, ffiScalars[p.Type].cgo+"("+p.Name+")")
+			}
+		}
+		for _, r := range fn.Results {
+			results = append(results, r.Type)
+		}
+		if fn.ReturnsError() {
+			pre.WriteString("\tvar cerr *C.char\n")
+			args = append(args, "&cerr")
+		}
+
+		fmt.Fprintf(&sb, "\n// rust%s calls %s, the Rust port of %s.\n", fn.Name, symbol, fn.Name)
+		sig := "func rust" + fn.Name + "(" + strings.Join(params, ", ") + ")"
+		switch len(results) {
+		case 0:
+		case 1:
+			sig += " " + results[0]
+		default:
+			sig += " (" + strings.Join(results, ", ") + ")"
+		}
+		sb.WriteString(sig + " {\n")
+		sb.WriteString(pre.String())
+
+		call := "C." + symbol + "(" + strings.Join(args, ", ") + ")"
+		value, zero := "", ""
+		if len(values) == 1 {
+			fmt.Fprintf(&sb, "\tout := %s\n", call)
+			switch t := values[0].Type; t {
+			case "string":
+				sb.WriteString("\tdefer C." + pkg.Name + "_free_string(out)\n")
+				value, zero = "C.GoString(out)", `""`
+			case "bool":
+				value, zero = "bool(out)", "false"
+			default:
+				value, zero = t+"(out)", "0"
+			}
+		} else {
+			fmt.Fprintf(&sb, "\t%s\n", call)
+		}
+
+		if fn.ReturnsError() {
+			sb.WriteString("\tif cerr != nil {\n")
+			fmt.Fprintf(&sb, "\t\tdefer C.%s_free_string(cerr)\n", pkg.Name)
+			if value != "" {
+				fmt.Fprintf(&sb, "\t\treturn %s, errors.New(C.GoString(cerr))\n\t}\n", zero)
+				fmt.Fprintf(&sb, "\treturn %s, nil\n", value)
+			} else {
+				sb.WriteString("\t\treturn errors.New(C.GoString(cerr))\n\t}\n\treturn nil\n")
+			}
+		} else if value != "" {
+			fmt.Fprintf(&sb, "\treturn %s\n", value)
+		}
+		sb.WriteString("}\n")
+	}
+
+	if usesBytes {
+		sb.WriteString(`
+// cBytes passes a byte slice to C without copying. Rust must not keep the pointer.
+func cBytes(b []byte) (*C.uint8_t, C.size_t) {
+	if len(b) == 0 {
+		return nil, 0
+	}
+	return (*C.uint8_t)(unsafe.Pointer(&b[0])), C.size_t(len(b))
+}
+`)
+	}
+	return sb.String()
+}
+
+// writeDoc writes a Go doc comment as comment lines with the given prefix.
+func writeDoc(sb *strings.Builder, prefix, doc string) {
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		if line == "" {
+			sb.WriteString(prefix + "\n")
+			continue
+		}
+		sb.WriteString(prefix + " " + line + "\n")
+	}
+}
@@ -0,0 +1,289 @@
+// Package scaffold generates bridge, service and test scaffolding around the exported
+// functions of a Go package, for migrations that port one package at a time.
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+// File is a generated file, relative to the module root.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// Param is a function parameter or result.
+type Param struct {
+	Name string // empty for unnamed results
+	Type string // Go type expression, e.g. []byte
+}
+
+// Func is an exported top-level function of a package.
+type Func struct {
+	Name     string
+	Doc      string
+	Params   []Param
+	Results  []Param
+	Variadic bool
+	Generic  bool
+	Imports  []string // import specs the signature refers to, e.g. "time" or pb "example.com/pb"
+	File     string
+	Line     int
+}
+
+// Package is the exported API of one Go package.
+type Package struct {
+	Name       string
+	Dir        string // slash-separated, relative to the module root
+	ImportPath string
+	Funcs      []Func      // sorted by name
+	Benchmarks []Benchmark // from the package's test files, sorted by name
+}
+
+// Load finds the package in dir (slash-separated, relative to the module root) and
+// collects its exported functions from non-test files and its benchmarks from test
+// files. Methods are not included.
+func Load(tree *gosrc.Tree, module, dir string) (*Package, error) {
+	dir = path.Clean(strings.TrimPrefix(dir, module+"/"))
+	if dir == module {
+		dir = "."
+	}
+	pkg := &Package{Dir: dir, ImportPath: module}
+	if dir != "." {
+		pkg.ImportPath = module + "/" + dir
+	}
+
+	for _, f := range tree.Files {
+		if path.Dir(f.Path) != dir {
+			continue
+		}
+		if f.Test {
+			pkg.Benchmarks = append(pkg.Benchmarks, benchmarks(tree, f.AST)...)
+			continue
+		}
+		pkg.Name = f.Package
+		for _, decl := range f.AST.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv != nil || !fd.Name.IsExported() {
+				continue
+			}
+			fn := Func{
+				Name:    fd.Name.Name,
+				Generic: fd.Type.TypeParams != nil && len(fd.Type.TypeParams.List) > 0,
+			}
+			if fd.Doc != nil {
+				fn.Doc = strings.TrimSpace(fd.Doc.Text())
+			}
+			fn.File, fn.Line = tree.Position(fd.Pos())
+			fn.Params, fn.Variadic = params(tree.Fset, fd.Type.Params, "arg")
+			fn.Results, _ = params(tree.Fset, fd.Type.Results, "")
+			fn.Imports = signatureImports(f.AST, fd.Type)
+			pkg.Funcs = append(pkg.Funcs, fn)
+		}
+	}
+	if pkg.Name == "" {
+		return nil, fmt.Errorf("no Go package in %s", dir)
+	}
+	sort.Slice(pkg.Funcs, func(i, j int) bool { return pkg.Funcs[i].Name < pkg.Funcs[j].Name })
+	sort.Slice(pkg.Benchmarks, func(i, j int) bool { return pkg.Benchmarks[i].Name < pkg.Benchmarks[j].Name })
+	return pkg, nil
+}
+
+// params flattens a field list. Unnamed parameters get a prefix and their index as name.
+func params(fset *token.FileSet, fields *ast.FieldList, unnamed string) ([]Param, bool) {
+	if fields == nil {
+		return nil, false
+	}
+	var result []Param
+	variadic := false
+	for _, field := range fields.List {
+		typ := field.Type
+		if e, ok := typ.(*ast.Ellipsis); ok {
+			variadic = true
+			typ = &ast.ArrayType{Elt: e.Elt}
+		}
+		var buf bytes.Buffer
+		_ = printer.Fprint(&buf, fset, typ)
+		if len(field.Names) == 0 {
+			name := ""
+			if unnamed != "" {
+				name = fmt.Sprintf("%s%d", unnamed, len(result))
+			}
+			result = append(result, Param{Name: name, Type: buf.String()})
+			continue
+		}
+		for _, n := range field.Names {
+			name := n.Name
+			if name == "_" && unnamed != "" {
+				name = fmt.Sprintf("%s%d", unnamed, len(result))
+			}
+			result = append(result, Param{Name: name, Type: buf.String()})
+		}
+	}
+	return result, variadic
+}
+
+// signatureImports returns the import specs of file that a function type refers to.
+func signatureImports(file *ast.File, ft *ast.FuncType) []string {
+	byName := map[string]string{}
+	for _, imp := range file.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		spec := imp.Path.Value
+		name := importName(p)
+		if imp.Name != nil {
+			name = imp.Name.Name
+			spec = name + " " + spec
+		}
+		byName[name] = spec
+	}
+
+	seen := map[string]bool{}
+	var specs []string
+	ast.Inspect(ft, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if x, ok := sel.X.(*ast.Ident); ok {
+			if spec, ok := byName[x.Name]; ok && !seen[spec] {
+				seen[spec] = true
+				specs = append(specs, spec)
+			}
+		}
+		return true
+	})
+	sort.Strings(specs)
+	return specs
+}
+
+// importName guesses the package name of an import path: its last element without a
+// major version suffix (example.com/foo/v2, gopkg.in/yaml.v3).
+func importName(importPath string) string {
+	name := path.Base(importPath)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = path.Base(path.Dir(importPath))
+	}
+	if i := strings.Index(name, ".v"); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// ReturnsError reports whether the last result is an error.
+func (f *Func) ReturnsError() bool {
+	return len(f.Results) > 0 && f.Results[len(f.Results)-1].Type == "error"
+}
+
+// Values returns the results without a trailing error.
+func (f *Func) Values() []Param {
+	if f.ReturnsError() {
+		return f.Results[:len(f.Results)-1]
+	}
+	return f.Results
+}
+
+// Signature returns the Go signature, e.g. "Parse(s string) (int, error)".
+func (f *Func) Signature() string {
+	var ps []string
+	for _, p := range f.Params {
+		ps = append(ps, p.Name+" "+p.Type)
+	}
+	var rs []string
+	for _, r := range f.Results {
+		rs = append(rs, strings.TrimSpace(r.Name+" "+r.Type))
+	}
+	sig := f.Name + "(" + strings.Join(ps, ", ") + ")"
+	switch len(rs) {
+	case 0:
+	case 1:
+		sig += " " + rs[0]
+	default:
+		sig += " (" + strings.Join(rs, ", ") + ")"
+	}
+	return sig
+}
+
+// Skipped is an exported function the generator could not bridge.
+type Skipped struct {
+	Func   string
+	Reason string
+}
+
+// snake converts a Go identifier to snake_case, keeping acronyms together:
+// ParseHTTPHeader becomes parse_http_header.
+func snake(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				sb.WriteByte('_')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// rustKeywords are reserved in Rust and need a raw identifier or a suffix.
+var rustKeywords = map[string]bool{
+	"as": true, "async": true, "await": true, "box": true, "crate": true, "dyn": true,
+	"enum": true, "extern": true, "fn": true, "impl": true, "in": true, "let": true,
+	"loop": true, "match": true, "mod": true, "move": true, "mut": true, "pub": true,
+	"ref": true, "self": true, "static": true, "struct": true, "super": true, "trait": true,
+	"type": true, "unsafe": true, "use": true, "where": true, "while": true, "yield": true,
+}
+
+// rustIdent returns a Rust-safe snake_case identifier.
+func rustIdent(name string) string {
+	s := snake(name)
+	if rustKeywords[s] {
+		return s + "_"
+	}
+	return s
+}
+
+// relPath returns the slash-separated path from one module-relative directory to another.
+func relPath(from, to string) string {
+	fromParts := splitDir(from)
+	toParts := splitDir(to)
+	i := 0
+	for i < len(fromParts) && i < len(toParts) && fromParts[i] == toParts[i] {
+		i++
+	}
+	var parts []string
+	for range fromParts[i:] {
+		parts = append(parts, "..")
+	}
+	parts = append(parts, toParts[i:]...)
+	if len(parts) == 0 {
+		return "."
+	}
+	return strings.Join(parts, "/")
+}
+
+func splitDir(dir string) []string {
+	dir = path.Clean(dir)
+	if dir == "." {
+		return nil
+	}
+	return strings.Split(dir, "/")
+}
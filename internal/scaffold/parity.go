@@ -0,0 +1,464 @@
+package scaffold
+
+import (
+	"fmt"
+	"go/format"
+	"path"
+	"sort"
+	"strings"
+)
+
+// ParityOptions configure parity harness generation.
+type ParityOptions struct {
+	Funcs    []string // functions to record; all that can be recorded if empty
+	CrateDir string   // slash-separated, relative to the module root, e.g. parity/strutil
+}
+
+// Parity is a generated golden-test harness: a Go recording wrapper that writes the
+// inputs and outputs of calls to fixture files, and Rust tests replaying them against
+// the port.
+type Parity struct {
+	Files       []File
+	Recorded    []string
+	Skipped     []Skipped
+	FixtureDir  string // directory the recorder writes to, relative to the module root
+	RecordTest  string // Go test that drives the recorder
+	PackagePath string // go test argument for the package, e.g. ./internal/strutil
+}
+
+// parityFunc is a function the harness records.
+type parityFunc struct {
+	fn      Func
+	args    []field // without context.Context parameters
+	params  []Param // wrapper parameters, renamed where they clash with its locals
+	results []field
+}
+
+// GenerateParity generates the golden-test harness for the exported functions of pkg.
+func GenerateParity(pkg *Package, opts ParityOptions) (*Parity, error) {
+	if opts.CrateDir == "" {
+		opts.CrateDir = "parity/" + pkg.Name
+	}
+	selected := map[string]bool{}
+	for _, name := range opts.Funcs {
+		selected[name] = true
+	}
+
+	pkgPath := "./" + pkg.Dir
+	if pkg.Dir == "." {
+		pkgPath = "."
+	}
+	result := &Parity{
+		FixtureDir:  path.Join(pkg.Dir, "testdata", "parity"),
+		RecordTest:  "TestParityRecord",
+		PackagePath: pkgPath,
+	}
+
+	var funcs []parityFunc
+	for _, fn := range pkg.Funcs {
+		if len(selected) > 0 && !selected[fn.Name] {
+			continue
+		}
+		delete(selected, fn.Name)
+		pf, reason := newParityFunc(fn)
+		if reason != "" {
+			result.Skipped = append(result.Skipped, Skipped{Func: fn.Name, Reason: reason})
+			continue
+		}
+		funcs = append(funcs, pf)
+		result.Recorded = append(result.Recorded, fn.Name)
+	}
+	if len(selected) > 0 {
+		var missing []string
+		for name := range selected {
+			missing = append(missing, name)
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("no exported function %s in %s", strings.Join(missing, ", "), pkg.ImportPath)
+	}
+	if len(funcs) == 0 {
+		return nil, fmt.Errorf("no exported functions in %s can be recorded", pkg.ImportPath)
+	}
+
+	wrapper, err := format.Source([]byte(parityRecorder(pkg, funcs)))
+	if err != nil {
+		return nil, fmt.Errorf("formatting recorder: %w", err)
+	}
+	recordTest, err := format.Source([]byte(parityRecordTest(pkg, funcs, pkgPath)))
+	if err != nil {
+		return nil, fmt.Errorf("formatting record test: %w", err)
+	}
+	result.Files = []File{
+		{Path: path.Join(pkg.Dir, pkg.Name+"_parity.go"), Content: wrapper},
+		{Path: path.Join(pkg.Dir, pkg.Name+"_parity_test.go"), Content: recordTest},
+		{Path: path.Join(opts.CrateDir, "Cargo.toml"), Content: []byte(parityCargoToml(pkg, funcs))},
+		{Path: path.Join(opts.CrateDir, "src", "lib.rs"), Content: []byte(parityLib(pkg))},
+		{Path: path.Join(opts.CrateDir, "tests", "parity.rs"), Content: []byte(parityTests(pkg, funcs, relPath(opts.CrateDir, result.FixtureDir), pkgPath))},
+	}
+	return result, nil
+}
+
+func newParityFunc(fn Func) (parityFunc, string) {
+	if fn.Generic {
+		return parityFunc{}, "generic functions cannot be recorded"
+	}
+	pf := parityFunc{fn: fn}
+	for _, p := range fn.Params {
+		if strings.HasPrefix(p.Type, "func") || strings.Contains(p.Type, "chan ") {
+			return parityFunc{}, "parameter " + p.Name + " has type " + p.Type
+		}
+		name := p.Name
+		if parityReserved(name) {
+			name += "Arg"
+		}
+		pf.params = append(pf.params, Param{Name: name, Type: p.Type})
+		if p.Type == "context.Context" {
+			continue // contexts are not part of the recorded behavior
+		}
+		pf.args = append(pf.args, field{goName: p.Name, goType: p.Type, wire: fixtureType(p.Type)})
+	}
+	values := fn.Values()
+	for i, v := range values {
+		if strings.HasPrefix(v.Type, "func") || strings.Contains(v.Type, "chan ") {
+			return parityFunc{}, "result type " + v.Type
+		}
+		pf.results = append(pf.results, field{goName: resultName(v, i, len(values)), goType: v.Type, wire: fixtureType(v.Type)})
+	}
+	return pf, ""
+}
+
+// parityReserved reports whether a parameter name clashes with the recorder's locals.
+func parityReserved(name string) bool {
+	return name == "err" || (len(name) > 1 && name[0] == 'r' && strings.Trim(name[1:], "0123456789") == "")
+}
+
+// fixtureType is the Rust type a fixture value is read as. Types without a known serde
+// counterpart are kept as JSON values.
+func fixtureType(goType string) wireType {
+	if w, ok := wireTypeFor(goType); ok {
+		return w
+	}
+	return wireType{rust: "serde_json::Value"}
+}
+
+func parityRecorder(pkg *Package, funcs []parityFunc) string {
+	imports := map[string]bool{`"encoding/json"`: true, `"os"`: true, `"path/filepath"`: true, `"sync"`: true}
+	for _, pf := range funcs {
+		for _, spec := range pf.fn.Imports {
+			imports[spec] = true
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("//go:build parity\n\n")
+	sb.WriteString("// Code generated by rinku parity. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&sb, "package %s\n\n", pkg.Name)
+	writeImports(&sb, imports)
+
+	for _, pf := range funcs {
+		fn := pf.fn
+		var params, call, args, locals, results []string
+		for i, p := range pf.params {
+			if fn.Variadic && i == len(pf.params)-1 {
+				params = append(params, p.Name+" ..."+strings.TrimPrefix(p.Type, "[]"))
+				call = append(call, p.Name+"...")
+			} else {
+				params = append(params, p.Name+" "+p.Type)
+				call = append(call, p.Name)
+			}
+			if p.Type != "context.Context" {
+				args = append(args, fmt.Sprintf("%q: %s", fn.Params[i].Name, p.Name))
+			}
+		}
+		var types []string
+		for i, f := range pf.results {
+			local := fmt.Sprintf("r%d", i)
+			locals = append(locals, local)
+			results = append(results, fmt.Sprintf("%q: %s", f.goName, local))
+			types = append(types, f.goType)
+		}
+		errArg := "nil"
+		if fn.ReturnsError() {
+			locals = append(locals, "err")
+			types = append(types, "error")
+			errArg = "err"
+		}
+
+		fmt.Fprintf(&sb, "\n// record%s calls %s and appends the call to testdata/parity/%s.jsonl.\n", fn.Name, fn.Name, fn.Name)
+		fmt.Fprintf(&sb, "func record%s(%s) %s {\n", fn.Name, strings.Join(params, ", "), resultList(types))
+		invoke := fmt.Sprintf("%s(%s)", fn.Name, strings.Join(call, ", "))
+		if len(locals) > 0 {
+			fmt.Fprintf(&sb, "\t%s := %s\n", strings.Join(locals, ", "), invoke)
+		} else {
+			fmt.Fprintf(&sb, "\t%s\n", invoke)
+		}
+		fmt.Fprintf(&sb, "\tparityRecord(%q, map[string]any{%s}, map[string]any{%s}, %s)\n",
+			fn.Name, strings.Join(args, ", "), strings.Join(results, ", "), errArg)
+		if len(locals) > 0 {
+			fmt.Fprintf(&sb, "\treturn %s\n", strings.Join(locals, ", "))
+		}
+		sb.WriteString("}\n")
+	}
+
+	sb.WriteString(`
+var parityMu sync.Mutex
+
+// parityRecord appends one call to <dir>/<fn>.jsonl, where dir is $RINKU_PARITY_DIR or
+// testdata/parity. Failing calls are recorded with their error message instead of results.
+func parityRecord(fn string, args, results map[string]any, callErr error) {
+	dir := os.Getenv("RINKU_PARITY_DIR")
+	if dir == "" {
+		dir = filepath.Join("testdata", "parity")
+	}
+	call := struct {
+		Args    map[string]any ` + "`json:\"args\"`" + `
+		Results map[string]any ` + "`json:\"results\"`" + `
+		Error   *string        ` + "`json:\"error\"`" + `
+	}{Args: args, Results: results}
+	if callErr != nil {
+		msg := callErr.Error()
+		call.Results, call.Error = nil, &msg
+	}
+	line, err := json.Marshal(call)
+	if err != nil {
+		panic("rinku parity: recording " + fn + ": " + err.Error())
+	}
+
+	parityMu.Lock()
+	defer parityMu.Unlock()
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		panic("rinku parity: " + err.Error())
+	}
+	f, err := os.OpenFile(filepath.Join(dir, fn+".jsonl"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		panic("rinku parity: " + err.Error())
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		panic("rinku parity: " + err.Error())
+	}
+}
+`)
+	return sb.String()
+}
+
+func parityRecordTest(pkg *Package, funcs []parityFunc, pkgPath string) string {
+	imports := map[string]bool{`"testing"`: true}
+	for _, pf := range funcs {
+		for _, spec := range pf.fn.Imports {
+			if parityTestUses(pf, spec) {
+				imports[spec] = true
+			}
+		}
+	}
+	usesContext := false
+
+	var body strings.Builder
+	for _, pf := range funcs {
+		fn := pf.fn
+		fmt.Fprintf(&body, "\tt.Run(%q, func(t *testing.T) {\n", fn.Name)
+		var fields, call []string
+		for i, p := range pf.params {
+			arg := "c." + p.Name
+			if p.Type == "context.Context" {
+				arg = "context.Background()"
+				usesContext = true
+			} else {
+				fields = append(fields, p.Name+" "+p.Type)
+			}
+			if fn.Variadic && i == len(pf.params)-1 {
+				arg += "..."
+			}
+			call = append(call, arg)
+		}
+		invoke := fmt.Sprintf("record%s(%s)", fn.Name, strings.Join(call, ", "))
+		if len(fields) == 0 {
+			fmt.Fprintf(&body, "\t\t%s\n", invoke)
+		} else {
+			fmt.Fprintf(&body, "\t\tfor _, c := range []struct {\n\t\t\t%s\n\t\t}{\n", strings.Join(fields, "\n\t\t\t"))
+			fmt.Fprintf(&body, "\t\t\t// %s\n", parityCaseHint(pf))
+			fmt.Fprintf(&body, "\t\t} {\n\t\t\t%s\n\t\t}\n", invoke)
+		}
+		body.WriteString("\t})\n")
+	}
+	if usesContext {
+		imports[`"context"`] = true
+	}
+
+	var sb strings.Builder
+	sb.WriteString("//go:build parity\n\n")
+	fmt.Fprintf(&sb, "package %s\n\n", pkg.Name)
+	writeImports(&sb, imports)
+	sb.WriteString("\n// TestParityRecord records the fixtures the Rust parity tests replay. Add inputs that cover\n")
+	sb.WriteString("// the behavior worth preserving, delete testdata/parity and run:\n//\n")
+	fmt.Fprintf(&sb, "//\tgo test -tags parity -run TestParityRecord %s\n", pkgPath)
+	sb.WriteString("func TestParityRecord(t *testing.T) {\n")
+	sb.WriteString(body.String())
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// parityTestUses reports whether the record test needs an import of the function's
+// signature: only the types of recorded parameters appear in it.
+func parityTestUses(pf parityFunc, spec string) bool {
+	name, _, ok := strings.Cut(spec, " ")
+	if !ok {
+		name = importName(strings.Trim(spec, `"`))
+	}
+	for _, f := range pf.args {
+		if strings.Contains(f.goType, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// parityCaseHint is a commented example case, e.g. {s: "", strict: false},
+func parityCaseHint(pf parityFunc) string {
+	var parts []string
+	for _, p := range pf.params {
+		if p.Type != "context.Context" {
+			parts = append(parts, p.Name+": "+zeroValue(p.Type))
+		}
+	}
+	return "{" + strings.Join(parts, ", ") + "},"
+}
+
+func writeImports(sb *strings.Builder, specs map[string]bool) {
+	var sorted []string
+	for spec := range specs {
+		sorted = append(sorted, spec)
+	}
+	sort.Strings(sorted)
+	sb.WriteString("import (\n")
+	for _, spec := range sorted {
+		fmt.Fprintf(sb, "\t%s\n", spec)
+	}
+	sb.WriteString(")\n")
+}
+
+func resultList(types []string) string {
+	switch len(types) {
+	case 0:
+		return ""
+	case 1:
+		return types[0]
+	default:
+		return "(" + strings.Join(types, ", ") + ")"
+	}
+}
+
+func parityUsesBytes(funcs []parityFunc) bool {
+	for _, pf := range funcs {
+		for _, f := range append(append([]field{}, pf.args...), pf.results...) {
+			if f.goType == "[]byte" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parityCargoToml(pkg *Package, funcs []parityFunc) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Parity tests for the Rust port of %s, generated by rinku parity.\n", pkg.ImportPath)
+	fmt.Fprintf(&sb, "[package]\nname = %q\nversion = \"0.1.0\"\nedition = \"2021\"\npublish = false\n\n", pkg.Name+"-parity")
+	sb.WriteString("[dependencies]\n")
+	fmt.Fprintf(&sb, "# The ported crate, e.g. %s = { path = \"../../%s\" }\n\n", pkg.Name, pkg.Name)
+	sb.WriteString("[dev-dependencies]\n")
+	if parityUsesBytes(funcs) {
+		sb.WriteString("base64 = \"0.22\"\n")
+	}
+	sb.WriteString("serde = { version = \"1\", features = [\"derive\"] }\n")
+	sb.WriteString("serde_json = \"1\"\n")
+	return sb.String()
+}
+
+func parityLib(pkg *Package) string {
+	return fmt.Sprintf("//! Test-only crate for the Rust port of %s; see tests/parity.rs.\n", pkg.ImportPath)
+}
+
+func parityTests(pkg *Package, funcs []parityFunc, fixtureDir, pkgPath string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "//! Parity tests replaying calls recorded from the Go package %s, generated by\n", pkg.ImportPath)
+	sb.WriteString("//! rinku parity.\n")
+	sb.WriteString("//!\n")
+	fmt.Fprintf(&sb, "//! Record the fixtures with `go test -tags parity -run TestParityRecord %s`, then\n", pkgPath)
+	sb.WriteString("//! replace each todo!() with a call to the Rust port and assert it matches the case.\n\n")
+	sb.WriteString("use serde::de::DeserializeOwned;\nuse serde::Deserialize;\n")
+	if parityUsesBytes(funcs) {
+		sb.WriteString(rustB64Module)
+	}
+	for _, pf := range funcs {
+		if anyNullable(pf.args) || anyNullable(pf.results) {
+			sb.WriteString(rustNullableFn)
+			break
+		}
+	}
+	fmt.Fprintf(&sb, `
+/// A recorded Go call: its arguments and either its results or its error message.
+#[derive(Deserialize)]
+#[allow(dead_code)]
+struct Case<A, R> {
+    args: A,
+    results: Option<R>,
+    error: Option<String>,
+}
+
+/// Reads the fixtures recorded for a Go function.
+fn cases<A: DeserializeOwned, R: DeserializeOwned>(func: &str) -> Vec<Case<A, R>> {
+    let path = format!("{}/%s/{}.jsonl", env!("CARGO_MANIFEST_DIR"), func);
+    let data = std::fs::read_to_string(&path)
+        .unwrap_or_else(|err| panic!("reading {path}: {err}; record fixtures with TestParityRecord first"));
+    let cases: Vec<Case<A, R>> = data
+        .lines()
+        .filter(|line| !line.trim().is_empty())
+        .enumerate()
+        .map(|(i, line)| serde_json::from_str(line).unwrap_or_else(|err| panic!("{path}:{}: {err}", i + 1)))
+        .collect();
+    assert!(!cases.is_empty(), "{path} has no recorded calls");
+    cases
+}
+`, fixtureDir)
+
+	for _, pf := range funcs {
+		fn := pf.fn
+		args := fn.Name + "Args"
+		results := fn.Name + "Results"
+		writeFixtureStruct(&sb, args, pf.args)
+		writeFixtureStruct(&sb, results, pf.results)
+
+		fmt.Fprintf(&sb, "\n/// Go: %s\n#[test]\n", fn.Signature())
+		fmt.Fprintf(&sb, "fn %s_matches_go() {\n", snake(fn.Name))
+		fmt.Fprintf(&sb, "    for case in cases::<%s, %s>(%q) {\n", args, results, fn.Name)
+		if fn.ReturnsError() {
+			sb.WriteString("        // case.error is set, and case.results is None, when the Go call failed.\n")
+		}
+		fmt.Fprintf(&sb, "        let _ = &case;\n        todo!(\"call the Rust port of %s.%s and compare with the case\");\n", pkg.Name, fn.Name)
+		sb.WriteString("    }\n}\n")
+	}
+	return sb.String()
+}
+
+func writeFixtureStruct(sb *strings.Builder, name string, fields []field) {
+	sb.WriteString("\n#[derive(Deserialize)]\n#[allow(dead_code)]\n")
+	if len(fields) == 0 {
+		fmt.Fprintf(sb, "struct %s {}\n", name)
+		return
+	}
+	fmt.Fprintf(sb, "struct %s {\n", name)
+	for _, f := range fields {
+		ident := rustIdent(f.goName)
+		if ident != f.goName {
+			fmt.Fprintf(sb, "    #[serde(rename = %q)]\n", f.goName)
+		}
+		if f.goType == "[]byte" {
+			sb.WriteString("    #[serde(with = \"b64\")]\n")
+		}
+		if nullable(f) {
+			sb.WriteString("    #[serde(deserialize_with = \"nullable\")]\n")
+		}
+		fmt.Fprintf(sb, "    %s: %s,\n", ident, f.wire.rust)
+	}
+	sb.WriteString("}\n")
+}
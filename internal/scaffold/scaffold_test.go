@@ -0,0 +1,373 @@
+package scaffold
+
+import (
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stephan/rinku/internal/gosrc"
+)
+
+const strutilSource = `package strutil
+
+// Reverse returns s with its runes in reverse order.
+func Reverse(s string) string { return s }
+
+// Count counts occurrences of b in data.
+func Count(data []byte, b byte) int { return 0 }
+
+func Parse(s string, strict bool) (int64, error) { return 0, nil }
+
+func Reset() {}
+
+func Split(s, sep string) []string { return nil }
+
+func Join(parts ...string) string { return "" }
+
+func Map[T any](v T) T { return v }
+
+func unexported() {}
+
+type Buffer struct{}
+
+func (b *Buffer) Len() int { return 0 }
+`
+
+func loadPackage(t *testing.T, files map[string]string) *Package {
+	t.Helper()
+	dir := t.TempDir()
+	for rel, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tree, err := gosrc.Parse(dir)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	pkg, err := Load(tree, "example.com/app", "example.com/app/internal/strutil")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	return pkg
+}
+
+func file(t *testing.T, files []File, path string) string {
+	t.Helper()
+	for _, f := range files {
+		if f.Path == path {
+			return string(f.Content)
+		}
+	}
+	t.Fatalf("no generated file %s", path)
+	return ""
+}
+
+func TestLoad(t *testing.T) {
+	pkg := loadPackage(t, map[string]string{"internal/strutil/strutil.go": strutilSource})
+	if pkg.Name != "strutil" || pkg.Dir != "internal/strutil" {
+		t.Errorf("package = %s in %s", pkg.Name, pkg.Dir)
+	}
+
+	var names []string
+	for _, fn := range pkg.Funcs {
+		names = append(names, fn.Name)
+	}
+	if got := strings.Join(names, ","); got != "Count,Join,Map,Parse,Reset,Reverse,Split" {
+		t.Errorf("Funcs = %s", got)
+	}
+
+	parse := pkg.Funcs[3]
+	if parse.Signature() != "Parse(s string, strict bool) (int64, error)" || !parse.ReturnsError() {
+		t.Errorf("Parse signature = %q", parse.Signature())
+	}
+	if pkg.Funcs[1].Signature() != "Join(parts []string) string" || !pkg.Funcs[1].Variadic {
+		t.Errorf("Join = %+v", pkg.Funcs[1])
+	}
+}
+
+func TestGenerateFFI(t *testing.T) {
+	pkg := loadPackage(t, map[string]string{"internal/strutil/strutil.go": strutilSource})
+
+	ffi, err := GenerateFFI(pkg, FFIOptions{})
+	if err != nil {
+		t.Fatalf("GenerateFFI failed: %v", err)
+	}
+	if got := strings.Join(ffi.Bridged, ","); got != "Count,Parse,Reset,Reverse" {
+		t.Errorf("Bridged = %s", got)
+	}
+	if len(ffi.Skipped) != 3 {
+		t.Errorf("Skipped = %+v, want Join, Map and Split", ffi.Skipped)
+	}
+
+	lib := file(t, ffi.Files, "ffi/strutil/src/lib.rs")
+	for _, want := range []string{
+		`pub extern "C" fn strutil_reverse(s: *const c_char) -> *mut c_char {`,
+		`pub extern "C" fn strutil_count(data: *const u8, data_len: usize, b: u8) -> i64 {`,
+		`pub extern "C" fn strutil_parse(s: *const c_char, strict: bool, err: *mut *mut c_char) -> i64 {`,
+		`pub extern "C" fn strutil_free_string(s: *mut c_char) {`,
+		"/// Reverse returns s with its runes in reverse order.",
+		"//! - Map: generic functions have no C ABI",
+	} {
+		if !strings.Contains(lib, want) {
+			t.Errorf("lib.rs missing %q:\n%s", want, lib)
+		}
+	}
+
+	cargo := file(t, ffi.Files, "ffi/strutil/Cargo.toml")
+	if !strings.Contains(cargo, `crate-type = ["staticlib"]`) || !strings.Contains(cargo, "cbindgen") {
+		t.Errorf("Cargo.toml:\n%s", cargo)
+	}
+
+	wrapper := file(t, ffi.Files, "internal/strutil/strutil_rust.go")
+	formatted, err := format.Source([]byte(wrapper))
+	if err != nil {
+		t.Fatalf("wrapper does not parse: %v\n%s", err, wrapper)
+	}
+	if string(formatted) != wrapper {
+		t.Errorf("wrapper is not gofmt-formatted:\n%s", wrapper)
+	}
+	for _, want := range []string{
+		"//go:build rust",
+		"#cgo LDFLAGS: -L${SRCDIR}/../../ffi/strutil/target/release -lstrutil_ffi",
+		`#include "../../ffi/strutil/include/strutil_ffi.h"`,
+		"func rustParse(s string, strict bool) (int64, error) {",
+		"return 0, errors.New(C.GoString(cerr))",
+		"func cBytes(b []byte) (*C.uint8_t, C.size_t) {",
+	} {
+		if !strings.Contains(wrapper, want) {
+			t.Errorf("wrapper missing %q:\n%s", want, wrapper)
+		}
+	}
+}
+
+func TestSnake(t *testing.T) {
+	tests := map[string]string{
+		"Reverse":         "reverse",
+		"ParseHTTPHeader": "parse_http_header",
+		"ToUTF8":          "to_utf8",
+		"ID":              "id",
+	}
+	for in, want := range tests {
+		if got := snake(in); got != want {
+			t.Errorf("snake(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGenerateSeam_HTTP(t *testing.T) {
+	pkg := loadPackage(t, map[string]string{"internal/strutil/strutil.go": strutilSource})
+
+	seam, err := GenerateSeam(pkg, SeamOptions{})
+	if err != nil {
+		t.Fatalf("GenerateSeam failed: %v", err)
+	}
+	if got := strings.Join(seam.Bridged, ","); got != "Count,Parse,Reset,Reverse,Split" {
+		t.Errorf("Bridged = %s", got)
+	}
+
+	main := file(t, seam.Files, "seam/strutil/src/main.rs")
+	for _, want := range []string{
+		`.route("/rpc/Reverse", post(reverse))`,
+		"async fn parse(Json(req): Json<ParseRequest>) -> Result<Json<ParseResponse>, RpcError> {",
+		"    #[serde(with = \"b64\")]\n    pub data: Vec<u8>,",
+		"pub struct SplitResponse {\n    pub result: Vec<String>,\n}",
+		"pub struct ResetRequest {}",
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main.rs missing %q:\n%s", want, main)
+		}
+	}
+	if cargo := file(t, seam.Files, "seam/strutil/Cargo.toml"); !strings.Contains(cargo, "axum") || !strings.Contains(cargo, "base64") {
+		t.Errorf("Cargo.toml:\n%s", cargo)
+	}
+
+	client := file(t, seam.Files, "internal/strutil/strutilseam/client.go")
+	for _, want := range []string{
+		"package strutilseam",
+		"func (c *Client) Parse(ctx context.Context, s string, strict bool) (int64, error) {",
+		"func (c *Client) Reset(ctx context.Context) error {",
+		"return resp.Result, nil",
+	} {
+		if !strings.Contains(client, want) {
+			t.Errorf("client.go missing %q:\n%s", want, client)
+		}
+	}
+}
+
+func TestGenerateSeam_GRPC(t *testing.T) {
+	pkg := loadPackage(t, map[string]string{"internal/strutil/strutil.go": strutilSource + `
+func Tally(counts []int) int { return 0 }
+`})
+
+	seam, err := GenerateSeam(pkg, SeamOptions{Transport: TransportGRPC})
+	if err != nil {
+		t.Fatalf("GenerateSeam failed: %v", err)
+	}
+	if len(seam.Skipped) != 3 || seam.Skipped[2].Func != "Tally" {
+		t.Errorf("Skipped = %+v, want Join, Map and Tally ([]int has no generated counterpart)", seam.Skipped)
+	}
+
+	proto := file(t, seam.Files, "seam/strutil/proto/strutil.proto")
+	for _, want := range []string{
+		`option go_package = "example.com/app/internal/strutil/strutilseam;strutilseam";`,
+		"rpc Count(CountRequest) returns (CountResponse);",
+		"message CountRequest {\n  bytes data = 1;\n  uint32 b = 2;\n}",
+		"message SplitResponse {\n  repeated string result = 1;\n}",
+	} {
+		if !strings.Contains(proto, want) {
+			t.Errorf("proto missing %q:\n%s", want, proto)
+		}
+	}
+
+	main := file(t, seam.Files, "seam/strutil/src/main.rs")
+	if !strings.Contains(main, "use pb::strutil_server::{Strutil, StrutilServer};") ||
+		!strings.Contains(main, "async fn reverse(&self, request: Request<pb::ReverseRequest>)") {
+		t.Errorf("main.rs:\n%s", main)
+	}
+
+	client := file(t, seam.Files, "internal/strutil/strutilseam/client.go")
+	for _, want := range []string{
+		"//go:generate protoc -I ../../../seam/strutil/proto",
+		"resp, err := c.rpc.Count(ctx, &CountRequest{Data: data, B: uint32(b)})",
+		"return int(resp.Result), nil",
+	} {
+		if !strings.Contains(client, want) {
+			t.Errorf("client.go missing %q:\n%s", want, client)
+		}
+	}
+}
+
+func TestGenerateBench(t *testing.T) {
+	pkg := loadPackage(t, map[string]string{
+		"internal/strutil/strutil.go": strutilSource,
+		"internal/strutil/strutil_test.go": `package strutil
+
+import "testing"
+
+func BenchmarkReverse(b *testing.B) {
+	for b.Loop() {
+		Reverse("hello")
+	}
+}
+
+func BenchmarkSplit(b *testing.B) {
+	b.Run("short", func(b *testing.B) {})
+	b.Run("long input", func(b *testing.B) {})
+}
+
+func Benchmarkhelper(b *testing.B) {}
+
+func BenchmarkNotABenchmark(n int) {}
+`,
+	})
+	if len(pkg.Benchmarks) != 2 || strings.Join(pkg.Benchmarks[1].Names(), ",") != "BenchmarkSplit/short,BenchmarkSplit/long_input" {
+		t.Fatalf("Benchmarks = %+v", pkg.Benchmarks)
+	}
+
+	bench, err := GenerateBench(pkg, BenchOptions{})
+	if err != nil {
+		t.Fatalf("GenerateBench failed: %v", err)
+	}
+
+	rs := file(t, bench.Files, "bench/strutil/benches/strutil.rs")
+	for _, want := range []string{
+		"// Go (strutil_test.go:5):",
+		"//     for b.Loop() {",
+		`c.bench_function("BenchmarkReverse", |b| b.iter(|| todo!("port BenchmarkReverse")));`,
+		`let mut group = c.benchmark_group("BenchmarkSplit");`,
+		`group.bench_function("long_input",`,
+		"criterion_group!(benches, benchmark_reverse, benchmark_split);",
+	} {
+		if !strings.Contains(rs, want) {
+			t.Errorf("benches/strutil.rs missing %q:\n%s", want, rs)
+		}
+	}
+
+	goBench := file(t, bench.Files, "bench/strutil/go-bench.sh")
+	if !strings.Contains(goBench, `(cd ../.. && go test -run '^$' -bench '^(BenchmarkReverse|BenchmarkSplit)$' -benchmem -count 5 "$@" ./internal/strutil)`) {
+		t.Errorf("go-bench.sh:\n%s", goBench)
+	}
+	compare := file(t, bench.Files, "bench/strutil/compare.sh")
+	if !strings.Contains(compare, "for name in 'BenchmarkReverse' 'BenchmarkSplit/short' 'BenchmarkSplit/long_input'; do") {
+		t.Errorf("compare.sh:\n%s", compare)
+	}
+
+	if _, err := GenerateBench(loadPackage(t, map[string]string{"internal/strutil/strutil.go": strutilSource}), BenchOptions{}); err == nil {
+		t.Error("GenerateBench succeeded for a package without benchmarks")
+	}
+}
+
+func TestGenerateParity(t *testing.T) {
+	pkg := loadPackage(t, map[string]string{
+		"internal/strutil/strutil.go": strutilSource,
+		"internal/strutil/wait.go": `package strutil
+
+import (
+	"context"
+	"time"
+)
+
+func Wait(ctx context.Context, d time.Duration) error { return nil }
+`,
+	})
+
+	parity, err := GenerateParity(pkg, ParityOptions{})
+	if err != nil {
+		t.Fatalf("GenerateParity failed: %v", err)
+	}
+	if len(parity.Skipped) != 1 || parity.Skipped[0].Func != "Map" {
+		t.Errorf("Skipped = %+v, want Map", parity.Skipped)
+	}
+
+	recorder := file(t, parity.Files, "internal/strutil/strutil_parity.go")
+	for _, want := range []string{
+		"//go:build parity",
+		"\t\"time\"\n",
+		"func recordParse(s string, strict bool) (int64, error) {\n\tr0, err := Parse(s, strict)\n" +
+			"\tparityRecord(\"Parse\", map[string]any{\"s\": s, \"strict\": strict}, map[string]any{\"result\": r0}, err)",
+		"func recordJoin(parts ...string) string {\n\tr0 := Join(parts...)",
+		"func recordWait(ctx context.Context, d time.Duration) error {",
+		"parityRecord(\"Wait\", map[string]any{\"d\": d}, map[string]any{}, err)",
+	} {
+		if !strings.Contains(recorder, want) {
+			t.Errorf("recorder missing %q:\n%s", want, recorder)
+		}
+	}
+
+	recordTest := file(t, parity.Files, "internal/strutil/strutil_parity_test.go")
+	for _, want := range []string{
+		"go test -tags parity -run TestParityRecord ./internal/strutil",
+		"// {s: \"\", strict: false},",
+		"recordJoin(c.parts...)",
+		"recordWait(context.Background(), c.d)",
+		"\t\trecordReset()\n",
+	} {
+		if !strings.Contains(recordTest, want) {
+			t.Errorf("record test missing %q:\n%s", want, recordTest)
+		}
+	}
+
+	tests := file(t, parity.Files, "parity/strutil/tests/parity.rs")
+	for _, want := range []string{
+		`let path = format!("{}/../../internal/strutil/testdata/parity/{}.jsonl", env!("CARGO_MANIFEST_DIR"), func);`,
+		"struct CountArgs {\n    #[serde(with = \"b64\")]\n    data: Vec<u8>,\n    b: u8,\n}",
+		"struct WaitArgs {\n    d: serde_json::Value,\n}",
+		"struct SplitResults {\n    #[serde(deserialize_with = \"nullable\")]\n    result: Vec<String>,\n}",
+		"fn parse_matches_go() {\n    for case in cases::<ParseArgs, ParseResults>(\"Parse\") {",
+	} {
+		if !strings.Contains(tests, want) {
+			t.Errorf("parity.rs missing %q:\n%s", want, tests)
+		}
+	}
+
+	if _, err := GenerateParity(pkg, ParityOptions{Funcs: []string{"Reverse", "Nope"}}); err == nil || !strings.Contains(err.Error(), "Nope") {
+		t.Errorf("unknown function: err = %v", err)
+	}
+}
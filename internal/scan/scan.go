@@ -0,0 +1,204 @@
+// Package scan maps the dependencies of a manifest to a target language and holds the
+// document rinku scan writes in json and yaml. The /scan endpoint of rinku serve
+// answers with the same document, so CI scripts read one schema from either.
+package scan
+
+import (
+	"slices"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/target"
+	"github.com/stephan/rinku/internal/testkit"
+)
+
+// Result is the json and yaml output of scan.
+type Result struct {
+	Module         string          `json:"module,omitempty"`
+	GoVersion      string          `json:"go_version,omitempty"`
+	Package        string          `json:"package,omitempty"`         // name in a requirements.txt or package.json
+	SourceLang     string          `json:"source_language,omitempty"` // language of a manifest other than go.mod
+	TargetLang     string          `json:"target_language,omitempty"` // ts or js; omitted for rust
+	CategoryFilter []string        `json:"category_filter,omitempty"` // --category
+	MinConfidence  float64         `json:"min_confidence,omitempty"`  // --min-confidence
+	Direct         int             `json:"direct"`
+	DirectTotal    int             `json:"direct_total,omitempty"` // before the filters, if any
+	Mapped         int             `json:"mapped"`
+	Transitive     *Transitive     `json:"transitive,omitempty"` // with --transitive
+	Dependencies   []Dependency    `json:"dependencies"`
+	Consolidations []Consolidation `json:"consolidations,omitempty"`
+	Eliminated     int             `json:"eliminated_by_consolidation,omitempty"`
+	TestingStack   []string        `json:"testing_stack,omitempty"`
+}
+
+// Dependency is a direct dependency and its equivalents, best first: Rust crates,
+// or npm packages for ts and js.
+type Dependency struct {
+	Dependency string   `json:"dependency"`
+	Status     string   `json:"status"` // mapped or unmapped
+	Category   string   `json:"category,omitempty"`
+	Confidence float64  `json:"confidence,omitempty"` // of the mapping, from 0 to 1
+	Crates     []string `json:"crates,omitzero"`
+	Packages   []string `json:"packages,omitzero"`
+	URLs       []string `json:"urls"`
+	Scope      string   `json:"scope,omitempty"`           // direct or transitive, with --transitive
+	Project    bool     `json:"project_mapping,omitempty"` // mapped by .rinku/mappings.json
+}
+
+// Transitive counts the indirect modules of a build, listed in Dependencies with
+// their Scope.
+type Transitive struct {
+	Total  int `json:"total"`
+	Mapped int `json:"mapped"`
+}
+
+// Consolidation is a crate or package that replaces several dependencies.
+type Consolidation struct {
+	Crate        string   `json:"crate,omitempty"`
+	Package      string   `json:"package,omitempty"`
+	URL          string   `json:"url"`
+	Dependencies []string `json:"dependencies"`
+}
+
+// Name returns the crate or package of the consolidation.
+func (c *Consolidation) Name() string {
+	if c.Crate != "" {
+		return c.Crate
+	}
+	return c.Package
+}
+
+// Index is the part of the mapping database a scan uses; *rinku.Rinku implements it.
+type Index interface {
+	target.Index
+	Category(sourceURL, targetLang string) string
+	Confidence(sourceURL, targetLang string) float64
+	Overlaid(sourceURL, targetLang string) bool
+}
+
+// Mapping is a dependency with its equivalents in the target language.
+type Mapping struct {
+	Name       string
+	Dep        string // dependency without annotations, e.g. "(dev)"; suppressions match it
+	Category   string // mapping category, empty if unmapped
+	Confidence float64
+	Targets    []string // crate or package name per URL
+	URLs       []string
+	Project    bool // mapped by .rinku/mappings.json rather than the database
+}
+
+// Map looks up the equivalents of a dependency in the language of b. libURL is empty for
+// packages missing from the database. Like target.Map, a dependency without a forward
+// mapping falls back to the mappings from that language to Go.
+func Map(idx Index, b target.Backend, name, libURL string, unsafe bool) Mapping {
+	m := Mapping{Name: name, Dep: name}
+	if libURL == "" {
+		return m
+	}
+	m.URLs = idx.Lookup(libURL, b.Lang(), unsafe)
+	m.Project = idx.Overlaid(libURL, b.Lang())
+	if len(m.URLs) > 0 {
+		m.Category = idx.Category(libURL, b.Lang())
+		m.Confidence = idx.Confidence(libURL, b.Lang())
+	} else if !m.Project {
+		m.URLs = idx.ReverseLookup(libURL, b.Lang(), unsafe)
+	}
+	for _, u := range m.URLs {
+		m.Targets = append(m.Targets, b.PackageName(idx, u))
+	}
+	return m
+}
+
+// Dependency returns the mapping as listed in Result for the target language lang.
+func (m *Mapping) Dependency(lang string) Dependency {
+	dep := Dependency{
+		Dependency: m.Name,
+		Status:     "mapped",
+		Category:   m.Category,
+		Confidence: m.Confidence,
+		URLs:       append([]string{}, m.URLs...),
+		Project:    m.Project,
+	}
+	if len(m.URLs) == 0 {
+		dep.Status = "unmapped"
+	}
+	if lang == "rust" {
+		dep.Crates = append([]string{}, m.Targets...)
+	} else {
+		dep.Packages = append([]string{}, m.Targets...)
+	}
+	return dep
+}
+
+// Fill completes data with the direct dependencies mapped to the target language lang:
+// the counts, a Dependency per mapping and the consolidations.
+func Fill(data *Result, lang string, mappings []Mapping) {
+	if lang != "rust" {
+		data.TargetLang = lang
+	}
+	data.Direct = len(mappings)
+	data.Mapped = 0
+	data.Dependencies = make([]Dependency, 0, len(mappings))
+	for _, m := range mappings {
+		data.Dependencies = append(data.Dependencies, m.Dependency(lang))
+		if len(m.URLs) > 0 {
+			data.Mapped++
+		}
+	}
+	data.Consolidations, data.Eliminated = nil, 0
+	for _, c := range consolidations(mappings) {
+		data.Eliminated += len(c.Dependencies) - 1
+		if lang != "rust" {
+			c.Package, c.Crate = c.Crate, ""
+		}
+		data.Consolidations = append(data.Consolidations, c)
+	}
+}
+
+// GoMod returns the scan of the direct dependencies of a go.mod in the target language
+// lang, b its backend: rinku scan go.mod without flags, and the /scan of rinku serve.
+func GoMod(idx Index, b target.Backend, lang string, mod *gomod.ParseResult, unsafe bool) (*Result, []Mapping) {
+	deps := mod.DirectDependencies()
+	mappings := make([]Mapping, 0, len(deps))
+	paths := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		mappings = append(mappings, Map(idx, b, dep.Path, cargo.ModulePathToGitHubURL(dep.Path), unsafe))
+		paths = append(paths, dep.Path)
+	}
+	data := &Result{Module: mod.Module, GoVersion: mod.GoVersion}
+	Fill(data, lang, mappings)
+	data.TestingStack = TestingStack(testkit.Detect(paths))
+	return data, mappings
+}
+
+// TestingStack returns the names of detected test frameworks, nil for none.
+func TestingStack(frameworks []testkit.Framework) []string {
+	var names []string
+	for _, fw := range frameworks {
+		names = append(names, fw.Name)
+	}
+	return names
+}
+
+// consolidations groups the mapped dependencies by their best equivalent and returns
+// the crates several distinct dependencies collapse to, e.g. logrus and zap to tracing,
+// in the order of their first dependency.
+func consolidations(mappings []Mapping) []Consolidation {
+	var groups []Consolidation
+	index := make(map[string]int)
+	seen := make(map[string]bool)
+	for _, m := range mappings {
+		if len(m.URLs) == 0 || seen[m.Dep] {
+			continue
+		}
+		seen[m.Dep] = true
+		i, ok := index[m.URLs[0]]
+		if !ok {
+			i = len(groups)
+			index[m.URLs[0]] = i
+			groups = append(groups, Consolidation{Crate: m.Targets[0], URL: m.URLs[0]})
+		}
+		groups[i].Dependencies = append(groups[i].Dependencies, m.Name)
+	}
+	return slices.DeleteFunc(groups, func(c Consolidation) bool { return len(c.Dependencies) < 2 })
+}
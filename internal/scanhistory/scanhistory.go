@@ -0,0 +1,158 @@
+// Package scanhistory keeps snapshots of scan results under .rinku/scans, so the mapping
+// coverage of a project can be followed over time without re-parsing old versions of
+// go.mod from git.
+package scanhistory
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/natefinch/atomic"
+
+	"github.com/stephan/rinku/internal/progress"
+)
+
+// Dir is the directory below progress.ProgressDir holding the snapshots.
+const Dir = "scans"
+
+// timeFormat names snapshot files so they sort chronologically.
+const timeFormat = "20060102-150405"
+
+const currentVersion = 1
+
+// Snapshot is the result of one scan.
+type Snapshot struct {
+	Version      int          `json:"version"`
+	ScannedAt    time.Time    `json:"scanned_at"`
+	ScannedBy    string       `json:"scanned_by,omitempty"`
+	Module       string       `json:"module"`
+	Target       string       `json:"target"` // rust, ts or js
+	Direct       int          `json:"direct"`
+	Mapped       int          `json:"mapped"`
+	Dependencies []Dependency `json:"dependencies"`
+
+	Path string `json:"-"` // relative to the project directory, set by Save and List
+}
+
+// Dependency is a direct dependency and the libraries it mapped to, none if unmapped.
+type Dependency struct {
+	Path    string   `json:"path"`
+	Targets []string `json:"targets"`
+}
+
+// Coverage returns the share of mapped direct dependencies in percent, 100 without
+// dependencies.
+func (s *Snapshot) Coverage() int {
+	if s.Direct == 0 {
+		return 100
+	}
+	return s.Mapped * 100 / s.Direct
+}
+
+// Unmapped returns the paths of the dependencies without a mapping.
+func (s *Snapshot) Unmapped() []string {
+	var paths []string
+	for _, dep := range s.Dependencies {
+		if len(dep.Targets) == 0 {
+			paths = append(paths, dep.Path)
+		}
+	}
+	return paths
+}
+
+// Change is the difference between two snapshots.
+type Change struct {
+	NewlyMapped   []string // unmapped or absent before, mapped now
+	NewlyUnmapped []string // mapped or absent before, unmapped now
+	Removed       []string // dependencies no longer in go.mod
+}
+
+// Compare returns what changed from prev to s.
+func (s *Snapshot) Compare(prev *Snapshot) Change {
+	before := make(map[string]bool, len(prev.Dependencies))
+	for _, dep := range prev.Dependencies {
+		before[dep.Path] = len(dep.Targets) > 0
+	}
+	var c Change
+	for _, dep := range s.Dependencies {
+		mapped, ok := before[dep.Path]
+		delete(before, dep.Path)
+		switch now := len(dep.Targets) > 0; {
+		case now && (!ok || !mapped):
+			c.NewlyMapped = append(c.NewlyMapped, dep.Path)
+		case !now && (!ok || mapped):
+			c.NewlyUnmapped = append(c.NewlyUnmapped, dep.Path)
+		}
+	}
+	for path := range before {
+		c.Removed = append(c.Removed, path)
+	}
+	sort.Strings(c.Removed)
+	return c
+}
+
+// Save writes a snapshot to .rinku/scans/<time>.json, never replacing an earlier one.
+func Save(projectDir string, s *Snapshot) error {
+	s.Version = currentVersion
+	dir := filepath.Join(projectDir, progress.ProgressDir, Dir)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("creating scan directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling scan: %w", err)
+	}
+
+	prefix := s.ScannedAt.UTC().Format(timeFormat)
+	file := prefix + ".json"
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(dir, file)); errors.Is(err, os.ErrNotExist) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("checking scan: %w", err)
+		}
+		file = fmt.Sprintf("%s-%d.json", prefix, i)
+	}
+	if err := atomic.WriteFile(filepath.Join(dir, file), bytes.NewReader(append(data, '\n'))); err != nil {
+		return fmt.Errorf("writing scan: %w", err)
+	}
+	s.Path = filepath.Join(progress.ProgressDir, Dir, file)
+	return nil
+}
+
+// List returns the snapshots of a project, oldest first, or nil if none were saved.
+func List(projectDir string) ([]Snapshot, error) {
+	dir := filepath.Join(projectDir, progress.ProgressDir, Dir)
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading scans: %w", err)
+	}
+	var snapshots []Snapshot
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name())) //#nosec G304 -- a file of .rinku/scans
+		if err != nil {
+			return nil, fmt.Errorf("reading scan %s: %w", e.Name(), err)
+		}
+		var s Snapshot
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, fmt.Errorf("parsing scan %s: %w", e.Name(), err)
+		}
+		s.Path = filepath.Join(progress.ProgressDir, Dir, e.Name())
+		snapshots = append(snapshots, s)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].ScannedAt.Before(snapshots[j].ScannedAt) })
+	return snapshots, nil
+}
@@ -0,0 +1,60 @@
+package scanhistory
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func snapshot(at time.Time, deps ...Dependency) *Snapshot {
+	s := &Snapshot{ScannedAt: at, Module: "example.com/app", Target: "rust", Direct: len(deps), Dependencies: deps}
+	for _, dep := range deps {
+		if len(dep.Targets) > 0 {
+			s.Mapped++
+		}
+	}
+	return s
+}
+
+func TestSaveAndList(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	first := snapshot(now, Dependency{Path: "a", Targets: []string{"x"}}, Dependency{Path: "b"})
+	second := snapshot(now.Add(time.Millisecond), Dependency{Path: "a", Targets: []string{"x"}}, Dependency{Path: "b", Targets: []string{"y"}})
+	for _, s := range []*Snapshot{first, second} {
+		if err := Save(dir, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if first.Path == second.Path {
+		t.Fatalf("snapshots of the same second share %s", first.Path)
+	}
+
+	list, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Coverage() != 50 || list[1].Coverage() != 100 {
+		t.Fatalf("List = %+v", list)
+	}
+	if list[1].Path != second.Path {
+		t.Errorf("Path = %s, want %s", list[1].Path, second.Path)
+	}
+}
+
+func TestList_None(t *testing.T) {
+	list, err := List(t.TempDir())
+	if err != nil || list != nil {
+		t.Errorf("List = %v, %v; want nil, nil", list, err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	now := time.Now()
+	prev := snapshot(now, Dependency{Path: "a", Targets: []string{"x"}}, Dependency{Path: "b"}, Dependency{Path: "c"})
+	cur := snapshot(now, Dependency{Path: "a"}, Dependency{Path: "b", Targets: []string{"y"}}, Dependency{Path: "d"})
+	c := cur.Compare(prev)
+	if !slices.Equal(c.NewlyMapped, []string{"b"}) || !slices.Equal(c.NewlyUnmapped, []string{"a", "d"}) || !slices.Equal(c.Removed, []string{"c"}) {
+		t.Errorf("Compare = %+v", c)
+	}
+}
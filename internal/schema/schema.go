@@ -0,0 +1,269 @@
+// Package schema holds the JSON schemas of the requirement and progress files under
+// .rinku and validates documents against them, so hand-edited or agent-written state is
+// rejected before rinku acts on it or writes it.
+//
+// The validator implements the keywords the schemas use: type, properties, required,
+// additionalProperties, items, enum, format date-time, minLength and minimum.
+package schema
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Names of the schemas.
+const (
+	Requirement = "requirement"
+	Progress    = "progress"
+)
+
+var (
+	//go:embed requirement.schema.json
+	requirementSchema []byte
+	//go:embed progress.schema.json
+	progressSchema []byte
+)
+
+// Names returns the names of the schemas.
+func Names() []string {
+	return []string{Progress, Requirement}
+}
+
+// Document returns the JSON schema of name, or nil for an unknown name.
+func Document(name string) []byte {
+	switch name {
+	case Requirement:
+		return requirementSchema
+	case Progress:
+		return progressSchema
+	}
+	return nil
+}
+
+// Violation is a part of a document that does not match its schema.
+type Violation struct {
+	Pointer string // JSON pointer of the value, empty for the document
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Pointer == "" {
+		return v.Message
+	}
+	return v.Pointer + ": " + v.Message
+}
+
+// Error is returned by Validate for a document that does not match its schema.
+type Error struct {
+	Schema     string
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.String()
+	}
+	return fmt.Sprintf("does not match the %s schema: %s", e.Schema, strings.Join(msgs, "; "))
+}
+
+// node is a schema or subschema.
+type node struct {
+	Type                 string           `json:"type"`
+	Properties           map[string]*node `json:"properties"`
+	Required             []string         `json:"required"`
+	AdditionalProperties json.RawMessage  `json:"additionalProperties"`
+	Items                *node            `json:"items"`
+	Enum                 []string         `json:"enum"`
+	Format               string           `json:"format"`
+	MinLength            *int             `json:"minLength"`
+	Minimum              *float64         `json:"minimum"`
+}
+
+// additional returns the schema of properties not listed in Properties; closed is true
+// if they are not allowed.
+func (n *node) additional() (schema *node, closed bool, err error) {
+	switch s := string(bytes.TrimSpace(n.AdditionalProperties)); s {
+	case "", "true":
+		return nil, false, nil
+	case "false":
+		return nil, true, nil
+	}
+	var sub node
+	if err := json.Unmarshal(n.AdditionalProperties, &sub); err != nil {
+		return nil, false, err
+	}
+	return &sub, false, nil
+}
+
+// Validate checks a JSON document against the schema of name. It returns an *Error
+// listing the violations, ordered by pointer, if the document does not match.
+func Validate(name string, data []byte) error {
+	doc := Document(name)
+	if doc == nil {
+		return fmt.Errorf("unknown schema %q", name)
+	}
+	var root node
+	if err := json.Unmarshal(doc, &root); err != nil {
+		return fmt.Errorf("parsing %s schema: %w", name, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return &Error{Schema: name, Violations: []Violation{{Message: fmt.Sprintf("invalid JSON: %v", err)}}}
+	}
+	if dec.More() {
+		return &Error{Schema: name, Violations: []Violation{{Message: "invalid JSON: data after the document"}}}
+	}
+
+	var c checker
+	if err := c.check(&root, v, ""); err != nil {
+		return fmt.Errorf("%s schema: %w", name, err)
+	}
+	if len(c.violations) == 0 {
+		return nil
+	}
+	sort.SliceStable(c.violations, func(i, j int) bool { return c.violations[i].Pointer < c.violations[j].Pointer })
+	return &Error{Schema: name, Violations: c.violations}
+}
+
+type checker struct {
+	violations []Violation
+}
+
+func (c *checker) addf(ptr, format string, args ...any) {
+	c.violations = append(c.violations, Violation{Pointer: ptr, Message: fmt.Sprintf(format, args...)})
+}
+
+// check validates v at ptr against n. The error is a broken schema, not a violation.
+func (c *checker) check(n *node, v any, ptr string) error {
+	if n.Type != "" && !hasType(v, n.Type) {
+		c.addf(ptr, "must be %s, not %s", article(n.Type), article(typeOf(v)))
+		return nil
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		return c.checkObject(n, v, ptr)
+	case []any:
+		if n.Items != nil {
+			for i, item := range v {
+				if err := c.check(n.Items, item, fmt.Sprintf("%s/%d", ptr, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case string:
+		if n.Enum != nil && !slices.Contains(n.Enum, v) {
+			c.addf(ptr, "must be one of %s, not %q", strings.Join(n.Enum, ", "), v)
+		}
+		if n.MinLength != nil && len([]rune(v)) < *n.MinLength {
+			if *n.MinLength == 1 {
+				c.addf(ptr, "must not be empty")
+			} else {
+				c.addf(ptr, "must be at least %d characters", *n.MinLength)
+			}
+		}
+		if n.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				c.addf(ptr, "must be an RFC 3339 date-time, not %q", v)
+			}
+		}
+	case json.Number:
+		if n.Minimum != nil {
+			if f, err := v.Float64(); err == nil && f < *n.Minimum {
+				c.addf(ptr, "must be at least %v, not %s", *n.Minimum, v)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *checker) checkObject(n *node, obj map[string]any, ptr string) error {
+	for _, key := range n.Required {
+		if _, ok := obj[key]; !ok {
+			c.addf(ptr+"/"+escape(key), "is required")
+		}
+	}
+	additional, closed, err := n.additional()
+	if err != nil {
+		return fmt.Errorf("additionalProperties of %q: %w", ptr, err)
+	}
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		sub, ok := n.Properties[key]
+		switch {
+		case ok:
+		case closed:
+			c.addf(ptr+"/"+escape(key), "is not a known property")
+			continue
+		case additional != nil:
+			sub = additional
+		default:
+			continue
+		}
+		if err := c.check(sub, obj[key], ptr+"/"+escape(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasType(v any, typ string) bool {
+	switch typ {
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := n.Int64()
+		return err == nil
+	case "number":
+		_, ok := v.(json.Number)
+		return ok
+	}
+	return typeOf(v) == typ
+}
+
+func typeOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func article(typ string) string {
+	switch typ {
+	case "null":
+		return typ
+	case "integer", "object", "array":
+		return "an " + typ
+	}
+	return "a " + typ
+}
+
+// escape escapes a property name for a JSON pointer.
+func escape(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
@@ -0,0 +1,106 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestDocuments(t *testing.T) {
+	for _, name := range Names() {
+		var doc map[string]any
+		if err := json.Unmarshal(Document(name), &doc); err != nil {
+			t.Fatalf("%s schema: %v", name, err)
+		}
+		if doc["$schema"] == nil || doc["$id"] == nil {
+			t.Errorf("%s schema lacks $schema or $id", name)
+		}
+	}
+	if err := Validate("plan", []byte(`{}`)); err == nil {
+		t.Error("Validate of an unknown schema succeeded")
+	}
+}
+
+const requirement = `{
+  "path": "api/cli",
+  "content": "Keep the --json flag",
+  "step": "2",
+  "created_at": "2026-01-02T10:00:00Z",
+  "updated_at": "2026-01-02T10:00:00.123456789+01:00",
+  "done": false,
+  "resolution": "ported"
+}`
+
+const progressJSON = `{
+  "version": 1,
+  "started_at": "2026-01-02T10:00:00Z",
+  "project_path": "/src/api",
+  "current_step": "2",
+  "steps": {
+    "1": {"id": "1", "status": "completed", "elapsed_seconds": 30, "checked": [1, 2]},
+    "2": {"id": "2", "status": "in_progress"}
+  },
+  "step_order": ["1", "2"]
+}`
+
+func violations(t *testing.T, name, data string) []string {
+	t.Helper()
+	err := Validate(name, []byte(data))
+	if err == nil {
+		return nil
+	}
+	var se *Error
+	if !errors.As(err, &se) {
+		t.Fatalf("Validate: %v", err)
+	}
+	var got []string
+	for _, v := range se.Violations {
+		got = append(got, v.String())
+	}
+	return got
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name, schema, data string
+		want               []string
+	}{
+		{"requirement", Requirement, requirement, nil},
+		{"progress", Progress, progressJSON, nil},
+		{"invalid JSON", Requirement, `{"path": `, []string{"invalid JSON: unexpected EOF"}},
+		{"not an object", Progress, `[]`, []string{"must be an object, not an array"}},
+		{
+			"requirement mistakes", Requirement,
+			`{"path": "", "content": 1, "step": "", "created_at": "yesterday", "done": "no", "resolution": "moved", "notes": ""}`,
+			[]string{
+				`/content: must be a string, not a number`,
+				`/created_at: must be an RFC 3339 date-time, not "yesterday"`,
+				`/done: must be a boolean, not a string`,
+				`/notes: is not a known property`,
+				`/path: must not be empty`,
+				`/resolution: must be one of ported, renamed, dropped, not "moved"`,
+				`/updated_at: is required`,
+			},
+		},
+		{
+			"step mistakes", Progress,
+			`{"version": 1.5, "started_at": "2026-01-02T10:00:00Z", "project_path": "", "current_step": "1",
+			  "steps": {"1": {"id": "1", "status": "done", "elapsed_seconds": -1, "checked": [0]}, "a/b": {"status": "pending"}},
+			  "step_order": ["1", null]}`,
+			[]string{
+				`/step_order/1: must be a string, not null`,
+				`/steps/1/checked/0: must be at least 1, not 0`,
+				`/steps/1/elapsed_seconds: must be at least 0, not -1`,
+				`/steps/1/status: must be one of pending, in_progress, completed, skipped, not "done"`,
+				`/steps/a~1b/id: is required`,
+				`/version: must be an integer, not a number`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		if got := violations(t, tt.schema, tt.data); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: violations =\n%q\nwant\n%q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// buckets are the upper bounds in seconds of the request duration histogram, the
+// defaults of the Prometheus client libraries.
+var buckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// endpoints are the paths metrics are labeled with; requests to any other path count
+// as "other", so scanners cannot grow the label set.
+var endpoints = []string{"/lookup", "/reverse", "/categories", "/scan", "/convert", "/healthz"}
+
+// metrics counts the API requests of a Handler for /metrics.
+type metrics struct {
+	mu        sync.Mutex
+	requests  map[requestKey]int    // by endpoint and status code
+	durations map[string]*histogram // by endpoint
+	lookups   map[lookupKey]int     // mapping lookups by endpoint and hit or miss
+}
+
+type requestKey struct {
+	endpoint string
+	code     int
+}
+
+type lookupKey struct {
+	endpoint string
+	hit      bool
+}
+
+type histogram struct {
+	counts []int // per bucket, not cumulative; the last is +Inf
+	sum    float64
+	total  int
+}
+
+// endpoint returns the endpoint label of path.
+func endpoint(path string) string {
+	if slices.Contains(endpoints, path) {
+		return path
+	}
+	return "other"
+}
+
+// request records a request to path answered with code after d.
+func (m *metrics) request(path string, code int, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.requests == nil {
+		m.requests = make(map[requestKey]int)
+		m.durations = make(map[string]*histogram)
+	}
+	ep := endpoint(path)
+	m.requests[requestKey{ep, code}]++
+	hist := m.durations[ep]
+	if hist == nil {
+		hist = &histogram{counts: make([]int, len(buckets)+1)}
+		m.durations[ep] = hist
+	}
+	s := d.Seconds()
+	i, _ := slices.BinarySearch(buckets, s)
+	hist.counts[i]++
+	hist.sum += s
+	hist.total++
+}
+
+// lookup records hits and misses of the mapping database at path: dependencies or
+// libraries with and without an equivalent.
+func (m *metrics) lookup(path string, hits, misses int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.lookups == nil {
+		m.lookups = make(map[lookupKey]int)
+	}
+	ep := endpoint(path)
+	if hits > 0 {
+		m.lookups[lookupKey{ep, true}] += hits
+	}
+	if misses > 0 {
+		m.lookups[lookupKey{ep, false}] += misses
+	}
+}
+
+// write writes the metrics in the Prometheus text exposition format.
+func (m *metrics) write(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fmt.Fprintln(w, "# HELP rinku_requests_total API requests by endpoint and status code.")
+	fmt.Fprintln(w, "# TYPE rinku_requests_total counter")
+	requests := sortedKeys(m.requests, func(a, b requestKey) int {
+		if a.endpoint != b.endpoint {
+			return strings.Compare(a.endpoint, b.endpoint)
+		}
+		return a.code - b.code
+	})
+	for _, k := range requests {
+		fmt.Fprintf(w, "rinku_requests_total{endpoint=%q,code=\"%d\"} %d\n", k.endpoint, k.code, m.requests[k])
+	}
+
+	fmt.Fprintln(w, "# HELP rinku_lookups_total Mapping lookups by endpoint and result (hit or miss).")
+	fmt.Fprintln(w, "# TYPE rinku_lookups_total counter")
+	lookups := sortedKeys(m.lookups, func(a, b lookupKey) int {
+		if a.endpoint != b.endpoint {
+			return strings.Compare(a.endpoint, b.endpoint)
+		}
+		return strings.Compare(result(a.hit), result(b.hit))
+	})
+	for _, k := range lookups {
+		fmt.Fprintf(w, "rinku_lookups_total{endpoint=%q,result=%q} %d\n", k.endpoint, result(k.hit), m.lookups[k])
+	}
+
+	fmt.Fprintln(w, "# HELP rinku_request_duration_seconds Latency of API requests by endpoint.")
+	fmt.Fprintln(w, "# TYPE rinku_request_duration_seconds histogram")
+	for _, ep := range sortedKeys(m.durations, strings.Compare) {
+		hist := m.durations[ep]
+		cumulative := 0
+		for i, le := range buckets {
+			cumulative += hist.counts[i]
+			fmt.Fprintf(w, "rinku_request_duration_seconds_bucket{endpoint=%q,le=%q} %d\n", ep, strconv.FormatFloat(le, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(w, "rinku_request_duration_seconds_bucket{endpoint=%q,le=\"+Inf\"} %d\n", ep, hist.total)
+		fmt.Fprintf(w, "rinku_request_duration_seconds_sum{endpoint=%q} %s\n", ep, strconv.FormatFloat(hist.sum, 'g', -1, 64))
+		fmt.Fprintf(w, "rinku_request_duration_seconds_count{endpoint=%q} %d\n", ep, hist.total)
+	}
+}
+
+func result(hit bool) string {
+	if hit {
+		return "hit"
+	}
+	return "miss"
+}
+
+func sortedKeys[K comparable, V any](m map[K]V, cmp func(a, b K) int) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, cmp)
+	return keys
+}
@@ -0,0 +1,399 @@
+// Package server is the JSON API of rinku serve: lookups and reverse lookups of a
+// library, the mappings by category, and the scan and Cargo.toml conversion of a go.mod
+// sent as request body, so a team can share one mapping service instead of installing
+// the CLI everywhere. The web UI at / is a page over the same API; /healthz and
+// /metrics are for the orchestrator and Prometheus.
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/scan"
+	"github.com/stephan/rinku/internal/target"
+	"github.com/stephan/rinku/internal/types"
+	"github.com/stephan/rinku/internal/url"
+	"github.com/stephan/rinku/render"
+)
+
+// MaxBody limits the go.mod bodies of /scan and /convert.
+const MaxBody = 1 << 20
+
+// Lookup is the mapping database.
+type Lookup interface {
+	cargo.Lookup
+	cargo.ReverseLookup
+	scan.Index
+	Sources(targetLang string) []string
+}
+
+// VersionHeader selects the API version of a request, see render.ParseAPIVersion.
+// Responses carry it with the version they were written in.
+const VersionHeader = "Rinku-Api-Version"
+
+// Handler serves the API.
+type Handler struct {
+	Lookup Lookup
+	Unsafe bool // include libraries with known vulnerabilities unless a request sets unsafe
+	// APIVersion is the version of the responses to requests without VersionHeader,
+	// render.APIVersion1 if zero.
+	APIVersion int
+	Logger     *log.Logger
+
+	metrics metrics
+}
+
+// LookupResponse is the response of /lookup.
+type LookupResponse struct {
+	Source     string              `json:"source"` // normalized source URL
+	Language   string              `json:"language"`
+	Category   string              `json:"category,omitempty"`
+	Confidence float64             `json:"confidence,omitempty"` // of the mapping, from 0 to 1
+	Targets    []Target            `json:"targets"`
+	Requires   []types.RequiredDep `json:"requires,omitempty"`
+}
+
+// Target is an equivalent library.
+type Target struct {
+	URL   string `json:"url"`
+	Crate string `json:"crate,omitempty"` // Rust targets only
+}
+
+// ReverseResponse is the response of /reverse.
+type ReverseResponse struct {
+	Target   string   `json:"target"` // normalized target URL
+	Language string   `json:"language"`
+	Sources  []string `json:"sources"`
+}
+
+// CategoriesResponse is the response of /categories.
+type CategoriesResponse struct {
+	Language   string     `json:"language"`
+	Categories []Category `json:"categories"`
+}
+
+// Category is a mapping category and the libraries mapped in it.
+type Category struct {
+	Name      string    `json:"name"`
+	Libraries []Library `json:"libraries"`
+}
+
+// Library is a source library and its equivalents.
+type Library struct {
+	Source  string   `json:"source"`
+	Targets []Target `json:"targets"`
+}
+
+// HealthResponse is the response of /healthz.
+type HealthResponse struct {
+	Status string `json:"status"` // ok
+}
+
+// ConvertResponse is the response of /convert.
+type ConvertResponse struct {
+	Module    string   `json:"module"`
+	Mapped    int      `json:"mapped"`
+	Unmapped  []string `json:"unmapped"` // Go modules left as TODO comments
+	CargoToml string   `json:"cargo_toml"`
+}
+
+// ErrorResponse is the body of every failed request.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// httpError is an error with the status code it is answered with.
+type httpError struct {
+	status int
+	msg    string
+}
+
+func (e *httpError) Error() string { return e.msg }
+
+func errorf(status int, format string, args ...any) error {
+	return &httpError{status: status, msg: fmt.Sprintf(format, args...)}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if (r.Method == http.MethodGet || r.Method == http.MethodHead) && isAsset(r.URL.Path) {
+		ui.ServeHTTP(w, r)
+		return
+	}
+	if r.URL.Path == "/metrics" && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		h.metrics.write(w)
+		return
+	}
+	start := time.Now()
+	var v any
+	version, err := h.version(r)
+	switch {
+	case err != nil: // answered below
+	case r.URL.Path == "/lookup":
+		v, err = h.get(w, r, h.lookup)
+	case r.URL.Path == "/reverse":
+		v, err = h.get(w, r, h.reverse)
+	case r.URL.Path == "/categories":
+		v, err = h.get(w, r, h.categories)
+	case r.URL.Path == "/scan":
+		v, err = h.post(w, r, h.scan)
+	case r.URL.Path == "/convert":
+		v, err = h.post(w, r, h.convert)
+	case r.URL.Path == "/healthz":
+		v, err = h.get(w, r, h.healthz)
+	default:
+		err = errorf(http.StatusNotFound, "no endpoint %s: use /lookup, /reverse, /categories, /scan, /convert, /healthz or /metrics", r.URL.Path)
+	}
+	status := http.StatusOK
+	if err != nil {
+		status = http.StatusInternalServerError
+		var he *httpError
+		if errors.As(err, &he) {
+			status = he.status
+		} else {
+			h.logf("%s %s: %v", r.Method, r.URL.Path, err)
+		}
+		v = ErrorResponse{Error: err.Error()}
+	} else {
+		v = render.Versioned(version, strings.TrimPrefix(r.URL.Path, "/"), v)
+	}
+	// Cargo.toml comments hold "->", which the default encoding escapes
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		h.logf("encoding response: %v", err)
+		buf.Reset()
+		buf.WriteString(`{"error": "encoding response"}` + "\n")
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if version != 0 {
+		w.Header().Set(VersionHeader, strconv.Itoa(version))
+		if notice := render.Deprecation(version); notice != "" {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Warning", "299 rinku "+strconv.Quote(notice))
+		}
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+	h.metrics.request(r.URL.Path, status, time.Since(start))
+}
+
+// version returns the API version of VersionHeader, or the default of the handler.
+func (h *Handler) version(r *http.Request) (int, error) {
+	s := r.Header.Get(VersionHeader)
+	if s == "" {
+		if h.APIVersion == 0 {
+			return render.APIVersion1, nil
+		}
+		return h.APIVersion, nil
+	}
+	v, err := render.ParseAPIVersion(s)
+	if err != nil {
+		return 0, errorf(http.StatusBadRequest, "%s: %v", VersionHeader, err)
+	}
+	return v, nil
+}
+
+func (h *Handler) logf(format string, args ...any) {
+	if h.Logger != nil {
+		h.Logger.Printf(format, args...)
+	}
+}
+
+func (h *Handler) get(w http.ResponseWriter, r *http.Request, fn func(*http.Request, bool) (any, error)) (any, error) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		return nil, errorf(http.StatusMethodNotAllowed, "%s takes GET", r.URL.Path)
+	}
+	unsafe, err := h.unsafe(r)
+	if err != nil {
+		return nil, err
+	}
+	return fn(r, unsafe)
+}
+
+func (h *Handler) post(w http.ResponseWriter, r *http.Request, fn func(*gomod.ParseResult, bool) (any, error)) (any, error) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		return nil, errorf(http.StatusMethodNotAllowed, "%s takes POST with a go.mod body", r.URL.Path)
+	}
+	unsafe, err := h.unsafe(r)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBody))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, errorf(http.StatusRequestEntityTooLarge, "go.mod is larger than %d bytes", MaxBody)
+		}
+		return nil, fmt.Errorf("reading body: %w", err)
+	}
+	mod, err := gomod.ParseReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, errorf(http.StatusBadRequest, "parsing go.mod: %v", err)
+	}
+	if mod.Module == "" {
+		return nil, errorf(http.StatusBadRequest, "parsing go.mod: no module directive")
+	}
+	return fn(mod, unsafe)
+}
+
+// unsafe returns the unsafe query parameter, or the default of the handler.
+func (h *Handler) unsafe(r *http.Request) (bool, error) {
+	s := r.URL.Query().Get("unsafe")
+	if s == "" {
+		return h.Unsafe, nil
+	}
+	unsafe, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, errorf(http.StatusBadRequest, "unsafe must be true or false, got %q", s)
+	}
+	return unsafe, nil
+}
+
+// library returns the url and lang query parameters, lang defaulting to def.
+func library(r *http.Request, def string) (string, string, error) {
+	q := r.URL.Query()
+	libURL := q.Get("url")
+	if libURL == "" {
+		return "", "", errorf(http.StatusBadRequest, "missing url parameter")
+	}
+	lang := q.Get("lang")
+	if lang == "" {
+		lang = def
+	}
+	return libURL, lang, nil
+}
+
+func (h *Handler) lookup(r *http.Request, unsafe bool) (any, error) {
+	libURL, lang, err := library(r, "rust")
+	if err != nil {
+		return nil, err
+	}
+	resp := &LookupResponse{
+		Source:     url.Normalize(libURL),
+		Language:   lang,
+		Category:   h.Lookup.Category(libURL, lang),
+		Confidence: h.Lookup.Confidence(libURL, lang),
+		Targets:    h.targets(libURL, lang, unsafe),
+		Requires:   h.Lookup.RequiredDeps(libURL, lang),
+	}
+	h.hit("/lookup", len(resp.Targets) > 0)
+	return resp, nil
+}
+
+// targets returns the equivalents of libURL in lang, with crate names for rust.
+func (h *Handler) targets(libURL, lang string, unsafe bool) []Target {
+	targets := []Target{}
+	for _, u := range h.Lookup.Lookup(libURL, lang, unsafe) {
+		t := Target{URL: u}
+		if lang == "rust" {
+			if t.Crate = h.Lookup.CrateName(u); t.Crate == "" {
+				t.Crate = cargo.ExtractCrateName(u)
+			}
+		}
+		targets = append(targets, t)
+	}
+	return targets
+}
+
+// categories lists the mapped libraries of the lang parameter (default rust) by
+// category, sorted by name. Uncategorized mappings are left out.
+func (h *Handler) categories(r *http.Request, unsafe bool) (any, error) {
+	lang := r.URL.Query().Get("lang")
+	if lang == "" {
+		lang = "rust"
+	}
+	byName := make(map[string]*Category)
+	for _, source := range h.Lookup.Sources(lang) {
+		name := h.Lookup.Category(source, lang)
+		if name == "" {
+			continue
+		}
+		targets := h.targets(source, lang, unsafe)
+		if len(targets) == 0 {
+			continue // only vulnerable targets
+		}
+		c := byName[name]
+		if c == nil {
+			c = &Category{Name: name}
+			byName[name] = c
+		}
+		c.Libraries = append(c.Libraries, Library{Source: source, Targets: targets})
+	}
+	resp := &CategoriesResponse{Language: lang, Categories: []Category{}}
+	for _, c := range byName {
+		resp.Categories = append(resp.Categories, *c)
+	}
+	sort.Slice(resp.Categories, func(i, j int) bool { return resp.Categories[i].Name < resp.Categories[j].Name })
+	return resp, nil
+}
+
+func (h *Handler) reverse(r *http.Request, unsafe bool) (any, error) {
+	target, lang, err := library(r, "go")
+	if err != nil {
+		return nil, err
+	}
+	libURL, err := cargo.ResolveTarget(h.Lookup, target)
+	if err != nil {
+		return nil, errorf(http.StatusNotFound, "%v", err)
+	}
+	sources := h.Lookup.ReverseLookup(libURL, lang, unsafe)
+	if sources == nil {
+		sources = []string{}
+	}
+	h.hit("/reverse", len(sources) > 0)
+	return &ReverseResponse{Target: url.Normalize(libURL), Language: lang, Sources: sources}, nil
+}
+
+func (h *Handler) scan(mod *gomod.ParseResult, unsafe bool) (any, error) {
+	b, ok := target.Lookup("rust")
+	if !ok {
+		return nil, errors.New("no rust target backend")
+	}
+	result, _ := scan.GoMod(h.Lookup, b, "rust", mod, unsafe)
+	h.metrics.lookup("/scan", result.Mapped, result.Direct-result.Mapped)
+	return result, nil
+}
+
+func (h *Handler) convert(mod *gomod.ParseResult, unsafe bool) (any, error) {
+	result := cargo.MapDependencies(mod.DirectDependencies(), h.Lookup, unsafe)
+	h.metrics.lookup("/convert", len(result.Mapped), len(result.Unmapped))
+	var buf bytes.Buffer
+	if err := cargo.GenerateCargoToml(&buf, mod.Module, result); err != nil {
+		return nil, fmt.Errorf("generating Cargo.toml: %w", err)
+	}
+	resp := &ConvertResponse{Module: mod.Module, Mapped: len(result.Mapped), Unmapped: []string{}, CargoToml: buf.String()}
+	for _, u := range result.Unmapped {
+		resp.Unmapped = append(resp.Unmapped, u.GoDep.Path)
+	}
+	return resp, nil
+}
+
+func (h *Handler) healthz(r *http.Request, unsafe bool) (any, error) {
+	return &HealthResponse{Status: "ok"}, nil
+}
+
+// hit records a single lookup of path.
+func (h *Handler) hit(path string, found bool) {
+	if found {
+		h.metrics.lookup(path, 1, 0)
+	} else {
+		h.metrics.lookup(path, 0, 1)
+	}
+}
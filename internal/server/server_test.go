@@ -0,0 +1,281 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stephan/rinku/internal/scan"
+	"github.com/stephan/rinku/internal/types"
+)
+
+type fakeLookup struct{}
+
+var forward = map[string][]string{
+	"https://github.com/spf13/cobra":      {"https://github.com/clap-rs/clap"},
+	"https://github.com/valyala/fasthttp": {"https://github.com/hyperium/hyper"},
+}
+
+func (fakeLookup) Lookup(sourceURL, targetLang string, unsafe bool) []string {
+	if sourceURL == "https://github.com/valyala/fasthttp" && !unsafe {
+		return nil
+	}
+	return forward[sourceURL]
+}
+
+func (fakeLookup) CrateName(rustURL string) string { return "" }
+
+func (fakeLookup) RequiredDeps(sourceURL, targetLang string) []types.RequiredDep { return nil }
+
+func (fakeLookup) ReverseLookup(targetURL, sourceLang string, unsafe bool) []string {
+	if targetURL == "https://github.com/clap-rs/clap" {
+		return []string{"https://github.com/spf13/cobra"}
+	}
+	return nil
+}
+
+func (fakeLookup) PackageURL(lang, name string) string {
+	if lang == "rust" && name == "clap" {
+		return "https://github.com/clap-rs/clap"
+	}
+	return ""
+}
+
+func (fakeLookup) Confidence(sourceURL, targetLang string) float64 {
+	if sourceURL == "https://github.com/spf13/cobra" {
+		return 0.9
+	}
+	return 0
+}
+
+func (fakeLookup) Overlaid(sourceURL, targetLang string) bool { return false }
+
+func (fakeLookup) PackageNames(lang, libURL string) []string { return nil }
+
+func (fakeLookup) Sources(targetLang string) []string {
+	return []string{"https://github.com/spf13/cobra", "https://github.com/valyala/fasthttp"}
+}
+
+func (fakeLookup) Category(sourceURL, targetLang string) string {
+	if sourceURL == "https://github.com/spf13/cobra" {
+		return "cli_framework"
+	}
+	return ""
+}
+
+const goMod = "module acme/api\n\ngo 1.22\n\nrequire (\n\tgithub.com/spf13/cobra v1.8.0\n\tgithub.com/valyala/fasthttp v1.50.0\n\tgolang.org/x/sys v0.1.0 // indirect\n)\n"
+
+func serve(t *testing.T, method, target, body string, v any) int {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	(&Handler{Lookup: fakeLookup{}}).ServeHTTP(rec, req)
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("%s %s: Content-Type = %q", method, target, ct)
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("%s %s: decoding %q: %v", method, target, rec.Body.String(), err)
+	}
+	return rec.Code
+}
+
+func TestLookup(t *testing.T) {
+	var resp LookupResponse
+	if code := serve(t, http.MethodGet, "/lookup?url=https://github.com/spf13/cobra", "", &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if resp.Source != "github.com/spf13/cobra" || resp.Language != "rust" || resp.Category != "cli_framework" || resp.Confidence != 0.9 {
+		t.Errorf("resp = %+v", resp)
+	}
+	if len(resp.Targets) != 1 || resp.Targets[0].Crate != "clap" {
+		t.Errorf("Targets = %+v", resp.Targets)
+	}
+
+	var reverse ReverseResponse
+	serve(t, http.MethodGet, "/reverse?url=https://github.com/clap-rs/clap", "", &reverse)
+	if reverse.Language != "go" || len(reverse.Sources) != 1 || reverse.Sources[0] != "https://github.com/spf13/cobra" {
+		t.Errorf("reverse = %+v", reverse)
+	}
+	for _, target := range []string{"clap", "https://crates.io/crates/clap"} {
+		var byCrate ReverseResponse
+		serve(t, http.MethodGet, "/reverse?url="+target, "", &byCrate)
+		if byCrate.Target != "github.com/clap-rs/clap" || len(byCrate.Sources) != 1 {
+			t.Errorf("reverse of %s = %+v", target, byCrate)
+		}
+	}
+}
+
+func TestCategories(t *testing.T) {
+	var resp CategoriesResponse
+	if code := serve(t, http.MethodGet, "/categories", "", &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if resp.Language != "rust" || len(resp.Categories) != 1 || resp.Categories[0].Name != "cli_framework" {
+		t.Fatalf("resp = %+v", resp)
+	}
+	libs := resp.Categories[0].Libraries
+	if len(libs) != 1 || libs[0].Source != "https://github.com/spf13/cobra" || libs[0].Targets[0].Crate != "clap" {
+		t.Errorf("Libraries = %+v", libs)
+	}
+}
+
+func TestUI(t *testing.T) {
+	for _, tt := range []struct {
+		path, contentType, contains string
+	}{
+		{"/", "text/html", "<title>rinku</title>"},
+		{"/app.js", "javascript", `api("/scan"`},
+		{"/style.css", "text/css", "body {"},
+	} {
+		rec := httptest.NewRecorder()
+		(&Handler{Lookup: fakeLookup{}}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Header().Get("Content-Type"), tt.contentType) || !strings.Contains(rec.Body.String(), tt.contains) {
+			t.Errorf("GET %s = %d %s", tt.path, rec.Code, rec.Header().Get("Content-Type"))
+		}
+	}
+
+	// Other methods get the API.
+	var resp ErrorResponse
+	if code := serve(t, http.MethodPost, "/", "", &resp); code != http.StatusNotFound {
+		t.Errorf("POST / = %d %+v", code, resp)
+	}
+}
+
+func TestScan(t *testing.T) {
+	var resp scan.Result
+	if code := serve(t, http.MethodPost, "/scan", goMod, &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if resp.Module != "acme/api" || resp.Direct != 2 || resp.Mapped != 1 {
+		t.Errorf("resp = %+v", resp)
+	}
+	if d := resp.Dependencies[0]; d.Dependency != "github.com/spf13/cobra" || d.Category != "cli_framework" || d.Confidence != 0.9 {
+		t.Errorf("Dependencies[0] = %+v", d)
+	}
+	if d := resp.Dependencies[1]; d.Dependency != "github.com/valyala/fasthttp" || d.Status != "unmapped" {
+		t.Errorf("Dependencies[1] = %+v", d)
+	}
+
+	serve(t, http.MethodPost, "/scan?unsafe=true", goMod, &resp)
+	if resp.Mapped != 2 {
+		t.Errorf("Mapped with unsafe = %d, want 2", resp.Mapped)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	var resp ConvertResponse
+	if code := serve(t, http.MethodPost, "/convert", goMod, &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if !strings.Contains(resp.CargoToml, `clap = "*"  # from github.com/spf13/cobra`) {
+		t.Errorf("CargoToml = %s", resp.CargoToml)
+	}
+	if len(resp.Unmapped) != 1 || resp.Unmapped[0] != "github.com/valyala/fasthttp" {
+		t.Errorf("Unmapped = %v", resp.Unmapped)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		method, target, body string
+		status               int
+		msg                  string
+	}{
+		{http.MethodGet, "/lookup", "", http.StatusBadRequest, "missing url parameter"},
+		{http.MethodGet, "/lookup?url=x&unsafe=maybe", "", http.StatusBadRequest, `unsafe must be true or false, got "maybe"`},
+		{http.MethodPost, "/lookup?url=x", "", http.StatusMethodNotAllowed, "/lookup takes GET"},
+		{http.MethodGet, "/scan", "", http.StatusMethodNotAllowed, "/scan takes POST with a go.mod body"},
+		{http.MethodPost, "/scan", "require (\n", http.StatusBadRequest, "parsing go.mod: unclosed require block"},
+		{http.MethodPost, "/convert", "go 1.22\n", http.StatusBadRequest, "parsing go.mod: no module directive"},
+		{http.MethodPost, "/scan", strings.Repeat("x", MaxBody+1), http.StatusRequestEntityTooLarge, "go.mod is larger than 1048576 bytes"},
+		{http.MethodGet, "/reverse?url=acme-billing", "", http.StatusNotFound, `no Rust library named "acme-billing" in the database`},
+		{http.MethodGet, "/missing.js", "", http.StatusNotFound, "no endpoint /missing.js: use /lookup, /reverse, /categories, /scan, /convert, /healthz or /metrics"},
+	}
+	for _, tt := range tests {
+		var resp ErrorResponse
+		if code := serve(t, tt.method, tt.target, tt.body, &resp); code != tt.status || resp.Error != tt.msg {
+			t.Errorf("%s %s = %d %q, want %d %q", tt.method, tt.target, code, resp.Error, tt.status, tt.msg)
+		}
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	var resp HealthResponse
+	if code := serve(t, http.MethodGet, "/healthz", "", &resp); code != http.StatusOK || resp.Status != "ok" {
+		t.Errorf("GET /healthz = %d %+v", code, resp)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	h := &Handler{Lookup: fakeLookup{}}
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/lookup?url=https://github.com/spf13/cobra", nil),
+		httptest.NewRequest(http.MethodGet, "/lookup?url=https://github.com/valyala/fasthttp", nil),
+		httptest.NewRequest(http.MethodGet, "/lookup", nil),
+		httptest.NewRequest(http.MethodPost, "/scan", strings.NewReader(goMod)),
+		httptest.NewRequest(http.MethodGet, "/wp-login.php", nil),
+	} {
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE rinku_requests_total counter\n",
+		`rinku_requests_total{endpoint="/lookup",code="200"} 2` + "\n",
+		`rinku_requests_total{endpoint="/lookup",code="400"} 1` + "\n",
+		`rinku_requests_total{endpoint="other",code="404"} 1` + "\n",
+		`rinku_lookups_total{endpoint="/lookup",result="hit"} 1` + "\n",
+		`rinku_lookups_total{endpoint="/lookup",result="miss"} 1` + "\n",
+		`rinku_lookups_total{endpoint="/scan",result="hit"} 1` + "\n",
+		`rinku_lookups_total{endpoint="/scan",result="miss"} 1` + "\n",
+		"# TYPE rinku_request_duration_seconds histogram\n",
+		`rinku_request_duration_seconds_bucket{endpoint="/lookup",le="+Inf"} 3` + "\n",
+		`rinku_request_duration_seconds_count{endpoint="/scan"} 1` + "\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics lack %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/lookup?url=https://github.com/spf13/cobra", nil)
+	req.Header.Set(VersionHeader, "2")
+	rec := httptest.NewRecorder()
+	(&Handler{Lookup: fakeLookup{}}).ServeHTTP(rec, req)
+	var env struct {
+		APIVersion int            `json:"api_version"`
+		Command    string         `json:"command"`
+		Data       LookupResponse `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &env); err != nil {
+		t.Fatal(err)
+	}
+	if env.APIVersion != 2 || env.Command != "lookup" || env.Data.Category != "cli_framework" {
+		t.Errorf("version 2 response = %+v", env)
+	}
+	if got := rec.Header().Get(VersionHeader); got != "2" {
+		t.Errorf("%s = %q", VersionHeader, got)
+	}
+
+	// The handler default applies without the header; errors are never wrapped.
+	rec = httptest.NewRecorder()
+	(&Handler{Lookup: fakeLookup{}, APIVersion: 2}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lookup", nil))
+	if got := rec.Body.String(); got != "{\n  \"error\": \"missing url parameter\"\n}\n" || rec.Header().Get(VersionHeader) != "2" {
+		t.Errorf("version 2 error = %s", got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/lookup?url=x", nil)
+	req.Header.Set(VersionHeader, "9")
+	rec = httptest.NewRecorder()
+	(&Handler{Lookup: fakeLookup{}}).ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), `Rinku-Api-Version: unsupported API version \"9\"`) {
+		t.Errorf("unsupported version = %d %s", rec.Code, rec.Body.String())
+	}
+}
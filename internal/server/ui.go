@@ -0,0 +1,40 @@
+package server
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"strings"
+)
+
+// uiFiles is the single-page web UI served at /: paste a go.mod to scan it and download
+// its Cargo.toml, or browse the mappings by category. It only calls the JSON API.
+//
+//go:embed ui
+var uiFiles embed.FS
+
+var (
+	uiFS = mustSub(uiFiles, "ui")
+	ui   = http.FileServerFS(uiFS)
+)
+
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+// isAsset reports whether path is / or a file of the UI.
+func isAsset(path string) bool {
+	if path == "/" {
+		return true
+	}
+	name := strings.TrimPrefix(path, "/")
+	if !fs.ValidPath(name) {
+		return false
+	}
+	info, err := fs.Stat(uiFS, name)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,141 @@
+// The web UI of rinku serve. It calls the JSON API of the same server in API version 1,
+// whatever --api-version the server defaults to.
+"use strict";
+
+const apiVersion = { "Rinku-Api-Version": "1" };
+
+async function api(path, options = {}) {
+  const resp = await fetch(path, { ...options, headers: { ...apiVersion, ...options.headers } });
+  const body = await resp.json();
+  if (!resp.ok) {
+    throw new Error(body.error || resp.statusText);
+  }
+  return body;
+}
+
+function el(tag, text) {
+  const e = document.createElement(tag);
+  if (text !== undefined) {
+    e.textContent = text;
+  }
+  return e;
+}
+
+function showError(id, err) {
+  const p = document.getElementById(id);
+  p.textContent = err ? err.message : "";
+  p.hidden = !err;
+}
+
+// Tabs
+
+document.querySelectorAll("nav button").forEach((button) => {
+  button.addEventListener("click", () => {
+    document.querySelectorAll("nav button").forEach((b) => b.classList.toggle("active", b === button));
+    document.querySelectorAll("main section").forEach((s) => { s.hidden = s.id !== button.dataset.tab; });
+    if (button.dataset.tab === "categories" && !categories) {
+      loadCategories();
+    }
+  });
+});
+
+// Scan and Cargo.toml
+
+const gomod = document.getElementById("gomod");
+const download = document.getElementById("download");
+
+function query(unsafeBox) {
+  return document.getElementById(unsafeBox).checked ? "?unsafe=true" : "";
+}
+
+document.getElementById("scan-form").addEventListener("submit", async (event) => {
+  event.preventDefault();
+  showError("scan-error", null);
+  document.getElementById("scan-result").hidden = true;
+  download.disabled = true;
+  try {
+    const scan = await api("/scan" + query("scan-unsafe"), { method: "POST", body: gomod.value });
+    renderScan(scan);
+    download.disabled = false;
+  } catch (err) {
+    showError("scan-error", err);
+  }
+});
+
+function renderScan(scan) {
+  const percent = scan.direct ? Math.round((100 * scan.mapped) / scan.direct) : 0;
+  document.getElementById("scan-summary").textContent =
+    `${scan.module}: ${scan.mapped} of ${scan.direct} direct dependencies mapped (${percent}%)`;
+  const rows = document.getElementById("scan-rows");
+  rows.replaceChildren();
+  for (const dep of scan.dependencies) {
+    const tr = el("tr");
+    tr.className = dep.status;
+    tr.append(
+      el("td", dep.dependency),
+      el("td", dep.category || ""),
+      el("td", dep.confidence ? dep.confidence.toFixed(2) : ""),
+      el("td", dep.status === "mapped" ? dep.crates.join(", ") : "no mapping"),
+    );
+    rows.append(tr);
+  }
+  document.getElementById("scan-result").hidden = false;
+}
+
+download.addEventListener("click", async () => {
+  showError("scan-error", null);
+  try {
+    const convert = await api("/convert" + query("scan-unsafe"), { method: "POST", body: gomod.value });
+    const a = el("a");
+    a.href = URL.createObjectURL(new Blob([convert.cargo_toml], { type: "application/toml" }));
+    a.download = "Cargo.toml";
+    a.click();
+    URL.revokeObjectURL(a.href);
+  } catch (err) {
+    showError("scan-error", err);
+  }
+});
+
+// Categories
+
+let categories = null;
+
+async function loadCategories() {
+  showError("categories-error", null);
+  try {
+    categories = (await api("/categories" + query("categories-unsafe"))).categories;
+    renderCategories();
+  } catch (err) {
+    showError("categories-error", err);
+  }
+}
+
+function renderCategories() {
+  const filter = document.getElementById("category-filter").value.trim().toLowerCase();
+  const list = document.getElementById("category-list");
+  list.replaceChildren();
+  for (const category of categories) {
+    const libraries = category.libraries.filter((lib) =>
+      !filter || category.name.includes(filter) || lib.source.toLowerCase().includes(filter) ||
+      lib.targets.some((t) => (t.crate || t.url).toLowerCase().includes(filter)));
+    if (libraries.length === 0) {
+      continue;
+    }
+    const details = el("details");
+    details.open = filter !== "";
+    details.append(el("summary", `${category.name} (${libraries.length})`));
+    const ul = el("ul");
+    for (const lib of libraries) {
+      ul.append(el("li", `${lib.source} → ${lib.targets.map((t) => t.crate || t.url).join(", ")}`));
+    }
+    details.append(ul);
+    list.append(details);
+  }
+}
+
+document.getElementById("category-filter").addEventListener("input", () => {
+  if (categories) {
+    renderCategories();
+  }
+});
+document.getElementById("categories-unsafe").addEventListener("change", loadCategories);
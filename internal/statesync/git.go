@@ -0,0 +1,112 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// DefaultBranch is the branch the git driver stores snapshots on.
+const DefaultBranch = "rinku-state"
+
+// snapshotFile is the name of the snapshot in the commits of the git driver.
+const snapshotFile = "rinku-state.tar.gz"
+
+// GitDriver commits snapshots to a branch of a git remote with plumbing commands, so
+// neither the working tree nor the checked-out branch is touched. Each push is a new
+// commit on top of the remote branch, keeping the history of the state.
+type GitDriver struct {
+	Dir    string // repository to run git in
+	Remote string // e.g. origin
+	Branch string
+}
+
+// NewGitDriver configures a driver for "<remote>[#branch]".
+func NewGitDriver(dir, spec string) (*GitDriver, error) {
+	remote, branch, _ := strings.Cut(spec, "#")
+	if remote == "" {
+		return nil, fmt.Errorf("invalid git remote %q (want git:<remote>[#branch])", spec)
+	}
+	if branch == "" {
+		branch = DefaultBranch
+	}
+	return &GitDriver{Dir: dir, Remote: remote, Branch: branch}, nil
+}
+
+// Push commits the snapshot and pushes it to the remote branch.
+func (d *GitDriver) Push(ctx context.Context, snapshot []byte, meta Meta) error {
+	blob, err := d.git(ctx, snapshot, "hash-object", "-w", "--stdin")
+	if err != nil {
+		return err
+	}
+	tree, err := d.git(ctx, []byte("100644 blob "+blob+"\t"+snapshotFile+"\n"), "mktree")
+	if err != nil {
+		return err
+	}
+
+	args := []string{"commit-tree", tree, "-m", commitMessage(meta)}
+	if parent, err := d.fetch(ctx); err == nil {
+		args = append(args, "-p", parent)
+	}
+	// State commits need an identity even on machines without a git user, such as
+	// agent sandboxes.
+	if email, _ := d.git(ctx, nil, "config", "--get", "user.email"); email == "" && os.Getenv("GIT_COMMITTER_EMAIL") == "" {
+		args = append([]string{"-c", "user.name=rinku", "-c", "user.email=rinku@localhost"}, args...)
+	}
+	commit, err := d.git(ctx, nil, args...)
+	if err != nil {
+		return err
+	}
+	if _, err := d.git(ctx, nil, "push", "-q", d.Remote, commit+":refs/heads/"+d.Branch); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Pull fetches the remote branch and reads its snapshot.
+func (d *GitDriver) Pull(ctx context.Context) ([]byte, error) {
+	commit, err := d.fetch(ctx)
+	if err != nil {
+		return nil, ErrNoSnapshot
+	}
+	cmd := exec.CommandContext(ctx, "git", "-C", d.Dir, "cat-file", "blob", commit+":"+snapshotFile) //#nosec G204 -- fixed git subcommand
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s from %s: %w", snapshotFile, d.Branch, err)
+	}
+	return out, nil
+}
+
+// fetch fetches the remote branch and returns its commit.
+func (d *GitDriver) fetch(ctx context.Context) (string, error) {
+	if _, err := d.git(ctx, nil, "fetch", "-q", d.Remote, "refs/heads/"+d.Branch); err != nil {
+		return "", err
+	}
+	return d.git(ctx, nil, "rev-parse", "FETCH_HEAD")
+}
+
+// git runs a git command in the repository and returns its trimmed output.
+func (d *GitDriver) git(ctx context.Context, stdin []byte, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", d.Dir}, args...)...) //#nosec G204 -- fixed git subcommands
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func commitMessage(meta Meta) string {
+	msg := fmt.Sprintf("rinku: %s %d/%d steps", meta.Project, meta.Completed, meta.Total)
+	if meta.Actor != "" {
+		msg += " (" + meta.Actor + ")"
+	}
+	return msg
+}
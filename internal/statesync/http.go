@@ -0,0 +1,86 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// TokenEnv holds a bearer token sent to HTTP sync endpoints.
+const TokenEnv = "RINKU_SYNC_TOKEN"
+
+// HTTPDriver PUTs snapshots to a URL and GETs them back. The X-Rinku-* headers let
+// a dashboard endpoint aggregate projects without unpacking the archive.
+type HTTPDriver struct {
+	URL    string
+	Token  string
+	Client *http.Client // http.DefaultClient if nil
+}
+
+func (d *HTTPDriver) client() *http.Client {
+	if d.Client != nil {
+		return d.Client
+	}
+	return http.DefaultClient
+}
+
+// Push uploads a snapshot.
+func (d *HTTPDriver) Push(ctx context.Context, snapshot []byte, meta Meta) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, d.URL, bytes.NewReader(snapshot))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	req.Header.Set("X-Rinku-Project", meta.Project)
+	req.Header.Set("X-Rinku-Completed", strconv.Itoa(meta.Completed))
+	req.Header.Set("X-Rinku-Total", strconv.Itoa(meta.Total))
+	if meta.Actor != "" {
+		req.Header.Set("X-Rinku-Actor", meta.Actor)
+	}
+	d.authorize(req)
+
+	resp, err := d.client().Do(req)
+	if err != nil {
+		return fmt.Errorf("pushing to %s: %w", d.URL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("pushing to %s: %s", d.URL, resp.Status)
+	}
+	return nil
+}
+
+// Pull downloads the last snapshot.
+func (d *HTTPDriver) Pull(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	d.authorize(req)
+
+	resp, err := d.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("pulling from %s: %w", d.URL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNoSnapshot
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("pulling from %s: %s", d.URL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSnapshotSize))
+	if err != nil {
+		return nil, fmt.Errorf("pulling from %s: %w", d.URL, err)
+	}
+	return data, nil
+}
+
+func (d *HTTPDriver) authorize(req *http.Request) {
+	if d.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+d.Token)
+	}
+}
@@ -0,0 +1,234 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3Driver stores snapshots as a single object, signing requests with AWS Signature
+// Version 4. Credentials and region come from the standard AWS environment variables;
+// AWS_ENDPOINT_URL selects an S3-compatible service such as MinIO.
+type S3Driver struct {
+	Endpoint     string // e.g. https://s3.eu-central-1.amazonaws.com; path-style requests
+	Region       string
+	Bucket       string
+	Key          string
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+	Client       *http.Client     // http.DefaultClient if nil
+	Now          func() time.Time // time.Now if nil
+}
+
+// NewS3Driver configures a driver for an s3://bucket/key URL from the environment.
+func NewS3Driver(remote string) (*S3Driver, error) {
+	u, err := url.Parse(remote)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", remote, err)
+	}
+	key := strings.TrimPrefix(u.Path, "/")
+	if u.Host == "" || key == "" {
+		return nil, fmt.Errorf("invalid S3 remote %q (want s3://bucket/key)", remote)
+	}
+	d := &S3Driver{
+		Endpoint:     firstEnv("AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL"),
+		Region:       firstEnv("AWS_REGION", "AWS_DEFAULT_REGION"),
+		Bucket:       u.Host,
+		Key:          key,
+		AccessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if d.Region == "" {
+		d.Region = "us-east-1"
+	}
+	if d.Endpoint == "" {
+		d.Endpoint = "https://s3." + d.Region + ".amazonaws.com"
+	}
+	if d.AccessKey == "" || d.SecretKey == "" {
+		return nil, fmt.Errorf("S3 sync needs AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	return d, nil
+}
+
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Push uploads a snapshot, storing meta as object metadata.
+func (d *S3Driver) Push(ctx context.Context, snapshot []byte, meta Meta) error {
+	headers := map[string]string{
+		"content-type":          "application/gzip",
+		"x-amz-meta-project":    meta.Project,
+		"x-amz-meta-completed":  strconv.Itoa(meta.Completed),
+		"x-amz-meta-total":      strconv.Itoa(meta.Total),
+		"x-amz-meta-rinku-sync": "1",
+	}
+	if meta.Actor != "" {
+		headers["x-amz-meta-actor"] = url.QueryEscape(meta.Actor)
+	}
+	resp, err := d.do(ctx, http.MethodPut, snapshot, headers)
+	if err != nil {
+		return fmt.Errorf("pushing to s3://%s/%s: %w", d.Bucket, d.Key, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("pushing to s3://%s/%s: %s", d.Bucket, d.Key, resp.Status)
+	}
+	return nil
+}
+
+// Pull downloads the snapshot object.
+func (d *S3Driver) Pull(ctx context.Context) ([]byte, error) {
+	resp, err := d.do(ctx, http.MethodGet, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("pulling from s3://%s/%s: %w", d.Bucket, d.Key, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNoSnapshot
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("pulling from s3://%s/%s: %s", d.Bucket, d.Key, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSnapshotSize))
+	if err != nil {
+		return nil, fmt.Errorf("pulling from s3://%s/%s: %w", d.Bucket, d.Key, err)
+	}
+	return data, nil
+}
+
+// do sends a signed path-style request for the object.
+func (d *S3Driver) do(ctx context.Context, method string, body []byte, headers map[string]string) (*http.Response, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(d.Endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("parsing endpoint: %w", err)
+	}
+	canonicalPath := endpoint.EscapedPath() + "/" + awsEscape(d.Bucket) + "/" + awsEscapePath(d.Key)
+	target := endpoint.Scheme + "://" + endpoint.Host + canonicalPath
+
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.URL.RawPath = canonicalPath
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	d.sign(req, body)
+
+	client := d.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// sign adds the SigV4 Authorization header, signing the host, x-amz-* and
+// content-type headers.
+func (d *S3Driver) sign(req *http.Request, body []byte) {
+	now := time.Now
+	if d.Now != nil {
+		now = d.Now
+	}
+	t := now().UTC()
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+	if d.SessionToken != "" {
+		req.Header.Set("x-amz-security-token", d.SessionToken)
+	}
+
+	signed := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		lk := strings.ToLower(k)
+		if strings.HasPrefix(lk, "x-amz-") || lk == "content-type" {
+			signed[lk] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+	names := make([]string, 0, len(signed))
+	for k := range signed {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + signed[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"", // no query
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + d.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+d.SecretKey), date)
+	key = hmacSHA256(key, d.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		d.AccessKey, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// awsEscapePath escapes each segment of an object key.
+func awsEscapePath(key string) string {
+	parts := strings.Split(key, "/")
+	for i, p := range parts {
+		parts[i] = awsEscape(p)
+	}
+	return strings.Join(parts, "/")
+}
+
+// awsEscape percent-encodes everything but the unreserved characters, as SigV4 requires.
+func awsEscape(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			sb.WriteByte(c)
+		} else {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,255 @@
+// Package statesync pushes the .rinku state of a project to a remote backend and pulls
+// it back, so progress, requirements and artifacts can be shared between machines and
+// aggregated by dashboards.
+package statesync
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/natefinch/atomic"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/progress"
+)
+
+// ConfigFile is the file below .rinku holding the sync remote. It stays local and is
+// not part of a snapshot.
+const ConfigFile = "sync.json"
+
+// maxSnapshotSize bounds the decompressed size of a pulled snapshot.
+const maxSnapshotSize = 256 << 20
+
+// Config selects the remote backend of a project.
+type Config struct {
+	// Remote is an http(s):// URL, an s3://bucket/key URL or git:<remote>[#branch].
+	Remote string `json:"remote"`
+}
+
+// Meta describes a pushed snapshot, for backends that can store it next to the data.
+type Meta struct {
+	Project   string // Go module path or project directory name
+	Completed int
+	Total     int
+	Actor     string // see progress.Actor
+}
+
+// Driver stores and retrieves snapshots.
+type Driver interface {
+	Push(ctx context.Context, snapshot []byte, meta Meta) error
+	// Pull returns the last pushed snapshot, or ErrNoSnapshot.
+	Pull(ctx context.Context) ([]byte, error)
+}
+
+// ErrNoSnapshot is returned by Pull when nothing has been pushed yet.
+var ErrNoSnapshot = errors.New("no snapshot on remote")
+
+// Open returns the driver for remote. projectDir is used by the git driver.
+func Open(remote, projectDir string) (Driver, error) {
+	switch {
+	case strings.HasPrefix(remote, "http://"), strings.HasPrefix(remote, "https://"):
+		return &HTTPDriver{URL: remote, Token: os.Getenv(TokenEnv)}, nil
+	case strings.HasPrefix(remote, "s3://"):
+		return NewS3Driver(remote)
+	case strings.HasPrefix(remote, "git:"):
+		return NewGitDriver(projectDir, strings.TrimPrefix(remote, "git:"))
+	default:
+		return nil, fmt.Errorf("unsupported sync remote %q (use https://..., s3://bucket/key or git:<remote>[#branch])", remote)
+	}
+}
+
+// configPath returns the path of the sync config of a project.
+func configPath(projectDir string) string {
+	return filepath.Join(projectDir, progress.ProgressDir, ConfigFile)
+}
+
+// LoadConfig reads the sync config. Returns nil, nil if sync is not configured.
+func LoadConfig(projectDir string) (*Config, error) {
+	data, err := os.ReadFile(configPath(projectDir)) //#nosec G304 -- projectDir from os.Getwd()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading sync config: %w", err)
+	}
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parsing sync config: %w", err)
+	}
+	return &c, nil
+}
+
+// SaveConfig writes the sync config atomically.
+func SaveConfig(projectDir string, c *Config) error {
+	if err := os.MkdirAll(filepath.Join(projectDir, progress.ProgressDir), 0750); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling sync config: %w", err)
+	}
+	return atomic.WriteFile(configPath(projectDir), bytes.NewReader(append(data, '\n')))
+}
+
+// Snapshot packs the .rinku directory, except the sync config, into a gzipped tar.
+// Entries are sorted so unchanged state produces the same archive.
+func Snapshot(projectDir string) ([]byte, error) {
+	root := filepath.Join(projectDir, progress.ProgressDir)
+	var files []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			rel, err := filepath.Rel(root, p)
+			if err != nil {
+				return err
+			}
+			if rel != ConfigFile {
+				files = append(files, filepath.ToSlash(rel))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", progress.ProgressDir, err)
+	}
+	sort.Strings(files)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range files {
+		full := filepath.Join(root, filepath.FromSlash(name))
+		data, err := os.ReadFile(full) //#nosec G304 -- path below .rinku
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		info, err := os.Stat(full)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: info.ModTime().UTC(), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, fmt.Errorf("writing snapshot: %w", err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return nil, fmt.Errorf("writing snapshot: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("writing snapshot: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Restore unpacks a snapshot into the .rinku directory, replacing the files it contains.
+// Local files missing from the snapshot are kept. It returns the number of files written.
+func Restore(projectDir string, snapshot []byte) (int, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(snapshot))
+	if err != nil {
+		return 0, fmt.Errorf("reading snapshot: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	root := filepath.Join(projectDir, progress.ProgressDir)
+	tr := tar.NewReader(io.LimitReader(gz, maxSnapshotSize))
+	n := 0
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return n, fmt.Errorf("reading snapshot: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if name == ConfigFile || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return n, fmt.Errorf("reading %s: %w", name, err)
+		}
+		target := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+			return n, fmt.Errorf("creating directory: %w", err)
+		}
+		if err := atomic.WriteFile(target, bytes.NewReader(data)); err != nil {
+			return n, fmt.Errorf("writing %s: %w", name, err)
+		}
+		if !hdr.ModTime.IsZero() {
+			_ = os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// Push snapshots the project and pushes it to the configured remote. It returns false
+// if sync is not configured.
+func Push(ctx context.Context, projectDir string) (bool, error) {
+	c, err := LoadConfig(projectDir)
+	if err != nil || c == nil {
+		return false, err
+	}
+	d, err := Open(c.Remote, projectDir)
+	if err != nil {
+		return true, err
+	}
+	snapshot, err := Snapshot(projectDir)
+	if err != nil {
+		return true, err
+	}
+	return true, d.Push(ctx, snapshot, meta(projectDir))
+}
+
+// Pull restores the last snapshot of the configured remote and returns the number of
+// files written.
+func Pull(ctx context.Context, projectDir string) (int, error) {
+	c, err := LoadConfig(projectDir)
+	if err != nil {
+		return 0, err
+	}
+	if c == nil {
+		return 0, errors.New("sync is not configured\nHint: Run 'rinku sync remote <url>' first")
+	}
+	d, err := Open(c.Remote, projectDir)
+	if err != nil {
+		return 0, err
+	}
+	snapshot, err := d.Pull(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return Restore(projectDir, snapshot)
+}
+
+// meta describes the project state being pushed.
+func meta(projectDir string) Meta {
+	m := Meta{Project: filepath.Base(projectDir), Actor: progress.Actor(projectDir)}
+	if result, err := gomod.Parse(filepath.Join(projectDir, "go.mod")); err == nil && result.Module != "" {
+		m.Project = result.Module
+	}
+	if mig, err := progress.Load(projectDir); err == nil && mig != nil {
+		m.Completed, m.Total = mig.Progress()
+	}
+	return m
+}
@@ -0,0 +1,210 @@
+package statesync
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stephan/rinku/internal/progress"
+)
+
+// writeState creates a small .rinku directory with progress and a requirement.
+func writeState(t *testing.T, dir string) {
+	t.Helper()
+	m := progress.New(dir, []string{"1", "2"})
+	if err := m.CompleteStep("1", "done", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	req := filepath.Join(dir, progress.ProgressDir, "progress", "requirements", "app", "cli.json")
+	if err := os.MkdirAll(filepath.Dir(req), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(req, []byte(`{"path":"app/cli"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveConfig(dir, &Config{Remote: "https://example.com/state"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// memoryServer stores one PUT body and serves it on GET.
+type memoryServer struct {
+	mu      sync.Mutex
+	body    []byte
+	headers http.Header
+}
+
+func (s *memoryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		s.body, _ = io.ReadAll(r.Body)
+		s.headers = r.Header.Clone()
+	case http.MethodGet:
+		if s.body == nil {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(s.body)
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	src := t.TempDir()
+	writeState(t, src)
+
+	snapshot, err := Snapshot(src)
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	again, _ := Snapshot(src)
+	if string(snapshot) != string(again) {
+		t.Error("snapshot of unchanged state should be identical")
+	}
+
+	dst := t.TempDir()
+	n, err := Restore(dst, snapshot)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if n != 3 { // progress.json, events.jsonl and the requirement
+		t.Errorf("restored %d files, want 3", n)
+	}
+	m, err := progress.Load(dst)
+	if err != nil || m == nil {
+		t.Fatalf("Load after restore = %v, %v", m, err)
+	}
+	if m.Steps["1"].CompletedBy != "alice" {
+		t.Errorf("CompletedBy = %q, want alice", m.Steps["1"].CompletedBy)
+	}
+	if c, _ := LoadConfig(dst); c != nil {
+		t.Error("sync config must not be part of the snapshot")
+	}
+}
+
+func TestHTTPDriver(t *testing.T) {
+	srv := &memoryServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	d := &HTTPDriver{URL: ts.URL + "/state", Token: "secret"}
+
+	if _, err := d.Pull(context.Background()); !errors.Is(err, ErrNoSnapshot) {
+		t.Fatalf("Pull before push = %v, want ErrNoSnapshot", err)
+	}
+	if err := d.Push(context.Background(), []byte("data"), Meta{Project: "example.com/app", Completed: 3, Total: 26}); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if got := srv.headers.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization = %q", got)
+	}
+	if got := srv.headers.Get("X-Rinku-Project") + " " + srv.headers.Get("X-Rinku-Completed"); got != "example.com/app 3" {
+		t.Errorf("meta headers = %q", got)
+	}
+	data, err := d.Pull(context.Background())
+	if err != nil || string(data) != "data" {
+		t.Errorf("Pull = %q, %v", data, err)
+	}
+}
+
+func TestS3Driver_Signs(t *testing.T) {
+	srv := &memoryServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	t.Setenv("AWS_ENDPOINT_URL", ts.URL)
+	t.Setenv("AWS_REGION", "eu-central-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	d, err := NewS3Driver("s3://bucket/teams/app state.tar.gz")
+	if err != nil {
+		t.Fatalf("NewS3Driver failed: %v", err)
+	}
+	d.Now = func() time.Time { return time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC) }
+
+	if err := d.Push(context.Background(), []byte("data"), Meta{Project: "app"}); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	auth := srv.headers.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20250301/eu-central-1/s3/aws4_request, SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date;") {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if got := srv.headers.Get("X-Amz-Content-Sha256"); got != sha256Hex([]byte("data")) {
+		t.Errorf("x-amz-content-sha256 = %q", got)
+	}
+	data, err := d.Pull(context.Background())
+	if err != nil || string(data) != "data" {
+		t.Errorf("Pull = %q, %v", data, err)
+	}
+
+	if _, err := NewS3Driver("s3://bucket"); err == nil {
+		t.Error("expected error for missing key")
+	}
+}
+
+func TestAWSEscape(t *testing.T) {
+	if got := awsEscapePath("teams/app state+1.tar.gz"); got != "teams/app%20state%2B1.tar.gz" {
+		t.Errorf("awsEscapePath = %q", got)
+	}
+}
+
+func TestGitDriver(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	remote := t.TempDir()
+	work := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", "--bare", remote},
+		{"-C", work, "init", "-q"},
+		{"-C", work, "remote", "add", "origin", remote},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	d, err := NewGitDriver(work, "origin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Pull(context.Background()); !errors.Is(err, ErrNoSnapshot) {
+		t.Fatalf("Pull before push = %v, want ErrNoSnapshot", err)
+	}
+	for _, content := range []string{"first", "second"} {
+		if err := d.Push(context.Background(), []byte(content), Meta{Project: "app", Completed: 1, Total: 2}); err != nil {
+			t.Fatalf("Push failed: %v", err)
+		}
+	}
+	data, err := d.Pull(context.Background())
+	if err != nil || string(data) != "second" {
+		t.Errorf("Pull = %q, %v", data, err)
+	}
+	out, err := exec.Command("git", "-C", remote, "rev-list", "--count", DefaultBranch).Output()
+	if err != nil || strings.TrimSpace(string(out)) != "2" {
+		t.Errorf("branch history = %q, %v, want 2 commits", out, err)
+	}
+}
+
+func TestOpen_Unsupported(t *testing.T) {
+	if _, err := Open("ftp://example.com", t.TempDir()); err == nil {
+		t.Error("expected error for unsupported remote")
+	}
+}
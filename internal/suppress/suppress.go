@@ -0,0 +1,201 @@
+// Package suppress reads .rinku/suppressions.yaml, the gaps a team has accepted: a
+// dependency that will not get a Rust equivalent, a requirement category that does not
+// apply. scan, verify and the step gates keep reporting a suppressed finding, with its
+// reason, but no longer treat it as a problem until the entry expires.
+package suppress
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/stephan/rinku/internal/pattern"
+	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/internal/reason"
+	"github.com/stephan/rinku/render"
+	"gopkg.in/yaml.v3"
+)
+
+const File = "suppressions.yaml"
+
+// RuleUnmapped is the rule of a dependency entry.
+const RuleUnmapped = "unmapped-dependency"
+
+// RuleExpired is the rule of the finding reported for an expired entry that still
+// matches a finding.
+const RuleExpired = "expired-suppression"
+
+const dateLayout = "2006-01-02"
+
+// Entry accepts the findings of a rule whose subject matches a pattern.
+type Entry struct {
+	Dependency string      `yaml:"dependency"` // Go module path; short for rule unmapped-dependency
+	Rule       string      `yaml:"rule"`       // e.g. missing-requirements or pending-requirement
+	Match      string      `yaml:"match"`      // pattern for the subject, e.g. db or api/**/admin; default all
+	Code       reason.Code `yaml:"code"`       // required: not-applicable, deferred, out-of-scope or blocked
+	Reason     string      `yaml:"reason"`     // required, shown next to every suppressed finding
+	Expires    string      `yaml:"expires"`    // YYYY-MM-DD, the last day the entry applies; default never
+}
+
+// rule returns the rule the entry applies to.
+func (e *Entry) rule() string {
+	if e.Dependency != "" {
+		return RuleUnmapped
+	}
+	return e.Rule
+}
+
+// subject returns the pattern for the subject of matching findings.
+func (e *Entry) subject() string {
+	if e.Dependency != "" {
+		return e.Dependency
+	}
+	return e.Match
+}
+
+// Expired reports whether the entry no longer applies on the day of now.
+func (e *Entry) Expired(now time.Time) bool {
+	if e.Expires == "" {
+		return false
+	}
+	return now.Format(dateLayout) > e.Expires // validated by Load
+}
+
+// Matches reports whether the entry covers findings of rule about subject, ignoring
+// expiry.
+func (e *Entry) Matches(rule, subject string) bool {
+	if e.rule() != rule {
+		return false
+	}
+	if e.subject() == "" {
+		return true
+	}
+	return subject != "" && pattern.Match(e.subject(), subject)
+}
+
+// Suppressions are the entries of a suppressions file.
+type Suppressions struct {
+	Entries []Entry `yaml:"suppressions"`
+}
+
+// Path returns the path to the suppressions file for a project directory.
+func Path(projectDir string) string {
+	return filepath.Join(projectDir, progress.ProgressDir, File)
+}
+
+// Load reads the suppressions file. Returns nil, nil if no file exists.
+func Load(projectDir string) (*Suppressions, error) {
+	data, err := os.ReadFile(Path(projectDir)) //#nosec G304 -- projectDir from os.Getwd(), not user input
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading suppressions: %w", err)
+	}
+	var s Suppressions
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&s); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing %s: %w", File, err)
+	}
+	for i := range s.Entries {
+		if err := s.Entries[i].validate(); err != nil {
+			return nil, fmt.Errorf("%s: entry %d: %w", File, i+1, err)
+		}
+	}
+	return &s, nil
+}
+
+func (e *Entry) validate() error {
+	switch {
+	case e.Dependency == "" && e.Rule == "":
+		return fmt.Errorf("set dependency or rule")
+	case e.Dependency != "" && (e.Rule != "" || e.Match != ""):
+		return fmt.Errorf("dependency %s: set dependency or rule and match, not both", e.Dependency)
+	case e.Reason == "":
+		return fmt.Errorf("reason is required")
+	}
+	if _, err := reason.Parse(string(e.Code)); err != nil {
+		return fmt.Errorf("code: %w", err)
+	}
+	if e.Expires != "" {
+		if _, err := time.Parse(dateLayout, e.Expires); err != nil {
+			return fmt.Errorf("expires %q is not a YYYY-MM-DD date", e.Expires)
+		}
+	}
+	return nil
+}
+
+// Lookup returns the first entry in effect on the day of now that covers findings of
+// rule about subject. A nil Suppressions suppresses nothing.
+func (s *Suppressions) Lookup(rule, subject string, now time.Time) (*Entry, bool) {
+	if s == nil {
+		return nil, false
+	}
+	for i := range s.Entries {
+		e := &s.Entries[i]
+		if e.Matches(rule, subject) && !e.Expired(now) {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// Counts counts the entries in effect on the day of now by reason code. A nil
+// Suppressions counts none.
+func (s *Suppressions) Counts(now time.Time) reason.Counts {
+	counts := make(reason.Counts)
+	if s == nil {
+		return counts
+	}
+	for i := range s.Entries {
+		if !s.Entries[i].Expired(now) {
+			counts[s.Entries[i].Code]++
+		}
+	}
+	return counts
+}
+
+// Apply sets the Suppression of every finding covered by an entry in effect and
+// returns the findings with a warning appended for each expired entry that would
+// still cover one: its gap is reported again and the entry needs a decision.
+func (s *Suppressions) Apply(findings []render.Finding, now time.Time) []render.Finding {
+	if s == nil {
+		return findings
+	}
+	expired := make(map[int]bool)
+	for i := range findings {
+		f := &findings[i]
+		if e, ok := s.Lookup(f.Rule, f.Subject, now); ok {
+			f.Suppression = e.Reason
+			continue
+		}
+		for j := range s.Entries {
+			if s.Entries[j].Matches(f.Rule, f.Subject) {
+				expired[j] = true
+			}
+		}
+	}
+	for j := range s.Entries {
+		if !expired[j] {
+			continue
+		}
+		e := &s.Entries[j]
+		what := e.rule()
+		if e.subject() != "" {
+			what += " " + e.subject()
+		}
+		findings = append(findings, render.Finding{
+			Rule:    RuleExpired,
+			Level:   render.LevelWarning,
+			Message: fmt.Sprintf("suppression of %s expired on %s (%s)", what, e.Expires, e.Reason),
+			File:    filepath.Join(progress.ProgressDir, File),
+			Subject: what,
+		})
+	}
+	return findings
+}
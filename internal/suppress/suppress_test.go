@@ -0,0 +1,143 @@
+package suppress
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stephan/rinku/internal/progress"
+	"github.com/stephan/rinku/render"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, progress.ProgressDir), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(Path(dir), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoad_NoFile(t *testing.T) {
+	s, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if s != nil {
+		t.Errorf("Load() = %+v, want nil", s)
+	}
+	if _, ok := s.Lookup(RuleUnmapped, "example.com/x", time.Now()); ok {
+		t.Error("nil Suppressions suppressed a finding")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := writeFile(t, `suppressions:
+  - dependency: example.com/soap
+    code: out-of-scope
+    reason: replaced by REST
+    expires: 2026-06-30
+  - rule: missing-requirements
+    match: web
+    code: not-applicable
+    reason: covered by the OpenAPI spec
+`)
+	s, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(s.Entries) != 2 || s.Entries[0].Expires != "2026-06-30" || s.Entries[1].Rule != "missing-requirements" {
+		t.Errorf("entries = %+v", s.Entries)
+	}
+	if got := s.Counts(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)).String(); got != "not-applicable 1" {
+		t.Errorf("Counts after expiry = %q", got)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"suppressions:\n  - dependency: example.com/x\n", "entry 1: reason is required"},
+		{"suppressions:\n  - reason: r\n", "set dependency or rule"},
+		{"suppressions:\n  - dependency: example.com/x\n    rule: cgo\n    reason: r\n", "not both"},
+		{"suppressions:\n  - dependency: example.com/x\n    reason: r\n", "code: missing reason code"},
+		{"suppressions:\n  - dependency: example.com/x\n    code: later\n    reason: r\n", `code: unknown reason code "later"`},
+		{"suppressions:\n  - dependency: example.com/x\n    code: deferred\n    reason: r\n    expires: 30.06.2026\n", "not a YYYY-MM-DD date"},
+		{"suppressions:\n  - dependency: example.com/x\n    reason: r\n    until: 2026-06-30\n", "field until not found"},
+	}
+	for _, tt := range tests {
+		_, err := Load(writeFile(t, tt.content))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Load(%q) error = %v, want %q", tt.content, err, tt.want)
+		}
+	}
+}
+
+func TestEntry_Expired(t *testing.T) {
+	e := Entry{Expires: "2026-06-30"}
+	for date, want := range map[string]bool{"2026-06-29": false, "2026-06-30": false, "2026-07-01": true} {
+		now, _ := time.Parse(dateLayout, date)
+		if got := e.Expired(now.Add(23 * time.Hour)); got != want {
+			t.Errorf("Expired(%s) = %v, want %v", date, got, want)
+		}
+	}
+	if (&Entry{}).Expired(time.Now()) {
+		t.Error("entry without expiry expired")
+	}
+}
+
+func TestEntry_Matches(t *testing.T) {
+	tests := []struct {
+		entry         Entry
+		rule, subject string
+		want          bool
+	}{
+		{Entry{Dependency: "example.com/soap"}, RuleUnmapped, "example.com/soap", true},
+		{Entry{Dependency: "example.com/soap"}, RuleUnmapped, "example.com/soapy", false},
+		{Entry{Dependency: "example.com/soap"}, "missing-requirements", "example.com/soap", false},
+		{Entry{Dependency: "example.com/private/*"}, RuleUnmapped, "example.com/private/ldap", true},
+		{Entry{Rule: "pending-requirement", Match: "app/cli/**/--verbose"}, "pending-requirement", "app/cli/flags/--verbose", true},
+		{Entry{Rule: "pending-requirement", Match: "app/api"}, "pending-requirement", "app/cli/flags/--verbose", false},
+		{Entry{Rule: "pending-requirement"}, "pending-requirement", "", true},
+		{Entry{Rule: "missing-requirements", Match: "web"}, "missing-requirements", "", false},
+	}
+	for _, tt := range tests {
+		if got := tt.entry.Matches(tt.rule, tt.subject); got != tt.want {
+			t.Errorf("%+v.Matches(%s, %s) = %v, want %v", tt.entry, tt.rule, tt.subject, got, tt.want)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	s := &Suppressions{Entries: []Entry{
+		{Dependency: "example.com/soap", Reason: "replaced by REST", Expires: "2026-12-31"},
+		{Dependency: "example.com/ldap", Reason: "waiting for identity", Expires: "2026-01-31"},
+		{Dependency: "example.com/unused", Reason: "stale", Expires: "2026-01-31"},
+	}}
+	findings := []render.Finding{
+		{Rule: RuleUnmapped, Message: "no Rust equivalent found for example.com/soap", Subject: "example.com/soap"},
+		{Rule: RuleUnmapped, Message: "no Rust equivalent found for example.com/ldap", Subject: "example.com/ldap"},
+		{Rule: RuleUnmapped, Message: "no Rust equivalent found for example.com/grpc", Subject: "example.com/grpc"},
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	got := s.Apply(findings, now)
+
+	if len(got) != 4 {
+		t.Fatalf("Apply returned %d findings, want 4: %+v", len(got), got)
+	}
+	if got[0].Suppression != "replaced by REST" || got[1].Suppressed() || got[2].Suppressed() {
+		t.Errorf("suppressions = %q, %q, %q", got[0].Suppression, got[1].Suppression, got[2].Suppression)
+	}
+	expired := got[3]
+	if expired.Rule != RuleExpired || expired.Level != render.LevelWarning ||
+		expired.Message != "suppression of unmapped-dependency example.com/ldap expired on 2026-01-31 (waiting for identity)" {
+		t.Errorf("expired finding = %+v", expired)
+	}
+}
@@ -0,0 +1,99 @@
+package target
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/stephan/rinku/internal/manifest"
+	"github.com/stephan/rinku/internal/pypi"
+)
+
+func init() {
+	Register("python", pythonBackend{})
+}
+
+// pythonBackend maps to PyPI projects, written as pyproject.toml or requirements.txt.
+type pythonBackend struct{}
+
+func (pythonBackend) Lang() string         { return manifest.Python }
+func (pythonBackend) ManifestFile() string { return "pyproject.toml" }
+
+// ManifestFiles implements ManifestFormats.
+func (pythonBackend) ManifestFiles() []string { return []string{"pyproject.toml", "requirements.txt"} }
+
+// PackageName returns the project name the database lists for the library, preferring
+// the one named like the repository, or else the repository name.
+func (pythonBackend) PackageName(idx Index, libURL string) string {
+	return packageName(idx, manifest.Python, libURL)
+}
+
+func (b pythonBackend) WriteManifest(w io.Writer, module string, m *Mapping) error {
+	return b.WriteManifestFile(w, "pyproject.toml", module, m)
+}
+
+// WriteManifestFile implements ManifestFormats. The project table of pyproject.toml is
+// the PEP 621 minimum; build-system is left to the chosen build backend.
+func (pythonBackend) WriteManifestFile(w io.Writer, file, module string, m *Mapping) error {
+	fmt.Fprintln(w, "# Generated by rinku - https://github.com/marvai-dev/rinku")
+	fmt.Fprintf(w, "# Original Go module: %s\n", module)
+
+	switch file {
+	case "pyproject.toml":
+		fmt.Fprintln(w, "\n[project]")
+		fmt.Fprintf(w, "name = %q\n", manifest.NormalizeName(manifest.Python, repoName(module)))
+		fmt.Fprintln(w, `version = "0.1.0"`)
+		fmt.Fprintln(w, `requires-python = ">=3.9"`)
+		if len(m.Mapped) == 0 {
+			fmt.Fprintln(w, "dependencies = []")
+			break
+		}
+		fmt.Fprintln(w, "dependencies = [")
+		for _, mapped := range m.Mapped {
+			fmt.Fprintf(w, "    %q,  # from %s -> %s\n", requirement(&mapped), mapped.Dep.Path, mapped.URLs[0])
+		}
+		fmt.Fprintln(w, "]")
+	case "requirements.txt":
+		if len(m.Mapped) > 0 {
+			fmt.Fprintln(w)
+		}
+		for _, mapped := range m.Mapped {
+			fmt.Fprintf(w, "%s  # from %s -> %s\n", requirement(&mapped), mapped.Dep.Path, mapped.URLs[0])
+		}
+	default:
+		return fmt.Errorf("unknown Python manifest %s (pyproject.toml or requirements.txt)", file)
+	}
+
+	if len(m.Unmapped) > 0 {
+		fmt.Fprintln(w, "\n# TODO: Find equivalents for these Go dependencies:")
+		for _, dep := range m.Unmapped {
+			fmt.Fprintf(w, "# TODO: find equivalent for %s\n", dep.Path)
+		}
+	}
+	return nil
+}
+
+// requirement returns the PEP 508 requirement of the first package of mapped.
+func requirement(mapped *Mapped) string {
+	if v := mapped.Version(0); v != "*" {
+		return mapped.Packages[0] + "==" + v
+	}
+	return mapped.Packages[0]
+}
+
+func (pythonBackend) Registry() Registry { return pypi.New() }
+
+// packageName returns the package name listed for libURL that equals the repository
+// name, the first listed name, or the repository name if the database lists none.
+func packageName(idx Index, lang, libURL string) string {
+	repo := repoName(libURL)
+	names := idx.PackageNames(lang, libURL)
+	for _, name := range names {
+		if name == manifest.NormalizeName(lang, repo) {
+			return name
+		}
+	}
+	if len(names) > 0 {
+		return names[0]
+	}
+	return manifest.NormalizeName(lang, repo)
+}
@@ -0,0 +1,57 @@
+package target
+
+import (
+	"context"
+	"io"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/cratesio"
+)
+
+func init() {
+	Register("rust", rustBackend{})
+}
+
+// rustBackend maps to crates, written as Cargo.toml by internal/cargo.
+type rustBackend struct{}
+
+func (rustBackend) Lang() string         { return "rust" }
+func (rustBackend) ManifestFile() string { return "Cargo.toml" }
+
+// PackageName returns the crate name the database configures, or one derived from the URL.
+func (rustBackend) PackageName(idx Index, libURL string) string {
+	if name := idx.CrateName(libURL); name != "" {
+		return name
+	}
+	return cargo.ExtractCrateName(libURL)
+}
+
+func (rustBackend) WriteManifest(w io.Writer, module string, m *Mapping) error {
+	result := &cargo.GenerateResult{}
+	for _, mapped := range m.Mapped {
+		result.Mapped = append(result.Mapped, cargo.MappedDependency{
+			GoDep:        mapped.Dep,
+			RustTargets:  mapped.URLs,
+			CrateNames:   mapped.Packages,
+			Versions:     mapped.Versions,
+			RequiredDeps: mapped.RequiredDeps,
+		})
+	}
+	for _, dep := range m.Unmapped {
+		result.Unmapped = append(result.Unmapped, cargo.UnmappedDependency{GoDep: dep})
+	}
+	return cargo.GenerateCargoToml(w, module, result)
+}
+
+func (rustBackend) Registry() Registry { return crates{cratesio.New()} }
+
+// crates adapts the crates.io client to Registry.
+type crates struct{ client *cratesio.Client }
+
+func (c crates) LatestVersion(ctx context.Context, name string) (string, error) {
+	crate, err := c.client.Crate(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return crate.LatestVersion(), nil
+}
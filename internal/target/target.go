@@ -0,0 +1,197 @@
+// Package target is the registry of target ecosystems a Go project's dependencies can
+// be mapped to. A Backend names the packages of one target language, writes its
+// dependency manifest and queries its package registry; everything else, looking up
+// equivalents and pinning versions, is shared. Adding an ecosystem is a new Backend
+// registered in an init function, like the rust, python and ts backends of this
+// package:
+//
+//	func init() {
+//		target.Register("kotlin", kotlinBackend{})
+//	}
+package target
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/types"
+)
+
+// Backend is a target ecosystem.
+type Backend interface {
+	// Lang is the language of the target libraries in the database, e.g. rust or js.
+	Lang() string
+	// ManifestFile is the name of the dependency manifest, e.g. Cargo.toml.
+	ManifestFile() string
+	// PackageName returns the registry name of a target library.
+	PackageName(idx Index, libURL string) string
+	// WriteManifest writes the manifest for the mapped dependencies of module.
+	WriteManifest(w io.Writer, module string, m *Mapping) error
+	// Registry returns a client of the ecosystem's package registry.
+	Registry() Registry
+}
+
+// ManifestFormats is implemented by backends that write more than one manifest, e.g.
+// pyproject.toml and requirements.txt. WriteManifest writes ManifestFile.
+type ManifestFormats interface {
+	ManifestFiles() []string
+	WriteManifestFile(w io.Writer, file, module string, m *Mapping) error
+}
+
+// ManifestFor returns the manifest written for an output file: the file's name if the
+// backend writes a manifest of that name, else its default ManifestFile.
+func ManifestFor(b Backend, output string) string {
+	if f, ok := b.(ManifestFormats); ok {
+		base := filepath.Base(output)
+		for _, name := range f.ManifestFiles() {
+			if name == base {
+				return name
+			}
+		}
+	}
+	return b.ManifestFile()
+}
+
+// WriteManifest writes the manifest named file, see ManifestFor.
+func WriteManifest(w io.Writer, b Backend, file, module string, m *Mapping) error {
+	if f, ok := b.(ManifestFormats); ok {
+		return f.WriteManifestFile(w, file, module, m)
+	}
+	return b.WriteManifest(w, module, m)
+}
+
+// Registry is a package registry, e.g. crates.io.
+type Registry interface {
+	LatestVersion(ctx context.Context, name string) (string, error)
+}
+
+// Index is the part of the mapping database backends use; *rinku.Rinku implements it.
+type Index interface {
+	Lookup(sourceURL, targetLang string, unsafe bool) []string
+	ReverseLookup(targetURL, sourceLang string, unsafe bool) []string
+	RequiredDeps(sourceURL, targetLang string) []types.RequiredDep
+	CrateName(rustURL string) string
+	PackageNames(lang, libURL string) []string
+}
+
+var (
+	mu       sync.RWMutex
+	backends = make(map[string]Backend)
+)
+
+// Register makes a backend available under a target name. It panics if b is nil or a
+// backend is already registered under name, like render.Register.
+func Register(name string, b Backend) {
+	mu.Lock()
+	defer mu.Unlock()
+	if b == nil {
+		panic("target: Register backend is nil")
+	}
+	if _, dup := backends[name]; dup {
+		panic("target: Register called twice for target " + name)
+	}
+	backends[name] = b
+}
+
+// Lookup returns the backend registered under name.
+func Lookup(name string) (Backend, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	b, ok := backends[name]
+	return b, ok
+}
+
+// Names returns the registered target names, sorted.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Mapping is the result of mapping Go dependencies to a target ecosystem.
+type Mapping struct {
+	Mapped   []Mapped
+	Unmapped []gomod.Dependency
+}
+
+// Mapped is a Go dependency with target equivalents.
+type Mapped struct {
+	Dep          gomod.Dependency
+	URLs         []string // target libraries, best first
+	Packages     []string // registry name per URL
+	Versions     []string // version requirement per URL; missing or empty means any
+	RequiredDeps []types.RequiredDep
+}
+
+// Version returns the version requirement of the i-th package, "*" if any version will do.
+func (m *Mapped) Version(i int) string {
+	if i < len(m.Versions) && m.Versions[i] != "" {
+		return m.Versions[i]
+	}
+	return "*"
+}
+
+// Map looks up the target equivalents of deps. The database records an equivalence in
+// the direction it was researched, so a dependency without a forward mapping to the
+// target language falls back to the mappings from that language to Go.
+func Map(b Backend, deps []gomod.Dependency, idx Index, unsafe bool) *Mapping {
+	m := &Mapping{}
+	for _, dep := range deps {
+		src := cargo.ModulePathToGitHubURL(dep.Path)
+		urls := idx.Lookup(src, b.Lang(), unsafe)
+		if len(urls) == 0 {
+			urls = idx.ReverseLookup(src, b.Lang(), unsafe)
+		}
+		if len(urls) == 0 {
+			m.Unmapped = append(m.Unmapped, dep)
+			continue
+		}
+		mapped := Mapped{Dep: dep, URLs: urls, RequiredDeps: idx.RequiredDeps(src, b.Lang())}
+		for _, u := range urls {
+			mapped.Packages = append(mapped.Packages, b.PackageName(idx, u))
+		}
+		m.Mapped = append(m.Mapped, mapped)
+	}
+	return m
+}
+
+// Pin sets the version requirement of every mapped package to its latest release in the
+// backend's registry. Packages the registry does not know keep any version.
+func Pin(ctx context.Context, b Backend, m *Mapping) error {
+	reg := b.Registry()
+	var failed []string
+	for i := range m.Mapped {
+		mapped := &m.Mapped[i]
+		mapped.Versions = make([]string, len(mapped.Packages))
+		for j, name := range mapped.Packages {
+			v, err := reg.LatestVersion(ctx, name)
+			if err != nil {
+				failed = append(failed, name)
+				continue
+			}
+			mapped.Versions[j] = v
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("no latest version of %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// repoName returns the last path segment of a library URL, the usual package name.
+func repoName(libURL string) string {
+	libURL = strings.TrimSuffix(strings.TrimSuffix(libURL, "/"), ".git")
+	return libURL[strings.LastIndex(libURL, "/")+1:]
+}
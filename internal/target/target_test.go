@@ -0,0 +1,222 @@
+package target
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stephan/rinku/internal/gomod"
+	"github.com/stephan/rinku/internal/types"
+)
+
+// index is a mapping database with forward, reverse and package entries.
+type index struct {
+	forward  map[string][]string // lang:source URL -> targets
+	reverse  map[string][]string // lang:target URL -> sources
+	crates   map[string]string
+	packages map[string][]string // lang:library URL -> names
+}
+
+func (i index) Lookup(sourceURL, targetLang string, unsafe bool) []string {
+	return i.forward[targetLang+":"+sourceURL]
+}
+
+func (i index) ReverseLookup(targetURL, sourceLang string, unsafe bool) []string {
+	return i.reverse[sourceLang+":"+targetURL]
+}
+
+func (i index) RequiredDeps(sourceURL, targetLang string) []types.RequiredDep { return nil }
+
+func (i index) CrateName(rustURL string) string { return i.crates[rustURL] }
+
+func (i index) PackageNames(lang, libURL string) []string {
+	return i.packages[lang+":"+libURL]
+}
+
+var testIndex = index{
+	forward: map[string][]string{
+		"rust:https://github.com/spf13/cobra":   {"https://github.com/clap-rs/clap"},
+		"python:https://github.com/spf13/cobra": {"https://github.com/pallets/click"},
+	},
+	reverse: map[string][]string{
+		"js:https://github.com/labstack/echo": {"https://github.com/expressjs/express"},
+		"js:https://github.com/ent/ent":       {"https://github.com/prisma/prisma"},
+	},
+	packages: map[string][]string{
+		"js:https://github.com/prisma/prisma": {"@prisma/client", "prisma"},
+	},
+}
+
+var testDeps = []gomod.Dependency{
+	{Path: "github.com/spf13/cobra", Version: "v1.8.0"},
+	{Path: "github.com/labstack/echo/v4", Version: "v4.11.0"},
+	{Path: "entgo.io/ent", Version: "v0.12.0"},
+	{Path: "github.com/acme/billing", Version: "v0.3.0"},
+}
+
+func TestRegistry(t *testing.T) {
+	if got := Names(); !reflect.DeepEqual(got, []string{"python", "rust", "ts"}) {
+		t.Errorf("Names() = %v", got)
+	}
+	if _, ok := Lookup("kotlin"); ok {
+		t.Error("Lookup(kotlin) found a backend")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("registering rust twice did not panic")
+		}
+	}()
+	Register("rust", rustBackend{})
+}
+
+func TestMap(t *testing.T) {
+	ts, _ := Lookup("ts")
+	m := Map(ts, testDeps, testIndex, false)
+
+	var packages []string
+	for _, mapped := range m.Mapped {
+		packages = append(packages, mapped.Packages...)
+	}
+	// echo has a reverse mapping from express; ent.io/ent is not on GitHub
+	if !reflect.DeepEqual(packages, []string{"express"}) {
+		t.Errorf("packages = %v", packages)
+	}
+	if len(m.Unmapped) != 3 {
+		t.Errorf("Unmapped = %v", m.Unmapped)
+	}
+
+	rust, _ := Lookup("rust")
+	if m := Map(rust, testDeps[:1], testIndex, false); len(m.Mapped) != 1 || m.Mapped[0].Packages[0] != "clap" {
+		t.Errorf("rust mapping = %+v", m)
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	ts, _ := Lookup("ts")
+	if got := ts.PackageName(testIndex, "https://github.com/prisma/prisma"); got != "prisma" {
+		t.Errorf("PackageName(prisma) = %q, want the name matching the repository", got)
+	}
+	python, _ := Lookup("python")
+	if got := python.PackageName(testIndex, "https://github.com/pallets/Click"); got != "click" {
+		t.Errorf("PackageName(click) = %q", got)
+	}
+	rust, _ := Lookup("rust")
+	if got := rust.PackageName(index{crates: map[string]string{"https://github.com/serde-rs/json": "serde_json"}}, "https://github.com/serde-rs/json"); got != "serde_json" {
+		t.Errorf("PackageName(serde_json) = %q", got)
+	}
+}
+
+func TestWriteManifest(t *testing.T) {
+	m := &Mapping{
+		Mapped: []Mapped{{
+			Dep:      gomod.Dependency{Path: "github.com/spf13/cobra"},
+			URLs:     []string{"https://github.com/pallets/click"},
+			Packages: []string{"click"},
+			Versions: []string{"8.1.7"},
+		}},
+		Unmapped: []gomod.Dependency{{Path: "github.com/acme/billing"}},
+	}
+	tests := []struct {
+		target, file, want string
+	}{
+		{"python", "pyproject.toml", `# Generated by rinku - https://github.com/marvai-dev/rinku
+# Original Go module: example.com/app
+
+[project]
+name = "app"
+version = "0.1.0"
+requires-python = ">=3.9"
+dependencies = [
+    "click==8.1.7",  # from github.com/spf13/cobra -> https://github.com/pallets/click
+]
+
+# TODO: Find equivalents for these Go dependencies:
+# TODO: find equivalent for github.com/acme/billing
+`},
+		{"python", "requirements.txt", `# Generated by rinku - https://github.com/marvai-dev/rinku
+# Original Go module: example.com/app
+
+click==8.1.7  # from github.com/spf13/cobra -> https://github.com/pallets/click
+
+# TODO: Find equivalents for these Go dependencies:
+# TODO: find equivalent for github.com/acme/billing
+`},
+		{"ts", "package.json", `{
+  "name": "app",
+  "version": "0.1.0",
+  "private": true,
+  "description": "Generated by rinku from Go module example.com/app",
+  "dependencies": {
+    "click": "8.1.7"
+  },
+  "//": [
+    "TODO: find equivalent for github.com/acme/billing"
+  ]
+}
+`},
+	}
+	for _, tt := range tests {
+		b, _ := Lookup(tt.target)
+		var sb strings.Builder
+		if err := WriteManifest(&sb, b, tt.file, "example.com/app", m); err != nil {
+			t.Fatal(err)
+		}
+		if sb.String() != tt.want {
+			t.Errorf("%s %s =\n%s\nwant:\n%s", tt.target, tt.file, sb.String(), tt.want)
+		}
+	}
+}
+
+func TestManifestFor(t *testing.T) {
+	python, _ := Lookup("python")
+	ts, _ := Lookup("ts")
+	tests := []struct {
+		b            Backend
+		output, want string
+	}{
+		{python, "-", "pyproject.toml"},
+		{python, "service/requirements.txt", "requirements.txt"},
+		{python, "deps.txt", "pyproject.toml"},
+		{ts, "requirements.txt", "package.json"},
+	}
+	for _, tt := range tests {
+		if got := ManifestFor(tt.b, tt.output); got != tt.want {
+			t.Errorf("ManifestFor(%s, %q) = %q, want %q", tt.b.Lang(), tt.output, got, tt.want)
+		}
+	}
+}
+
+type registry map[string]string
+
+func (r registry) LatestVersion(ctx context.Context, name string) (string, error) {
+	if v, ok := r[name]; ok {
+		return v, nil
+	}
+	return "", errors.New("not found")
+}
+
+// pinned is a backend whose registry is a map.
+type pinned struct {
+	Backend
+	registry
+}
+
+func (p pinned) Registry() Registry { return p.registry }
+
+func TestPin(t *testing.T) {
+	python, _ := Lookup("python")
+	b := pinned{python, registry{"click": "8.1.7"}}
+	m := &Mapping{Mapped: []Mapped{
+		{Packages: []string{"click"}},
+		{Packages: []string{"unknown"}},
+	}}
+	err := Pin(context.Background(), b, m)
+	if err == nil || !strings.Contains(err.Error(), "unknown") {
+		t.Errorf("Pin() error = %v, want the unknown package", err)
+	}
+	if m.Mapped[0].Version(0) != "8.1.7" || m.Mapped[1].Version(0) != "*" {
+		t.Errorf("versions = %v, %v", m.Mapped[0].Versions, m.Mapped[1].Versions)
+	}
+}
@@ -0,0 +1,57 @@
+package target
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/stephan/rinku/internal/manifest"
+	"github.com/stephan/rinku/internal/npm"
+)
+
+func init() {
+	Register("ts", tsBackend{})
+}
+
+// tsBackend maps to npm packages, written as package.json. TypeScript and JavaScript
+// libraries share the js language of the database.
+type tsBackend struct{}
+
+func (tsBackend) Lang() string         { return manifest.JS }
+func (tsBackend) ManifestFile() string { return "package.json" }
+
+// PackageName returns the npm name the database lists for the library, preferring the
+// one named like the repository, or else the repository name.
+func (tsBackend) PackageName(idx Index, libURL string) string {
+	return packageName(idx, manifest.JS, libURL)
+}
+
+// WriteManifest writes a package.json. JSON has no comments, so the unmapped Go
+// dependencies are listed under a "//" key, which npm ignores.
+func (tsBackend) WriteManifest(w io.Writer, module string, m *Mapping) error {
+	pkg := struct {
+		Name         string            `json:"name"`
+		Version      string            `json:"version"`
+		Private      bool              `json:"private"`
+		Description  string            `json:"description"`
+		Dependencies map[string]string `json:"dependencies"`
+		TODO         []string          `json:"//,omitempty"`
+	}{
+		Name:         strings.ToLower(repoName(module)),
+		Version:      "0.1.0",
+		Private:      true,
+		Description:  "Generated by rinku from Go module " + module,
+		Dependencies: make(map[string]string),
+	}
+	for _, mapped := range m.Mapped {
+		pkg.Dependencies[mapped.Packages[0]] = mapped.Version(0)
+	}
+	for _, dep := range m.Unmapped {
+		pkg.TODO = append(pkg.TODO, "TODO: find equivalent for "+dep.Path)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(pkg)
+}
+
+func (tsBackend) Registry() Registry { return npm.New() }
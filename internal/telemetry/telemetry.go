@@ -0,0 +1,249 @@
+// Package telemetry keeps opt-in, anonymous usage counts: the commands run and the
+// mapping database entries looked up, so maintainers can see which categories users
+// actually hit. Nothing is recorded until a user runs rinku telemetry on, and nothing
+// identifies a project: a lookup is only counted when it finds a database entry,
+// whose URL is public, never for the project's own or private modules, and command
+// arguments are dropped.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/natefinch/atomic"
+)
+
+// File is the state file below the user's config directory, e.g.
+// ~/.config/rinku/telemetry.json.
+const File = "telemetry.json"
+
+// DisableEnv turns recording off whatever the state file says, following the
+// DO_NOT_TRACK convention.
+const DisableEnv = "DO_NOT_TRACK"
+
+// EndpointEnv overrides Endpoint.
+const EndpointEnv = "RINKU_TELEMETRY_ENDPOINT"
+
+// Endpoint receives the counts as JSON. Release builds set it with
+// -ldflags "-X github.com/stephan/rinku/internal/telemetry.Endpoint=<url>"; without an
+// endpoint the counts are only kept locally, where rinku telemetry status shows them.
+var Endpoint = ""
+
+// SendInterval is the minimum time between two reports.
+const SendInterval = 24 * time.Hour
+
+// Counts are the usage counts of a period.
+type Counts struct {
+	Commands   map[string]int `json:"commands"`   // e.g. "req set", without arguments
+	Entries    map[string]int `json:"entries"`    // target language and normalized URL, e.g. rust:github.com/spf13/cobra
+	Categories map[string]int `json:"categories"` // mapping categories of the entries, e.g. cli_framework
+}
+
+func newCounts() Counts {
+	return Counts{Commands: map[string]int{}, Entries: map[string]int{}, Categories: map[string]int{}}
+}
+
+// Empty reports whether nothing was counted.
+func (c *Counts) Empty() bool {
+	return len(c.Commands) == 0 && len(c.Entries) == 0 && len(c.Categories) == 0
+}
+
+func (c *Counts) add(o Counts) {
+	for k, n := range o.Commands {
+		c.Commands[k] += n
+	}
+	for k, n := range o.Entries {
+		c.Entries[k] += n
+	}
+	for k, n := range o.Categories {
+		c.Categories[k] += n
+	}
+}
+
+// State is the content of the state file.
+type State struct {
+	Enabled bool      `json:"enabled"`
+	Since   time.Time `json:"since"`            // start of the pending counts
+	SentAt  time.Time `json:"sent_at,omitzero"` // last successful report
+	Pending Counts    `json:"pending"`          // counts not reported yet
+}
+
+// Report is the payload sent to the endpoint.
+type Report struct {
+	Version int       `json:"version"`
+	Since   time.Time `json:"since"`
+	Until   time.Time `json:"until"`
+	Counts
+}
+
+// Path returns the path of the state file in the user's config directory.
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("finding config directory: %w", err)
+	}
+	return filepath.Join(dir, "rinku", File), nil
+}
+
+// Load reads the state file. A missing file is a disabled state.
+func Load(path string) (*State, error) {
+	s := &State{Pending: newCounts()}
+	data, err := os.ReadFile(path) //#nosec G304 -- path from os.UserConfigDir
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading telemetry state: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	pending := newCounts()
+	pending.add(s.Pending)
+	s.Pending = pending
+	return s, nil
+}
+
+// Save atomically writes the state file.
+func (s *State) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling telemetry state: %w", err)
+	}
+	return atomic.WriteFile(path, bytes.NewReader(append(data, '\n')))
+}
+
+// SetEnabled turns recording on or off. Turning it off deletes the pending counts.
+func (s *State) SetEnabled(enabled bool, now time.Time) {
+	s.Enabled = enabled
+	if !enabled {
+		s.Since, s.Pending = time.Time{}, newCounts()
+	} else if s.Since.IsZero() {
+		s.Since = now
+	}
+}
+
+// Disabled reports whether DisableEnv turns recording off.
+func Disabled() bool {
+	v := os.Getenv(DisableEnv)
+	return v != "" && v != "0" && v != "false"
+}
+
+// CurrentEndpoint returns the endpoint reports are sent to, "" if none.
+func CurrentEndpoint() string {
+	if v := os.Getenv(EndpointEnv); v != "" {
+		return v
+	}
+	return Endpoint
+}
+
+// Recorder counts the usage of one rinku process. Its methods are safe for
+// concurrent use; a nil Recorder records nothing.
+type Recorder struct {
+	path   string
+	mu     sync.Mutex
+	counts Counts
+}
+
+// Open returns a recorder for the state file at path, or nil if telemetry is off.
+func Open(path string) (*Recorder, error) {
+	if Disabled() {
+		return nil, nil
+	}
+	s, err := Load(path)
+	if err != nil || !s.Enabled {
+		return nil, err
+	}
+	return &Recorder{path: path, counts: newCounts()}, nil
+}
+
+// Command counts a command, given as kong prints it ("req set <path> <content>"); the
+// arguments are dropped.
+func (r *Recorder) Command(command string) {
+	if r == nil {
+		return
+	}
+	var words []string
+	for _, w := range strings.Fields(command) {
+		if !strings.HasPrefix(w, "<") {
+			words = append(words, w)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts.Commands[strings.Join(words, " ")]++
+}
+
+// Lookup counts a lookup that found the database entry of sourceURL (normalized) for
+// targetLang, and its category if any.
+func (r *Recorder) Lookup(targetLang, sourceURL, category string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts.Entries[targetLang+":"+sourceURL]++
+	if category != "" {
+		r.counts.Categories[category]++
+	}
+}
+
+// Close adds the counts to the state file and, if an endpoint is set and the last
+// report is older than SendInterval, sends the pending counts and clears them. The
+// state is reloaded first, so counts are dropped if telemetry was turned off meanwhile.
+func (r *Recorder) Close(ctx context.Context, client *http.Client, now time.Time) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, err := Load(r.path)
+	if err != nil || !s.Enabled {
+		return err
+	}
+	s.Pending.add(r.counts)
+	r.counts = newCounts()
+
+	if endpoint := CurrentEndpoint(); endpoint != "" && !s.Pending.Empty() && now.Sub(s.SentAt) >= SendInterval {
+		if err := Send(ctx, client, endpoint, Report{Version: 1, Since: s.Since, Until: now, Counts: s.Pending}); err == nil {
+			s.SentAt, s.Since, s.Pending = now, now, newCounts()
+		}
+	}
+	return s.Save(r.path)
+}
+
+// Send posts a report to endpoint. client is http.DefaultClient if nil.
+func Send(ctx context.Context, client *http.Client, endpoint string, report Report) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("encoding report: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending telemetry: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("sending telemetry: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func enabledState(t *testing.T, now time.Time) string {
+	t.Helper()
+	t.Setenv(DisableEnv, "")
+	t.Setenv(EndpointEnv, "")
+	path := filepath.Join(t.TempDir(), "rinku", File)
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetEnabled(true, now)
+	if err := s.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestOpen_Off(t *testing.T) {
+	t.Setenv(DisableEnv, "")
+	rec, err := Open(filepath.Join(t.TempDir(), File))
+	if err != nil || rec != nil {
+		t.Fatalf("Open() = %v, %v; want nil recorder", rec, err)
+	}
+	// a nil recorder records nothing
+	rec.Command("scan <path>")
+	rec.Lookup("rust", "github.com/spf13/cobra", "cli_framework")
+	if err := rec.Close(context.Background(), nil, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOpen_DoNotTrack(t *testing.T) {
+	path := enabledState(t, time.Now())
+	t.Setenv(DisableEnv, "1")
+	if rec, _ := Open(path); rec != nil {
+		t.Error("Open() recorded with DO_NOT_TRACK=1")
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	path := enabledState(t, now)
+	for range 2 {
+		rec, err := Open(path)
+		if err != nil || rec == nil {
+			t.Fatalf("Open() = %v, %v", rec, err)
+		}
+		rec.Command("req set <path> <content>")
+		rec.Lookup("rust", "github.com/spf13/cobra", "cli_framework")
+		rec.Lookup("rust", "github.com/lib/pq", "")
+		if err := rec.Close(context.Background(), nil, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := s.Pending
+	if p.Commands["req set"] != 2 || p.Entries["rust:github.com/spf13/cobra"] != 2 || p.Entries["rust:github.com/lib/pq"] != 2 {
+		t.Errorf("pending = %+v", p)
+	}
+	if len(p.Categories) != 1 || p.Categories["cli_framework"] != 2 {
+		t.Errorf("categories = %v", p.Categories)
+	}
+}
+
+func TestRecorder_TurnedOffMeanwhile(t *testing.T) {
+	path := enabledState(t, time.Now())
+	rec, _ := Open(path)
+	rec.Command("lookup <url>")
+
+	s, _ := Load(path)
+	s.SetEnabled(false, time.Now())
+	if err := s.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Close(context.Background(), nil, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := Load(path); s.Enabled || !s.Pending.Empty() {
+		t.Errorf("state after off = %+v", s)
+	}
+}
+
+func TestRecorder_Send(t *testing.T) {
+	var reports []Report
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var report Report
+		if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+			t.Error(err)
+		}
+		reports = append(reports, report)
+	}))
+	defer srv.Close()
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	path := enabledState(t, start)
+	t.Setenv(EndpointEnv, srv.URL)
+	run := func(now time.Time) {
+		rec, _ := Open(path)
+		rec.Command("scan <path>")
+		if err := rec.Close(context.Background(), srv.Client(), now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run(start)                       // first report: never sent before
+	run(start.Add(time.Hour))        // within the interval: kept
+	run(start.Add(SendInterval + 1)) // due again
+	if len(reports) != 2 {
+		t.Fatalf("sent %d reports, want 2", len(reports))
+	}
+	if reports[0].Commands["scan"] != 1 || reports[1].Commands["scan"] != 2 || !reports[1].Since.Equal(start) {
+		t.Errorf("reports = %+v", reports)
+	}
+	if s, _ := Load(path); !s.Pending.Empty() || !s.SentAt.Equal(start.Add(SendInterval+1)) {
+		t.Errorf("state after send = %+v", s)
+	}
+}
@@ -0,0 +1,130 @@
+// Package testkit maps Go testing libraries to Rust test tooling.
+package testkit
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/stephan/rinku/internal/types"
+)
+
+// Framework describes a Go testing library and its Rust equivalents.
+type Framework struct {
+	Name        string
+	ImportPaths []string // Go modules or import path prefixes
+	Rust        string   // Rust equivalent, for display
+	DevDeps     []types.RequiredDep
+}
+
+// Frameworks lists the known Go testing libraries.
+// Stdlib packages (httptest, testing/quick) are only visible through source scanning.
+var Frameworks = []Framework{
+	{
+		Name:        "testify",
+		ImportPaths: []string{"github.com/stretchr/testify"},
+		Rust:        "assert!/assert_eq! macros, pretty_assertions, rstest fixtures",
+		DevDeps: []types.RequiredDep{
+			{Crate: "pretty_assertions", Reason: "readable assertion diffs (testify/assert)"},
+			{Crate: "rstest", Reason: "fixtures and parameterized tests (testify/suite)"},
+		},
+	},
+	{
+		Name:        "gomock",
+		ImportPaths: []string{"github.com/golang/mock", "go.uber.org/mock"}, // archived original and maintained fork
+		Rust:        "mockall",
+		DevDeps: []types.RequiredDep{
+			{Crate: "mockall", Reason: "generated mocks (gomock)"},
+		},
+	},
+	{
+		Name:        "httptest",
+		ImportPaths: []string{"net/http/httptest"},
+		Rust:        "axum-test for handlers, wiremock for HTTP client mocks",
+		DevDeps: []types.RequiredDep{
+			{Crate: "axum-test", Reason: "in-process handler tests (httptest.NewRecorder)"},
+			{Crate: "wiremock", Reason: "mock HTTP servers (httptest.NewServer)"},
+		},
+	},
+	{
+		Name:        "testcontainers-go",
+		ImportPaths: []string{"github.com/testcontainers/testcontainers-go"},
+		Rust:        "testcontainers-rs",
+		DevDeps: []types.RequiredDep{
+			{Crate: "testcontainers", Reason: "container-backed integration tests"},
+			{Crate: "testcontainers-modules", Reason: "prebuilt service containers"},
+		},
+	},
+	{
+		Name:        "ginkgo",
+		ImportPaths: []string{"github.com/onsi/ginkgo"},
+		Rust:        "rstest fixtures with nested test modules",
+		DevDeps: []types.RequiredDep{
+			{Crate: "rstest", Reason: "fixtures for BDD-style suites (ginkgo)"},
+		},
+	},
+	{
+		Name:        "gomega",
+		ImportPaths: []string{"github.com/onsi/gomega"},
+		Rust:        "assert macros, pretty_assertions",
+		DevDeps: []types.RequiredDep{
+			{Crate: "pretty_assertions", Reason: "readable assertion diffs (gomega)"},
+		},
+	},
+	{
+		Name:        "go-cmp",
+		ImportPaths: []string{"github.com/google/go-cmp"},
+		Rust:        "assert_eq! with pretty_assertions",
+		DevDeps: []types.RequiredDep{
+			{Crate: "pretty_assertions", Reason: "structural diffs (go-cmp)"},
+		},
+	},
+	{
+		Name:        "testing/quick",
+		ImportPaths: []string{"testing/quick"},
+		Rust:        "proptest",
+		DevDeps: []types.RequiredDep{
+			{Crate: "proptest", Reason: "property-based tests (testing/quick)"},
+		},
+	},
+}
+
+// Detect returns the frameworks matching any of the given module or import paths,
+// in the order of Frameworks.
+func Detect(paths []string) []Framework {
+	var result []Framework
+	for _, fw := range Frameworks {
+		if slices.ContainsFunc(paths, fw.matches) {
+			result = append(result, fw)
+		}
+	}
+	return result
+}
+
+// matches reports whether p is one of the import paths of fw or a package below one.
+func (fw Framework) matches(p string) bool {
+	return slices.ContainsFunc(fw.ImportPaths, func(prefix string) bool {
+		return p == prefix || strings.HasPrefix(p, prefix+"/")
+	})
+}
+
+// DevDependencies returns the Rust dev-dependencies for the given frameworks,
+// deduplicated by crate and sorted by crate name.
+func DevDependencies(frameworks []Framework) []types.RequiredDep {
+	seen := make(map[string]types.RequiredDep)
+	for _, fw := range frameworks {
+		for _, dep := range fw.DevDeps {
+			if _, ok := seen[dep.Crate]; !ok {
+				seen[dep.Crate] = dep
+			}
+		}
+	}
+	result := make([]types.RequiredDep, 0, len(seen))
+	for _, dep := range seen {
+		result = append(result, dep)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Crate < result[j].Crate
+	})
+	return result
+}
@@ -0,0 +1,73 @@
+package testkit
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{"module path", []string{"github.com/stretchr/testify"}, []string{"testify"}},
+		{"import subpackage", []string{"github.com/stretchr/testify/assert"}, []string{"testify"}},
+		{"major version suffix", []string{"github.com/onsi/ginkgo/v2"}, []string{"ginkgo"}},
+		{"stdlib httptest", []string{"net/http/httptest"}, []string{"httptest"}},
+		{"uber gomock", []string{"go.uber.org/mock/gomock"}, []string{"gomock"}},
+		{"both gomocks", []string{"github.com/golang/mock/gomock", "go.uber.org/mock/gomock"}, []string{"gomock"}},
+		{"similar prefix does not match", []string{"github.com/stretchr/testify-extra"}, nil},
+		{"unrelated", []string{"github.com/spf13/cobra", "net/http"}, nil},
+		{
+			"multiple in table order",
+			[]string{"net/http/httptest", "github.com/stretchr/testify/require"},
+			[]string{"testify", "httptest"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Detect(tt.paths)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Detect(%v) returned %d frameworks, want %d", tt.paths, len(got), len(tt.want))
+			}
+			for i, name := range tt.want {
+				if got[i].Name != name {
+					t.Errorf("Detect(%v)[%d] = %q, want %q", tt.paths, i, got[i].Name, name)
+				}
+			}
+		})
+	}
+}
+
+// TestFrameworks_Unique guards the table: a framework listed twice, or an import path
+// under two frameworks, would be reported twice in the testing stack.
+func TestFrameworks_Unique(t *testing.T) {
+	names := make(map[string]bool)
+	paths := make(map[string]string)
+	for _, fw := range Frameworks {
+		if names[fw.Name] {
+			t.Errorf("framework %s is listed twice; add its import paths to one entry", fw.Name)
+		}
+		names[fw.Name] = true
+		for _, p := range fw.ImportPaths {
+			if other, ok := paths[p]; ok {
+				t.Errorf("import path %s belongs to %s and %s", p, other, fw.Name)
+			}
+			paths[p] = fw.Name
+		}
+	}
+}
+
+func TestDevDependencies_DedupedAndSorted(t *testing.T) {
+	fws := Detect([]string{"github.com/stretchr/testify", "github.com/onsi/gomega", "github.com/golang/mock"})
+
+	deps := DevDependencies(fws)
+	want := []string{"mockall", "pretty_assertions", "rstest"}
+	if len(deps) != len(want) {
+		t.Fatalf("len(DevDependencies) = %d, want %d: %v", len(deps), len(want), deps)
+	}
+	for i, crate := range want {
+		if deps[i].Crate != crate {
+			t.Errorf("deps[%d].Crate = %q, want %q", i, deps[i].Crate, crate)
+		}
+	}
+}
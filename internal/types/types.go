@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 type LibsFile struct {
 	Libs map[string]Library `json:"libs"`
 }
@@ -9,10 +11,14 @@ type Library struct {
 	Lang      string   `json:"lang"`
 	Unsafe    string   `json:"unsafe,omitempty"`
 	CrateName string   `json:"crate_name,omitempty"`
+	Packages  []string `json:"packages,omitempty"` // npm or PyPI names of js and python libraries
 	Tags      []string `json:"tags,omitempty"`
 }
 
 type MappingsFile struct {
+	// Released is when this version of the database was published. Of the built-in
+	// and the downloaded database rinku uses the newer one.
+	Released time.Time `json:"released,omitzero"`
 	Mappings []Mapping `json:"mappings"`
 }
 
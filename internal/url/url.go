@@ -1,10 +1,19 @@
 package url
 
-import "strings"
+import (
+	"slices"
+	"strings"
+)
 
 // Normalize converts a URL to a canonical form for lookup.
 // It lowercases, strips http(s):// prefix, removes www. from host only,
-// and removes trailing slash. Returns empty string for invalid input.
+// drops a ?query or #fragment, a .git suffix and a viewer path such as
+// /tree/<ref>/<dir> or /blob/<ref>/<file>, and removes trailing slashes.
+// Returns empty string for invalid input.
+//
+// On GitHub and GitLab everything after /tree/ or /blob/ is dropped, so a library
+// inside a monorepo, as in owner/repo/tree/main/crates/ignore, normalizes to its
+// repository. Other hosts only lose a trailing /tree/<ref>.
 func Normalize(inputURL string) string {
 	if inputURL == "" {
 		return ""
@@ -14,25 +23,77 @@ func Normalize(inputURL string) string {
 	url = strings.TrimPrefix(url, "https://")
 	url = strings.TrimPrefix(url, "http://")
 
+	// Query and fragment never identify the library (?tab=readme, #installation)
+	if i := strings.IndexAny(url, "?#"); i >= 0 {
+		url = url[:i]
+	}
+
 	// Handle empty result after stripping protocol (e.g., "https://")
 	if url == "" {
 		return ""
 	}
 
-	// Only strip www. from the host portion, not from paths
-	// Find the first slash to separate host from path
-	slashIdx := strings.Index(url, "/")
-	if slashIdx == -1 {
-		// No path, entire string is host
-		url = strings.TrimPrefix(url, "www.")
-	} else {
-		// Split into host and path, only strip www. from host
-		host := url[:slashIdx]
-		path := url[slashIdx:]
-		host = strings.TrimPrefix(host, "www.")
-		url = host + path
-	}
+	// Only strip www. from the host portion, not from paths. The host is the
+	// prefix up to the first slash and "www." contains none, so trimming the
+	// whole string is equivalent and avoids re-joining host and path.
+	url = strings.TrimPrefix(url, "www.")
+
+	url = strings.TrimRight(url, "/")
+	url = stripViewerPath(url)
+	url = strings.TrimSuffix(url, ".git")
+	return strings.TrimRight(url, "/")
+}
+
+// forgeHosts are the hosts whose repository pages put the ref and path a browser shows
+// after /tree/ or /blob/ (GitLab's /-/tree/ and /-/blob/).
+var forgeHosts = []string{"github.com", "gitlab.com"}
 
-	url = strings.TrimSuffix(url, "/")
+// stripViewerPath removes the viewer path of a repository page. On a forge host that
+// is /tree/ or /blob/ and everything after it, since a ref may contain slashes
+// (/tree/release/v1) and be followed by a directory or file. On other hosts only a
+// trailing "/tree/<ref>" is removed.
+func stripViewerPath(url string) string {
+	host, _, _ := strings.Cut(url, "/")
+	if !slices.Contains(forgeHosts, host) {
+		return stripTreeRef(url)
+	}
+	for _, sep := range []string{"/-/tree/", "/-/blob/"} {
+		// GitLab groups nest, so the repository is anything before the separator
+		if i := strings.Index(url, sep); i >= 0 && strings.Count(url[:i], "/") >= 2 {
+			return url[:i]
+		}
+	}
+	// host/owner/repo/tree/<ref>...: the viewer path must follow a repository
+	repo := 0
+	for range 3 {
+		i := strings.IndexByte(url[repo:], '/')
+		if i < 0 {
+			return url
+		}
+		repo += i + 1
+	}
+	kind, ref, _ := strings.Cut(url[repo:], "/")
+	if (kind == "tree" || kind == "blob") && ref != "" {
+		return url[:repo-1]
+	}
 	return url
 }
+
+// stripTreeRef removes a trailing "/tree/<ref>" (or "/-/tree/<ref>") from
+// host/owner/repo/tree/<ref>.
+func stripTreeRef(url string) string {
+	i := strings.LastIndexByte(url, '/')
+	if i < 0 {
+		return url
+	}
+	rest, ok := strings.CutSuffix(url[:i], "/tree")
+	if !ok {
+		return url
+	}
+	rest = strings.TrimSuffix(rest, "/-")
+	// host/owner/repo: the viewer path must follow a repository
+	if strings.Count(rest, "/") < 2 {
+		return url
+	}
+	return rest
+}
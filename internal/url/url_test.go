@@ -21,6 +21,25 @@ func TestNormalize(t *testing.T) {
 		{"preserves www in path", "github.com/www.example/repo", "github.com/www.example/repo"},
 		{"strips www from host only", "www.github.com/www.foo/bar", "github.com/www.foo/bar"},
 		{"host without path", "www.github.com", "github.com"},
+		{"strips .git suffix", "https://github.com/foo/bar.git", "github.com/foo/bar"},
+		{"strips .git before trailing slash", "https://github.com/foo/bar.git/", "github.com/foo/bar"},
+		{"strips fragment", "https://github.com/foo/bar#readme", "github.com/foo/bar"},
+		{"strips query", "https://github.com/foo/bar?tab=readme-ov-file", "github.com/foo/bar"},
+		{"strips query and fragment", "https://github.com/foo/bar/?tab=x#usage", "github.com/foo/bar"},
+		{"strips tree branch", "https://github.com/foo/bar/tree/main", "github.com/foo/bar"},
+		{"strips tree branch with slash", "https://github.com/foo/bar/tree/v1.2.0/", "github.com/foo/bar"},
+		{"strips gitlab tree", "https://gitlab.com/foo/bar/-/tree/master", "gitlab.com/foo/bar"},
+		{"all together", "HTTPS://www.GitHub.com/Foo/Bar.git?x=1#y", "github.com/foo/bar"},
+		{"strips tree ref and subdir", "https://github.com/BurntSushi/ripgrep/tree/master/crates/ignore", "github.com/burntsushi/ripgrep"},
+		{"strips tree ref with slash", "https://github.com/foo/bar/tree/release/v1", "github.com/foo/bar"},
+		{"strips blob ref and path", "https://github.com/foo/bar/blob/main/docs/README.md", "github.com/foo/bar"},
+		{"strips gitlab blob", "https://gitlab.com/group/sub/bar/-/blob/main/go.mod", "gitlab.com/group/sub/bar"},
+		{"strips tree on other host", "https://git.example.com/foo/bar/tree/main", "git.example.com/foo/bar"},
+		{"keeps subdir on other host", "https://git.example.com/foo/bar/tree/main/pkg", "git.example.com/foo/bar/tree/main/pkg"},
+		{"keeps repo named blob", "github.com/foo/blob/main", "github.com/foo/blob/main"},
+		{"keeps repo named tree", "github.com/foo/tree/main", "github.com/foo/tree/main"},
+		{"keeps .git inside name", "github.com/foo/bar.gitlab", "github.com/foo/bar.gitlab"},
+		{"query only", "https://?x", ""},
 	}
 
 	for _, tt := range tests {
@@ -32,3 +51,10 @@ func TestNormalize(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkNormalize(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Normalize("https://www.GitHub.com/spf13/cobra/")
+	}
+}
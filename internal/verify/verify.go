@@ -2,9 +2,10 @@
 package verify
 
 import (
-	"path/filepath"
-	"strings"
+	"slices"
+	"sort"
 
+	"github.com/stephan/rinku/internal/pattern"
 	"github.com/stephan/rinku/internal/requirements"
 )
 
@@ -14,6 +15,7 @@ var TagToCategoryMap = map[string][]string{
 	"cli":              {"*/cli"},
 	"web":              {"*/api"},
 	"templating":       {"*/templates"},
+	"observability":    {"*/observability"},
 	"sql":              {"db"},
 	"orm":              {"db"},
 	"codegen:protobuf": {"codegen/protobuf"},
@@ -24,6 +26,17 @@ var TagToCategoryMap = map[string][]string{
 	"codegen:gqlgen":   {"codegen/gqlgen"},
 }
 
+// KnownPatterns are the requirement categories besides those of TagToCategoryMap: what
+// req extract and req api capture and the implementation steps gate on.
+var KnownPatterns = []string{
+	"*/config",
+	"*/static",
+	"*/middleware",
+	"*/sessions",
+	"pkg",
+	"tests",
+}
+
 // CategoryStatus represents coverage for a requirement category.
 type CategoryStatus struct {
 	Category        string
@@ -35,13 +48,23 @@ type CategoryStatus struct {
 	Paths           []string
 }
 
-// CheckCoverage compares expected tags against captured requirements.
-func CheckCoverage(projectDir string, tags []string) ([]CategoryStatus, error) {
-	// Get all requirements
-	allReqs, err := requirements.List(projectDir, "")
+// CheckCoverage compares expected tags against captured requirements. strip removes a
+// namespace from a path before it is matched, as for CheckUnknown; it may be nil. The
+// Paths of the results are the stored ones.
+func CheckCoverage(projectDir string, tags []string, strip func(string) string) ([]CategoryStatus, error) {
+	// Get all requirements in one pass
+	reqs, err := requirements.GetAll(projectDir, "")
 	if err != nil {
 		return nil, err
 	}
+	allReqs := make([]string, len(reqs))
+	done := make(map[string]bool, len(reqs))
+	stored := make(map[string]string, len(reqs)) // matched path -> stored path
+	for i, req := range reqs {
+		allReqs[i] = stripped(strip, req.Path)
+		done[allReqs[i]] = req.Done
+		stored[allReqs[i]] = req.Path
+	}
 
 	// Build set of expected categories from tags
 	expectedPatterns := make(map[string]string) // pattern -> tag
@@ -60,11 +83,11 @@ func CheckCoverage(projectDir string, tags []string) ([]CategoryStatus, error) {
 
 		// Count done requirements
 		doneCount := 0
-		for _, path := range matching {
-			req, _ := requirements.Get(projectDir, path)
-			if req != nil && req.Done {
+		for i, path := range matching {
+			if done[path] {
 				doneCount++
 			}
+			matching[i] = stored[path]
 		}
 
 		results = append(results, CategoryStatus{
@@ -78,34 +101,66 @@ func CheckCoverage(projectDir string, tags []string) ([]CategoryStatus, error) {
 		})
 	}
 
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Category != results[j].Category {
+			return results[i].Category < results[j].Category
+		}
+		return results[i].Pattern < results[j].Pattern
+	})
 	return results, nil
 }
 
+// CheckUnknown returns the requirement paths, sorted, that match no pattern of
+// TagToCategoryMap, KnownPatterns or extra. No coverage check or step gate sees them, so
+// they are often typos such as app/cl/flags/--port. strip removes a namespace from a
+// path before it is matched; it may be nil.
+func CheckUnknown(projectDir string, extra []string, strip func(string) string) ([]string, error) {
+	reqs, err := requirements.GetAll(projectDir, "")
+	if err != nil {
+		return nil, err
+	}
+	patterns := append(slices.Clone(KnownPatterns), extra...)
+	for _, ps := range TagToCategoryMap {
+		patterns = append(patterns, ps...)
+	}
+
+	var unknown []string
+	for _, req := range reqs {
+		path := stripped(strip, req.Path)
+		if !slices.ContainsFunc(patterns, func(p string) bool { return matchPattern(p, path) }) {
+			unknown = append(unknown, req.Path)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown, nil
+}
+
 // CheckImplementation returns done and pending requirement paths.
 func CheckImplementation(projectDir string) (done, pending []string, err error) {
-	paths, err := requirements.List(projectDir, "")
+	reqs, err := requirements.GetAll(projectDir, "")
 	if err != nil {
 		return nil, nil, err
 	}
 
-	for _, path := range paths {
-		req, err := requirements.Get(projectDir, path)
-		if err != nil {
-			return nil, nil, err
-		}
-		if req == nil {
-			continue
-		}
+	for _, req := range reqs {
 		if req.Done {
-			done = append(done, path)
+			done = append(done, req.Path)
 		} else {
-			pending = append(pending, path)
+			pending = append(pending, req.Path)
 		}
 	}
 
 	return done, pending, nil
 }
 
+// stripped returns path with strip applied, path itself if strip is nil.
+func stripped(strip func(string) string, path string) string {
+	if strip == nil {
+		return path
+	}
+	return strip(path)
+}
+
 // filterByPattern returns requirement paths matching the pattern.
 // Pattern supports * as a wildcard for a single path segment.
 func filterByPattern(reqs []string, pattern string) []string {
@@ -118,27 +173,10 @@ func filterByPattern(reqs []string, pattern string) []string {
 	return matches
 }
 
-// matchPattern checks if a requirement path matches the pattern.
-// * matches any single path segment (not including /).
-func matchPattern(pattern, path string) bool {
-	patternParts := strings.Split(pattern, "/")
-	pathParts := strings.Split(path, "/")
-
-	// Pattern must be a prefix match
-	if len(patternParts) > len(pathParts) {
-		return false
-	}
-
-	for i, pp := range patternParts {
-		if pp == "*" {
-			continue // Wildcard matches anything
-		}
-		if pp != pathParts[i] {
-			return false
-		}
-	}
-
-	return true
+// matchPattern checks if a requirement path matches the pattern, see package pattern.
+// Both are normalized with requirements.NormalizePath, so Windows separators match too.
+func matchPattern(pat, path string) bool {
+	return pattern.Match(requirements.NormalizePath(pat), requirements.NormalizePath(path))
 }
 
 // FilterByPattern is exported for use in gating.
@@ -151,26 +189,24 @@ func MatchPattern(pattern, path string) bool {
 	return matchPattern(pattern, path)
 }
 
-// GetRequirementStatus returns whether all requirements matching a pattern are done.
-func GetRequirementStatus(projectDir, pattern string) (allDone bool, pending []string, err error) {
-	paths, err := requirements.List(projectDir, "")
-	if err != nil {
+// GetRequirementStatus returns whether all requirements matching a pattern are done. A
+// pattern without requirements is satisfied. strip removes a namespace from a path
+// before it is matched, as for CheckUnknown; it may be nil. pending lists the stored
+// paths.
+func GetRequirementStatus(projectDir, pat string, strip func(string) string) (allDone bool, pending []string, err error) {
+	if err := pattern.Validate(requirements.NormalizePath(pat)); err != nil {
 		return false, nil, err
 	}
-
-	matching := filterByPattern(paths, pattern)
-	if len(matching) == 0 {
-		// No requirements for this pattern - consider it satisfied
-		return true, nil, nil
+	reqs, err := requirements.GetAll(projectDir, "")
+	if err != nil {
+		return false, nil, err
 	}
-
-	for _, path := range matching {
-		req, err := requirements.Get(projectDir, path)
-		if err != nil {
-			return false, nil, err
+	for _, req := range reqs {
+		if !matchPattern(pat, stripped(strip, req.Path)) {
+			continue
 		}
-		if req != nil && !req.Done {
-			pending = append(pending, path)
+		if !req.Done {
+			pending = append(pending, req.Path)
 		}
 	}
 
@@ -186,11 +222,12 @@ func GetRequirementsByPattern(projectDir, pattern string) ([]string, error) {
 	return filterByPattern(paths, pattern), nil
 }
 
-// ExpandWildcardPattern expands a pattern with * to match actual requirement paths.
-// Returns the pattern as-is if it contains no wildcard.
-func ExpandWildcardPattern(projectDir string, pattern string) ([]string, error) {
-	if !strings.Contains(pattern, "*") {
-		return []string{pattern}, nil
+// ExpandWildcardPattern expands a pattern with wildcards to the matching requirement
+// prefixes, e.g. */cli to api/cli and worker/cli. Returns the pattern as-is if it
+// contains no wildcard.
+func ExpandWildcardPattern(projectDir string, pat string) ([]string, error) {
+	if !pattern.HasMeta(pat) {
+		return []string{pat}, nil
 	}
 
 	paths, err := requirements.List(projectDir, "")
@@ -200,30 +237,9 @@ func ExpandWildcardPattern(projectDir string, pattern string) ([]string, error)
 
 	// Find unique prefixes that match the pattern
 	prefixes := make(map[string]struct{})
-	patternParts := strings.Split(pattern, "/")
-
 	for _, path := range paths {
-		pathParts := strings.Split(path, "/")
-		if len(pathParts) < len(patternParts) {
-			continue
-		}
-
-		// Check if path matches pattern and extract the expanded prefix
-		match := true
-		expanded := make([]string, len(patternParts))
-		for i, pp := range patternParts {
-			if pp == "*" {
-				expanded[i] = pathParts[i]
-			} else if pp == pathParts[i] {
-				expanded[i] = pp
-			} else {
-				match = false
-				break
-			}
-		}
-
-		if match {
-			prefixes[filepath.Join(expanded...)] = struct{}{}
+		if prefix, ok := pattern.MatchPrefix(requirements.NormalizePath(pat), path); ok {
+			prefixes[prefix] = struct{}{}
 		}
 	}
 
@@ -231,5 +247,6 @@ func ExpandWildcardPattern(projectDir string, pattern string) ([]string, error)
 	for p := range prefixes {
 		result = append(result, p)
 	}
+	sort.Strings(result)
 	return result, nil
 }
@@ -0,0 +1,176 @@
+package verify
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stephan/rinku/internal/progress"
+
+	"github.com/stephan/rinku/internal/requirements"
+)
+
+func TestCheckCoverageOrder(t *testing.T) {
+	dir := t.TempDir()
+	for _, p := range []string{"svc/cli/flags/--port", "svc/api/routes/GET/users", "worker/cli/commands/run"} {
+		if err := requirements.Set(dir, p, "x"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < 5; i++ {
+		statuses, err := CheckCoverage(dir, []string{"web", "cli", "templating"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, s := range statuses {
+			got = append(got, s.Category+" "+s.Pattern)
+		}
+		want := []string{"cli */cli", "templating */templates", "web */api"}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+
+	prefixes, err := ExpandWildcardPattern(dir, "*/cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(prefixes, []string{"svc/cli", "worker/cli"}) {
+		t.Errorf("ExpandWildcardPattern = %v", prefixes)
+	}
+}
+
+func TestExpandWildcardPattern_AnyDepth(t *testing.T) {
+	dir := t.TempDir()
+	for _, p := range []string{"svc/api/routes/GET/users", "svc/api/v1/routes/POST/users", "svc/api/v1/health"} {
+		if err := requirements.Set(dir, p, "x"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prefixes, err := ExpandWildcardPattern(dir, "svc/api/**/routes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(prefixes, []string{"svc/api/routes", "svc/api/v1/routes"}) {
+		t.Errorf("ExpandWildcardPattern = %v", prefixes)
+	}
+	if !MatchPattern("svc/api/**/routes", "svc/api/v1/routes/POST/users") || MatchPattern("svc/api/**/routes", "svc/api/v1/health") {
+		t.Error("MatchPattern does not handle **")
+	}
+}
+
+func TestCheckUnknown(t *testing.T) {
+	dir := t.TempDir()
+	for _, p := range []string{
+		"svc/cli/flags/--port", "svc/cl/flags/--host", "svc/config/env/PORT", "pkg/client/Dial",
+		"db/users", "dbs/users", "steps/3/svc/api/routes/GET/users", "review/auth",
+	} {
+		if err := requirements.Set(dir, p, "x"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	unknown, err := CheckUnknown(dir, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"dbs/users", "review/auth", "steps/3/svc/api/routes/GET/users", "svc/cl/flags/--host"}
+	if !reflect.DeepEqual(unknown, want) {
+		t.Errorf("CheckUnknown = %v, want %v", unknown, want)
+	}
+
+	strip := func(p string) string { return strings.TrimPrefix(p, "steps/3/") }
+	unknown, _ = CheckUnknown(dir, []string{"review"}, strip)
+	if want := []string{"dbs/users", "svc/cl/flags/--host"}; !reflect.DeepEqual(unknown, want) {
+		t.Errorf("CheckUnknown with extra pattern and namespace = %v, want %v", unknown, want)
+	}
+}
+
+func TestNamespaced(t *testing.T) {
+	dir := t.TempDir()
+	for _, p := range []string{"steps/3/svc/cli/flags/--port", "steps/3/svc/cli/flags/--host"} {
+		if err := requirements.Set(dir, p, "x"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := requirements.Done(dir, "steps/3/svc/cli/flags/--port"); err != nil {
+		t.Fatal(err)
+	}
+	strip := func(p string) string { return requirements.StripNamespace("steps/{step}", p) }
+
+	statuses, err := CheckCoverage(dir, []string{"cli"}, strip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0].Count != 2 || statuses[0].DoneCount != 1 || statuses[0].Paths[0] != "steps/3/svc/cli/flags/--host" {
+		t.Errorf("CheckCoverage = %+v", statuses)
+	}
+	allDone, pending, err := GetRequirementStatus(dir, "*/cli", strip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allDone || !reflect.DeepEqual(pending, []string{"steps/3/svc/cli/flags/--host"}) {
+		t.Errorf("GetRequirementStatus = %v, %v", allDone, pending)
+	}
+	if allDone, _, _ := GetRequirementStatus(dir, "*/cli", nil); !allDone {
+		t.Error("without strip, the namespaced paths should not match */cli")
+	}
+}
+
+func TestCheckImplementation(t *testing.T) {
+	dir := t.TempDir()
+	for _, p := range []string{"svc/cli/flags/--port", "svc/cli/flags/--host", "svc/api/routes/GET/users"} {
+		if err := requirements.Set(dir, p, "x"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := requirements.Done(dir, "svc/cli/flags/--port"); err != nil {
+		t.Fatal(err)
+	}
+
+	done, pending, err := CheckImplementation(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(done, []string{"svc/cli/flags/--port"}) || !reflect.DeepEqual(pending, []string{"svc/api/routes/GET/users", "svc/cli/flags/--host"}) {
+		t.Errorf("done = %v, pending = %v", done, pending)
+	}
+
+	allDone, notDone, err := GetRequirementStatus(dir, "svc/cli", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allDone || !reflect.DeepEqual(notDone, []string{"svc/cli/flags/--host"}) {
+		t.Errorf("GetRequirementStatus = %v, %v", allDone, notDone)
+	}
+	if allDone, _, _ := GetRequirementStatus(dir, "db", nil); !allDone {
+		t.Error("pattern without requirements should be satisfied")
+	}
+}
+
+// BenchmarkCheckCoverage measures verify on a project with 5000 captured requirements.
+func BenchmarkCheckCoverage(b *testing.B) {
+	dir := b.TempDir()
+	base := filepath.Join(dir, progress.ProgressDir, requirements.RequirementsDir)
+	for i := range 5000 {
+		reqPath := fmt.Sprintf("svc/%s/group%d/item%d", []string{"cli", "api", "templates"}[i%3], i%50, i)
+		file := filepath.Join(base, filepath.FromSlash(reqPath)+".json")
+		if err := os.MkdirAll(filepath.Dir(file), 0750); err != nil {
+			b.Fatal(err)
+		}
+		data := fmt.Sprintf(`{"path": %q, "content": "x", "done": %t}`, reqPath, i%2 == 0)
+		if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+			b.Fatal(err)
+		}
+	}
+	for b.Loop() {
+		if _, err := CheckCoverage(dir, []string{"cli", "web", "templating"}, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Package versionmap suggests the Rust crate version that was current when a Go module
+// version was released, so a port of a project on older dependencies can start from
+// the contemporaneous crate instead of always the latest one.
+package versionmap
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/cratesio"
+	"github.com/stephan/rinku/internal/goproxy"
+)
+
+var majorSuffixRe = regexp.MustCompile(`^v[0-9]+$`)
+
+// Registry fetches the published versions of a crate.
+type Registry interface {
+	Releases(ctx context.Context, name string) ([]cratesio.Release, error)
+}
+
+// Proxy fetches the publication time of a Go module version.
+type Proxy interface {
+	Info(ctx context.Context, module, version string) (*goproxy.Info, error)
+}
+
+// Suggestion is the crate release line matching a Go module version.
+type Suggestion struct {
+	GoModule  string
+	GoVersion string
+	GoTime    time.Time // when GoVersion was published
+	Crate     string
+	Version   string    // newest stable release published by GoTime
+	Released  time.Time // when Version was published
+	Req       string    // Cargo requirement for the release line of Version, e.g. 0.6
+	Latest    string    // newest stable release
+	Breaking  int       // semver-incompatible release lines between Version and Latest
+	Predates  bool      // the Go version is older than the crate; Version is its first release
+}
+
+// String returns a sentence like "you were on gin v1.9.1 (2023); axum 0.6 is the
+// contemporaneous equivalent (latest 0.8.4, 2 breaking releases since)".
+func (s *Suggestion) String() string {
+	were := fmt.Sprintf("you were on %s %s (%d)", shortName(s.GoModule), s.GoVersion, s.GoTime.Year())
+	if s.Predates {
+		return fmt.Sprintf("%s; %s was first released later (%s, %d)", were, s.Crate, s.Req, s.Released.Year())
+	}
+	if s.Breaking == 0 {
+		return fmt.Sprintf("%s; %s %s is the contemporaneous equivalent and still the current release line", were, s.Crate, s.Req)
+	}
+	plural := "s"
+	if s.Breaking == 1 {
+		plural = ""
+	}
+	return fmt.Sprintf("%s; %s %s is the contemporaneous equivalent (latest %s, %d breaking release%s since)", were, s.Crate, s.Req, s.Latest, s.Breaking, plural)
+}
+
+// Suggest picks the newest stable, non-yanked release published at or before goTime.
+// It returns nil if the crate has no stable release.
+func Suggest(goModule, goVersion string, goTime time.Time, crate string, releases []cratesio.Release) *Suggestion {
+	type release struct {
+		v cargo.Version
+		t time.Time
+	}
+	var stable []release
+	for _, r := range releases {
+		if r.Yanked || strings.ContainsAny(r.Num, "-+") {
+			continue
+		}
+		v, err := cargo.ParseVersion(r.Num)
+		if err != nil {
+			continue
+		}
+		stable = append(stable, release{v, r.CreatedAt})
+	}
+	if len(stable) == 0 {
+		return nil
+	}
+	sort.Slice(stable, func(i, j int) bool { return stable[i].v.Compare(stable[j].v) < 0 })
+
+	s := &Suggestion{GoModule: goModule, GoVersion: goVersion, GoTime: goTime, Crate: crate, Latest: stable[len(stable)-1].v.String()}
+	pick := -1
+	for i, r := range stable {
+		if !r.t.After(goTime) {
+			pick = i
+		}
+	}
+	if pick < 0 {
+		// Pick the first release by date; backports can be published after newer lines.
+		pick = 0
+		for i, r := range stable {
+			if r.t.Before(stable[pick].t) {
+				pick = i
+			}
+		}
+		s.Predates = true
+	}
+	s.Version = stable[pick].v.String()
+	s.Released = stable[pick].t
+	s.Req = requirement(stable[pick].v)
+
+	line := releaseLine(stable[pick].v)
+	newer := make(map[cargo.Version]bool)
+	for _, r := range stable[pick+1:] {
+		if l := releaseLine(r.v); l != line && !newer[l] {
+			newer[l] = true
+			s.Breaking++
+		}
+	}
+	return s
+}
+
+// releaseLine returns the release that starts the semver-compatible line of v: 1.x
+// releases are compatible with each other, 0.x releases only within a minor version.
+func releaseLine(v cargo.Version) cargo.Version {
+	switch {
+	case v.Major > 0:
+		return cargo.Version{Major: v.Major}
+	case v.Minor > 0:
+		return cargo.Version{Minor: v.Minor}
+	}
+	return v
+}
+
+// requirement returns the Cargo requirement for v without the patch (1.4, 0.6), which
+// lets Cargo pick any compatible later patch; 0.0.x releases are pinned exactly.
+func requirement(v cargo.Version) string {
+	if v.Major == 0 && v.Minor == 0 {
+		return v.String()
+	}
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// shortName returns the last path element of a module path, skipping a major version
+// suffix: github.com/go-chi/chi/v5 is chi.
+func shortName(module string) string {
+	base := path.Base(module)
+	if majorSuffixRe.MatchString(base) {
+		return path.Base(path.Dir(module))
+	}
+	return base
+}
+
+// Resolver looks up publication times and crate releases, caching releases per crate.
+type Resolver struct {
+	Registry Registry
+	Proxy    Proxy
+
+	releases map[string][]cratesio.Release
+}
+
+// NewResolver returns a resolver using the given registry and proxy.
+func NewResolver(registry Registry, proxy Proxy) *Resolver {
+	return &Resolver{Registry: registry, Proxy: proxy, releases: make(map[string][]cratesio.Release)}
+}
+
+// Suggest looks up when module@version was published and the crate version current at
+// that time.
+func (r *Resolver) Suggest(ctx context.Context, module, version, crate string) (*Suggestion, error) {
+	info, err := r.Proxy.Info(ctx, module, version)
+	if err != nil {
+		return nil, err
+	}
+	releases, ok := r.releases[crate]
+	if !ok {
+		releases, err = r.Registry.Releases(ctx, crate)
+		if err != nil {
+			return nil, err
+		}
+		r.releases[crate] = releases
+	}
+	s := Suggest(module, version, info.Time, crate, releases)
+	if s == nil {
+		return nil, fmt.Errorf("%s has no stable release", crate)
+	}
+	return s, nil
+}
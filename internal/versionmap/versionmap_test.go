@@ -0,0 +1,125 @@
+package versionmap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stephan/rinku/internal/cratesio"
+	"github.com/stephan/rinku/internal/goproxy"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+var axumReleases = []cratesio.Release{
+	{Num: "0.8.4", CreatedAt: date("2025-04-30")},
+	{Num: "0.8.0-rc.1", CreatedAt: date("2024-12-01")},
+	{Num: "0.7.9", CreatedAt: date("2024-11-15")},
+	{Num: "0.7.0", CreatedAt: date("2023-11-27")},
+	{Num: "0.6.21", CreatedAt: date("2023-12-05")}, // backport published after 0.7.0
+	{Num: "0.6.20", CreatedAt: date("2023-08-01"), Yanked: true},
+	{Num: "0.6.18", CreatedAt: date("2023-05-01")},
+	{Num: "0.5.17", CreatedAt: date("2022-10-24")},
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name     string
+		goTime   string
+		version  string
+		req      string
+		breaking int
+		predates bool
+	}{
+		{"contemporaneous", "2023-06-12", "0.6.18", "0.6", 2, false},
+		{"yanked release skipped", "2023-08-15", "0.6.18", "0.6", 2, false},
+		{"pre-release skipped", "2024-12-10", "0.7.9", "0.7", 1, false},
+		{"latest line", "2025-06-01", "0.8.4", "0.8", 0, false},
+		{"older than crate", "2020-01-01", "0.5.17", "0.5", 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Suggest("github.com/gin-gonic/gin", "v1.9.1", date(tt.goTime), "axum", axumReleases)
+			if s.Version != tt.version || s.Req != tt.req || s.Breaking != tt.breaking || s.Predates != tt.predates {
+				t.Errorf("Suggest() = %s (req %s, %d breaking, predates %v), want %s (req %s, %d breaking, predates %v)",
+					s.Version, s.Req, s.Breaking, s.Predates, tt.version, tt.req, tt.breaking, tt.predates)
+			}
+			if s.Latest != "0.8.4" {
+				t.Errorf("Latest = %s, want 0.8.4", s.Latest)
+			}
+		})
+	}
+
+	if s := Suggest("m", "v1", date("2023-01-01"), "c", []cratesio.Release{{Num: "1.0.0-beta"}}); s != nil {
+		t.Errorf("Suggest() without stable releases = %+v, want nil", s)
+	}
+}
+
+func TestSuggest_MajorVersions(t *testing.T) {
+	releases := []cratesio.Release{
+		{Num: "4.5.0", CreatedAt: date("2024-02-01")},
+		{Num: "4.4.0", CreatedAt: date("2023-08-01")},
+		{Num: "3.2.25", CreatedAt: date("2023-05-01")},
+	}
+	s := Suggest("github.com/spf13/cobra", "v1.7.0", date("2023-09-01"), "clap", releases)
+	if s.Req != "4.4" || s.Breaking != 0 {
+		t.Errorf("Suggest() = req %s, %d breaking; want 4.4, 0", s.Req, s.Breaking)
+	}
+}
+
+func TestSuggestion_String(t *testing.T) {
+	s := Suggest("github.com/gin-gonic/gin", "v1.9.1", date("2023-06-12"), "axum", axumReleases)
+	want := "you were on gin v1.9.1 (2023); axum 0.6 is the contemporaneous equivalent (latest 0.8.4, 2 breaking releases since)"
+	if got := s.String(); got != want {
+		t.Errorf("String() = %q\nwant %q", got, want)
+	}
+
+	s = Suggest("github.com/go-chi/chi/v5", "v5.2.1", date("2025-06-01"), "axum", axumReleases)
+	want = "you were on chi v5.2.1 (2025); axum 0.8 is the contemporaneous equivalent and still the current release line"
+	if got := s.String(); got != want {
+		t.Errorf("String() = %q\nwant %q", got, want)
+	}
+}
+
+type fakeRegistry struct{ calls int }
+
+func (f *fakeRegistry) Releases(ctx context.Context, name string) ([]cratesio.Release, error) {
+	f.calls++
+	if name != "axum" {
+		return nil, cratesio.ErrNotFound
+	}
+	return axumReleases, nil
+}
+
+type fakeProxy struct{}
+
+func (fakeProxy) Info(ctx context.Context, module, version string) (*goproxy.Info, error) {
+	return &goproxy.Info{Version: version, Time: date("2023-06-12")}, nil
+}
+
+func TestResolver(t *testing.T) {
+	registry := &fakeRegistry{}
+	r := NewResolver(registry, fakeProxy{})
+	for _, module := range []string{"github.com/gin-gonic/gin", "github.com/labstack/echo/v4"} {
+		s, err := r.Suggest(context.Background(), module, "v1.0.0", "axum")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Req != "0.6" {
+			t.Errorf("Suggest(%s) = %s, want 0.6", module, s.Req)
+		}
+	}
+	if registry.calls != 1 {
+		t.Errorf("registry calls = %d, want 1 (cached)", registry.calls)
+	}
+	if _, err := r.Suggest(context.Background(), "github.com/spf13/cobra", "v1.7.0", "clap"); !errors.Is(err, cratesio.ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/gomod"
+)
+
+// Coverage is the mapping coverage of one changed go.mod.
+type Coverage struct {
+	Path        string // path of the go.mod within the repository
+	Module      string
+	Direct      int
+	Mapped      int
+	NewUnmapped []string // unmapped direct dependencies not required before the change
+}
+
+// Compare maps the direct dependencies of head and reports the unmapped ones that are
+// not direct dependencies of base. base may be nil for a newly added go.mod.
+func Compare(path string, base, head []byte, lookup cargo.Lookup, unsafe bool) (*Coverage, error) {
+	headMod, err := gomod.ParseReader(bytes.NewReader(head))
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	before := make(map[string]bool)
+	if base != nil {
+		baseMod, err := gomod.ParseReader(bytes.NewReader(base))
+		if err != nil {
+			return nil, fmt.Errorf("parsing base %s: %w", path, err)
+		}
+		for _, dep := range baseMod.DirectDependencies() {
+			before[dep.Path] = true
+		}
+	}
+
+	deps := headMod.DirectDependencies()
+	mapping := cargo.MapDependencies(deps, lookup, unsafe)
+	cov := &Coverage{
+		Path:   path,
+		Module: headMod.Module,
+		Direct: len(deps),
+		Mapped: len(mapping.Mapped),
+	}
+	for _, u := range mapping.Unmapped {
+		if !before[u.GoDep.Path] {
+			cov.NewUnmapped = append(cov.NewUnmapped, u.GoDep.Path)
+		}
+	}
+	sort.Strings(cov.NewUnmapped)
+	return cov, nil
+}
+
+// Percent returns the share of mapped direct dependencies, 100 when there are none.
+func (c *Coverage) Percent() float64 {
+	if c.Direct == 0 {
+		return 100
+	}
+	return float64(c.Mapped) * 100 / float64(c.Direct)
+}
+
+// Markdown renders coverage results as a review comment.
+func Markdown(results []*Coverage) string {
+	var sb strings.Builder
+	sb.WriteString("### rinku: Rust mapping coverage\n\n")
+	sb.WriteString("| go.mod | Direct dependencies | Mapped | Coverage |\n")
+	sb.WriteString("|---|---:|---:|---:|\n")
+	for _, c := range results {
+		fmt.Fprintf(&sb, "| `%s` | %d | %d | %.0f%% |\n", c.Path, c.Direct, c.Mapped, c.Percent())
+	}
+
+	var introduced []string
+	for _, c := range results {
+		for _, m := range c.NewUnmapped {
+			introduced = append(introduced, fmt.Sprintf("- `%s` (%s)", m, c.Path))
+		}
+	}
+	sb.WriteString("\n")
+	if len(introduced) == 0 {
+		sb.WriteString("No newly introduced unmapped dependencies.\n")
+	} else {
+		sb.WriteString("**Newly introduced dependencies without a Rust mapping:**\n\n")
+		sb.WriteString(strings.Join(introduced, "\n"))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
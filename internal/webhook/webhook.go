@@ -0,0 +1,232 @@
+// Package webhook handles GitHub push and pull request events, scanning changed go.mod
+// files and commenting with their Rust mapping coverage.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/github"
+)
+
+// maxPayloadSize bounds webhook request bodies.
+const maxPayloadSize = 5 << 20
+
+// GitHub is the subset of the GitHub API the handler uses.
+type GitHub interface {
+	FileAt(ctx context.Context, repo github.Repo, ref, path string) ([]byte, error)
+	PullRequestFiles(ctx context.Context, repo github.Repo, number int) ([]string, error)
+	CreateIssueComment(ctx context.Context, repo github.Repo, number int, body string) error
+	CreateCommitComment(ctx context.Context, repo github.Repo, sha, body string) error
+}
+
+// Handler serves GitHub webhook deliveries.
+type Handler struct {
+	Secret []byte // webhook secret used to verify X-Hub-Signature-256
+	GitHub GitHub
+	Lookup cargo.Lookup
+	Unsafe bool
+	Logger *log.Logger
+}
+
+type repository struct {
+	Name  string `json:"name"`
+	Owner struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+func (r repository) repo() github.Repo {
+	return github.Repo{Owner: r.Owner.Login, Name: r.Name}
+}
+
+type pullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Base struct {
+			SHA string `json:"sha"`
+		} `json:"base"`
+		Head struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	} `json:"pull_request"`
+	Repository repository `json:"repository"`
+}
+
+type pushEvent struct {
+	Before  string `json:"before"`
+	After   string `json:"after"`
+	Deleted bool   `json:"deleted"`
+	Commits []struct {
+		Added    []string `json:"added"`
+		Modified []string `json:"modified"`
+	} `json:"commits"`
+	Repository repository `json:"repository"`
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, "reading body", http.StatusBadRequest)
+		return
+	}
+	if !h.validSignature(body, r.Header.Get("X-Hub-Signature-256")) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	event := r.Header.Get("X-GitHub-Event")
+	msg, err := h.handle(r.Context(), event, body)
+	if err != nil {
+		h.logf("%s event failed: %v", event, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h.logf("%s: %s", event, msg)
+	fmt.Fprintln(w, msg)
+}
+
+func (h *Handler) validSignature(body []byte, header string) bool {
+	sig, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, h.Secret)
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+func (h *Handler) logf(format string, args ...any) {
+	if h.Logger != nil {
+		h.Logger.Printf(format, args...)
+	}
+}
+
+func (h *Handler) handle(ctx context.Context, event string, body []byte) (string, error) {
+	switch event {
+	case "ping":
+		return "pong", nil
+	case "pull_request":
+		var e pullRequestEvent
+		if err := json.Unmarshal(body, &e); err != nil {
+			return "", fmt.Errorf("parsing pull_request event: %w", err)
+		}
+		return h.handlePullRequest(ctx, &e)
+	case "push":
+		var e pushEvent
+		if err := json.Unmarshal(body, &e); err != nil {
+			return "", fmt.Errorf("parsing push event: %w", err)
+		}
+		return h.handlePush(ctx, &e)
+	default:
+		return "ignored event " + event, nil
+	}
+}
+
+func (h *Handler) handlePullRequest(ctx context.Context, e *pullRequestEvent) (string, error) {
+	switch e.Action {
+	case "opened", "synchronize", "reopened":
+	default:
+		return "ignored action " + e.Action, nil
+	}
+
+	repo := e.Repository.repo()
+	files, err := h.GitHub.PullRequestFiles(ctx, repo, e.Number)
+	if err != nil {
+		return "", err
+	}
+	results, err := h.coverage(ctx, repo, e.PullRequest.Base.SHA, e.PullRequest.Head.SHA, goModFiles(files))
+	if err != nil || len(results) == 0 {
+		return "no go.mod changes", err
+	}
+	if err := h.GitHub.CreateIssueComment(ctx, repo, e.Number, Markdown(results)); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("commented on %s#%d", repo, e.Number), nil
+}
+
+func (h *Handler) handlePush(ctx context.Context, e *pushEvent) (string, error) {
+	if e.Deleted {
+		return "ignored branch deletion", nil
+	}
+
+	var files []string
+	for _, c := range e.Commits {
+		files = append(files, c.Added...)
+		files = append(files, c.Modified...)
+	}
+
+	repo := e.Repository.repo()
+	results, err := h.coverage(ctx, repo, e.Before, e.After, goModFiles(files))
+	if err != nil || len(results) == 0 {
+		return "no go.mod changes", err
+	}
+	if err := h.GitHub.CreateCommitComment(ctx, repo, e.After, Markdown(results)); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("commented on %s@%s", repo, e.After), nil
+}
+
+// coverage compares each go.mod between the base and head commits.
+func (h *Handler) coverage(ctx context.Context, repo github.Repo, baseRef, headRef string, paths []string) ([]*Coverage, error) {
+	var results []*Coverage
+	for _, p := range paths {
+		head, err := h.GitHub.FileAt(ctx, repo, headRef, p)
+		if errors.Is(err, github.ErrNotFound) {
+			continue // removed again later in the push
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var base []byte
+		if baseRef != "" && strings.Trim(baseRef, "0") != "" {
+			base, err = h.GitHub.FileAt(ctx, repo, baseRef, p)
+			if err != nil && !errors.Is(err, github.ErrNotFound) {
+				return nil, err
+			}
+		}
+
+		cov, err := Compare(p, base, head, h.Lookup, h.Unsafe)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, cov)
+	}
+	return results, nil
+}
+
+// goModFiles returns the unique go.mod paths among files, sorted.
+func goModFiles(files []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, f := range files {
+		if path.Base(f) == "go.mod" && !seen[f] {
+			seen[f] = true
+			result = append(result, f)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
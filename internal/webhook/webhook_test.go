@@ -0,0 +1,147 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stephan/rinku/internal/github"
+	"github.com/stephan/rinku/internal/types"
+)
+
+type fakeLookup map[string][]string
+
+func (f fakeLookup) Lookup(sourceURL, targetLang string, unsafe bool) []string {
+	return f[sourceURL]
+}
+
+func (f fakeLookup) CrateName(rustURL string) string { return "" }
+
+func (f fakeLookup) RequiredDeps(sourceURL, targetLang string) []types.RequiredDep { return nil }
+
+type fakeGitHub struct {
+	files    map[string]string // ref:path -> content
+	prFiles  []string
+	comments []string
+}
+
+func (f *fakeGitHub) FileAt(ctx context.Context, repo github.Repo, ref, path string) ([]byte, error) {
+	data, ok := f.files[ref+":"+path]
+	if !ok {
+		return nil, github.ErrNotFound
+	}
+	return []byte(data), nil
+}
+
+func (f *fakeGitHub) PullRequestFiles(ctx context.Context, repo github.Repo, number int) ([]string, error) {
+	return f.prFiles, nil
+}
+
+func (f *fakeGitHub) CreateIssueComment(ctx context.Context, repo github.Repo, number int, body string) error {
+	f.comments = append(f.comments, body)
+	return nil
+}
+
+func (f *fakeGitHub) CreateCommitComment(ctx context.Context, repo github.Repo, sha, body string) error {
+	f.comments = append(f.comments, body)
+	return nil
+}
+
+const (
+	baseGoMod = "module acme/api\n\nrequire (\n\tgithub.com/spf13/cobra v1.8.0\n\texample.com/old v1.0.0\n)\n"
+	headGoMod = "module acme/api\n\nrequire (\n\tgithub.com/spf13/cobra v1.8.0\n\texample.com/old v1.0.0\n\texample.com/new v0.1.0\n)\n"
+)
+
+var testLookup = fakeLookup{"https://github.com/spf13/cobra": {"https://github.com/clap-rs/clap"}}
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliver(h *Handler, event, body, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-Hub-Signature-256", signature)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCompare(t *testing.T) {
+	cov, err := Compare("go.mod", []byte(baseGoMod), []byte(headGoMod), testLookup, false)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if cov.Direct != 3 || cov.Mapped != 1 {
+		t.Errorf("Direct = %d, Mapped = %d, want 3 and 1", cov.Direct, cov.Mapped)
+	}
+	if len(cov.NewUnmapped) != 1 || cov.NewUnmapped[0] != "example.com/new" {
+		t.Errorf("NewUnmapped = %v, want [example.com/new]", cov.NewUnmapped)
+	}
+}
+
+func TestCompare_NewGoMod(t *testing.T) {
+	cov, err := Compare("go.mod", nil, []byte(headGoMod), testLookup, false)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if len(cov.NewUnmapped) != 2 {
+		t.Errorf("NewUnmapped = %v, want both unmapped dependencies", cov.NewUnmapped)
+	}
+}
+
+func TestServeHTTP_RejectsBadSignature(t *testing.T) {
+	h := &Handler{Secret: []byte("s3cret"), GitHub: &fakeGitHub{}, Lookup: testLookup}
+	rec := deliver(h, "ping", `{}`, sign("wrong", `{}`))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", rec.Code)
+	}
+}
+
+func TestServeHTTP_PullRequest(t *testing.T) {
+	gh := &fakeGitHub{
+		files:   map[string]string{"base1:go.mod": baseGoMod, "head1:go.mod": headGoMod},
+		prFiles: []string{"main.go", "go.mod"},
+	}
+	h := &Handler{Secret: []byte("s3cret"), GitHub: gh, Lookup: testLookup}
+
+	body := `{"action": "opened", "number": 7,
+		"pull_request": {"base": {"sha": "base1"}, "head": {"sha": "head1"}},
+		"repository": {"name": "api", "owner": {"login": "acme"}}}`
+	rec := deliver(h, "pull_request", body, sign("s3cret", body))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	if len(gh.comments) != 1 {
+		t.Fatalf("got %d comments, want 1", len(gh.comments))
+	}
+	comment := gh.comments[0]
+	if !strings.Contains(comment, "| `go.mod` | 3 | 1 | 33% |") {
+		t.Errorf("comment missing coverage row:\n%s", comment)
+	}
+	if !strings.Contains(comment, "- `example.com/new` (go.mod)") || strings.Contains(comment, "example.com/old") {
+		t.Errorf("comment should list only the new unmapped dependency:\n%s", comment)
+	}
+}
+
+func TestServeHTTP_PushWithoutGoModChanges(t *testing.T) {
+	gh := &fakeGitHub{}
+	h := &Handler{Secret: []byte("s3cret"), GitHub: gh, Lookup: testLookup}
+
+	body := `{"before": "a", "after": "b", "commits": [{"modified": ["README.md"]}],
+		"repository": {"name": "api", "owner": {"login": "acme"}}}`
+	rec := deliver(h, "push", body, sign("s3cret", body))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	if len(gh.comments) != 0 {
+		t.Errorf("got %d comments, want none", len(gh.comments))
+	}
+}
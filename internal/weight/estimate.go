@@ -0,0 +1,97 @@
+package weight
+
+import (
+	"context"
+	"sort"
+
+	"github.com/stephan/rinku/internal/gomod"
+)
+
+// Target is a direct Go dependency and the Rust crate it maps to; Crate is empty for an
+// unmapped dependency.
+type Target struct {
+	Module  string
+	Version string
+	Crate   string
+	Req     string // version requirement of Crate
+}
+
+// Estimate is the dependency count of a project before and after the migration.
+type Estimate struct {
+	GoDirect   int
+	GoIndirect int
+	// Eliminated are the indirect modules no unmapped direct dependency requires: the
+	// mapped crates cover what they were pulled in for.
+	Eliminated []string
+	// Retained are the indirect modules an unmapped direct dependency requires. They
+	// stay part of the porting work until it has a replacement.
+	Retained []string
+	Crates   []string // the mapped crates and their dependency trees, sorted
+	Unmapped int      // direct dependencies without a crate
+	Failed   []string // dependencies whose go.mod or crate tree could not be fetched
+}
+
+// Before returns the number of Go modules in the build.
+func (e *Estimate) Before() int {
+	return e.GoDirect + e.GoIndirect
+}
+
+// After returns the number of crates after the migration, counting every unmapped
+// dependency as one crate still to be found.
+func (e *Estimate) After() int {
+	return len(e.Crates) + e.Unmapped
+}
+
+// Estimate attributes the indirect modules of a build, the modules of its go.sum that
+// are not direct dependencies, to the direct dependencies whose go.mod requires them,
+// and resolves the crate trees of the mapped ones. Unless a module is required by an
+// unmapped dependency, it disappears with the migration.
+func (r *Resolver) Estimate(ctx context.Context, module string, targets []Target, sum []gomod.Dependency) *Estimate {
+	e := &Estimate{GoDirect: len(targets)}
+	direct := map[string]bool{module: true}
+	for _, t := range targets {
+		direct[t.Module] = true
+	}
+
+	retained := make(map[string]bool)
+	crates := make(map[string]bool)
+	for _, t := range targets {
+		if t.Crate == "" {
+			e.Unmapped++
+			mod, err := r.Proxy.GoMod(ctx, t.Module, t.Version)
+			if err != nil {
+				e.Failed = append(e.Failed, t.Module)
+				continue
+			}
+			for _, dep := range mod.Dependencies {
+				retained[dep.Path] = true
+			}
+			continue
+		}
+		deps, err := r.RustDependencies(ctx, t.Crate, t.Req)
+		if err != nil {
+			e.Failed = append(e.Failed, t.Crate)
+		}
+		crates[t.Crate] = true
+		for _, name := range deps {
+			crates[name] = true
+		}
+	}
+
+	for _, dep := range sum {
+		if direct[dep.Path] {
+			continue
+		}
+		e.GoIndirect++
+		if retained[dep.Path] {
+			e.Retained = append(e.Retained, dep.Path)
+		} else {
+			e.Eliminated = append(e.Eliminated, dep.Path)
+		}
+	}
+	for name := range crates {
+		e.Crates = append(e.Crates, name)
+	}
+	sort.Strings(e.Crates)
+	return e
+}
@@ -0,0 +1,61 @@
+package weight
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stephan/rinku/internal/cratesio"
+	"github.com/stephan/rinku/internal/gomod"
+)
+
+// goMods is a proxy serving go.mod files with the given requirements.
+type goMods map[string][]string
+
+func (g goMods) GoMod(_ context.Context, module, _ string) (*gomod.ParseResult, error) {
+	result := &gomod.ParseResult{Module: module}
+	for _, path := range g[module] {
+		result.Dependencies = append(result.Dependencies, gomod.Dependency{Path: path, Indirect: true})
+	}
+	return result, nil
+}
+
+func TestEstimate(t *testing.T) {
+	index := fakeIndex{
+		"tracing":      {{Name: "tracing", Version: "0.1.40", Deps: []cratesio.IndexDep{dep("tracing-core", "^0.1")}}},
+		"tracing-core": {{Name: "tracing-core", Version: "0.1.32"}},
+		"clap":         {{Name: "clap", Version: "4.5.0"}},
+	}
+	proxy := goMods{"example.com/soap": {"golang.org/x/net", "example.com/xml"}}
+	targets := []Target{
+		{Module: "github.com/sirupsen/logrus", Version: "v1.9.3", Crate: "tracing", Req: "*"},
+		{Module: "github.com/rs/zerolog", Version: "v1.32.0", Crate: "tracing", Req: "*"},
+		{Module: "github.com/spf13/cobra", Version: "v1.8.0", Crate: "clap", Req: "*"},
+		{Module: "example.com/soap", Version: "v0.3.0"},
+	}
+	var sum []gomod.Dependency
+	for _, path := range []string{
+		"example.com/app", "example.com/soap", "example.com/xml", "github.com/mattn/go-colorable",
+		"github.com/rs/zerolog", "github.com/sirupsen/logrus", "github.com/spf13/cobra",
+		"github.com/spf13/pflag", "golang.org/x/net", "golang.org/x/sys",
+	} {
+		sum = append(sum, gomod.Dependency{Path: path, Version: "v1.0.0"})
+	}
+
+	e := NewResolver(index, proxy).Estimate(context.Background(), "example.com/app", targets, sum)
+	if e.GoDirect != 4 || e.GoIndirect != 5 || e.Before() != 9 {
+		t.Errorf("direct = %d, indirect = %d, before = %d", e.GoDirect, e.GoIndirect, e.Before())
+	}
+	if want := []string{"github.com/mattn/go-colorable", "github.com/spf13/pflag", "golang.org/x/sys"}; !reflect.DeepEqual(e.Eliminated, want) {
+		t.Errorf("Eliminated = %v, want %v", e.Eliminated, want)
+	}
+	if want := []string{"example.com/xml", "golang.org/x/net"}; !reflect.DeepEqual(e.Retained, want) {
+		t.Errorf("Retained = %v, want %v", e.Retained, want)
+	}
+	if want := []string{"clap", "tracing", "tracing-core"}; !reflect.DeepEqual(e.Crates, want) || e.After() != 4 {
+		t.Errorf("Crates = %v, After() = %d", e.Crates, e.After())
+	}
+	if len(e.Failed) != 0 {
+		t.Errorf("Failed = %v", e.Failed)
+	}
+}
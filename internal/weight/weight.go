@@ -0,0 +1,158 @@
+// Package weight compares the transitive dependency footprint of Go modules with that of
+// their mapped Rust crates.
+package weight
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stephan/rinku/internal/cargo"
+	"github.com/stephan/rinku/internal/cratesio"
+	"github.com/stephan/rinku/internal/gomod"
+)
+
+// A Rust target counts as heavy when it pulls at least HeavyRatio times the Go module's
+// dependencies and at least HeavyMargin more of them.
+const (
+	HeavyRatio  = 3
+	HeavyMargin = 10
+)
+
+// maxCrates bounds how many crates a single dependency tree walk may resolve.
+const maxCrates = 500
+
+// Index fetches crate versions from a registry index.
+type Index interface {
+	IndexEntries(ctx context.Context, name string) ([]cratesio.IndexEntry, error)
+}
+
+// Proxy fetches go.mod files of module versions.
+type Proxy interface {
+	GoMod(ctx context.Context, module, version string) (*gomod.ParseResult, error)
+}
+
+// Comparison is the dependency weight of one Go module and its primary Rust crate.
+type Comparison struct {
+	GoModule  string
+	GoVersion string
+	Crate     string
+	GoDeps    int // modules required by the Go module's go.mod
+	RustDeps  int // crates in the Rust crate's resolved dependency tree, excluding itself
+}
+
+// Heavy reports whether the Rust crate pulls dramatically more dependencies.
+func (c *Comparison) Heavy() bool {
+	return c.RustDeps >= HeavyRatio*c.GoDeps && c.RustDeps-c.GoDeps >= HeavyMargin
+}
+
+// Resolver computes dependency counts, caching index lookups across calls.
+type Resolver struct {
+	Index Index
+	Proxy Proxy
+
+	entries map[string][]cratesio.IndexEntry
+}
+
+// NewResolver returns a resolver using the given index and proxy.
+func NewResolver(index Index, proxy Proxy) *Resolver {
+	return &Resolver{Index: index, Proxy: proxy, entries: make(map[string][]cratesio.IndexEntry)}
+}
+
+// Compare measures a Go module at version against a Rust crate matching req.
+func (r *Resolver) Compare(ctx context.Context, module, version, crate, req string) (*Comparison, error) {
+	mod, err := r.Proxy.GoMod(ctx, module, version)
+	if err != nil {
+		return nil, err
+	}
+	deps, err := r.RustDependencies(ctx, crate, req)
+	if err != nil {
+		return nil, err
+	}
+	return &Comparison{
+		GoModule:  module,
+		GoVersion: version,
+		Crate:     crate,
+		GoDeps:    len(mod.Dependencies),
+		RustDeps:  len(deps),
+	}, nil
+}
+
+// RustDependencies resolves the normal, non-optional dependency tree of the newest crate
+// version matching req and returns the sorted names of the crates in it. Platform-specific
+// dependencies are left out. Go modules since 1.17 list their whole pruned module graph in
+// go.mod, so this is the closest Rust counterpart to counting go.mod requirements.
+func (r *Resolver) RustDependencies(ctx context.Context, crate, req string) ([]string, error) {
+	seen := map[string]bool{crate: true}
+	queue := []struct{ name, req string }{{crate, req}}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if len(seen) > maxCrates {
+			return nil, fmt.Errorf("dependency tree of %s exceeds %d crates", crate, maxCrates)
+		}
+
+		entry, err := r.resolve(ctx, next.name, next.req)
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range entry.Deps {
+			if dep.Optional || dep.Target != "" || (dep.Kind != "" && dep.Kind != "normal") {
+				continue
+			}
+			name := dep.Crate()
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			queue = append(queue, struct{ name, req string }{name, dep.Req})
+		}
+	}
+
+	delete(seen, crate)
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// resolve returns the newest non-yanked release matching req, preferring stable versions.
+func (r *Resolver) resolve(ctx context.Context, name, req string) (*cratesio.IndexEntry, error) {
+	entries, ok := r.entries[name]
+	if !ok {
+		var err error
+		entries, err = r.Index.IndexEntries(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		r.entries[name] = entries
+	}
+
+	var best *cratesio.IndexEntry
+	var bestVersion cargo.Version
+	bestStable := false
+	for i := range entries {
+		e := &entries[i]
+		if e.Yanked {
+			continue
+		}
+		if ok, err := cargo.MatchesRequirement(req, e.Version); err != nil || !ok {
+			continue
+		}
+		v, err := cargo.ParseVersion(e.Version)
+		if err != nil {
+			continue
+		}
+		stable := !strings.Contains(e.Version, "-")
+		if best == nil || (stable && !bestStable) || (stable == bestStable && v.Compare(bestVersion) >= 0) {
+			best, bestVersion, bestStable = e, v, stable
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no version of %s matches %s", name, req)
+	}
+	return best, nil
+}
@@ -0,0 +1,92 @@
+package weight
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stephan/rinku/internal/cratesio"
+	"github.com/stephan/rinku/internal/gomod"
+)
+
+type fakeIndex map[string][]cratesio.IndexEntry
+
+func (f fakeIndex) IndexEntries(_ context.Context, name string) ([]cratesio.IndexEntry, error) {
+	return f[name], nil
+}
+
+type fakeProxy map[string]int
+
+func (f fakeProxy) GoMod(_ context.Context, module, _ string) (*gomod.ParseResult, error) {
+	result := &gomod.ParseResult{Module: module}
+	for i := 0; i < f[module]; i++ {
+		result.Dependencies = append(result.Dependencies, gomod.Dependency{Path: "example.com/dep"})
+	}
+	return result, nil
+}
+
+func dep(name, req string) cratesio.IndexDep {
+	return cratesio.IndexDep{Name: name, Req: req, Kind: "normal"}
+}
+
+func TestRustDependencies(t *testing.T) {
+	index := fakeIndex{
+		"web": {
+			{Name: "web", Version: "1.0.0", Deps: []cratesio.IndexDep{dep("old", "^1")}},
+			{Name: "web", Version: "1.2.0", Deps: []cratesio.IndexDep{
+				dep("http", "^0.2"),
+				dep("tokio", "^1"),
+				{Name: "serde", Req: "^1", Optional: true},
+				{Name: "criterion", Req: "^0.5", Kind: "dev"},
+				{Name: "winapi", Req: "^0.3", Kind: "normal", Target: "cfg(windows)"},
+			}},
+			{Name: "web", Version: "1.3.0", Yanked: true},
+			{Name: "web", Version: "2.0.0-beta.1"},
+		},
+		"http":  {{Name: "http", Version: "0.2.9", Deps: []cratesio.IndexDep{dep("bytes", "^1")}}},
+		"tokio": {{Name: "tokio", Version: "1.40.0", Deps: []cratesio.IndexDep{{Name: "b", Req: "^1", Package: "bytes"}}}},
+		"bytes": {{Name: "bytes", Version: "1.7.0"}},
+	}
+
+	r := NewResolver(index, fakeProxy{})
+	got, err := r.RustDependencies(context.Background(), "web", "^1")
+	if err != nil {
+		t.Fatalf("RustDependencies failed: %v", err)
+	}
+	if want := []string{"bytes", "http", "tokio"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RustDependencies = %v, want %v", got, want)
+	}
+}
+
+func TestRustDependencies_NoMatch(t *testing.T) {
+	r := NewResolver(fakeIndex{"x": {{Name: "x", Version: "0.1.0"}}}, fakeProxy{})
+	if _, err := r.RustDependencies(context.Background(), "x", "^1"); err == nil {
+		t.Error("expected error when no version matches")
+	}
+}
+
+func TestCompare_Heavy(t *testing.T) {
+	index := fakeIndex{"big": {{Name: "big", Version: "1.0.0"}}}
+	for i := 0; i < 12; i++ {
+		name := string(rune('a' + i))
+		index["big"][0].Deps = append(index["big"][0].Deps, dep(name, "*"))
+		index[name] = []cratesio.IndexEntry{{Name: name, Version: "1.0.0"}}
+	}
+
+	r := NewResolver(index, fakeProxy{"example.com/small": 2, "example.com/wide": 8})
+	small, err := r.Compare(context.Background(), "example.com/small", "v1.0.0", "big", "*")
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if small.GoDeps != 2 || small.RustDeps != 12 || !small.Heavy() {
+		t.Errorf("small = %+v, want heavy with 2 vs 12", small)
+	}
+
+	wide, err := r.Compare(context.Background(), "example.com/wide", "v1.0.0", "big", "*")
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if wide.Heavy() {
+		t.Errorf("wide = %+v should not be heavy (12 < 3×8)", wide)
+	}
+}
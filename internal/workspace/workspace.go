@@ -0,0 +1,409 @@
+// Package workspace proposes a Cargo workspace for a Go monorepo: one crate per Go
+// module and, if wanted, per internal package, named by a configurable policy.
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/stephan/rinku/internal/gomod"
+)
+
+// Kind is the kind of crate a Go module or package becomes.
+type Kind string
+
+const (
+	KindLib Kind = "lib"
+	KindBin Kind = "bin" // the module's root package is main
+)
+
+// Case styles of crate names.
+const (
+	CaseKebab = "kebab" // acme-billing-ledger, the crates.io convention
+	CaseSnake = "snake" // acme_billing_ledger
+)
+
+// Collision resolutions, for units whose last directory has the same name.
+const (
+	CollisionParent = "parent" // prepend parent directories until the names differ
+	CollisionSuffix = "suffix" // append -2, -3, … in directory order
+	CollisionError  = "error"  // refuse to choose
+)
+
+// Policy controls how crates are named, the [crates] table of .rinku.toml:
+//
+//	[crates]
+//	prefix = "acme-"
+//	case = "kebab"
+//	collisions = "parent"
+//	internal = true
+type Policy struct {
+	Prefix     string `toml:"prefix" json:"prefix,omitempty"`
+	Case       string `toml:"case" json:"case"`             // CaseKebab (default) or CaseSnake
+	Collisions string `toml:"collisions" json:"collisions"` // CollisionParent (default), CollisionSuffix or CollisionError
+	Internal   bool   `toml:"internal" json:"internal"`     // also split each module's internal/<pkg> into a crate
+}
+
+// WithDefaults returns the policy with empty settings set to their defaults.
+func (p Policy) WithDefaults() Policy {
+	if p.Case == "" {
+		p.Case = CaseKebab
+	}
+	if p.Collisions == "" {
+		p.Collisions = CollisionParent
+	}
+	return p
+}
+
+// Validate reports unknown case styles and collision resolutions.
+func (p Policy) Validate() error {
+	switch p.Case {
+	case "", CaseKebab, CaseSnake:
+	default:
+		return fmt.Errorf("unknown case %q (kebab or snake)", p.Case)
+	}
+	switch p.Collisions {
+	case "", CollisionParent, CollisionSuffix, CollisionError:
+	default:
+		return fmt.Errorf("unknown collisions %q (parent, suffix or error)", p.Collisions)
+	}
+	return nil
+}
+
+// Unit is a Go module or internal package that becomes a crate.
+type Unit struct {
+	GoPath string `json:"go_path"` // module path, or import path of an internal package
+	Dir    string `json:"dir"`     // slash-separated, relative to the repository root
+	Kind   Kind   `json:"kind"`
+	Owner  string `json:"owner,omitempty"` // Dir of the module of an internal package
+}
+
+// Crate is a planned workspace member.
+type Crate struct {
+	Name string   `json:"name"`
+	Path string   `json:"path"` // member directory, relative to the workspace root
+	Unit Unit     `json:"unit"`
+	Deps []string `json:"deps,omitempty"` // crates of the module's internal packages
+}
+
+// Layout is the proposed workspace.
+type Layout struct {
+	Policy Policy  `json:"policy"`
+	Crates []Crate `json:"crates"`
+}
+
+// Discover finds the Go modules below root, skipping what the go tool ignores, and
+// with internal set the packages directly below each module's internal/ directory
+// (with their subpackages). Units are sorted by directory.
+func Discover(root string, internal bool) ([]Unit, error) {
+	var modules []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && p != root && skipDir(d.Name()) {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && d.Name() == "go.mod" {
+			modules = append(modules, filepath.Dir(p))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("finding Go modules: %w", err)
+	}
+
+	isModule := make(map[string]bool, len(modules))
+	for _, dir := range modules {
+		isModule[dir] = true
+	}
+	var units []Unit
+	for _, dir := range modules {
+		result, err := gomod.Parse(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return nil, err
+		}
+		rel := relDir(root, dir)
+		kind := KindLib
+		if packageName(dir) == "main" {
+			kind = KindBin
+		}
+		units = append(units, Unit{GoPath: result.Module, Dir: rel, Kind: kind})
+		if !internal {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(dir, "internal"))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("reading internal packages: %w", err)
+		}
+		for _, e := range entries {
+			pkgDir := filepath.Join(dir, "internal", e.Name())
+			if !e.IsDir() || skipDir(e.Name()) || isModule[pkgDir] || !hasGoFiles(pkgDir) {
+				continue
+			}
+			units = append(units, Unit{
+				GoPath: result.Module + "/internal/" + e.Name(),
+				Dir:    relDir(root, pkgDir),
+				Kind:   KindLib,
+				Owner:  rel,
+			})
+		}
+	}
+	sort.Slice(units, func(i, j int) bool { return units[i].Dir < units[j].Dir })
+	return units, nil
+}
+
+// Build names a crate for every unit. Crates live in crates/<name>; a module depends on
+// the crates of its internal packages.
+func Build(units []Unit, policy Policy) (*Layout, error) {
+	policy = policy.WithDefaults()
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	segments := make([][]string, len(units))
+	for i, u := range units {
+		segments[i] = nameSegments(u)
+	}
+	names, collisions := resolveNames(segments, policy)
+	if len(collisions) > 0 {
+		var dirs []string
+		for _, i := range collisions[0] {
+			dirs = append(dirs, units[i].Dir)
+		}
+		return nil, fmt.Errorf("crate name %q is used by %s; set collisions to parent or suffix", names[collisions[0][0]], strings.Join(dirs, ", "))
+	}
+
+	l := &Layout{Policy: policy}
+	byDir := make(map[string]int, len(units))
+	for i, u := range units {
+		byDir[u.Dir] = i
+		l.Crates = append(l.Crates, Crate{Name: names[i], Path: "crates/" + names[i], Unit: u})
+	}
+	for i, u := range units {
+		if owner, ok := byDir[u.Owner]; ok && u.Owner != "" {
+			l.Crates[owner].Deps = append(l.Crates[owner].Deps, l.Crates[i].Name)
+		}
+	}
+	return l, nil
+}
+
+// CargoToml returns the workspace manifest listing every crate as a member.
+func (l *Layout) CargoToml() string {
+	var sb strings.Builder
+	sb.WriteString("# Generated by rinku - https://github.com/marvai-dev/rinku\n")
+	sb.WriteString("[workspace]\nresolver = \"2\"\nmembers = [\n")
+	for _, c := range l.Crates {
+		fmt.Fprintf(&sb, "    %q,\n", c.Path)
+	}
+	sb.WriteString("]\n")
+	return sb.String()
+}
+
+// nameSegments returns the words a crate name is built from, starting with the last:
+// the module path's last element for the repository root module, and the directories
+// without "internal" otherwise.
+func nameSegments(u Unit) []string {
+	if u.Dir == "." {
+		return []string{moduleBase(u.GoPath)}
+	}
+	var segs []string
+	for _, part := range strings.Split(u.Dir, "/") {
+		if part != "internal" {
+			segs = append(segs, part)
+		}
+	}
+	if len(segs) == 0 {
+		segs = []string{path.Base(u.Dir)}
+	}
+	return segs
+}
+
+// resolveNames formats a name per unit from its last segments, resolving duplicates as
+// the policy says. With CollisionError it returns the groups of units sharing a name.
+func resolveNames(segments [][]string, policy Policy) ([]string, [][]int) {
+	depth := make([]int, len(segments)) // trailing segments used per unit
+	for i := range depth {
+		depth[i] = 1
+	}
+	name := func(i int) string {
+		segs := segments[i]
+		return formatName(policy.Prefix+strings.Join(segs[len(segs)-depth[i]:], "-"), policy.Case)
+	}
+
+	for policy.Collisions == CollisionParent {
+		changed := false
+		for _, group := range duplicates(len(segments), name) {
+			for _, i := range group {
+				if depth[i] < len(segments[i]) {
+					depth[i]++
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	names := make([]string, len(segments))
+	for i := range segments {
+		names[i] = name(i)
+	}
+	groups := duplicates(len(segments), name)
+	if len(groups) > 0 && policy.Collisions == CollisionError {
+		return names, groups
+	}
+	// parent leaves duplicates only when a directory path is a suffix of another
+	sep := "-"
+	if policy.Case == CaseSnake {
+		sep = "_"
+	}
+	taken := make(map[string]bool, len(names))
+	for _, n := range names {
+		taken[n] = true
+	}
+	for _, group := range groups {
+		for k, i := range group[1:] {
+			for n := k + 2; ; n++ {
+				candidate := fmt.Sprintf("%s%s%d", names[group[0]], sep, n)
+				if !taken[candidate] {
+					names[i] = candidate
+					taken[candidate] = true
+					break
+				}
+			}
+		}
+	}
+	return names, nil
+}
+
+// duplicates returns the groups of indexes sharing a name, in index order.
+func duplicates(n int, name func(int) string) [][]int {
+	byName := make(map[string][]int)
+	var order []string
+	for i := 0; i < n; i++ {
+		k := name(i)
+		if byName[k] == nil {
+			order = append(order, k)
+		}
+		byName[k] = append(byName[k], i)
+	}
+	var groups [][]int
+	for _, k := range order {
+		if len(byName[k]) > 1 {
+			groups = append(groups, byName[k])
+		}
+	}
+	return groups
+}
+
+// formatName lowercases name and joins its words with the separator of the case
+// style. camelCase starts a new word; a leading digit gets a "crate" prefix because
+// crate names must start with a letter.
+func formatName(name, style string) string {
+	sep := '-'
+	if style == CaseSnake {
+		sep = '_'
+	}
+	var sb strings.Builder
+	prevLower, pendingSep := false, false
+	for _, r := range name {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			if prevLower {
+				pendingSep = true
+			}
+			r += 'a' - 'A'
+			prevLower = false
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			prevLower = true
+		default:
+			pendingSep, prevLower = true, false
+			continue
+		}
+		if pendingSep && sb.Len() > 0 {
+			sb.WriteRune(sep)
+		}
+		pendingSep = false
+		sb.WriteRune(r)
+	}
+	s := sb.String()
+	switch {
+	case s == "":
+		return "crate"
+	case s[0] >= '0' && s[0] <= '9':
+		return "crate" + string(sep) + s
+	}
+	return s
+}
+
+// moduleBase returns the last element of a module path without a major version suffix.
+func moduleBase(modulePath string) string {
+	base := path.Base(modulePath)
+	if len(base) > 1 && base[0] == 'v' && strings.Trim(base[1:], "0123456789") == "" {
+		base = path.Base(path.Dir(modulePath))
+	}
+	return base
+}
+
+// packageName returns the package clause of the first non-test Go file in dir, "" if
+// there is none.
+func packageName(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, parser.PackageClauseOnly)
+		if err == nil {
+			return f.Name.Name
+		}
+	}
+	return ""
+}
+
+// hasGoFiles reports whether dir or a directory below it holds Go files.
+func hasGoFiles(dir string) bool {
+	found := false
+	_ = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || found {
+			return filepath.SkipAll
+		}
+		if d.IsDir() && p != dir && skipDir(d.Name()) {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && strings.HasSuffix(d.Name(), ".go") {
+			found = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return found
+}
+
+// skipDir reports whether the go tool ignores a directory, like gosrc.Walk.
+func skipDir(name string) bool {
+	return name == "vendor" || name == "testdata" ||
+		strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+func relDir(root, dir string) string {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return filepath.ToSlash(dir)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,179 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func crateNames(l *Layout) []string {
+	var names []string
+	for _, c := range l.Crates {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func TestBuild_Collisions(t *testing.T) {
+	units := []Unit{
+		{GoPath: "example.com/mono", Dir: "."},
+		{GoPath: "example.com/mono/api", Dir: "api"},
+		{GoPath: "example.com/mono/api/internal/store", Dir: "api/internal/store", Owner: "api"},
+		{GoPath: "example.com/mono/billing", Dir: "services/billing"},
+		{GoPath: "example.com/mono/billing/internal/store", Dir: "services/billing/internal/store", Owner: "services/billing"},
+		{GoPath: "example.com/mono/legacy/store", Dir: "legacy/store"},
+	}
+	tests := []struct {
+		policy Policy
+		want   []string
+	}{
+		{Policy{}, []string{"mono", "api", "api-store", "billing", "billing-store", "legacy-store"}},
+		{Policy{Collisions: CollisionSuffix}, []string{"mono", "api", "store", "billing", "store-2", "store-3"}},
+		{Policy{Prefix: "acme_", Case: CaseSnake}, []string{"acme_mono", "acme_api", "acme_api_store", "acme_billing", "acme_billing_store", "acme_legacy_store"}},
+	}
+	for _, tt := range tests {
+		l, err := Build(units, tt.policy)
+		if err != nil {
+			t.Fatalf("Build(%+v) failed: %v", tt.policy, err)
+		}
+		if got := crateNames(l); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Build(%+v) = %v, want %v", tt.policy, got, tt.want)
+		}
+	}
+
+	_, err := Build(units, Policy{Collisions: CollisionError})
+	if err == nil || !strings.Contains(err.Error(), "api/internal/store, services/billing/internal/store, legacy/store") {
+		t.Errorf("CollisionError: err = %v", err)
+	}
+}
+
+func TestBuild_ParentExhausted(t *testing.T) {
+	// a/store is a suffix of x/a/store, so only a number tells them apart
+	units := []Unit{{Dir: "a/store"}, {Dir: "x/a/store"}}
+	l, err := Build(units, Policy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := crateNames(l), []string{"a-store", "x-a-store"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("names = %v, want %v", got, want)
+	}
+
+	units = []Unit{{Dir: "store"}, {Dir: "internal/store"}}
+	l, err = Build(units, Policy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := crateNames(l), []string{"store", "store-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("names = %v, want %v", got, want)
+	}
+}
+
+func TestBuild_Deps(t *testing.T) {
+	units := []Unit{
+		{GoPath: "example.com/api", Dir: "api"},
+		{GoPath: "example.com/api/internal/auth", Dir: "api/internal/auth", Owner: "api"},
+	}
+	l, err := Build(units, Policy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := l.Crates[0].Deps; !reflect.DeepEqual(got, []string{"auth"}) {
+		t.Errorf("Deps = %v", got)
+	}
+	if !strings.Contains(l.CargoToml(), "members = [\n    \"crates/api\",\n    \"crates/auth\",\n]") {
+		t.Errorf("CargoToml() =\n%s", l.CargoToml())
+	}
+}
+
+func TestBuild_InvalidPolicy(t *testing.T) {
+	if _, err := Build(nil, Policy{Case: "camel"}); err == nil {
+		t.Error("expected error for unknown case")
+	}
+	if _, err := Build(nil, Policy{Collisions: "merge"}); err == nil {
+		t.Error("expected error for unknown collisions")
+	}
+}
+
+func TestFormatName(t *testing.T) {
+	tests := []struct {
+		name, style, want string
+	}{
+		{"acme-userService", CaseKebab, "acme-user-service"},
+		{"acme-userService", CaseSnake, "acme_user_service"},
+		{"my_lib.v2", CaseKebab, "my-lib-v2"},
+		{"HTTPServer", CaseKebab, "httpserver"},
+		{"9p", CaseKebab, "crate-9p"},
+		{"--", CaseKebab, "crate"},
+	}
+	for _, tt := range tests {
+		if got := formatName(tt.name, tt.style); got != tt.want {
+			t.Errorf("formatName(%q, %s) = %q, want %q", tt.name, tt.style, got, tt.want)
+		}
+	}
+}
+
+func TestModuleBase(t *testing.T) {
+	for path, want := range map[string]string{
+		"github.com/acme/mono":    "mono",
+		"github.com/acme/mono/v3": "mono",
+		"example.com/vault":       "vault",
+	} {
+		if got := moduleBase(path); got != want {
+			t.Errorf("moduleBase(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	for path, content := range map[string]string{
+		"go.mod":                               "module example.com/mono\n",
+		"cmd/tool/go.mod":                      "module example.com/mono/cmd/tool\n",
+		"cmd/tool/main.go":                     "package main\n",
+		"cmd/tool/main_test.go":                "package main_test\n",
+		"internal/auth/auth.go":                "package auth\n",
+		"internal/auth/jwt/jwt.go":             "package jwt\n",
+		"internal/docs/README.md":              "no Go here\n",
+		"internal/plugin/go.mod":               "module example.com/mono/internal/plugin\n",
+		"internal/plugin/plugin.go":            "package plugin\n",
+		"vendor/example.com/dep/go.mod":        "module example.com/dep\n",
+		"testdata/fixture/go.mod":              "module fixture\n",
+		"_old/go.mod":                          "module old\n",
+		"internal/_draft/draft.go":             "package draft\n",
+		"internal/plugin/internal/x/x.go":      "package x\n",
+		"internal/testdata/fixture/fixture.go": "package fixture\n",
+	} {
+		p := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(p), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	units, err := Discover(root, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Unit{
+		{GoPath: "example.com/mono", Dir: ".", Kind: KindLib},
+		{GoPath: "example.com/mono/cmd/tool", Dir: "cmd/tool", Kind: KindBin},
+		{GoPath: "example.com/mono/internal/auth", Dir: "internal/auth", Kind: KindLib, Owner: "."},
+		{GoPath: "example.com/mono/internal/plugin", Dir: "internal/plugin", Kind: KindLib},
+		{GoPath: "example.com/mono/internal/plugin/internal/x", Dir: "internal/plugin/internal/x", Kind: KindLib, Owner: "internal/plugin"},
+	}
+	if !reflect.DeepEqual(units, want) {
+		t.Errorf("Discover() =\n%+v\nwant\n%+v", units, want)
+	}
+
+	units, err = Discover(root, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(units) != 3 {
+		t.Errorf("Discover(internal=false) = %+v, want the 3 modules", units)
+	}
+}
@@ -0,0 +1,295 @@
+package render
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// renderText writes doc.Text, or the title, fields and an aligned table.
+func renderText(w io.Writer, doc *Document) error {
+	if doc.Text != nil {
+		return doc.Text(w)
+	}
+	if doc.Title != "" {
+		fmt.Fprintf(w, "%s\n\n", doc.Title)
+	}
+	for _, f := range doc.Fields {
+		fmt.Fprintf(w, "%s: %s\n", f.Name, f.Value)
+	}
+	if len(doc.Rows) == 0 {
+		return nil
+	}
+	if len(doc.Fields) > 0 {
+		fmt.Fprintln(w)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(doc.Columns, "\t"))
+	for _, row := range doc.Rows {
+		fmt.Fprintln(tw, strings.TrimRight(strings.Join(row, "\t"), "\t")) // no padding after the last value
+	}
+	return tw.Flush()
+}
+
+// value returns the structured form of doc written by json and yaml, in the form of
+// APIVersion.
+func value(doc *Document) any {
+	return Versioned(APIVersion, doc.Command, dataOf(doc))
+}
+
+// dataOf returns doc.Data, or the fields and rows of doc. Field names become snake_case
+// keys.
+func dataOf(doc *Document) any {
+	if doc.Data != nil {
+		return doc.Data
+	}
+	fields := make(map[string]string, len(doc.Fields))
+	for _, f := range doc.Fields {
+		fields[key(f.Name)] = f.Value
+	}
+	rows := make([]map[string]string, 0, len(doc.Rows))
+	for _, row := range doc.Rows {
+		obj := make(map[string]string, len(doc.Columns))
+		for i, col := range doc.Columns {
+			if i < len(row) {
+				obj[col] = row[i]
+			}
+		}
+		rows = append(rows, obj)
+	}
+	v := map[string]any{"command": doc.Command, "fields": fields, "rows": rows}
+	if doc.Title != "" {
+		v["title"] = doc.Title
+	}
+	return v
+}
+
+// key returns a field name as a json and yaml key: Go version is go_version.
+func key(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), " ", "_")
+}
+
+func renderJSON(w io.Writer, doc *Document) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(value(doc))
+}
+
+// renderYAML writes the same structure as renderJSON. Data goes through JSON first, so
+// its json tags name the keys in both formats.
+func renderYAML(w io.Writer, doc *Document) error {
+	data, err := json.Marshal(value(doc))
+	if err != nil {
+		return err
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func renderCSV(w io.Writer, doc *Document) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(doc.Columns); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(doc.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// renderPorcelain writes one tab-separated line per row, without header or summary,
+// for scripts. Tabs and newlines inside values are replaced by spaces.
+func renderPorcelain(w io.Writer, doc *Document) error {
+	clean := strings.NewReplacer("\t", " ", "\n", " ")
+	for _, row := range doc.Rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = clean.Replace(cell)
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(cells, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderMarkdown(w io.Writer, doc *Document) error {
+	cell := strings.NewReplacer("|", `\|`, "\n", "<br>")
+	if doc.Title != "" {
+		fmt.Fprintf(w, "# %s\n\n", doc.Title)
+	}
+	for _, f := range doc.Fields {
+		fmt.Fprintf(w, "- **%s:** %s\n", f.Name, f.Value)
+	}
+	if len(doc.Fields) > 0 {
+		fmt.Fprintln(w)
+	}
+	if len(doc.Columns) > 0 {
+		fmt.Fprintf(w, "| %s |\n", strings.Join(doc.Columns, " | "))
+		fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", len(doc.Columns)))
+		for _, row := range doc.Rows {
+			cells := make([]string, len(row))
+			for i, c := range row {
+				cells[i] = cell.Replace(c)
+			}
+			fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+		}
+		fmt.Fprintln(w)
+	}
+	for _, f := range doc.Findings {
+		msg := f.Message
+		if f.Suppressed() {
+			msg += fmt.Sprintf(" (suppressed: %s)", f.Suppression)
+		}
+		if _, err := fmt.Fprintf(w, "> **%s:** %s\n", f.Level, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var htmlTmpl = template.Must(template.New("doc").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>rinku {{.Command}}{{if .Title}}: {{.Title}}{{end}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+dt { font-weight: bold; }
+.error { color: #b00; } .warning { color: #a60; }
+</style>
+</head>
+<body>
+{{if .Title}}<h1>{{.Title}}</h1>
+{{end}}{{if .Fields}}<dl>
+{{range .Fields}}<dt>{{.Name}}</dt><dd>{{.Value}}</dd>
+{{end}}</dl>
+{{end}}{{if .Columns}}<table>
+<tr>{{range .Columns}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}{{if .Findings}}<ul>
+{{range .Findings}}<li class="{{.Level}}">{{.Message}}{{if .Suppression}} (suppressed: {{.Suppression}}){{end}}</li>
+{{end}}</ul>
+{{end}}</body>
+</html>
+`))
+
+func renderHTML(w io.Writer, doc *Document) error {
+	return htmlTmpl.Execute(w, doc)
+}
+
+// SARIF 2.1.0, the subset code scanning services read.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations,omitempty"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+// sarifSuppression marks an accepted result; code scanning does not alert on it.
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+	} `json:"physicalLocation"`
+}
+
+// renderSARIF writes doc.Findings as a SARIF log; documents without findings produce
+// a run without results.
+func renderSARIF(w io.Writer, doc *Document) error {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: "rinku", InformationURI: "https://github.com/marvai-dev/rinku", Rules: []sarifRule{}}},
+		Results: []sarifResult{},
+	}
+	rules := make(map[string]bool)
+	for _, f := range doc.Findings {
+		rules[f.Rule] = true
+		level := f.Level
+		if level == "" {
+			level = LevelWarning
+		}
+		res := sarifResult{RuleID: f.Rule, Level: level, Message: sarifMessage{Text: f.Message}}
+		if f.File != "" {
+			var loc sarifLocation
+			loc.PhysicalLocation.ArtifactLocation.URI = filepath.ToSlash(f.File)
+			res.Locations = []sarifLocation{loc}
+		}
+		if f.Suppressed() {
+			res.Suppressions = []sarifSuppression{{Kind: "external", Justification: f.Suppression}}
+		}
+		run.Results = append(run.Results, res)
+	}
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: id})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	})
+}
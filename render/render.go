@@ -0,0 +1,141 @@
+// Package render writes command results in the output formats of the rinku CLI.
+//
+// A command describes its result once as a Document: summary fields, a table and,
+// for problems worth flagging in CI, findings. Every registered Renderer can write
+// any Document, so a format is implemented once for all commands. Programs that embed
+// rinku can add formats with Register:
+//
+//	render.Register("tsv", render.RendererFunc(func(w io.Writer, doc *render.Document) error {
+//		...
+//	}))
+package render
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Document is the result of a command in a form every renderer understands.
+type Document struct {
+	Command  string   // command that produced it, e.g. scan
+	Title    string   // heading, e.g. the module name
+	Fields   []Field  // summary values, in display order
+	Columns  []string // table header
+	Rows     [][]string
+	Findings []Finding // problems, e.g. unmapped dependencies; written by sarif
+
+	// Data is the value written by json and yaml. If nil, they write the fields and
+	// rows (as objects keyed by column).
+	Data any
+	// Text writes the command's own text output. If nil, text writes the fields and
+	// an aligned table.
+	Text func(w io.Writer) error
+}
+
+// Field is a named summary value.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Finding is a problem reported by a command.
+type Finding struct {
+	Rule    string // stable identifier, e.g. unmapped-dependency
+	Level   string // error, warning or note
+	Message string
+	File    string // path the finding is about, relative to the working directory
+	Subject string // what it is about, e.g. the dependency or requirement path
+
+	// Suppression is the reason the finding was accepted, e.g. in
+	// .rinku/suppressions.yaml. Suppressed findings are still written, so the
+	// accepted gaps stay visible: sarif marks them suppressed and markdown and html
+	// append the reason.
+	Suppression string
+}
+
+// Suppressed reports whether the finding was accepted.
+func (f Finding) Suppressed() bool {
+	return f.Suppression != ""
+}
+
+// Finding levels.
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+)
+
+// Renderer writes a Document in one format.
+type Renderer interface {
+	Render(w io.Writer, doc *Document) error
+}
+
+// RendererFunc adapts a function to the Renderer interface.
+type RendererFunc func(w io.Writer, doc *Document) error
+
+// Render calls f(w, doc).
+func (f RendererFunc) Render(w io.Writer, doc *Document) error {
+	return f(w, doc)
+}
+
+var (
+	mu        sync.RWMutex
+	renderers = make(map[string]Renderer)
+)
+
+// Register makes a renderer available under a format name. It panics if r is nil or a
+// renderer is already registered under name, like database/sql.Register.
+func Register(name string, r Renderer) {
+	mu.Lock()
+	defer mu.Unlock()
+	if r == nil {
+		panic("render: Register renderer is nil")
+	}
+	if _, dup := renderers[name]; dup {
+		panic("render: Register called twice for format " + name)
+	}
+	renderers[name] = r
+}
+
+// Lookup returns the renderer registered under name.
+func Lookup(name string) (Renderer, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	r, ok := renderers[name]
+	return r, ok
+}
+
+// Formats returns the registered format names, sorted.
+func Formats() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render writes doc in the named format.
+func Render(w io.Writer, format string, doc *Document) error {
+	r, ok := Lookup(format)
+	if !ok {
+		return fmt.Errorf("unknown output format %q (available: %s)", format, strings.Join(Formats(), ", "))
+	}
+	return r.Render(w, doc)
+}
+
+func init() {
+	Register("text", RendererFunc(renderText))
+	Register("json", RendererFunc(renderJSON))
+	Register("yaml", RendererFunc(renderYAML))
+	Register("csv", RendererFunc(renderCSV))
+	Register("markdown", RendererFunc(renderMarkdown))
+	Register("html", RendererFunc(renderHTML))
+	Register("sarif", RendererFunc(renderSARIF))
+	Register("porcelain", RendererFunc(renderPorcelain))
+}
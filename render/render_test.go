@@ -0,0 +1,257 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+func testDoc() *Document {
+	return &Document{
+		Command: "scan",
+		Title:   "example.com/app",
+		Fields:  []Field{{Name: "Go version", Value: "1.22"}, {Name: "Mapped", Value: "1"}},
+		Columns: []string{"dependency", "crate"},
+		Rows: [][]string{
+			{"github.com/gin-gonic/gin", "axum"},
+			{"github.com/acme/x|y", ""},
+		},
+		Findings: []Finding{{Rule: "unmapped-dependency", Level: LevelWarning, Message: "no Rust equivalent found for github.com/acme/x|y", File: "go.mod"}},
+	}
+}
+
+func render(t *testing.T, format string, doc *Document) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Render(&buf, format, doc); err != nil {
+		t.Fatalf("Render(%s): %v", format, err)
+	}
+	return buf.String()
+}
+
+func TestFormats(t *testing.T) {
+	want := []string{"csv", "html", "json", "markdown", "porcelain", "sarif", "text", "yaml"}
+	got := Formats()
+	for _, name := range want {
+		found := false
+		for _, g := range got {
+			found = found || g == name
+		}
+		if !found {
+			t.Errorf("Formats() = %v, missing %s", got, name)
+		}
+	}
+}
+
+func TestRender_Unknown(t *testing.T) {
+	err := Render(io.Discard, "xml", testDoc())
+	if err == nil || !strings.Contains(err.Error(), `unknown output format "xml"`) {
+		t.Errorf("err = %v, want unknown output format", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("test-upper", RendererFunc(func(w io.Writer, doc *Document) error {
+		_, err := io.WriteString(w, strings.ToUpper(doc.Title))
+		return err
+	}))
+	if got := render(t, "test-upper", testDoc()); got != "EXAMPLE.COM/APP" {
+		t.Errorf("got %q", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a format twice did not panic")
+		}
+	}()
+	Register("test-upper", RendererFunc(renderText))
+}
+
+func TestText(t *testing.T) {
+	got := render(t, "text", testDoc())
+	want := `example.com/app
+
+Go version: 1.22
+Mapped: 1
+
+dependency                crate
+github.com/gin-gonic/gin  axum
+github.com/acme/x|y
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	doc := testDoc()
+	doc.Text = func(w io.Writer) error {
+		_, err := io.WriteString(w, "custom\n")
+		return err
+	}
+	if got := render(t, "text", doc); got != "custom\n" {
+		t.Errorf("Text func not used: %q", got)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var got struct {
+		Command string              `json:"command"`
+		Fields  map[string]string   `json:"fields"`
+		Rows    []map[string]string `json:"rows"`
+	}
+	if err := json.Unmarshal([]byte(render(t, "json", testDoc())), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Command != "scan" || got.Fields["go_version"] != "1.22" {
+		t.Errorf("got %+v", got)
+	}
+	if len(got.Rows) != 2 || got.Rows[0]["crate"] != "axum" {
+		t.Errorf("rows = %v", got.Rows)
+	}
+
+	doc := testDoc()
+	doc.Data = struct {
+		Module string `json:"module"`
+	}{"example.com/app"}
+	if got := render(t, "json", doc); got != "{\n  \"module\": \"example.com/app\"\n}\n" {
+		t.Errorf("Data not used: %q", got)
+	}
+}
+
+func TestYAML(t *testing.T) {
+	doc := testDoc()
+	doc.Data = struct {
+		GoVersion string `json:"go_version"`
+	}{"1.22"}
+	if got := render(t, "yaml", doc); got != "go_version: \"1.22\"\n" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestCSV(t *testing.T) {
+	want := "dependency,crate\ngithub.com/gin-gonic/gin,axum\ngithub.com/acme/x|y,\n"
+	if got := render(t, "csv", testDoc()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPorcelain(t *testing.T) {
+	doc := testDoc()
+	doc.Rows = append(doc.Rows, []string{"a\tb", "c\nd"})
+	want := "github.com/gin-gonic/gin\taxum\ngithub.com/acme/x|y\t\na b\tc d\n"
+	if got := render(t, "porcelain", doc); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	got := render(t, "markdown", testDoc())
+	for _, want := range []string{
+		"# example.com/app\n",
+		"- **Go version:** 1.22\n",
+		"| dependency | crate |\n| --- | --- |\n",
+		`| github.com/acme/x\|y |  |`,
+		"> **warning:** no Rust equivalent",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestHTML(t *testing.T) {
+	doc := testDoc()
+	doc.Title = "<script>"
+	got := render(t, "html", doc)
+	if strings.Contains(got, "<script>") || !strings.Contains(got, "&lt;script&gt;") {
+		t.Errorf("title not escaped:\n%s", got)
+	}
+	if !strings.Contains(got, "<td>axum</td>") {
+		t.Errorf("missing row:\n%s", got)
+	}
+}
+
+func TestSARIF(t *testing.T) {
+	var log sarifLog
+	if err := json.Unmarshal([]byte(render(t, "sarif", testDoc())), &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("got %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 1 || run.Tool.Driver.Rules[0].ID != "unmapped-dependency" {
+		t.Errorf("rules = %+v", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 1 {
+		t.Fatalf("results = %+v", run.Results)
+	}
+	res := run.Results[0]
+	if res.Level != "warning" || res.Locations[0].PhysicalLocation.ArtifactLocation.URI != "go.mod" {
+		t.Errorf("result = %+v", res)
+	}
+}
+
+func TestSARIF_Suppressed(t *testing.T) {
+	doc := testDoc()
+	doc.Findings[0].Suppression = "replaced by a REST client"
+	var log sarifLog
+	if err := json.Unmarshal([]byte(render(t, "sarif", doc)), &log); err != nil {
+		t.Fatal(err)
+	}
+	res := log.Runs[0].Results[0]
+	if len(res.Suppressions) != 1 || res.Suppressions[0].Kind != "external" || res.Suppressions[0].Justification != "replaced by a REST client" {
+		t.Errorf("suppressions = %+v", res.Suppressions)
+	}
+	if got := render(t, "markdown", doc); !strings.Contains(got, "(suppressed: replaced by a REST client)") {
+		t.Errorf("markdown misses the reason:\n%s", got)
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	t.Cleanup(func() { APIVersion = APIVersion1 })
+	doc := testDoc()
+	doc.Data = map[string]int{"mapped": 1}
+
+	if got := render(t, "json", doc); got != "{\n  \"mapped\": 1\n}\n" {
+		t.Errorf("version 1 json = %s", got)
+	}
+	APIVersion = APIVersion2
+	var env struct {
+		APIVersion int            `json:"api_version"`
+		Command    string         `json:"command"`
+		Data       map[string]int `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(render(t, "json", doc)), &env); err != nil {
+		t.Fatal(err)
+	}
+	if env.APIVersion != 2 || env.Command != "scan" || env.Data["mapped"] != 1 {
+		t.Errorf("version 2 json = %+v", env)
+	}
+	if got := render(t, "yaml", doc); !strings.HasPrefix(got, "api_version: 2\ncommand: scan\ndata:\n  mapped: 1\n") {
+		t.Errorf("version 2 yaml = %s", got)
+	}
+}
+
+func TestParseAPIVersion(t *testing.T) {
+	for s, want := range map[string]int{"": APIVersion1, "1": APIVersion1, "2": APIVersion2, "v2": APIVersion2} {
+		if v, err := ParseAPIVersion(s); v != want || err != nil {
+			t.Errorf("ParseAPIVersion(%q) = %d, %v", s, v, err)
+		}
+	}
+	for _, s := range []string{"0", "3", "two"} {
+		if _, err := ParseAPIVersion(s); err == nil || !strings.Contains(err.Error(), "(supported: 1, 2)") {
+			t.Errorf("ParseAPIVersion(%q) = %v", s, err)
+		}
+	}
+
+	if Deprecation(APIVersion2) != "" {
+		t.Errorf("version 2 is deprecated")
+	}
+	apiVersions[APIVersion1] = "use version 2"
+	t.Cleanup(func() { apiVersions[APIVersion1] = "" })
+	if got := Deprecation(APIVersion1); got != "use version 2" {
+		t.Errorf("Deprecation(1) = %q", got)
+	}
+}
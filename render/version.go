@@ -0,0 +1,77 @@
+package render
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// API versions of the json and yaml output. Within a version, fields are only added,
+// never renamed or removed; a change that would break a consumer comes with a new
+// version, and the old one keeps its schema until it is retired after a deprecation.
+//
+// Version 1 writes the data of a command as is. Version 2 wraps it in an Envelope
+// that names the version and the command, so a consumer can check what it reads.
+const (
+	APIVersion1 = 1
+	APIVersion2 = 2
+
+	LatestAPIVersion = APIVersion2
+)
+
+// APIVersion is the version Render writes json and yaml in. Programs that embed rinku
+// set it once before rendering, like Register.
+var APIVersion = APIVersion1
+
+// apiVersions are the supported versions, each with its deprecation notice, empty
+// unless the version is going to be retired.
+var apiVersions = map[int]string{
+	APIVersion1: "",
+	APIVersion2: "",
+}
+
+// APIVersions returns the supported API versions, oldest first.
+func APIVersions() []int {
+	return slices.Sorted(maps.Keys(apiVersions))
+}
+
+// ParseAPIVersion returns the version of s, e.g. 2 or v2, or APIVersion1 if s is
+// empty. It fails for a version that is not supported.
+func ParseAPIVersion(s string) (int, error) {
+	if s == "" {
+		return APIVersion1, nil
+	}
+	v, err := strconv.Atoi(strings.TrimPrefix(s, "v"))
+	if _, ok := apiVersions[v]; err != nil || !ok {
+		supported := make([]string, 0, len(apiVersions))
+		for _, v := range APIVersions() {
+			supported = append(supported, strconv.Itoa(v))
+		}
+		return 0, fmt.Errorf("unsupported API version %q (supported: %s)", s, strings.Join(supported, ", "))
+	}
+	return v, nil
+}
+
+// Deprecation returns the notice to warn consumers of version v with, or "" if v is
+// not deprecated.
+func Deprecation(v int) string {
+	return apiVersions[v]
+}
+
+// Envelope is the json and yaml output of API version 2 and later.
+type Envelope struct {
+	APIVersion int    `json:"api_version"`
+	Command    string `json:"command"`
+	Data       any    `json:"data"`
+}
+
+// Versioned returns v, the data of command, in the form of API version version: as is
+// for version 1, in an Envelope from version 2.
+func Versioned(version int, command string, v any) any {
+	if version < APIVersion2 {
+		return v
+	}
+	return Envelope{APIVersion: version, Command: command, Data: v}
+}
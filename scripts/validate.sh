@@ -21,7 +21,7 @@ command -v jq >/dev/null 2>&1 || { echo "Error: jq is required"; exit 1; }
 command -v curl >/dev/null 2>&1 || { echo "Error: curl is required"; exit 1; }
 
 # Extract repo path from GitHub URL
-# Handles: https://github.com/owner/repo, https://github.com/owner/repo/tree/main/...
+# Handles: https://github.com/owner/repo
 parse_repo() {
     local url="$1"
     echo "$url" | sed 's|https://github.com/||' | cut -d'/' -f1-2
@@ -130,14 +130,18 @@ while IFS= read -r line; do
     repo=$(parse_repo "$url")
     crate=$(parse_crate_name "$url")
 
+    # Crates inside a monorepo point at crates.io; only their vulnerabilities are checked
+    if [[ "$url" == https://crates.io/crates/* ]]; then
+        repo="$url"
+        repo_result="pass|crates.io"
     # Skip org pages (no repo)
-    if [[ ! "$repo" =~ / ]] || [[ "$repo" =~ /$ ]]; then
+    elif [[ ! "$repo" =~ / ]] || [[ "$repo" =~ /$ ]]; then
         echo -e "${YELLOW}[SKIP]${NC} $key (org page, not a repo)"
         continue
+    else
+        # Check repo legitimacy
+        repo_result=$(check_repo "$repo")
     fi
-
-    # Check repo legitimacy
-    repo_result=$(check_repo "$repo")
     repo_status=$(echo "$repo_result" | cut -d'|' -f1)
     repo_details=$(echo "$repo_result" | cut -d'|' -f2-)
 